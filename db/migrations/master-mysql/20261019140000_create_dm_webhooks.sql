@@ -0,0 +1,43 @@
+-- Create "dm_webhook_subscriptions" table
+CREATE TABLE `dm_webhook_subscriptions` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` text NOT NULL,
+  `target_url` text NOT NULL,
+  `secret` text NOT NULL,
+  `events` text NOT NULL,
+  `active` bool NOT NULL DEFAULT 1,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NOT NULL,
+  PRIMARY KEY (`id`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "dm_webhook_deliveries" table
+CREATE TABLE `dm_webhook_deliveries` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `subscription_id` int NOT NULL,
+  `event` varchar(64) NOT NULL,
+  `event_id` varchar(36) NOT NULL,
+  `payload` longtext NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `attempts` int NOT NULL DEFAULT 0,
+  `response_status` int NULL,
+  `last_error` text NULL,
+  `replay_of` int NULL,
+  `delivered_at` timestamp NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_dm_webhook_deliveries_status` (`status`),
+  INDEX `idx_dm_webhook_deliveries_subscription_id` (`subscription_id`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+
+-- Webhook購読（データ管理の子メニュー）
+INSERT IGNORE INTO `goadmin_menu` (`id`, `parent_id`, `type`, `order`, `title`, `icon`, `uri`, `plugin_name`, `created_at`, `updated_at`)
+VALUES (17, 10, 1, 2, 'Webhook購読', 'fa-plug', '/info/dm-webhook-subscriptions', '', NOW(), NOW());
+
+-- Webhook配信ログ（データ管理の子メニュー）
+INSERT IGNORE INTO `goadmin_menu` (`id`, `parent_id`, `type`, `order`, `title`, `icon`, `uri`, `plugin_name`, `created_at`, `updated_at`)
+VALUES (18, 10, 1, 3, 'Webhook配信ログ', 'fa-paper-plane', '/info/dm-webhook-deliveries', '', NOW(), NOW());
+
+-- Webhook再送（カスタムページの子メニュー）
+INSERT IGNORE INTO `goadmin_menu` (`id`, `parent_id`, `type`, `order`, `title`, `icon`, `uri`, `plugin_name`, `created_at`, `updated_at`)
+VALUES (19, 14, 1, 3, 'Webhook再送', 'fa-repeat', '/webhook/replay', '', NOW(), NOW());
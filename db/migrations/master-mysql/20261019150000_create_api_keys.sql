@@ -0,0 +1,19 @@
+-- Create "api_keys" table
+CREATE TABLE `api_keys` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `jti` varchar(32) NOT NULL,
+  `name` text NOT NULL,
+  `owner` text NOT NULL,
+  `scopes` text NOT NULL,
+  `version` varchar(32) NOT NULL,
+  `env` varchar(32) NOT NULL,
+  `last_used_at` timestamp NULL,
+  `revoked_at` timestamp NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_api_keys_jti` (`jti`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+
+-- APIキー発行ページで発行済みキーの一覧・失効も行うため、メニュー名を変更
+UPDATE `goadmin_menu` SET `title` = 'APIキー管理' WHERE `id` = 16;
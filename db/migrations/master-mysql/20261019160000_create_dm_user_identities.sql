@@ -0,0 +1,13 @@
+-- Create "dm_user_identities" table
+CREATE TABLE `dm_user_identities` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `issuer` varchar(255) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `dm_user_id` varchar(32) NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_dm_user_identities_issuer_subject` (`issuer`, `subject`),
+  INDEX `idx_dm_user_identities_dm_user_id` (`dm_user_id`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,29 @@
+-- Create "rate_limit_plans" table
+CREATE TABLE `rate_limit_plans` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(64) NOT NULL,
+  `requests_per_minute` int NOT NULL DEFAULT 0,
+  `requests_per_hour` int NOT NULL DEFAULT 0,
+  `requests_per_day` int NOT NULL DEFAULT 0,
+  `requests_per_month` int NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_rate_limit_plans_name` (`name`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "rate_limit_plan_assignments" table
+CREATE TABLE `rate_limit_plan_assignments` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `principal_type` varchar(16) NOT NULL,
+  `principal_id` varchar(255) NOT NULL,
+  `plan_name` varchar(64) NOT NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_rate_limit_plan_assignments_principal` (`principal_type`, `principal_id`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+
+-- 初期データ: プラン（0は無制限）
+INSERT IGNORE INTO `rate_limit_plans` (`name`, `requests_per_minute`, `requests_per_hour`, `requests_per_day`, `requests_per_month`, `created_at`, `updated_at`) VALUES
+    ('default', 60, 1000, 10000, 200000, NOW(), NOW()),
+    ('unlimited', 0, 0, 0, 0, NOW(), NOW());
@@ -0,0 +1,48 @@
+-- Create "dm_webhook_subscriptions" table
+CREATE TABLE "dm_webhook_subscriptions" (
+  "id" serial NOT NULL,
+  "name" text NOT NULL,
+  "target_url" text NOT NULL,
+  "secret" text NOT NULL,
+  "events" text NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create "dm_webhook_deliveries" table
+CREATE TABLE "dm_webhook_deliveries" (
+  "id" serial NOT NULL,
+  "subscription_id" integer NOT NULL,
+  "event" text NOT NULL,
+  "event_id" text NOT NULL,
+  "payload" text NOT NULL,
+  "status" text NOT NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "response_status" integer NULL,
+  "last_error" text NULL,
+  "replay_of" integer NULL,
+  "delivered_at" timestamp NULL,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_dm_webhook_deliveries_subscription_id" to table: "dm_webhook_deliveries"
+CREATE INDEX "idx_dm_webhook_deliveries_subscription_id" ON "dm_webhook_deliveries" ("subscription_id");
+-- Create index "idx_dm_webhook_deliveries_status" to table: "dm_webhook_deliveries"
+CREATE INDEX "idx_dm_webhook_deliveries_status" ON "dm_webhook_deliveries" ("status");
+
+-- Webhook購読（データ管理の子メニュー）
+INSERT INTO goadmin_menu (id, parent_id, type, "order", title, icon, uri, plugin_name, created_at, updated_at)
+VALUES (17, 10, 1, 2, 'Webhook購読', 'fa-plug', '/info/dm-webhook-subscriptions', '', NOW(), NOW())
+ON CONFLICT DO NOTHING;
+
+-- Webhook配信ログ（データ管理の子メニュー）
+INSERT INTO goadmin_menu (id, parent_id, type, "order", title, icon, uri, plugin_name, created_at, updated_at)
+VALUES (18, 10, 1, 3, 'Webhook配信ログ', 'fa-paper-plane', '/info/dm-webhook-deliveries', '', NOW(), NOW())
+ON CONFLICT DO NOTHING;
+
+-- Webhook再送（カスタムページの子メニュー）
+INSERT INTO goadmin_menu (id, parent_id, type, "order", title, icon, uri, plugin_name, created_at, updated_at)
+VALUES (19, 14, 1, 3, 'Webhook再送', 'fa-repeat', '/webhook/replay', '', NOW(), NOW())
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,20 @@
+-- Create "api_keys" table
+CREATE TABLE "api_keys" (
+  "id" serial NOT NULL,
+  "jti" character varying(32) NOT NULL,
+  "name" text NOT NULL,
+  "owner" text NOT NULL,
+  "scopes" text NOT NULL,
+  "version" text NOT NULL,
+  "env" text NOT NULL,
+  "last_used_at" timestamp NULL,
+  "revoked_at" timestamp NULL,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_api_keys_jti" to table: "api_keys"
+CREATE UNIQUE INDEX "idx_api_keys_jti" ON "api_keys" ("jti");
+
+-- APIキー発行ページで発行済みキーの一覧・失効も行うため、メニュー名を変更
+UPDATE goadmin_menu SET title = 'APIキー管理' WHERE id = 16;
@@ -0,0 +1,15 @@
+-- Create "dm_user_identities" table
+CREATE TABLE "dm_user_identities" (
+  "id" serial NOT NULL,
+  "issuer" character varying(255) NOT NULL,
+  "subject" character varying(255) NOT NULL,
+  "dm_user_id" character varying(32) NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_dm_user_identities_issuer_subject" to table: "dm_user_identities"
+CREATE UNIQUE INDEX "idx_dm_user_identities_issuer_subject" ON "dm_user_identities" ("issuer", "subject");
+-- Create index "idx_dm_user_identities_dm_user_id" to table: "dm_user_identities"
+CREATE INDEX "idx_dm_user_identities_dm_user_id" ON "dm_user_identities" ("dm_user_id");
@@ -0,0 +1,32 @@
+-- Create "rate_limit_plans" table
+CREATE TABLE "rate_limit_plans" (
+  "id" serial NOT NULL,
+  "name" character varying(64) NOT NULL,
+  "requests_per_minute" integer NOT NULL DEFAULT 0,
+  "requests_per_hour" integer NOT NULL DEFAULT 0,
+  "requests_per_day" integer NOT NULL DEFAULT 0,
+  "requests_per_month" integer NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_rate_limit_plans_name" to table: "rate_limit_plans"
+CREATE UNIQUE INDEX "idx_rate_limit_plans_name" ON "rate_limit_plans" ("name");
+-- Create "rate_limit_plan_assignments" table
+CREATE TABLE "rate_limit_plan_assignments" (
+  "id" serial NOT NULL,
+  "principal_type" character varying(16) NOT NULL,
+  "principal_id" character varying(255) NOT NULL,
+  "plan_name" character varying(64) NOT NULL,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_rate_limit_plan_assignments_principal" to table: "rate_limit_plan_assignments"
+CREATE UNIQUE INDEX "idx_rate_limit_plan_assignments_principal" ON "rate_limit_plan_assignments" ("principal_type", "principal_id");
+
+-- 初期データ: プラン（0は無制限）
+INSERT INTO rate_limit_plans (name, requests_per_minute, requests_per_hour, requests_per_day, requests_per_month, created_at, updated_at) VALUES
+    ('default', 60, 1000, 10000, 200000, NOW(), NOW()),
+    ('unlimited', 0, 0, 0, 0, NOW(), NOW())
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Create index "idx_dm_posts_000_fulltext" to table: "dm_posts_000"
+CREATE FULLTEXT INDEX `idx_dm_posts_000_fulltext` ON `dm_posts_000` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_001_fulltext" to table: "dm_posts_001"
+CREATE FULLTEXT INDEX `idx_dm_posts_001_fulltext` ON `dm_posts_001` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_002_fulltext" to table: "dm_posts_002"
+CREATE FULLTEXT INDEX `idx_dm_posts_002_fulltext` ON `dm_posts_002` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_003_fulltext" to table: "dm_posts_003"
+CREATE FULLTEXT INDEX `idx_dm_posts_003_fulltext` ON `dm_posts_003` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_004_fulltext" to table: "dm_posts_004"
+CREATE FULLTEXT INDEX `idx_dm_posts_004_fulltext` ON `dm_posts_004` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_005_fulltext" to table: "dm_posts_005"
+CREATE FULLTEXT INDEX `idx_dm_posts_005_fulltext` ON `dm_posts_005` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_006_fulltext" to table: "dm_posts_006"
+CREATE FULLTEXT INDEX `idx_dm_posts_006_fulltext` ON `dm_posts_006` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_007_fulltext" to table: "dm_posts_007"
+CREATE FULLTEXT INDEX `idx_dm_posts_007_fulltext` ON `dm_posts_007` (`title`, `content`) WITH PARSER ngram;
@@ -0,0 +1,34 @@
+-- Enable "pg_trgm" extension
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+-- Create index "idx_dm_posts_000_fulltext" to table: "dm_posts_000"
+CREATE INDEX "idx_dm_posts_000_fulltext" ON "dm_posts_000" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_000_trgm" to table: "dm_posts_000"
+CREATE INDEX "idx_dm_posts_000_trgm" ON "dm_posts_000" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_001_fulltext" to table: "dm_posts_001"
+CREATE INDEX "idx_dm_posts_001_fulltext" ON "dm_posts_001" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_001_trgm" to table: "dm_posts_001"
+CREATE INDEX "idx_dm_posts_001_trgm" ON "dm_posts_001" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_002_fulltext" to table: "dm_posts_002"
+CREATE INDEX "idx_dm_posts_002_fulltext" ON "dm_posts_002" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_002_trgm" to table: "dm_posts_002"
+CREATE INDEX "idx_dm_posts_002_trgm" ON "dm_posts_002" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_003_fulltext" to table: "dm_posts_003"
+CREATE INDEX "idx_dm_posts_003_fulltext" ON "dm_posts_003" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_003_trgm" to table: "dm_posts_003"
+CREATE INDEX "idx_dm_posts_003_trgm" ON "dm_posts_003" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_004_fulltext" to table: "dm_posts_004"
+CREATE INDEX "idx_dm_posts_004_fulltext" ON "dm_posts_004" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_004_trgm" to table: "dm_posts_004"
+CREATE INDEX "idx_dm_posts_004_trgm" ON "dm_posts_004" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_005_fulltext" to table: "dm_posts_005"
+CREATE INDEX "idx_dm_posts_005_fulltext" ON "dm_posts_005" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_005_trgm" to table: "dm_posts_005"
+CREATE INDEX "idx_dm_posts_005_trgm" ON "dm_posts_005" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_006_fulltext" to table: "dm_posts_006"
+CREATE INDEX "idx_dm_posts_006_fulltext" ON "dm_posts_006" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_006_trgm" to table: "dm_posts_006"
+CREATE INDEX "idx_dm_posts_006_trgm" ON "dm_posts_006" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_007_fulltext" to table: "dm_posts_007"
+CREATE INDEX "idx_dm_posts_007_fulltext" ON "dm_posts_007" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_007_trgm" to table: "dm_posts_007"
+CREATE INDEX "idx_dm_posts_007_trgm" ON "dm_posts_007" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
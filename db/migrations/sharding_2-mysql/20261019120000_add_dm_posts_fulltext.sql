@@ -0,0 +1,16 @@
+-- Create index "idx_dm_posts_008_fulltext" to table: "dm_posts_008"
+CREATE FULLTEXT INDEX `idx_dm_posts_008_fulltext` ON `dm_posts_008` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_009_fulltext" to table: "dm_posts_009"
+CREATE FULLTEXT INDEX `idx_dm_posts_009_fulltext` ON `dm_posts_009` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_010_fulltext" to table: "dm_posts_010"
+CREATE FULLTEXT INDEX `idx_dm_posts_010_fulltext` ON `dm_posts_010` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_011_fulltext" to table: "dm_posts_011"
+CREATE FULLTEXT INDEX `idx_dm_posts_011_fulltext` ON `dm_posts_011` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_012_fulltext" to table: "dm_posts_012"
+CREATE FULLTEXT INDEX `idx_dm_posts_012_fulltext` ON `dm_posts_012` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_013_fulltext" to table: "dm_posts_013"
+CREATE FULLTEXT INDEX `idx_dm_posts_013_fulltext` ON `dm_posts_013` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_014_fulltext" to table: "dm_posts_014"
+CREATE FULLTEXT INDEX `idx_dm_posts_014_fulltext` ON `dm_posts_014` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_015_fulltext" to table: "dm_posts_015"
+CREATE FULLTEXT INDEX `idx_dm_posts_015_fulltext` ON `dm_posts_015` (`title`, `content`) WITH PARSER ngram;
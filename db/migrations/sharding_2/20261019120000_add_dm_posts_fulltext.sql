@@ -0,0 +1,34 @@
+-- Enable "pg_trgm" extension
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+-- Create index "idx_dm_posts_008_fulltext" to table: "dm_posts_008"
+CREATE INDEX "idx_dm_posts_008_fulltext" ON "dm_posts_008" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_008_trgm" to table: "dm_posts_008"
+CREATE INDEX "idx_dm_posts_008_trgm" ON "dm_posts_008" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_009_fulltext" to table: "dm_posts_009"
+CREATE INDEX "idx_dm_posts_009_fulltext" ON "dm_posts_009" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_009_trgm" to table: "dm_posts_009"
+CREATE INDEX "idx_dm_posts_009_trgm" ON "dm_posts_009" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_010_fulltext" to table: "dm_posts_010"
+CREATE INDEX "idx_dm_posts_010_fulltext" ON "dm_posts_010" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_010_trgm" to table: "dm_posts_010"
+CREATE INDEX "idx_dm_posts_010_trgm" ON "dm_posts_010" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_011_fulltext" to table: "dm_posts_011"
+CREATE INDEX "idx_dm_posts_011_fulltext" ON "dm_posts_011" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_011_trgm" to table: "dm_posts_011"
+CREATE INDEX "idx_dm_posts_011_trgm" ON "dm_posts_011" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_012_fulltext" to table: "dm_posts_012"
+CREATE INDEX "idx_dm_posts_012_fulltext" ON "dm_posts_012" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_012_trgm" to table: "dm_posts_012"
+CREATE INDEX "idx_dm_posts_012_trgm" ON "dm_posts_012" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_013_fulltext" to table: "dm_posts_013"
+CREATE INDEX "idx_dm_posts_013_fulltext" ON "dm_posts_013" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_013_trgm" to table: "dm_posts_013"
+CREATE INDEX "idx_dm_posts_013_trgm" ON "dm_posts_013" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_014_fulltext" to table: "dm_posts_014"
+CREATE INDEX "idx_dm_posts_014_fulltext" ON "dm_posts_014" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_014_trgm" to table: "dm_posts_014"
+CREATE INDEX "idx_dm_posts_014_trgm" ON "dm_posts_014" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_015_fulltext" to table: "dm_posts_015"
+CREATE INDEX "idx_dm_posts_015_fulltext" ON "dm_posts_015" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_015_trgm" to table: "dm_posts_015"
+CREATE INDEX "idx_dm_posts_015_trgm" ON "dm_posts_015" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
@@ -0,0 +1,16 @@
+-- Create index "idx_dm_posts_016_fulltext" to table: "dm_posts_016"
+CREATE FULLTEXT INDEX `idx_dm_posts_016_fulltext` ON `dm_posts_016` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_017_fulltext" to table: "dm_posts_017"
+CREATE FULLTEXT INDEX `idx_dm_posts_017_fulltext` ON `dm_posts_017` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_018_fulltext" to table: "dm_posts_018"
+CREATE FULLTEXT INDEX `idx_dm_posts_018_fulltext` ON `dm_posts_018` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_019_fulltext" to table: "dm_posts_019"
+CREATE FULLTEXT INDEX `idx_dm_posts_019_fulltext` ON `dm_posts_019` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_020_fulltext" to table: "dm_posts_020"
+CREATE FULLTEXT INDEX `idx_dm_posts_020_fulltext` ON `dm_posts_020` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_021_fulltext" to table: "dm_posts_021"
+CREATE FULLTEXT INDEX `idx_dm_posts_021_fulltext` ON `dm_posts_021` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_022_fulltext" to table: "dm_posts_022"
+CREATE FULLTEXT INDEX `idx_dm_posts_022_fulltext` ON `dm_posts_022` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_023_fulltext" to table: "dm_posts_023"
+CREATE FULLTEXT INDEX `idx_dm_posts_023_fulltext` ON `dm_posts_023` (`title`, `content`) WITH PARSER ngram;
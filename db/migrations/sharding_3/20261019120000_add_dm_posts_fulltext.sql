@@ -0,0 +1,34 @@
+-- Enable "pg_trgm" extension
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+-- Create index "idx_dm_posts_016_fulltext" to table: "dm_posts_016"
+CREATE INDEX "idx_dm_posts_016_fulltext" ON "dm_posts_016" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_016_trgm" to table: "dm_posts_016"
+CREATE INDEX "idx_dm_posts_016_trgm" ON "dm_posts_016" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_017_fulltext" to table: "dm_posts_017"
+CREATE INDEX "idx_dm_posts_017_fulltext" ON "dm_posts_017" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_017_trgm" to table: "dm_posts_017"
+CREATE INDEX "idx_dm_posts_017_trgm" ON "dm_posts_017" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_018_fulltext" to table: "dm_posts_018"
+CREATE INDEX "idx_dm_posts_018_fulltext" ON "dm_posts_018" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_018_trgm" to table: "dm_posts_018"
+CREATE INDEX "idx_dm_posts_018_trgm" ON "dm_posts_018" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_019_fulltext" to table: "dm_posts_019"
+CREATE INDEX "idx_dm_posts_019_fulltext" ON "dm_posts_019" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_019_trgm" to table: "dm_posts_019"
+CREATE INDEX "idx_dm_posts_019_trgm" ON "dm_posts_019" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_020_fulltext" to table: "dm_posts_020"
+CREATE INDEX "idx_dm_posts_020_fulltext" ON "dm_posts_020" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_020_trgm" to table: "dm_posts_020"
+CREATE INDEX "idx_dm_posts_020_trgm" ON "dm_posts_020" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_021_fulltext" to table: "dm_posts_021"
+CREATE INDEX "idx_dm_posts_021_fulltext" ON "dm_posts_021" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_021_trgm" to table: "dm_posts_021"
+CREATE INDEX "idx_dm_posts_021_trgm" ON "dm_posts_021" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_022_fulltext" to table: "dm_posts_022"
+CREATE INDEX "idx_dm_posts_022_fulltext" ON "dm_posts_022" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_022_trgm" to table: "dm_posts_022"
+CREATE INDEX "idx_dm_posts_022_trgm" ON "dm_posts_022" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_023_fulltext" to table: "dm_posts_023"
+CREATE INDEX "idx_dm_posts_023_fulltext" ON "dm_posts_023" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_023_trgm" to table: "dm_posts_023"
+CREATE INDEX "idx_dm_posts_023_trgm" ON "dm_posts_023" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
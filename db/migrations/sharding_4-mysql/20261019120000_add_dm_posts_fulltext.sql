@@ -0,0 +1,16 @@
+-- Create index "idx_dm_posts_024_fulltext" to table: "dm_posts_024"
+CREATE FULLTEXT INDEX `idx_dm_posts_024_fulltext` ON `dm_posts_024` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_025_fulltext" to table: "dm_posts_025"
+CREATE FULLTEXT INDEX `idx_dm_posts_025_fulltext` ON `dm_posts_025` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_026_fulltext" to table: "dm_posts_026"
+CREATE FULLTEXT INDEX `idx_dm_posts_026_fulltext` ON `dm_posts_026` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_027_fulltext" to table: "dm_posts_027"
+CREATE FULLTEXT INDEX `idx_dm_posts_027_fulltext` ON `dm_posts_027` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_028_fulltext" to table: "dm_posts_028"
+CREATE FULLTEXT INDEX `idx_dm_posts_028_fulltext` ON `dm_posts_028` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_029_fulltext" to table: "dm_posts_029"
+CREATE FULLTEXT INDEX `idx_dm_posts_029_fulltext` ON `dm_posts_029` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_030_fulltext" to table: "dm_posts_030"
+CREATE FULLTEXT INDEX `idx_dm_posts_030_fulltext` ON `dm_posts_030` (`title`, `content`) WITH PARSER ngram;
+-- Create index "idx_dm_posts_031_fulltext" to table: "dm_posts_031"
+CREATE FULLTEXT INDEX `idx_dm_posts_031_fulltext` ON `dm_posts_031` (`title`, `content`) WITH PARSER ngram;
@@ -0,0 +1,34 @@
+-- Enable "pg_trgm" extension
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+-- Create index "idx_dm_posts_024_fulltext" to table: "dm_posts_024"
+CREATE INDEX "idx_dm_posts_024_fulltext" ON "dm_posts_024" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_024_trgm" to table: "dm_posts_024"
+CREATE INDEX "idx_dm_posts_024_trgm" ON "dm_posts_024" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_025_fulltext" to table: "dm_posts_025"
+CREATE INDEX "idx_dm_posts_025_fulltext" ON "dm_posts_025" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_025_trgm" to table: "dm_posts_025"
+CREATE INDEX "idx_dm_posts_025_trgm" ON "dm_posts_025" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_026_fulltext" to table: "dm_posts_026"
+CREATE INDEX "idx_dm_posts_026_fulltext" ON "dm_posts_026" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_026_trgm" to table: "dm_posts_026"
+CREATE INDEX "idx_dm_posts_026_trgm" ON "dm_posts_026" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_027_fulltext" to table: "dm_posts_027"
+CREATE INDEX "idx_dm_posts_027_fulltext" ON "dm_posts_027" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_027_trgm" to table: "dm_posts_027"
+CREATE INDEX "idx_dm_posts_027_trgm" ON "dm_posts_027" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_028_fulltext" to table: "dm_posts_028"
+CREATE INDEX "idx_dm_posts_028_fulltext" ON "dm_posts_028" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_028_trgm" to table: "dm_posts_028"
+CREATE INDEX "idx_dm_posts_028_trgm" ON "dm_posts_028" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_029_fulltext" to table: "dm_posts_029"
+CREATE INDEX "idx_dm_posts_029_fulltext" ON "dm_posts_029" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_029_trgm" to table: "dm_posts_029"
+CREATE INDEX "idx_dm_posts_029_trgm" ON "dm_posts_029" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_030_fulltext" to table: "dm_posts_030"
+CREATE INDEX "idx_dm_posts_030_fulltext" ON "dm_posts_030" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_030_trgm" to table: "dm_posts_030"
+CREATE INDEX "idx_dm_posts_030_trgm" ON "dm_posts_030" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
+-- Create index "idx_dm_posts_031_fulltext" to table: "dm_posts_031"
+CREATE INDEX "idx_dm_posts_031_fulltext" ON "dm_posts_031" USING GIN (to_tsvector('simple'::regconfig, ((("title" || ' '::text) || "content"))));
+-- Create index "idx_dm_posts_031_trgm" to table: "dm_posts_031"
+CREATE INDEX "idx_dm_posts_031_trgm" ON "dm_posts_031" USING GIN (((("title" || ' '::text) || "content")) gin_trgm_ops);
@@ -0,0 +1,2 @@
+-- Add updated_at to dm_news_view (used by news feeds)
+CREATE OR REPLACE VIEW `dm_news_view` AS SELECT `id`, `title`, `content`, `published_at`, `updated_at` FROM `dm_news`;
@@ -44,6 +44,303 @@ table "dm_news" {
   }
 }
 
+// dm_webhook_subscriptions テーブル（Webhookの購読設定）
+table "dm_webhook_subscriptions" {
+  schema = schema.webdb_master
+  column "id" {
+    null           = false
+    type           = int
+    auto_increment = true
+  }
+  column "name" {
+    null = false
+    type = text
+  }
+  column "target_url" {
+    null = false
+    type = text
+  }
+  column "secret" {
+    null = false
+    type = text
+  }
+  column "events" {
+    null = false
+    type = text
+  }
+  column "active" {
+    null    = false
+    type    = bool
+    default = 1
+  }
+  column "created_at" {
+    null = false
+    type = timestamp
+  }
+  column "updated_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.id]
+  }
+}
+
+// dm_webhook_deliveries テーブル（Webhookの配信ログ）
+table "dm_webhook_deliveries" {
+  schema = schema.webdb_master
+  column "id" {
+    null           = false
+    type           = int
+    auto_increment = true
+  }
+  column "subscription_id" {
+    null = false
+    type = int
+  }
+  column "event" {
+    null = false
+    type = varchar(64)
+  }
+  column "event_id" {
+    null = false
+    type = varchar(36)
+  }
+  column "payload" {
+    null = false
+    type = longtext
+  }
+  column "status" {
+    null = false
+    type = varchar(16)
+  }
+  column "attempts" {
+    null    = false
+    type    = int
+    default = 0
+  }
+  column "response_status" {
+    null = true
+    type = int
+  }
+  column "last_error" {
+    null = true
+    type = text
+  }
+  column "replay_of" {
+    null = true
+    type = int
+  }
+  column "delivered_at" {
+    null = true
+    type = timestamp
+  }
+  column "created_at" {
+    null = false
+    type = timestamp
+  }
+  column "updated_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_dm_webhook_deliveries_subscription_id" {
+    columns = [column.subscription_id]
+  }
+  index "idx_dm_webhook_deliveries_status" {
+    columns = [column.status]
+  }
+}
+
+// api_keys テーブル（発行済みPublic APIキーの台帳）
+table "api_keys" {
+  schema = schema.webdb_master
+  column "id" {
+    null           = false
+    type           = int
+    auto_increment = true
+  }
+  column "jti" {
+    null = false
+    type = varchar(32)
+  }
+  column "name" {
+    null = false
+    type = text
+  }
+  column "owner" {
+    null = false
+    type = text
+  }
+  column "scopes" {
+    null = false
+    type = text
+  }
+  column "version" {
+    null = false
+    type = varchar(32)
+  }
+  column "env" {
+    null = false
+    type = varchar(32)
+  }
+  column "last_used_at" {
+    null = true
+    type = timestamp
+  }
+  column "revoked_at" {
+    null = true
+    type = timestamp
+  }
+  column "created_at" {
+    null = false
+    type = timestamp
+  }
+  column "updated_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_api_keys_jti" {
+    unique  = true
+    columns = [column.jti]
+  }
+}
+
+// dm_user_identities テーブル（OIDCのIssuerで認証したユーザーとdm_usersの紐づけ）
+table "dm_user_identities" {
+  schema = schema.webdb_master
+  column "id" {
+    null           = false
+    type           = int
+    auto_increment = true
+  }
+  column "issuer" {
+    null = false
+    type = varchar(255)
+  }
+  column "subject" {
+    null = false
+    type = varchar(255)
+  }
+  column "dm_user_id" {
+    null = false
+    type = varchar(32)
+  }
+  column "email" {
+    null = false
+    type = varchar(255)
+  }
+  column "created_at" {
+    null = false
+    type = timestamp
+  }
+  column "updated_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_dm_user_identities_issuer_subject" {
+    unique  = true
+    columns = [column.issuer, column.subject]
+  }
+  index "idx_dm_user_identities_dm_user_id" {
+    columns = [column.dm_user_id]
+  }
+}
+
+// rate_limit_plans テーブル（呼び出し元ごとのレートリミット・クォータのプラン。0は無制限）
+table "rate_limit_plans" {
+  schema = schema.webdb_master
+  column "id" {
+    null           = false
+    type           = int
+    auto_increment = true
+  }
+  column "name" {
+    null = false
+    type = varchar(64)
+  }
+  column "requests_per_minute" {
+    null    = false
+    type    = int
+    default = 0
+  }
+  column "requests_per_hour" {
+    null    = false
+    type    = int
+    default = 0
+  }
+  column "requests_per_day" {
+    null    = false
+    type    = int
+    default = 0
+  }
+  column "requests_per_month" {
+    null    = false
+    type    = int
+    default = 0
+  }
+  column "created_at" {
+    null = false
+    type = timestamp
+  }
+  column "updated_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_rate_limit_plans_name" {
+    unique  = true
+    columns = [column.name]
+  }
+}
+
+// rate_limit_plan_assignments テーブル（APIキー・ユーザーごとのプランの割り当て）
+table "rate_limit_plan_assignments" {
+  schema = schema.webdb_master
+  column "id" {
+    null           = false
+    type           = int
+    auto_increment = true
+  }
+  column "principal_type" {
+    null = false
+    type = varchar(16)
+  }
+  column "principal_id" {
+    null = false
+    type = varchar(255)
+  }
+  column "plan_name" {
+    null = false
+    type = varchar(64)
+  }
+  column "created_at" {
+    null = false
+    type = timestamp
+  }
+  column "updated_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_rate_limit_plan_assignments_principal" {
+    unique  = true
+    columns = [column.principal_type, column.principal_id]
+  }
+}
+
 // GoAdmin メニューテーブル
 table "goadmin_menu" {
   schema = schema.webdb_master
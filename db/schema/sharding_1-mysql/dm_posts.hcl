@@ -35,6 +35,11 @@ table "dm_posts_000" {
   index "idx_dm_posts_000_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_000_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_001" {
@@ -72,6 +77,11 @@ table "dm_posts_001" {
   index "idx_dm_posts_001_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_001_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_002" {
@@ -109,6 +119,11 @@ table "dm_posts_002" {
   index "idx_dm_posts_002_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_002_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_003" {
@@ -146,6 +161,11 @@ table "dm_posts_003" {
   index "idx_dm_posts_003_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_003_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_004" {
@@ -183,6 +203,11 @@ table "dm_posts_004" {
   index "idx_dm_posts_004_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_004_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_005" {
@@ -220,6 +245,11 @@ table "dm_posts_005" {
   index "idx_dm_posts_005_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_005_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_006" {
@@ -257,6 +287,11 @@ table "dm_posts_006" {
   index "idx_dm_posts_006_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_006_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_007" {
@@ -294,4 +329,9 @@ table "dm_posts_007" {
   index "idx_dm_posts_007_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_007_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
@@ -35,6 +35,19 @@ table "dm_posts_000" {
   index "idx_dm_posts_000_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_000_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_000_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_001" {
@@ -72,6 +85,19 @@ table "dm_posts_001" {
   index "idx_dm_posts_001_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_001_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_001_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_002" {
@@ -109,6 +135,19 @@ table "dm_posts_002" {
   index "idx_dm_posts_002_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_002_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_002_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_003" {
@@ -146,6 +185,19 @@ table "dm_posts_003" {
   index "idx_dm_posts_003_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_003_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_003_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_004" {
@@ -183,6 +235,19 @@ table "dm_posts_004" {
   index "idx_dm_posts_004_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_004_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_004_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_005" {
@@ -220,6 +285,19 @@ table "dm_posts_005" {
   index "idx_dm_posts_005_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_005_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_005_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_006" {
@@ -257,6 +335,19 @@ table "dm_posts_006" {
   index "idx_dm_posts_006_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_006_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_006_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_007" {
@@ -294,4 +385,17 @@ table "dm_posts_007" {
   index "idx_dm_posts_007_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_007_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_007_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
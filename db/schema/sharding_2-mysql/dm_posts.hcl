@@ -35,6 +35,11 @@ table "dm_posts_008" {
   index "idx_dm_posts_008_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_008_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_009" {
@@ -72,6 +77,11 @@ table "dm_posts_009" {
   index "idx_dm_posts_009_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_009_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_010" {
@@ -109,6 +119,11 @@ table "dm_posts_010" {
   index "idx_dm_posts_010_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_010_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_011" {
@@ -146,6 +161,11 @@ table "dm_posts_011" {
   index "idx_dm_posts_011_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_011_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_012" {
@@ -183,6 +203,11 @@ table "dm_posts_012" {
   index "idx_dm_posts_012_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_012_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_013" {
@@ -220,6 +245,11 @@ table "dm_posts_013" {
   index "idx_dm_posts_013_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_013_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_014" {
@@ -257,6 +287,11 @@ table "dm_posts_014" {
   index "idx_dm_posts_014_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_014_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_015" {
@@ -294,4 +329,9 @@ table "dm_posts_015" {
   index "idx_dm_posts_015_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_015_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
@@ -35,6 +35,19 @@ table "dm_posts_008" {
   index "idx_dm_posts_008_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_008_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_008_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_009" {
@@ -72,6 +85,19 @@ table "dm_posts_009" {
   index "idx_dm_posts_009_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_009_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_009_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_010" {
@@ -109,6 +135,19 @@ table "dm_posts_010" {
   index "idx_dm_posts_010_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_010_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_010_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_011" {
@@ -146,6 +185,19 @@ table "dm_posts_011" {
   index "idx_dm_posts_011_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_011_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_011_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_012" {
@@ -183,6 +235,19 @@ table "dm_posts_012" {
   index "idx_dm_posts_012_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_012_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_012_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_013" {
@@ -220,6 +285,19 @@ table "dm_posts_013" {
   index "idx_dm_posts_013_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_013_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_013_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_014" {
@@ -257,6 +335,19 @@ table "dm_posts_014" {
   index "idx_dm_posts_014_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_014_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_014_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_015" {
@@ -294,4 +385,17 @@ table "dm_posts_015" {
   index "idx_dm_posts_015_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_015_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_015_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
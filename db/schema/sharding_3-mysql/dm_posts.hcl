@@ -35,6 +35,11 @@ table "dm_posts_016" {
   index "idx_dm_posts_016_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_016_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_017" {
@@ -72,6 +77,11 @@ table "dm_posts_017" {
   index "idx_dm_posts_017_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_017_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_018" {
@@ -109,6 +119,11 @@ table "dm_posts_018" {
   index "idx_dm_posts_018_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_018_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_019" {
@@ -146,6 +161,11 @@ table "dm_posts_019" {
   index "idx_dm_posts_019_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_019_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_020" {
@@ -183,6 +203,11 @@ table "dm_posts_020" {
   index "idx_dm_posts_020_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_020_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_021" {
@@ -220,6 +245,11 @@ table "dm_posts_021" {
   index "idx_dm_posts_021_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_021_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_022" {
@@ -257,6 +287,11 @@ table "dm_posts_022" {
   index "idx_dm_posts_022_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_022_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_023" {
@@ -294,4 +329,9 @@ table "dm_posts_023" {
   index "idx_dm_posts_023_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_023_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
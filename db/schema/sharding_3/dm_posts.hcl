@@ -35,6 +35,19 @@ table "dm_posts_016" {
   index "idx_dm_posts_016_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_016_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_016_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_017" {
@@ -72,6 +85,19 @@ table "dm_posts_017" {
   index "idx_dm_posts_017_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_017_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_017_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_018" {
@@ -109,6 +135,19 @@ table "dm_posts_018" {
   index "idx_dm_posts_018_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_018_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_018_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_019" {
@@ -146,6 +185,19 @@ table "dm_posts_019" {
   index "idx_dm_posts_019_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_019_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_019_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_020" {
@@ -183,6 +235,19 @@ table "dm_posts_020" {
   index "idx_dm_posts_020_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_020_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_020_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_021" {
@@ -220,6 +285,19 @@ table "dm_posts_021" {
   index "idx_dm_posts_021_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_021_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_021_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_022" {
@@ -257,6 +335,19 @@ table "dm_posts_022" {
   index "idx_dm_posts_022_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_022_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_022_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_023" {
@@ -294,4 +385,17 @@ table "dm_posts_023" {
   index "idx_dm_posts_023_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_023_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_023_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
@@ -35,6 +35,11 @@ table "dm_posts_024" {
   index "idx_dm_posts_024_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_024_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_025" {
@@ -72,6 +77,11 @@ table "dm_posts_025" {
   index "idx_dm_posts_025_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_025_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_026" {
@@ -109,6 +119,11 @@ table "dm_posts_026" {
   index "idx_dm_posts_026_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_026_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_027" {
@@ -146,6 +161,11 @@ table "dm_posts_027" {
   index "idx_dm_posts_027_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_027_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_028" {
@@ -183,6 +203,11 @@ table "dm_posts_028" {
   index "idx_dm_posts_028_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_028_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_029" {
@@ -220,6 +245,11 @@ table "dm_posts_029" {
   index "idx_dm_posts_029_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_029_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_030" {
@@ -257,6 +287,11 @@ table "dm_posts_030" {
   index "idx_dm_posts_030_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_030_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
 
 table "dm_posts_031" {
@@ -294,4 +329,9 @@ table "dm_posts_031" {
   index "idx_dm_posts_031_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_031_fulltext" {
+    columns = [column.title, column.content]
+    type    = FULLTEXT
+    parser  = ngram
+  }
 }
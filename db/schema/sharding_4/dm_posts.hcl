@@ -35,6 +35,19 @@ table "dm_posts_024" {
   index "idx_dm_posts_024_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_024_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_024_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_025" {
@@ -72,6 +85,19 @@ table "dm_posts_025" {
   index "idx_dm_posts_025_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_025_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_025_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_026" {
@@ -109,6 +135,19 @@ table "dm_posts_026" {
   index "idx_dm_posts_026_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_026_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_026_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_027" {
@@ -146,6 +185,19 @@ table "dm_posts_027" {
   index "idx_dm_posts_027_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_027_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_027_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_028" {
@@ -183,6 +235,19 @@ table "dm_posts_028" {
   index "idx_dm_posts_028_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_028_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_028_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_029" {
@@ -220,6 +285,19 @@ table "dm_posts_029" {
   index "idx_dm_posts_029_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_029_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_029_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_030" {
@@ -257,6 +335,19 @@ table "dm_posts_030" {
   index "idx_dm_posts_030_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_030_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_030_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
 
 table "dm_posts_031" {
@@ -294,4 +385,17 @@ table "dm_posts_031" {
   index "idx_dm_posts_031_created_at" {
     columns = [column.created_at]
   }
+  index "idx_dm_posts_031_fulltext" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((title || ' '::text) || content)))"
+    }
+  }
+  index "idx_dm_posts_031_trgm" {
+    type = GIN
+    on {
+      expr = "(((title || ' '::text) || content))"
+      ops  = "gin_trgm_ops"
+    }
+  }
 }
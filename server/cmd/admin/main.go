@@ -26,12 +26,16 @@ import (
 	"github.com/taku-o/go-webdb-template/internal/admin"
 	adminAuth "github.com/taku-o/go-webdb-template/internal/admin/auth"
 	"github.com/taku-o/go-webdb-template/internal/admin/pages"
+	"github.com/taku-o/go-webdb-template/internal/apikey"
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/config"
 	appdb "github.com/taku-o/go-webdb-template/internal/db"
 	"github.com/taku-o/go-webdb-template/internal/logging"
 	"github.com/taku-o/go-webdb-template/internal/repository"
 	"github.com/taku-o/go-webdb-template/internal/service"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
 	adminUsecase "github.com/taku-o/go-webdb-template/internal/usecase/admin"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
 )
 
 func main() {
@@ -54,14 +58,37 @@ func main() {
 
 	// Repository層の初期化
 	dmUserRepository := repository.NewDmUserRepository(groupManager)
+	dmWebhookRepository := repository.NewDmWebhookRepository(groupManager)
+	apiKeyRepository := repository.NewAPIKeyRepository(groupManager)
 
 	// Service層の初期化
 	dmUserService := service.NewDmUserService(dmUserRepository)
-	apiKeyService := service.NewAPIKeyService()
+	// 発行するAPIキーの署名鍵の初期化
+	apiKeySet, err := auth.NewKeySet(&cfg.API)
+	if err != nil {
+		log.Fatalf("Failed to create api key set: %v", err)
+	}
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository, apiKeySet)
+	dmWebhookService := service.NewDmWebhookService(dmWebhookRepository, cfg.Webhook.Timeout)
+
+	// Asynqクライアントの初期化（Webhook配信ジョブの登録用）
+	// Redisが起動していない場合でも、管理画面の起動は継続する
+	var webhookDispatcher *usecaseapi.WebhookDispatcher
+	jobQueueClient, err := jobqueue.NewClient(cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to create job queue client: %v", err)
+		webhookDispatcher = usecaseapi.NewWebhookDispatcher(dmWebhookService, nil, &cfg.Webhook)
+	} else {
+		defer jobQueueClient.Close()
+		webhookDispatcher = usecaseapi.NewWebhookDispatcher(dmWebhookService, usecaseapi.NewJobQueueClientAdapter(jobQueueClient), &cfg.Webhook)
+	}
 
 	// Usecase層の初期化
-	dmUserRegisterUsecase := adminUsecase.NewDmUserRegisterUsecase(dmUserService)
-	apiKeyUsecase := adminUsecase.NewAPIKeyUsecase(apiKeyService, cfg)
+	dmUserRegisterUsecase := adminUsecase.NewDmUserRegisterUsecase(dmUserService, webhookDispatcher)
+	// 失効をAPIサーバーの失効確認のキャッシュに反映する（Redisの場合は即時に反映される）
+	apiKeyRegistry := apikey.NewRegistry(cfg, apiKeyService)
+	apiKeyUsecase := adminUsecase.NewAPIKeyUsecase(apiKeyService, apiKeyRegistry, cfg)
+	webhookReplayUsecase := adminUsecase.NewWebhookReplayUsecase(webhookDispatcher)
 
 	// Gorilla Mux Router
 	app := mux.NewRouter()
@@ -119,6 +146,9 @@ func main() {
 	app.HandleFunc("/admin/api-key", gorillaAdapter.Content(func(ctx gorillaAdapter.Context) (types.Panel, error) {
 		return pages.APIKeyPage(goadminContext.NewContext(ctx.Request), apiKeyUsecase)
 	})).Methods("GET", "POST")
+	app.HandleFunc("/admin/webhook/replay", gorillaAdapter.Content(func(ctx gorillaAdapter.Context) (types.Panel, error) {
+		return pages.WebhookReplayPage(goadminContext.NewContext(ctx.Request), webhookReplayUsecase)
+	})).Methods("GET", "POST")
 
 	// アクセスログの初期化（production環境以外）
 	var httpHandler http.Handler = app
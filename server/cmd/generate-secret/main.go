@@ -2,23 +2,68 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/taku-o/go-webdb-template/internal/service"
 	"github.com/taku-o/go-webdb-template/internal/usecase/cli"
 )
 
 func main() {
+	// コマンドライン引数の解析
+	rotate := flag.Bool("rotate", false, "Rotate the signing key of public API keys in the config file")
+	configPath := flag.String("config", "", "Config file to update with -rotate (default: ../config/{APP_ENV}/config.yaml)")
+	algorithm := flag.String("algorithm", "HS256", "Signing algorithm of the new key with -rotate (HS256, RS256, EdDSA)")
+	keyDir := flag.String("key-dir", "", "Directory to save the private key for RS256/EdDSA (default: keys/ next to the config file)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
 	// Service層の初期化
 	secretService := service.NewSecretService()
 
 	// Usecase層の初期化
 	generateSecretUsecase := cli.NewGenerateSecretUsecase(secretService)
 
-	// 秘密鍵の生成
 	ctx := context.Background()
+
+	// 署名鍵のローテーション
+	if *rotate {
+		if *configPath == "" {
+			env := os.Getenv("APP_ENV")
+			if env == "" {
+				env = "develop"
+			}
+			*configPath = fmt.Sprintf("../config/%s/config.yaml", env)
+		}
+		if *keyDir == "" {
+			*keyDir = filepath.Join(filepath.Dir(*configPath), "keys")
+		}
+
+		rotation, err := generateSecretUsecase.RotateSigningKey(ctx, *configPath, *algorithm, *keyDir)
+		if err != nil {
+			log.Fatalf("Failed to rotate signing key: %v", err)
+		}
+
+		fmt.Printf("Signing key: %s\n", rotation.KeyID)
+		if rotation.RetiredKeyID != "" {
+			fmt.Printf("Retired key: %s (verified until the grace period ends)\n", rotation.RetiredKeyID)
+		}
+		for _, keyID := range rotation.RemovedKeyIDs {
+			fmt.Printf("Removed key: %s\n", keyID)
+		}
+		fmt.Printf("Updated %s. Restart the API server and the admin server to apply.\n", *configPath)
+
+		os.Exit(0)
+	}
+
+	// 秘密鍵の生成
 	secretKey, err := generateSecretUsecase.GenerateSecret(ctx)
 	if err != nil {
 		log.Fatalf("Failed to generate secret key: %v", err)
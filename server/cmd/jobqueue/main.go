@@ -11,7 +11,14 @@ import (
 	"time"
 
 	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
 	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+	"github.com/taku-o/go-webdb-template/internal/storage"
+	"github.com/taku-o/go-webdb-template/internal/stream"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+	usecasejobqueue "github.com/taku-o/go-webdb-template/internal/usecase/jobqueue"
 )
 
 func main() {
@@ -34,7 +41,78 @@ func main() {
 		log.Fatalf("Failed to create job queue server: %v", err)
 	}
 
-	// 3. HTTPサーバーの初期化
+	// 3. DB接続が必要なジョブハンドラーの登録
+	// GroupManagerは遅延接続のため、DBが起動していない場合でもサーバーの起動は継続する
+	groupManager, err := db.NewGroupManager(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create group manager: %v", err)
+	}
+	defer groupManager.CloseAll()
+
+	// Asynqクライアントの初期化（Webhookの配信ログ作成ジョブ・配信ジョブの登録用）
+	// Redisが起動していない場合でも、JobQueueサーバーの起動は継続する
+	var jobQueueClient *jobqueue.Client
+	jobQueueClient, err = jobqueue.NewClient(cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to create job queue client: %v", err)
+		jobQueueClient = nil
+	} else {
+		defer jobQueueClient.Close()
+	}
+
+	// Webhook配信ログ・購読設定はmasterグループから読み込む
+	// WebhookDispatcherの初期化（jobQueueClientがnilの場合は配信ログを配信失敗として記録）
+	dmWebhookRepo := repository.NewDmWebhookRepository(groupManager)
+	dmWebhookService := service.NewDmWebhookService(dmWebhookRepo, cfg.Webhook.Timeout)
+	var webhookDispatcher *usecaseapi.WebhookDispatcher
+	if jobQueueClient != nil {
+		webhookDispatcher = usecaseapi.NewWebhookDispatcher(dmWebhookService, usecaseapi.NewJobQueueClientAdapter(jobQueueClient), &cfg.Webhook)
+	} else {
+		webhookDispatcher = usecaseapi.NewWebhookDispatcher(dmWebhookService, nil, &cfg.Webhook)
+	}
+
+	dmUserRepo := repository.NewDmUserRepository(groupManager)
+	dmPostRepo := repository.NewDmPostRepository(groupManager)
+	tableSelector := db.NewTableSelector(db.DBShardingTableCount, db.DBShardingTablesPerDB)
+	dmBulkImportService := service.NewDmBulkImportService(dmUserRepo, dmPostRepo, tableSelector)
+	bulkImportUsecase := usecasejobqueue.NewBulkImportUsecase(dmBulkImportService, webhookDispatcher)
+	bulkImportProcessor := jobqueue.NewBulkImportProcessor(bulkImportUsecase)
+	jobQueueServer.HandleFunc(jobqueue.JobTypeBulkImport, bulkImportProcessor.ProcessTask)
+
+	// エクスポートファイルはAPIサーバーと同じストレージに保存する
+	exportStore, err := storage.NewExportStore(&cfg.Upload)
+	if err != nil {
+		log.Fatalf("Failed to create export store: %v", err)
+	}
+	dmExportService := service.NewDmExportService(dmUserRepo, dmPostRepo, exportStore, cfg.Export.KeyPrefix)
+	exportUsecase := usecasejobqueue.NewExportUsecase(dmExportService)
+	exportProcessor := jobqueue.NewExportProcessor(exportUsecase)
+	jobQueueServer.HandleFunc(jobqueue.JobTypeExport, exportProcessor.ProcessTask)
+
+	webhookDispatchUsecase := usecasejobqueue.NewWebhookDispatchUsecase(webhookDispatcher)
+	webhookDispatchProcessor := jobqueue.NewWebhookDispatchProcessor(webhookDispatchUsecase)
+	jobQueueServer.HandleFunc(jobqueue.JobTypeWebhookDispatch, webhookDispatchProcessor.ProcessTask)
+	webhookDeliveryUsecase := usecasejobqueue.NewWebhookDeliveryUsecase(dmWebhookService)
+	webhookDeliveryProcessor := jobqueue.NewWebhookDeliveryProcessor(webhookDeliveryUsecase)
+	jobQueueServer.HandleFunc(jobqueue.JobTypeWebhookDelivery, webhookDeliveryProcessor.ProcessTask)
+
+	// 予約公開ニュースのSSEイベントはRedis pub/sub経由でAPIサーバーに配信する
+	// Redis（cache_server.redis.default）が設定されていない場合、APIサーバーには届かない
+	streamBroker, err := stream.NewBroker(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create stream broker: %v", err)
+	}
+	defer streamBroker.Close()
+	if _, ok := streamBroker.(*stream.MemoryBroker); ok {
+		log.Println("WARNING: Redis for stream is not configured, scheduled news events will not reach API servers")
+	}
+	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
+	dmNewsService := service.NewDmNewsService(dmNewsRepo)
+	streamNewsPublishedUsecase := usecasejobqueue.NewStreamNewsPublishedUsecase(dmNewsService, streamBroker)
+	streamNewsPublishedProcessor := jobqueue.NewStreamNewsPublishedProcessor(streamNewsPublishedUsecase)
+	jobQueueServer.HandleFunc(jobqueue.JobTypeStreamNewsPublished, streamNewsPublishedProcessor.ProcessTask)
+
+	// 4. HTTPサーバーの初期化
 	mux := http.NewServeMux()
 
 	// Health check endpoint (認証不要)
@@ -51,7 +129,7 @@ func main() {
 		WriteTimeout: cfg.JobQueue.WriteTimeout,
 	}
 
-	// 4. Asynqサーバーの起動（バックグラウンド）
+	// 5. Asynqサーバーの起動（バックグラウンド）
 	go func() {
 		log.Println("Starting job queue processing...")
 		if err := jobQueueServer.Start(); err != nil {
@@ -60,7 +138,7 @@ func main() {
 		}
 	}()
 
-	// 5. HTTPサーバーの起動（バックグラウンド）
+	// 6. HTTPサーバーの起動（バックグラウンド）
 	go func() {
 		log.Printf("Starting HTTP server on port %d", cfg.JobQueue.Port)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -70,7 +148,7 @@ func main() {
 
 	log.Println("JobQueue server started successfully")
 
-	// 6. Graceful shutdown
+	// 7. Graceful shutdown
 	// シグナル待機（SIGINT、SIGTERMを受信した場合、Graceful shutdownを実行）
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -78,12 +156,12 @@ func main() {
 
 	log.Println("Shutting down JobQueue server...")
 
-	// 7. Asynqサーバーの停止
+	// 8. Asynqサーバーの停止
 	if err := jobQueueServer.Shutdown(); err != nil {
 		log.Printf("JobQueue server shutdown error: %v", err)
 	}
 
-	// 8. HTTPサーバーの停止（30秒のタイムアウト）
+	// 9. HTTPサーバーの停止（30秒のタイムアウト）
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
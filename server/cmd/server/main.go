@@ -4,21 +4,30 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/taku-o/go-webdb-template/internal/api/graphqlapi"
+	"github.com/taku-o/go-webdb-template/internal/api/grpcapi"
 	"github.com/taku-o/go-webdb-template/internal/api/handler"
 	"github.com/taku-o/go-webdb-template/internal/api/router"
+	"github.com/taku-o/go-webdb-template/internal/apikey"
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/config"
 	"github.com/taku-o/go-webdb-template/internal/db"
 	"github.com/taku-o/go-webdb-template/internal/logging"
+	"github.com/taku-o/go-webdb-template/internal/ratelimit"
 	"github.com/taku-o/go-webdb-template/internal/repository"
 	"github.com/taku-o/go-webdb-template/internal/service"
 	"github.com/taku-o/go-webdb-template/internal/service/email"
 	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+	"github.com/taku-o/go-webdb-template/internal/storage"
+	"github.com/taku-o/go-webdb-template/internal/stream"
 	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -42,21 +51,110 @@ func main() {
 	// Repository層の初期化（GORM版を使用）
 	dmUserRepo := repository.NewDmUserRepository(groupManager)
 	dmPostRepo := repository.NewDmPostRepository(groupManager)
+	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
+	dmWebhookRepo := repository.NewDmWebhookRepository(groupManager)
+	apiKeyRepo := repository.NewAPIKeyRepository(groupManager)
+	dmUserIdentityRepo := repository.NewDmUserIdentityRepository(groupManager)
+	rateLimitPlanRepo := repository.NewRateLimitPlanRepository(groupManager)
+
+	// TableSelectorの初期化
+	tableSelector := db.NewTableSelector(db.DBShardingTableCount, db.DBShardingTablesPerDB)
 
 	// Service層の初期化
 	dmUserService := service.NewDmUserService(dmUserRepo)
 	dmPostService := service.NewDmPostService(dmPostRepo, dmUserRepo)
+	dmNewsService := service.NewDmNewsService(dmNewsRepo)
+	dmNewsFeedService := service.NewDmNewsFeedService(dmNewsRepo)
 	dateService := service.NewDateService()
+	dmBulkImportService := service.NewDmBulkImportService(dmUserRepo, dmPostRepo, tableSelector)
+	dmWebhookService := service.NewDmWebhookService(dmWebhookRepo, cfg.Webhook.Timeout)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, nil)
+	dmUserIdentityService := service.NewDmUserIdentityService(dmUserIdentityRepo)
+	rateLimitPlanService := service.NewRateLimitPlanService(rateLimitPlanRepo, cfg.API.RateLimit.Principal.DefaultPlan)
+
+	// APIキーの台帳の初期化（失効確認の結果はRedisまたはメモリにキャッシュする）
+	apiKeyRegistry := apikey.NewRegistry(cfg, apiKeyService)
+	defer apiKeyRegistry.Wait()
+
+	// 呼び出し元（APIキー・ユーザー）ごとのレートリミット・クォータの初期化（設定で有効な場合のみ）
+	// ストレージの初期化に失敗した場合はログに記録し、呼び出し元ごとの制限なしで起動を継続する（fail-open方式）
+	var principalLimiter *ratelimit.PrincipalLimiter
+	if cfg.API.RateLimit.Enabled && cfg.API.RateLimit.Principal.Enabled {
+		principalLimiter, err = ratelimit.NewPrincipalLimiter(cfg, rateLimitPlanService)
+		if err != nil {
+			log.Printf("WARNING: Failed to create principal rate limiter: %v", err)
+			principalLimiter = nil
+		}
+	}
+
+	// Asynqクライアントの初期化（ジョブ登録用）
+	// Redisが起動していない場合でも、APIサーバーの起動は継続する
+	// 注意: ジョブの消化処理は別プロセスのJobQueueサーバーで実行される
+	var jobQueueClient *jobqueue.Client
+	jobQueueClient, err = jobqueue.NewClient(cfg)
+	if err != nil {
+		// Redis接続エラーを標準エラー出力に記録（起動処理は継続）
+		log.Printf("WARNING: Failed to create job queue client: %v", err)
+		log.Printf("WARNING: Job queue functionality will be unavailable until Redis is started")
+		jobQueueClient = nil
+	} else {
+		defer jobQueueClient.Close()
+	}
+
+	// WebhookDispatcherの初期化（jobQueueClientがnilの場合は配信ログを配信失敗として記録）
+	var webhookDispatcher *usecaseapi.WebhookDispatcher
+	if jobQueueClient != nil {
+		webhookDispatcher = usecaseapi.NewWebhookDispatcher(dmWebhookService, usecaseapi.NewJobQueueClientAdapter(jobQueueClient), &cfg.Webhook)
+	} else {
+		webhookDispatcher = usecaseapi.NewWebhookDispatcher(dmWebhookService, nil, &cfg.Webhook)
+	}
+
+	// Server-Sent Eventsのブローカーの初期化（Redisが設定されている場合はpub/subでAPIサーバー間に配信）
+	streamBroker, err := stream.NewBroker(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create stream broker: %v", err)
+	}
+
+	// StreamNotifierの初期化（jobQueueClientがnilの場合は予約公開ニュースのイベントを配信しない）
+	var streamNotifier *usecaseapi.StreamNotifier
+	if jobQueueClient != nil {
+		streamNotifier = usecaseapi.NewStreamNotifier(streamBroker, usecaseapi.NewJobQueueClientAdapter(jobQueueClient))
+	} else {
+		streamNotifier = usecaseapi.NewStreamNotifier(streamBroker, nil)
+	}
 
 	// Usecase層の初期化
 	todayUsecase := usecaseapi.NewTodayUsecase(dateService)
-	dmUserUsecase := usecaseapi.NewDmUserUsecase(dmUserService)
-	dmPostUsecase := usecaseapi.NewDmPostUsecase(dmPostService)
+	dmUserUsecase := usecaseapi.NewDmUserUsecase(dmUserService, webhookDispatcher)
+	dmPostUsecase := usecaseapi.NewDmPostUsecase(dmPostService, webhookDispatcher, streamNotifier)
+	dmNewsUsecase := usecaseapi.NewDmNewsUsecase(dmNewsService, streamNotifier)
+	streamUsecase := usecaseapi.NewStreamUsecase(streamBroker)
+	dmNewsFeedUsecase := usecaseapi.NewDmNewsFeedUsecase(dmNewsFeedService, &cfg.Feed)
+
+	// 紐づけるアカウントのJWTの検証に使うIssuerの一覧（認証ミドルウェアと同じ設定）
+	identityIssuers, err := auth.NewIssuerRegistry(&cfg.API)
+	if err != nil {
+		log.Fatalf("Failed to create issuer registry: %v", err)
+	}
+	defer identityIssuers.Close()
+	// 初回アクセス時のユーザー作成とアカウントの紐づけ（認証ミドルウェアのIdentityResolverとしても使う）
+	dmMeUsecase := usecaseapi.NewDmMeUsecase(dmUserService, dmUserIdentityService, identityIssuers, webhookDispatcher, cfg.API.IdentityCacheTTL)
 
 	// Handler層の初期化
 	dmUserHandler := handler.NewDmUserHandler(dmUserUsecase)
 	dmPostHandler := handler.NewDmPostHandler(dmPostUsecase)
+	dmNewsHandler := handler.NewDmNewsHandler(dmNewsUsecase)
+	dmNewsFeedHandler := handler.NewDmNewsFeedHandler(dmNewsFeedUsecase)
+	dmMeHandler := handler.NewDmMeHandler(dmMeUsecase)
 	todayHandler := handler.NewTodayHandler(todayUsecase)
+	streamHandler := handler.NewStreamHandler(streamUsecase, cfg.Stream.HeartbeatInterval)
+
+	// GraphQLHandlerの初期化
+	graphQLExecutor, err := graphqlapi.NewExecutor(dmUserUsecase, dmPostUsecase, dmNewsUsecase, &cfg.GraphQL)
+	if err != nil {
+		log.Fatalf("Failed to create graphql executor: %v", err)
+	}
+	graphQLHandler := handler.NewGraphQLHandler(graphQLExecutor)
 
 	// メール送信ログの初期化
 	var mailLogger *logging.MailLogger
@@ -86,34 +184,56 @@ func main() {
 	// EmailHandlerの初期化
 	emailHandler := handler.NewEmailHandler(emailUsecase)
 
-	// Asynqクライアントの初期化（ジョブ登録用）
-	// Redisが起動していない場合でも、APIサーバーの起動は継続する
-	// 注意: ジョブの消化処理は別プロセスのJobQueueサーバーで実行される
-	var jobQueueClient *jobqueue.Client
-	jobQueueClient, err = jobqueue.NewClient(cfg)
+	// エクスポートファイルの保存先の初期化（アップロードと同じストレージを使用）
+	exportStore, err := storage.NewExportStore(&cfg.Upload)
 	if err != nil {
-		// Redis接続エラーを標準エラー出力に記録（起動処理は継続）
-		log.Printf("WARNING: Failed to create job queue client: %v", err)
-		log.Printf("WARNING: Job queue functionality will be unavailable until Redis is started")
-		jobQueueClient = nil
-	} else {
-		defer jobQueueClient.Close()
+		log.Fatalf("Failed to create export store: %v", err)
 	}
+	dmExportService := service.NewDmExportService(dmUserRepo, dmPostRepo, exportStore, cfg.Export.KeyPrefix)
 
 	// DmJobqueueUsecaseの初期化（jobQueueClientがnilの場合も許可）
+	// DmBulkImportUsecaseの初期化（jobQueueClientがnilの場合は同期処理のみ）
+	// DmExportUsecaseの初期化（jobQueueClientがnilの場合はエクスポート不可）
 	var dmJobqueueUsecase *usecaseapi.DmJobqueueUsecase
+	var dmBulkImportUsecase *usecaseapi.DmBulkImportUsecase
+	var dmExportUsecase *usecaseapi.DmExportUsecase
 	if jobQueueClient != nil {
 		jobQueueClientAdapter := usecaseapi.NewJobQueueClientAdapter(jobQueueClient)
 		dmJobqueueUsecase = usecaseapi.NewDmJobqueueUsecase(jobQueueClientAdapter)
+		dmBulkImportUsecase = usecaseapi.NewDmBulkImportUsecase(dmBulkImportService, jobQueueClientAdapter, jobQueueClientAdapter, webhookDispatcher)
+		dmExportUsecase = usecaseapi.NewDmExportUsecase(dmExportService, jobQueueClientAdapter, jobQueueClientAdapter, &cfg.Export)
 	} else {
 		dmJobqueueUsecase = usecaseapi.NewDmJobqueueUsecase(nil)
+		dmBulkImportUsecase = usecaseapi.NewDmBulkImportUsecase(dmBulkImportService, nil, nil, webhookDispatcher)
+		dmExportUsecase = usecaseapi.NewDmExportUsecase(dmExportService, nil, nil, &cfg.Export)
 	}
 
 	// DmJobqueueHandlerの初期化
 	dmJobqueueHandler := handler.NewDmJobqueueHandler(dmJobqueueUsecase)
 
+	// DmBulkHandlerの初期化
+	dmBulkHandler := handler.NewDmBulkHandler(dmBulkImportUsecase)
+
+	// DmExportHandlerの初期化
+	dmExportHandler := handler.NewDmExportHandler(dmExportUsecase)
+
 	// Echoルーターの初期化
-	e := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, emailHandler, dmJobqueueHandler, cfg)
+	e := router.NewRouter(router.Deps{
+		DmUserHandler:     dmUserHandler,
+		DmPostHandler:     dmPostHandler,
+		TodayHandler:      todayHandler,
+		EmailHandler:      emailHandler,
+		DmJobqueueHandler: dmJobqueueHandler,
+		DmBulkHandler:     dmBulkHandler,
+		DmExportHandler:   dmExportHandler,
+		DmNewsHandler:     dmNewsHandler,
+		DmNewsFeedHandler: dmNewsFeedHandler,
+		DmMeHandler:       dmMeHandler,
+		GraphQLHandler:    graphQLHandler,
+		APIKeyRegistry:    apiKeyRegistry,
+		IdentityResolver:  dmMeUsecase,
+		PrincipalLimiter:  principalLimiter,
+	}, cfg)
 
 	// UploadHandlerの初期化（設定がある場合のみ）
 	if cfg.Upload.BasePath != "" {
@@ -122,12 +242,15 @@ func main() {
 			log.Fatalf("Failed to create upload handler: %v", err)
 		}
 		// TUSアップロードエンドポイントの登録
-		if err := router.RegisterUploadEndpoints(e, uploadHandler, cfg); err != nil {
+		if err := router.RegisterUploadEndpoints(e, uploadHandler, apiKeyRegistry, dmMeUsecase, principalLimiter, cfg); err != nil {
 			log.Fatalf("Failed to register upload endpoints: %v", err)
 		}
 		log.Printf("Upload endpoint enabled: %s", cfg.Upload.BasePath)
 	}
 
+	// Server-Sent Eventsエンドポイントの登録
+	router.RegisterStreamEndpoints(e, streamHandler, apiKeyRegistry, dmMeUsecase, principalLimiter, cfg)
+
 	// アクセスログの初期化
 	accessLogger, err := logging.NewAccessLogger("api", cfg.Logging.OutputDir)
 	if err != nil {
@@ -145,6 +268,13 @@ func main() {
 	e.Server.WriteTimeout = cfg.Server.WriteTimeout
 	e.Server.IdleTimeout = cfg.Server.IdleTimeout
 
+	// シャットダウン時にSSEの接続を終了させる（Shutdownは接続中のリクエストの完了を待つため）
+	e.Server.RegisterOnShutdown(func() {
+		if err := streamBroker.Close(); err != nil {
+			log.Printf("Stream broker close error: %v", err)
+		}
+	})
+
 	// Graceful shutdown
 	go func() {
 		log.Printf("Starting server on port %d", cfg.Server.Port)
@@ -153,6 +283,22 @@ func main() {
 		}
 	}()
 
+	// gRPCサーバーの起動（ポートが設定されている場合のみ）
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Port > 0 {
+		grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			log.Fatalf("Failed to listen gRPC port: %v", err)
+		}
+		grpcServer = grpcapi.NewServer(dmUserUsecase, dmPostUsecase, apiKeyRegistry, dmMeUsecase, principalLimiter, cfg)
+		go func() {
+			log.Printf("Starting gRPC server on port %d", cfg.GRPC.Port)
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Printf("gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
 	// シグナル待機
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -168,5 +314,20 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// gRPCサーバーのGraceful shutdown（タイムアウトした場合は強制停止）
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			log.Println("gRPC server forced to shutdown")
+			grpcServer.Stop()
+		}
+	}
+
 	log.Println("Server exited")
 }
@@ -101,8 +101,10 @@ func TestGeneratorsMap(t *testing.T) {
 		t.Error("Generators map is nil")
 	}
 
-	// dm-newsジェネレータのみ確認（dm_users/dm_postsはシャーディンググループにあるため管理対象外）
-	if _, ok := Generators["dm-news"]; !ok {
-		t.Error("dm-news generator not found in Generators map")
+	// masterグループのテーブルのみ確認（dm_users/dm_postsはシャーディンググループにあるため管理対象外）
+	for _, name := range []string{"dm-news", "dm-webhook-subscriptions", "dm-webhook-deliveries"} {
+		if _, ok := Generators[name]; !ok {
+			t.Errorf("%s generator not found in Generators map", name)
+		}
 	}
 }
@@ -6,40 +6,56 @@ import (
 	"html/template"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/GoAdminGroup/go-admin/context"
 	"github.com/GoAdminGroup/go-admin/template/types"
 
 	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/usecase/admin"
 )
 
-// APIKeyPage はAPIキー発行ページを返す
+// APIKeyPage はAPIキー管理ページ（発行・一覧・失効）を返す
 // 注意: RegisterCustomPagesで"/api-key"と登録すると、実際のURLは"/admin/api-key"になる
 // HTML内のリンクも"/admin/api-key"とする必要がある
 func APIKeyPage(ctx *context.Context, apiKeyUsecase *admin.APIKeyUsecase) (types.Panel, error) {
-	// POSTリクエスト: キー生成
 	if ctx.Method() == http.MethodPost {
+		// POSTリクエスト: キー失効
+		if ctx.FormValue("action") == "revoke" {
+			return handleRevokeKey(ctx, apiKeyUsecase)
+		}
+		// POSTリクエスト: キー生成
 		return handleGenerateKey(ctx, apiKeyUsecase)
 	}
 
-	// GETリクエスト: フォーム表示
-	return renderAPIKeyPage()
+	// GETリクエスト: フォームと発行済みキーの一覧を表示
+	return renderAPIKeyPage(ctx, apiKeyUsecase, "", "", nil, nil, "")
 }
 
 // handleGenerateKey はAPIキーを生成
 func handleGenerateKey(ctx *context.Context, apiKeyUsecase *admin.APIKeyUsecase) (types.Panel, error) {
+	name := strings.TrimSpace(ctx.FormValue("name"))
+	owner := strings.TrimSpace(ctx.FormValue("owner"))
+	scopes := ctx.Request.Form["scopes"]
+
+	// バリデーション
+	errors := validateAPIKeyInput(name, owner, scopes)
+	if len(errors) > 0 {
+		return renderAPIKeyPage(ctx, apiKeyUsecase, name, owner, scopes, errors, "")
+	}
+
 	// 現在の環境を取得
 	env := os.Getenv("APP_ENV")
 	if env == "" {
 		env = "develop"
 	}
 
-	// 鍵の生成（usecase層を呼び出し）
-	token, err := apiKeyUsecase.GenerateAPIKey(ctx.Request.Context(), env)
+	// 鍵の生成と台帳への登録（usecase層を呼び出し）
+	token, err := apiKeyUsecase.GenerateAPIKey(ctx.Request.Context(), env, &model.IssueAPIKeyRequest{Name: name, Owner: owner, Scopes: scopes})
 	if err != nil {
-		return types.Panel{}, err
+		return renderAPIKeyPage(ctx, apiKeyUsecase, name, owner, scopes, []string{err.Error()}, "")
 	}
 
 	// ペイロードのデコード（usecase層を呼び出し）
@@ -52,31 +68,191 @@ func handleGenerateKey(ctx *context.Context, apiKeyUsecase *admin.APIKeyUsecase)
 	return renderAPIKeyResult(token, claims)
 }
 
-// renderAPIKeyPage はAPIキー発行ページをレンダリング
-func renderAPIKeyPage() (types.Panel, error) {
-	content := `
+// handleRevokeKey はAPIキーを失効
+func handleRevokeKey(ctx *context.Context, apiKeyUsecase *admin.APIKeyUsecase) (types.Panel, error) {
+	jti := strings.TrimSpace(ctx.FormValue("jti"))
+
+	// usecase層を呼び出し
+	if err := apiKeyUsecase.RevokeAPIKey(ctx.Request.Context(), jti); err != nil {
+		return renderAPIKeyPage(ctx, apiKeyUsecase, "", "", nil, []string{err.Error()}, "")
+	}
+
+	return renderAPIKeyPage(ctx, apiKeyUsecase, "", "", nil, nil, fmt.Sprintf("APIキー（jti: %s）を失効しました", jti))
+}
+
+// validateAPIKeyInput は入力値をバリデーションする
+func validateAPIKeyInput(name, owner string, scopes []string) []string {
+	var errors []string
+
+	if name == "" {
+		errors = append(errors, "名前は必須です")
+	} else if len(name) > 100 {
+		errors = append(errors, "名前は100文字以内で入力してください")
+	}
+
+	if owner == "" {
+		errors = append(errors, "所有者は必須です")
+	} else if len(owner) > 100 {
+		errors = append(errors, "所有者は100文字以内で入力してください")
+	}
+
+	if len(scopes) == 0 {
+		errors = append(errors, "スコープを1つ以上選択してください")
+	}
+	for _, scope := range scopes {
+		if !auth.IsPublicAPIKeyScope(scope) {
+			errors = append(errors, fmt.Sprintf("不明なスコープです: %s", scope))
+		}
+	}
+
+	return errors
+}
+
+// renderAPIKeyPage はAPIキー発行フォームと発行済みキーの一覧をレンダリング
+func renderAPIKeyPage(ctx *context.Context, apiKeyUsecase *admin.APIKeyUsecase, name, owner string, scopes []string, errors []string, message string) (types.Panel, error) {
+	apiKeys, err := apiKeyUsecase.ListAPIKeys(ctx.Request.Context())
+	if err != nil {
+		errors = append(errors, err.Error())
+	}
+
+	alertHTML := ""
+	if len(errors) > 0 {
+		alertHTML = `<div class="alert alert-danger"><ul>`
+		for _, e := range errors {
+			alertHTML += fmt.Sprintf("<li>%s</li>", template.HTMLEscapeString(e))
+		}
+		alertHTML += `</ul></div>`
+	}
+	if message != "" {
+		alertHTML += fmt.Sprintf(`<div class="alert alert-success">%s</div>`, template.HTMLEscapeString(message))
+	}
+
+	content := fmt.Sprintf(`
+%s
 <div class="box box-primary">
     <div class="box-header with-border">
         <h3 class="box-title">Public APIキー発行</h3>
     </div>
-    <div class="box-body">
-        <p>新しいPublic APIキーを発行します。</p>
-        <form action="/admin/api-key" method="POST">
+    <form action="/admin/api-key" method="POST">
+        <div class="box-body">
+            <p>新しいPublic APIキーを発行します。発行したキーは台帳に登録され、個別に失効できます。</p>
+            <div class="form-group">
+                <label for="name">名前 <span class="text-red">*</span></label>
+                <input type="text" class="form-control" id="name" name="name" value="%s" placeholder="用途や連携先の名前" required maxlength="100">
+            </div>
+            <div class="form-group">
+                <label for="owner">所有者 <span class="text-red">*</span></label>
+                <input type="text" class="form-control" id="owner" name="owner" value="%s" placeholder="キーを管理する担当者・チーム" required maxlength="100">
+            </div>
+            <div class="form-group">
+                <label>スコープ <span class="text-red">*</span></label>
+                <p class="help-block">キーで呼び出せるAPIを選択します。必要なスコープのみを付与してください。</p>
+%s
+            </div>
+        </div>
+        <div class="box-footer">
             <button type="submit" class="btn btn-primary">
                 <i class="fa fa-key"></i> APIキーを発行
             </button>
-        </form>
-    </div>
+        </div>
+    </form>
 </div>
-`
+%s
+`, alertHTML, template.HTMLEscapeString(name), template.HTMLEscapeString(owner), renderScopeCheckboxes(scopes), renderAPIKeyList(apiKeys))
 
 	return types.Panel{
-		Title:       "APIキー発行",
-		Description: "Public APIキーを発行します",
+		Title:       "APIキー管理",
+		Description: "Public APIキーの発行・失効を行います",
 		Content:     template.HTML(content),
 	}, nil
 }
 
+// renderScopeCheckboxes はスコープの選択欄をレンダリング（selectedのスコープを選択状態にする）
+func renderScopeCheckboxes(selected []string) string {
+	html := ""
+	for _, scope := range auth.PublicAPIKeyScopes {
+		checked := ""
+		for _, s := range selected {
+			if s == scope {
+				checked = " checked"
+				break
+			}
+		}
+		html += fmt.Sprintf(`
+                <div class="checkbox">
+                    <label><input type="checkbox" name="scopes" value="%s"%s> <code>%s</code></label>
+                </div>`, scope, checked, scope)
+	}
+	return html
+}
+
+// renderAPIKeyList は発行済みキーの一覧をレンダリング
+func renderAPIKeyList(apiKeys []*model.APIKey) string {
+	rows := ""
+	for _, apiKey := range apiKeys {
+		lastUsedAt := "-"
+		if apiKey.LastUsedAt != nil {
+			lastUsedAt = apiKey.LastUsedAt.Format("2006-01-02 15:04:05")
+		}
+
+		action := fmt.Sprintf(`<form action="/admin/api-key" method="POST" onsubmit="return confirm('このAPIキーを失効しますか？');">
+                    <input type="hidden" name="action" value="revoke">
+                    <input type="hidden" name="jti" value="%s">
+                    <button type="submit" class="btn btn-danger btn-xs">失効</button>
+                </form>`, template.HTMLEscapeString(apiKey.JTI))
+		if apiKey.Revoked() {
+			action = fmt.Sprintf(`<span class="label label-default">失効済み（%s）</span>`, apiKey.RevokedAt.Format("2006-01-02 15:04:05"))
+		}
+
+		rows += fmt.Sprintf(`
+            <tr>
+                <td>%s</td>
+                <td>%s</td>
+                <td><code>%s</code></td>
+                <td>%s</td>
+                <td>%s</td>
+                <td>%s</td>
+                <td>%s</td>
+                <td>%s</td>
+            </tr>`,
+			template.HTMLEscapeString(apiKey.Name),
+			template.HTMLEscapeString(apiKey.Owner),
+			template.HTMLEscapeString(apiKey.JTI),
+			template.HTMLEscapeString(apiKey.Scopes),
+			template.HTMLEscapeString(apiKey.Version),
+			apiKey.CreatedAt.Format("2006-01-02 15:04:05"),
+			lastUsedAt,
+			action,
+		)
+	}
+	if rows == "" {
+		rows = `
+            <tr><td colspan="8">発行済みのAPIキーはありません</td></tr>`
+	}
+
+	return fmt.Sprintf(`
+<div class="box">
+    <div class="box-header with-border">
+        <h3 class="box-title">発行済みAPIキー</h3>
+    </div>
+    <div class="box-body table-responsive no-padding">
+        <table class="table table-hover">
+            <tr>
+                <th>名前</th>
+                <th>所有者</th>
+                <th>jti</th>
+                <th>スコープ</th>
+                <th>バージョン</th>
+                <th>発行日時</th>
+                <th>最終利用日時</th>
+                <th></th>
+            </tr>%s
+        </table>
+    </div>
+</div>
+`, rows)
+}
+
 // renderAPIKeyResult は生成結果をレンダリング
 func renderAPIKeyResult(token string, claims *auth.JWTClaims) (types.Panel, error) {
 	// ペイロードをJSON形式で整形
@@ -111,10 +287,15 @@ func renderAPIKeyResult(token string, claims *auth.JWTClaims) (types.Panel, erro
             <label>環境</label>
             <p>%s</p>
         </div>
+        <div class="form-group">
+            <label>jti</label>
+            <p><code>%s</code></p>
+        </div>
         <div class="form-group">
             <button type="button" class="btn btn-success" onclick="downloadAPIKey()">
                 <i class="fa fa-download"></i> ダウンロード
             </button>
+            <a href="/admin/api-key" class="btn btn-default">発行済みAPIキー一覧</a>
         </div>
     </div>
 </div>
@@ -134,7 +315,7 @@ function downloadAPIKey() {
     document.body.removeChild(a);
 }
 </script>
-`, template.HTMLEscapeString(token), template.HTMLEscapeString(string(payloadJSON)), issuedAt, claims.Version, claims.Env, token)
+`, template.HTMLEscapeString(token), template.HTMLEscapeString(string(payloadJSON)), issuedAt, claims.Version, claims.Env, template.HTMLEscapeString(claims.JTI), token)
 
 	return types.Panel{
 		Title:       "APIキー発行結果",
@@ -0,0 +1,99 @@
+package pages
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/taku-o/go-webdb-template/internal/usecase/admin"
+)
+
+// WebhookReplayPage はWebhook再送ページを返す
+// GETの場合は?id=で配信ログIDを指定してフォームに入力済みにできる
+func WebhookReplayPage(ctx *context.Context, webhookReplayUsecase *admin.WebhookReplayUsecase) (types.Panel, error) {
+	if ctx.Method() == http.MethodPost {
+		return handleWebhookReplayPost(ctx, webhookReplayUsecase)
+	}
+	return renderWebhookReplayForm(ctx.Query("id"), nil)
+}
+
+// handleWebhookReplayPost はPOSTリクエストを処理する
+func handleWebhookReplayPost(ctx *context.Context, webhookReplayUsecase *admin.WebhookReplayUsecase) (types.Panel, error) {
+	idValue := strings.TrimSpace(ctx.FormValue("id"))
+	deliveryID, err := strconv.ParseInt(idValue, 10, 64)
+	if err != nil || deliveryID <= 0 {
+		return renderWebhookReplayForm(idValue, []string{"配信ログIDは正の整数で入力してください"})
+	}
+
+	// usecase層を呼び出し
+	replay, err := webhookReplayUsecase.ReplayDelivery(ctx.Request.Context(), deliveryID)
+	if err != nil {
+		return renderWebhookReplayForm(idValue, []string{err.Error()})
+	}
+
+	content := fmt.Sprintf(`
+<div class="box box-success">
+    <div class="box-header with-border">
+        <h3 class="box-title">再送を受け付けました</h3>
+    </div>
+    <div class="box-body">
+        <p>配信ログ %d の再送用に配信ログ %d を作成し、配信ジョブを登録しました。</p>
+        <p>配信結果はWebhook配信ログで確認できます。</p>
+    </div>
+    <div class="box-footer">
+        <a href="/admin/info/dm-webhook-deliveries" class="btn btn-primary">配信ログ一覧へ</a>
+        <a href="/admin/webhook/replay" class="btn btn-default">続けて再送</a>
+    </div>
+</div>
+`, deliveryID, replay.ID)
+
+	return types.Panel{
+		Title:       "Webhook再送",
+		Description: "再送を受け付けました",
+		Content:     template.HTML(content),
+	}, nil
+}
+
+// renderWebhookReplayForm はWebhook再送フォームをレンダリングする
+func renderWebhookReplayForm(id string, errors []string) (types.Panel, error) {
+	errorHTML := ""
+	if len(errors) > 0 {
+		errorHTML = `<div class="alert alert-danger"><ul>`
+		for _, e := range errors {
+			errorHTML += fmt.Sprintf("<li>%s</li>", template.HTMLEscapeString(e))
+		}
+		errorHTML += `</ul></div>`
+	}
+
+	content := fmt.Sprintf(`
+%s
+<div class="box box-primary">
+    <div class="box-header with-border">
+        <h3 class="box-title">Webhook再送</h3>
+    </div>
+    <form action="/admin/webhook/replay" method="POST">
+        <div class="box-body">
+            <p>配信ログと同じイベントID・ペイロードで、新しい配信ログを作成して再送します。</p>
+            <div class="form-group">
+                <label for="id">配信ログID <span class="text-red">*</span></label>
+                <input type="number" class="form-control" id="id" name="id" value="%s" placeholder="配信ログIDを入力" required min="1">
+            </div>
+        </div>
+        <div class="box-footer">
+            <button type="submit" class="btn btn-primary">再送</button>
+            <a href="/admin/info/dm-webhook-deliveries" class="btn btn-default">配信ログ一覧</a>
+        </div>
+    </form>
+</div>
+`, errorHTML, template.HTMLEscapeString(id))
+
+	return types.Panel{
+		Title:       "Webhook再送",
+		Description: "配信ログを指定してWebhookを再送します",
+		Content:     template.HTML(content),
+	}, nil
+}
@@ -89,9 +89,130 @@ func GetDmNewsTable(ctx *context.Context) table.Table {
 	return newsTable
 }
 
+// GetDmWebhookSubscriptionsTable はdm_webhook_subscriptionsテーブルのGoAdmin設定を返す
+// シークレットは一覧に表示せず、編集フォームでのみ扱う
+func GetDmWebhookSubscriptionsTable(ctx *context.Context) table.Table {
+	subscriptionsTable := table.NewDefaultTable(ctx, table.Config{
+		Driver:     currentDriver,
+		CanAdd:     true,
+		Editable:   true,
+		Deletable:  true,
+		Exportable: false,
+		Connection: table.DefaultConnectionName,
+		PrimaryKey: table.PrimaryKey{
+			Type: db.Int,
+			Name: "id",
+		},
+	})
+
+	// 一覧表示設定
+	info := subscriptionsTable.GetInfo()
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField("名前", "name", db.Varchar).FieldSortable().FieldFilterable()
+	info.AddField("配信先URL", "target_url", db.Text).FieldFilterable()
+	info.AddField("イベント", "events", db.Text).FieldFilterable()
+	info.AddField("有効", "active", db.Bool).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			// PostgreSQLはtrue/false、MySQLは1/0で返る
+			if value.Value == "true" || value.Value == "1" {
+				return "有効"
+			}
+			return "無効"
+		})
+	info.AddField("作成日時", "created_at", db.Datetime).FieldSortable()
+	info.AddField("更新日時", "updated_at", db.Datetime).FieldSortable()
+
+	info.SetTable("dm_webhook_subscriptions").SetTitle("Webhook購読").SetDescription("Webhook購読設定一覧")
+
+	// フォーム設定（新規作成・編集）
+	// 有効フラグはPostgreSQL（boolean）・MySQL（tinyint）の両方で受け付けられる1/0で保存する
+	formList := subscriptionsTable.GetForm()
+	formList.AddField("ID", "id", db.Int, form.Default).
+		FieldNotAllowEdit().
+		FieldHide()
+	formList.AddField("名前", "name", db.Varchar, form.Text).FieldMust()
+	formList.AddField("配信先URL", "target_url", db.Text, form.Url).FieldMust()
+	formList.AddField("シークレット", "secret", db.Text, form.Text).
+		FieldMust().
+		FieldHelpMsg("X-Signatureヘッダーの署名（HMAC-SHA256）に使用します")
+	formList.AddField("イベント", "events", db.Text, form.Text).
+		FieldMust().
+		FieldDefault("*").
+		FieldHelpMsg("カンマ区切りで指定します（例: user.created,post.*）。* は全イベント")
+	formList.AddField("有効", "active", db.Bool, form.Switch).
+		FieldOptions(types.FieldOptions{
+			{Text: "有効", Value: "1"},
+			{Text: "無効", Value: "0"},
+		}).
+		FieldDefault("1")
+	formList.AddField("作成日時", "created_at", db.Datetime, form.Datetime).
+		FieldHide().
+		FieldPostFilterFn(func(value types.PostFieldModel) interface{} {
+			if value.Value.Value() == "" {
+				return time.Now().Format("2006-01-02 15:04:05")
+			}
+			return value.Value.Value()
+		})
+	formList.AddField("更新日時", "updated_at", db.Datetime, form.Datetime).
+		FieldHide().
+		FieldPostFilterFn(func(value types.PostFieldModel) interface{} {
+			return time.Now().Format("2006-01-02 15:04:05")
+		})
+
+	formList.SetTable("dm_webhook_subscriptions").SetTitle("Webhook購読").SetDescription("Webhook購読設定")
+
+	return subscriptionsTable
+}
+
+// GetDmWebhookDeliveriesTable はdm_webhook_deliveriesテーブルのGoAdmin設定を返す
+// 配信ログは閲覧のみとし、再送はWebhook再送ページから行う
+func GetDmWebhookDeliveriesTable(ctx *context.Context) table.Table {
+	deliveriesTable := table.NewDefaultTable(ctx, table.Config{
+		Driver:     currentDriver,
+		CanAdd:     false,
+		Editable:   false,
+		Deletable:  false,
+		Exportable: true,
+		Connection: table.DefaultConnectionName,
+		PrimaryKey: table.PrimaryKey{
+			Type: db.Int,
+			Name: "id",
+		},
+	})
+
+	// 一覧表示設定
+	info := deliveriesTable.GetInfo()
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField("購読ID", "subscription_id", db.Int).FieldSortable().FieldFilterable()
+	info.AddField("イベント", "event", db.Varchar).FieldFilterable()
+	info.AddField("イベントID", "event_id", db.Varchar).FieldFilterable()
+	info.AddField("状態", "status", db.Varchar).FieldFilterable()
+	info.AddField("試行回数", "attempts", db.Int).FieldSortable()
+	info.AddField("応答ステータス", "response_status", db.Int)
+	info.AddField("最終エラー", "last_error", db.Text)
+	info.AddField("再送元ID", "replay_of", db.Int).FieldFilterable()
+	info.AddField("配信日時", "delivered_at", db.Datetime).FieldSortable()
+	info.AddField("作成日時", "created_at", db.Datetime).FieldSortable()
+	info.AddField("ペイロード", "payload", db.Text).FieldHide()
+
+	info.SetTable("dm_webhook_deliveries").SetTitle("Webhook配信ログ").SetDescription("Webhook配信ログ一覧").
+		SetSortField("id").SetSortDesc()
+
+	// 詳細表示用（フォームからの更新は行わない）
+	formList := deliveriesTable.GetForm()
+	formList.AddField("ID", "id", db.Int, form.Default).FieldNotAllowEdit()
+	formList.AddField("ペイロード", "payload", db.Text, form.TextArea).FieldNotAllowEdit()
+
+	formList.SetTable("dm_webhook_deliveries").SetTitle("Webhook配信ログ").SetDescription("Webhook配信ログ")
+
+	return deliveriesTable
+}
+
 // Generators はGoAdminに登録するテーブルジェネレータのマップ
 // 注意: dm_usersとdm_postsはシャーディンググループにあるため、
 // GoAdmin（masterグループのみ使用）では管理できません
 var Generators = map[string]table.Generator{
-	"dm-news": GetDmNewsTable,
+	"dm-news":                  GetDmNewsTable,
+	"dm-webhook-subscriptions": GetDmWebhookSubscriptionsTable,
+	"dm-webhook-deliveries":    GetDmWebhookDeliveriesTable,
 }
@@ -0,0 +1,24 @@
+package apiversion
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// adapters はバージョンごとのレスポンスのアダプター
+// ハンドラーは最新のモデルでレスポンスを返すため、モデルのJSONの形式を変更した場合は
+// 変更前のバージョンにAdaptBodyで変更前の形式へ変換するアダプターを追加する
+//
+//	"v1": {
+//		AdaptBody(func(u *model.DmUser) any { return newDmUserV1(u) }),
+//	},
+var adapters = map[string][]huma.Transformer{}
+
+// AdaptBody は指定した型のレスポンスボディを変換するアダプターを作成（それ以外の型はそのまま返す）
+func AdaptBody[T any](convert func(T) any) huma.Transformer {
+	return func(ctx huma.Context, status string, v any) (any, error) {
+		if body, ok := v.(T); ok {
+			return convert(body), nil
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,100 @@
+package apiversion
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// apiPathPrefix はAPIのパスのプレフィックス（バージョンなし）
+const apiPathPrefix = "/api"
+
+// Version はURLで指定するAPIバージョン
+type Version struct {
+	Name         string
+	DeprecatedAt *time.Time
+	Sunset       *time.Time
+}
+
+// NewVersions は設定からAPIバージョンの一覧を作成（設定の順序を保持し、最後が最新）
+func NewVersions(cfgs []config.APIVersionConfig) ([]*Version, error) {
+	versions := make([]*Version, 0, len(cfgs))
+	seen := make(map[string]bool, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.Name == "" || strings.Contains(cfg.Name, "/") {
+			return nil, fmt.Errorf("invalid api version name: %q", cfg.Name)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("duplicate api version: %s", cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		v := &Version{Name: cfg.Name}
+		if cfg.DeprecatedAt != "" {
+			t, err := time.Parse(time.RFC3339, cfg.DeprecatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deprecated_at for api version %s: %w", cfg.Name, err)
+			}
+			v.DeprecatedAt = &t
+		}
+		if cfg.Sunset != "" {
+			t, err := time.Parse(time.RFC3339, cfg.Sunset)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sunset for api version %s: %w", cfg.Name, err)
+			}
+			v.Sunset = &t
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+// Prefix はバージョンのパスのプレフィックス（/api/{name}）
+func (v *Version) Prefix() string {
+	return apiPathPrefix + "/" + v.Name
+}
+
+// Adapters はバージョンのレスポンスのアダプター
+func (v *Version) Adapters() []huma.Transformer {
+	return adapters[v.Name]
+}
+
+// NewGroup はエンドポイントのパス（/api/...）をバージョンのパス（/api/{name}/...）に変えて登録するグループを作成
+// /api/以外のパス（ニュースフィードなど）はバージョン管理の対象外のため登録しない
+func (v *Version) NewGroup(api huma.API) *huma.Group {
+	group := huma.NewGroup(api)
+	// ドキュメントへの追加とルーティングで同じOperationが渡されるため、コピーを変更する
+	group.UseModifier(func(o *huma.Operation, next func(*huma.Operation)) {
+		if !strings.HasPrefix(o.Path, apiPathPrefix+"/") {
+			return
+		}
+		modified := *o
+		modified.Path = v.Prefix() + strings.TrimPrefix(o.Path, apiPathPrefix)
+		next(&modified)
+	})
+	return group
+}
+
+// HeaderMiddleware は非推奨のバージョンのレスポンスにDeprecation・Sunset・Linkヘッダーを設定するミドルウェア
+// pathPrefixは処理するパスのプレフィックス（バージョンなしのパスの場合は/api）、successorは移行先のバージョン
+func (v *Version) HeaderMiddleware(pathPrefix string, successor *Version) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		path := ctx.URL().Path
+		if v.DeprecatedAt != nil && strings.HasPrefix(path, pathPrefix+"/") {
+			// RFC 9745: Deprecation: @<UNIX時間>
+			ctx.SetHeader("Deprecation", fmt.Sprintf("@%d", v.DeprecatedAt.Unix()))
+			// RFC 8594: Sunset: <HTTP-date>
+			if v.Sunset != nil {
+				ctx.SetHeader("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if successor != nil && successor != v {
+				ctx.AppendHeader("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor.Prefix(), strings.TrimPrefix(path, pathPrefix)))
+			}
+		}
+		next(ctx)
+	}
+}
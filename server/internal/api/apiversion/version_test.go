@@ -0,0 +1,182 @@
+package apiversion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+type testItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type testItemInput struct {
+	ID string `path:"id"`
+}
+
+type testItemOutput struct {
+	Body testItem
+}
+
+// registerTestItem はテスト用のエンドポイント（GET /api/items/{id}）を登録
+func registerTestItem(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-item",
+		Method:      http.MethodGet,
+		Path:        "/api/items/{id}",
+	}, func(ctx context.Context, input *testItemInput) (*testItemOutput, error) {
+		return &testItemOutput{Body: testItem{ID: input.ID, Name: "item"}}, nil
+	})
+}
+
+func TestNewVersions(t *testing.T) {
+	versions, err := NewVersions([]config.APIVersionConfig{
+		{Name: "v1", DeprecatedAt: "2026-10-19T00:00:00Z", Sunset: "2027-04-30T00:00:00Z"},
+		{Name: "v2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	assert.Equal(t, "v1", versions[0].Name)
+	assert.Equal(t, "/api/v1", versions[0].Prefix())
+	require.NotNil(t, versions[0].DeprecatedAt)
+	assert.True(t, versions[0].DeprecatedAt.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)))
+	require.NotNil(t, versions[0].Sunset)
+	assert.True(t, versions[0].Sunset.Equal(time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, "v2", versions[1].Name)
+	assert.Nil(t, versions[1].DeprecatedAt)
+	assert.Nil(t, versions[1].Sunset)
+}
+
+func TestNewVersions_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfgs []config.APIVersionConfig
+	}{
+		{"empty name", []config.APIVersionConfig{{Name: ""}}},
+		{"name with slash", []config.APIVersionConfig{{Name: "v1/beta"}}},
+		{"duplicate", []config.APIVersionConfig{{Name: "v1"}, {Name: "v1"}}},
+		{"invalid deprecated_at", []config.APIVersionConfig{{Name: "v1", DeprecatedAt: "2026-10-19"}}},
+		{"invalid sunset", []config.APIVersionConfig{{Name: "v1", Sunset: "tomorrow"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVersions(tt.cfgs)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVersion_NewGroup(t *testing.T) {
+	_, api := humatest.New(t)
+	v := &Version{Name: "v2"}
+	registerTestItem(v.NewGroup(api))
+
+	resp := api.Get("/api/v2/items/1")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Contains(t, resp.Body.String(), `"id":"1"`)
+
+	resp = api.Get("/api/items/1")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	assert.Contains(t, api.OpenAPI().Paths, "/api/v2/items/{id}")
+}
+
+func TestVersion_NewGroup_SkipsNonAPIPaths(t *testing.T) {
+	_, api := humatest.New(t)
+	v := &Version{Name: "v2"}
+	huma.Register(v.NewGroup(api), huma.Operation{
+		OperationID: "get-feed",
+		Method:      http.MethodGet,
+		Path:        "/feeds/items.rss",
+	}, func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		return &struct{}{}, nil
+	})
+
+	assert.Empty(t, api.OpenAPI().Paths)
+	assert.Equal(t, http.StatusNotFound, api.Get("/feeds/items.rss").Code)
+	assert.Equal(t, http.StatusNotFound, api.Get("/api/v2/feeds/items.rss").Code)
+}
+
+func TestVersion_HeaderMiddleware(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+	v1 := &Version{Name: "v1", DeprecatedAt: &deprecatedAt, Sunset: &sunset}
+	v2 := &Version{Name: "v2"}
+
+	_, api := humatest.New(t)
+	api.UseMiddleware(v1.HeaderMiddleware(v1.Prefix(), v2))
+	registerTestItem(v1.NewGroup(api))
+
+	resp := api.Get("/api/v1/items/1")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Equal(t, "@1792368000", resp.Header().Get("Deprecation"))
+	assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", resp.Header().Get("Sunset"))
+	assert.Contains(t, resp.Header().Values("Link"), `</api/v2/items/1>; rel="successor-version"`)
+}
+
+func TestVersion_HeaderMiddleware_Unversioned(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	v1 := &Version{Name: "v1", DeprecatedAt: &deprecatedAt}
+	v2 := &Version{Name: "v2"}
+
+	// バージョンなしのパスは最初のバージョンとして扱う
+	_, api := humatest.New(t)
+	api.UseMiddleware(v1.HeaderMiddleware("/api", v2))
+	registerTestItem(api)
+
+	resp := api.Get("/api/items/1")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Equal(t, "@1792368000", resp.Header().Get("Deprecation"))
+	assert.Empty(t, resp.Header().Get("Sunset"))
+	assert.Contains(t, resp.Header().Values("Link"), `</api/v2/items/1>; rel="successor-version"`)
+}
+
+func TestVersion_HeaderMiddleware_NotDeprecated(t *testing.T) {
+	v2 := &Version{Name: "v2"}
+
+	_, api := humatest.New(t)
+	api.UseMiddleware(v2.HeaderMiddleware(v2.Prefix(), v2))
+	registerTestItem(v2.NewGroup(api))
+
+	resp := api.Get("/api/v2/items/1")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Empty(t, resp.Header().Get("Deprecation"))
+	assert.Empty(t, resp.Header().Get("Sunset"))
+	assert.Empty(t, resp.Header().Values("Link"))
+}
+
+func TestAdaptBody(t *testing.T) {
+	// 旧バージョンの形式（nameをtitleとして返す）に変換するアダプター
+	type testItemV1 struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	config := huma.DefaultConfig("test", "v1")
+	config.Transformers = []huma.Transformer{
+		AdaptBody(func(item testItem) any { return testItemV1{ID: item.ID, Title: item.Name} }),
+	}
+	_, api := humatest.New(t, config)
+	registerTestItem(api)
+
+	resp := api.Get("/api/items/1")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Contains(t, resp.Body.String(), `"title":"item"`)
+	assert.NotContains(t, resp.Body.String(), `"name"`)
+
+	// 他の型はそのまま返す
+	v, err := AdaptBody(func(item testItem) any { return nil })(nil, "200", "unchanged")
+	require.NoError(t, err)
+	assert.Equal(t, "unchanged", v)
+}
@@ -0,0 +1,89 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// ErrInvalidQuery はクエリの構文・検証エラー、または深さ・複雑度の上限を超えた場合のエラー
+var ErrInvalidQuery = errors.New("invalid graphql query")
+
+// Request はGraphQLのリクエスト
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Executor はGraphQLのクエリを実行する
+type Executor struct {
+	schema        graphql.Schema
+	dmUserUsecase *usecaseapi.DmUserUsecase
+	dmPostUsecase *usecaseapi.DmPostUsecase
+	maxDepth      int
+	maxComplexity int
+}
+
+// NewExecutor は新しいExecutorを作成
+func NewExecutor(dmUserUsecase *usecaseapi.DmUserUsecase, dmPostUsecase *usecaseapi.DmPostUsecase, dmNewsUsecase *usecaseapi.DmNewsUsecase, cfg *config.GraphQLConfig) (*Executor, error) {
+	schema, err := newSchema(dmUserUsecase, dmPostUsecase, dmNewsUsecase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create graphql schema: %w", err)
+	}
+
+	return &Executor{
+		schema:        schema,
+		dmUserUsecase: dmUserUsecase,
+		dmPostUsecase: dmPostUsecase,
+		maxDepth:      cfg.MaxDepth,
+		maxComplexity: cfg.MaxComplexity,
+	}, nil
+}
+
+// Execute はクエリを実行
+// 構文・検証エラーや深さ・複雑度の上限超過の場合は、エラー内容を含む結果とErrInvalidQueryを返す
+func (e *Executor) Execute(ctx context.Context, req *Request) (*graphql.Result, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, ErrInvalidQuery
+	}
+
+	validation := graphql.ValidateDocument(&e.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}, ErrInvalidQuery
+	}
+
+	cost, err := analyzeQueryCost(&e.schema, doc, req.OperationName, req.Variables)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, ErrInvalidQuery
+	}
+	if e.maxDepth > 0 && cost.depth > e.maxDepth {
+		err := fmt.Errorf("query depth %d exceeds the maximum of %d", cost.depth, e.maxDepth)
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, ErrInvalidQuery
+	}
+	if e.maxComplexity > 0 && cost.complexity > e.maxComplexity {
+		err := fmt.Errorf("query complexity %d exceeds the maximum of %d", cost.complexity, e.maxComplexity)
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, ErrInvalidQuery
+	}
+
+	// データローダーはリクエストごとに作成（キャッシュをリクエスト間で共有しない）
+	ctx = withLoaders(ctx, newLoaders(e.dmUserUsecase, e.dmPostUsecase))
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	}), nil
+}
@@ -0,0 +1,241 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// mockDmUserService はDmUserServiceInterfaceのモック
+type mockDmUserService struct {
+	usecaseapi.DmUserServiceInterface
+	batchGetFunc func(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error)
+	listFunc     func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
+}
+
+func (m *mockDmUserService) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	return m.batchGetFunc(ctx, ids)
+}
+
+func (m *mockDmUserService) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
+	return m.listFunc(ctx, limit, offset, filter, sort)
+}
+
+// mockDmPostService はDmPostServiceInterfaceのモック
+type mockDmPostService struct {
+	usecaseapi.DmPostServiceInterface
+	listFunc        func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	listByUsersFunc func(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error)
+}
+
+func (m *mockDmPostService) ListDmPosts(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+	return m.listFunc(ctx, limit, offset, filter, sort)
+}
+
+func (m *mockDmPostService) ListDmPostsByUsers(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	return m.listByUsersFunc(ctx, userIDs, limit)
+}
+
+// mockDmNewsService はDmNewsServiceInterfaceのモック
+type mockDmNewsService struct {
+	usecaseapi.DmNewsServiceInterface
+	getFunc func(ctx context.Context, id int64) (*model.DmNews, error)
+}
+
+func (m *mockDmNewsService) GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error) {
+	return m.getFunc(ctx, id)
+}
+
+func testID(prefix string, n int) string {
+	return fmt.Sprintf("%s%031d", prefix, n)
+}
+
+func newTestExecutor(t *testing.T, userService *mockDmUserService, postService *mockDmPostService, newsService *mockDmNewsService, cfg *config.GraphQLConfig) *Executor {
+	t.Helper()
+	if cfg == nil {
+		cfg = &config.GraphQLConfig{MaxDepth: 8, MaxComplexity: 2000}
+	}
+	executor, err := NewExecutor(
+		usecaseapi.NewDmUserUsecase(userService, nil),
+		usecaseapi.NewDmPostUsecase(postService, nil, nil),
+		usecaseapi.NewDmNewsUsecase(newsService, nil),
+		cfg,
+	)
+	require.NoError(t, err)
+	return executor
+}
+
+// resultJSON は実行結果をJSON文字列に変換
+func resultJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestExecutor_UsersWithPostsBatched(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := []*model.DmUser{
+		{ID: testID("u", 1), Name: "Alice", Email: "alice@example.com", CreatedAt: now, UpdatedAt: now},
+		{ID: testID("u", 2), Name: "Bob", Email: "bob@example.com", CreatedAt: now, UpdatedAt: now},
+	}
+	listByUsersCalls := 0
+	userService := &mockDmUserService{
+		listFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
+			assert.Equal(t, 2, limit)
+			return users, nil
+		},
+	}
+	postService := &mockDmPostService{
+		listByUsersFunc: func(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+			listByUsersCalls++
+			assert.ElementsMatch(t, []string{users[0].ID, users[1].ID}, userIDs)
+			assert.Equal(t, 3, limit)
+			return map[string][]*model.DmPost{
+				users[0].ID: {{ID: testID("p", 1), UserID: users[0].ID, Title: "Hello", CreatedAt: now, UpdatedAt: now}},
+			}, nil
+		},
+	}
+	executor := newTestExecutor(t, userService, postService, &mockDmNewsService{}, nil)
+
+	result, err := executor.Execute(context.Background(), &Request{
+		Query: `query { users(limit: 2) { name posts(limit: 3) { title } } }`,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"users":[{"name":"Alice","posts":[{"title":"Hello"}]},{"name":"Bob","posts":[]}]}`, resultJSON(t, result.Data))
+	assert.Equal(t, 1, listByUsersCalls)
+}
+
+func TestExecutor_PostsWithUserBatched(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	userID := testID("u", 1)
+	batchGetCalls := 0
+	userService := &mockDmUserService{
+		batchGetFunc: func(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+			batchGetCalls++
+			// 同じユーザーは1件にまとめられる
+			assert.Equal(t, []string{userID, testID("u", 9)}, ids)
+			return &model.DmUserBatchGetResult{
+				Items:   []*model.DmUser{{ID: userID, Name: "Alice", CreatedAt: now, UpdatedAt: now}},
+				Missing: []string{testID("u", 9)},
+			}, nil
+		},
+	}
+	postService := &mockDmPostService{
+		listFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+			assert.Equal(t, "-created_at", sort)
+			return []*model.DmPost{
+				{ID: testID("p", 1), UserID: userID, CreatedAt: now, UpdatedAt: now},
+				{ID: testID("p", 2), UserID: userID, CreatedAt: now, UpdatedAt: now},
+				{ID: testID("p", 3), UserID: testID("u", 9), CreatedAt: now, UpdatedAt: now},
+			}, nil
+		},
+	}
+	executor := newTestExecutor(t, userService, postService, &mockDmNewsService{}, nil)
+
+	result, err := executor.Execute(context.Background(), &Request{
+		Query:     `query Posts($sort: String) { posts(sort: $sort) { id user { name } } }`,
+		Variables: map[string]interface{}{"sort": "-created_at"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{"posts":[{"id":%q,"user":{"name":"Alice"}},{"id":%q,"user":{"name":"Alice"}},{"id":%q,"user":null}]}`,
+		testID("p", 1), testID("p", 2), testID("p", 3)), resultJSON(t, result.Data))
+	assert.Equal(t, 1, batchGetCalls)
+}
+
+func TestExecutor_News(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	authorID := int64(7)
+	newsService := &mockDmNewsService{
+		getFunc: func(ctx context.Context, id int64) (*model.DmNews, error) {
+			if id != 1 {
+				return nil, fmt.Errorf("news not found: %d", id)
+			}
+			return &model.DmNews{ID: 1, Title: "News", AuthorID: &authorID, PublishedAt: &now, CreatedAt: now, UpdatedAt: now}, nil
+		},
+	}
+	executor := newTestExecutor(t, &mockDmUserService{}, &mockDmPostService{}, newsService, nil)
+
+	result, err := executor.Execute(context.Background(), &Request{
+		Query: `{ found: news(id: "1") { id title authorId publishedAt } missing: news(id: "2") { id } }`,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"found":{"id":"1","title":"News","authorId":"7","publishedAt":"2026-01-01T00:00:00Z"},"missing":null}`, resultJSON(t, result.Data))
+}
+
+func TestExecutor_InvalidArgument(t *testing.T) {
+	executor := newTestExecutor(t, &mockDmUserService{}, &mockDmPostService{}, &mockDmNewsService{}, nil)
+
+	result, err := executor.Execute(context.Background(), &Request{Query: `{ user(id: "short") { id } }`})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "must be 32 characters")
+
+	result, err = executor.Execute(context.Background(), &Request{Query: `{ users(limit: 101) { id } }`})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "limit must be between 1 and 100")
+}
+
+func TestExecutor_InvalidQuery(t *testing.T) {
+	executor := newTestExecutor(t, &mockDmUserService{}, &mockDmPostService{}, &mockDmNewsService{}, nil)
+
+	// 構文エラー
+	result, err := executor.Execute(context.Background(), &Request{Query: `{ users { id }`})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	assert.NotEmpty(t, result.Errors)
+
+	// 存在しないフィールド
+	result, err = executor.Execute(context.Background(), &Request{Query: `{ users { password } }`})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	assert.NotEmpty(t, result.Errors)
+}
+
+func TestExecutor_MaxDepth(t *testing.T) {
+	executor := newTestExecutor(t, &mockDmUserService{}, &mockDmPostService{}, &mockDmNewsService{},
+		&config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 100000})
+
+	result, err := executor.Execute(context.Background(), &Request{
+		Query: `{ users(limit: 1) { posts(limit: 1) { user { posts(limit: 1) { title } } } } }`,
+	})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "query depth 5 exceeds the maximum of 4")
+}
+
+func TestExecutor_MaxComplexity(t *testing.T) {
+	executor := newTestExecutor(t, &mockDmUserService{}, &mockDmPostService{}, &mockDmNewsService{},
+		&config.GraphQLConfig{MaxDepth: 10, MaxComplexity: 500})
+
+	// users(100) × posts(省略時20) × 1フィールド = 1 + 100 * (1 + 20 * 1) = 2101
+	result, err := executor.Execute(context.Background(), &Request{
+		Query:     `query Users($limit: Int) { users(limit: $limit) { ...userPosts } } fragment userPosts on DmUser { posts { title } }`,
+		Variables: map[string]interface{}{"limit": float64(100)},
+	})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "query complexity 2101 exceeds the maximum of 500")
+}
+
+func TestAnalyzeQueryCost_IgnoresIntrospection(t *testing.T) {
+	executor := newTestExecutor(t, &mockDmUserService{}, &mockDmPostService{}, &mockDmNewsService{},
+		&config.GraphQLConfig{MaxDepth: 2, MaxComplexity: 10})
+
+	result, err := executor.Execute(context.Background(), &Request{
+		Query: `{ __schema { types { name fields { name type { name ofType { name } } } } } }`,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+}
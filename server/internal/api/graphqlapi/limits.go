@@ -0,0 +1,167 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// queryCost はクエリの深さと複雑度
+type queryCost struct {
+	depth      int
+	complexity int
+}
+
+// costAnalyzer はクエリの深さと複雑度を計算する
+// 複雑度はフィールドごとに1、リストを返すフィールドは子フィールドの複雑度にlimit引数（省略時はデフォルト値）を掛けたもの
+// イントロスペクション（__で始まるフィールド）は計算に含めない
+type costAnalyzer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// analyzeQueryCost は実行するオペレーションの深さと複雑度を計算
+// 検証済み（フラグメントの循環がない）のドキュメントを渡すこと
+func analyzeQueryCost(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) (queryCost, error) {
+	a := &costAnalyzer{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		return queryCost{}, fmt.Errorf("unknown operation named %q", operationName)
+	}
+
+	return a.selectionSet(operation.SelectionSet, schema.QueryType()), nil
+}
+
+// selectionSet は選択セットの深さ（最も深いフィールド）と複雑度（全フィールドの合計）を計算
+func (a *costAnalyzer) selectionSet(set *ast.SelectionSet, parent graphql.Type) queryCost {
+	var cost queryCost
+	if set == nil {
+		return cost
+	}
+
+	for _, selection := range set.Selections {
+		var sub queryCost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			sub = a.field(selection, parent)
+		case *ast.InlineFragment:
+			sub = a.selectionSet(selection.SelectionSet, a.typeCondition(selection.TypeCondition, parent))
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				sub = a.selectionSet(fragment.SelectionSet, a.typeCondition(fragment.TypeCondition, parent))
+			}
+		}
+		cost.depth = max(cost.depth, sub.depth)
+		cost.complexity += sub.complexity
+	}
+	return cost
+}
+
+// field はフィールドの深さと複雑度を計算
+func (a *costAnalyzer) field(field *ast.Field, parent graphql.Type) queryCost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return queryCost{}
+	}
+
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return queryCost{depth: 1, complexity: 1}
+	}
+	def, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return queryCost{depth: 1, complexity: 1}
+	}
+
+	children := a.selectionSet(field.SelectionSet, namedType(def.Type))
+	multiplier := 1
+	if isListType(def.Type) {
+		multiplier = a.limitArgument(field, def)
+	}
+	return queryCost{
+		depth:      children.depth + 1,
+		complexity: 1 + children.complexity*multiplier,
+	}
+}
+
+// limitArgument はリストを返すフィールドのlimit引数の値を返す（limit引数がない場合は1）
+func (a *costAnalyzer) limitArgument(field *ast.Field, def *graphql.FieldDefinition) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				return max(n, 1)
+			}
+		case *ast.Variable:
+			switch n := a.variables[value.Name.Value].(type) {
+			case int:
+				return max(n, 1)
+			case float64:
+				return max(int(n), 1)
+			}
+		}
+	}
+	for _, arg := range def.Args {
+		if arg.Name() == "limit" {
+			if n, ok := arg.DefaultValue.(int); ok {
+				return max(n, 1)
+			}
+		}
+	}
+	return 1
+}
+
+// typeCondition はフラグメントの型条件の型を返す（型条件がない場合は親の型）
+func (a *costAnalyzer) typeCondition(named *ast.Named, parent graphql.Type) graphql.Type {
+	if named == nil {
+		return parent
+	}
+	if t := a.schema.Type(named.Name.Value); t != nil {
+		return t
+	}
+	return parent
+}
+
+// namedType はNonNull・Listを外した型を返す
+func namedType(t graphql.Type) graphql.Type {
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// isListType はリストを返す型か判定
+func isListType(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
@@ -0,0 +1,145 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// batchLoader はリクエスト内の取得をまとめるデータローダー
+// loadはキーを予約してサンクを返し、最初にサンクが呼ばれた時点で予約済みのキーをまとめて取得する
+// graphql-goはリスト内の各要素のフィールドを解決してからサンクを呼ぶため、同じ階層の取得が1回にまとまる
+type batchLoader[K comparable, V any] struct {
+	mu       sync.Mutex
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	maxBatch int
+	pending  []K
+	entries  map[K]*loaderEntry[V]
+}
+
+type loaderEntry[V any] struct {
+	value V
+	err   error
+	done  bool
+}
+
+func newBatchLoader[K comparable, V any](maxBatch int, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{
+		fetch:    fetch,
+		maxBatch: maxBatch,
+		entries:  make(map[K]*loaderEntry[V]),
+	}
+}
+
+// load はキーを予約し、値を返すサンクを返す（取得済みのキーはキャッシュを返す）
+// 見つからなかったキーの値はゼロ値になる
+func (l *batchLoader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &loaderEntry[V]{}
+		l.entries[key] = entry
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !entry.done {
+			l.flush(ctx)
+		}
+		return entry.value, entry.err
+	}
+}
+
+// flush は予約済みのキーをmaxBatch件ずつまとめて取得（ロックを保持して呼び出す）
+func (l *batchLoader[K, V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	for start := 0; start < len(keys); start += l.maxBatch {
+		end := min(start+l.maxBatch, len(keys))
+		batch := keys[start:end]
+
+		values, err := l.fetch(ctx, batch)
+		for _, key := range batch {
+			entry := l.entries[key]
+			entry.value, entry.err, entry.done = values[key], err, true
+		}
+	}
+}
+
+// userPostsKey はユーザーの投稿一覧のキー（件数ごとにまとめて取得する）
+type userPostsKey struct {
+	userID string
+	limit  int
+}
+
+// loaders はリクエストごとのデータローダー
+type loaders struct {
+	users     *batchLoader[string, *model.DmUser]
+	posts     *batchLoader[model.DmPostKey, *model.DmPost]
+	userPosts *batchLoader[userPostsKey, []*model.DmPost]
+}
+
+// newLoaders はユースケースの一括取得を使ったデータローダーを作成
+// 一括取得はシャーディングテーブルごとに1回のクエリにまとめられる
+func newLoaders(dmUserUsecase *usecaseapi.DmUserUsecase, dmPostUsecase *usecaseapi.DmPostUsecase) *loaders {
+	return &loaders{
+		users: newBatchLoader(model.DmBatchGetMaxIDs, func(ctx context.Context, ids []string) (map[string]*model.DmUser, error) {
+			result, err := dmUserUsecase.BatchGetDmUsers(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			found := make(map[string]*model.DmUser, len(result.Items))
+			for _, dmUser := range result.Items {
+				found[dmUser.ID] = dmUser
+			}
+			return found, nil
+		}),
+		posts: newBatchLoader(model.DmBatchGetMaxIDs, func(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error) {
+			result, err := dmPostUsecase.BatchGetDmPosts(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+			found := make(map[model.DmPostKey]*model.DmPost, len(result.Items))
+			for _, dmPost := range result.Items {
+				found[model.DmPostKey{ID: dmPost.ID, UserID: dmPost.UserID}] = dmPost
+			}
+			return found, nil
+		}),
+		userPosts: newBatchLoader(model.DmBatchGetMaxIDs, func(ctx context.Context, keys []userPostsKey) (map[userPostsKey][]*model.DmPost, error) {
+			// 件数ごとにユーザーIDをまとめて取得
+			userIDsByLimit := make(map[int][]string)
+			for _, key := range keys {
+				userIDsByLimit[key.limit] = append(userIDsByLimit[key.limit], key.userID)
+			}
+			found := make(map[userPostsKey][]*model.DmPost, len(keys))
+			for limit, userIDs := range userIDsByLimit {
+				dmPosts, err := dmPostUsecase.ListDmPostsByUsers(ctx, userIDs, limit)
+				if err != nil {
+					return nil, err
+				}
+				for _, userID := range userIDs {
+					found[userPostsKey{userID: userID, limit: limit}] = dmPosts[userID]
+				}
+			}
+			return found, nil
+		}),
+	}
+}
+
+type loadersContextKey struct{}
+
+// withLoaders はデータローダーをコンテキストに設定
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, l)
+}
+
+// loadersFrom はコンテキストからデータローダーを取得
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersContextKey{}).(*loaders)
+}
@@ -0,0 +1,289 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// 一覧取得の取得件数（RESTのlimitパラメータと同じ）
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// newSchema はユーザー・投稿・ニュースのGraphQLスキーマを作成
+// 個別取得とネストしたフィールド（user.posts、post.user）はデータローダー経由で一括取得する
+func newSchema(dmUserUsecase *usecaseapi.DmUserUsecase, dmPostUsecase *usecaseapi.DmPostUsecase, dmNewsUsecase *usecaseapi.DmNewsUsecase) (graphql.Schema, error) {
+	var dmUserType, dmPostType *graphql.Object
+
+	dmUserType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "DmUser",
+		Description: "ユーザー",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        stringField(graphql.ID, func(u *model.DmUser) string { return u.ID }),
+				"name":      stringField(graphql.String, func(u *model.DmUser) string { return u.Name }),
+				"email":     stringField(graphql.String, func(u *model.DmUser) string { return u.Email }),
+				"createdAt": timeField(func(u *model.DmUser) interface{} { return u.CreatedAt }),
+				"updatedAt": timeField(func(u *model.DmUser) interface{} { return u.UpdatedAt }),
+				"posts": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dmPostType))),
+					Description: "ユーザーの投稿（作成日時の新しい順）",
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListLimit},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, _, err := listRange(p.Args)
+						if err != nil {
+							return nil, err
+						}
+						u := p.Source.(*model.DmUser)
+						return loadersFrom(p.Context).userPosts.load(p.Context, userPostsKey{userID: u.ID, limit: limit}), nil
+					},
+				},
+			}
+		}),
+	})
+
+	dmPostType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "DmPost",
+		Description: "投稿",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        stringField(graphql.ID, func(p *model.DmPost) string { return p.ID }),
+				"userId":    stringField(graphql.ID, func(p *model.DmPost) string { return p.UserID }),
+				"title":     stringField(graphql.String, func(p *model.DmPost) string { return p.Title }),
+				"content":   stringField(graphql.String, func(p *model.DmPost) string { return p.Content }),
+				"createdAt": timeField(func(p *model.DmPost) interface{} { return p.CreatedAt }),
+				"updatedAt": timeField(func(p *model.DmPost) interface{} { return p.UpdatedAt }),
+				"user": &graphql.Field{
+					Type:        dmUserType,
+					Description: "投稿したユーザー",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						post := p.Source.(*model.DmPost)
+						return loadersFrom(p.Context).users.load(p.Context, post.UserID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	dmNewsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "DmNews",
+		Description: "公開済みのニュース",
+		Fields: graphql.Fields{
+			"id":      stringField(graphql.ID, func(n *model.DmNews) string { return strconv.FormatInt(n.ID, 10) }),
+			"title":   stringField(graphql.String, func(n *model.DmNews) string { return n.Title }),
+			"content": stringField(graphql.String, func(n *model.DmNews) string { return n.Content }),
+			"authorId": &graphql.Field{
+				Type: graphql.ID,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					n := p.Source.(*model.DmNews)
+					if n.AuthorID == nil {
+						return nil, nil
+					}
+					return strconv.FormatInt(*n.AuthorID, 10), nil
+				},
+			},
+			"publishedAt": &graphql.Field{
+				Type: graphql.DateTime,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					n := p.Source.(*model.DmNews)
+					if n.PublishedAt == nil {
+						return nil, nil
+					}
+					return *n.PublishedAt, nil
+				},
+			},
+			"createdAt": timeField(func(n *model.DmNews) interface{} { return n.CreatedAt }),
+			"updatedAt": timeField(func(n *model.DmNews) interface{} { return n.UpdatedAt }),
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type:        dmUserType,
+				Description: "ユーザーを取得（存在しない場合はnull）",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArgument(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return loadersFrom(p.Context).users.load(p.Context, id), nil
+				},
+			},
+			"users": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dmUserType))),
+				Description: "ユーザー一覧を取得",
+				Args:        listArguments(true),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset, err := listRange(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					filter, sort := listQuery(p.Args)
+					return dmUserUsecase.ListDmUsers(p.Context, limit, offset, filter, sort)
+				},
+			},
+			"post": &graphql.Field{
+				Type:        dmPostType,
+				Description: "投稿を取得（存在しない場合はnull）",
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArgument(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					userID, err := idArgument(p.Args, "userId")
+					if err != nil {
+						return nil, err
+					}
+					return loadersFrom(p.Context).posts.load(p.Context, model.DmPostKey{ID: id, UserID: userID}), nil
+				},
+			},
+			"posts": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dmPostType))),
+				Description: "投稿一覧を取得（userIdを指定した場合はそのユーザーの投稿のみ）",
+				Args: func() graphql.FieldConfigArgument {
+					args := listArguments(true)
+					args["userId"] = &graphql.ArgumentConfig{Type: graphql.ID}
+					return args
+				}(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset, err := listRange(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					filter, sort := listQuery(p.Args)
+					if _, ok := p.Args["userId"]; ok {
+						userID, err := idArgument(p.Args, "userId")
+						if err != nil {
+							return nil, err
+						}
+						return dmPostUsecase.ListDmPostsByUser(p.Context, userID, limit, offset, filter, sort)
+					}
+					return dmPostUsecase.ListDmPosts(p.Context, limit, offset, filter, sort)
+				},
+			},
+			"news": &graphql.Field{
+				Type:        dmNewsType,
+				Description: "公開済みのニュースを取得（存在しない場合はnull）",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := strconv.ParseInt(p.Args["id"].(string), 10, 64)
+					if err != nil || id < 1 {
+						return nil, fmt.Errorf("invalid id format: must be a positive integer")
+					}
+					// RESTの取得と同様に、取得できない場合は存在しないものとして扱う
+					dmNews, err := dmNewsUsecase.GetPublishedDmNews(p.Context, id)
+					if err != nil {
+						return nil, nil
+					}
+					return dmNews, nil
+				},
+			},
+			"newsList": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dmNewsType))),
+				Description: "公開済みのニュース一覧を取得（公開日時の新しい順）",
+				Args: func() graphql.FieldConfigArgument {
+					args := listArguments(false)
+					args["authorId"] = &graphql.ArgumentConfig{Type: graphql.ID}
+					return args
+				}(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset, err := listRange(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					var authorID *int64
+					if v, ok := p.Args["authorId"]; ok {
+						id, err := strconv.ParseInt(v.(string), 10, 64)
+						if err != nil || id < 1 {
+							return nil, fmt.Errorf("invalid authorId format: must be a positive integer")
+						}
+						authorID = &id
+					}
+					return dmNewsUsecase.ListPublishedDmNews(p.Context, authorID, limit, offset)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// stringField はモデルの文字列を返すNonNullのフィールドを作成
+func stringField[T any](t graphql.Type, get func(*T) string) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(t),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*T)), nil
+		},
+	}
+}
+
+// timeField はモデルの日時を返すNonNullのフィールドを作成
+func timeField[T any](get func(*T) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(graphql.DateTime),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*T)), nil
+		},
+	}
+}
+
+// listArguments は一覧取得の引数（withListQueryがtrueの場合はfilter・sortを含む）
+func listArguments(withListQuery bool) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListLimit},
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+	}
+	if withListQuery {
+		args["filter"] = &graphql.ArgumentConfig{Type: graphql.String, Description: "フィルタ条件（field:op:value をカンマ区切り）"}
+		args["sort"] = &graphql.ArgumentConfig{Type: graphql.String, Description: "並び順（field または -field をカンマ区切り）"}
+	}
+	return args
+}
+
+// listRange は取得件数とオフセットを検証
+func listRange(args map[string]interface{}) (int, int, error) {
+	limit, _ := args["limit"].(int)
+	offset, _ := args["offset"].(int)
+	if limit < 1 || limit > maxListLimit {
+		return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxListLimit)
+	}
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("offset must be greater than or equal to 0")
+	}
+	return limit, offset, nil
+}
+
+// listQuery はfilter・sortの引数を返す（省略時は空文字）
+func listQuery(args map[string]interface{}) (string, string) {
+	filter, _ := args["filter"].(string)
+	sort, _ := args["sort"].(string)
+	return filter, sort
+}
+
+// idArgument はUUID文字列の引数を検証（32文字であること）
+func idArgument(args map[string]interface{}, name string) (string, error) {
+	id, _ := args[name].(string)
+	if len(id) != 32 {
+		return "", fmt.Errorf("invalid %s format: must be 32 characters", name)
+	}
+	return id, nil
+}
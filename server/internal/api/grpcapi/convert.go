@@ -0,0 +1,173 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	dmv1 "github.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 一覧取得の取得件数（RESTのlimitパラメータと同じ）
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// checkAccessLevel は公開レベルのチェック（RESTのエンドポイントと同じルール）
+func checkAccessLevel(ctx context.Context, endpointLevel auth.AccessLevel) error {
+	if err := auth.CheckAccessLevel(ctx, endpointLevel); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// validateID はUUID文字列のバリデーション（32文字であること）
+func validateID(field, id string) error {
+	if len(id) != 32 {
+		return status.Errorf(codes.InvalidArgument, "invalid %s format: must be 32 characters", field)
+	}
+	return nil
+}
+
+// validateRequest はリクエストをvalidateタグとカスタムルールで検証（サービス層と同じ検証）
+func validateRequest(req interface{}) error {
+	if err := validation.Struct(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// listRange は取得件数とオフセットを検証し、省略時のデフォルト値を適用
+// 一覧は各シャードテーブルからoffset+limit件を取得して並べ替えるため、offset+limitはmodel.ListMaxWindow以下
+func listRange(limit, offset int32) (int, int, error) {
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit < 1 || limit > maxListLimit {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxListLimit)
+	}
+	if offset < 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "offset must be greater than or equal to 0")
+	}
+	if int(offset)+int(limit) > model.ListMaxWindow {
+		return 0, 0, status.Error(codes.InvalidArgument, fmt.Sprintf("offset + limit must be less than or equal to %d", model.ListMaxWindow))
+	}
+	return int(limit), int(offset), nil
+}
+
+// searchQuery は全文検索の条件を検証し、省略時のデフォルト値を適用
+func searchQuery(req *dmv1.SearchDmPostsRequest) (*model.DmPostSearchQuery, error) {
+	if strings.TrimSpace(req.GetQ()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "q must not be blank")
+	}
+
+	mode := req.GetMode()
+	switch mode {
+	case "":
+		mode = model.DmPostSearchModeWord
+	case model.DmPostSearchModeWord, model.DmPostSearchModeNgram:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "mode must be %s or %s", model.DmPostSearchModeWord, model.DmPostSearchModeNgram)
+	}
+
+	sort := req.GetSort()
+	switch sort {
+	case "":
+		sort = model.DmPostSearchSortRelevance
+	case model.DmPostSearchSortRelevance, model.DmPostSearchSortDate:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "sort must be %s or %s", model.DmPostSearchSortRelevance, model.DmPostSearchSortDate)
+	}
+
+	limit, offset, err := listRange(req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	if offset+limit > model.DmPostSearchMaxWindow {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("offset + limit must be less than or equal to %d", model.DmPostSearchMaxWindow))
+	}
+
+	return &model.DmPostSearchQuery{
+		Query:  req.GetQ(),
+		Mode:   mode,
+		Sort:   sort,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// toStatusError はユースケースのエラーをエラーの種類（apperror）に応じたgRPCのステータスに変換
+// 種類がないエラーはInternalとし、production環境では内部の詳細を含めない
+func toStatusError(err error) error {
+	switch apperror.KindOf(err) {
+	case apperror.ErrForbidden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case apperror.ErrNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case apperror.ErrConflict:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case apperror.ErrValidation:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case apperror.ErrUnavailable:
+		return status.Error(codes.Unavailable, err.Error())
+	case apperror.ErrTimeout:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if os.Getenv("APP_ENV") == "production" {
+		return status.Error(codes.Internal, "internal error")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// toDmUser はユーザーをprotobufのメッセージに変換
+func toDmUser(u *model.DmUser) *dmv1.DmUser {
+	return &dmv1.DmUser{
+		Id:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
+
+// toDmPost は投稿をprotobufのメッセージに変換
+func toDmPost(p *model.DmPost) *dmv1.DmPost {
+	return &dmv1.DmPost{
+		Id:        p.ID,
+		UserId:    p.UserID,
+		Title:     p.Title,
+		Content:   p.Content,
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+}
+
+// toDmUserPost はユーザーと投稿のJOIN結果をprotobufのメッセージに変換
+func toDmUserPost(up *model.DmUserPost) *dmv1.DmUserPost {
+	return &dmv1.DmUserPost{
+		PostId:      up.PostID,
+		PostTitle:   up.PostTitle,
+		PostContent: up.PostContent,
+		UserId:      up.UserID,
+		UserName:    up.UserName,
+		UserEmail:   up.UserEmail,
+		CreatedAt:   timestamppb.New(up.CreatedAt),
+	}
+}
+
+// toDmPostKey は投稿のキーをprotobufのメッセージに変換
+func toDmPostKey(k model.DmPostKey) *dmv1.DmPostKey {
+	return &dmv1.DmPostKey{
+		Id:     k.ID,
+		UserId: k.UserID,
+	}
+}
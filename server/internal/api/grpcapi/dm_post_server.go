@@ -0,0 +1,241 @@
+package grpcapi
+
+import (
+	"context"
+
+	dmv1 "github.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+	"google.golang.org/grpc"
+)
+
+// DmPostServer は投稿のgRPCサービス
+// RESTの投稿APIと同じユースケースを使用し、公開レベルも同じ（public）
+type DmPostServer struct {
+	dmv1.UnimplementedDmPostServiceServer
+	dmPostUsecase *usecaseapi.DmPostUsecase
+}
+
+// NewDmPostServer は新しいDmPostServerを作成
+func NewDmPostServer(dmPostUsecase *usecaseapi.DmPostUsecase) *DmPostServer {
+	return &DmPostServer{
+		dmPostUsecase: dmPostUsecase,
+	}
+}
+
+// CreateDmPost は投稿を作成
+func (s *DmPostServer) CreateDmPost(ctx context.Context, req *dmv1.CreateDmPostRequest) (*dmv1.DmPost, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validateID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	createReq := &model.CreateDmPostRequest{
+		UserID:  req.GetUserId(),
+		Title:   req.GetTitle(),
+		Content: req.GetContent(),
+	}
+	if err := validateRequest(createReq); err != nil {
+		return nil, err
+	}
+
+	dmPost, err := s.dmPostUsecase.CreateDmPost(ctx, createReq)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmPost(dmPost), nil
+}
+
+// GetDmPost は投稿を取得
+func (s *DmPostServer) GetDmPost(ctx context.Context, req *dmv1.GetDmPostRequest) (*dmv1.DmPost, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validatePostKey(req.GetId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	dmPost, err := s.dmPostUsecase.GetDmPost(ctx, req.GetId(), req.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmPost(dmPost), nil
+}
+
+// BatchGetDmPosts は投稿を一括取得
+func (s *DmPostServer) BatchGetDmPosts(ctx context.Context, req *dmv1.BatchGetDmPostsRequest) (*dmv1.BatchGetDmPostsResponse, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+
+	keys := make([]model.DmPostKey, 0, len(req.GetKeys()))
+	for _, key := range req.GetKeys() {
+		keys = append(keys, model.DmPostKey{ID: key.GetId(), UserID: key.GetUserId()})
+	}
+
+	result, err := s.dmPostUsecase.BatchGetDmPosts(ctx, keys)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &dmv1.BatchGetDmPostsResponse{
+		Items:   make([]*dmv1.DmPost, 0, len(result.Items)),
+		Missing: make([]*dmv1.DmPostKey, 0, len(result.Missing)),
+	}
+	for _, dmPost := range result.Items {
+		resp.Items = append(resp.Items, toDmPost(dmPost))
+	}
+	for _, key := range result.Missing {
+		resp.Missing = append(resp.Missing, toDmPostKey(key))
+	}
+	return resp, nil
+}
+
+// ListDmPosts は投稿一覧を1件ずつストリームで返す（user_idを指定した場合はそのユーザーの投稿のみ）
+func (s *DmPostServer) ListDmPosts(req *dmv1.ListDmPostsRequest, stream grpc.ServerStreamingServer[dmv1.DmPost]) error {
+	ctx := stream.Context()
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return err
+	}
+
+	limit, offset, err := listRange(req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return err
+	}
+
+	var dmPosts []*model.DmPost
+	if req.GetUserId() != "" {
+		if err := validateID("user_id", req.GetUserId()); err != nil {
+			return err
+		}
+		dmPosts, err = s.dmPostUsecase.ListDmPostsByUser(ctx, req.GetUserId(), limit, offset, req.GetFilter(), req.GetSort())
+	} else {
+		dmPosts, err = s.dmPostUsecase.ListDmPosts(ctx, limit, offset, req.GetFilter(), req.GetSort())
+	}
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	for _, dmPost := range dmPosts {
+		if err := stream.Send(toDmPost(dmPost)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListDmUserPosts はユーザーと投稿のJOIN結果を1件ずつストリームで返す
+func (s *DmPostServer) ListDmUserPosts(req *dmv1.ListDmUserPostsRequest, stream grpc.ServerStreamingServer[dmv1.DmUserPost]) error {
+	ctx := stream.Context()
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return err
+	}
+
+	limit, offset, err := listRange(req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return err
+	}
+
+	dmUserPosts, err := s.dmPostUsecase.GetDmUserPosts(ctx, limit, offset)
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	for _, dmUserPost := range dmUserPosts {
+		if err := stream.Send(toDmUserPost(dmUserPost)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchDmPosts は全文検索の結果を関連度順または新しい順に1件ずつストリームで返す
+func (s *DmPostServer) SearchDmPosts(req *dmv1.SearchDmPostsRequest, stream grpc.ServerStreamingServer[dmv1.DmPostSearchHit]) error {
+	ctx := stream.Context()
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return err
+	}
+
+	query, err := searchQuery(req)
+	if err != nil {
+		return err
+	}
+
+	hits, err := s.dmPostUsecase.SearchDmPosts(ctx, query)
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	for _, hit := range hits {
+		if err := stream.Send(&dmv1.DmPostSearchHit{Post: toDmPost(&hit.DmPost), Score: hit.Score}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateDmPost は投稿を更新
+func (s *DmPostServer) UpdateDmPost(ctx context.Context, req *dmv1.UpdateDmPostRequest) (*dmv1.DmPost, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validatePostKey(req.GetId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	updateReq := &model.UpdateDmPostRequest{
+		Title:   req.GetTitle(),
+		Content: req.GetContent(),
+	}
+	if err := validateRequest(updateReq); err != nil {
+		return nil, err
+	}
+
+	dmPost, err := s.dmPostUsecase.UpdateDmPost(ctx, req.GetId(), req.GetUserId(), updateReq)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmPost(dmPost), nil
+}
+
+// PatchDmPost は投稿を部分更新（JSON Merge Patch）
+func (s *DmPostServer) PatchDmPost(ctx context.Context, req *dmv1.PatchDmPostRequest) (*dmv1.DmPost, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validatePostKey(req.GetId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	dmPost, err := s.dmPostUsecase.PatchDmPost(ctx, req.GetId(), req.GetUserId(), []byte(req.GetMergePatch()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmPost(dmPost), nil
+}
+
+// DeleteDmPost は投稿を削除
+func (s *DmPostServer) DeleteDmPost(ctx context.Context, req *dmv1.DeleteDmPostRequest) (*dmv1.DeleteDmPostResponse, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validatePostKey(req.GetId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.dmPostUsecase.DeleteDmPost(ctx, req.GetId(), req.GetUserId()); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &dmv1.DeleteDmPostResponse{}, nil
+}
+
+// validatePostKey は投稿IDとユーザーIDのバリデーション
+func validatePostKey(id, userID string) error {
+	if err := validateID("id", id); err != nil {
+		return err
+	}
+	return validateID("user_id", userID)
+}
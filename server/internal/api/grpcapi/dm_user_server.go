@@ -0,0 +1,163 @@
+package grpcapi
+
+import (
+	"context"
+
+	dmv1 "github.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+	"google.golang.org/grpc"
+)
+
+// DmUserServer はユーザーのgRPCサービス
+// RESTのユーザーAPIと同じユースケースを使用し、公開レベルも同じ（public）
+type DmUserServer struct {
+	dmv1.UnimplementedDmUserServiceServer
+	dmUserUsecase *usecaseapi.DmUserUsecase
+}
+
+// NewDmUserServer は新しいDmUserServerを作成
+func NewDmUserServer(dmUserUsecase *usecaseapi.DmUserUsecase) *DmUserServer {
+	return &DmUserServer{
+		dmUserUsecase: dmUserUsecase,
+	}
+}
+
+// CreateDmUser はユーザーを作成
+func (s *DmUserServer) CreateDmUser(ctx context.Context, req *dmv1.CreateDmUserRequest) (*dmv1.DmUser, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+
+	createReq := &model.CreateDmUserRequest{
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+	}
+	if err := validateRequest(createReq); err != nil {
+		return nil, err
+	}
+
+	dmUser, err := s.dmUserUsecase.CreateDmUser(ctx, createReq)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmUser(dmUser), nil
+}
+
+// GetDmUser はユーザーを取得
+func (s *DmUserServer) GetDmUser(ctx context.Context, req *dmv1.GetDmUserRequest) (*dmv1.DmUser, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validateID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	dmUser, err := s.dmUserUsecase.GetDmUser(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmUser(dmUser), nil
+}
+
+// BatchGetDmUsers はユーザーを一括取得
+func (s *DmUserServer) BatchGetDmUsers(ctx context.Context, req *dmv1.BatchGetDmUsersRequest) (*dmv1.BatchGetDmUsersResponse, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+
+	result, err := s.dmUserUsecase.BatchGetDmUsers(ctx, req.GetIds())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &dmv1.BatchGetDmUsersResponse{
+		Items:   make([]*dmv1.DmUser, 0, len(result.Items)),
+		Missing: result.Missing,
+	}
+	for _, dmUser := range result.Items {
+		resp.Items = append(resp.Items, toDmUser(dmUser))
+	}
+	return resp, nil
+}
+
+// ListDmUsers はユーザー一覧を1件ずつストリームで返す
+func (s *DmUserServer) ListDmUsers(req *dmv1.ListDmUsersRequest, stream grpc.ServerStreamingServer[dmv1.DmUser]) error {
+	ctx := stream.Context()
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return err
+	}
+
+	limit, offset, err := listRange(req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return err
+	}
+
+	dmUsers, err := s.dmUserUsecase.ListDmUsers(ctx, limit, offset, req.GetFilter(), req.GetSort())
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	for _, dmUser := range dmUsers {
+		if err := stream.Send(toDmUser(dmUser)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateDmUser はユーザーを更新
+func (s *DmUserServer) UpdateDmUser(ctx context.Context, req *dmv1.UpdateDmUserRequest) (*dmv1.DmUser, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validateID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	updateReq := &model.UpdateDmUserRequest{
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+	}
+	if err := validateRequest(updateReq); err != nil {
+		return nil, err
+	}
+
+	dmUser, err := s.dmUserUsecase.UpdateDmUser(ctx, req.GetId(), updateReq)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmUser(dmUser), nil
+}
+
+// PatchDmUser はユーザーを部分更新（JSON Merge Patch）
+func (s *DmUserServer) PatchDmUser(ctx context.Context, req *dmv1.PatchDmUserRequest) (*dmv1.DmUser, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validateID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	dmUser, err := s.dmUserUsecase.PatchDmUser(ctx, req.GetId(), []byte(req.GetMergePatch()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toDmUser(dmUser), nil
+}
+
+// DeleteDmUser はユーザーを削除
+func (s *DmUserServer) DeleteDmUser(ctx context.Context, req *dmv1.DeleteDmUserRequest) (*dmv1.DeleteDmUserResponse, error) {
+	if err := checkAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, err
+	}
+	if err := validateID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.dmUserUsecase.DeleteDmUser(ctx, req.GetId()); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &dmv1.DeleteDmUserResponse{}, nil
+}
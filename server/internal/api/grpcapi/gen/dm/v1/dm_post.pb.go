@@ -0,0 +1,1119 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: dm/v1/dm_post.proto
+
+package dmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DmPost は投稿（ID, UserIDはUUIDv7形式の32文字の16進数文字列）
+type DmPost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DmPost) Reset() {
+	*x = DmPost{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DmPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DmPost) ProtoMessage() {}
+
+func (x *DmPost) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DmPost.ProtoReflect.Descriptor instead.
+func (*DmPost) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DmPost) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DmPost) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DmPost) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DmPost) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *DmPost) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DmPost) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// DmPostKey は投稿を特定するキー
+type DmPostKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DmPostKey) Reset() {
+	*x = DmPostKey{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DmPostKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DmPostKey) ProtoMessage() {}
+
+func (x *DmPostKey) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DmPostKey.ProtoReflect.Descriptor instead.
+func (*DmPostKey) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DmPostKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DmPostKey) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// DmUserPost はユーザーと投稿のJOIN結果
+type DmUserPost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	PostTitle     string                 `protobuf:"bytes,2,opt,name=post_title,json=postTitle,proto3" json:"post_title,omitempty"`
+	PostContent   string                 `protobuf:"bytes,3,opt,name=post_content,json=postContent,proto3" json:"post_content,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName      string                 `protobuf:"bytes,5,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	UserEmail     string                 `protobuf:"bytes,6,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DmUserPost) Reset() {
+	*x = DmUserPost{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DmUserPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DmUserPost) ProtoMessage() {}
+
+func (x *DmUserPost) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DmUserPost.ProtoReflect.Descriptor instead.
+func (*DmUserPost) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DmUserPost) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *DmUserPost) GetPostTitle() string {
+	if x != nil {
+		return x.PostTitle
+	}
+	return ""
+}
+
+func (x *DmUserPost) GetPostContent() string {
+	if x != nil {
+		return x.PostContent
+	}
+	return ""
+}
+
+func (x *DmUserPost) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DmUserPost) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *DmUserPost) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
+}
+
+func (x *DmUserPost) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// DmPostSearchHit は全文検索の結果
+type DmPostSearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *DmPost                `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DmPostSearchHit) Reset() {
+	*x = DmPostSearchHit{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DmPostSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DmPostSearchHit) ProtoMessage() {}
+
+func (x *DmPostSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DmPostSearchHit.ProtoReflect.Descriptor instead.
+func (*DmPostSearchHit) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DmPostSearchHit) GetPost() *DmPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *DmPostSearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type CreateDmPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDmPostRequest) Reset() {
+	*x = CreateDmPostRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDmPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDmPostRequest) ProtoMessage() {}
+
+func (x *CreateDmPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDmPostRequest.ProtoReflect.Descriptor instead.
+func (*CreateDmPostRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateDmPostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateDmPostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateDmPostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type GetDmPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDmPostRequest) Reset() {
+	*x = GetDmPostRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDmPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDmPostRequest) ProtoMessage() {}
+
+func (x *GetDmPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDmPostRequest.ProtoReflect.Descriptor instead.
+func (*GetDmPostRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetDmPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetDmPostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BatchGetDmPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 最大100件
+	Keys          []*DmPostKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetDmPostsRequest) Reset() {
+	*x = BatchGetDmPostsRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetDmPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetDmPostsRequest) ProtoMessage() {}
+
+func (x *BatchGetDmPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetDmPostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetDmPostsRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetDmPostsRequest) GetKeys() []*DmPostKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type BatchGetDmPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 見つかった投稿（リクエストの順序）
+	Items []*DmPost `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// 見つからなかったキー（リクエストの順序）
+	Missing       []*DmPostKey `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetDmPostsResponse) Reset() {
+	*x = BatchGetDmPostsResponse{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetDmPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetDmPostsResponse) ProtoMessage() {}
+
+func (x *BatchGetDmPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetDmPostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetDmPostsResponse) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchGetDmPostsResponse) GetItems() []*DmPost {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchGetDmPostsResponse) GetMissing() []*DmPostKey {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+type ListDmPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 取得件数（1〜100、省略時は20）、offset+limitは1000以下
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 指定した場合はそのユーザーの投稿のみ
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// RESTのfilterパラメータと同じ形式
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// RESTのsortパラメータと同じ形式
+	Sort          string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDmPostsRequest) Reset() {
+	*x = ListDmPostsRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDmPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDmPostsRequest) ProtoMessage() {}
+
+func (x *ListDmPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDmPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListDmPostsRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDmPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDmPostsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListDmPostsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListDmPostsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListDmPostsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListDmUserPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 取得件数（1〜100、省略時は20）、offset+limitは1000以下
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDmUserPostsRequest) Reset() {
+	*x = ListDmUserPostsRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDmUserPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDmUserPostsRequest) ProtoMessage() {}
+
+func (x *ListDmUserPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDmUserPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListDmUserPostsRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListDmUserPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDmUserPostsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type SearchDmPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Q     string                 `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	// "word"（省略時）または "ngram"
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// "relevance"（省略時）または "date"
+	Sort string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// 取得件数（1〜100、省略時は20）、offset+limitは1000以下
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDmPostsRequest) Reset() {
+	*x = SearchDmPostsRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDmPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDmPostsRequest) ProtoMessage() {}
+
+func (x *SearchDmPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDmPostsRequest.ProtoReflect.Descriptor instead.
+func (*SearchDmPostsRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchDmPostsRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchDmPostsRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SearchDmPostsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchDmPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchDmPostsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type UpdateDmPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDmPostRequest) Reset() {
+	*x = UpdateDmPostRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDmPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDmPostRequest) ProtoMessage() {}
+
+func (x *UpdateDmPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDmPostRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDmPostRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateDmPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateDmPostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateDmPostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateDmPostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type PatchDmPostRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// JSON Merge Patch（RFC 7396）のドキュメント
+	MergePatch    string `protobuf:"bytes,3,opt,name=merge_patch,json=mergePatch,proto3" json:"merge_patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchDmPostRequest) Reset() {
+	*x = PatchDmPostRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchDmPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchDmPostRequest) ProtoMessage() {}
+
+func (x *PatchDmPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchDmPostRequest.ProtoReflect.Descriptor instead.
+func (*PatchDmPostRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PatchDmPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchDmPostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PatchDmPostRequest) GetMergePatch() string {
+	if x != nil {
+		return x.MergePatch
+	}
+	return ""
+}
+
+type DeleteDmPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDmPostRequest) Reset() {
+	*x = DeleteDmPostRequest{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDmPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDmPostRequest) ProtoMessage() {}
+
+func (x *DeleteDmPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDmPostRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDmPostRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteDmPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteDmPostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteDmPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDmPostResponse) Reset() {
+	*x = DeleteDmPostResponse{}
+	mi := &file_dm_v1_dm_post_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDmPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDmPostResponse) ProtoMessage() {}
+
+func (x *DeleteDmPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_post_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDmPostResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDmPostResponse) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_post_proto_rawDescGZIP(), []int{14}
+}
+
+var File_dm_v1_dm_post_proto protoreflect.FileDescriptor
+
+const file_dm_v1_dm_post_proto_rawDesc = "" +
+	"\n" +
+	"\x13dm/v1/dm_post.proto\x12\x05dm.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x01\n" +
+	"\x06DmPost\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"4\n" +
+	"\tDmPostKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xf7\x01\n" +
+	"\n" +
+	"DmUserPost\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1d\n" +
+	"\n" +
+	"post_title\x18\x02 \x01(\tR\tpostTitle\x12!\n" +
+	"\fpost_content\x18\x03 \x01(\tR\vpostContent\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tuser_name\x18\x05 \x01(\tR\buserName\x12\x1d\n" +
+	"\n" +
+	"user_email\x18\x06 \x01(\tR\tuserEmail\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"J\n" +
+	"\x0fDmPostSearchHit\x12!\n" +
+	"\x04post\x18\x01 \x01(\v2\r.dm.v1.DmPostR\x04post\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"^\n" +
+	"\x13CreateDmPostRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\";\n" +
+	"\x10GetDmPostRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\">\n" +
+	"\x16BatchGetDmPostsRequest\x12$\n" +
+	"\x04keys\x18\x01 \x03(\v2\x10.dm.v1.DmPostKeyR\x04keys\"j\n" +
+	"\x17BatchGetDmPostsResponse\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.dm.v1.DmPostR\x05items\x12*\n" +
+	"\amissing\x18\x02 \x03(\v2\x10.dm.v1.DmPostKeyR\amissing\"\x87\x01\n" +
+	"\x12ListDmPostsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\"F\n" +
+	"\x16ListDmUserPostsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"z\n" +
+	"\x14SearchDmPostsRequest\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"n\n" +
+	"\x13UpdateDmPostRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\"^\n" +
+	"\x12PatchDmPostRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vmerge_patch\x18\x03 \x01(\tR\n" +
+	"mergePatch\">\n" +
+	"\x13DeleteDmPostRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x16\n" +
+	"\x14DeleteDmPostResponse2\xd8\x04\n" +
+	"\rDmPostService\x129\n" +
+	"\fCreateDmPost\x12\x1a.dm.v1.CreateDmPostRequest\x1a\r.dm.v1.DmPost\x123\n" +
+	"\tGetDmPost\x12\x17.dm.v1.GetDmPostRequest\x1a\r.dm.v1.DmPost\x12P\n" +
+	"\x0fBatchGetDmPosts\x12\x1d.dm.v1.BatchGetDmPostsRequest\x1a\x1e.dm.v1.BatchGetDmPostsResponse\x129\n" +
+	"\vListDmPosts\x12\x19.dm.v1.ListDmPostsRequest\x1a\r.dm.v1.DmPost0\x01\x12E\n" +
+	"\x0fListDmUserPosts\x12\x1d.dm.v1.ListDmUserPostsRequest\x1a\x11.dm.v1.DmUserPost0\x01\x12F\n" +
+	"\rSearchDmPosts\x12\x1b.dm.v1.SearchDmPostsRequest\x1a\x16.dm.v1.DmPostSearchHit0\x01\x129\n" +
+	"\fUpdateDmPost\x12\x1a.dm.v1.UpdateDmPostRequest\x1a\r.dm.v1.DmPost\x127\n" +
+	"\vPatchDmPost\x12\x19.dm.v1.PatchDmPostRequest\x1a\r.dm.v1.DmPost\x12G\n" +
+	"\fDeleteDmPost\x12\x1a.dm.v1.DeleteDmPostRequest\x1a\x1b.dm.v1.DeleteDmPostResponseBIZGgithub.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1;dmv1b\x06proto3"
+
+var (
+	file_dm_v1_dm_post_proto_rawDescOnce sync.Once
+	file_dm_v1_dm_post_proto_rawDescData []byte
+)
+
+func file_dm_v1_dm_post_proto_rawDescGZIP() []byte {
+	file_dm_v1_dm_post_proto_rawDescOnce.Do(func() {
+		file_dm_v1_dm_post_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dm_v1_dm_post_proto_rawDesc), len(file_dm_v1_dm_post_proto_rawDesc)))
+	})
+	return file_dm_v1_dm_post_proto_rawDescData
+}
+
+var file_dm_v1_dm_post_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_dm_v1_dm_post_proto_goTypes = []any{
+	(*DmPost)(nil),                  // 0: dm.v1.DmPost
+	(*DmPostKey)(nil),               // 1: dm.v1.DmPostKey
+	(*DmUserPost)(nil),              // 2: dm.v1.DmUserPost
+	(*DmPostSearchHit)(nil),         // 3: dm.v1.DmPostSearchHit
+	(*CreateDmPostRequest)(nil),     // 4: dm.v1.CreateDmPostRequest
+	(*GetDmPostRequest)(nil),        // 5: dm.v1.GetDmPostRequest
+	(*BatchGetDmPostsRequest)(nil),  // 6: dm.v1.BatchGetDmPostsRequest
+	(*BatchGetDmPostsResponse)(nil), // 7: dm.v1.BatchGetDmPostsResponse
+	(*ListDmPostsRequest)(nil),      // 8: dm.v1.ListDmPostsRequest
+	(*ListDmUserPostsRequest)(nil),  // 9: dm.v1.ListDmUserPostsRequest
+	(*SearchDmPostsRequest)(nil),    // 10: dm.v1.SearchDmPostsRequest
+	(*UpdateDmPostRequest)(nil),     // 11: dm.v1.UpdateDmPostRequest
+	(*PatchDmPostRequest)(nil),      // 12: dm.v1.PatchDmPostRequest
+	(*DeleteDmPostRequest)(nil),     // 13: dm.v1.DeleteDmPostRequest
+	(*DeleteDmPostResponse)(nil),    // 14: dm.v1.DeleteDmPostResponse
+	(*timestamppb.Timestamp)(nil),   // 15: google.protobuf.Timestamp
+}
+var file_dm_v1_dm_post_proto_depIdxs = []int32{
+	15, // 0: dm.v1.DmPost.created_at:type_name -> google.protobuf.Timestamp
+	15, // 1: dm.v1.DmPost.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 2: dm.v1.DmUserPost.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: dm.v1.DmPostSearchHit.post:type_name -> dm.v1.DmPost
+	1,  // 4: dm.v1.BatchGetDmPostsRequest.keys:type_name -> dm.v1.DmPostKey
+	0,  // 5: dm.v1.BatchGetDmPostsResponse.items:type_name -> dm.v1.DmPost
+	1,  // 6: dm.v1.BatchGetDmPostsResponse.missing:type_name -> dm.v1.DmPostKey
+	4,  // 7: dm.v1.DmPostService.CreateDmPost:input_type -> dm.v1.CreateDmPostRequest
+	5,  // 8: dm.v1.DmPostService.GetDmPost:input_type -> dm.v1.GetDmPostRequest
+	6,  // 9: dm.v1.DmPostService.BatchGetDmPosts:input_type -> dm.v1.BatchGetDmPostsRequest
+	8,  // 10: dm.v1.DmPostService.ListDmPosts:input_type -> dm.v1.ListDmPostsRequest
+	9,  // 11: dm.v1.DmPostService.ListDmUserPosts:input_type -> dm.v1.ListDmUserPostsRequest
+	10, // 12: dm.v1.DmPostService.SearchDmPosts:input_type -> dm.v1.SearchDmPostsRequest
+	11, // 13: dm.v1.DmPostService.UpdateDmPost:input_type -> dm.v1.UpdateDmPostRequest
+	12, // 14: dm.v1.DmPostService.PatchDmPost:input_type -> dm.v1.PatchDmPostRequest
+	13, // 15: dm.v1.DmPostService.DeleteDmPost:input_type -> dm.v1.DeleteDmPostRequest
+	0,  // 16: dm.v1.DmPostService.CreateDmPost:output_type -> dm.v1.DmPost
+	0,  // 17: dm.v1.DmPostService.GetDmPost:output_type -> dm.v1.DmPost
+	7,  // 18: dm.v1.DmPostService.BatchGetDmPosts:output_type -> dm.v1.BatchGetDmPostsResponse
+	0,  // 19: dm.v1.DmPostService.ListDmPosts:output_type -> dm.v1.DmPost
+	2,  // 20: dm.v1.DmPostService.ListDmUserPosts:output_type -> dm.v1.DmUserPost
+	3,  // 21: dm.v1.DmPostService.SearchDmPosts:output_type -> dm.v1.DmPostSearchHit
+	0,  // 22: dm.v1.DmPostService.UpdateDmPost:output_type -> dm.v1.DmPost
+	0,  // 23: dm.v1.DmPostService.PatchDmPost:output_type -> dm.v1.DmPost
+	14, // 24: dm.v1.DmPostService.DeleteDmPost:output_type -> dm.v1.DeleteDmPostResponse
+	16, // [16:25] is the sub-list for method output_type
+	7,  // [7:16] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_dm_v1_dm_post_proto_init() }
+func file_dm_v1_dm_post_proto_init() {
+	if File_dm_v1_dm_post_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dm_v1_dm_post_proto_rawDesc), len(file_dm_v1_dm_post_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dm_v1_dm_post_proto_goTypes,
+		DependencyIndexes: file_dm_v1_dm_post_proto_depIdxs,
+		MessageInfos:      file_dm_v1_dm_post_proto_msgTypes,
+	}.Build()
+	File_dm_v1_dm_post_proto = out.File
+	file_dm_v1_dm_post_proto_goTypes = nil
+	file_dm_v1_dm_post_proto_depIdxs = nil
+}
@@ -0,0 +1,449 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: dm/v1/dm_post.proto
+
+package dmv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DmPostService_CreateDmPost_FullMethodName    = "/dm.v1.DmPostService/CreateDmPost"
+	DmPostService_GetDmPost_FullMethodName       = "/dm.v1.DmPostService/GetDmPost"
+	DmPostService_BatchGetDmPosts_FullMethodName = "/dm.v1.DmPostService/BatchGetDmPosts"
+	DmPostService_ListDmPosts_FullMethodName     = "/dm.v1.DmPostService/ListDmPosts"
+	DmPostService_ListDmUserPosts_FullMethodName = "/dm.v1.DmPostService/ListDmUserPosts"
+	DmPostService_SearchDmPosts_FullMethodName   = "/dm.v1.DmPostService/SearchDmPosts"
+	DmPostService_UpdateDmPost_FullMethodName    = "/dm.v1.DmPostService/UpdateDmPost"
+	DmPostService_PatchDmPost_FullMethodName     = "/dm.v1.DmPostService/PatchDmPost"
+	DmPostService_DeleteDmPost_FullMethodName    = "/dm.v1.DmPostService/DeleteDmPost"
+)
+
+// DmPostServiceClient is the client API for DmPostService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DmPostService は投稿API（DmPostUsecase）のgRPC版
+// Access Level: public（Public API Key JWT または Auth0 JWT でアクセス可能）
+// 投稿はユーザーIDでシャーディングされるため、投稿の指定にはuser_idも必要
+type DmPostServiceClient interface {
+	CreateDmPost(ctx context.Context, in *CreateDmPostRequest, opts ...grpc.CallOption) (*DmPost, error)
+	GetDmPost(ctx context.Context, in *GetDmPostRequest, opts ...grpc.CallOption) (*DmPost, error)
+	BatchGetDmPosts(ctx context.Context, in *BatchGetDmPostsRequest, opts ...grpc.CallOption) (*BatchGetDmPostsResponse, error)
+	// ListDmPosts は投稿を1件ずつストリームで返す
+	ListDmPosts(ctx context.Context, in *ListDmPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmPost], error)
+	// ListDmUserPosts はユーザーと投稿のJOIN結果を1件ずつストリームで返す
+	ListDmUserPosts(ctx context.Context, in *ListDmUserPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmUserPost], error)
+	// SearchDmPosts は全文検索の結果を1件ずつストリームで返す
+	SearchDmPosts(ctx context.Context, in *SearchDmPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmPostSearchHit], error)
+	UpdateDmPost(ctx context.Context, in *UpdateDmPostRequest, opts ...grpc.CallOption) (*DmPost, error)
+	PatchDmPost(ctx context.Context, in *PatchDmPostRequest, opts ...grpc.CallOption) (*DmPost, error)
+	DeleteDmPost(ctx context.Context, in *DeleteDmPostRequest, opts ...grpc.CallOption) (*DeleteDmPostResponse, error)
+}
+
+type dmPostServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDmPostServiceClient(cc grpc.ClientConnInterface) DmPostServiceClient {
+	return &dmPostServiceClient{cc}
+}
+
+func (c *dmPostServiceClient) CreateDmPost(ctx context.Context, in *CreateDmPostRequest, opts ...grpc.CallOption) (*DmPost, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmPost)
+	err := c.cc.Invoke(ctx, DmPostService_CreateDmPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmPostServiceClient) GetDmPost(ctx context.Context, in *GetDmPostRequest, opts ...grpc.CallOption) (*DmPost, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmPost)
+	err := c.cc.Invoke(ctx, DmPostService_GetDmPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmPostServiceClient) BatchGetDmPosts(ctx context.Context, in *BatchGetDmPostsRequest, opts ...grpc.CallOption) (*BatchGetDmPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetDmPostsResponse)
+	err := c.cc.Invoke(ctx, DmPostService_BatchGetDmPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmPostServiceClient) ListDmPosts(ctx context.Context, in *ListDmPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmPost], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DmPostService_ServiceDesc.Streams[0], DmPostService_ListDmPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDmPostsRequest, DmPost]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmPostService_ListDmPostsClient = grpc.ServerStreamingClient[DmPost]
+
+func (c *dmPostServiceClient) ListDmUserPosts(ctx context.Context, in *ListDmUserPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmUserPost], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DmPostService_ServiceDesc.Streams[1], DmPostService_ListDmUserPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDmUserPostsRequest, DmUserPost]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmPostService_ListDmUserPostsClient = grpc.ServerStreamingClient[DmUserPost]
+
+func (c *dmPostServiceClient) SearchDmPosts(ctx context.Context, in *SearchDmPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmPostSearchHit], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DmPostService_ServiceDesc.Streams[2], DmPostService_SearchDmPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchDmPostsRequest, DmPostSearchHit]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmPostService_SearchDmPostsClient = grpc.ServerStreamingClient[DmPostSearchHit]
+
+func (c *dmPostServiceClient) UpdateDmPost(ctx context.Context, in *UpdateDmPostRequest, opts ...grpc.CallOption) (*DmPost, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmPost)
+	err := c.cc.Invoke(ctx, DmPostService_UpdateDmPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmPostServiceClient) PatchDmPost(ctx context.Context, in *PatchDmPostRequest, opts ...grpc.CallOption) (*DmPost, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmPost)
+	err := c.cc.Invoke(ctx, DmPostService_PatchDmPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmPostServiceClient) DeleteDmPost(ctx context.Context, in *DeleteDmPostRequest, opts ...grpc.CallOption) (*DeleteDmPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDmPostResponse)
+	err := c.cc.Invoke(ctx, DmPostService_DeleteDmPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DmPostServiceServer is the server API for DmPostService service.
+// All implementations must embed UnimplementedDmPostServiceServer
+// for forward compatibility.
+//
+// DmPostService は投稿API（DmPostUsecase）のgRPC版
+// Access Level: public（Public API Key JWT または Auth0 JWT でアクセス可能）
+// 投稿はユーザーIDでシャーディングされるため、投稿の指定にはuser_idも必要
+type DmPostServiceServer interface {
+	CreateDmPost(context.Context, *CreateDmPostRequest) (*DmPost, error)
+	GetDmPost(context.Context, *GetDmPostRequest) (*DmPost, error)
+	BatchGetDmPosts(context.Context, *BatchGetDmPostsRequest) (*BatchGetDmPostsResponse, error)
+	// ListDmPosts は投稿を1件ずつストリームで返す
+	ListDmPosts(*ListDmPostsRequest, grpc.ServerStreamingServer[DmPost]) error
+	// ListDmUserPosts はユーザーと投稿のJOIN結果を1件ずつストリームで返す
+	ListDmUserPosts(*ListDmUserPostsRequest, grpc.ServerStreamingServer[DmUserPost]) error
+	// SearchDmPosts は全文検索の結果を1件ずつストリームで返す
+	SearchDmPosts(*SearchDmPostsRequest, grpc.ServerStreamingServer[DmPostSearchHit]) error
+	UpdateDmPost(context.Context, *UpdateDmPostRequest) (*DmPost, error)
+	PatchDmPost(context.Context, *PatchDmPostRequest) (*DmPost, error)
+	DeleteDmPost(context.Context, *DeleteDmPostRequest) (*DeleteDmPostResponse, error)
+	mustEmbedUnimplementedDmPostServiceServer()
+}
+
+// UnimplementedDmPostServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDmPostServiceServer struct{}
+
+func (UnimplementedDmPostServiceServer) CreateDmPost(context.Context, *CreateDmPostRequest) (*DmPost, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateDmPost not implemented")
+}
+func (UnimplementedDmPostServiceServer) GetDmPost(context.Context, *GetDmPostRequest) (*DmPost, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDmPost not implemented")
+}
+func (UnimplementedDmPostServiceServer) BatchGetDmPosts(context.Context, *BatchGetDmPostsRequest) (*BatchGetDmPostsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetDmPosts not implemented")
+}
+func (UnimplementedDmPostServiceServer) ListDmPosts(*ListDmPostsRequest, grpc.ServerStreamingServer[DmPost]) error {
+	return status.Error(codes.Unimplemented, "method ListDmPosts not implemented")
+}
+func (UnimplementedDmPostServiceServer) ListDmUserPosts(*ListDmUserPostsRequest, grpc.ServerStreamingServer[DmUserPost]) error {
+	return status.Error(codes.Unimplemented, "method ListDmUserPosts not implemented")
+}
+func (UnimplementedDmPostServiceServer) SearchDmPosts(*SearchDmPostsRequest, grpc.ServerStreamingServer[DmPostSearchHit]) error {
+	return status.Error(codes.Unimplemented, "method SearchDmPosts not implemented")
+}
+func (UnimplementedDmPostServiceServer) UpdateDmPost(context.Context, *UpdateDmPostRequest) (*DmPost, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDmPost not implemented")
+}
+func (UnimplementedDmPostServiceServer) PatchDmPost(context.Context, *PatchDmPostRequest) (*DmPost, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchDmPost not implemented")
+}
+func (UnimplementedDmPostServiceServer) DeleteDmPost(context.Context, *DeleteDmPostRequest) (*DeleteDmPostResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteDmPost not implemented")
+}
+func (UnimplementedDmPostServiceServer) mustEmbedUnimplementedDmPostServiceServer() {}
+func (UnimplementedDmPostServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeDmPostServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DmPostServiceServer will
+// result in compilation errors.
+type UnsafeDmPostServiceServer interface {
+	mustEmbedUnimplementedDmPostServiceServer()
+}
+
+func RegisterDmPostServiceServer(s grpc.ServiceRegistrar, srv DmPostServiceServer) {
+	// If the following call panics, it indicates UnimplementedDmPostServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DmPostService_ServiceDesc, srv)
+}
+
+func _DmPostService_CreateDmPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDmPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmPostServiceServer).CreateDmPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmPostService_CreateDmPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmPostServiceServer).CreateDmPost(ctx, req.(*CreateDmPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmPostService_GetDmPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDmPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmPostServiceServer).GetDmPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmPostService_GetDmPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmPostServiceServer).GetDmPost(ctx, req.(*GetDmPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmPostService_BatchGetDmPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetDmPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmPostServiceServer).BatchGetDmPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmPostService_BatchGetDmPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmPostServiceServer).BatchGetDmPosts(ctx, req.(*BatchGetDmPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmPostService_ListDmPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDmPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DmPostServiceServer).ListDmPosts(m, &grpc.GenericServerStream[ListDmPostsRequest, DmPost]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmPostService_ListDmPostsServer = grpc.ServerStreamingServer[DmPost]
+
+func _DmPostService_ListDmUserPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDmUserPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DmPostServiceServer).ListDmUserPosts(m, &grpc.GenericServerStream[ListDmUserPostsRequest, DmUserPost]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmPostService_ListDmUserPostsServer = grpc.ServerStreamingServer[DmUserPost]
+
+func _DmPostService_SearchDmPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchDmPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DmPostServiceServer).SearchDmPosts(m, &grpc.GenericServerStream[SearchDmPostsRequest, DmPostSearchHit]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmPostService_SearchDmPostsServer = grpc.ServerStreamingServer[DmPostSearchHit]
+
+func _DmPostService_UpdateDmPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDmPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmPostServiceServer).UpdateDmPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmPostService_UpdateDmPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmPostServiceServer).UpdateDmPost(ctx, req.(*UpdateDmPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmPostService_PatchDmPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchDmPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmPostServiceServer).PatchDmPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmPostService_PatchDmPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmPostServiceServer).PatchDmPost(ctx, req.(*PatchDmPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmPostService_DeleteDmPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDmPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmPostServiceServer).DeleteDmPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmPostService_DeleteDmPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmPostServiceServer).DeleteDmPost(ctx, req.(*DeleteDmPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DmPostService_ServiceDesc is the grpc.ServiceDesc for DmPostService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DmPostService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dm.v1.DmPostService",
+	HandlerType: (*DmPostServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDmPost",
+			Handler:    _DmPostService_CreateDmPost_Handler,
+		},
+		{
+			MethodName: "GetDmPost",
+			Handler:    _DmPostService_GetDmPost_Handler,
+		},
+		{
+			MethodName: "BatchGetDmPosts",
+			Handler:    _DmPostService_BatchGetDmPosts_Handler,
+		},
+		{
+			MethodName: "UpdateDmPost",
+			Handler:    _DmPostService_UpdateDmPost_Handler,
+		},
+		{
+			MethodName: "PatchDmPost",
+			Handler:    _DmPostService_PatchDmPost_Handler,
+		},
+		{
+			MethodName: "DeleteDmPost",
+			Handler:    _DmPostService_DeleteDmPost_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListDmPosts",
+			Handler:       _DmPostService_ListDmPosts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDmUserPosts",
+			Handler:       _DmPostService_ListDmUserPosts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SearchDmPosts",
+			Handler:       _DmPostService_SearchDmPosts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dm/v1/dm_post.proto",
+}
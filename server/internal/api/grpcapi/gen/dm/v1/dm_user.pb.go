@@ -0,0 +1,682 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: dm/v1/dm_user.proto
+
+package dmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DmUser はユーザー（IDはUUIDv7形式の32文字の16進数文字列）
+type DmUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DmUser) Reset() {
+	*x = DmUser{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DmUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DmUser) ProtoMessage() {}
+
+func (x *DmUser) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DmUser.ProtoReflect.Descriptor instead.
+func (*DmUser) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DmUser) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DmUser) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DmUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *DmUser) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DmUser) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateDmUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDmUserRequest) Reset() {
+	*x = CreateDmUserRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDmUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDmUserRequest) ProtoMessage() {}
+
+func (x *CreateDmUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDmUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateDmUserRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateDmUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateDmUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type GetDmUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDmUserRequest) Reset() {
+	*x = GetDmUserRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDmUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDmUserRequest) ProtoMessage() {}
+
+func (x *GetDmUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDmUserRequest.ProtoReflect.Descriptor instead.
+func (*GetDmUserRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetDmUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchGetDmUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 最大100件
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetDmUsersRequest) Reset() {
+	*x = BatchGetDmUsersRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetDmUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetDmUsersRequest) ProtoMessage() {}
+
+func (x *BatchGetDmUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetDmUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetDmUsersRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetDmUsersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetDmUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 見つかったユーザー（リクエストの順序）
+	Items []*DmUser `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// 見つからなかったID（リクエストの順序）
+	Missing       []string `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetDmUsersResponse) Reset() {
+	*x = BatchGetDmUsersResponse{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetDmUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetDmUsersResponse) ProtoMessage() {}
+
+func (x *BatchGetDmUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetDmUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetDmUsersResponse) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetDmUsersResponse) GetItems() []*DmUser {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchGetDmUsersResponse) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+type ListDmUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 取得件数（1〜100、省略時は20）、offset+limitは1000以下
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// RESTのfilterパラメータと同じ形式（例: "name:eq:Alice"）
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// RESTのsortパラメータと同じ形式（例: "-created_at"）
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDmUsersRequest) Reset() {
+	*x = ListDmUsersRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDmUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDmUsersRequest) ProtoMessage() {}
+
+func (x *ListDmUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDmUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListDmUsersRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDmUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDmUsersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListDmUsersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListDmUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type UpdateDmUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDmUserRequest) Reset() {
+	*x = UpdateDmUserRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDmUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDmUserRequest) ProtoMessage() {}
+
+func (x *UpdateDmUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDmUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDmUserRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateDmUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateDmUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateDmUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type PatchDmUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// JSON Merge Patch（RFC 7396）のドキュメント
+	MergePatch    string `protobuf:"bytes,2,opt,name=merge_patch,json=mergePatch,proto3" json:"merge_patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchDmUserRequest) Reset() {
+	*x = PatchDmUserRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchDmUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchDmUserRequest) ProtoMessage() {}
+
+func (x *PatchDmUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchDmUserRequest.ProtoReflect.Descriptor instead.
+func (*PatchDmUserRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PatchDmUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchDmUserRequest) GetMergePatch() string {
+	if x != nil {
+		return x.MergePatch
+	}
+	return ""
+}
+
+type DeleteDmUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDmUserRequest) Reset() {
+	*x = DeleteDmUserRequest{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDmUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDmUserRequest) ProtoMessage() {}
+
+func (x *DeleteDmUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDmUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDmUserRequest) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteDmUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteDmUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDmUserResponse) Reset() {
+	*x = DeleteDmUserResponse{}
+	mi := &file_dm_v1_dm_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDmUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDmUserResponse) ProtoMessage() {}
+
+func (x *DeleteDmUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dm_v1_dm_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDmUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDmUserResponse) Descriptor() ([]byte, []int) {
+	return file_dm_v1_dm_user_proto_rawDescGZIP(), []int{9}
+}
+
+var File_dm_v1_dm_user_proto protoreflect.FileDescriptor
+
+const file_dm_v1_dm_user_proto_rawDesc = "" +
+	"\n" +
+	"\x13dm/v1/dm_user.proto\x12\x05dm.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x01\n" +
+	"\x06DmUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"?\n" +
+	"\x13CreateDmUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"\"\n" +
+	"\x10GetDmUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x16BatchGetDmUsersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"X\n" +
+	"\x17BatchGetDmUsersResponse\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.dm.v1.DmUserR\x05items\x12\x18\n" +
+	"\amissing\x18\x02 \x03(\tR\amissing\"n\n" +
+	"\x12ListDmUsersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"O\n" +
+	"\x13UpdateDmUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"E\n" +
+	"\x12PatchDmUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vmerge_patch\x18\x02 \x01(\tR\n" +
+	"mergePatch\"%\n" +
+	"\x13DeleteDmUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x16\n" +
+	"\x14DeleteDmUserResponse2\xc9\x03\n" +
+	"\rDmUserService\x129\n" +
+	"\fCreateDmUser\x12\x1a.dm.v1.CreateDmUserRequest\x1a\r.dm.v1.DmUser\x123\n" +
+	"\tGetDmUser\x12\x17.dm.v1.GetDmUserRequest\x1a\r.dm.v1.DmUser\x12P\n" +
+	"\x0fBatchGetDmUsers\x12\x1d.dm.v1.BatchGetDmUsersRequest\x1a\x1e.dm.v1.BatchGetDmUsersResponse\x129\n" +
+	"\vListDmUsers\x12\x19.dm.v1.ListDmUsersRequest\x1a\r.dm.v1.DmUser0\x01\x129\n" +
+	"\fUpdateDmUser\x12\x1a.dm.v1.UpdateDmUserRequest\x1a\r.dm.v1.DmUser\x127\n" +
+	"\vPatchDmUser\x12\x19.dm.v1.PatchDmUserRequest\x1a\r.dm.v1.DmUser\x12G\n" +
+	"\fDeleteDmUser\x12\x1a.dm.v1.DeleteDmUserRequest\x1a\x1b.dm.v1.DeleteDmUserResponseBIZGgithub.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1;dmv1b\x06proto3"
+
+var (
+	file_dm_v1_dm_user_proto_rawDescOnce sync.Once
+	file_dm_v1_dm_user_proto_rawDescData []byte
+)
+
+func file_dm_v1_dm_user_proto_rawDescGZIP() []byte {
+	file_dm_v1_dm_user_proto_rawDescOnce.Do(func() {
+		file_dm_v1_dm_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dm_v1_dm_user_proto_rawDesc), len(file_dm_v1_dm_user_proto_rawDesc)))
+	})
+	return file_dm_v1_dm_user_proto_rawDescData
+}
+
+var file_dm_v1_dm_user_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_dm_v1_dm_user_proto_goTypes = []any{
+	(*DmUser)(nil),                  // 0: dm.v1.DmUser
+	(*CreateDmUserRequest)(nil),     // 1: dm.v1.CreateDmUserRequest
+	(*GetDmUserRequest)(nil),        // 2: dm.v1.GetDmUserRequest
+	(*BatchGetDmUsersRequest)(nil),  // 3: dm.v1.BatchGetDmUsersRequest
+	(*BatchGetDmUsersResponse)(nil), // 4: dm.v1.BatchGetDmUsersResponse
+	(*ListDmUsersRequest)(nil),      // 5: dm.v1.ListDmUsersRequest
+	(*UpdateDmUserRequest)(nil),     // 6: dm.v1.UpdateDmUserRequest
+	(*PatchDmUserRequest)(nil),      // 7: dm.v1.PatchDmUserRequest
+	(*DeleteDmUserRequest)(nil),     // 8: dm.v1.DeleteDmUserRequest
+	(*DeleteDmUserResponse)(nil),    // 9: dm.v1.DeleteDmUserResponse
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
+}
+var file_dm_v1_dm_user_proto_depIdxs = []int32{
+	10, // 0: dm.v1.DmUser.created_at:type_name -> google.protobuf.Timestamp
+	10, // 1: dm.v1.DmUser.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: dm.v1.BatchGetDmUsersResponse.items:type_name -> dm.v1.DmUser
+	1,  // 3: dm.v1.DmUserService.CreateDmUser:input_type -> dm.v1.CreateDmUserRequest
+	2,  // 4: dm.v1.DmUserService.GetDmUser:input_type -> dm.v1.GetDmUserRequest
+	3,  // 5: dm.v1.DmUserService.BatchGetDmUsers:input_type -> dm.v1.BatchGetDmUsersRequest
+	5,  // 6: dm.v1.DmUserService.ListDmUsers:input_type -> dm.v1.ListDmUsersRequest
+	6,  // 7: dm.v1.DmUserService.UpdateDmUser:input_type -> dm.v1.UpdateDmUserRequest
+	7,  // 8: dm.v1.DmUserService.PatchDmUser:input_type -> dm.v1.PatchDmUserRequest
+	8,  // 9: dm.v1.DmUserService.DeleteDmUser:input_type -> dm.v1.DeleteDmUserRequest
+	0,  // 10: dm.v1.DmUserService.CreateDmUser:output_type -> dm.v1.DmUser
+	0,  // 11: dm.v1.DmUserService.GetDmUser:output_type -> dm.v1.DmUser
+	4,  // 12: dm.v1.DmUserService.BatchGetDmUsers:output_type -> dm.v1.BatchGetDmUsersResponse
+	0,  // 13: dm.v1.DmUserService.ListDmUsers:output_type -> dm.v1.DmUser
+	0,  // 14: dm.v1.DmUserService.UpdateDmUser:output_type -> dm.v1.DmUser
+	0,  // 15: dm.v1.DmUserService.PatchDmUser:output_type -> dm.v1.DmUser
+	9,  // 16: dm.v1.DmUserService.DeleteDmUser:output_type -> dm.v1.DeleteDmUserResponse
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_dm_v1_dm_user_proto_init() }
+func file_dm_v1_dm_user_proto_init() {
+	if File_dm_v1_dm_user_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dm_v1_dm_user_proto_rawDesc), len(file_dm_v1_dm_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dm_v1_dm_user_proto_goTypes,
+		DependencyIndexes: file_dm_v1_dm_user_proto_depIdxs,
+		MessageInfos:      file_dm_v1_dm_user_proto_msgTypes,
+	}.Build()
+	File_dm_v1_dm_user_proto = out.File
+	file_dm_v1_dm_user_proto_goTypes = nil
+	file_dm_v1_dm_user_proto_depIdxs = nil
+}
@@ -0,0 +1,361 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: dm/v1/dm_user.proto
+
+package dmv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DmUserService_CreateDmUser_FullMethodName    = "/dm.v1.DmUserService/CreateDmUser"
+	DmUserService_GetDmUser_FullMethodName       = "/dm.v1.DmUserService/GetDmUser"
+	DmUserService_BatchGetDmUsers_FullMethodName = "/dm.v1.DmUserService/BatchGetDmUsers"
+	DmUserService_ListDmUsers_FullMethodName     = "/dm.v1.DmUserService/ListDmUsers"
+	DmUserService_UpdateDmUser_FullMethodName    = "/dm.v1.DmUserService/UpdateDmUser"
+	DmUserService_PatchDmUser_FullMethodName     = "/dm.v1.DmUserService/PatchDmUser"
+	DmUserService_DeleteDmUser_FullMethodName    = "/dm.v1.DmUserService/DeleteDmUser"
+)
+
+// DmUserServiceClient is the client API for DmUserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DmUserService はユーザーAPI（DmUserUsecase）のgRPC版
+// Access Level: public（Public API Key JWT または Auth0 JWT でアクセス可能）
+type DmUserServiceClient interface {
+	CreateDmUser(ctx context.Context, in *CreateDmUserRequest, opts ...grpc.CallOption) (*DmUser, error)
+	GetDmUser(ctx context.Context, in *GetDmUserRequest, opts ...grpc.CallOption) (*DmUser, error)
+	BatchGetDmUsers(ctx context.Context, in *BatchGetDmUsersRequest, opts ...grpc.CallOption) (*BatchGetDmUsersResponse, error)
+	// ListDmUsers はユーザーを1件ずつストリームで返す
+	ListDmUsers(ctx context.Context, in *ListDmUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmUser], error)
+	UpdateDmUser(ctx context.Context, in *UpdateDmUserRequest, opts ...grpc.CallOption) (*DmUser, error)
+	PatchDmUser(ctx context.Context, in *PatchDmUserRequest, opts ...grpc.CallOption) (*DmUser, error)
+	DeleteDmUser(ctx context.Context, in *DeleteDmUserRequest, opts ...grpc.CallOption) (*DeleteDmUserResponse, error)
+}
+
+type dmUserServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDmUserServiceClient(cc grpc.ClientConnInterface) DmUserServiceClient {
+	return &dmUserServiceClient{cc}
+}
+
+func (c *dmUserServiceClient) CreateDmUser(ctx context.Context, in *CreateDmUserRequest, opts ...grpc.CallOption) (*DmUser, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmUser)
+	err := c.cc.Invoke(ctx, DmUserService_CreateDmUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmUserServiceClient) GetDmUser(ctx context.Context, in *GetDmUserRequest, opts ...grpc.CallOption) (*DmUser, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmUser)
+	err := c.cc.Invoke(ctx, DmUserService_GetDmUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmUserServiceClient) BatchGetDmUsers(ctx context.Context, in *BatchGetDmUsersRequest, opts ...grpc.CallOption) (*BatchGetDmUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetDmUsersResponse)
+	err := c.cc.Invoke(ctx, DmUserService_BatchGetDmUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmUserServiceClient) ListDmUsers(ctx context.Context, in *ListDmUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DmUser], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DmUserService_ServiceDesc.Streams[0], DmUserService_ListDmUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDmUsersRequest, DmUser]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmUserService_ListDmUsersClient = grpc.ServerStreamingClient[DmUser]
+
+func (c *dmUserServiceClient) UpdateDmUser(ctx context.Context, in *UpdateDmUserRequest, opts ...grpc.CallOption) (*DmUser, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmUser)
+	err := c.cc.Invoke(ctx, DmUserService_UpdateDmUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmUserServiceClient) PatchDmUser(ctx context.Context, in *PatchDmUserRequest, opts ...grpc.CallOption) (*DmUser, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DmUser)
+	err := c.cc.Invoke(ctx, DmUserService_PatchDmUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dmUserServiceClient) DeleteDmUser(ctx context.Context, in *DeleteDmUserRequest, opts ...grpc.CallOption) (*DeleteDmUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDmUserResponse)
+	err := c.cc.Invoke(ctx, DmUserService_DeleteDmUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DmUserServiceServer is the server API for DmUserService service.
+// All implementations must embed UnimplementedDmUserServiceServer
+// for forward compatibility.
+//
+// DmUserService はユーザーAPI（DmUserUsecase）のgRPC版
+// Access Level: public（Public API Key JWT または Auth0 JWT でアクセス可能）
+type DmUserServiceServer interface {
+	CreateDmUser(context.Context, *CreateDmUserRequest) (*DmUser, error)
+	GetDmUser(context.Context, *GetDmUserRequest) (*DmUser, error)
+	BatchGetDmUsers(context.Context, *BatchGetDmUsersRequest) (*BatchGetDmUsersResponse, error)
+	// ListDmUsers はユーザーを1件ずつストリームで返す
+	ListDmUsers(*ListDmUsersRequest, grpc.ServerStreamingServer[DmUser]) error
+	UpdateDmUser(context.Context, *UpdateDmUserRequest) (*DmUser, error)
+	PatchDmUser(context.Context, *PatchDmUserRequest) (*DmUser, error)
+	DeleteDmUser(context.Context, *DeleteDmUserRequest) (*DeleteDmUserResponse, error)
+	mustEmbedUnimplementedDmUserServiceServer()
+}
+
+// UnimplementedDmUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDmUserServiceServer struct{}
+
+func (UnimplementedDmUserServiceServer) CreateDmUser(context.Context, *CreateDmUserRequest) (*DmUser, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateDmUser not implemented")
+}
+func (UnimplementedDmUserServiceServer) GetDmUser(context.Context, *GetDmUserRequest) (*DmUser, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDmUser not implemented")
+}
+func (UnimplementedDmUserServiceServer) BatchGetDmUsers(context.Context, *BatchGetDmUsersRequest) (*BatchGetDmUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetDmUsers not implemented")
+}
+func (UnimplementedDmUserServiceServer) ListDmUsers(*ListDmUsersRequest, grpc.ServerStreamingServer[DmUser]) error {
+	return status.Error(codes.Unimplemented, "method ListDmUsers not implemented")
+}
+func (UnimplementedDmUserServiceServer) UpdateDmUser(context.Context, *UpdateDmUserRequest) (*DmUser, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDmUser not implemented")
+}
+func (UnimplementedDmUserServiceServer) PatchDmUser(context.Context, *PatchDmUserRequest) (*DmUser, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchDmUser not implemented")
+}
+func (UnimplementedDmUserServiceServer) DeleteDmUser(context.Context, *DeleteDmUserRequest) (*DeleteDmUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteDmUser not implemented")
+}
+func (UnimplementedDmUserServiceServer) mustEmbedUnimplementedDmUserServiceServer() {}
+func (UnimplementedDmUserServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeDmUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DmUserServiceServer will
+// result in compilation errors.
+type UnsafeDmUserServiceServer interface {
+	mustEmbedUnimplementedDmUserServiceServer()
+}
+
+func RegisterDmUserServiceServer(s grpc.ServiceRegistrar, srv DmUserServiceServer) {
+	// If the following call panics, it indicates UnimplementedDmUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DmUserService_ServiceDesc, srv)
+}
+
+func _DmUserService_CreateDmUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDmUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmUserServiceServer).CreateDmUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmUserService_CreateDmUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmUserServiceServer).CreateDmUser(ctx, req.(*CreateDmUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmUserService_GetDmUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDmUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmUserServiceServer).GetDmUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmUserService_GetDmUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmUserServiceServer).GetDmUser(ctx, req.(*GetDmUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmUserService_BatchGetDmUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetDmUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmUserServiceServer).BatchGetDmUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmUserService_BatchGetDmUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmUserServiceServer).BatchGetDmUsers(ctx, req.(*BatchGetDmUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmUserService_ListDmUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDmUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DmUserServiceServer).ListDmUsers(m, &grpc.GenericServerStream[ListDmUsersRequest, DmUser]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DmUserService_ListDmUsersServer = grpc.ServerStreamingServer[DmUser]
+
+func _DmUserService_UpdateDmUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDmUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmUserServiceServer).UpdateDmUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmUserService_UpdateDmUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmUserServiceServer).UpdateDmUser(ctx, req.(*UpdateDmUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmUserService_PatchDmUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchDmUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmUserServiceServer).PatchDmUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmUserService_PatchDmUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmUserServiceServer).PatchDmUser(ctx, req.(*PatchDmUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DmUserService_DeleteDmUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDmUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DmUserServiceServer).DeleteDmUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DmUserService_DeleteDmUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DmUserServiceServer).DeleteDmUser(ctx, req.(*DeleteDmUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DmUserService_ServiceDesc is the grpc.ServiceDesc for DmUserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DmUserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dm.v1.DmUserService",
+	HandlerType: (*DmUserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDmUser",
+			Handler:    _DmUserService_CreateDmUser_Handler,
+		},
+		{
+			MethodName: "GetDmUser",
+			Handler:    _DmUserService_GetDmUser_Handler,
+		},
+		{
+			MethodName: "BatchGetDmUsers",
+			Handler:    _DmUserService_BatchGetDmUsers_Handler,
+		},
+		{
+			MethodName: "UpdateDmUser",
+			Handler:    _DmUserService_UpdateDmUser_Handler,
+		},
+		{
+			MethodName: "PatchDmUser",
+			Handler:    _DmUserService_PatchDmUser_Handler,
+		},
+		{
+			MethodName: "DeleteDmUser",
+			Handler:    _DmUserService_DeleteDmUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListDmUsers",
+			Handler:       _DmUserService_ListDmUsers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dm/v1/dm_user.proto",
+}
@@ -0,0 +1,47 @@
+package grpcapi
+
+import (
+	"os"
+
+	dmv1 "github.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/ratelimit"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// NewServer はユーザー・投稿のサービスを登録したgRPCサーバーを作成
+// RESTのAPIと同じJWT（Public API Key JWT / Auth0などOIDCのIssuerのJWT）で認証し、ヘルスチェックサービスは認証なしで利用できる
+func NewServer(dmUserUsecase *usecaseapi.DmUserUsecase, dmPostUsecase *usecaseapi.DmPostUsecase, apiKeyRegistry auth.APIKeyRegistry, identityResolver auth.IdentityResolver, principalLimiter *ratelimit.PrincipalLimiter, cfg *config.Config) *grpc.Server {
+	// 環境情報を取得
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = "develop"
+	}
+
+	// 認証インターセプターを作成
+	unaryAuth, streamAuth := auth.NewGRPCAuthInterceptors(&cfg.API, env, apiKeyRegistry, identityResolver)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{unaryAuth}
+	streamInterceptors := []grpc.StreamServerInterceptor{streamAuth}
+
+	// 呼び出し元ごとのレートリミット・クォータ（認証の後に適用する）
+	if principalLimiter != nil {
+		unaryLimit, streamLimit := principalLimiter.GRPCInterceptors()
+		unaryInterceptors = append(unaryInterceptors, unaryLimit)
+		streamInterceptors = append(streamInterceptors, streamLimit)
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	dmv1.RegisterDmUserServiceServer(s, NewDmUserServer(dmUserUsecase))
+	dmv1.RegisterDmPostServiceServer(s, NewDmPostServer(dmPostUsecase))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+
+	return s
+}
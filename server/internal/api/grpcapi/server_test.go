@@ -0,0 +1,456 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dmv1 "github.com/taku-o/go-webdb-template/internal/api/grpcapi/gen/dm/v1"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testSecretKey = "test-secret-key-for-jwt-signing"
+	testUserID    = "0123456789abcdef0123456789abcdef"
+	testPostID    = "fedcba9876543210fedcba9876543210"
+)
+
+// mockDmUserService はDmUserServiceInterfaceのモック
+type mockDmUserService struct {
+	createFunc    func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error)
+	getFunc       func(ctx context.Context, id string) (*model.DmUser, error)
+	batchGetFunc  func(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error)
+	listFunc      func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
+	updateFunc    func(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error)
+	patchFunc     func(ctx context.Context, id string, patch []byte) (*model.DmUser, error)
+	deleteFunc    func(ctx context.Context, id string) error
+	emailExistsFn func(ctx context.Context, email string) (bool, error)
+}
+
+func (m *mockDmUserService) CreateDmUser(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+	return m.createFunc(ctx, req)
+}
+
+func (m *mockDmUserService) GetDmUser(ctx context.Context, id string) (*model.DmUser, error) {
+	return m.getFunc(ctx, id)
+}
+
+func (m *mockDmUserService) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	return m.batchGetFunc(ctx, ids)
+}
+
+func (m *mockDmUserService) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
+	return m.listFunc(ctx, limit, offset, filter, sort)
+}
+
+func (m *mockDmUserService) UpdateDmUser(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error) {
+	return m.updateFunc(ctx, id, req)
+}
+
+func (m *mockDmUserService) PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+	return m.patchFunc(ctx, id, patch)
+}
+
+func (m *mockDmUserService) DeleteDmUser(ctx context.Context, id string) error {
+	return m.deleteFunc(ctx, id)
+}
+
+func (m *mockDmUserService) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	return m.emailExistsFn(ctx, email)
+}
+
+// mockDmPostService はDmPostServiceInterfaceのモック
+type mockDmPostService struct {
+	createFunc      func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
+	getFunc         func(ctx context.Context, id string, userID string) (*model.DmPost, error)
+	batchGetFunc    func(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error)
+	listFunc        func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	listByUserFunc  func(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	listByUsersFunc func(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error)
+	userPostsFunc   func(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
+	searchFunc      func(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error)
+	updateFunc      func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
+	patchFunc       func(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error)
+	deleteFunc      func(ctx context.Context, id string, userID string) error
+}
+
+func (m *mockDmPostService) CreateDmPost(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
+	return m.createFunc(ctx, req)
+}
+
+func (m *mockDmPostService) GetDmPost(ctx context.Context, id string, userID string) (*model.DmPost, error) {
+	return m.getFunc(ctx, id, userID)
+}
+
+func (m *mockDmPostService) BatchGetDmPosts(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error) {
+	return m.batchGetFunc(ctx, keys)
+}
+
+func (m *mockDmPostService) ListDmPosts(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+	return m.listFunc(ctx, limit, offset, filter, sort)
+}
+
+func (m *mockDmPostService) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+	return m.listByUserFunc(ctx, userID, limit, offset, filter, sort)
+}
+
+func (m *mockDmPostService) ListDmPostsByUsers(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	return m.listByUsersFunc(ctx, userIDs, limit)
+}
+
+func (m *mockDmPostService) GetDmUserPosts(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error) {
+	return m.userPostsFunc(ctx, limit, offset)
+}
+
+func (m *mockDmPostService) SearchDmPosts(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+	return m.searchFunc(ctx, q)
+}
+
+func (m *mockDmPostService) UpdateDmPost(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error) {
+	return m.updateFunc(ctx, id, userID, req)
+}
+
+func (m *mockDmPostService) PatchDmPost(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error) {
+	return m.patchFunc(ctx, id, userID, patch)
+}
+
+func (m *mockDmPostService) DeleteDmPost(ctx context.Context, id string, userID string) error {
+	return m.deleteFunc(ctx, id, userID)
+}
+
+// startTestServer はbufconn上でgRPCサーバーを起動し、接続を返す
+func startTestServer(t *testing.T, userService *mockDmUserService, postService *mockDmPostService) *grpc.ClientConn {
+	t.Helper()
+
+	cfg := &config.Config{
+		API: config.APIConfig{
+			CurrentVersion:  "v2",
+			SecretKey:       testSecretKey,
+			InvalidVersions: []string{"v1"},
+		},
+	}
+	s := NewServer(
+		usecaseapi.NewDmUserUsecase(userService, nil),
+		usecaseapi.NewDmPostUsecase(postService, nil, nil),
+		nil,
+		nil,
+		nil,
+		cfg,
+	)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// authContext はPublic API Key JWTを付与したコンテキストを返す
+func authContext(t *testing.T) context.Context {
+	t.Helper()
+	token, err := auth.GeneratePublicAPIKey(testSecretKey, "v2", "develop", time.Now().Unix(), "")
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// recvAll はストリームの全メッセージを受信
+func recvAll[T any](t *testing.T, stream grpc.ServerStreamingClient[T]) ([]*T, error) {
+	t.Helper()
+	var items []*T
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+}
+
+func TestServer_Unauthenticated(t *testing.T) {
+	conn := startTestServer(t, &mockDmUserService{}, &mockDmPostService{})
+	client := dmv1.NewDmUserServiceClient(conn)
+
+	_, err := client.GetDmUser(context.Background(), &dmv1.GetDmUserRequest{Id: testUserID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.ListDmUsers(context.Background(), &dmv1.ListDmUsersRequest{})
+	require.NoError(t, err)
+	_, err = recvAll(t, stream)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_HealthCheck(t *testing.T) {
+	conn := startTestServer(t, &mockDmUserService{}, &mockDmPostService{})
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestDmUserServer_CreateDmUser(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	userService := &mockDmUserService{
+		createFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+			return &model.DmUser{ID: testUserID, Name: req.Name, Email: req.Email, CreatedAt: now, UpdatedAt: now}, nil
+		},
+	}
+	client := dmv1.NewDmUserServiceClient(startTestServer(t, userService, &mockDmPostService{}))
+	ctx := authContext(t)
+
+	t.Run("success", func(t *testing.T) {
+		user, err := client.CreateDmUser(ctx, &dmv1.CreateDmUserRequest{Name: "Alice", Email: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, user.GetId())
+		assert.Equal(t, "Alice", user.GetName())
+		assert.Equal(t, "alice@example.com", user.GetEmail())
+		assert.True(t, now.Equal(user.GetCreatedAt().AsTime()))
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		_, err := client.CreateDmUser(ctx, &dmv1.CreateDmUserRequest{Name: "Alice", Email: "not-an-email"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDmUserServer_GetDmUser(t *testing.T) {
+	userService := &mockDmUserService{
+		getFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
+			return nil, fmt.Errorf("%w: %s", apperror.NotFound("user not found"), id)
+		},
+	}
+	client := dmv1.NewDmUserServiceClient(startTestServer(t, userService, &mockDmPostService{}))
+	ctx := authContext(t)
+
+	_, err := client.GetDmUser(ctx, &dmv1.GetDmUserRequest{Id: "short"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetDmUser(ctx, &dmv1.GetDmUserRequest{Id: testUserID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDmUserServer_ListDmUsers(t *testing.T) {
+	var gotLimit, gotOffset int
+	userService := &mockDmUserService{
+		listFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
+			if filter == "bad" {
+				return nil, fmt.Errorf("%w: unknown field", usecaseapi.ErrInvalidListQuery)
+			}
+			gotLimit, gotOffset = limit, offset
+			return []*model.DmUser{
+				{ID: testUserID, Name: "Alice"},
+				{ID: testPostID, Name: "Bob"},
+			}, nil
+		},
+	}
+	client := dmv1.NewDmUserServiceClient(startTestServer(t, userService, &mockDmPostService{}))
+	ctx := authContext(t)
+
+	t.Run("streams each user with default limit", func(t *testing.T) {
+		stream, err := client.ListDmUsers(ctx, &dmv1.ListDmUsersRequest{Offset: 5})
+		require.NoError(t, err)
+		users, err := recvAll(t, stream)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "Alice", users[0].GetName())
+		assert.Equal(t, "Bob", users[1].GetName())
+		assert.Equal(t, 20, gotLimit)
+		assert.Equal(t, 5, gotOffset)
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		stream, err := client.ListDmUsers(ctx, &dmv1.ListDmUsersRequest{Limit: 101})
+		require.NoError(t, err)
+		_, err = recvAll(t, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("offset + limit out of range", func(t *testing.T) {
+		stream, err := client.ListDmUsers(ctx, &dmv1.ListDmUsersRequest{Limit: 20, Offset: int32(model.ListMaxWindow - 10)})
+		require.NoError(t, err)
+		_, err = recvAll(t, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid list query", func(t *testing.T) {
+		stream, err := client.ListDmUsers(ctx, &dmv1.ListDmUsersRequest{Filter: "bad"})
+		require.NoError(t, err)
+		_, err = recvAll(t, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDmUserServer_PatchDmUser(t *testing.T) {
+	userService := &mockDmUserService{
+		patchFunc: func(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+			if string(patch) == "[]" {
+				return nil, fmt.Errorf("%w: must be a JSON object", usecaseapi.ErrInvalidMergePatch)
+			}
+			return &model.DmUser{ID: id, Name: "Patched"}, nil
+		},
+	}
+	client := dmv1.NewDmUserServiceClient(startTestServer(t, userService, &mockDmPostService{}))
+	ctx := authContext(t)
+
+	user, err := client.PatchDmUser(ctx, &dmv1.PatchDmUserRequest{Id: testUserID, MergePatch: `{"name":"Patched"}`})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched", user.GetName())
+
+	_, err = client.PatchDmUser(ctx, &dmv1.PatchDmUserRequest{Id: testUserID, MergePatch: "[]"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDmPostServer_ListDmPosts(t *testing.T) {
+	var gotUserID string
+	postService := &mockDmPostService{
+		listFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+			return []*model.DmPost{{ID: testPostID, UserID: testUserID, Title: "all"}}, nil
+		},
+		listByUserFunc: func(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+			gotUserID = userID
+			return []*model.DmPost{{ID: testPostID, UserID: userID, Title: "by user"}}, nil
+		},
+	}
+	client := dmv1.NewDmPostServiceClient(startTestServer(t, &mockDmUserService{}, postService))
+	ctx := authContext(t)
+
+	stream, err := client.ListDmPosts(ctx, &dmv1.ListDmPostsRequest{})
+	require.NoError(t, err)
+	posts, err := recvAll(t, stream)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "all", posts[0].GetTitle())
+
+	stream, err = client.ListDmPosts(ctx, &dmv1.ListDmPostsRequest{UserId: testUserID})
+	require.NoError(t, err)
+	posts, err = recvAll(t, stream)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "by user", posts[0].GetTitle())
+	assert.Equal(t, testUserID, gotUserID)
+
+	stream, err = client.ListDmPosts(ctx, &dmv1.ListDmPostsRequest{UserId: "short"})
+	require.NoError(t, err)
+	_, err = recvAll(t, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDmPostServer_SearchDmPosts(t *testing.T) {
+	var gotQuery *model.DmPostSearchQuery
+	postService := &mockDmPostService{
+		searchFunc: func(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+			gotQuery = q
+			return []*model.DmPostSearchHit{
+				{DmPost: model.DmPost{ID: testPostID, UserID: testUserID, Title: "go"}, Score: 0.5},
+			}, nil
+		},
+	}
+	client := dmv1.NewDmPostServiceClient(startTestServer(t, &mockDmUserService{}, postService))
+	ctx := authContext(t)
+
+	t.Run("applies defaults", func(t *testing.T) {
+		stream, err := client.SearchDmPosts(ctx, &dmv1.SearchDmPostsRequest{Q: "go"})
+		require.NoError(t, err)
+		hits, err := recvAll(t, stream)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, "go", hits[0].GetPost().GetTitle())
+		assert.Equal(t, 0.5, hits[0].GetScore())
+		assert.Equal(t, &model.DmPostSearchQuery{
+			Query: "go",
+			Mode:  model.DmPostSearchModeWord,
+			Sort:  model.DmPostSearchSortRelevance,
+			Limit: 20,
+		}, gotQuery)
+	})
+
+	tests := []struct {
+		name string
+		req  *dmv1.SearchDmPostsRequest
+	}{
+		{"blank q", &dmv1.SearchDmPostsRequest{Q: "  "}},
+		{"unknown mode", &dmv1.SearchDmPostsRequest{Q: "go", Mode: "regex"}},
+		{"unknown sort", &dmv1.SearchDmPostsRequest{Q: "go", Sort: "title"}},
+		{"window exceeded", &dmv1.SearchDmPostsRequest{Q: "go", Limit: 100, Offset: 950}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.SearchDmPosts(ctx, tt.req)
+			require.NoError(t, err)
+			_, err = recvAll(t, stream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestDmPostServer_BatchGetDmPosts(t *testing.T) {
+	postService := &mockDmPostService{
+		batchGetFunc: func(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error) {
+			if len(keys) == 0 {
+				return nil, fmt.Errorf("%w: keys must not be empty", usecaseapi.ErrInvalidBatchGet)
+			}
+			return &model.DmPostBatchGetResult{
+				Items:   []*model.DmPost{{ID: keys[0].ID, UserID: keys[0].UserID}},
+				Missing: keys[1:],
+			}, nil
+		},
+	}
+	client := dmv1.NewDmPostServiceClient(startTestServer(t, &mockDmUserService{}, postService))
+	ctx := authContext(t)
+
+	resp, err := client.BatchGetDmPosts(ctx, &dmv1.BatchGetDmPostsRequest{Keys: []*dmv1.DmPostKey{
+		{Id: testPostID, UserId: testUserID},
+		{Id: testUserID, UserId: testUserID},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetItems(), 1)
+	assert.Equal(t, testPostID, resp.GetItems()[0].GetId())
+	require.Len(t, resp.GetMissing(), 1)
+	assert.Equal(t, testUserID, resp.GetMissing()[0].GetId())
+
+	_, err = client.BatchGetDmPosts(ctx, &dmv1.BatchGetDmPostsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDmPostServer_DeleteDmPost(t *testing.T) {
+	postService := &mockDmPostService{
+		deleteFunc: func(ctx context.Context, id string, userID string) error {
+			return errors.New("database error")
+		},
+	}
+	client := dmv1.NewDmPostServiceClient(startTestServer(t, &mockDmUserService{}, postService))
+	ctx := authContext(t)
+
+	_, err := client.DeleteDmPost(ctx, &dmv1.DeleteDmPostRequest{Id: testPostID})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeleteDmPost(ctx, &dmv1.DeleteDmPostRequest{Id: testPostID, UserId: testUserID})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
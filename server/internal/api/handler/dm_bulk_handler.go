@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// 一括登録リクエストの制限値
+const (
+	BulkImportMaxBodyBytes    = 64 * 1024 * 1024 // リクエストボディの最大サイズ（64MB）
+	BulkImportBodyReadTimeout = 60 * time.Second // リクエストボディの読み込みタイムアウト
+)
+
+// DmBulkHandler は一括登録APIのハンドラー
+type DmBulkHandler struct {
+	dmBulkImportUsecase *usecaseapi.DmBulkImportUsecase
+}
+
+// NewDmBulkHandler は新しいDmBulkHandlerを作成
+func NewDmBulkHandler(dmBulkImportUsecase *usecaseapi.DmBulkImportUsecase) *DmBulkHandler {
+	return &DmBulkHandler{
+		dmBulkImportUsecase: dmBulkImportUsecase,
+	}
+}
+
+// RegisterDmBulkEndpoints はHuma APIに一括登録エンドポイントを登録
+func RegisterDmBulkEndpoints(api huma.API, h *DmBulkHandler) {
+	// POST /api/dm-users/bulk - ユーザー一括登録
+	huma.Register(api, huma.Operation{
+		OperationID:     "bulk-import-users",
+		Method:          http.MethodPost,
+		Path:            "/api/dm-users/bulk",
+		Summary:         "ユーザーを一括登録",
+		Description:     "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nNDJSON（application/x-ndjson）またはJSON配列（application/json）でユーザーを一括登録します。行数が多い場合は非同期ジョブとして受け付け、202とジョブIDを返します。",
+		Tags:            []string{"users"},
+		MaxBodyBytes:    BulkImportMaxBodyBytes,
+		BodyReadTimeout: BulkImportBodyReadTimeout,
+		Security:        auth.BearerSecurity(auth.ScopeUsersWrite),
+	}, func(ctx context.Context, input *humaapi.BulkImportInput) (*humaapi.BulkImportOutput, error) {
+		return h.importRows(ctx, input, h.dmBulkImportUsecase.ImportDmUsers)
+	})
+
+	// POST /api/dm-posts/bulk - 投稿一括登録
+	huma.Register(api, huma.Operation{
+		OperationID:     "bulk-import-posts",
+		Method:          http.MethodPost,
+		Path:            "/api/dm-posts/bulk",
+		Summary:         "投稿を一括登録",
+		Description:     "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nNDJSON（application/x-ndjson）またはJSON配列（application/json）で投稿を一括登録します。行数が多い場合は非同期ジョブとして受け付け、202とジョブIDを返します。",
+		Tags:            []string{"posts"},
+		MaxBodyBytes:    BulkImportMaxBodyBytes,
+		BodyReadTimeout: BulkImportBodyReadTimeout,
+		Security:        auth.BearerSecurity(auth.ScopePostsWrite),
+	}, func(ctx context.Context, input *humaapi.BulkImportInput) (*humaapi.BulkImportOutput, error) {
+		return h.importRows(ctx, input, h.dmBulkImportUsecase.ImportDmPosts)
+	})
+
+	// GET /api/bulk-jobs/{id} - 一括登録ジョブの状態取得
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bulk-import-job",
+		Method:      http.MethodGet,
+		Path:        "/api/bulk-jobs/{id}",
+		Summary:     "一括登録ジョブの状態を取得",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
+		Tags:        []string{"bulk"},
+		Security:    auth.BearerSecurity(auth.ScopeJobsRead),
+	}, func(ctx context.Context, input *humaapi.GetBulkImportJobInput) (*humaapi.BulkImportJobOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		job, err := h.dmBulkImportUsecase.GetImportJob(ctx, input.ID)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.BulkImportJobOutput{}
+		resp.Body.JobID = job.JobID
+		resp.Body.Status = job.Status
+		resp.Body.Result = job.Result
+		resp.Body.Error = job.Error
+		resp.Body.CompletedAt = job.CompletedAt
+		return resp, nil
+	})
+}
+
+// importRows はリクエストボディを解析し、一括登録を実行
+func (h *DmBulkHandler) importRows(ctx context.Context, input *humaapi.BulkImportInput, importFunc func(ctx context.Context, rows []json.RawMessage) (*usecaseapi.BulkImportOutcome, error)) (*humaapi.BulkImportOutput, error) {
+	// 公開レベルのチェック（publicエンドポイント）
+	if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, huma.Error403Forbidden(err.Error())
+	}
+
+	rows, err := parseBulkImportBody(input.ContentType, input.RawBody)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+
+	outcome, err := importFunc(ctx, rows)
+	if err != nil {
+		if errors.Is(err, usecaseapi.ErrBulkImportTooManyRows) {
+			return nil, huma.NewError(http.StatusRequestEntityTooLarge, err.Error())
+		}
+		return nil, newHTTPError(err)
+	}
+
+	resp := &humaapi.BulkImportOutput{}
+	if outcome.JobID != "" {
+		resp.Status = http.StatusAccepted
+		resp.Location = "/api/bulk-jobs/" + outcome.JobID
+		resp.Body.Status = usecaseapi.BulkImportJobStatusPending
+		resp.Body.JobID = outcome.JobID
+		return resp, nil
+	}
+
+	resp.Status = http.StatusOK
+	resp.Body.Status = usecaseapi.BulkImportJobStatusCompleted
+	resp.Body.Result = outcome.Result
+	return resp, nil
+}
+
+// parseBulkImportBody はNDJSONまたはJSON配列のリクエストボディを行単位に分割
+// Content-Typeがapplication/jsonの場合、または先頭が'['の場合はJSON配列として扱う
+func parseBulkImportBody(contentType string, body []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || trimmed[0] == '[' {
+		var rows []json.RawMessage
+		if err := json.Unmarshal(trimmed, &rows); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %v", err)
+		}
+		return rows, nil
+	}
+
+	// NDJSON: 1行1レコード（空行は無視）
+	var rows []json.RawMessage
+	for i, line := range bytes.Split(trimmed, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return nil, fmt.Errorf("invalid JSON at line %d", i+1)
+		}
+		rows = append(rows, json.RawMessage(line))
+	}
+	return rows, nil
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// TestRegisterDmBulkEndpointsExists はRegisterDmBulkEndpoints関数が存在することを確認
+func TestRegisterDmBulkEndpointsExists(t *testing.T) {
+	var _ func(api huma.API, h *DmBulkHandler) = RegisterDmBulkEndpoints
+}
+
+func TestNewDmBulkHandler(t *testing.T) {
+	dmBulkImportUsecase := usecaseapi.NewDmBulkImportUsecase(nil, nil, nil, nil)
+	handler := NewDmBulkHandler(dmBulkImportUsecase)
+	assert.NotNil(t, handler)
+}
+
+func TestParseBulkImportBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantRows    int
+		wantErr     bool
+	}{
+		{
+			name:        "NDJSON",
+			contentType: "application/x-ndjson",
+			body:        "{\"name\":\"a\"}\n{\"name\":\"b\"}\n",
+			wantRows:    2,
+		},
+		{
+			name:        "NDJSON with blank lines and CRLF",
+			contentType: "application/x-ndjson",
+			body:        "{\"name\":\"a\"}\r\n\r\n{\"name\":\"b\"}\r\n",
+			wantRows:    2,
+		},
+		{
+			name:        "JSON array",
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"name":"a"},{"name":"b"},{"name":"c"}]`,
+			wantRows:    3,
+		},
+		{
+			name:        "JSON array detected by leading bracket",
+			contentType: "",
+			body:        ` [{"name":"a"}]`,
+			wantRows:    1,
+		},
+		{
+			name:        "empty body",
+			contentType: "application/x-ndjson",
+			body:        "  \n",
+			wantRows:    0,
+		},
+		{
+			name:        "invalid NDJSON line",
+			contentType: "application/x-ndjson",
+			body:        "{\"name\":\"a\"}\n{broken\n",
+			wantErr:     true,
+		},
+		{
+			name:        "invalid JSON array",
+			contentType: "application/json",
+			body:        `[{"name":"a"},`,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseBulkImportBody(tt.contentType, []byte(tt.body))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, rows, tt.wantRows)
+		})
+	}
+}
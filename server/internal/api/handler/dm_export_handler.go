@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/storage"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// ExportDownloadWriteTimeout はエクスポートファイル配信時の書き込みタイムアウト
+const ExportDownloadWriteTimeout = 10 * time.Minute
+
+// DmExportHandler はエクスポートAPIのハンドラー
+type DmExportHandler struct {
+	dmExportUsecase *usecaseapi.DmExportUsecase
+}
+
+// NewDmExportHandler は新しいDmExportHandlerを作成
+func NewDmExportHandler(dmExportUsecase *usecaseapi.DmExportUsecase) *DmExportHandler {
+	return &DmExportHandler{
+		dmExportUsecase: dmExportUsecase,
+	}
+}
+
+// RegisterDmExportEndpoints はHuma APIにエクスポートエンドポイントを登録
+func RegisterDmExportEndpoints(api huma.API, h *DmExportHandler) {
+	// POST /api/exports - エクスポートジョブ登録
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-export",
+		Method:        http.MethodPost,
+		Path:          "/api/exports",
+		Summary:       "エクスポートジョブを登録",
+		Description:   "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\ndm_users/dm_postsをCSV・NDJSON・XLSX形式でエクスポートするジョブを登録します。進捗と完了後のダウンロードURLは GET /api/exports/{id} で取得します。",
+		Tags:          []string{"exports"},
+		DefaultStatus: http.StatusAccepted,
+		Security:      auth.BearerSecurity(auth.ScopeExportsWrite),
+	}, func(ctx context.Context, input *humaapi.CreateExportInput) (*humaapi.CreateExportOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		req := &model.ExportRequest{
+			Target: input.Body.Target,
+			Format: input.Body.Format,
+			Fields: input.Body.Fields,
+			Filter: model.ExportFilter{
+				CreatedFrom: input.Body.Filter.CreatedFrom,
+				CreatedTo:   input.Body.Filter.CreatedTo,
+				UserID:      input.Body.Filter.UserID,
+			},
+		}
+
+		jobID, err := h.dmExportUsecase.RequestExport(ctx, req)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.CreateExportOutput{}
+		resp.Location = "/api/exports/" + jobID
+		resp.Body.JobID = jobID
+		resp.Body.Status = usecaseapi.ExportJobStatusPending
+		return resp, nil
+	})
+
+	// GET /api/exports/{id} - エクスポートジョブの状態取得
+	huma.Register(api, huma.Operation{
+		OperationID: "get-export",
+		Method:      http.MethodGet,
+		Path:        "/api/exports/{id}",
+		Summary:     "エクスポートジョブの状態を取得",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\n完了している場合は期限付きのダウンロードURLを返します。URLは取得のたびに新しく発行されます。",
+		Tags:        []string{"exports"},
+		Security:    auth.BearerSecurity(auth.ScopeExportsRead),
+	}, func(ctx context.Context, input *humaapi.GetExportInput) (*humaapi.ExportJobOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		job, err := h.dmExportUsecase.GetExport(ctx, input.ID)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.ExportJobOutput{}
+		resp.Body.JobID = job.JobID
+		resp.Body.Status = job.Status
+		resp.Body.Progress = job.Progress
+		resp.Body.FileName = job.FileName
+		resp.Body.Size = job.Size
+		resp.Body.DownloadURL = job.DownloadURL
+		resp.Body.ExpiresAt = job.ExpiresAt
+		resp.Body.Error = job.Error
+		resp.Body.CompletedAt = job.CompletedAt
+		return resp, nil
+	})
+
+	// GET /exports/download - エクスポートファイルのダウンロード（ローカルストレージのみ）
+	// 署名付きURLで認証するため、JWT認証の対象外のパスに配置する
+	huma.Register(api, huma.Operation{
+		OperationID: "download-export",
+		Method:      http.MethodGet,
+		Path:        storage.LocalExportDownloadPath,
+		Summary:     "エクスポートファイルをダウンロード",
+		Description: "**Access Level:** `signed URL` (エクスポートジョブ取得APIが返す署名付きURLでアクセス可能)\n\nローカルストレージ使用時のみ利用できます。S3使用時はS3の署名付きURLから直接ダウンロードします。",
+		Tags:        []string{"exports"},
+	}, func(ctx context.Context, input *humaapi.DownloadExportInput) (*huma.StreamResponse, error) {
+		f, err := h.dmExportUsecase.OpenDownload(input.Key, input.Name, input.Expires, input.Signature)
+		if err != nil {
+			if errors.Is(err, usecaseapi.ErrInvalidDownloadSignature) {
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			if errors.Is(err, usecaseapi.ErrExportDownloadNotSupported) || errors.Is(err, os.ErrNotExist) {
+				return nil, huma.Error404NotFound("export file not found")
+			}
+			return nil, newHTTPError(err)
+		}
+
+		// ストリーミングレスポンスを返す
+		return &huma.StreamResponse{
+			Body: func(humaCtx huma.Context) {
+				defer f.Close()
+
+				humaCtx.SetHeader("Content-Type", exportDownloadContentType(input.Name))
+				humaCtx.SetHeader("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, input.Name))
+				if info, err := f.Stat(); err == nil {
+					humaCtx.SetHeader("Content-Length", fmt.Sprintf("%d", info.Size()))
+				}
+
+				w := humaCtx.BodyWriter()
+
+				// http.ResponseWriterを取り出してタイムアウトを設定
+				if rw, ok := w.(http.ResponseWriter); ok {
+					rc := http.NewResponseController(rw)
+					if err := rc.SetWriteDeadline(time.Now().Add(ExportDownloadWriteTimeout)); err != nil {
+						log.Printf("Warning: Failed to set write deadline: %v", err)
+					}
+				}
+
+				if _, err := io.Copy(w, f); err != nil {
+					log.Printf("Error writing export file: %v", err)
+				}
+			},
+		}, nil
+	})
+}
+
+// exportDownloadContentType はファイル名の拡張子に対応するContent-Typeを返す
+func exportDownloadContentType(fileName string) string {
+	switch path.Ext(fileName) {
+	case "." + model.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case "." + model.ExportFormatNDJSON:
+		return "application/x-ndjson"
+	case "." + model.ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// TestRegisterDmExportEndpointsExists はRegisterDmExportEndpoints関数が存在することを確認
+func TestRegisterDmExportEndpointsExists(t *testing.T) {
+	var _ func(api huma.API, h *DmExportHandler) = RegisterDmExportEndpoints
+}
+
+func TestNewDmExportHandler(t *testing.T) {
+	dmExportUsecase := usecaseapi.NewDmExportUsecase(nil, nil, nil, &config.ExportConfig{})
+	handler := NewDmExportHandler(dmExportUsecase)
+	assert.NotNil(t, handler)
+}
+
+func TestExportDownloadContentType(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     string
+	}{
+		{"dm_users-20260101T000000Z.csv", "text/csv; charset=utf-8"},
+		{"dm_posts-20260101T000000Z.ndjson", "application/x-ndjson"},
+		{"dm_users-20260101T000000Z.xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"unknown.bin", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			assert.Equal(t, tt.want, exportDownloadContentType(tt.fileName))
+		})
+	}
+}
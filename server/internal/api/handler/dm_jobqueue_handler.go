@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
 )
 
@@ -48,11 +49,8 @@ func (h *DmJobqueueHandler) RegisterJob(ctx context.Context, req *RegisterJobReq
 	// usecase層でジョブ登録を実行
 	jobID, err := h.dmJobqueueUsecase.RegisterJob(ctx, req.Message, req.DelaySeconds, req.MaxRetry)
 	if err != nil {
-		// Redis接続が利用できない場合のエラーハンドリング
-		if err.Error() == "job queue service is unavailable: Redis is not connected" {
-			return nil, huma.Error503ServiceUnavailable(err.Error())
-		}
-		return nil, huma.Error500InternalServerError(err.Error())
+		// Redis接続が利用できない場合（ErrJobQueueUnavailable）は503を返す
+		return nil, newHTTPError(err)
 	}
 
 	return &RegisterJobResponse{
@@ -73,6 +71,7 @@ func RegisterDmJobqueueEndpoints(api huma.API, h *DmJobqueueHandler) {
 		Description:   "**参考コード**: 将来の本実装に影響しない名前を使用",
 		Tags:          []string{"jobqueue-demo"},
 		DefaultStatus: http.StatusCreated,
+		Security:      auth.BearerSecurity(auth.ScopeJobsWrite),
 	}, func(ctx context.Context, input *RegisterJobInput) (*RegisterJobOutput, error) {
 		resp, err := h.RegisterJob(ctx, &input.Body)
 		if err != nil {
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// DmMeHandler は呼び出し元のユーザーAPIのハンドラー
+type DmMeHandler struct {
+	dmMeUsecase *usecaseapi.DmMeUsecase
+}
+
+// NewDmMeHandler は新しいDmMeHandlerを作成
+func NewDmMeHandler(dmMeUsecase *usecaseapi.DmMeUsecase) *DmMeHandler {
+	return &DmMeHandler{
+		dmMeUsecase: dmMeUsecase,
+	}
+}
+
+// RegisterDmMeEndpoints はHuma APIに呼び出し元のユーザーのエンドポイントを登録
+// OIDCのIssuerで認証したユーザーのみ利用できる（Public APIキーはスコープがないため認証ミドルウェアで拒否される）
+func RegisterDmMeEndpoints(api huma.API, h *DmMeHandler) {
+	// GET /api/me - 呼び出し元のユーザー取得
+	huma.Register(api, huma.Operation{
+		OperationID: "get-me",
+		Method:      http.MethodGet,
+		Path:        "/api/me",
+		Summary:     "呼び出し元のユーザーを取得",
+		Description: "**Access Level:** `public` (OIDCのIssuerのJWT でアクセス可能。Public API Key JWT は不可)\n\n初回アクセス時はJWTの名前・メールアドレスでユーザーを作成して紐づけます。ユーザーと紐づくアカウントの一覧を返します。",
+		Tags:        []string{"me"},
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *struct{}) (*humaapi.DmMeOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		dmMe, err := h.dmMeUsecase.GetDmMe(ctx)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmMeOutput{}
+		resp.Body = *dmMe
+		return resp, nil
+	})
+
+	// POST /api/me/identities - アカウントの紐づけ
+	huma.Register(api, huma.Operation{
+		OperationID:   "link-me-identity",
+		Method:        http.MethodPost,
+		Path:          "/api/me/identities",
+		Summary:       "別のIssuerのアカウントを紐づけ",
+		Description:   "**Access Level:** `public` (OIDCのIssuerのJWT でアクセス可能。Public API Key JWT は不可)\n\n紐づけるアカウントのJWTで本人確認し、呼び出し元のユーザーに紐づけます。他のユーザーに紐づけ済みの場合は409を返します。",
+		Tags:          []string{"me"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *humaapi.LinkDmMeIdentityInput) (*humaapi.DmUserIdentityOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		identity, err := h.dmMeUsecase.LinkDmMeIdentity(ctx, input.Body.Token)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmUserIdentityOutput{}
+		resp.Body = *identity
+		return resp, nil
+	})
+
+	// DELETE /api/me/identities/{id} - アカウントの紐づけ解除
+	huma.Register(api, huma.Operation{
+		OperationID:   "unlink-me-identity",
+		Method:        http.MethodDelete,
+		Path:          "/api/me/identities/{id}",
+		Summary:       "アカウントの紐づけを解除",
+		Description:   "**Access Level:** `public` (OIDCのIssuerのJWT でアクセス可能。Public API Key JWT は不可)\n\n使用中のアカウントの紐づけは解除できません（409）。",
+		Tags:          []string{"me"},
+		DefaultStatus: http.StatusNoContent,
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *humaapi.UnlinkDmMeIdentityInput) (*struct{}, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		if err := h.dmMeUsecase.UnlinkDmMeIdentity(ctx, input.ID); err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		return nil, nil
+	})
+}
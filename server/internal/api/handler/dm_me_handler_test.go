@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// meDmUserRepository はGetByIDのみを実装したDmUserRepositoryInterfaceのモック
+type meDmUserRepository struct {
+	repository.DmUserRepositoryInterface
+}
+
+func (m *meDmUserRepository) GetByID(ctx context.Context, id string) (*model.DmUser, error) {
+	return &model.DmUser{ID: id, Name: "Alice", Email: "alice@example.com"}, nil
+}
+
+// meDmUserIdentityRepository はListByDmUserID・Deleteのみを実装したDmUserIdentityRepositoryInterfaceのモック
+type meDmUserIdentityRepository struct {
+	repository.DmUserIdentityRepositoryInterface
+	identities []*model.DmUserIdentity
+}
+
+func (m *meDmUserIdentityRepository) ListByDmUserID(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+	return m.identities, nil
+}
+
+func (m *meDmUserIdentityRepository) Delete(ctx context.Context, dmUserID string, id int64) error {
+	for _, identity := range m.identities {
+		if identity.ID == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d", repository.ErrDmUserIdentityNotFound, id)
+}
+
+// newDmMeTestAPI は指定した呼び出し元を設定したテスト用APIに呼び出し元のユーザーのエンドポイントを登録
+func newDmMeTestAPI(t *testing.T, principal *auth.Principal) humatest.TestAPI {
+	_, api := humatest.New(t)
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		ctx = huma.WithValue(ctx, auth.AllowedAccessLevelKey, auth.AccessLevelPublic)
+		next(huma.WithValue(ctx, auth.PrincipalKey, principal))
+	})
+	identityRepo := &meDmUserIdentityRepository{identities: []*model.DmUserIdentity{
+		{ID: 1, Issuer: "https://example.auth0.com/", Subject: "auth0|123", DmUserID: "user1"},
+		{ID: 2, Issuer: "https://keycloak.example.com/realms/app", Subject: "456", DmUserID: "user1"},
+	}}
+	u := usecaseapi.NewDmMeUsecase(service.NewDmUserService(&meDmUserRepository{}), service.NewDmUserIdentityService(identityRepo), nil, nil, 0)
+	RegisterDmMeEndpoints(api, NewDmMeHandler(u))
+	return api
+}
+
+func TestDmMeHandler_Get(t *testing.T) {
+	api := newDmMeTestAPI(t, &auth.Principal{Issuer: "https://example.auth0.com/", Subject: "auth0|123", Type: auth.PrincipalTypeUser, UserID: "user1"})
+
+	resp := api.Get("/api/me")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Contains(t, resp.Body.String(), `"email":"alice@example.com"`)
+	assert.Contains(t, resp.Body.String(), `"subject":"456"`)
+
+	// 紐づいていないユーザー
+	api = newDmMeTestAPI(t, &auth.Principal{Issuer: "https://example.auth0.com/", Subject: "auth0|789", Type: auth.PrincipalTypeUser})
+	resp = api.Get("/api/me")
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestDmMeHandler_Unlink(t *testing.T) {
+	api := newDmMeTestAPI(t, &auth.Principal{Issuer: "https://example.auth0.com/", Subject: "auth0|123", Type: auth.PrincipalTypeUser, UserID: "user1"})
+
+	resp := api.Delete("/api/me/identities/2")
+	assert.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+
+	// 使用中のアカウント
+	resp = api.Delete("/api/me/identities/1")
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = api.Delete("/api/me/identities/99")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestDmMeHandler_Link_NotConfigured(t *testing.T) {
+	api := newDmMeTestAPI(t, &auth.Principal{Issuer: "https://example.auth0.com/", Subject: "auth0|123", Type: auth.PrincipalTypeUser, UserID: "user1"})
+
+	resp := api.Post("/api/me/identities", map[string]any{"token": "token"})
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+}
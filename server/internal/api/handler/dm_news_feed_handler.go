@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// DmNewsFeedHandler はニュースフィード（RSS / Atom）のハンドラー
+type DmNewsFeedHandler struct {
+	dmNewsFeedUsecase *usecaseapi.DmNewsFeedUsecase
+}
+
+// NewDmNewsFeedHandler は新しいDmNewsFeedHandlerを作成
+func NewDmNewsFeedHandler(dmNewsFeedUsecase *usecaseapi.DmNewsFeedUsecase) *DmNewsFeedHandler {
+	return &DmNewsFeedHandler{
+		dmNewsFeedUsecase: dmNewsFeedUsecase,
+	}
+}
+
+// RegisterDmNewsFeedEndpoints はHuma APIにニュースフィードエンドポイントを登録
+// フィードリーダーから取得されるため、JWT認証の対象外のパスに配置する
+func RegisterDmNewsFeedEndpoints(api huma.API, h *DmNewsFeedHandler) {
+	feeds := []struct {
+		operationID string
+		path        string
+		format      string
+		summary     string
+	}{
+		{"get-news-rss", model.DmNewsFeedRSSPath, model.DmNewsFeedFormatRSS, "公開済みニュースのRSSフィードを取得"},
+		{"get-news-atom", model.DmNewsFeedAtomPath, model.DmNewsFeedFormatAtom, "公開済みニュースのAtomフィードを取得"},
+	}
+
+	for _, feed := range feeds {
+		format := feed.format
+
+		// GET /feeds/news.rss, /feeds/news.atom - ニュースフィード取得
+		huma.Register(api, huma.Operation{
+			OperationID: feed.operationID,
+			Method:      http.MethodGet,
+			Path:        feed.path,
+			Summary:     feed.summary,
+			Description: "**Access Level:** `none` (認証不要)\n\n公開日時が現在日時以前のニュースを新しい順に返します。ETag（If-None-Match）による条件付きGETに対応し、次に公開予定のニュースの公開日時までキャッシュできます。",
+			Tags:        []string{"news"},
+		}, func(ctx context.Context, input *humaapi.GetDmNewsFeedInput) (*huma.StreamResponse, error) {
+			dmNewsFeed, err := h.dmNewsFeedUsecase.GetDmNewsFeed(ctx, format)
+			if err != nil {
+				return nil, newHTTPError(err)
+			}
+
+			headers := dmNewsFeedCacheHeaders(dmNewsFeed, time.Now())
+			if dmNewsFeedNotModified(input, dmNewsFeed) {
+				return nil, huma.ErrorWithHeaders(huma.Status304NotModified(), headers)
+			}
+
+			// ストリーミングレスポンスを返す
+			return &huma.StreamResponse{
+				Body: func(humaCtx huma.Context) {
+					for name, values := range headers {
+						humaCtx.SetHeader(name, values[0])
+					}
+					humaCtx.SetHeader("Content-Type", dmNewsFeed.ContentType)
+					humaCtx.SetHeader("Content-Length", strconv.Itoa(len(dmNewsFeed.Body)))
+
+					if _, err := humaCtx.BodyWriter().Write(dmNewsFeed.Body); err != nil {
+						log.Printf("Error writing news feed: %v", err)
+					}
+				},
+			}, nil
+		})
+	}
+}
+
+// dmNewsFeedCacheHeaders はフィードのキャッシュ関連ヘッダーを返す
+// 次に公開予定のニュースがある場合はその公開日時までキャッシュさせる
+func dmNewsFeedCacheHeaders(feed *model.DmNewsFeed, now time.Time) http.Header {
+	maxAge := int(feed.CacheUntil.Sub(now).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	headers := http.Header{}
+	headers.Set("ETag", `"`+feed.ETag+`"`)
+	if !feed.LastModified.IsZero() {
+		headers.Set("Last-Modified", feed.LastModified.UTC().Format(http.TimeFormat))
+	}
+	headers.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	headers.Set("Expires", feed.CacheUntil.UTC().Format(http.TimeFormat))
+	return headers
+}
+
+// dmNewsFeedNotModified は条件付きGETでクライアントのキャッシュが有効か判定する
+// Last-Modifiedは最新のアイテムの日時のため、ニュースの削除や非公開化では変わらない
+// そのためETagがある場合はIf-None-Matchのみで判定し、If-Modified-SinceはETagがない場合のみ評価する
+func dmNewsFeedNotModified(input *humaapi.GetDmNewsFeedInput, feed *model.DmNewsFeed) bool {
+	if feed.ETag != "" {
+		for _, value := range input.IfNoneMatch {
+			for _, etag := range strings.Split(value, ",") {
+				etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+				if etag == "*" || strings.Trim(etag, `"`) == feed.ETag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if input.IfModifiedSince.IsZero() || feed.LastModified.IsZero() {
+		return false
+	}
+	// Last-Modifiedは秒単位で返しているため、秒未満を切り捨てて比較する
+	return !feed.LastModified.Truncate(time.Second).After(input.IfModifiedSince)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// feedDmNewsRepository はフィード取得のみを実装したDmNewsRepositoryInterfaceのモック
+type feedDmNewsRepository struct {
+	repository.DmNewsRepositoryInterface
+	items         []*model.DmNewsFeedItem
+	nextPublishAt *time.Time
+}
+
+func (m *feedDmNewsRepository) ListFeedItems(ctx context.Context, now time.Time, limit int) ([]*model.DmNewsFeedItem, error) {
+	return m.items, nil
+}
+
+func (m *feedDmNewsRepository) GetNextPublishAt(ctx context.Context, now time.Time) (*time.Time, error) {
+	return m.nextPublishAt, nil
+}
+
+// newDmNewsFeedTestAPI はテスト用APIにニュースフィードエンドポイントを登録（認証ミドルウェアなし）
+func newDmNewsFeedTestAPI(t *testing.T, repo *feedDmNewsRepository) humatest.TestAPI {
+	_, api := humatest.New(t)
+	feedUsecase := usecaseapi.NewDmNewsFeedUsecase(service.NewDmNewsFeedService(repo), &config.FeedConfig{
+		Title:       "News",
+		SiteURL:     "https://example.com",
+		ItemLimit:   20,
+		MaxCacheAge: time.Hour,
+	})
+	RegisterDmNewsFeedEndpoints(api, NewDmNewsFeedHandler(feedUsecase))
+	return api
+}
+
+// TestRegisterDmNewsFeedEndpointsExists はRegisterDmNewsFeedEndpoints関数が存在することを確認
+func TestRegisterDmNewsFeedEndpointsExists(t *testing.T) {
+	var _ func(api huma.API, h *DmNewsFeedHandler) = RegisterDmNewsFeedEndpoints
+}
+
+func TestDmNewsFeedHandler_Get(t *testing.T) {
+	published := time.Now().Add(-time.Hour).Truncate(time.Second)
+	next := time.Now().Add(10 * time.Minute)
+	api := newDmNewsFeedTestAPI(t, &feedDmNewsRepository{
+		items:         []*model.DmNewsFeedItem{{ID: 1, Title: "First", Content: "Body", PublishedAt: published, UpdatedAt: published}},
+		nextPublishAt: &next,
+	})
+
+	resp := api.Get("/feeds/news.rss")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Equal(t, "application/rss+xml; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Body.String(), `<guid isPermaLink="true">https://example.com/news/1</guid>`)
+	assert.NotEmpty(t, resp.Header().Get("ETag"))
+	assert.Equal(t, published.UTC().Format(http.TimeFormat), resp.Header().Get("Last-Modified"))
+
+	// 次の公開予定までキャッシュさせる
+	cacheControl := resp.Header().Get("Cache-Control")
+	require.True(t, strings.HasPrefix(cacheControl, "public, max-age="), cacheControl)
+	maxAge, err := strconv.Atoi(strings.TrimPrefix(cacheControl, "public, max-age="))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, maxAge, 600)
+	assert.Greater(t, maxAge, 500)
+
+	resp = api.Get("/feeds/news.atom")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Equal(t, "application/atom+xml; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Body.String(), `<id>https://example.com/news/1</id>`)
+}
+
+func TestDmNewsFeedHandler_ConditionalGet(t *testing.T) {
+	published := time.Now().Add(-time.Hour).Truncate(time.Second)
+	api := newDmNewsFeedTestAPI(t, &feedDmNewsRepository{
+		items: []*model.DmNewsFeedItem{{ID: 1, Title: "First", Content: "Body", PublishedAt: published, UpdatedAt: published}},
+	})
+
+	resp := api.Get("/feeds/news.rss")
+	require.Equal(t, http.StatusOK, resp.Code)
+	etag := resp.Header().Get("ETag")
+	lastModified := resp.Header().Get("Last-Modified")
+
+	tests := []struct {
+		name     string
+		headers  []any
+		wantCode int
+	}{
+		{name: "ETagが一致", headers: []any{"If-None-Match: " + etag}, wantCode: http.StatusNotModified},
+		{name: "弱いETagでも一致", headers: []any{`If-None-Match: "other", W/` + etag}, wantCode: http.StatusNotModified},
+		{name: "ETagが不一致", headers: []any{`If-None-Match: "other"`}, wantCode: http.StatusOK},
+		// 削除・非公開化はLast-Modifiedに反映されないため、ETagがある場合はIf-Modified-Sinceを評価しない
+		{name: "If-Modified-Sinceのみ", headers: []any{"If-Modified-Since: " + lastModified}, wantCode: http.StatusOK},
+		{name: "ETag不一致を優先", headers: []any{`If-None-Match: "other"`, "If-Modified-Since: " + lastModified}, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := api.Get("/feeds/news.rss", tt.headers...)
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantCode == http.StatusNotModified {
+				assert.Empty(t, resp.Body.String())
+				assert.Equal(t, etag, resp.Header().Get("ETag"))
+				assert.NotEmpty(t, resp.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestDmNewsFeedNotModified_WithoutETag(t *testing.T) {
+	lastModified := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	feed := &model.DmNewsFeed{LastModified: lastModified}
+
+	// ETagがない場合のみIf-Modified-Sinceで判定する
+	assert.True(t, dmNewsFeedNotModified(&humaapi.GetDmNewsFeedInput{IfModifiedSince: lastModified}, feed))
+	assert.False(t, dmNewsFeedNotModified(&humaapi.GetDmNewsFeedInput{IfModifiedSince: lastModified.Add(-time.Second)}, feed))
+	assert.False(t, dmNewsFeedNotModified(&humaapi.GetDmNewsFeedInput{}, feed))
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// DmNewsHandler はニュースAPIのハンドラー
+type DmNewsHandler struct {
+	dmNewsUsecase *usecaseapi.DmNewsUsecase
+}
+
+// NewDmNewsHandler は新しいDmNewsHandlerを作成
+func NewDmNewsHandler(dmNewsUsecase *usecaseapi.DmNewsUsecase) *DmNewsHandler {
+	return &DmNewsHandler{
+		dmNewsUsecase: dmNewsUsecase,
+	}
+}
+
+// RegisterDmNewsEndpoints はHuma APIにニュースエンドポイントを登録
+// 一覧・取得は公開済みのニュースのみを返すpublic API、作成・更新・削除はprivate API
+func RegisterDmNewsEndpoints(api huma.API, h *DmNewsHandler) {
+	// GET /api/dm-news - 公開済みニュース一覧取得
+	huma.Register(api, huma.Operation{
+		OperationID: "list-news",
+		Method:      http.MethodGet,
+		Path:        "/api/dm-news",
+		Summary:     "公開済みニュース一覧を取得",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\n`published_at` が現在日時以前のニュースを公開日時の新しい順に返します。",
+		Tags:        []string{"news"},
+		Security:    auth.BearerSecurity(auth.ScopeNewsRead),
+	}, func(ctx context.Context, input *humaapi.ListDmNewsInput) (*humaapi.DmNewsListOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		var authorID *int64
+		if input.AuthorID > 0 {
+			authorID = &input.AuthorID
+		}
+
+		dmNews, err := h.dmNewsUsecase.ListPublishedDmNews(ctx, authorID, input.Limit, input.Offset)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmNewsListOutput{}
+		resp.Body = dmNews
+		return resp, nil
+	})
+
+	// GET /api/dm-news/{id} - 公開済みニュース取得
+	huma.Register(api, huma.Operation{
+		OperationID: "get-news",
+		Method:      http.MethodGet,
+		Path:        "/api/dm-news/{id}",
+		Summary:     "公開済みニュースを取得",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\n非公開・公開日時前のニュースは404を返します。",
+		Tags:        []string{"news"},
+		Security:    auth.BearerSecurity(auth.ScopeNewsRead),
+	}, func(ctx context.Context, input *humaapi.GetDmNewsInput) (*humaapi.DmNewsOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		dmNews, err := h.dmNewsUsecase.GetPublishedDmNews(ctx, input.ID)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmNewsOutput{}
+		resp.Body = *dmNews
+		return resp, nil
+	})
+
+	// POST /api/dm-news - ニュース作成（private API）
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-news",
+		Method:        http.MethodPost,
+		Path:          "/api/dm-news",
+		Summary:       "[private] ニュースを作成",
+		Description:   "**Access Level:** `private` (Auth0 JWT でアクセス可能)\n\n`published_at` を省略すると非公開、未来の日時を指定するとその日時に公開されます。",
+		Tags:          []string{"news"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *humaapi.CreateDmNewsInput) (*humaapi.DmNewsOutput, error) {
+		// 公開レベルのチェック（privateエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPrivate); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		req := &model.CreateDmNewsRequest{
+			Title:       input.Body.Title,
+			Content:     input.Body.Content,
+			AuthorID:    input.Body.AuthorID,
+			PublishedAt: input.Body.PublishedAt,
+		}
+
+		dmNews, err := h.dmNewsUsecase.CreateDmNews(ctx, req)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmNewsOutput{}
+		resp.Body = *dmNews
+		return resp, nil
+	})
+
+	// PUT /api/dm-news/{id} - ニュース更新（private API）
+	huma.Register(api, huma.Operation{
+		OperationID: "update-news",
+		Method:      http.MethodPut,
+		Path:        "/api/dm-news/{id}",
+		Summary:     "[private] ニュースを更新",
+		Description: "**Access Level:** `private` (Auth0 JWT でアクセス可能)",
+		Tags:        []string{"news"},
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *humaapi.UpdateDmNewsInput) (*humaapi.DmNewsOutput, error) {
+		// 公開レベルのチェック（privateエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPrivate); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		req := &model.UpdateDmNewsRequest{
+			Title:       input.Body.Title,
+			Content:     input.Body.Content,
+			AuthorID:    input.Body.AuthorID,
+			PublishedAt: input.Body.PublishedAt,
+		}
+
+		dmNews, err := h.dmNewsUsecase.UpdateDmNews(ctx, input.ID, req)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmNewsOutput{}
+		resp.Body = *dmNews
+		return resp, nil
+	})
+
+	// PATCH /api/dm-news/{id} - ニュース部分更新（JSON Merge Patch、private API）
+	huma.Register(api, huma.Operation{
+		OperationID: "patch-news",
+		Method:      http.MethodPatch,
+		Path:        "/api/dm-news/{id}",
+		Summary:     "[private] ニュースを部分更新",
+		Description: "**Access Level:** `private` (Auth0 JWT でアクセス可能)\n\nJSON Merge Patch（RFC 7396）で指定したフィールドのみを更新します。`author_id`・`published_at` は null を指定するとクリアされます。",
+		Tags:        []string{"news"},
+		// 更新内容はサービス層でRFC 7396として検証するため、スキーマによる検証は行わない
+		SkipValidateBody: true,
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *humaapi.PatchDmNewsInput) (*humaapi.DmNewsOutput, error) {
+		// 公開レベルのチェック（privateエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPrivate); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		dmNews, err := h.dmNewsUsecase.PatchDmNews(ctx, input.ID, input.RawBody)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmNewsOutput{}
+		resp.Body = *dmNews
+		return resp, nil
+	})
+
+	// DELETE /api/dm-news/{id} - ニュース削除（private API）
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-news",
+		Method:        http.MethodDelete,
+		Path:          "/api/dm-news/{id}",
+		Summary:       "[private] ニュースを削除",
+		Description:   "**Access Level:** `private` (Auth0 JWT でアクセス可能)",
+		Tags:          []string{"news"},
+		DefaultStatus: http.StatusNoContent,
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+		},
+	}, func(ctx context.Context, input *humaapi.DeleteDmNewsInput) (*struct{}, error) {
+		// 公開レベルのチェック（privateエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPrivate); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		if err := h.dmNewsUsecase.DeleteDmNews(ctx, input.ID); err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		return nil, nil
+	})
+}
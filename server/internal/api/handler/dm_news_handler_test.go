@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// MockDmNewsRepository はDmNewsRepositoryInterfaceのモック
+type MockDmNewsRepository struct {
+	repository.DmNewsRepositoryInterface
+	Fields    map[string]interface{}
+	Published []*model.DmNews
+	Query     *model.DmNewsListQuery
+}
+
+func (m *MockDmNewsRepository) GetByID(ctx context.Context, id int64) (*model.DmNews, error) {
+	for _, news := range m.Published {
+		if news.ID == id {
+			return news, nil
+		}
+	}
+	return &model.DmNews{ID: id, Title: "News", Content: "Content"}, nil
+}
+
+func (m *MockDmNewsRepository) ListPublished(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error) {
+	m.Query = q
+	return m.Published, nil
+}
+
+func (m *MockDmNewsRepository) Patch(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error) {
+	m.Fields = fields
+	news := &model.DmNews{ID: id, Title: "News", Content: "Content"}
+	if title, ok := fields["title"].(string); ok {
+		news.Title = title
+	}
+	return news, nil
+}
+
+// newDmNewsTestAPI は指定したアクセスレベルを設定したテスト用APIにニュースエンドポイントを登録
+func newDmNewsTestAPI(t *testing.T, repo *MockDmNewsRepository, level auth.AccessLevel) humatest.TestAPI {
+	_, api := humatest.New(t)
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		next(huma.WithValue(ctx, auth.AllowedAccessLevelKey, level))
+	})
+	RegisterDmNewsEndpoints(api, NewDmNewsHandler(usecaseapi.NewDmNewsUsecase(service.NewDmNewsService(repo), nil)))
+	return api
+}
+
+// TestRegisterDmNewsEndpointsExists はRegisterDmNewsEndpoints関数が存在することを確認
+func TestRegisterDmNewsEndpointsExists(t *testing.T) {
+	var _ func(api huma.API, h *DmNewsHandler) = RegisterDmNewsEndpoints
+}
+
+func TestDmNewsHandler_Patch(t *testing.T) {
+	repo := &MockDmNewsRepository{}
+	api := newDmNewsTestAPI(t, repo, auth.AccessLevelPrivate)
+
+	resp := api.Patch("/api/dm-news/1", "Content-Type: application/merge-patch+json",
+		strings.NewReader(`{"title":"Patched","author_id":null}`))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Contains(t, resp.Body.String(), `"title":"Patched"`)
+	assert.Equal(t, map[string]interface{}{"title": "Patched", "author_id": nil}, repo.Fields)
+}
+
+func TestDmNewsHandler_Patch_Invalid(t *testing.T) {
+	api := newDmNewsTestAPI(t, &MockDmNewsRepository{}, auth.AccessLevelPrivate)
+
+	resp := api.Patch("/api/dm-news/1", "Content-Type: application/merge-patch+json",
+		strings.NewReader(`{"content":null}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+}
+
+func TestDmNewsHandler_Patch_OpenAPI(t *testing.T) {
+	api := newDmNewsTestAPI(t, &MockDmNewsRepository{}, auth.AccessLevelPrivate)
+
+	op := api.OpenAPI().Paths["/api/dm-news/{id}"].Patch
+	require.NotNil(t, op)
+	assert.Contains(t, op.RequestBody.Content, "application/merge-patch+json")
+}
+
+func TestDmNewsHandler_Patch_PublicForbidden(t *testing.T) {
+	api := newDmNewsTestAPI(t, &MockDmNewsRepository{}, auth.AccessLevelPublic)
+
+	resp := api.Patch("/api/dm-news/1", "Content-Type: application/merge-patch+json",
+		strings.NewReader(`{"title":"Patched"}`))
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	// 作成・削除もprivate API
+	resp = api.Post("/api/dm-news", map[string]interface{}{"title": "News", "content": "Content"})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	resp = api.Delete("/api/dm-news/1")
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestDmNewsHandler_List(t *testing.T) {
+	publishedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	repo := &MockDmNewsRepository{Published: []*model.DmNews{{ID: 1, Title: "Published", PublishedAt: &publishedAt}}}
+	api := newDmNewsTestAPI(t, repo, auth.AccessLevelPublic)
+
+	resp := api.Get("/api/dm-news?author_id=7&limit=5")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Contains(t, resp.Body.String(), `"title":"Published"`)
+	require.NotNil(t, repo.Query)
+	require.NotNil(t, repo.Query.AuthorID)
+	assert.Equal(t, int64(7), *repo.Query.AuthorID)
+	assert.Equal(t, 5, repo.Query.Limit)
+	assert.False(t, repo.Query.Now.IsZero())
+
+	// author_id省略時は作成者で絞り込まない
+	resp = api.Get("/api/dm-news")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Nil(t, repo.Query.AuthorID)
+}
+
+func TestDmNewsHandler_Get_Unpublished(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	repo := &MockDmNewsRepository{Published: []*model.DmNews{{ID: 2, Title: "Scheduled", PublishedAt: &future}}}
+	api := newDmNewsTestAPI(t, repo, auth.AccessLevelPublic)
+
+	// 公開日時前のニュースは404
+	resp := api.Get("/api/dm-news/2")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	// 公開日時未設定のニュースも404
+	resp = api.Get("/api/dm-news/3")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
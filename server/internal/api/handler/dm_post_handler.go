@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
@@ -34,9 +36,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		Description:   "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:          []string{"posts"},
 		DefaultStatus: http.StatusCreated,
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:      auth.BearerSecurity(auth.ScopePostsWrite),
 	}, func(ctx context.Context, input *humaapi.CreateDmPostInput) (*humaapi.DmPostOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -56,7 +56,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 
 		dmPost, err := h.dmPostUsecase.CreateDmPost(ctx, req)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmPostOutput{}
@@ -64,6 +64,44 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		return resp, nil
 	})
 
+	// GET /api/dm-posts/search - 投稿全文検索
+	huma.Register(api, huma.Operation{
+		OperationID: "search-posts",
+		Method:      http.MethodGet,
+		Path:        "/api/dm-posts/search",
+		Summary:     "投稿を全文検索",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nタイトルと内容を全シャードテーブルから全文検索し、関連度順または新しい順にマージして返します。",
+		Tags:        []string{"posts"},
+		Security:    auth.BearerSecurity(auth.ScopePostsRead),
+	}, func(ctx context.Context, input *humaapi.SearchDmPostsInput) (*humaapi.DmPostSearchOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		if strings.TrimSpace(input.Q) == "" {
+			return nil, huma.Error400BadRequest("q must not be blank")
+		}
+		if input.Offset+input.Limit > model.DmPostSearchMaxWindow {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("offset + limit must be less than or equal to %d", model.DmPostSearchMaxWindow))
+		}
+
+		hits, err := h.dmPostUsecase.SearchDmPosts(ctx, &model.DmPostSearchQuery{
+			Query:  input.Q,
+			Mode:   input.Mode,
+			Sort:   input.Sort,
+			Limit:  input.Limit,
+			Offset: input.Offset,
+		})
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmPostSearchOutput{}
+		resp.Body = hits
+		return resp, nil
+	})
+
 	// GET /api/dm-posts/{id} - 投稿取得
 	huma.Register(api, huma.Operation{
 		OperationID: "get-post",
@@ -72,9 +110,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		Summary:     "投稿を取得",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"posts"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopePostsRead),
 	}, func(ctx context.Context, input *humaapi.GetDmPostInput) (*humaapi.DmPostOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -91,7 +127,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 
 		dmPost, err := h.dmPostUsecase.GetDmPost(ctx, input.ID, input.UserID)
 		if err != nil {
-			return nil, huma.Error404NotFound(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmPostOutput{}
@@ -99,23 +135,50 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		return resp, nil
 	})
 
-	// GET /api/dm-posts - 投稿一覧取得
+	// POST /api/dm-posts/batch-get - 投稿一括取得
 	huma.Register(api, huma.Operation{
+		OperationID: "batch-get-posts",
+		Method:      http.MethodPost,
+		Path:        "/api/dm-posts/batch-get",
+		Summary:     "投稿を一括取得",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\n指定した投稿IDとユーザーIDの組の投稿をリクエストの順序で返します。見つからなかった組は `missing` に含まれます。",
+		Tags:        []string{"posts"},
+		Security:    auth.BearerSecurity(auth.ScopePostsRead),
+	}, func(ctx context.Context, input *humaapi.BatchGetDmPostsInput) (*humaapi.DmPostBatchGetOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		result, err := h.dmPostUsecase.BatchGetDmPosts(ctx, input.Body.Keys)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmPostBatchGetOutput{}
+		resp.Body = *result
+		return resp, nil
+	})
+
+	// GET /api/dm-posts - 投稿一覧取得
+	huma.Register(withListQueryParams(api, model.DmPostListFields), huma.Operation{
 		OperationID: "list-posts",
 		Method:      http.MethodGet,
 		Path:        "/api/dm-posts",
 		Summary:     "投稿一覧を取得",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"posts"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopePostsRead),
 	}, func(ctx context.Context, input *humaapi.ListDmPostsInput) (*humaapi.DmPostsOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
 			return nil, huma.Error403Forbidden(err.Error())
 		}
 
+		if input.Offset+input.Limit > model.ListMaxWindow {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("offset + limit must be less than or equal to %d", model.ListMaxWindow))
+		}
+
 		var dmPosts []*model.DmPost
 		var err error
 
@@ -124,13 +187,13 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 			if len(input.UserID) != 32 {
 				return nil, huma.Error400BadRequest("invalid user_id format: must be 32 characters")
 			}
-			dmPosts, err = h.dmPostUsecase.ListDmPostsByUser(ctx, input.UserID, input.Limit, input.Offset)
+			dmPosts, err = h.dmPostUsecase.ListDmPostsByUser(ctx, input.UserID, input.Limit, input.Offset, input.Filter, input.Sort)
 		} else {
-			dmPosts, err = h.dmPostUsecase.ListDmPosts(ctx, input.Limit, input.Offset)
+			dmPosts, err = h.dmPostUsecase.ListDmPosts(ctx, input.Limit, input.Offset, input.Filter, input.Sort)
 		}
 
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmPostsOutput{}
@@ -146,9 +209,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		Summary:     "投稿を更新",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"posts"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopePostsWrite),
 	}, func(ctx context.Context, input *humaapi.UpdateDmPostInput) (*humaapi.DmPostOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -170,7 +231,42 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 
 		dmPost, err := h.dmPostUsecase.UpdateDmPost(ctx, input.ID, input.UserID, req)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmPostOutput{}
+		resp.Body = *dmPost
+		return resp, nil
+	})
+
+	// PATCH /api/dm-posts/{id} - 投稿部分更新（JSON Merge Patch）
+	huma.Register(api, huma.Operation{
+		OperationID: "patch-post",
+		Method:      http.MethodPatch,
+		Path:        "/api/dm-posts/{id}",
+		Summary:     "投稿を部分更新",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nJSON Merge Patch（RFC 7396）で指定したフィールドのみを更新します。",
+		Tags:        []string{"posts"},
+		// 更新内容はサービス層でRFC 7396として検証するため、スキーマによる検証は行わない
+		SkipValidateBody: true,
+		Security:         auth.BearerSecurity(auth.ScopePostsWrite),
+	}, func(ctx context.Context, input *humaapi.PatchDmPostInput) (*humaapi.DmPostOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		// UUID文字列のバリデーション（32文字であること）
+		if len(input.ID) != 32 {
+			return nil, huma.Error400BadRequest("invalid id format: must be 32 characters")
+		}
+		if len(input.UserID) != 32 {
+			return nil, huma.Error400BadRequest("invalid user_id format: must be 32 characters")
+		}
+
+		dmPost, err := h.dmPostUsecase.PatchDmPost(ctx, input.ID, input.UserID, input.RawBody)
+		if err != nil {
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmPostOutput{}
@@ -187,9 +283,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		Description:   "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:          []string{"posts"},
 		DefaultStatus: http.StatusNoContent,
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:      auth.BearerSecurity(auth.ScopePostsWrite),
 	}, func(ctx context.Context, input *humaapi.DeleteDmPostInput) (*struct{}, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -206,7 +300,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 
 		err := h.dmPostUsecase.DeleteDmPost(ctx, input.ID, input.UserID)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		return nil, nil
@@ -220,9 +314,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 		Summary:     "ユーザーと投稿のJOIN結果を取得",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"posts"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopePostsRead, auth.ScopeUsersRead),
 	}, func(ctx context.Context, input *humaapi.GetDmUserPostsInput) (*humaapi.DmUserPostsOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -231,7 +323,7 @@ func RegisterDmPostEndpoints(api huma.API, h *DmPostHandler) {
 
 		dmUserPosts, err := h.dmPostUsecase.GetDmUserPosts(ctx, input.Limit, input.Offset)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmUserPostsOutput{}
@@ -1,9 +1,20 @@
 package handler
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
 )
 
 // TestRegisterDmPostEndpointsExists はRegisterDmPostEndpoints関数が存在することを確認
@@ -11,3 +22,48 @@ func TestRegisterDmPostEndpointsExists(t *testing.T) {
 	// RegisterDmPostEndpoints関数のシグネチャを確認
 	var _ func(api huma.API, h *DmPostHandler) = RegisterDmPostEndpoints
 }
+
+// batchGetDmPostRepository はGetByKeysのみを実装したDmPostRepositoryInterfaceのモック
+type batchGetDmPostRepository struct {
+	repository.DmPostRepositoryInterface
+	posts map[model.DmPostKey]*model.DmPost
+}
+
+func (m *batchGetDmPostRepository) GetByKeys(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error) {
+	found := make(map[model.DmPostKey]*model.DmPost)
+	for _, key := range keys {
+		if post, ok := m.posts[key]; ok {
+			found[key] = post
+		}
+	}
+	return found, nil
+}
+
+func TestDmPostHandler_BatchGet(t *testing.T) {
+	key := model.DmPostKey{ID: "post-001", UserID: "user-001"}
+	repo := &batchGetDmPostRepository{posts: map[model.DmPostKey]*model.DmPost{
+		key: {ID: key.ID, UserID: key.UserID, Title: "Post 1"},
+	}}
+
+	_, api := humatest.New(t)
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		next(huma.WithValue(ctx, auth.AllowedAccessLevelKey, auth.AccessLevelPublic))
+	})
+	RegisterDmPostEndpoints(api, NewDmPostHandler(usecaseapi.NewDmPostUsecase(service.NewDmPostService(repo, nil), nil, nil)))
+
+	resp := api.Post("/api/dm-posts/batch-get", map[string]interface{}{
+		"keys": []map[string]string{
+			{"id": "post-404", "user_id": "user-001"},
+			{"id": "post-001", "user_id": "user-001"},
+		},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Contains(t, resp.Body.String(), `"title":"Post 1"`)
+	assert.Contains(t, resp.Body.String(), `"missing":[{"id":"post-404","user_id":"user-001"}]`)
+
+	// user_idのないキーはスキーマ検証で拒否される
+	resp = api.Post("/api/dm-posts/batch-get", map[string]interface{}{
+		"keys": []map[string]string{{"id": "post-001"}},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+}
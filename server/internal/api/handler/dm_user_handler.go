@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/csv"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
@@ -37,9 +38,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		Description:   "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:          []string{"users"},
 		DefaultStatus: http.StatusCreated,
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:      auth.BearerSecurity(auth.ScopeUsersWrite),
 	}, func(ctx context.Context, input *humaapi.CreateDmUserInput) (*humaapi.DmUserOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -53,7 +52,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 
 		dmUser, err := h.dmUserUsecase.CreateDmUser(ctx, req)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmUserOutput{}
@@ -67,11 +66,10 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		Method:      http.MethodGet,
 		Path:        "/api/export/dm-users/csv",
 		Summary:     "ユーザー情報をCSV形式でダウンロード",
-		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\n**非推奨:** 先頭20件のみを出力します。全件のエクスポートには POST /api/exports を使用してください。",
 		Tags:        []string{"users"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Deprecated:  true,
+		Security:    auth.BearerSecurity(auth.ScopeUsersRead),
 	}, func(ctx context.Context, input *struct{}) (*huma.StreamResponse, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -79,9 +77,9 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		}
 
 		// ユーザー情報20件を取得
-		users, err := h.dmUserUsecase.ListDmUsers(ctx, 20, 0)
+		users, err := h.dmUserUsecase.ListDmUsers(ctx, 20, 0, "", "")
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		// ストリーミングレスポンスを返す
@@ -143,9 +141,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		Summary:     "ユーザーを取得",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"users"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopeUsersRead),
 	}, func(ctx context.Context, input *humaapi.GetDmUserInput) (*humaapi.DmUserOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -159,7 +155,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 
 		dmUser, err := h.dmUserUsecase.GetDmUser(ctx, input.ID)
 		if err != nil {
-			return nil, huma.Error404NotFound(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmUserOutput{}
@@ -167,26 +163,53 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		return resp, nil
 	})
 
-	// GET /api/dm-users - ユーザー一覧取得
+	// POST /api/dm-users/batch-get - ユーザー一括取得
 	huma.Register(api, huma.Operation{
+		OperationID: "batch-get-users",
+		Method:      http.MethodPost,
+		Path:        "/api/dm-users/batch-get",
+		Summary:     "ユーザーを一括取得",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\n指定したIDのユーザーをリクエストの順序で返します。見つからなかったIDは `missing` に含まれます。",
+		Tags:        []string{"users"},
+		Security:    auth.BearerSecurity(auth.ScopeUsersRead),
+	}, func(ctx context.Context, input *humaapi.BatchGetDmUsersInput) (*humaapi.DmUserBatchGetOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		result, err := h.dmUserUsecase.BatchGetDmUsers(ctx, input.Body.IDs)
+		if err != nil {
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmUserBatchGetOutput{}
+		resp.Body = *result
+		return resp, nil
+	})
+
+	// GET /api/dm-users - ユーザー一覧取得
+	huma.Register(withListQueryParams(api, model.DmUserListFields), huma.Operation{
 		OperationID: "list-users",
 		Method:      http.MethodGet,
 		Path:        "/api/dm-users",
 		Summary:     "ユーザー一覧を取得",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"users"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopeUsersRead),
 	}, func(ctx context.Context, input *humaapi.ListDmUsersInput) (*humaapi.DmUsersOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
 			return nil, huma.Error403Forbidden(err.Error())
 		}
 
-		dmUsers, err := h.dmUserUsecase.ListDmUsers(ctx, input.Limit, input.Offset)
+		if input.Offset+input.Limit > model.ListMaxWindow {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("offset + limit must be less than or equal to %d", model.ListMaxWindow))
+		}
+
+		dmUsers, err := h.dmUserUsecase.ListDmUsers(ctx, input.Limit, input.Offset, input.Filter, input.Sort)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmUsersOutput{}
@@ -202,9 +225,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		Summary:     "ユーザーを更新",
 		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:        []string{"users"},
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:    auth.BearerSecurity(auth.ScopeUsersWrite),
 	}, func(ctx context.Context, input *humaapi.UpdateDmUserInput) (*humaapi.DmUserOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -223,7 +244,39 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 
 		dmUser, err := h.dmUserUsecase.UpdateDmUser(ctx, input.ID, req)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
+		}
+
+		resp := &humaapi.DmUserOutput{}
+		resp.Body = *dmUser
+		return resp, nil
+	})
+
+	// PATCH /api/dm-users/{id} - ユーザー部分更新（JSON Merge Patch）
+	huma.Register(api, huma.Operation{
+		OperationID: "patch-user",
+		Method:      http.MethodPatch,
+		Path:        "/api/dm-users/{id}",
+		Summary:     "ユーザーを部分更新",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nJSON Merge Patch（RFC 7396）で指定したフィールドのみを更新します。",
+		Tags:        []string{"users"},
+		// 更新内容はサービス層でRFC 7396として検証するため、スキーマによる検証は行わない
+		SkipValidateBody: true,
+		Security:         auth.BearerSecurity(auth.ScopeUsersWrite),
+	}, func(ctx context.Context, input *humaapi.PatchDmUserInput) (*humaapi.DmUserOutput, error) {
+		// 公開レベルのチェック（publicエンドポイント）
+		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+
+		// UUID文字列のバリデーション（32文字であること）
+		if len(input.ID) != 32 {
+			return nil, huma.Error400BadRequest("invalid id format: must be 32 characters")
+		}
+
+		dmUser, err := h.dmUserUsecase.PatchDmUser(ctx, input.ID, input.RawBody)
+		if err != nil {
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.DmUserOutput{}
@@ -240,9 +293,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 		Description:   "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:          []string{"users"},
 		DefaultStatus: http.StatusNoContent,
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:      auth.BearerSecurity(auth.ScopeUsersWrite),
 	}, func(ctx context.Context, input *humaapi.DeleteDmUserInput) (*struct{}, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -256,7 +307,7 @@ func RegisterDmUserEndpoints(api huma.API, h *DmUserHandler) {
 
 		err := h.dmUserUsecase.DeleteDmUser(ctx, input.ID)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(err.Error())
+			return nil, newHTTPError(err)
 		}
 
 		return nil, nil
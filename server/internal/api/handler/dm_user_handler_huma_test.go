@@ -1,10 +1,20 @@
 package handler
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
 )
 
 // TestRegisterDmUserEndpointsExists はRegisterDmUserEndpoints関数が存在することを確認
@@ -21,3 +31,59 @@ func TestDmUserHandler_DownloadUsersCSV_ReturnType(t *testing.T) {
 	// この時点でコンパイルが通れば、StreamResponse型が正しく使用されている
 	assert.True(t, true, "StreamResponse type is correctly used")
 }
+
+// batchGetDmUserRepository はGetByIDsのみを実装したDmUserRepositoryInterfaceのモック
+type batchGetDmUserRepository struct {
+	repository.DmUserRepositoryInterface
+	users map[string]*model.DmUser
+}
+
+func (m *batchGetDmUserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*model.DmUser, error) {
+	found := make(map[string]*model.DmUser)
+	for _, id := range ids {
+		if user, ok := m.users[id]; ok {
+			found[id] = user
+		}
+	}
+	return found, nil
+}
+
+// newDmUserTestAPI はpublicアクセスレベルを設定したテスト用APIにユーザーエンドポイントを登録
+func newDmUserTestAPI(t *testing.T, repo repository.DmUserRepositoryInterface) humatest.TestAPI {
+	_, api := humatest.New(t)
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		next(huma.WithValue(ctx, auth.AllowedAccessLevelKey, auth.AccessLevelPublic))
+	})
+	RegisterDmUserEndpoints(api, NewDmUserHandler(usecaseapi.NewDmUserUsecase(service.NewDmUserService(repo), nil)))
+	return api
+}
+
+func TestDmUserHandler_BatchGet(t *testing.T) {
+	api := newDmUserTestAPI(t, &batchGetDmUserRepository{users: map[string]*model.DmUser{
+		"user-001": {ID: "user-001", Name: "User 1"},
+		"user-002": {ID: "user-002", Name: "User 2"},
+	}})
+
+	resp := api.Post("/api/dm-users/batch-get", map[string]interface{}{
+		"ids": []string{"user-002", "user-404", "user-001"},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.JSONEq(t, `{
+		"items": [
+			{"id":"user-002","name":"User 2","email":"","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"},
+			{"id":"user-001","name":"User 1","email":"","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}
+		],
+		"missing": ["user-404"]
+	}`, resp.Body.String())
+}
+
+func TestDmUserHandler_BatchGet_TooMany(t *testing.T) {
+	api := newDmUserTestAPI(t, &batchGetDmUserRepository{})
+
+	ids := make([]string, model.DmBatchGetMaxIDs+1)
+	for i := range ids {
+		ids[i] = "user-001"
+	}
+	resp := api.Post("/api/dm-users/batch-get", map[string]interface{}{"ids": ids})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+}
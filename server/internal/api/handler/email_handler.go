@@ -35,9 +35,7 @@ func RegisterEmailEndpoints(api huma.API, h *EmailHandler) {
 		Description:   "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)",
 		Tags:          []string{"email"},
 		DefaultStatus: http.StatusOK,
-		Security: []map[string][]string{
-			{"bearerAuth": {}},
-		},
+		Security:      auth.BearerSecurity(auth.ScopeEmailSend),
 	}, func(ctx context.Context, input *humaapi.SendEmailInput) (*humaapi.SendEmailOutput, error) {
 		// 公開レベルのチェック（publicエンドポイント）
 		if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
@@ -53,7 +51,7 @@ func RegisterEmailEndpoints(api huma.API, h *EmailHandler) {
 
 		// usecase層でメール送信を実行
 		if err := h.emailUsecase.SendEmail(ctx, input.Body.To, input.Body.Template, input.Body.Data); err != nil {
-			return nil, huma.Error400BadRequest(fmt.Sprintf("failed to send email: %v", err))
+			return nil, newHTTPError(err)
 		}
 
 		resp := &humaapi.SendEmailOutput{}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+)
+
+// httpStatusOf はエラーの種類（apperror）に対応するHTTPステータスを返す（種類がない場合は500）
+func httpStatusOf(err error) int {
+	switch apperror.KindOf(err) {
+	case apperror.ErrForbidden:
+		return http.StatusForbidden
+	case apperror.ErrNotFound:
+		return http.StatusNotFound
+	case apperror.ErrConflict:
+		return http.StatusConflict
+	case apperror.ErrValidation:
+		return http.StatusUnprocessableEntity
+	case apperror.ErrUnavailable:
+		return http.StatusServiceUnavailable
+	case apperror.ErrTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// newHTTPError はユースケースのエラーをHTTPステータスのエラーに変換
+// レスポンスはapplication/problem+json（RFC 7807）で返す
+// 検証エラーはフィールドごとのエラーをerrors（locationはbody.フィールド名）に含める
+// 5xxのエラーはログに記録し、production環境では内部の詳細を含めずにステータスの説明のみを返す
+func newHTTPError(err error) error {
+	var statusErr huma.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr
+	}
+
+	var validationErr *validation.Error
+	if errors.As(err, &validationErr) {
+		details := make([]error, 0, len(validationErr.Fields))
+		for _, f := range validationErr.Fields {
+			details = append(details, &huma.ErrorDetail{Message: f.Message, Location: "body." + f.Field})
+		}
+		return huma.NewError(http.StatusUnprocessableEntity, err.Error(), details...)
+	}
+
+	status := httpStatusOf(err)
+	if status >= http.StatusInternalServerError {
+		logrus.WithError(err).WithField("status", status).Error("api request failed")
+		if os.Getenv("APP_ENV") == "production" {
+			return huma.NewError(status, http.StatusText(status))
+		}
+	}
+	return huma.NewError(status, err.Error())
+}
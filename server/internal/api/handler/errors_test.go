@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+)
+
+// newErrorTestAPI は指定したエラーをnewHTTPErrorで返すエンドポイント（GET /api/error）を登録したテスト用APIを作成
+func newErrorTestAPI(t *testing.T, err error) humatest.TestAPI {
+	_, api := humatest.New(t)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-error",
+		Method:      http.MethodGet,
+		Path:        "/api/error",
+	}, func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		return nil, newHTTPError(err)
+	})
+	return api
+}
+
+func TestNewHTTPError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"forbidden", apperror.Forbidden("not the owner of the user"), http.StatusForbidden},
+		{"not found", fmt.Errorf("failed to get user: %w", apperror.NotFound("user not found")), http.StatusNotFound},
+		{"conflict", apperror.Conflict("email already exists"), http.StatusConflict},
+		{"validation", fmt.Errorf("%w: too many ids", apperror.Validation("invalid batch get request")), http.StatusUnprocessableEntity},
+		{"unavailable", apperror.Unavailable("job queue service is unavailable"), http.StatusServiceUnavailable},
+		{"timeout", fmt.Errorf("failed to query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{"internal", errors.New("failed to connect"), http.StatusInternalServerError},
+		{"status error", huma.Error403Forbidden("forbidden"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := newErrorTestAPI(t, tt.err).Get("/api/error")
+			require.Equal(t, tt.status, resp.Code, resp.Body.String())
+			assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+			assert.Contains(t, resp.Body.String(), tt.err.Error())
+		})
+	}
+}
+
+func TestNewHTTPError_Production(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+
+	// 5xxのエラーは内部の詳細を返さない
+	resp := newErrorTestAPI(t, errors.New("dial tcp 10.0.0.1:5432: connection refused")).Get("/api/error")
+	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "10.0.0.1")
+	assert.Contains(t, resp.Body.String(), `"detail":"Internal Server Error"`)
+
+	resp = newErrorTestAPI(t, apperror.Unavailable("job queue service is unavailable: Redis is not connected")).Get("/api/error")
+	require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "Redis")
+
+	// 4xxのエラーはメッセージを返す
+	resp = newErrorTestAPI(t, fmt.Errorf("%w: abc", apperror.NotFound("user not found"))).Get("/api/error")
+	require.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, resp.Body.String(), "user not found: abc")
+}
+
+func TestNewHTTPError_ValidationFields(t *testing.T) {
+	err := fmt.Errorf("failed to create user: %w", validation.NewFieldError("email", validation.RuleUnique, ""))
+	resp := newErrorTestAPI(t, err).Get("/api/error")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+
+	var body huma.ErrorModel
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "body.email", body.Errors[0].Location)
+	assert.Equal(t, "email is already registered", body.Errors[0].Message)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/taku-o/go-webdb-template/internal/api/graphqlapi"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+)
+
+// GraphQLHandler はGraphQL APIのハンドラー
+type GraphQLHandler struct {
+	executor *graphqlapi.Executor
+}
+
+// NewGraphQLHandler は新しいGraphQLHandlerを作成
+func NewGraphQLHandler(executor *graphqlapi.Executor) *GraphQLHandler {
+	return &GraphQLHandler{
+		executor: executor,
+	}
+}
+
+// RegisterGraphQLEndpoints はHuma APIにGraphQLエンドポイントを登録
+// クエリのみを提供するため、GET・POSTともに参照系のスコープを要求する
+func RegisterGraphQLEndpoints(api huma.API, h *GraphQLHandler) {
+	// GET /api/graphql - クエリ実行（クエリパラメータ）
+	huma.Register(api, huma.Operation{
+		OperationID: "graphql-get",
+		Method:      http.MethodGet,
+		Path:        "/api/graphql",
+		Summary:     "GraphQLクエリを実行（GET）",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nユーザー・投稿・ニュースをGraphQLで取得します。`variables` はJSONオブジェクトの文字列で指定します。",
+		Tags:        []string{"graphql"},
+		Security:    auth.BearerSecurity(auth.ScopeUsersRead, auth.ScopePostsRead, auth.ScopeNewsRead),
+	}, func(ctx context.Context, input *humaapi.GraphQLGetInput) (*humaapi.GraphQLOutput, error) {
+		req := &graphqlapi.Request{
+			Query:         input.Query,
+			OperationName: input.OperationName,
+		}
+		if input.Variables != "" {
+			if err := json.Unmarshal([]byte(input.Variables), &req.Variables); err != nil {
+				return nil, huma.Error400BadRequest("variables must be a JSON object")
+			}
+		}
+		return h.execute(ctx, req)
+	})
+
+	// POST /api/graphql - クエリ実行（リクエストボディ）
+	huma.Register(api, huma.Operation{
+		OperationID: "graphql-post",
+		Method:      http.MethodPost,
+		Path:        "/api/graphql",
+		Summary:     "GraphQLクエリを実行（POST）",
+		Description: "**Access Level:** `public` (Public API Key JWT または Auth0 JWT でアクセス可能)\n\nユーザー・投稿・ニュースをGraphQLで取得します。GET・POSTともに`users:read`・`posts:read`・`news:read`スコープが必要です。",
+		Tags:        []string{"graphql"},
+		Security:    auth.BearerSecurity(auth.ScopeUsersRead, auth.ScopePostsRead, auth.ScopeNewsRead),
+	}, func(ctx context.Context, input *humaapi.GraphQLPostInput) (*humaapi.GraphQLOutput, error) {
+		return h.execute(ctx, &graphqlapi.Request{
+			Query:         input.Body.Query,
+			OperationName: input.Body.OperationName,
+			Variables:     input.Body.Variables,
+		})
+	})
+}
+
+// execute は公開レベルをチェックしてクエリを実行
+func (h *GraphQLHandler) execute(ctx context.Context, req *graphqlapi.Request) (*humaapi.GraphQLOutput, error) {
+	// 公開レベルのチェック（publicエンドポイント）
+	if err := auth.CheckAccessLevel(ctx, auth.AccessLevelPublic); err != nil {
+		return nil, huma.Error403Forbidden(err.Error())
+	}
+
+	result, err := h.executor.Execute(ctx, req)
+	if err != nil && !errors.Is(err, graphqlapi.ErrInvalidQuery) {
+		return nil, newHTTPError(err)
+	}
+
+	resp := &humaapi.GraphQLOutput{Status: http.StatusOK, Body: result}
+	if err != nil {
+		resp.Status = http.StatusBadRequest
+	}
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/api/graphqlapi"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// newGraphQLTestAPI は指定したアクセスレベルを設定したテスト用APIにGraphQLエンドポイントを登録
+func newGraphQLTestAPI(t *testing.T, level auth.AccessLevel) humatest.TestAPI {
+	_, api := humatest.New(t)
+	if level != "" {
+		api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+			next(huma.WithValue(ctx, auth.AllowedAccessLevelKey, level))
+		})
+	}
+
+	publishedAt := time.Now().Add(-time.Hour)
+	repo := &MockDmNewsRepository{Published: []*model.DmNews{{ID: 1, Title: "News", Content: "Content", PublishedAt: &publishedAt}}}
+	dmNewsUsecase := usecaseapi.NewDmNewsUsecase(service.NewDmNewsService(repo), nil)
+	executor, err := graphqlapi.NewExecutor(usecaseapi.NewDmUserUsecase(nil, nil), usecaseapi.NewDmPostUsecase(nil, nil, nil), dmNewsUsecase,
+		&config.GraphQLConfig{MaxDepth: 8, MaxComplexity: 2000})
+	require.NoError(t, err)
+	RegisterGraphQLEndpoints(api, NewGraphQLHandler(executor))
+	return api
+}
+
+func TestGraphQLHandler_Get(t *testing.T) {
+	api := newGraphQLTestAPI(t, auth.AccessLevelPublic)
+
+	query := url.Values{}
+	query.Set("query", `query News($id: ID!) { news(id: $id) { id title } }`)
+	query.Set("variables", `{"id":"1"}`)
+	resp := api.Get("/api/graphql?" + query.Encode())
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.JSONEq(t, `{"data":{"news":{"id":"1","title":"News"}}}`, resp.Body.String())
+
+	query.Set("variables", `not json`)
+	resp = api.Get("/api/graphql?" + query.Encode())
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestGraphQLHandler_Post(t *testing.T) {
+	api := newGraphQLTestAPI(t, auth.AccessLevelPublic)
+
+	resp := api.Post("/api/graphql", strings.NewReader(`{"query":"{ news(id: \"1\") { title } }"}`))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.JSONEq(t, `{"data":{"news":{"title":"News"}}}`, resp.Body.String())
+}
+
+func TestGraphQLHandler_InvalidQuery(t *testing.T) {
+	api := newGraphQLTestAPI(t, auth.AccessLevelPublic)
+
+	resp := api.Post("/api/graphql", strings.NewReader(`{"query":"{ news(id: \"1\") { unknown } }"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"errors"`)
+}
+
+func TestGraphQLHandler_Forbidden(t *testing.T) {
+	api := newGraphQLTestAPI(t, "")
+
+	resp := api.Post("/api/graphql", strings.NewReader(`{"query":"{ news(id: \"1\") { title } }"}`))
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// withListQueryParams は一覧取得のfilter・sortパラメータの説明を許可フィールドから生成してOpenAPIに反映するグループを作成
+// モディファイアはhuma.Registerが入力の型からパラメータを生成した後に実行されるため、
+// バージョンのグループでパスが変わっても（/api/v1/...など）登録したOperationに反映される
+func withListQueryParams(api huma.API, fields []model.ListField) huma.API {
+	group := huma.NewGroup(api)
+	group.UseSimpleModifier(func(o *huma.Operation) {
+		for _, p := range o.Parameters {
+			switch p.Name {
+			case "filter":
+				p.Description = listFilterDescription(fields)
+			case "sort":
+				p.Description = listSortDescription(fields)
+			}
+		}
+	})
+	return group
+}
+
+// listFilterDescription はfilterパラメータの説明を生成
+func listFilterDescription(fields []model.ListField) string {
+	var b strings.Builder
+	b.WriteString("フィルタ条件。`field:op:value` をカンマ区切りで指定（すべての条件をANDで結合）。日時はRFC3339またはYYYY-MM-DD形式。\n\n指定可能なフィールド:\n")
+	for _, f := range fields {
+		fmt.Fprintf(&b, "- `%s` (%s): %s\n", f.Name, f.Type, strings.Join(model.ListFieldOps[f.Type], ", "))
+	}
+	return b.String()
+}
+
+// listSortDescription はsortパラメータの説明を生成
+func listSortDescription(fields []model.ListField) string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.Sortable {
+			names = append(names, "`"+f.Name+"`")
+		}
+	}
+	return "並び順。`field`（昇順）または `-field`（降順）をカンマ区切りで指定。\n\n指定可能なフィールド: " + strings.Join(names, ", ")
+}
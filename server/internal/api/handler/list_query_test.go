@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/api/apiversion"
+	humaapi "github.com/taku-o/go-webdb-template/internal/api/huma"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestWithListQueryParams(t *testing.T) {
+	_, api := humatest.New(t)
+	huma.Register(withListQueryParams(api, model.DmPostListFields), huma.Operation{
+		OperationID: "list-posts",
+		Method:      http.MethodGet,
+		Path:        "/api/dm-posts",
+	}, func(ctx context.Context, input *humaapi.ListDmPostsInput) (*humaapi.DmPostsOutput, error) {
+		return &humaapi.DmPostsOutput{}, nil
+	})
+
+	descriptions := map[string]string{}
+	for _, p := range api.OpenAPI().Paths["/api/dm-posts"].Get.Parameters {
+		descriptions[p.Name] = p.Description
+	}
+	require.Contains(t, descriptions, "filter")
+	require.Contains(t, descriptions, "sort")
+
+	assert.Contains(t, descriptions["filter"], "`title` (string): eq, ne, prefix, contains")
+	assert.Contains(t, descriptions["filter"], "`created_at` (time): eq, ne, gt, gte, lt, lte")
+	assert.Contains(t, descriptions["sort"], "`created_at`")
+	assert.NotContains(t, descriptions["sort"], "`content`")
+}
+
+// TestWithListQueryParams_VersionedPath はバージョンのグループでパスが変わっても説明が反映されることを確認
+func TestWithListQueryParams_VersionedPath(t *testing.T) {
+	_, api := humatest.New(t)
+	v1 := &apiversion.Version{Name: "v1"}
+	huma.Register(withListQueryParams(v1.NewGroup(api), model.DmUserListFields), huma.Operation{
+		OperationID: "list-users",
+		Method:      http.MethodGet,
+		Path:        "/api/dm-users",
+	}, func(ctx context.Context, input *humaapi.ListDmUsersInput) (*humaapi.DmUsersOutput, error) {
+		return &humaapi.DmUsersOutput{}, nil
+	})
+
+	pathItem := api.OpenAPI().Paths["/api/v1/dm-users"]
+	require.NotNil(t, pathItem)
+	require.NotNil(t, pathItem.Get)
+	descriptions := map[string]string{}
+	for _, p := range pathItem.Get.Parameters {
+		descriptions[p.Name] = p.Description
+	}
+	assert.Contains(t, descriptions["filter"], "`email` (string)")
+	assert.Contains(t, descriptions["sort"], "`name`")
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// streamRetryMillis はクライアントに指示する再接続までの待ち時間（SSEのretryフィールド）
+const streamRetryMillis = 3000
+
+// StreamHandler はServer-Sent Events（/api/stream/*）のハンドラー
+type StreamHandler struct {
+	streamUsecase     *usecaseapi.StreamUsecase
+	heartbeatInterval time.Duration
+}
+
+// NewStreamHandler は新しいStreamHandlerを作成
+// heartbeatIntervalごとにコメント行を送信し、プロキシによる無通信切断を防ぐ
+func NewStreamHandler(streamUsecase *usecaseapi.StreamUsecase, heartbeatInterval time.Duration) *StreamHandler {
+	return &StreamHandler{
+		streamUsecase:     streamUsecase,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// StreamPosts は投稿作成のイベントを配信する
+func (h *StreamHandler) StreamPosts(c echo.Context) error {
+	return h.serve(c, model.StreamTopicPosts)
+}
+
+// StreamNews はニュース公開のイベントを配信する
+func (h *StreamHandler) StreamNews(c echo.Context) error {
+	return h.serve(c, model.StreamTopicNews)
+}
+
+// serve はトピックを購読し、クライアントが切断するまでイベントを送信する
+// 再開位置はLast-Event-IDヘッダー（なければlast_event_idクエリパラメータ）で指定する
+func (h *StreamHandler) serve(c echo.Context, topic string) error {
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+
+	sub, err := h.streamUsecase.Subscribe(topic, lastEventID, c.QueryParam("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"code":    http.StatusServiceUnavailable,
+			"message": "stream is unavailable",
+		})
+	}
+	defer sub.Close()
+
+	// 長時間の接続となるため、サーバーの書き込みタイムアウトを解除する
+	_ = http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{})
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", streamRetryMillis); err != nil {
+		return nil
+	}
+	for _, event := range sub.Replay {
+		if err := writeStreamEvent(res, event); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	ticker := time.NewTicker(h.heartbeatInterval)
+	defer ticker.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events:
+			if !ok {
+				// 受信の遅延・サーバー停止で購読が終了した場合は切断し、Last-Event-IDで再接続させる
+				return nil
+			}
+			if err := writeStreamEvent(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-ticker.C:
+			if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// writeStreamEvent はイベントをSSEの形式で書き込む
+func writeStreamEvent(w io.Writer, event *model.StreamEvent) error {
+	_, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	return err
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/stream"
+	usecaseapi "github.com/taku-o/go-webdb-template/internal/usecase/api"
+)
+
+// newStreamTestServer はテスト用サーバーにSSEエンドポイントを登録（認証ミドルウェアなし）
+func newStreamTestServer(t *testing.T, broker stream.Broker, heartbeatInterval time.Duration) *httptest.Server {
+	e := echo.New()
+	h := NewStreamHandler(usecaseapi.NewStreamUsecase(broker), heartbeatInterval)
+	e.GET("/api/stream/posts", h.StreamPosts)
+	e.GET("/api/stream/news", h.StreamNews)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// openStream はSSEの接続を開き、retryフィールドまで読み進めたReaderを返す
+// retryフィールドは購読の登録後に送信されるため、以降に配信したイベントは接続に届く
+func openStream(t *testing.T, url string, header http.Header) *bufio.Reader {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, "retry: 3000\n\n", readStreamBlock(t, reader))
+	return reader
+}
+
+// readStreamBlock は空行までの1ブロックを読み込む
+func readStreamBlock(t *testing.T, reader *bufio.Reader) string {
+	var b strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		b.WriteString(line)
+		if line == "\n" {
+			return b.String()
+		}
+	}
+}
+
+func TestStreamHandler_StreamPosts(t *testing.T) {
+	broker := stream.NewMemoryBroker(10)
+	defer broker.Close()
+	server := newStreamTestServer(t, broker, time.Minute)
+
+	reader := openStream(t, server.URL+"/api/stream/posts", nil)
+
+	event, err := stream.NewPostCreatedEvent(&model.DmPost{ID: "p1", UserID: "u1", Title: "Title"})
+	require.NoError(t, err)
+	require.NoError(t, broker.Publish(context.Background(), event))
+
+	block := readStreamBlock(t, reader)
+	assert.True(t, strings.HasPrefix(block, "id: "+event.ID+"\nevent: post.created\ndata: {"), block)
+	assert.Contains(t, block, `"user_id":"u1"`)
+}
+
+func TestStreamHandler_UserIDFilter(t *testing.T) {
+	broker := stream.NewMemoryBroker(10)
+	defer broker.Close()
+	server := newStreamTestServer(t, broker, time.Minute)
+
+	reader := openStream(t, server.URL+"/api/stream/posts?user_id=u2", nil)
+
+	other, err := stream.NewPostCreatedEvent(&model.DmPost{ID: "p1", UserID: "u1"})
+	require.NoError(t, err)
+	require.NoError(t, broker.Publish(context.Background(), other))
+	event, err := stream.NewPostCreatedEvent(&model.DmPost{ID: "p2", UserID: "u2"})
+	require.NoError(t, err)
+	require.NoError(t, broker.Publish(context.Background(), event))
+
+	assert.Contains(t, readStreamBlock(t, reader), "id: "+event.ID+"\n")
+}
+
+func TestStreamHandler_LastEventID(t *testing.T) {
+	broker := stream.NewMemoryBroker(10)
+	defer broker.Close()
+	server := newStreamTestServer(t, broker, time.Minute)
+
+	var events []*model.StreamEvent
+	for _, id := range []int64{1, 2, 3} {
+		now := time.Now()
+		event, err := stream.NewNewsPublishedEvent(&model.DmNews{ID: id, PublishedAt: &now})
+		require.NoError(t, err)
+		require.NoError(t, broker.Publish(context.Background(), event))
+		events = append(events, event)
+	}
+
+	t.Run("header", func(t *testing.T) {
+		reader := openStream(t, server.URL+"/api/stream/news", http.Header{"Last-Event-Id": {events[0].ID}})
+		assert.Contains(t, readStreamBlock(t, reader), "id: "+events[1].ID+"\nevent: news.published\n")
+		assert.Contains(t, readStreamBlock(t, reader), "id: "+events[2].ID+"\n")
+	})
+
+	t.Run("query parameter", func(t *testing.T) {
+		reader := openStream(t, server.URL+"/api/stream/news?last_event_id="+events[1].ID, nil)
+		assert.Contains(t, readStreamBlock(t, reader), "id: "+events[2].ID+"\n")
+	})
+}
+
+func TestStreamHandler_Heartbeat(t *testing.T) {
+	broker := stream.NewMemoryBroker(10)
+	defer broker.Close()
+	server := newStreamTestServer(t, broker, 20*time.Millisecond)
+
+	reader := openStream(t, server.URL+"/api/stream/posts", nil)
+	assert.Equal(t, ": heartbeat\n\n", readStreamBlock(t, reader))
+}
+
+func TestStreamHandler_BrokerClosed(t *testing.T) {
+	broker := stream.NewMemoryBroker(10)
+	server := newStreamTestServer(t, broker, time.Minute)
+	require.NoError(t, broker.Close())
+
+	resp, err := http.Get(server.URL + "/api/stream/posts")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
@@ -57,7 +57,7 @@ func TestTodayHandler_GetTodayWithPublicAccessLevel(t *testing.T) {
 	_, err := handler.GetToday(ctx)
 	// publicアクセスレベルではprivate APIにアクセスできないためエラー
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "private API requires Auth0 authentication")
+	assert.Contains(t, err.Error(), "private API requires user authentication")
 }
 
 func TestTodayHandler_GetTodayWithoutAccessLevel(t *testing.T) {
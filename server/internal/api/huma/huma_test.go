@@ -70,6 +70,49 @@ func TestListDmUsersInput(t *testing.T) {
 	}
 }
 
+// TestListInputs_FilterSort は一覧取得入力のfilter・sortパラメータを確認
+func TestListInputs_FilterSort(t *testing.T) {
+	for _, inputType := range []reflect.Type{reflect.TypeOf(ListDmUsersInput{}), reflect.TypeOf(ListDmPostsInput{})} {
+		filterField, ok := inputType.FieldByName("Filter")
+		if !ok || filterField.Tag.Get("query") != "filter" {
+			t.Errorf("%s should have Filter field with query:\"filter\" tag", inputType.Name())
+		}
+		sortField, ok := inputType.FieldByName("Sort")
+		if !ok || sortField.Tag.Get("query") != "sort" {
+			t.Errorf("%s should have Sort field with query:\"sort\" tag", inputType.Name())
+		}
+	}
+}
+
+// TestPatchInputs はJSON Merge Patch入力のRawBodyとcontentTypeを確認
+func TestPatchInputs(t *testing.T) {
+	for _, inputType := range []reflect.Type{
+		reflect.TypeOf(PatchDmUserInput{}),
+		reflect.TypeOf(PatchDmPostInput{}),
+		reflect.TypeOf(PatchDmNewsInput{}),
+	} {
+		if _, ok := inputType.FieldByName("RawBody"); !ok {
+			t.Errorf("%s should have RawBody field", inputType.Name())
+		}
+		bodyField, ok := inputType.FieldByName("Body")
+		if !ok || bodyField.Tag.Get("contentType") != "application/merge-patch+json" {
+			t.Errorf("%s Body should have contentType:\"application/merge-patch+json\" tag", inputType.Name())
+		}
+	}
+}
+
+// TestBatchGetInputs は一括取得入力の件数制限タグを確認
+func TestBatchGetInputs(t *testing.T) {
+	for _, field := range []reflect.StructField{
+		reflect.TypeOf(BatchGetDmUsersInput{}.Body).Field(0),
+		reflect.TypeOf(BatchGetDmPostsInput{}.Body).Field(0),
+	} {
+		if field.Tag.Get("minItems") != "1" || field.Tag.Get("maxItems") != "100" {
+			t.Errorf("%s should have minItems:\"1\" and maxItems:\"100\" tags", field.Name)
+		}
+	}
+}
+
 // TestUpdateDmUserInput はUpdateDmUserInputの構造を確認
 func TestUpdateDmUserInput(t *testing.T) {
 	input := UpdateDmUserInput{}
@@ -218,6 +261,37 @@ func TestGetDmUserPostsInput(t *testing.T) {
 	}
 }
 
+// TestSearchDmPostsInput はSearchDmPostsInputの構造を確認
+func TestSearchDmPostsInput(t *testing.T) {
+	inputType := reflect.TypeOf(SearchDmPostsInput{})
+
+	qField, ok := inputType.FieldByName("Q")
+	if !ok {
+		t.Fatal("SearchDmPostsInput should have Q field")
+	}
+	if qField.Tag.Get("query") != "q" || qField.Tag.Get("required") != "true" {
+		t.Error("Q should have query:\"q\" and required:\"true\" tags")
+	}
+
+	modeField, _ := inputType.FieldByName("Mode")
+	if modeField.Tag.Get("enum") != "word,ngram" {
+		t.Error("Mode should have enum:\"word,ngram\" tag")
+	}
+
+	sortField, _ := inputType.FieldByName("Sort")
+	if sortField.Tag.Get("enum") != "relevance,date" {
+		t.Error("Sort should have enum:\"relevance,date\" tag")
+	}
+}
+
+// TestDmPostSearchOutput はDmPostSearchOutputの構造を確認
+func TestDmPostSearchOutput(t *testing.T) {
+	bodyType := reflect.TypeOf(DmPostSearchOutput{}.Body)
+	if bodyType.Kind() != reflect.Slice {
+		t.Error("Body should be a slice")
+	}
+}
+
 // TestDmPostOutput はDmPostOutputの構造を確認
 func TestDmPostOutput(t *testing.T) {
 	output := DmPostOutput{}
@@ -256,3 +330,97 @@ func TestDeleteDmPostOutput(t *testing.T) {
 	_ = DeleteDmPostOutput{}
 	// 204 No Contentなのでフィールドは不要
 }
+
+// TestBulkImportInput はBulkImportInputの構造を確認
+func TestBulkImportInput(t *testing.T) {
+	inputType := reflect.TypeOf(BulkImportInput{})
+
+	rawBodyField, ok := inputType.FieldByName("RawBody")
+	if !ok {
+		t.Error("BulkImportInput should have RawBody field")
+	}
+	if rawBodyField.Tag.Get("contentType") != "application/x-ndjson" {
+		t.Error("RawBody should have contentType:\"application/x-ndjson\" tag")
+	}
+
+	contentTypeField, ok := inputType.FieldByName("ContentType")
+	if !ok {
+		t.Error("BulkImportInput should have ContentType field")
+	}
+	if contentTypeField.Tag.Get("header") != "Content-Type" {
+		t.Error("ContentType should have header:\"Content-Type\" tag")
+	}
+}
+
+// TestBulkImportOutput はBulkImportOutputの構造を確認
+func TestBulkImportOutput(t *testing.T) {
+	outputType := reflect.TypeOf(BulkImportOutput{})
+
+	if _, ok := outputType.FieldByName("Status"); !ok {
+		t.Error("BulkImportOutput should have Status field")
+	}
+	if _, ok := outputType.FieldByName("Body"); !ok {
+		t.Error("BulkImportOutput should have Body field")
+	}
+}
+
+// TestCreateExportInput はCreateExportInputの構造を確認
+func TestCreateExportInput(t *testing.T) {
+	bodyType := reflect.TypeOf(CreateExportInput{}.Body)
+
+	targetField, ok := bodyType.FieldByName("Target")
+	if !ok {
+		t.Error("Body should have Target field")
+	}
+	if targetField.Tag.Get("enum") != "dm_users,dm_posts" {
+		t.Error("Target should have enum:\"dm_users,dm_posts\" tag")
+	}
+
+	formatField, ok := bodyType.FieldByName("Format")
+	if !ok {
+		t.Error("Body should have Format field")
+	}
+	if formatField.Tag.Get("enum") != "csv,ndjson,xlsx" {
+		t.Error("Format should have enum:\"csv,ndjson,xlsx\" tag")
+	}
+
+	if _, ok := bodyType.FieldByName("Filter"); !ok {
+		t.Error("Body should have Filter field")
+	}
+}
+
+// TestExportJobOutput はExportJobOutputの構造を確認
+func TestExportJobOutput(t *testing.T) {
+	bodyType := reflect.TypeOf(ExportJobOutput{}.Body)
+
+	for _, name := range []string{"JobID", "Status", "Progress", "DownloadURL", "ExpiresAt"} {
+		if _, ok := bodyType.FieldByName(name); !ok {
+			t.Errorf("Body should have %s field", name)
+		}
+	}
+}
+
+// TestListDmNewsInput はListDmNewsInputのクエリパラメータを確認
+func TestListDmNewsInput(t *testing.T) {
+	inputType := reflect.TypeOf(ListDmNewsInput{})
+	for name, query := range map[string]string{"Limit": "limit", "Offset": "offset", "AuthorID": "author_id"} {
+		field, ok := inputType.FieldByName(name)
+		if !ok || field.Tag.Get("query") != query {
+			t.Errorf("ListDmNewsInput should have %s field with query:%q tag", name, query)
+		}
+	}
+}
+
+// TestCreateDmNewsInput はCreateDmNewsInputの必須フィールドを確認
+func TestCreateDmNewsInput(t *testing.T) {
+	bodyType := reflect.TypeOf(CreateDmNewsInput{}.Body)
+	for _, name := range []string{"Title", "Content"} {
+		field, _ := bodyType.FieldByName(name)
+		if field.Tag.Get("required") != "true" {
+			t.Errorf("%s should have required:\"true\" tag", name)
+		}
+	}
+	if field, _ := bodyType.FieldByName("PublishedAt"); field.Tag.Get("required") == "true" {
+		t.Error("PublishedAt should be optional")
+	}
+}
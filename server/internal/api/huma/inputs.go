@@ -1,5 +1,11 @@
 package humaapi
 
+import (
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
 // CreateDmUserInput はユーザー作成リクエストの入力構造体
 type CreateDmUserInput struct {
 	Body struct {
@@ -13,10 +19,19 @@ type GetDmUserInput struct {
 	ID string `path:"id" doc:"ユーザーID（文字列形式）"`
 }
 
+// BatchGetDmUsersInput はユーザー一括取得リクエストの入力構造体
+type BatchGetDmUsersInput struct {
+	Body struct {
+		IDs []string `json:"ids" required:"true" minItems:"1" maxItems:"100" doc:"ユーザーIDリスト（最大100件、重複は1件として扱う）"`
+	}
+}
+
 // ListDmUsersInput はユーザー一覧取得リクエストの入力構造体
 type ListDmUsersInput struct {
-	Limit  int `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"取得件数"`
-	Offset int `query:"offset" default:"0" minimum:"0" doc:"オフセット"`
+	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"取得件数"`
+	Offset int    `query:"offset" default:"0" minimum:"0" maximum:"999" doc:"オフセット（offset+limitは1000以下）"`
+	Filter string `query:"filter" maxLength:"1000" doc:"フィルタ条件（field:op:value をカンマ区切り）" example:"name:prefix:tak,created_at:gte:2026-01-01"`
+	Sort   string `query:"sort" maxLength:"200" doc:"並び順（field または -field をカンマ区切り）" example:"-created_at"`
 }
 
 // UpdateDmUserInput はユーザー更新リクエストの入力構造体
@@ -28,6 +43,17 @@ type UpdateDmUserInput struct {
 	}
 }
 
+// PatchDmUserInput はユーザー部分更新（JSON Merge Patch）リクエストの入力構造体
+// Bodyはスキーマのドキュメント用で、更新内容はRawBodyをRFC 7396として解釈する
+type PatchDmUserInput struct {
+	ID   string `path:"id" doc:"ユーザーID（文字列形式）"`
+	Body struct {
+		Name  *string `json:"name,omitempty" maxLength:"100" doc:"ユーザー名"`
+		Email *string `json:"email,omitempty" format:"email" maxLength:"255" doc:"メールアドレス"`
+	} `contentType:"application/merge-patch+json"`
+	RawBody []byte
+}
+
 // DeleteDmUserInput はユーザー削除リクエストの入力構造体
 type DeleteDmUserInput struct {
 	ID string `path:"id" doc:"ユーザーID（文字列形式）"`
@@ -48,11 +74,30 @@ type GetDmPostInput struct {
 	UserID string `query:"user_id" required:"true" doc:"ユーザーID（文字列形式）"`
 }
 
+// BatchGetDmPostsInput は投稿一括取得リクエストの入力構造体
+// 投稿はユーザーIDでシャーディングされるため、投稿IDとユーザーIDの組で指定する
+type BatchGetDmPostsInput struct {
+	Body struct {
+		Keys []model.DmPostKey `json:"keys" required:"true" minItems:"1" maxItems:"100" doc:"投稿IDとユーザーIDの組のリスト（最大100件、重複は1件として扱う）"`
+	}
+}
+
 // ListDmPostsInput は投稿一覧取得リクエストの入力構造体
 type ListDmPostsInput struct {
 	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"取得件数"`
-	Offset int    `query:"offset" default:"0" minimum:"0" doc:"オフセット"`
+	Offset int    `query:"offset" default:"0" minimum:"0" maximum:"999" doc:"オフセット（offset+limitは1000以下）"`
 	UserID string `query:"user_id" default:"" doc:"ユーザーID（文字列形式、空の場合は全件取得）"`
+	Filter string `query:"filter" maxLength:"1000" doc:"フィルタ条件（field:op:value をカンマ区切り）" example:"title:contains:go,created_at:gte:2026-01-01"`
+	Sort   string `query:"sort" maxLength:"200" doc:"並び順（field または -field をカンマ区切り）" example:"-created_at"`
+}
+
+// SearchDmPostsInput は投稿全文検索リクエストの入力構造体
+type SearchDmPostsInput struct {
+	Q      string `query:"q" required:"true" minLength:"1" maxLength:"200" doc:"検索語（wordモードでは \"フレーズ\"、OR、-除外 を利用可能）"`
+	Mode   string `query:"mode" default:"word" enum:"word,ngram" doc:"検索方式（word: 単語単位、ngram: 部分一致。分かち書きされない日本語はngramを推奨）"`
+	Sort   string `query:"sort" default:"relevance" enum:"relevance,date" doc:"並び順（relevance: 関連度順、date: 新しい順）"`
+	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"取得件数"`
+	Offset int    `query:"offset" default:"0" minimum:"0" maximum:"999" doc:"オフセット（offset+limitは1000以下）"`
 }
 
 // UpdateDmPostInput は投稿更新リクエストの入力構造体
@@ -65,6 +110,75 @@ type UpdateDmPostInput struct {
 	}
 }
 
+// PatchDmPostInput は投稿部分更新（JSON Merge Patch）リクエストの入力構造体
+// Bodyはスキーマのドキュメント用で、更新内容はRawBodyをRFC 7396として解釈する
+type PatchDmPostInput struct {
+	ID     string `path:"id" doc:"投稿ID（文字列形式）"`
+	UserID string `query:"user_id" required:"true" doc:"ユーザーID（文字列形式）"`
+	Body   struct {
+		Title   *string `json:"title,omitempty" maxLength:"200" doc:"タイトル"`
+		Content *string `json:"content,omitempty" doc:"内容"`
+	} `contentType:"application/merge-patch+json"`
+	RawBody []byte
+}
+
+// CreateDmNewsInput はニュース作成リクエストの入力構造体
+type CreateDmNewsInput struct {
+	Body struct {
+		Title       string     `json:"title" required:"true" maxLength:"255" doc:"タイトル"`
+		Content     string     `json:"content" required:"true" doc:"内容"`
+		AuthorID    *int64     `json:"author_id,omitempty" doc:"作成者ID"`
+		PublishedAt *time.Time `json:"published_at,omitempty" doc:"公開日時（省略時は非公開、未来の日時の場合はその日時に公開）"`
+	}
+}
+
+// GetDmNewsInput はニュース取得リクエストの入力構造体
+type GetDmNewsInput struct {
+	ID int64 `path:"id" minimum:"1" doc:"ニュースID"`
+}
+
+// ListDmNewsInput はニュース一覧取得リクエストの入力構造体
+type ListDmNewsInput struct {
+	Limit    int   `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"取得件数"`
+	Offset   int   `query:"offset" default:"0" minimum:"0" doc:"オフセット"`
+	AuthorID int64 `query:"author_id" minimum:"0" doc:"作成者ID（省略時は全件）"`
+}
+
+// UpdateDmNewsInput はニュース更新リクエストの入力構造体
+type UpdateDmNewsInput struct {
+	ID   int64 `path:"id" minimum:"1" doc:"ニュースID"`
+	Body struct {
+		Title       string     `json:"title,omitempty" maxLength:"255" doc:"タイトル"`
+		Content     string     `json:"content,omitempty" doc:"内容"`
+		AuthorID    *int64     `json:"author_id,omitempty" doc:"作成者ID"`
+		PublishedAt *time.Time `json:"published_at,omitempty" doc:"公開日時"`
+	}
+}
+
+// PatchDmNewsInput はニュース部分更新（JSON Merge Patch）リクエストの入力構造体
+// Bodyはスキーマのドキュメント用で、更新内容はRawBodyをRFC 7396として解釈する
+type PatchDmNewsInput struct {
+	ID   int64 `path:"id" minimum:"1" doc:"ニュースID"`
+	Body struct {
+		Title       *string    `json:"title,omitempty" maxLength:"255" doc:"タイトル"`
+		Content     *string    `json:"content,omitempty" doc:"内容"`
+		AuthorID    *int64     `json:"author_id,omitempty" nullable:"true" doc:"作成者ID（nullで解除）"`
+		PublishedAt *time.Time `json:"published_at,omitempty" nullable:"true" doc:"公開日時（nullで解除）"`
+	} `contentType:"application/merge-patch+json"`
+	RawBody []byte
+}
+
+// DeleteDmNewsInput はニュース削除リクエストの入力構造体
+type DeleteDmNewsInput struct {
+	ID int64 `path:"id" minimum:"1" doc:"ニュースID"`
+}
+
+// GetDmNewsFeedInput はニュースフィード取得リクエストの入力構造体（条件付きGET）
+type GetDmNewsFeedInput struct {
+	IfNoneMatch     []string  `header:"If-None-Match" doc:"前回取得時のETag（一致した場合は304を返す）"`
+	IfModifiedSince time.Time `header:"If-Modified-Since" doc:"前回取得時のLast-Modified（フィードにETagがある場合は無視。ETagとIf-None-Matchを使うこと）"`
+}
+
 // DeleteDmPostInput は投稿削除リクエストの入力構造体
 type DeleteDmPostInput struct {
 	ID     string `path:"id" doc:"投稿ID（文字列形式）"`
@@ -89,3 +203,71 @@ type SendEmailInput struct {
 		Data     map[string]interface{} `json:"data" required:"true" doc:"テンプレートデータ"`
 	}
 }
+
+// BulkImportInput は一括登録リクエストの入力構造体
+// リクエストボディはNDJSON（1行1レコード）またはJSON配列を受け付ける
+type BulkImportInput struct {
+	ContentType string `header:"Content-Type" doc:"application/x-ndjson または application/json"`
+	RawBody     []byte `contentType:"application/x-ndjson"`
+}
+
+// GetBulkImportJobInput は一括登録ジョブ取得リクエストの入力構造体
+type GetBulkImportJobInput struct {
+	ID string `path:"id" doc:"ジョブID"`
+}
+
+// CreateExportInput はエクスポートジョブ登録リクエストの入力構造体
+type CreateExportInput struct {
+	Body struct {
+		Target string   `json:"target" required:"true" enum:"dm_users,dm_posts" doc:"エクスポート対象"`
+		Format string   `json:"format" required:"true" enum:"csv,ndjson,xlsx" doc:"出力形式"`
+		Fields []string `json:"fields,omitempty" doc:"出力フィールド（省略時は全フィールド）"`
+		Filter struct {
+			CreatedFrom *time.Time `json:"created_from,omitempty" doc:"作成日時の下限（この日時を含む）"`
+			CreatedTo   *time.Time `json:"created_to,omitempty" doc:"作成日時の上限（この日時を含まない）"`
+			UserID      string     `json:"user_id,omitempty" doc:"ユーザーID（dm_postsのみ）"`
+		} `json:"filter,omitempty" doc:"絞り込み条件"`
+	}
+}
+
+// GetExportInput はエクスポートジョブ取得リクエストの入力構造体
+type GetExportInput struct {
+	ID string `path:"id" doc:"ジョブID"`
+}
+
+// DownloadExportInput はエクスポートファイルダウンロードリクエストの入力構造体
+// パラメータはエクスポートジョブ取得APIが返す署名付きURLに含まれる
+type DownloadExportInput struct {
+	Key       string `query:"key" required:"true" doc:"ファイルキー"`
+	Name      string `query:"name" required:"true" doc:"ダウンロード時のファイル名"`
+	Expires   string `query:"expires" required:"true" doc:"有効期限（UNIX時間）"`
+	Signature string `query:"signature" required:"true" doc:"署名"`
+}
+
+// LinkDmMeIdentityInput はアカウント紐づけリクエストの入力構造体
+type LinkDmMeIdentityInput struct {
+	Body struct {
+		Token string `json:"token" required:"true" minLength:"1" doc:"紐づけるアカウントのIssuerが発行したJWT"`
+	}
+}
+
+// UnlinkDmMeIdentityInput はアカウント紐づけ解除リクエストの入力構造体
+type UnlinkDmMeIdentityInput struct {
+	ID int64 `path:"id" minimum:"1" doc:"紐づけID"`
+}
+
+// GraphQLGetInput はGETによるGraphQLリクエストの入力構造体
+type GraphQLGetInput struct {
+	Query         string `query:"query" required:"true" minLength:"1" doc:"GraphQLクエリ"`
+	OperationName string `query:"operationName" doc:"実行するオペレーション名"`
+	Variables     string `query:"variables" doc:"変数（JSONオブジェクト）"`
+}
+
+// GraphQLPostInput はPOSTによるGraphQLリクエストの入力構造体
+type GraphQLPostInput struct {
+	Body struct {
+		Query         string                 `json:"query" required:"true" minLength:"1" doc:"GraphQLクエリ"`
+		OperationName string                 `json:"operationName,omitempty" doc:"実行するオペレーション名"`
+		Variables     map[string]interface{} `json:"variables,omitempty" doc:"変数"`
+	}
+}
@@ -1,12 +1,22 @@
 package humaapi
 
-import "github.com/taku-o/go-webdb-template/internal/model"
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
 
 // DmUserOutput はユーザー単体のレスポンス構造体
 type DmUserOutput struct {
 	Body model.DmUser
 }
 
+// DmUserBatchGetOutput はユーザー一括取得のレスポンス構造体
+type DmUserBatchGetOutput struct {
+	Body model.DmUserBatchGetResult
+}
+
 // DmUsersOutput はユーザー一覧のレスポンス構造体
 type DmUsersOutput struct {
 	Body []*model.DmUser
@@ -16,16 +26,46 @@ type DmUsersOutput struct {
 type DeleteDmUserOutput struct {
 }
 
+// DmMeOutput は呼び出し元のユーザーのレスポンス構造体
+type DmMeOutput struct {
+	Body model.DmMe
+}
+
+// DmUserIdentityOutput はアカウントの紐づけのレスポンス構造体
+type DmUserIdentityOutput struct {
+	Body model.DmUserIdentity
+}
+
 // DmPostOutput は投稿単体のレスポンス構造体
 type DmPostOutput struct {
 	Body model.DmPost
 }
 
+// DmPostBatchGetOutput は投稿一括取得のレスポンス構造体
+type DmPostBatchGetOutput struct {
+	Body model.DmPostBatchGetResult
+}
+
 // DmPostsOutput は投稿一覧のレスポンス構造体
 type DmPostsOutput struct {
 	Body []*model.DmPost
 }
 
+// DmNewsOutput はニュースのレスポンス構造体
+type DmNewsOutput struct {
+	Body model.DmNews
+}
+
+// DmNewsListOutput はニュース一覧のレスポンス構造体
+type DmNewsListOutput struct {
+	Body []*model.DmNews
+}
+
+// DmPostSearchOutput は投稿全文検索のレスポンス構造体
+type DmPostSearchOutput struct {
+	Body []*model.DmPostSearchHit
+}
+
 // DmUserPostsOutput はユーザーと投稿のJOIN結果のレスポンス構造体
 type DmUserPostsOutput struct {
 	Body []*model.DmUserPost
@@ -49,3 +89,57 @@ type SendEmailOutput struct {
 		Message string `json:"message" doc:"メッセージ"`
 	}
 }
+
+// BulkImportOutput は一括登録のレスポンス構造体
+// 同期処理の場合は200と処理結果、非同期処理の場合は202とジョブIDを返す
+type BulkImportOutput struct {
+	Status   int
+	Location string `header:"Location" doc:"非同期処理の場合のジョブ参照URL"`
+	Body     struct {
+		Status string                  `json:"status" doc:"処理状態（completed / pending）"`
+		JobID  string                  `json:"job_id,omitempty" doc:"非同期処理の場合のジョブID"`
+		Result *model.BulkImportResult `json:"result,omitempty" doc:"同期処理の場合の行ごとの処理結果"`
+	}
+}
+
+// BulkImportJobOutput は一括登録ジョブのレスポンス構造体
+type BulkImportJobOutput struct {
+	Body struct {
+		JobID       string                  `json:"job_id" doc:"ジョブID"`
+		Status      string                  `json:"status" doc:"処理状態（pending / processing / completed / failed）"`
+		Result      *model.BulkImportResult `json:"result,omitempty" doc:"完了時の行ごとの処理結果"`
+		Error       string                  `json:"error,omitempty" doc:"失敗時のエラーメッセージ"`
+		CompletedAt *time.Time              `json:"completed_at,omitempty" doc:"完了日時"`
+	}
+}
+
+// CreateExportOutput はエクスポートジョブ登録のレスポンス構造体（202）
+type CreateExportOutput struct {
+	Location string `header:"Location" doc:"ジョブ参照URL"`
+	Body     struct {
+		JobID  string `json:"job_id" doc:"ジョブID"`
+		Status string `json:"status" doc:"処理状態（pending）"`
+	}
+}
+
+// ExportJobOutput はエクスポートジョブのレスポンス構造体
+type ExportJobOutput struct {
+	Body struct {
+		JobID       string                `json:"job_id" doc:"ジョブID"`
+		Status      string                `json:"status" doc:"処理状態（pending / processing / completed / failed）"`
+		Progress    *model.ExportProgress `json:"progress,omitempty" doc:"進捗（処理済み件数 / 対象件数）"`
+		FileName    string                `json:"file_name,omitempty" doc:"完了時のファイル名"`
+		Size        int64                 `json:"size,omitempty" doc:"完了時のファイルサイズ（バイト）"`
+		DownloadURL string                `json:"download_url,omitempty" doc:"完了時の期限付きダウンロードURL"`
+		ExpiresAt   *time.Time            `json:"expires_at,omitempty" doc:"ダウンロードURLの有効期限"`
+		Error       string                `json:"error,omitempty" doc:"失敗時のエラーメッセージ"`
+		CompletedAt *time.Time            `json:"completed_at,omitempty" doc:"完了日時"`
+	}
+}
+
+// GraphQLOutput はGraphQLのレスポンス構造体
+// クエリが不正な場合は400、それ以外はフィールドのエラーを含めて200を返す
+type GraphQLOutput struct {
+	Status int
+	Body   *graphql.Result
+}
@@ -10,14 +10,36 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
+	"github.com/taku-o/go-webdb-template/internal/api/apiversion"
 	"github.com/taku-o/go-webdb-template/internal/api/handler"
 	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/idempotency"
 	"github.com/taku-o/go-webdb-template/internal/ratelimit"
 )
 
+// Deps はNewRouterが登録するハンドラーと認証・レートリミットの依存
+// DmUserHandler・DmPostHandler・TodayHandler以外はnilの場合、対応するエンドポイント・機能を登録しない
+type Deps struct {
+	DmUserHandler     *handler.DmUserHandler
+	DmPostHandler     *handler.DmPostHandler
+	TodayHandler      *handler.TodayHandler
+	EmailHandler      *handler.EmailHandler
+	DmJobqueueHandler *handler.DmJobqueueHandler
+	DmBulkHandler     *handler.DmBulkHandler
+	DmExportHandler   *handler.DmExportHandler
+	DmNewsHandler     *handler.DmNewsHandler
+	DmNewsFeedHandler *handler.DmNewsFeedHandler
+	DmMeHandler       *handler.DmMeHandler
+	GraphQLHandler    *handler.GraphQLHandler
+
+	APIKeyRegistry   auth.APIKeyRegistry         // Public APIキーの失効確認（nilの場合は確認しない）
+	IdentityResolver auth.IdentityResolver       // OIDCのユーザーとdm_usersの紐づけの解決（nilの場合は解決しない）
+	PrincipalLimiter *ratelimit.PrincipalLimiter // 呼び出し元ごとのレートリミット・クォータ（nilの場合は適用しない）
+}
+
 // NewRouter は新しいEchoルーターを作成
-func NewRouter(dmUserHandler *handler.DmUserHandler, dmPostHandler *handler.DmPostHandler, todayHandler *handler.TodayHandler, emailHandler *handler.EmailHandler, dmJobqueueHandler *handler.DmJobqueueHandler, cfg *config.Config) *echo.Echo {
+func NewRouter(deps Deps, cfg *config.Config) *echo.Echo {
 	e := echo.New()
 
 	// デバッグモードの設定（開発環境のみ）
@@ -29,11 +51,6 @@ func NewRouter(dmUserHandler *handler.DmUserHandler, dmPostHandler *handler.DmPo
 		e.Debug = true
 	}
 
-	// AUTH0_ISSUER_BASE_URLが空の場合、サーバー起動時にエラーを発生させる
-	if cfg.API.Auth0IssuerBaseURL == "" {
-		panic("AUTH0_ISSUER_BASE_URL is required in config")
-	}
-
 	// Recoverミドルウェア
 	e.Use(middleware.Recover())
 
@@ -47,21 +64,146 @@ func NewRouter(dmUserHandler *handler.DmUserHandler, dmPostHandler *handler.DmPo
 	}))
 
 	// レートリミットミドルウェア（認証ミドルウェアの前に適用）
+	// ストレージの障害はミドルウェア内でfail-open方式で扱い、エラーはポリシー・除外するルートの設定の誤りのみ
 	rateLimitMiddleware, err := ratelimit.NewRateLimitMiddleware(cfg)
 	if err != nil {
-		// エラー時はログに記録し、サーバー起動を継続（fail-open方式）
-		logrus.WithError(err).Error("failed to create rate limit middleware")
-	} else {
-		e.Use(rateLimitMiddleware)
+		panic(fmt.Sprintf("invalid rate limit config: %v", err))
 	}
+	e.Use(rateLimitMiddleware)
 
 	// Health check
 	e.GET("/health", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
 	})
 
-	// Huma API設定
-	humaConfig := huma.DefaultConfig("go-webdb-template API", "1.0.0")
+	// Public APIキーの検証に使う公開鍵（RS256 / EdDSA）のJWKS（認証なし）
+	apiKeySet, err := auth.NewKeySet(&cfg.API)
+	if err != nil {
+		panic(fmt.Sprintf("invalid api signing keys in config: %v", err))
+	}
+	e.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, apiKeySet.JWKS())
+	})
+
+	// APIバージョンの一覧（最後が最新）
+	versions, err := apiversion.NewVersions(cfg.API.Versions)
+	if err != nil {
+		panic(fmt.Sprintf("invalid api versions in config: %v", err))
+	}
+
+	// 認証ミドルウェア（/api/パスのみ）
+	authMiddleware := auth.NewHumaAuthMiddleware(&cfg.API, env, deps.APIKeyRegistry, deps.IdentityResolver)
+
+	// Idempotency-Keyミドルウェア（POSTリクエストの重複実行を防止）
+	idempotencyMiddleware, err := idempotency.NewIdempotencyMiddleware(cfg)
+	if err != nil {
+		// エラー時はログに記録し、サーバー起動を継続（fail-open方式）
+		logrus.WithError(err).Error("failed to create idempotency middleware")
+	}
+
+	// 呼び出し元ごとのレートリミット・クォータ（認証ミドルウェアの後に適用、PrincipalLimiterが設定されている場合のみ）
+	// Idempotency-Keyミドルウェアは匿名の呼び出し元・上限に達した呼び出し元が記録を作成しないよう最後に適用
+	useAuthMiddlewares := func(api huma.API) {
+		api.UseMiddleware(authMiddleware)
+		if deps.PrincipalLimiter != nil {
+			api.UseMiddleware(deps.PrincipalLimiter.HumaMiddleware())
+		}
+		if idempotencyMiddleware != nil {
+			api.UseMiddleware(idempotencyMiddleware)
+		}
+	}
+
+	// Humaエンドポイントの登録（バージョンなしのパスとバージョンごとのパスに同じエンドポイントを登録）
+	registerEndpoints := func(api huma.API) {
+		handler.RegisterDmUserEndpoints(api, deps.DmUserHandler)
+		handler.RegisterDmPostEndpoints(api, deps.DmPostHandler)
+		handler.RegisterTodayEndpoints(api, deps.TodayHandler)
+
+		// EmailHandlerが設定されている場合のみ登録
+		if deps.EmailHandler != nil {
+			handler.RegisterEmailEndpoints(api, deps.EmailHandler)
+		}
+
+		// DmJobqueueHandlerが設定されている場合のみ登録
+		if deps.DmJobqueueHandler != nil {
+			handler.RegisterDmJobqueueEndpoints(api, deps.DmJobqueueHandler)
+		}
+
+		// DmBulkHandlerが設定されている場合のみ登録
+		if deps.DmBulkHandler != nil {
+			handler.RegisterDmBulkEndpoints(api, deps.DmBulkHandler)
+		}
+
+		// DmExportHandlerが設定されている場合のみ登録
+		if deps.DmExportHandler != nil {
+			handler.RegisterDmExportEndpoints(api, deps.DmExportHandler)
+		}
+
+		// DmNewsHandlerが設定されている場合のみ登録
+		if deps.DmNewsHandler != nil {
+			handler.RegisterDmNewsEndpoints(api, deps.DmNewsHandler)
+		}
+
+		// DmNewsFeedHandlerが設定されている場合のみ登録
+		if deps.DmNewsFeedHandler != nil {
+			handler.RegisterDmNewsFeedEndpoints(api, deps.DmNewsFeedHandler)
+		}
+
+		// DmMeHandlerが設定されている場合のみ登録
+		if deps.DmMeHandler != nil {
+			handler.RegisterDmMeEndpoints(api, deps.DmMeHandler)
+		}
+	}
+
+	// Huma API設定（バージョンなしのパス）
+	humaConfig := newHumaConfig(cfg, "1.0.0")
+
+	// バージョンなしのパスは最初のバージョンとして扱う（同じアダプター・非推奨ヘッダーを適用）
+	var oldest, latest *apiversion.Version
+	if len(versions) > 0 {
+		oldest, latest = versions[0], versions[len(versions)-1]
+		humaConfig.Transformers = oldest.Adapters()
+	}
+
+	// Huma APIインスタンスの作成（ルートレベル、認証なし）
+	humaAPI := humaecho.New(e, humaConfig)
+
+	// 非推奨のヘッダーは認証エラーのレスポンスにも設定するため、認証より前に適用する
+	if oldest != nil {
+		humaAPI.UseMiddleware(oldest.HeaderMiddleware("/api", latest))
+	}
+	// Humaミドルウェアとして認証を追加（/api/パスのみ）
+	useAuthMiddlewares(humaAPI)
+
+	registerEndpoints(humaAPI)
+
+	// GraphQLHandlerが設定されている場合のみ登録（GraphQLはスキーマで互換性を管理するためバージョンなしのパスのみ）
+	if deps.GraphQLHandler != nil {
+		handler.RegisterGraphQLEndpoints(humaAPI, deps.GraphQLHandler)
+	}
+
+	// バージョンごとのHuma APIインスタンスの作成（/api/{version}/...、OpenAPIドキュメントもバージョンごとに分ける）
+	for _, v := range versions {
+		versionConfig := newHumaConfig(cfg, v.Name)
+		versionConfig.OpenAPIPath = v.Prefix() + "/openapi"
+		versionConfig.DocsPath = v.Prefix() + "/docs"
+		versionConfig.SchemasPath = v.Prefix() + "/schemas"
+		// アダプターはリンク（$schema）の付与より前に適用する
+		versionConfig.Transformers = v.Adapters()
+
+		versionAPI := humaecho.New(e, versionConfig)
+		versionAPI.UseMiddleware(v.HeaderMiddleware(v.Prefix(), latest))
+		useAuthMiddlewares(versionAPI)
+
+		registerEndpoints(v.NewGroup(versionAPI))
+	}
+
+	return e
+}
+
+// newHumaConfig はHuma APIの設定を作成
+func newHumaConfig(cfg *config.Config, version string) huma.Config {
+	humaConfig := huma.DefaultConfig("go-webdb-template API", version)
 	humaConfig.DocsPath = "/docs"
 	humaConfig.Servers = []*huma.Server{
 		{
@@ -80,34 +222,11 @@ func NewRouter(dmUserHandler *handler.DmUserHandler, dmPostHandler *handler.DmPo
 			},
 		},
 	}
-
-	// Huma APIインスタンスの作成（ルートレベル、認証なし）
-	humaAPI := humaecho.New(e, humaConfig)
-
-	// Humaミドルウェアとして認証を追加（/api/パスのみ）
-	humaAPI.UseMiddleware(auth.NewHumaAuthMiddleware(&cfg.API, env, cfg.API.Auth0IssuerBaseURL))
-
-	// Humaエンドポイントの登録
-	handler.RegisterDmUserEndpoints(humaAPI, dmUserHandler)
-	handler.RegisterDmPostEndpoints(humaAPI, dmPostHandler)
-	handler.RegisterTodayEndpoints(humaAPI, todayHandler)
-
-	// EmailHandlerが設定されている場合のみ登録
-	if emailHandler != nil {
-		handler.RegisterEmailEndpoints(humaAPI, emailHandler)
-	}
-
-	// DmJobqueueHandlerが設定されている場合のみ登録
-	if dmJobqueueHandler != nil {
-		handler.RegisterDmJobqueueEndpoints(humaAPI, dmJobqueueHandler)
-	}
-
-	return e
+	return humaConfig
 }
 
-
 // RegisterUploadEndpoints はTUSアップロードエンドポイントを登録する
-func RegisterUploadEndpoints(e *echo.Echo, h *handler.UploadHandler, cfg *config.Config) error {
+func RegisterUploadEndpoints(e *echo.Echo, h *handler.UploadHandler, apiKeyRegistry auth.APIKeyRegistry, identityResolver auth.IdentityResolver, principalLimiter *ratelimit.PrincipalLimiter, cfg *config.Config) error {
 	if h == nil {
 		return nil
 	}
@@ -126,15 +245,50 @@ func RegisterUploadEndpoints(e *echo.Echo, h *handler.UploadHandler, cfg *config
 	}
 
 	// 認証ミドルウェアを作成
-	authMiddleware := auth.NewEchoAuthMiddleware(&cfg.API, env, cfg.API.Auth0IssuerBaseURL)
+	authMiddleware := auth.NewEchoAuthMiddleware(&cfg.API, env, apiKeyRegistry, identityResolver)
+
+	// スコープ検証ミドルウェアを作成（Public APIキーはuploads:writeスコープが必要）
+	scopeMiddleware := auth.RequireEchoScopes(auth.ScopeUploadsWrite)
 
 	// ファイル検証ミドルウェアを作成
 	validationMiddleware := handler.NewUploadValidationMiddleware(uploadCfg)
 
-	// TUSプロトコルの全メソッドをサポート（認証ミドルウェアとファイル検証ミドルウェアを適用）
-	// ミドルウェアは後から追加したものが先に実行される（認証 -> 検証 -> TUSハンドラー）
-	e.Any(basePath, echo.WrapHandler(tusHandler), authMiddleware, validationMiddleware)
-	e.Any(basePath+"/*", echo.WrapHandler(tusHandler), authMiddleware, validationMiddleware)
+	// TUSプロトコルの全メソッドをサポート（認証ミドルウェア、スコープ検証ミドルウェアとファイル検証ミドルウェアを適用）
+	// ミドルウェアは後から追加したものが先に実行される（認証 -> 呼び出し元ごとのレートリミット -> スコープ検証 -> 検証 -> TUSハンドラー）
+	middlewares := authMiddlewares(authMiddleware, principalLimiter)
+	middlewares = append(middlewares, scopeMiddleware, validationMiddleware)
+	e.Any(basePath, echo.WrapHandler(tusHandler), middlewares...)
+	e.Any(basePath+"/*", echo.WrapHandler(tusHandler), middlewares...)
 
 	return nil
 }
+
+// RegisterStreamEndpoints はServer-Sent Eventsのエンドポイントを登録する
+// Humaはストリーミング応答に対応しないため、Echoに直接登録し認証ミドルウェアを適用する
+func RegisterStreamEndpoints(e *echo.Echo, h *handler.StreamHandler, apiKeyRegistry auth.APIKeyRegistry, identityResolver auth.IdentityResolver, principalLimiter *ratelimit.PrincipalLimiter, cfg *config.Config) {
+	if h == nil {
+		return
+	}
+
+	// 環境情報を取得
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = "develop"
+	}
+
+	// 認証ミドルウェアを作成
+	authMiddleware := auth.NewEchoAuthMiddleware(&cfg.API, env, apiKeyRegistry, identityResolver)
+
+	middlewares := authMiddlewares(authMiddleware, principalLimiter)
+	e.GET("/api/stream/posts", h.StreamPosts, append(middlewares, auth.RequireEchoScopes(auth.ScopePostsRead))...)
+	e.GET("/api/stream/news", h.StreamNews, append(middlewares, auth.RequireEchoScopes(auth.ScopeNewsRead))...)
+}
+
+// authMiddlewares はEchoに直接登録するルートの認証ミドルウェアと呼び出し元ごとのレートリミットのミドルウェアを返す
+// principalLimiterがnilの場合は認証ミドルウェアのみ
+func authMiddlewares(authMiddleware echo.MiddlewareFunc, principalLimiter *ratelimit.PrincipalLimiter) []echo.MiddlewareFunc {
+	if principalLimiter == nil {
+		return []echo.MiddlewareFunc{authMiddleware}
+	}
+	return []echo.MiddlewareFunc{authMiddleware, principalLimiter.EchoMiddleware()}
+}
@@ -1,14 +1,18 @@
 package router
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/taku-o/go-webdb-template/internal/api/handler"
 	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/ratelimit"
 	"github.com/taku-o/go-webdb-template/test/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,7 +24,7 @@ func TestOpenAPIEndpoint(t *testing.T) {
 	cfg := testutil.GetTestConfig()
 
 	// ハンドラーはnilでも登録テストは可能
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// テスト用のAPIトークンを取得
 	token, err := testutil.GetTestAPIToken()
@@ -66,7 +70,7 @@ func TestOpenAPIEndpoint(t *testing.T) {
 func TestHealthEndpoint(t *testing.T) {
 	cfg := testutil.GetTestConfig()
 
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
 	rec := httptest.NewRecorder()
@@ -76,6 +80,23 @@ func TestHealthEndpoint(t *testing.T) {
 	assert.Equal(t, "OK", rec.Body.String())
 }
 
+// TestJWKSEndpoint はJWKSエンドポイントが認証なしで公開鍵の一覧を返すことを確認
+func TestJWKSEndpoint(t *testing.T) {
+	cfg := testutil.GetTestConfig()
+
+	router := NewRouter(Deps{}, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var jwks map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jwks))
+	// テスト設定はHS256のsecret_keyのみのため、公開する鍵はない
+	assert.Equal(t, []interface{}{}, jwks["keys"])
+}
+
 // TestRegisterDmUserEndpointsIntegration はユーザーエンドポイントが登録されることを確認
 func TestRegisterDmUserEndpointsIntegration(t *testing.T) {
 	// RegisterDmUserEndpoints関数のシグネチャを確認
@@ -98,7 +119,7 @@ func TestSecuritySchemeInOpenAPI(t *testing.T) {
 	cfg := testutil.GetTestConfig()
 
 	// ルーターを作成
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// テスト用のAPIトークンを取得
 	token, err := testutil.GetTestAPIToken()
@@ -141,7 +162,7 @@ func TestEndpointSecurityInOpenAPI(t *testing.T) {
 	cfg := testutil.GetTestConfig()
 
 	// ルーターを作成
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// テスト用のAPIトークンを取得
 	token, err := testutil.GetTestAPIToken()
@@ -168,23 +189,24 @@ func TestEndpointSecurityInOpenAPI(t *testing.T) {
 	endpointsToCheck := []struct {
 		path   string
 		method string
+		scopes []interface{}
 	}{
 		// Users endpoints
-		{"/api/dm-users", "post"},
-		{"/api/dm-users", "get"},
-		{"/api/dm-users/{id}", "get"},
-		{"/api/dm-users/{id}", "put"},
-		{"/api/dm-users/{id}", "delete"},
-		{"/api/export/dm-users/csv", "get"},
+		{"/api/dm-users", "post", []interface{}{"users:write"}},
+		{"/api/dm-users", "get", []interface{}{"users:read"}},
+		{"/api/dm-users/{id}", "get", []interface{}{"users:read"}},
+		{"/api/dm-users/{id}", "put", []interface{}{"users:write"}},
+		{"/api/dm-users/{id}", "delete", []interface{}{"users:write"}},
+		{"/api/export/dm-users/csv", "get", []interface{}{"users:read"}},
 		// Posts endpoints
-		{"/api/dm-posts", "post"},
-		{"/api/dm-posts", "get"},
-		{"/api/dm-posts/{id}", "get"},
-		{"/api/dm-posts/{id}", "put"},
-		{"/api/dm-posts/{id}", "delete"},
-		{"/api/dm-user-posts", "get"},
-		// Today endpoint
-		{"/api/today", "get"},
+		{"/api/dm-posts", "post", []interface{}{"posts:write"}},
+		{"/api/dm-posts", "get", []interface{}{"posts:read"}},
+		{"/api/dm-posts/{id}", "get", []interface{}{"posts:read"}},
+		{"/api/dm-posts/{id}", "put", []interface{}{"posts:write"}},
+		{"/api/dm-posts/{id}", "delete", []interface{}{"posts:write"}},
+		{"/api/dm-user-posts", "get", []interface{}{"posts:read", "users:read"}},
+		// Today endpoint（privateのためスコープなし）
+		{"/api/today", "get", []interface{}{}},
 	}
 
 	for _, ep := range endpointsToCheck {
@@ -202,8 +224,9 @@ func TestEndpointSecurityInOpenAPI(t *testing.T) {
 			secItem, ok := security[0].(map[string]interface{})
 			require.True(t, ok, "security item should be a map for %s %s", ep.method, ep.path)
 
-			_, ok = secItem["bearerAuth"]
+			scopes, ok := secItem["bearerAuth"]
 			assert.True(t, ok, "bearerAuth should exist in security for %s %s", ep.method, ep.path)
+			assert.Equal(t, ep.scopes, scopes, "scopes should be declared for %s %s", ep.method, ep.path)
 		})
 	}
 }
@@ -214,7 +237,7 @@ func TestEndpointAccessLevelInOpenAPI(t *testing.T) {
 	cfg := testutil.GetTestConfig()
 
 	// ルーターを作成
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// テスト用のAPIトークンを取得
 	token, err := testutil.GetTestAPIToken()
@@ -297,6 +320,131 @@ func TestEndpointAccessLevelInOpenAPI(t *testing.T) {
 	}
 }
 
+// TestVersionedOpenAPIEndpoint はバージョンごとのOpenAPIドキュメントにバージョンのパスが含まれることを確認
+func TestVersionedOpenAPIEndpoint(t *testing.T) {
+	cfg := testutil.GetTestConfig()
+	router := NewRouter(Deps{}, cfg)
+
+	for _, version := range []string{"v1", "v2"} {
+		t.Run(version, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/"+version+"/openapi.json", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var openAPI map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &openAPI))
+
+			info, ok := openAPI["info"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, version, info["version"])
+
+			paths, ok := openAPI["paths"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Contains(t, paths, "/api/"+version+"/dm-users")
+			assert.Contains(t, paths, "/api/"+version+"/dm-posts/{id}")
+			assert.NotContains(t, paths, "/api/dm-users")
+
+			// 一覧取得のfilterパラメータの説明がバージョンのパスにも反映される
+			listUsers := paths["/api/"+version+"/dm-users"].(map[string]interface{})["get"].(map[string]interface{})
+			descriptions := map[string]string{}
+			for _, param := range listUsers["parameters"].([]interface{}) {
+				p := param.(map[string]interface{})
+				description, _ := p["description"].(string)
+				descriptions[p["name"].(string)] = description
+			}
+			assert.Contains(t, descriptions["filter"], "`email` (string)")
+			assert.Contains(t, descriptions["sort"], "`created_at`")
+		})
+	}
+}
+
+// TestVersionedEndpoint_DeprecationHeaders は非推奨のバージョンとバージョンなしのパスにDeprecationヘッダーが設定されることを確認
+func TestVersionedEndpoint_DeprecationHeaders(t *testing.T) {
+	// テスト用のAPIトークンの環境に合わせる
+	t.Setenv("APP_ENV", testutil.TestEnv)
+
+	cfg := testutil.GetTestConfig()
+	cfg.API.Versions[0].DeprecatedAt = "2026-10-19T00:00:00Z"
+	router := NewRouter(Deps{}, cfg)
+
+	token, err := testutil.GetTestAPIToken()
+	require.NoError(t, err)
+
+	// /api/todayはprivate APIのため、Public API Key JWTでは403になる（認証エラーのレスポンスにもヘッダーが設定される）
+	tests := []struct {
+		path            string
+		wantDeprecation bool
+		wantSuccessor   string
+	}{
+		{"/api/v1/today", true, "</api/v2/today>; rel=\"successor-version\""},
+		{"/api/today", true, "</api/v2/today>; rel=\"successor-version\""},
+		{"/api/v2/today", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			if tt.wantDeprecation {
+				assert.Equal(t, "@1792368000", rec.Header().Get("Deprecation"))
+				assert.Contains(t, rec.Header().Values("Link"), tt.wantSuccessor)
+			} else {
+				assert.Empty(t, rec.Header().Get("Deprecation"))
+			}
+		})
+	}
+}
+
+// stubPlanSource は全ての呼び出し元に同じプランを適用するratelimit.PlanSource
+type stubPlanSource struct {
+	plan *model.RateLimitPlan
+}
+
+func (s *stubPlanSource) GetRateLimitPlan(ctx context.Context, principalType, principalID string) (*model.RateLimitPlan, error) {
+	return s.plan, nil
+}
+
+// TestPrincipalRateLimit は認証した呼び出し元ごとのレートリミットがバージョンなし・バージョンごとのパスで共有されることを確認
+func TestPrincipalRateLimit(t *testing.T) {
+	t.Setenv("APP_ENV", testutil.TestEnv)
+
+	cfg := testutil.GetTestConfig()
+	cfg.API.RateLimit.StorageType = "memory"
+	limiter, err := ratelimit.NewPrincipalLimiter(cfg, &stubPlanSource{plan: &model.RateLimitPlan{Name: "test", RequestsPerMinute: 1, RequestsPerDay: 100}})
+	require.NoError(t, err)
+	// 本文が不正なリクエストはハンドラーを呼び出さないため、ユースケースなしで登録する
+	emailHandler := handler.NewEmailHandler(nil)
+	router := NewRouter(Deps{EmailHandler: emailHandler, PrincipalLimiter: limiter}, cfg)
+
+	token, err := testutil.GetTestAPIToken()
+	require.NoError(t, err)
+
+	// 本文の検証エラーになるリクエストも数える
+	req := httptest.NewRequest(http.MethodPost, "/api/email/send", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "100", rec.Header().Get("X-Quota-Daily-Limit"))
+	assert.Equal(t, "99", rec.Header().Get("X-Quota-Daily-Remaining"))
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v2/email/send", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"code":429,"message":"Too Many Requests"}`, rec.Body.String())
+}
+
 // TestRegisterUploadEndpoints はTUSアップロードエンドポイントが登録されることを確認
 func TestRegisterUploadEndpoints(t *testing.T) {
 	// テスト用の一時ディレクトリを作成
@@ -319,10 +467,10 @@ func TestRegisterUploadEndpoints(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg := testutil.GetTestConfig()
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// TUSエンドポイントを登録
-	err = RegisterUploadEndpoints(router, uploadHandler, cfg)
+	err = RegisterUploadEndpoints(router, uploadHandler, nil, nil, nil, cfg)
 	require.NoError(t, err)
 
 	// TUS OPTIONSリクエストのテスト
@@ -361,10 +509,10 @@ func TestRegisterUploadEndpoints_Unauthorized(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg := testutil.GetTestConfig()
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// TUSエンドポイントを登録
-	err = RegisterUploadEndpoints(router, uploadHandler, cfg)
+	err = RegisterUploadEndpoints(router, uploadHandler, nil, nil, nil, cfg)
 	require.NoError(t, err)
 
 	// 認証なしのリクエスト
@@ -378,6 +526,9 @@ func TestRegisterUploadEndpoints_Unauthorized(t *testing.T) {
 
 // TestRegisterUploadEndpoints_FileSizeExceeded はファイルサイズ超過時に413を返すことを確認
 func TestRegisterUploadEndpoints_FileSizeExceeded(t *testing.T) {
+	// テスト用のAPIトークンの環境に合わせる
+	t.Setenv("APP_ENV", testutil.TestEnv)
+
 	// テスト用の一時ディレクトリを作成
 	tempDir := t.TempDir()
 	uploadPath := filepath.Join(tempDir, "uploads")
@@ -398,10 +549,10 @@ func TestRegisterUploadEndpoints_FileSizeExceeded(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg := testutil.GetTestConfig()
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// TUSエンドポイントを登録
-	err = RegisterUploadEndpoints(router, uploadHandler, cfg)
+	err = RegisterUploadEndpoints(router, uploadHandler, nil, nil, nil, cfg)
 	require.NoError(t, err)
 
 	token, err := testutil.GetTestAPIToken()
@@ -420,6 +571,9 @@ func TestRegisterUploadEndpoints_FileSizeExceeded(t *testing.T) {
 
 // TestRegisterUploadEndpoints_InvalidExtension は無効な拡張子で400を返すことを確認
 func TestRegisterUploadEndpoints_InvalidExtension(t *testing.T) {
+	// テスト用のAPIトークンの環境に合わせる
+	t.Setenv("APP_ENV", testutil.TestEnv)
+
 	// テスト用の一時ディレクトリを作成
 	tempDir := t.TempDir()
 	uploadPath := filepath.Join(tempDir, "uploads")
@@ -440,10 +594,10 @@ func TestRegisterUploadEndpoints_InvalidExtension(t *testing.T) {
 	require.NoError(t, err)
 
 	cfg := testutil.GetTestConfig()
-	router := NewRouter(nil, nil, nil, nil, nil, cfg)
+	router := NewRouter(Deps{}, cfg)
 
 	// TUSエンドポイントを登録
-	err = RegisterUploadEndpoints(router, uploadHandler, cfg)
+	err = RegisterUploadEndpoints(router, uploadHandler, nil, nil, nil, cfg)
 	require.NoError(t, err)
 
 	token, err := testutil.GetTestAPIToken()
@@ -0,0 +1,76 @@
+// Package apikey は発行済みPublic APIキーの台帳の失効確認をキャッシュし、利用記録の書き込みを間引く
+package apikey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// Cache はjtiごとの失効確認の結果を保持するキャッシュのインターフェース
+type Cache interface {
+	// Get はキャッシュした失効状態を返す（キャッシュがない場合はfoundがfalse）
+	Get(ctx context.Context, jti string) (revoked bool, found bool, err error)
+	// Set は失効状態をttlの間キャッシュする
+	Set(ctx context.Context, jti string, revoked bool, ttl time.Duration) error
+}
+
+// NewCache は設定に応じたキャッシュを作成
+// Redis（cache_server.redis.default.cluster.addrs）が設定されている場合はRedis、なければメモリにキャッシュする
+func NewCache(cfg *config.Config) Cache {
+	if len(cfg.CacheServer.Redis.Default.Cluster.Addrs) == 0 {
+		return NewMemoryCache()
+	}
+	return NewRedisCache(cfg)
+}
+
+// MemoryCache はプロセス内のメモリにキャッシュする（Redisがない環境用）
+// 管理画面での失効は、キャッシュの期限が切れた後に反映される
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// NewMemoryCache は新しいMemoryCacheを作成
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Get はキャッシュした失効状態を返す
+func (c *MemoryCache) Get(ctx context.Context, jti string) (bool, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[jti]
+	if !ok {
+		return false, false, nil
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, jti)
+		return false, false, nil
+	}
+	return entry.revoked, true, nil
+}
+
+// Set は失効状態をttlの間キャッシュする
+func (c *MemoryCache) Set(ctx context.Context, jti string, revoked bool, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[jti] = memoryEntry{
+		revoked:   revoked,
+		expiresAt: c.now().Add(ttl),
+	}
+	return nil
+}
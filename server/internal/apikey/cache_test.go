@@ -0,0 +1,39 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func TestNewCache_Memory(t *testing.T) {
+	cache := NewCache(&config.Config{})
+	assert.IsType(t, &MemoryCache{}, cache)
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	_, found, err := cache.Get(ctx, "jti")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, cache.Set(ctx, "jti", true, time.Minute))
+	revoked, found, err := cache.Get(ctx, "jti")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, revoked)
+
+	// 期限切れの後はキャッシュがない
+	now = now.Add(time.Minute)
+	_, found, err = cache.Get(ctx, "jti")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
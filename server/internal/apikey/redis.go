@@ -0,0 +1,104 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// RedisCache はRedisにキャッシュする（管理画面とAPIサーバーで共有し、失効を即時に反映する）
+type RedisCache struct {
+	client    *redis.ClusterClient
+	keyPrefix string
+}
+
+// NewRedisCache は新しいRedisCacheを作成
+// Redisへの接続は遅延接続であり、Redisが起動していない場合は各操作がエラーを返す
+func NewRedisCache(cfg *config.Config) *RedisCache {
+	return &RedisCache{
+		client:    redis.NewClusterClient(buildRedisClusterOptions(cfg)),
+		keyPrefix: cfg.API.KeyRegistry.KeyPrefix,
+	}
+}
+
+// Get はキャッシュした失効状態を返す（"1"が失効、"0"が有効）
+func (c *RedisCache) Get(ctx context.Context, jti string) (bool, bool, error) {
+	value, err := c.client.Get(ctx, c.keyPrefix+jti).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get api key cache: %w", err)
+	}
+	return value == "1", true, nil
+}
+
+// Set は失効状態をttlの間キャッシュする
+func (c *RedisCache) Set(ctx context.Context, jti string, revoked bool, ttl time.Duration) error {
+	value := "0"
+	if revoked {
+		value = "1"
+	}
+	if err := c.client.Set(ctx, c.keyPrefix+jti, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set api key cache: %w", err)
+	}
+	return nil
+}
+
+// buildRedisClusterOptions はRedis Cluster接続オプションを構築する
+func buildRedisClusterOptions(cfg *config.Config) *redis.ClusterOptions {
+	clusterCfg := cfg.CacheServer.Redis.Default.Cluster
+	clusterOpts := &redis.ClusterOptions{
+		Addrs: clusterCfg.Addrs,
+	}
+
+	// 接続オプションの設定（設定ファイルから読み込む、未設定の場合はデフォルト値を使用）
+	if clusterCfg.MaxRetries > 0 {
+		clusterOpts.MaxRetries = clusterCfg.MaxRetries
+	} else {
+		clusterOpts.MaxRetries = 2 // デフォルト値
+	}
+
+	if clusterCfg.MinRetryBackoff > 0 {
+		clusterOpts.MinRetryBackoff = clusterCfg.MinRetryBackoff
+	} else {
+		clusterOpts.MinRetryBackoff = 8 * time.Millisecond // デフォルト値
+	}
+
+	if clusterCfg.MaxRetryBackoff > 0 {
+		clusterOpts.MaxRetryBackoff = clusterCfg.MaxRetryBackoff
+	} else {
+		clusterOpts.MaxRetryBackoff = 512 * time.Millisecond // デフォルト値
+	}
+
+	if clusterCfg.DialTimeout > 0 {
+		clusterOpts.DialTimeout = clusterCfg.DialTimeout
+	} else {
+		clusterOpts.DialTimeout = 5 * time.Second // デフォルト値
+	}
+
+	if clusterCfg.ReadTimeout > 0 {
+		clusterOpts.ReadTimeout = clusterCfg.ReadTimeout
+	} else {
+		clusterOpts.ReadTimeout = 3 * time.Second // デフォルト値
+	}
+
+	if clusterCfg.PoolSize > 0 {
+		clusterOpts.PoolSize = clusterCfg.PoolSize
+	} else {
+		clusterOpts.PoolSize = 10 * runtime.NumCPU() // デフォルト値: CPU数×10
+	}
+
+	if clusterCfg.PoolTimeout > 0 {
+		clusterOpts.PoolTimeout = clusterCfg.PoolTimeout
+	} else {
+		clusterOpts.PoolTimeout = 4 * time.Second // デフォルト値
+	}
+
+	return clusterOpts
+}
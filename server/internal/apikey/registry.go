@@ -0,0 +1,103 @@
+package apikey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// usageRecordTimeout は最終利用日時の記録のタイムアウト
+const usageRecordTimeout = 5 * time.Second
+
+// Source は台帳の参照・更新を行うインターフェース（service.APIKeyServiceが実装する）
+type Source interface {
+	IsAPIKeyRevoked(ctx context.Context, jti string) (bool, error)
+	RecordAPIKeyUsage(ctx context.Context, jti string, usedAt time.Time) error
+}
+
+// Registry はauth.APIKeyRegistryの実装
+// 失効確認の結果をキャッシュし、最終利用日時の記録はキーごとにUsageIntervalに1回に間引く
+type Registry struct {
+	source Source
+	cache  Cache
+	cfg    *config.APIKeyRegistryConfig
+	now    func() time.Time
+
+	mu           sync.Mutex
+	lastRecorded map[string]time.Time
+	wg           sync.WaitGroup
+}
+
+// NewRegistry は新しいRegistryを作成
+func NewRegistry(cfg *config.Config, source Source) *Registry {
+	return newRegistry(&cfg.API.KeyRegistry, source, NewCache(cfg))
+}
+
+func newRegistry(cfg *config.APIKeyRegistryConfig, source Source, cache Cache) *Registry {
+	return &Registry{
+		source:       source,
+		cache:        cache,
+		cfg:          cfg,
+		now:          time.Now,
+		lastRecorded: make(map[string]time.Time),
+	}
+}
+
+// IsRevoked はjtiのキーが失効しているかを返す
+// キャッシュを参照できない場合は台帳を直接参照する
+func (r *Registry) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	revoked, found, err := r.cache.Get(ctx, jti)
+	if err != nil {
+		logrus.WithError(err).Warn("failed to get api key revocation from cache")
+	} else if found {
+		return revoked, nil
+	}
+
+	revoked, err = r.source.IsAPIKeyRevoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+	if err := r.cache.Set(ctx, jti, revoked, r.cfg.CacheTTL); err != nil {
+		logrus.WithError(err).Warn("failed to set api key revocation to cache")
+	}
+	return revoked, nil
+}
+
+// RecordUsage はjtiのキーの最終利用日時を非同期に記録
+// 前回の記録からUsageIntervalが経過していない場合は記録しない
+func (r *Registry) RecordUsage(ctx context.Context, jti string) {
+	usedAt := r.now()
+
+	r.mu.Lock()
+	if last, ok := r.lastRecorded[jti]; ok && usedAt.Sub(last) < r.cfg.UsageInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastRecorded[jti] = usedAt
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		// リクエストのキャンセルに影響されないよう、独立したコンテキストで記録する
+		ctx, cancel := context.WithTimeout(context.Background(), usageRecordTimeout)
+		defer cancel()
+		if err := r.source.RecordAPIKeyUsage(ctx, jti, usedAt); err != nil {
+			logrus.WithError(err).WithField("jti", jti).Warn("failed to record api key usage")
+		}
+	}()
+}
+
+// MarkRevoked は失効したキーをキャッシュに反映する（Redisの場合は他のサーバーにも即時に反映される）
+func (r *Registry) MarkRevoked(ctx context.Context, jti string) error {
+	return r.cache.Set(ctx, jti, true, r.cfg.CacheTTL)
+}
+
+// Wait は記録中の最終利用日時の書き込みが終わるまで待つ（シャットダウン時・テスト用）
+func (r *Registry) Wait() {
+	r.wg.Wait()
+}
@@ -0,0 +1,107 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// MockSource はSourceのモック
+type MockSource struct {
+	IsAPIKeyRevokedFunc   func(ctx context.Context, jti string) (bool, error)
+	RecordAPIKeyUsageFunc func(ctx context.Context, jti string, usedAt time.Time) error
+}
+
+func (m *MockSource) IsAPIKeyRevoked(ctx context.Context, jti string) (bool, error) {
+	if m.IsAPIKeyRevokedFunc != nil {
+		return m.IsAPIKeyRevokedFunc(ctx, jti)
+	}
+	return false, nil
+}
+
+func (m *MockSource) RecordAPIKeyUsage(ctx context.Context, jti string, usedAt time.Time) error {
+	if m.RecordAPIKeyUsageFunc != nil {
+		return m.RecordAPIKeyUsageFunc(ctx, jti, usedAt)
+	}
+	return nil
+}
+
+var testRegistryConfig = &config.APIKeyRegistryConfig{CacheTTL: time.Minute, UsageInterval: 5 * time.Minute}
+
+func TestRegistry_IsRevoked(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	source := &MockSource{
+		IsAPIKeyRevokedFunc: func(ctx context.Context, jti string) (bool, error) {
+			calls++
+			return jti == "revoked", nil
+		},
+	}
+	registry := newRegistry(testRegistryConfig, source, NewMemoryCache())
+
+	revoked, err := registry.IsRevoked(ctx, "revoked")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = registry.IsRevoked(ctx, "active")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// 2回目以降はキャッシュを参照する
+	_, err = registry.IsRevoked(ctx, "active")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// 失効をキャッシュに反映する
+	require.NoError(t, registry.MarkRevoked(ctx, "active"))
+	revoked, err = registry.IsRevoked(ctx, "active")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRegistry_IsRevoked_SourceError(t *testing.T) {
+	source := &MockSource{
+		IsAPIKeyRevokedFunc: func(ctx context.Context, jti string) (bool, error) {
+			return false, errors.New("connection refused")
+		},
+	}
+	registry := newRegistry(testRegistryConfig, source, NewMemoryCache())
+
+	_, err := registry.IsRevoked(context.Background(), "jti")
+	assert.Error(t, err)
+}
+
+func TestRegistry_RecordUsage(t *testing.T) {
+	var mu sync.Mutex
+	recorded := make([]string, 0)
+	source := &MockSource{
+		RecordAPIKeyUsageFunc: func(ctx context.Context, jti string, usedAt time.Time) error {
+			mu.Lock()
+			defer mu.Unlock()
+			recorded = append(recorded, jti)
+			return nil
+		},
+	}
+	registry := newRegistry(testRegistryConfig, source, NewMemoryCache())
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	registry.RecordUsage(ctx, "a")
+	registry.RecordUsage(ctx, "a")
+	registry.RecordUsage(ctx, "b")
+	registry.Wait()
+	assert.ElementsMatch(t, []string{"a", "b"}, recorded)
+
+	// UsageIntervalが経過した後は再び記録する
+	now = now.Add(5 * time.Minute)
+	registry.RecordUsage(ctx, "a")
+	registry.Wait()
+	assert.Len(t, recorded, 3)
+}
@@ -0,0 +1,87 @@
+// Package apperror はリポジトリからハンドラーまで共通で使用するドメインエラーの種類を定義する
+// 各層のエラーは種類のエラーをラップして作成し、ハンドラーで種類に応じたHTTPステータスに変換する
+package apperror
+
+import (
+	"context"
+	"errors"
+)
+
+// エラーの種類（errors.Isで判定する）
+var (
+	// ErrForbidden は呼び出し元に操作の権限がない場合のエラー（403）
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound は対象が存在しない場合のエラー（404）
+	ErrNotFound = errors.New("not found")
+	// ErrConflict は既存のデータと競合する場合のエラー（409）
+	ErrConflict = errors.New("conflict")
+	// ErrValidation はリクエストの内容が不正な場合のエラー（422）
+	ErrValidation = errors.New("validation failed")
+	// ErrUnavailable は依存するサービスが利用できない場合のエラー（503）
+	ErrUnavailable = errors.New("service unavailable")
+	// ErrTimeout は処理が時間内に完了しなかった場合のエラー（504）
+	ErrTimeout = errors.New("timeout")
+)
+
+// kindError は種類を持つエラー（メッセージには種類を含めない）
+type kindError struct {
+	kind    error
+	message string
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// New は種類kindのエラーを作成（errors.Is(err, kind)がtrueになる）
+func New(kind error, message string) error {
+	return &kindError{kind: kind, message: message}
+}
+
+// Forbidden はErrForbiddenの種類のエラーを作成
+func Forbidden(message string) error {
+	return New(ErrForbidden, message)
+}
+
+// NotFound はErrNotFoundの種類のエラーを作成
+func NotFound(message string) error {
+	return New(ErrNotFound, message)
+}
+
+// Conflict はErrConflictの種類のエラーを作成
+func Conflict(message string) error {
+	return New(ErrConflict, message)
+}
+
+// Validation はErrValidationの種類のエラーを作成
+func Validation(message string) error {
+	return New(ErrValidation, message)
+}
+
+// Unavailable はErrUnavailableの種類のエラーを作成
+func Unavailable(message string) error {
+	return New(ErrUnavailable, message)
+}
+
+// Timeout はErrTimeoutの種類のエラーを作成
+func Timeout(message string) error {
+	return New(ErrTimeout, message)
+}
+
+// KindOf はエラーの種類を返す（種類がない場合はnil）
+// context.DeadlineExceededはErrTimeoutとして扱う
+func KindOf(err error) error {
+	for _, kind := range []error{ErrForbidden, ErrNotFound, ErrConflict, ErrValidation, ErrUnavailable, ErrTimeout} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	errUserNotFound := NotFound("user not found")
+	err := fmt.Errorf("failed to get user: %w", fmt.Errorf("%w: abc", errUserNotFound))
+
+	assert.Equal(t, "failed to get user: user not found: abc", err.Error())
+	assert.ErrorIs(t, err, errUserNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrValidation)
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"forbidden", Forbidden("not the owner of the user"), ErrForbidden},
+		{"not found", NotFound("user not found"), ErrNotFound},
+		{"conflict", Conflict("email already exists"), ErrConflict},
+		{"validation", fmt.Errorf("%w: too many ids", Validation("invalid batch get request")), ErrValidation},
+		{"unavailable", Unavailable("job queue is unavailable"), ErrUnavailable},
+		{"timeout", Timeout("export timed out"), ErrTimeout},
+		{"deadline exceeded", fmt.Errorf("failed to query: %w", context.DeadlineExceeded), ErrTimeout},
+		{"internal", errors.New("failed to connect"), nil},
+		{"nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, KindOf(tt.err))
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package auth
+
+import "context"
+
+// APIKeyRegistry は発行済みPublic APIキーの台帳（キーごとの失効確認と利用記録）
+type APIKeyRegistry interface {
+	// IsRevoked はjtiのキーが失効しているかを返す（台帳にないキーは失効していないものとして扱う）
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// RecordUsage はjtiのキーが使用されたことを記録する（リクエストの処理を待たせないよう非同期に記録する）
+	RecordUsage(ctx context.Context, jti string)
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// authenticator はBearerトークン（Public API Key JWT / OIDCのIssuerのJWT）を検証し、許容する公開レベルと呼び出し元を返す
+// HTTP（Huma・Echo）とgRPCの認証で共通に使う。返すエラーのメッセージはそのままレスポンスに使う
+type authenticator struct {
+	apiKeys          *JWTValidator
+	issuers          *IssuerRegistry
+	identityResolver IdentityResolver
+}
+
+// errIdentityResolution は紐づくdm_usersの解決に失敗した場合のエラー（認証エラーではなくサーバーエラーとして扱う）
+var errIdentityResolution = errors.New("Failed to resolve user")
+
+// newAuthenticator は新しいauthenticatorを作成（起動時エラーはpanic）
+// identityResolverがnilの場合、user_idクレームのないユーザーはdm_usersと紐づけない
+func newAuthenticator(cfg *config.APIConfig, env string, apiKeyRegistry APIKeyRegistry, identityResolver IdentityResolver) *authenticator {
+	validator, err := NewJWTValidator(cfg, env, apiKeyRegistry)
+	if err != nil {
+		// エラーハンドリング（起動時エラーとして処理）
+		panic("failed to create JWTValidator: " + err.Error())
+	}
+
+	issuers, err := NewIssuerRegistry(cfg)
+	if err != nil {
+		// エラーハンドリング（起動時エラーとして処理）
+		panic("failed to create IssuerRegistry: " + err.Error())
+	}
+
+	return &authenticator{
+		apiKeys:          validator,
+		issuers:          issuers,
+		identityResolver: identityResolver,
+	}
+}
+
+// bearerToken はAuthorizationヘッダーの値からBearerトークンを取り出す
+func bearerToken(authHeader string) (string, error) {
+	if authHeader == "" {
+		return "", errors.New("Authorization header is required")
+	}
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", errors.New("Invalid authorization header format")
+	}
+	return parts[1], nil
+}
+
+// authenticate はBearerトークンを検証し、許容する公開レベルと呼び出し元を返す
+func (a *authenticator) authenticate(ctx context.Context, tokenString string) (AccessLevel, *Principal, error) {
+	// JWT種類の判別
+	jwtType, issuer, err := a.issuers.DetectJWTType(tokenString)
+	if err != nil {
+		if errors.Is(err, ErrUnknownIssuer) {
+			return "", nil, errors.New("Unknown token issuer")
+		}
+		return "", nil, errors.New("Invalid token format")
+	}
+
+	// JWT種類に応じた検証
+	switch jwtType {
+	case JWTTypeOIDC:
+		token, err := issuer.Validate(tokenString)
+		if err != nil {
+			return "", nil, errors.New("Invalid " + issuer.Name() + " JWT")
+		}
+		principal := issuer.Principal(token)
+		if err := a.resolveUserID(ctx, principal); err != nil {
+			return "", nil, err
+		}
+		// 公開レベルはIssuerの設定（デフォルトはpublicとprivateの両方にアクセス可能）
+		return issuer.AccessLevel(), principal, nil
+
+	case JWTTypePublicAPIKey:
+		claims, err := a.apiKeys.ValidateJWT(ctx, tokenString)
+		if err != nil {
+			return "", nil, errors.New("Invalid API key")
+		}
+		// Public API Key JWTはpublicなAPIのみアクセス可能
+		return AccessLevelPublic, newAPIKeyPrincipal(claims), nil
+	}
+
+	return "", nil, errors.New("Unknown JWT type")
+}
+
+// resolveUserID はuser_idクレームのないユーザーに紐づくdm_usersのIDを設定
+func (a *authenticator) resolveUserID(ctx context.Context, principal *Principal) error {
+	if a.identityResolver == nil || principal.UserID != "" {
+		return nil
+	}
+	userID, err := a.identityResolver.ResolveUserID(ctx, principal)
+	if err != nil {
+		log.Printf("Failed to resolve user for %s %s: %v", principal.Issuer, principal.Subject, err)
+		return errIdentityResolution
+	}
+	principal.UserID = userID
+	return nil
+}
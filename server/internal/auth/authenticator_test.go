@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// MockIdentityResolver はIdentityResolverのモック
+type MockIdentityResolver struct {
+	ResolveUserIDFunc func(ctx context.Context, principal *Principal) (string, error)
+	calls             int
+}
+
+func (m *MockIdentityResolver) ResolveUserID(ctx context.Context, principal *Principal) (string, error) {
+	m.calls++
+	if m.ResolveUserIDFunc != nil {
+		return m.ResolveUserIDFunc(ctx, principal)
+	}
+	return "", nil
+}
+
+func TestAuthenticator_ResolveUserID(t *testing.T) {
+	keycloak := newTestIssuer(t, testKeycloakIssuer)
+	cfg := getTestAPIConfig()
+	cfg.Issuers = []config.IssuerConfig{keycloak.config()}
+	cfg.Issuers[0].Claims.UserID = "dm_user_id"
+	ctx := context.Background()
+
+	resolver := &MockIdentityResolver{
+		ResolveUserIDFunc: func(ctx context.Context, principal *Principal) (string, error) {
+			assert.Equal(t, testKeycloakIssuer, principal.Issuer)
+			assert.Equal(t, "alice@example.com", principal.Email)
+			return "0192a0b0c0d0e0f00000000000000001", nil
+		},
+	}
+	authn := newAuthenticator(cfg, mwTestEnv, nil, resolver)
+
+	_, principal, err := authn.authenticate(ctx, keycloak.sign(t, keycloak.claims(jwt.MapClaims{"email": "alice@example.com"})))
+	require.NoError(t, err)
+	assert.Equal(t, "0192a0b0c0d0e0f00000000000000001", principal.UserID)
+	assert.Equal(t, 1, resolver.calls)
+
+	// user_idクレームがある場合は解決しない
+	_, principal, err = authn.authenticate(ctx, keycloak.sign(t, keycloak.claims(jwt.MapClaims{"dm_user_id": "0192a0b0c0d0e0f00000000000000002"})))
+	require.NoError(t, err)
+	assert.Equal(t, "0192a0b0c0d0e0f00000000000000002", principal.UserID)
+	assert.Equal(t, 1, resolver.calls)
+
+	// Public APIキーは解決しない
+	token, err := getTestAPIToken()
+	require.NoError(t, err)
+	_, _, err = authn.authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolver.calls)
+
+	// 解決に失敗した場合はサーバーエラー
+	resolver.ResolveUserIDFunc = func(ctx context.Context, principal *Principal) (string, error) {
+		return "", errors.New("database unavailable")
+	}
+	_, _, err = authn.authenticate(ctx, keycloak.sign(t, keycloak.claims(nil)))
+	assert.ErrorIs(t, err, errIdentityResolution)
+	assert.Equal(t, http.StatusInternalServerError, authErrorStatus(err))
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcHealthServicePrefix はヘルスチェックサービスのメソッド名のプレフィックス（認証不要）
+const grpcHealthServicePrefix = "/grpc.health.v1.Health/"
+
+// grpcReadMethodPrefixes は参照系のRPC名のプレフィックス（リソースの:readスコープで呼び出せる）
+// それ以外のRPCはリソースの:writeスコープが必要
+var grpcReadMethodPrefixes = []string{"Get", "List", "Search", "BatchGet"}
+
+// grpcServiceResources はgRPCサービスとスコープのリソース名の対応
+var grpcServiceResources = map[string]string{
+	"dm.v1.DmUserService": "users",
+	"dm.v1.DmPostService": "posts",
+}
+
+// NewGRPCAuthInterceptors はgRPC用の認証インターセプター（unary, stream）を作成
+// HTTPの認証ミドルウェアと同じく、authorizationメタデータのJWTを検証し、許容する公開レベルをコンテキストに設定する
+func NewGRPCAuthInterceptors(cfg *config.APIConfig, env string, apiKeyRegistry APIKeyRegistry, identityResolver IdentityResolver) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	authn := newAuthenticator(cfg, env, apiKeyRegistry, identityResolver)
+
+	authenticate := func(ctx context.Context, fullMethod string) (context.Context, error) {
+		// ヘルスチェックは認証をスキップ
+		if strings.HasPrefix(fullMethod, grpcHealthServicePrefix) {
+			return ctx, nil
+		}
+
+		// authorizationメタデータからBearerトークンを取得
+		md, _ := metadata.FromIncomingContext(ctx)
+		var authHeader string
+		if values := md.Get("authorization"); len(values) > 0 {
+			authHeader = values[0]
+		}
+		tokenString, err := bearerToken(authHeader)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		// JWTの検証
+		allowedAccessLevel, principal, err := authn.authenticate(ctx, tokenString)
+		if err != nil {
+			if errors.Is(err, errIdentityResolution) {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		// JWTの許容する公開レベルと呼び出し元をコンテキストに設定
+		newCtx := withAuthContext(ctx, allowedAccessLevel, principal)
+
+		// スコープ検証（RPCのサービスに対応するリソースのスコープ）
+		if err := CheckScopes(newCtx, grpcRequiredScopes(fullMethod)); err != nil {
+			return nil, status.Error(codes.PermissionDenied, "Insufficient scope: "+err.Error())
+		}
+
+		return newCtx, nil
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(newCtx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: newCtx})
+	}
+
+	return unary, stream
+}
+
+// authServerStream は認証済みのコンテキストを返すServerStream
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context は認証済みのコンテキストを返す
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcRequiredScopes はRPCの呼び出しに必要なスコープを返す
+// 参照系のRPC（Get, List, Search, BatchGet）はリソースの:read、それ以外は:writeを要求する
+// 対応するリソースがないサービスはPublic APIキーで呼び出せない（nilを返す）
+func grpcRequiredScopes(fullMethod string) []string {
+	sep := strings.LastIndex(fullMethod, "/")
+	if sep < 0 {
+		return nil
+	}
+	resource, ok := grpcServiceResources[strings.TrimPrefix(fullMethod[:sep], "/")]
+	if !ok {
+		return nil
+	}
+
+	name := fullMethod[sep+1:]
+	for _, prefix := range grpcReadMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return []string{resource + ":read"}
+		}
+	}
+	return []string{resource + ":write"}
+}
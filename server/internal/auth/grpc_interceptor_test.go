@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcTestContext はauthorizationメタデータを設定したコンテキストを返す
+func grpcTestContext(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+// callUnary はunaryインターセプターを通してハンドラーを呼び出し、ハンドラーが受け取ったコンテキストを返す
+func callUnary(t *testing.T, interceptor grpc.UnaryServerInterceptor, ctx context.Context, fullMethod string) (context.Context, error) {
+	t.Helper()
+	var handlerCtx context.Context
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCtx = ctx
+		return nil, nil
+	})
+	return handlerCtx, err
+}
+
+// grpcTestStream はコンテキストのみを返すServerStream
+type grpcTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestGRPCUnaryInterceptor_NoAuthorization(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	_, err := callUnary(t, unary, grpcTestContext(""), "/dm.v1.DmUserService/GetDmUser")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGRPCUnaryInterceptor_InvalidAuthorization(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	_, err := callUnary(t, unary, grpcTestContext("InvalidToken"), "/dm.v1.DmUserService/GetDmUser")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = callUnary(t, unary, grpcTestContext("Bearer invalid"), "/dm.v1.DmUserService/GetDmUser")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGRPCUnaryInterceptor_ValidToken(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	token, err := getTestAPIToken()
+	require.NoError(t, err)
+
+	ctx, err := callUnary(t, unary, grpcTestContext("Bearer "+token), "/dm.v1.DmUserService/CreateDmUser")
+	require.NoError(t, err)
+	assert.Equal(t, AccessLevelPublic, ctx.Value(AllowedAccessLevelKey))
+	assert.NoError(t, CheckAccessLevel(ctx, AccessLevelPublic))
+	assert.Error(t, CheckAccessLevel(ctx, AccessLevelPrivate))
+}
+
+func TestGRPCUnaryInterceptor_Scope(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	// readスコープのみのPublic API Key JWT
+	claims := &JWTClaims{
+		Issuer:   "go-webdb-template",
+		Subject:  "public_client",
+		Type:     "public",
+		Scope:    []string{"read"},
+		IssuedAt: time.Now().Unix(),
+		Version:  "v2",
+		Env:      mwTestEnv,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mwTestSecretKey))
+	require.NoError(t, err)
+	ctx := grpcTestContext("Bearer " + token)
+
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmUserService/GetDmUser")
+	assert.NoError(t, err)
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmPostService/BatchGetDmPosts")
+	assert.NoError(t, err)
+
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmUserService/CreateDmUser")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmPostService/DeleteDmPost")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGRPCUnaryInterceptor_ResourceScope(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	// users:readスコープのみのPublic API Key JWT
+	claims := newPublicAPIKeyClaims("v2", mwTestEnv, time.Now().Unix(), "", []string{ScopeUsersRead})
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mwTestSecretKey))
+	require.NoError(t, err)
+	ctx := grpcTestContext("Bearer " + token)
+
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmUserService/GetDmUser")
+	assert.NoError(t, err)
+
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmPostService/GetDmPost")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callUnary(t, unary, ctx, "/dm.v1.DmUserService/CreateDmUser")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGRPCUnaryInterceptor_Auth0NotConfigured(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	// RS256（Auth0 JWT）として判別されるトークン
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iss": "https://example.auth0.com/"})
+	token.Header["kid"] = "test"
+	signingString, err := token.SigningString()
+	require.NoError(t, err)
+
+	_, err = callUnary(t, unary, grpcTestContext("Bearer "+signingString+".c2ln"), "/dm.v1.DmUserService/GetDmUser")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGRPCUnaryInterceptor_HealthCheckSkipsAuth(t *testing.T) {
+	unary, _ := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	_, err := callUnary(t, unary, grpcTestContext(""), "/grpc.health.v1.Health/Check")
+	assert.NoError(t, err)
+}
+
+func TestGRPCStreamInterceptor(t *testing.T) {
+	_, stream := NewGRPCAuthInterceptors(getTestAPIConfig(), mwTestEnv, nil, nil)
+
+	info := &grpc.StreamServerInfo{FullMethod: "/dm.v1.DmPostService/ListDmPosts", IsServerStream: true}
+	var handlerCtx context.Context
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handlerCtx = ss.Context()
+		return nil
+	}
+
+	err := stream(nil, &grpcTestStream{ctx: grpcTestContext("")}, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	token, err := getTestAPIToken()
+	require.NoError(t, err)
+	err = stream(nil, &grpcTestStream{ctx: grpcTestContext("Bearer " + token)}, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, AccessLevelPublic, handlerCtx.Value(AllowedAccessLevelKey))
+}
+
+func TestGRPCRequiredScopes(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		want       []string
+	}{
+		{"/dm.v1.DmUserService/GetDmUser", []string{ScopeUsersRead}},
+		{"/dm.v1.DmUserService/ListDmUsers", []string{ScopeUsersRead}},
+		{"/dm.v1.DmUserService/BatchGetDmUsers", []string{ScopeUsersRead}},
+		{"/dm.v1.DmPostService/SearchDmPosts", []string{ScopePostsRead}},
+		{"/dm.v1.DmPostService/ListDmUserPosts", []string{ScopePostsRead}},
+		{"/dm.v1.DmUserService/CreateDmUser", []string{ScopeUsersWrite}},
+		{"/dm.v1.DmUserService/UpdateDmUser", []string{ScopeUsersWrite}},
+		{"/dm.v1.DmUserService/PatchDmUser", []string{ScopeUsersWrite}},
+		{"/dm.v1.DmPostService/DeleteDmPost", []string{ScopePostsWrite}},
+		{"/unknown.v1.UnknownService/GetUnknown", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fullMethod, func(t *testing.T) {
+			assert.Equal(t, tt.want, grpcRequiredScopes(tt.fullMethod))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package auth
+
+import "context"
+
+// IdentityResolver はOIDCのIssuerで認証したユーザーと紐づくdm_usersのIDを解決する
+type IdentityResolver interface {
+	// ResolveUserID はissとsubに紐づくdm_usersのIDを返す（初回アクセス時はユーザーを作成して紐づける。紐づけられない場合は空）
+	ResolveUserID(ctx context.Context, principal *Principal) (string, error)
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// PublicAPIKeyIssuer はPublic API Key JWTのiss
+const PublicAPIKeyIssuer = "go-webdb-template"
+
+// ErrUnknownIssuer はissが登録されていないJWTのエラー
+var ErrUnknownIssuer = errors.New("unknown issuer")
+
+// IssuerRegistry はJWTを受け入れるOIDCのIssuerの一覧（issで検索する）
+type IssuerRegistry struct {
+	validators map[string]*OIDCValidator
+}
+
+// NewIssuerRegistry はAPI設定から新しいIssuerRegistryを作成
+// auth0_issuer_base_urlを指定した場合はAuth0、issuersを指定した場合はそれぞれのIssuerを登録する（どちらもない場合は空）
+func NewIssuerRegistry(cfg *config.APIConfig) (*IssuerRegistry, error) {
+	issuers := make([]config.IssuerConfig, 0, len(cfg.Issuers)+1)
+	if cfg.Auth0IssuerBaseURL != "" {
+		issuers = append(issuers, auth0IssuerConfig(cfg))
+	}
+	issuers = append(issuers, cfg.Issuers...)
+
+	r := &IssuerRegistry{validators: make(map[string]*OIDCValidator, len(issuers))}
+	for _, issuerCfg := range issuers {
+		if issuerCfg.Issuer == PublicAPIKeyIssuer {
+			r.Close()
+			return nil, fmt.Errorf("issuer %s is reserved for public API keys", PublicAPIKeyIssuer)
+		}
+		if _, ok := r.validators[issuerCfg.Issuer]; ok {
+			r.Close()
+			return nil, fmt.Errorf("duplicate issuer: %s", issuerCfg.Issuer)
+		}
+		validator, err := NewOIDCValidator(issuerCfg)
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("failed to create validator for issuer %s: %w", issuerCfg.Name, err)
+		}
+		r.validators[issuerCfg.Issuer] = validator
+	}
+	return r, nil
+}
+
+// auth0IssuerConfig はAuth0の設定（auth0_issuer_base_url、auth0、auth0_claims）をIssuerの設定に変換
+func auth0IssuerConfig(cfg *config.APIConfig) config.IssuerConfig {
+	baseURL := strings.TrimSuffix(cfg.Auth0IssuerBaseURL, "/")
+	issuer := cfg.Auth0.Issuer
+	if issuer == "" {
+		issuer = baseURL + "/"
+	}
+	return config.IssuerConfig{
+		Name:        "auth0",
+		Issuer:      issuer,
+		JWKSURL:     baseURL + "/.well-known/jwks.json",
+		JWKSFile:    cfg.Auth0.JWKSFile,
+		Audience:    cfg.Auth0.Audience,
+		ClockSkew:   cfg.Auth0.ClockSkew,
+		AccessLevel: string(AccessLevelPrivate),
+		Claims: config.IssuerClaimsConfig{
+			Roles:  cfg.Auth0Claims.Roles,
+			UserID: cfg.Auth0Claims.UserID,
+		},
+		Permissions: cfg.Auth0.Permissions,
+	}
+}
+
+// Lookup はissに対応するIssuerの検証機能を返す
+func (r *IssuerRegistry) Lookup(issuer string) (*OIDCValidator, bool) {
+	validator, ok := r.validators[issuer]
+	return validator, ok
+}
+
+// Len は登録されたIssuerの数を返す
+func (r *IssuerRegistry) Len() int {
+	return len(r.validators)
+}
+
+// DetectJWTType はJWTの種類とOIDCのIssuerの検証機能を判別（署名検証前）
+// Public API Key JWTの場合、検証機能はnil
+func (r *IssuerRegistry) DetectJWTType(tokenString string) (JWTType, *OIDCValidator, error) {
+	issuer, err := tokenIssuer(tokenString)
+	if err != nil {
+		return JWTTypeUnknown, nil, err
+	}
+
+	if issuer == PublicAPIKeyIssuer {
+		return JWTTypePublicAPIKey, nil, nil
+	}
+	if validator, ok := r.Lookup(issuer); ok {
+		return JWTTypeOIDC, validator, nil
+	}
+
+	return JWTTypeUnknown, nil, fmt.Errorf("%w: %s", ErrUnknownIssuer, issuer)
+}
+
+// VerifyToken はOIDCのIssuerのJWTを検証し、呼び出し元を返す（Public API Key JWTや登録されていないIssuerのJWTはエラー）
+func (r *IssuerRegistry) VerifyToken(tokenString string) (*Principal, error) {
+	jwtType, validator, err := r.DetectJWTType(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if jwtType != JWTTypeOIDC {
+		return nil, errors.New("token is not issued by an OIDC issuer")
+	}
+	token, err := validator.Validate(tokenString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s JWT: %w", validator.Name(), err)
+	}
+	return validator.Principal(token), nil
+}
+
+// Close はリソースを解放
+func (r *IssuerRegistry) Close() {
+	for _, validator := range r.validators {
+		validator.Close()
+	}
+}
+
+// tokenIssuer はJWTのissを取得（署名検証前）
+func tokenIssuer(tokenString string) (string, error) {
+	// 署名検証なしでパース
+	parser := jwt.NewParser()
+	token, _, err := parser.ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return "", fmt.Errorf("failed to parse JWT: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", errors.New("invalid token claims")
+	}
+
+	issuer, ok := claims["iss"].(string)
+	if !ok {
+		return "", errors.New("missing issuer claim")
+	}
+	return issuer, nil
+}
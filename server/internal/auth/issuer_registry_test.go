@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func TestNewIssuerRegistry(t *testing.T) {
+	keycloak := newTestIssuer(t, testKeycloakIssuer)
+
+	// IdPを設定しない場合は空
+	cfg := getTestAPIConfig()
+	cfg.Auth0IssuerBaseURL = ""
+	registry, err := NewIssuerRegistry(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 0, registry.Len())
+
+	// Auth0とissuersの両方を登録
+	cfg = getTestAPIConfig()
+	cfg.Issuers = []config.IssuerConfig{keycloak.config()}
+	registry, err = NewIssuerRegistry(cfg)
+	require.NoError(t, err)
+	defer registry.Close()
+	assert.Equal(t, 2, registry.Len())
+	validator, ok := registry.Lookup(mwTestAuth0IssuerBaseURL + "/")
+	require.True(t, ok)
+	assert.Equal(t, "auth0", validator.Name())
+	_, ok = registry.Lookup(testKeycloakIssuer)
+	assert.True(t, ok)
+
+	// 重複したIssuer
+	cfg.Issuers = []config.IssuerConfig{keycloak.config(), keycloak.config()}
+	_, err = NewIssuerRegistry(cfg)
+	assert.Error(t, err)
+
+	// Public API Key JWTのissは使えない
+	reserved := keycloak.config()
+	reserved.Issuer = PublicAPIKeyIssuer
+	cfg.Issuers = []config.IssuerConfig{reserved}
+	_, err = NewIssuerRegistry(cfg)
+	assert.Error(t, err)
+}
+
+func TestIssuerRegistry_DetectJWTType(t *testing.T) {
+	keycloak := newTestIssuer(t, testKeycloakIssuer)
+	cfg := getTestAPIConfig()
+	cfg.Auth0IssuerBaseURL = ""
+	cfg.Issuers = []config.IssuerConfig{keycloak.config()}
+	registry, err := NewIssuerRegistry(cfg)
+	require.NoError(t, err)
+	defer registry.Close()
+
+	sign := func(claims jwt.MapClaims) string {
+		// 署名は検証しないのでどんな署名でも良い
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+		return tokenString
+	}
+
+	jwtType, validator, err := registry.DetectJWTType(sign(jwt.MapClaims{"iss": PublicAPIKeyIssuer}))
+	require.NoError(t, err)
+	assert.Equal(t, JWTTypePublicAPIKey, jwtType)
+	assert.Nil(t, validator)
+
+	jwtType, validator, err = registry.DetectJWTType(sign(jwt.MapClaims{"iss": testKeycloakIssuer}))
+	require.NoError(t, err)
+	assert.Equal(t, JWTTypeOIDC, jwtType)
+	require.NotNil(t, validator)
+	assert.Equal(t, "test", validator.Name())
+
+	// 登録されていないIssuer（Auth0のドメインでも設定がなければ受け入れない）
+	jwtType, _, err = registry.DetectJWTType(sign(jwt.MapClaims{"iss": "https://example.auth0.com/"}))
+	assert.ErrorIs(t, err, ErrUnknownIssuer)
+	assert.Equal(t, JWTTypeUnknown, jwtType)
+
+	_, _, err = registry.DetectJWTType(sign(jwt.MapClaims{"sub": "user123"}))
+	assert.Error(t, err)
+
+	_, _, err = registry.DetectJWTType("invalid-token")
+	assert.Error(t, err)
+}
+
+func TestIssuerRegistry_VerifyToken(t *testing.T) {
+	keycloak := newTestIssuer(t, testKeycloakIssuer)
+	cfg := getTestAPIConfig()
+	cfg.Auth0IssuerBaseURL = ""
+	cfg.Issuers = []config.IssuerConfig{keycloak.config()}
+	registry, err := NewIssuerRegistry(cfg)
+	require.NoError(t, err)
+	defer registry.Close()
+
+	principal, err := registry.VerifyToken(keycloak.sign(t, keycloak.claims(jwt.MapClaims{"email": "user@example.com"})))
+	require.NoError(t, err)
+	assert.Equal(t, testKeycloakIssuer, principal.Issuer)
+	assert.Equal(t, "user|123", principal.Subject)
+	assert.Equal(t, "user@example.com", principal.Email)
+
+	// 期限切れのJWT
+	_, err = registry.VerifyToken(keycloak.sign(t, keycloak.claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})))
+	assert.Error(t, err)
+
+	// Public API Key JWTは受け入れない
+	token, err := getTestAPIToken()
+	require.NoError(t, err)
+	_, err = registry.VerifyToken(token)
+	assert.Error(t, err)
+}
@@ -1,9 +1,9 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -15,7 +15,7 @@ import (
 type JWTType string
 
 const (
-	JWTTypeAuth0        JWTType = "auth0"
+	JWTTypeOIDC         JWTType = "oidc" // OIDCのIssuer（Auth0など）が発行したJWT
 	JWTTypePublicAPIKey JWTType = "public_api_key"
 	JWTTypeUnknown      JWTType = "unknown"
 )
@@ -29,6 +29,7 @@ type JWTClaims struct {
 	IssuedAt int64    `json:"iat"`
 	Version  string   `json:"version"`
 	Env      string   `json:"env"`
+	JTI      string   `json:"jti,omitempty"` // キーごとの識別子（台帳での失効・利用記録に使用、jti導入前のキーは空）
 	jwt.RegisteredClaims
 }
 
@@ -67,30 +68,32 @@ func (c JWTClaims) GetAudience() (jwt.ClaimStrings, error) {
 
 // JWTValidator はJWT検証機能を提供
 type JWTValidator struct {
-	secretKey       string
+	keySet          *KeySet
 	invalidVersions []string
 	currentEnv      string
+	apiKeyRegistry  APIKeyRegistry
 }
 
 // NewJWTValidator は新しいJWTValidatorを作成
-func NewJWTValidator(cfg *config.APIConfig, env string) *JWTValidator {
+// apiKeyRegistryがnilの場合はキーごとの失効確認・利用記録を行わない
+func NewJWTValidator(cfg *config.APIConfig, env string, apiKeyRegistry APIKeyRegistry) (*JWTValidator, error) {
+	keySet, err := NewKeySet(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key set: %w", err)
+	}
+
 	return &JWTValidator{
-		secretKey:       cfg.SecretKey,
+		keySet:          keySet,
 		invalidVersions: cfg.InvalidVersions,
 		currentEnv:      env,
-	}
+		apiKeyRegistry:  apiKeyRegistry,
+	}, nil
 }
 
 // ValidateJWT はJWTトークンを検証
-func (v *JWTValidator) ValidateJWT(tokenString string) (*JWTClaims, error) {
-	// JWTトークンをパース
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// 署名アルゴリズムの検証
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(v.secretKey), nil
-	})
+func (v *JWTValidator) ValidateJWT(ctx context.Context, tokenString string) (*JWTClaims, error) {
+	// JWTトークンをパース（kidに対応する鍵で署名を検証）
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, v.keySet.Keyfunc)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JWT: %w", err)
@@ -107,13 +110,25 @@ func (v *JWTValidator) ValidateJWT(tokenString string) (*JWTClaims, error) {
 		return nil, err
 	}
 
+	// キーごとの失効確認と利用記録（jtiのないキーはバージョンでのみ無効化できる）
+	if v.apiKeyRegistry != nil && claims.JTI != "" {
+		revoked, err := v.apiKeyRegistry.IsRevoked(ctx, claims.JTI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check api key revocation: %w", err)
+		}
+		if revoked {
+			return nil, errors.New("api key has been revoked")
+		}
+		v.apiKeyRegistry.RecordUsage(ctx, claims.JTI)
+	}
+
 	return claims, nil
 }
 
 // validateClaims はクレームを検証
 func (v *JWTValidator) validateClaims(claims *JWTClaims) error {
 	// issの検証
-	if claims.Issuer != "go-webdb-template" {
+	if claims.Issuer != PublicAPIKeyIssuer {
 		return errors.New("invalid issuer")
 	}
 
@@ -161,19 +176,24 @@ func ParseJWTClaims(tokenString string) (*JWTClaims, error) {
 	return claims, nil
 }
 
-// GeneratePublicAPIKey はPublic JWTキーを生成
-func GeneratePublicAPIKey(secretKey string, currentVersion string, env string, issuedAt int64) (string, error) {
-	claims := &JWTClaims{
-		Issuer:   "go-webdb-template",
+// newPublicAPIKeyClaims はPublic APIキーのクレームを作成
+func newPublicAPIKeyClaims(currentVersion string, env string, issuedAt int64, jti string, scopes []string) *JWTClaims {
+	return &JWTClaims{
+		Issuer:   PublicAPIKeyIssuer,
 		Subject:  "public_client",
 		Type:     "public",
-		Scope:    []string{"read", "write"},
+		Scope:    scopes,
 		IssuedAt: issuedAt,
 		Version:  currentVersion,
 		Env:      env,
+		JTI:      jti,
 	}
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+// GeneratePublicAPIKey はsecretKeyでkidなしのPublic JWTキーを生成（すべてのスコープを付与する）
+// jtiは台帳でキーを識別するための値（空の場合はキーごとの失効ができない）
+func GeneratePublicAPIKey(secretKey string, currentVersion string, env string, issuedAt int64, jti string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newPublicAPIKeyClaims(currentVersion, env, issuedAt, jti, PublicAPIKeyScopes))
 	tokenString, err := token.SignedString([]byte(secretKey))
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
@@ -182,36 +202,12 @@ func GeneratePublicAPIKey(secretKey string, currentVersion string, env string, i
 	return tokenString, nil
 }
 
-// DetectJWTType はJWTの種類を判別（署名検証前）
-func DetectJWTType(tokenString string) (JWTType, error) {
-	// 署名検証なしでパース
-	parser := jwt.NewParser()
-	token, _, err := parser.ParseUnverified(tokenString, jwt.MapClaims{})
+// GeneratePublicAPIKey は署名に使う鍵でkid付きのPublic JWTキーを生成（scopesを付与する）
+func (ks *KeySet) GeneratePublicAPIKey(currentVersion string, env string, issuedAt int64, jti string, scopes []string) (string, error) {
+	tokenString, err := ks.Sign(newPublicAPIKeyClaims(currentVersion, env, issuedAt, jti, scopes))
 	if err != nil {
-		return JWTTypeUnknown, fmt.Errorf("failed to parse JWT: %w", err)
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return JWTTypeUnknown, errors.New("invalid token claims")
-	}
-
-	// issによる判別
-	issuer, ok := claims["iss"].(string)
-	if !ok {
-		return JWTTypeUnknown, errors.New("missing issuer claim")
-	}
-
-	if issuer == "go-webdb-template" {
-		return JWTTypePublicAPIKey, nil
-	}
-
-	// Auth0のドメインパターンをチェック
-	if strings.HasPrefix(issuer, "https://") &&
-		(strings.Contains(issuer, ".auth0.com") ||
-			strings.Contains(issuer, ".auth0.jp")) {
-		return JWTTypeAuth0, nil
+		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
 
-	return JWTTypeUnknown, fmt.Errorf("unknown issuer: %s", issuer)
+	return tokenString, nil
 }
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -148,10 +150,11 @@ func TestJWTValidator_ValidateJWT(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			validator := NewJWTValidator(cfg, tt.env)
+			validator, err := NewJWTValidator(cfg, tt.env, nil)
+			require.NoError(t, err)
 			tokenString := createTestToken(tt.claims, tt.secretKey)
 
-			claims, err := validator.ValidateJWT(tokenString)
+			claims, err := validator.ValidateJWT(context.Background(), tokenString)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -171,6 +174,58 @@ func TestJWTValidator_ValidateJWT(t *testing.T) {
 	}
 }
 
+// fakeAPIKeyRegistry はAPIKeyRegistryのテスト用実装
+type fakeAPIKeyRegistry struct {
+	revoked map[string]bool
+	err     error
+	used    []string
+}
+
+func (r *fakeAPIKeyRegistry) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return r.revoked[jti], r.err
+}
+
+func (r *fakeAPIKeyRegistry) RecordUsage(ctx context.Context, jti string) {
+	r.used = append(r.used, jti)
+}
+
+func TestJWTValidator_ValidateJWT_Registry(t *testing.T) {
+	cfg := &config.APIConfig{SecretKey: testSecretKey, CurrentVersion: "v2"}
+	registry := &fakeAPIKeyRegistry{revoked: map[string]bool{"revoked-key": true}}
+	validator, err := NewJWTValidator(cfg, "develop", registry)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	token, err := GeneratePublicAPIKey(testSecretKey, "v2", "develop", time.Now().Unix(), "active-key")
+	require.NoError(t, err)
+	claims, err := validator.ValidateJWT(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "active-key", claims.JTI)
+	assert.Equal(t, []string{"active-key"}, registry.used)
+
+	// 失効したキーは拒否し、利用を記録しない
+	token, err = GeneratePublicAPIKey(testSecretKey, "v2", "develop", time.Now().Unix(), "revoked-key")
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, token)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api key has been revoked")
+	assert.Equal(t, []string{"active-key"}, registry.used)
+
+	// jtiのないキーは台帳を参照しない
+	registry.err = errors.New("registry unavailable")
+	token, err = GeneratePublicAPIKey(testSecretKey, "v2", "develop", time.Now().Unix(), "")
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, token)
+	require.NoError(t, err)
+
+	// 台帳を参照できない場合は拒否する
+	token, err = GeneratePublicAPIKey(testSecretKey, "v2", "develop", time.Now().Unix(), "active-key")
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, token)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to check api key revocation")
+}
+
 func TestJWTValidator_IsVersionInvalid(t *testing.T) {
 	cfg := &config.APIConfig{
 		SecretKey:       testSecretKey,
@@ -178,7 +233,8 @@ func TestJWTValidator_IsVersionInvalid(t *testing.T) {
 		InvalidVersions: []string{"v1", "v0"},
 	}
 
-	validator := NewJWTValidator(cfg, "develop")
+	validator, err := NewJWTValidator(cfg, "develop", nil)
+	require.NoError(t, err)
 
 	tests := []struct {
 		version string
@@ -225,73 +281,3 @@ func TestParseJWTClaims_InvalidToken(t *testing.T) {
 	_, err := ParseJWTClaims("invalid-token")
 	require.Error(t, err)
 }
-
-func TestDetectJWTType(t *testing.T) {
-	tests := []struct {
-		name      string
-		claims    jwt.MapClaims
-		wantType  JWTType
-		wantErr   bool
-	}{
-		{
-			name: "Public API Key JWT",
-			claims: jwt.MapClaims{
-				"iss": "go-webdb-template",
-				"sub": "public_client",
-			},
-			wantType: JWTTypePublicAPIKey,
-			wantErr:  false,
-		},
-		{
-			name: "Auth0 JWT with .auth0.com domain",
-			claims: jwt.MapClaims{
-				"iss": "https://dev-oaa5vtzmld4dsxtd.jp.auth0.com/",
-				"sub": "auth0|123456",
-			},
-			wantType: JWTTypeAuth0,
-			wantErr:  false,
-		},
-		{
-			name: "Auth0 JWT with .auth0.jp domain",
-			claims: jwt.MapClaims{
-				"iss": "https://example.auth0.jp/",
-				"sub": "auth0|123456",
-			},
-			wantType: JWTTypeAuth0,
-			wantErr:  false,
-		},
-		{
-			name: "Unknown issuer",
-			claims: jwt.MapClaims{
-				"iss": "https://unknown.example.com/",
-				"sub": "user123",
-			},
-			wantType: JWTTypeUnknown,
-			wantErr:  true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// テスト用のJWTトークンを生成（署名は検証しないのでどんな署名でも良い）
-			token := jwt.NewWithClaims(jwt.SigningMethodHS256, tt.claims)
-			tokenString, err := token.SignedString([]byte("test-secret"))
-			require.NoError(t, err)
-
-			gotType, err := DetectJWTType(tokenString)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Equal(t, tt.wantType, gotType)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.wantType, gotType)
-			}
-		})
-	}
-}
-
-func TestDetectJWTType_InvalidToken(t *testing.T) {
-	_, err := DetectJWTType("invalid-token")
-	require.Error(t, err)
-}
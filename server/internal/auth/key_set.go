@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// 署名アルゴリズム
+const (
+	SigningAlgorithmHS256 = "HS256"
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+// defaultLegacyKeyID はAPIConfig.SecretKey（ローテーション導入前の鍵）の鍵のIDのデフォルト値
+const defaultLegacyKeyID = "legacy"
+
+// signingKey はkidで識別する署名鍵
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	retiredAt time.Time // ゼロ値の場合は現役
+}
+
+// KeySet はPublic APIキーのJWTの署名鍵の集合
+// 現役の鍵のうち1つで署名し、退役した鍵も猶予期間の間は検証に使う
+// APIConfig.SecretKey（ローテーション導入前の鍵）も他の鍵と同じく退役でき、kidなしのJWTはこの鍵で検証する
+type KeySet struct {
+	keys        map[string]*signingKey
+	signingKey  *signingKey
+	legacyKey   *signingKey // kidなしのJWTの鍵（SecretKeyが空の場合はnil）
+	gracePeriod time.Duration
+	now         func() time.Time
+}
+
+// NewKeySet は設定から新しいKeySetを作成
+func NewKeySet(cfg *config.APIConfig) (*KeySet, error) {
+	ks := &KeySet{
+		keys:        make(map[string]*signingKey),
+		gracePeriod: cfg.Signing.GracePeriod,
+		now:         time.Now,
+	}
+
+	for _, keyCfg := range cfg.Signing.Keys {
+		if keyCfg.ID == "" {
+			return nil, errors.New("signing key id is required")
+		}
+		if _, ok := ks.keys[keyCfg.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id: %s", keyCfg.ID)
+		}
+		key, err := newSigningKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %w", keyCfg.ID, err)
+		}
+		ks.keys[key.id] = key
+	}
+
+	if cfg.SecretKey != "" {
+		legacyKeyID := cfg.Signing.LegacyKeyID
+		if legacyKeyID == "" {
+			legacyKeyID = defaultLegacyKeyID
+		}
+		if _, ok := ks.keys[legacyKeyID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id: %s", legacyKeyID)
+		}
+		key, err := newSigningKey(config.APISigningKeyConfig{
+			ID:        legacyKeyID,
+			Algorithm: SigningAlgorithmHS256,
+			Secret:    cfg.SecretKey,
+			RetiredAt: cfg.Signing.LegacyRetiredAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %w", legacyKeyID, err)
+		}
+		ks.keys[key.id] = key
+		ks.legacyKey = key
+	}
+
+	if cfg.Signing.KeyID != "" {
+		key, ok := ks.keys[cfg.Signing.KeyID]
+		if !ok {
+			return nil, fmt.Errorf("signing key not found: %s", cfg.Signing.KeyID)
+		}
+		if !key.retiredAt.IsZero() {
+			return nil, fmt.Errorf("signing key is retired: %s", cfg.Signing.KeyID)
+		}
+		ks.signingKey = key
+	} else if ks.legacyKey != nil && ks.legacyKey.retiredAt.IsZero() {
+		ks.signingKey = ks.legacyKey
+	}
+
+	return ks, nil
+}
+
+// newSigningKey は設定から署名鍵を作成
+func newSigningKey(cfg config.APISigningKeyConfig) (*signingKey, error) {
+	key := &signingKey{id: cfg.ID}
+
+	if cfg.RetiredAt != "" {
+		retiredAt, err := time.Parse(time.RFC3339, cfg.RetiredAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retired_at: %w", err)
+		}
+		key.retiredAt = retiredAt
+	}
+
+	switch cfg.Algorithm {
+	case "", SigningAlgorithmHS256:
+		if cfg.Secret == "" {
+			return nil, errors.New("secret is required for HS256")
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(cfg.Secret)
+		key.verifyKey = []byte(cfg.Secret)
+	case SigningAlgorithmRS256, SigningAlgorithmEdDSA:
+		privateKey, err := loadPrivateKey(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		switch k := privateKey.(type) {
+		case *rsa.PrivateKey:
+			if cfg.Algorithm != SigningAlgorithmRS256 {
+				return nil, fmt.Errorf("private key type does not match algorithm %s", cfg.Algorithm)
+			}
+			key.method = jwt.SigningMethodRS256
+			key.signKey = k
+			key.verifyKey = &k.PublicKey
+		case ed25519.PrivateKey:
+			if cfg.Algorithm != SigningAlgorithmEdDSA {
+				return nil, fmt.Errorf("private key type does not match algorithm %s", cfg.Algorithm)
+			}
+			key.method = jwt.SigningMethodEdDSA
+			key.signKey = k
+			key.verifyKey = k.Public()
+		default:
+			return nil, fmt.Errorf("unsupported private key type: %T", privateKey)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", cfg.Algorithm)
+	}
+
+	return key, nil
+}
+
+// loadPrivateKey はPKCS#8のPEMファイルから秘密鍵を読み込む
+func loadPrivateKey(path string) (crypto.PrivateKey, error) {
+	if path == "" {
+		return nil, errors.New("private_key_file is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode private key PEM")
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return privateKey, nil
+}
+
+// Sign はクレームに署名してJWTを生成
+// SecretKeyの鍵で署名する場合は従来どおりkidを付けない
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.signingKey == nil {
+		return "", errors.New("signing key is not configured")
+	}
+
+	token := jwt.NewWithClaims(ks.signingKey.method, claims)
+	if ks.signingKey != ks.legacyKey {
+		token.Header["kid"] = ks.signingKey.id
+	}
+	return token.SignedString(ks.signingKey.signKey)
+}
+
+// Keyfunc はJWTのkidヘッダーに対応する検証鍵を返す（jwt.Keyfunc）
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if kid == "" {
+		// ローテーション導入前のkidなしのJWT
+		if ks.legacyKey == nil {
+			return nil, errors.New("kid is required")
+		}
+		key, ok = ks.legacyKey, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	// 署名アルゴリズムの検証（鍵のアルゴリズムと一致する場合のみ許可）
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	if key.expired(ks.now(), ks.gracePeriod) {
+		return nil, fmt.Errorf("signing key has expired: %s", key.id)
+	}
+	return key.verifyKey, nil
+}
+
+// expired は退役した鍵の猶予期間が過ぎているかを返す
+func (k *signingKey) expired(now time.Time, gracePeriod time.Duration) bool {
+	return !k.retiredAt.IsZero() && now.After(k.retiredAt.Add(gracePeriod))
+}
+
+// JWK はJSON Web Key（RFC 7517）の公開鍵
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS はJSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewRSAJWK はRSA公開鍵（RS256）のJWKを作成
+func NewRSAJWK(kid string, publicKey *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		Alg: SigningAlgorithmRS256,
+		N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+	}
+}
+
+// JWKS は検証に使える公開鍵（RS256 / EdDSA）の一覧を返す
+// HS256の鍵は共有鍵のため公開しない
+func (ks *KeySet) JWKS() *JWKS {
+	now := ks.now()
+	jwks := &JWKS{Keys: make([]JWK, 0)}
+	for _, key := range ks.keys {
+		if key.expired(now, ks.gracePeriod) {
+			continue
+		}
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, NewRSAJWK(key.id, publicKey))
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.id,
+				Use: "sig",
+				Alg: SigningAlgorithmEdDSA,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+	return jwks
+}
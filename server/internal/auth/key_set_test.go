@@ -0,0 +1,286 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// writeTestPrivateKey は秘密鍵をPKCS#8のPEMファイルに書き出す
+func writeTestPrivateKey(t *testing.T, privateKey interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	return path
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	now := time.Now()
+	cfg := &config.APIConfig{
+		SecretKey:      testSecretKey,
+		CurrentVersion: "v2",
+		Signing: config.APISigningConfig{
+			KeyID:       "k2",
+			GracePeriod: 24 * time.Hour,
+			Keys: []config.APISigningKeyConfig{
+				{ID: "k0", Algorithm: "HS256", Secret: "k0-secret", RetiredAt: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+				{ID: "k1", Algorithm: "HS256", Secret: "k1-secret", RetiredAt: now.Add(-time.Hour).Format(time.RFC3339)},
+				{ID: "k2", Algorithm: "HS256", Secret: "k2-secret"},
+			},
+		},
+	}
+	validator, err := NewJWTValidator(cfg, "develop", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// 署名に使う鍵のkidが付与され、検証できる
+	token, err := validator.keySet.GeneratePublicAPIKey("v2", "develop", now.Unix(), "", PublicAPIKeyScopes)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "k2", parsed.Header["kid"])
+	_, err = validator.ValidateJWT(ctx, token)
+	require.NoError(t, err)
+
+	sign := func(kid, secret string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, newPublicAPIKeyClaims("v2", "develop", now.Unix(), "", PublicAPIKeyScopes))
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		tokenString, err := token.SignedString([]byte(secret))
+		require.NoError(t, err)
+		return tokenString
+	}
+
+	// 猶予期間中の退役した鍵は検証できる
+	_, err = validator.ValidateJWT(ctx, sign("k1", "k1-secret"))
+	require.NoError(t, err)
+
+	// 猶予期間を過ぎた鍵は拒否する
+	_, err = validator.ValidateJWT(ctx, sign("k0", "k0-secret"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signing key has expired")
+
+	// 未知のkidは拒否する
+	_, err = validator.ValidateJWT(ctx, sign("unknown", "k2-secret"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown signing key")
+
+	// kidと異なる鍵で署名されたトークンは拒否する
+	_, err = validator.ValidateJWT(ctx, sign("k2", "k1-secret"))
+	require.Error(t, err)
+
+	// kidなしのトークンはsecret_keyで検証する
+	_, err = validator.ValidateJWT(ctx, sign("", testSecretKey))
+	require.NoError(t, err)
+}
+
+func TestKeySet_LegacyKeyRotation(t *testing.T) {
+	now := time.Now()
+	newConfig := func(retiredAt time.Time) *config.APIConfig {
+		return &config.APIConfig{
+			SecretKey:      testSecretKey,
+			CurrentVersion: "v2",
+			Signing: config.APISigningConfig{
+				KeyID:           "k1",
+				GracePeriod:     24 * time.Hour,
+				Keys:            []config.APISigningKeyConfig{{ID: "k1", Algorithm: "HS256", Secret: "k1-secret"}},
+				LegacyRetiredAt: retiredAt.Format(time.RFC3339),
+			},
+		}
+	}
+	ctx := context.Background()
+
+	// secret_keyで署名されたkidなしのキー（ローテーション導入前に発行）
+	legacyToken, err := GeneratePublicAPIKey(testSecretKey, "v2", "develop", now.Unix(), "")
+	require.NoError(t, err)
+
+	// 猶予期間中は退役したsecret_keyで検証できる（kidなし、kid: legacyのどちらも）
+	validator, err := NewJWTValidator(newConfig(now.Add(-time.Hour)), "develop", nil)
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, legacyToken)
+	require.NoError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newPublicAPIKeyClaims("v2", "develop", now.Unix(), "", PublicAPIKeyScopes))
+	token.Header["kid"] = "legacy"
+	tokenString, err := token.SignedString([]byte(testSecretKey))
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, tokenString)
+	require.NoError(t, err)
+
+	// 猶予期間を過ぎるとkidなしのキーは拒否する
+	validator, err = NewJWTValidator(newConfig(now.Add(-48*time.Hour)), "develop", nil)
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, legacyToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signing key has expired: legacy")
+
+	// 現役の鍵で署名したキーは引き続き検証できる
+	token2, err := validator.keySet.GeneratePublicAPIKey("v2", "develop", now.Unix(), "", PublicAPIKeyScopes)
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, token2)
+	require.NoError(t, err)
+}
+
+func TestKeySet_LegacyKeySigning(t *testing.T) {
+	// key_idが空の場合はsecret_keyでkidなしの署名を行う
+	keySet, err := NewKeySet(&config.APIConfig{SecretKey: testSecretKey})
+	require.NoError(t, err)
+	token, err := keySet.GeneratePublicAPIKey("v2", "develop", time.Now().Unix(), "", PublicAPIKeyScopes)
+	require.NoError(t, err)
+	parsed, err := jwt.ParseWithClaims(token, &JWTClaims{}, keySet.Keyfunc)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.Header["kid"])
+
+	// secret_keyを退役させた場合、key_idが空では署名できない
+	keySet, err = NewKeySet(&config.APIConfig{
+		SecretKey: testSecretKey,
+		Signing:   config.APISigningConfig{LegacyRetiredAt: "2026-01-01T00:00:00Z"},
+	})
+	require.NoError(t, err)
+	_, err = keySet.GeneratePublicAPIKey("v2", "develop", time.Now().Unix(), "", PublicAPIKeyScopes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signing key is not configured")
+}
+
+func TestKeySet_AsymmetricKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cfg := &config.APIConfig{
+		CurrentVersion: "v2",
+		Signing: config.APISigningConfig{
+			GracePeriod: time.Hour,
+			Keys: []config.APISigningKeyConfig{
+				{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: writeTestPrivateKey(t, rsaKey)},
+				{ID: "ed", Algorithm: "EdDSA", PrivateKeyFile: writeTestPrivateKey(t, edKey)},
+				{ID: "hs", Algorithm: "HS256", Secret: "hs-secret"},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	for _, kid := range []string{"rsa", "ed"} {
+		t.Run(kid, func(t *testing.T) {
+			keyCfg := *cfg
+			keyCfg.Signing.KeyID = kid
+			validator, err := NewJWTValidator(&keyCfg, "develop", nil)
+			require.NoError(t, err)
+
+			token, err := validator.keySet.GeneratePublicAPIKey("v2", "develop", time.Now().Unix(), "", PublicAPIKeyScopes)
+			require.NoError(t, err)
+			_, err = validator.ValidateJWT(ctx, token)
+			require.NoError(t, err)
+		})
+	}
+
+	// RS256の鍵のkidでHS256署名したトークンは拒否する（アルゴリズムの取り違え）
+	validator, err := NewJWTValidator(cfg, "develop", nil)
+	require.NoError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newPublicAPIKeyClaims("v2", "develop", time.Now().Unix(), "", PublicAPIKeyScopes))
+	token.Header["kid"] = "rsa"
+	tokenString, err := token.SignedString(x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey))
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, tokenString)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected signing method")
+
+	// secret_keyがない場合、kidなしのトークンは拒否する
+	tokenString, err = GeneratePublicAPIKey("any-secret", "v2", "develop", time.Now().Unix(), "")
+	require.NoError(t, err)
+	_, err = validator.ValidateJWT(ctx, tokenString)
+	require.Error(t, err)
+
+	// JWKSにはRS256 / EdDSAの公開鍵のみを含む
+	jwks := validator.keySet.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	kids := map[string]JWK{}
+	for _, key := range jwks.Keys {
+		kids[key.Kid] = key
+	}
+	assert.Equal(t, "RSA", kids["rsa"].Kty)
+	assert.Equal(t, "AQAB", kids["rsa"].E)
+	assert.NotEmpty(t, kids["rsa"].N)
+	assert.Equal(t, "OKP", kids["ed"].Kty)
+	assert.Equal(t, "Ed25519", kids["ed"].Crv)
+	assert.NotEmpty(t, kids["ed"].X)
+}
+
+func TestNewKeySet_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		signing config.APISigningConfig
+		errMsg  string
+	}{
+		{
+			name:    "signing key not found",
+			signing: config.APISigningConfig{KeyID: "missing"},
+			errMsg:  "signing key not found",
+		},
+		{
+			name: "retired signing key",
+			signing: config.APISigningConfig{
+				KeyID: "k1",
+				Keys:  []config.APISigningKeyConfig{{ID: "k1", Secret: "secret", RetiredAt: "2026-01-01T00:00:00Z"}},
+			},
+			errMsg: "signing key is retired",
+		},
+		{
+			name: "duplicate key id",
+			signing: config.APISigningConfig{
+				Keys: []config.APISigningKeyConfig{{ID: "k1", Secret: "a"}, {ID: "k1", Secret: "b"}},
+			},
+			errMsg: "duplicate signing key id",
+		},
+		{
+			name: "missing secret",
+			signing: config.APISigningConfig{
+				Keys: []config.APISigningKeyConfig{{ID: "k1", Algorithm: "HS256"}},
+			},
+			errMsg: "secret is required",
+		},
+		{
+			name: "legacy key id conflicts",
+			signing: config.APISigningConfig{
+				Keys: []config.APISigningKeyConfig{{ID: "legacy", Secret: "a"}},
+			},
+			errMsg: "duplicate signing key id: legacy",
+		},
+		{
+			name:    "invalid legacy retired_at",
+			signing: config.APISigningConfig{LegacyRetiredAt: "yesterday"},
+			errMsg:  "invalid retired_at",
+		},
+		{
+			name: "unsupported algorithm",
+			signing: config.APISigningConfig{
+				Keys: []config.APISigningKeyConfig{{ID: "k1", Algorithm: "none", Secret: "a"}},
+			},
+			errMsg: "unsupported algorithm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeySet(&config.APIConfig{SecretKey: testSecretKey, Signing: tt.signing})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
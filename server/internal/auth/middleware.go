@@ -26,47 +26,11 @@ const (
 	AccessLevelPrivate AccessLevel = "private"
 )
 
-// validateScope はスコープを検証
-func validateScope(claims *JWTClaims, method string) error {
-	hasRead := false
-	hasWrite := false
-
-	for _, scope := range claims.Scope {
-		if scope == "read" {
-			hasRead = true
-		}
-		if scope == "write" {
-			hasWrite = true
-		}
-	}
-
-	// GETリクエストにはreadスコープが必要
-	if method == "GET" && !hasRead {
-		return errors.New("read scope required")
-	}
-
-	// POST/PUT/DELETEリクエストにはwriteスコープが必要
-	if (method == "POST" || method == "PUT" || method == "DELETE") && !hasWrite {
-		return errors.New("write scope required")
-	}
-
-	return nil
-}
-
 // NewHumaAuthMiddleware は新しいHuma形式の認証ミドルウェアを作成
-func NewHumaAuthMiddleware(cfg *config.APIConfig, env string, auth0IssuerBaseURL string) func(ctx huma.Context, next func(huma.Context)) {
-	validator := NewJWTValidator(cfg, env)
-
-	// Auth0Validatorの初期化
-	var auth0Validator *Auth0Validator
-	if auth0IssuerBaseURL != "" {
-		var err error
-		auth0Validator, err = NewAuth0Validator(auth0IssuerBaseURL)
-		if err != nil {
-			// エラーハンドリング（起動時エラーとして処理）
-			panic("failed to create Auth0Validator: " + err.Error())
-		}
-	}
+// apiKeyRegistryを指定した場合はPublic APIキーのキーごとの失効確認・利用記録を行う
+// identityResolverを指定した場合はOIDCのIssuerで認証したユーザーをdm_usersと紐づける
+func NewHumaAuthMiddleware(cfg *config.APIConfig, env string, apiKeyRegistry APIKeyRegistry, identityResolver IdentityResolver) func(ctx huma.Context, next func(huma.Context)) {
+	authn := newAuthenticator(cfg, env, apiKeyRegistry, identityResolver)
 
 	return func(ctx huma.Context, next func(huma.Context)) {
 		path := ctx.URL().Path
@@ -83,71 +47,29 @@ func NewHumaAuthMiddleware(cfg *config.APIConfig, env string, auth0IssuerBaseURL
 			return
 		}
 
-		// AuthorizationヘッダーからJWTトークンを取得
-		authHeader := ctx.Header("Authorization")
-		if authHeader == "" {
-			writeHumaError(ctx, http.StatusUnauthorized, "Authorization header is required")
-			return
-		}
-
-		// Bearerトークンの抽出
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			writeHumaError(ctx, http.StatusUnauthorized, "Invalid authorization header format")
+		// AuthorizationヘッダーからBearerトークンを取得
+		tokenString, err := bearerToken(ctx.Header("Authorization"))
+		if err != nil {
+			writeHumaError(ctx, http.StatusUnauthorized, err.Error())
 			return
 		}
 
-		tokenString := parts[1]
-
-		// JWT種類の判別
-		jwtType, err := DetectJWTType(tokenString)
+		// JWTの検証
+		allowedAccessLevel, principal, err := authn.authenticate(ctx.Context(), tokenString)
 		if err != nil {
-			writeHumaError(ctx, http.StatusUnauthorized, "Invalid token format")
+			writeHumaError(ctx, authErrorStatus(err), err.Error())
 			return
 		}
 
-		var claims *JWTClaims
-		var allowedAccessLevel AccessLevel
+		// JWTの許容する公開レベルと呼び出し元をコンテキストに設定
+		newCtx := withAuthContext(ctx.Context(), allowedAccessLevel, principal)
 
-		// JWT種類に応じた検証
-		switch jwtType {
-		case JWTTypeAuth0:
-			if auth0Validator == nil {
-				writeHumaError(ctx, http.StatusUnauthorized, "Auth0 JWT validation is not configured")
-				return
-			}
-			_, err = auth0Validator.ValidateAuth0JWT(tokenString)
-			if err != nil {
-				writeHumaError(ctx, http.StatusUnauthorized, "Invalid Auth0 JWT")
-				return
-			}
-			// Auth0 JWTはpublicとprivateの両方にアクセス可能
-			allowedAccessLevel = AccessLevelPrivate
-
-		case JWTTypePublicAPIKey:
-			claims, err = validator.ValidateJWT(tokenString)
-			if err != nil {
-				writeHumaError(ctx, http.StatusUnauthorized, "Invalid API key")
-				return
-			}
-			// Public API Key JWTはpublicなAPIのみアクセス可能
-			allowedAccessLevel = AccessLevelPublic
-
-		default:
-			writeHumaError(ctx, http.StatusUnauthorized, "Unknown JWT type")
+		// スコープ検証（Operationで宣言されたスコープ）
+		if err := CheckScopes(newCtx, RequiredScopes(ctx.Operation())); err != nil {
+			writeHumaError(ctx, http.StatusForbidden, "Insufficient scope: "+err.Error())
 			return
 		}
 
-		// スコープ検証（Public API Key JWTの場合のみ）
-		if jwtType == JWTTypePublicAPIKey && claims != nil {
-			if err := validateScope(claims, ctx.Method()); err != nil {
-				writeHumaError(ctx, http.StatusForbidden, "Insufficient scope")
-				return
-			}
-		}
-
-		// JWTの許容する公開レベルをコンテキストに設定
-		newCtx := context.WithValue(ctx.Context(), AllowedAccessLevelKey, allowedAccessLevel)
 		ctx = huma.WithContext(ctx, newCtx)
 
 		// 次のハンドラーを実行
@@ -155,97 +77,71 @@ func NewHumaAuthMiddleware(cfg *config.APIConfig, env string, auth0IssuerBaseURL
 	}
 }
 
-
 // NewEchoAuthMiddleware はEcho用の認証ミドルウェアを作成する
 // TUSエンドポイントなどEchoに直接登録されるハンドラーで使用する
-func NewEchoAuthMiddleware(cfg *config.APIConfig, env string, auth0IssuerBaseURL string) echo.MiddlewareFunc {
-	validator := NewJWTValidator(cfg, env)
-
-	// Auth0Validatorの初期化
-	var auth0Validator *Auth0Validator
-	if auth0IssuerBaseURL != "" {
-		var err error
-		auth0Validator, err = NewAuth0Validator(auth0IssuerBaseURL)
-		if err != nil {
-			// エラーハンドリング（起動時エラーとして処理）
-			panic("failed to create Auth0Validator: " + err.Error())
-		}
-	}
+func NewEchoAuthMiddleware(cfg *config.APIConfig, env string, apiKeyRegistry APIKeyRegistry, identityResolver IdentityResolver) echo.MiddlewareFunc {
+	authn := newAuthenticator(cfg, env, apiKeyRegistry, identityResolver)
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// AuthorizationヘッダーからJWTトークンを取得
-			authHeader := c.Request().Header.Get("Authorization")
-			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Authorization header is required",
-				})
-			}
-
-			// Bearerトークンの抽出
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
+			// AuthorizationヘッダーからBearerトークンを取得
+			tokenString, err := bearerToken(c.Request().Header.Get("Authorization"))
+			if err != nil {
 				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Invalid authorization header format",
+					"error": err.Error(),
 				})
 			}
 
-			tokenString := parts[1]
-
-			// JWT種類の判別
-			jwtType, err := DetectJWTType(tokenString)
+			// JWTの検証
+			allowedAccessLevel, principal, err := authn.authenticate(c.Request().Context(), tokenString)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Invalid token format",
+				return c.JSON(authErrorStatus(err), map[string]string{
+					"error": err.Error(),
 				})
 			}
 
-			var claims *JWTClaims
+			// JWTの許容する公開レベルと呼び出し元をコンテキストに設定
+			// スコープはRequireEchoScopesで検証する
+			c.SetRequest(c.Request().WithContext(withAuthContext(c.Request().Context(), allowedAccessLevel, principal)))
 
-			// JWT種類に応じた検証
-			switch jwtType {
-			case JWTTypeAuth0:
-				if auth0Validator == nil {
-					return c.JSON(http.StatusUnauthorized, map[string]string{
-						"error": "Auth0 JWT validation is not configured",
-					})
-				}
-				_, err = auth0Validator.ValidateAuth0JWT(tokenString)
-				if err != nil {
-					return c.JSON(http.StatusUnauthorized, map[string]string{
-						"error": "Invalid Auth0 JWT",
-					})
-				}
-
-			case JWTTypePublicAPIKey:
-				claims, err = validator.ValidateJWT(tokenString)
-				if err != nil {
-					return c.JSON(http.StatusUnauthorized, map[string]string{
-						"error": "Invalid API key",
-					})
-				}
+			// 次のハンドラーを実行
+			return next(c)
+		}
+	}
+}
 
-			default:
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Unknown JWT type",
+// RequireEchoScopes はEchoのルートで要求するスコープを検証するミドルウェアを作成する
+// NewEchoAuthMiddlewareの後に適用する
+func RequireEchoScopes(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := CheckScopes(c.Request().Context(), scopes); err != nil {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error": "Insufficient scope: " + err.Error(),
 				})
 			}
-
-			// スコープ検証（Public API Key JWTの場合のみ）
-			if jwtType == JWTTypePublicAPIKey && claims != nil {
-				if err := validateScope(claims, c.Request().Method); err != nil {
-					return c.JSON(http.StatusForbidden, map[string]string{
-						"error": "Insufficient scope",
-					})
-				}
-			}
-
-			// 次のハンドラーを実行
 			return next(c)
 		}
 	}
 }
 
+// withAuthContext は許容する公開レベルと呼び出し元をコンテキストに設定
+func withAuthContext(ctx context.Context, allowedAccessLevel AccessLevel, principal *Principal) context.Context {
+	ctx = context.WithValue(ctx, AllowedAccessLevelKey, allowedAccessLevel)
+	if principal != nil {
+		ctx = WithPrincipal(ctx, principal)
+	}
+	return ctx
+}
+
+// authErrorStatus は認証エラーのHTTPステータスコードを返す（紐づくdm_usersの解決に失敗した場合は500）
+func authErrorStatus(err error) int {
+	if errors.Is(err, errIdentityResolution) {
+		return http.StatusInternalServerError
+	}
+	return http.StatusUnauthorized
+}
+
 // writeHumaError はHumaコンテキストにエラーレスポンスを書き込む
 func writeHumaError(ctx huma.Context, statusCode int, message string) {
 	ctx.SetStatus(statusCode)
@@ -269,7 +165,7 @@ func isOpenAPIPath(path string) bool {
 }
 
 // CheckAccessLevel はコンテキストから許容する公開レベルを取得し、エンドポイントの公開レベルと比較
-// エンドポイントがprivateの場合、Auth0 JWTでのみアクセス可能
+// エンドポイントがprivateの場合、privateを許容するIssuer（Auth0など）のJWTでのみアクセス可能
 func CheckAccessLevel(ctx context.Context, endpointLevel AccessLevel) error {
 	allowedLevel, ok := ctx.Value(AllowedAccessLevelKey).(AccessLevel)
 	if !ok {
@@ -278,7 +174,7 @@ func CheckAccessLevel(ctx context.Context, endpointLevel AccessLevel) error {
 
 	// エンドポイントがprivateで、JWTがpublicの場合はエラー
 	if endpointLevel == AccessLevelPrivate && allowedLevel == AccessLevelPublic {
-		return errors.New("private API requires Auth0 authentication")
+		return errors.New("private API requires user authentication")
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,49 +27,22 @@ func getTestAPIConfig() *config.APIConfig {
 		SecretKey:          mwTestSecretKey,
 		InvalidVersions:    []string{"v1"},
 		Auth0IssuerBaseURL: mwTestAuth0IssuerBaseURL,
+		Auth0: config.Auth0Config{
+			// JWKSを取得せず、オフラインで検証する
+			JWKSFile: "testdata/auth0_jwks.json",
+		},
 	}
 }
 
 // getTestAPIToken はテスト用のAPIトークンを生成
 func getTestAPIToken() (string, error) {
-	return GeneratePublicAPIKey(mwTestSecretKey, "v2", mwTestEnv, time.Now().Unix())
+	return GeneratePublicAPIKey(mwTestSecretKey, "v2", mwTestEnv, time.Now().Unix(), "")
 }
 
-func TestValidateScope(t *testing.T) {
-	tests := []struct {
-		name    string
-		scope   []string
-		method  string
-		wantErr bool
-	}{
-		{"read scope GET", []string{"read"}, "GET", false},
-		{"read scope POST", []string{"read"}, "POST", true},
-		{"write scope GET", []string{"write"}, "GET", true},
-		{"write scope POST", []string{"write"}, "POST", false},
-		{"write scope PUT", []string{"write"}, "PUT", false},
-		{"write scope DELETE", []string{"write"}, "DELETE", false},
-		{"read and write scope GET", []string{"read", "write"}, "GET", false},
-		{"read and write scope POST", []string{"read", "write"}, "POST", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			claims := &JWTClaims{Scope: tt.scope}
-			err := validateScope(claims, tt.method)
-			if tt.wantErr {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-			}
-		})
-	}
-}
-
-// TestNewEchoAuthMiddleware はEcho用認証ミドルウェアの作成を確認
 func TestNewEchoAuthMiddleware(t *testing.T) {
 	cfg := getTestAPIConfig()
 
-	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, mwTestAuth0IssuerBaseURL)
+	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, nil, nil)
 	require.NotNil(t, middleware)
 }
 
@@ -76,7 +50,7 @@ func TestNewEchoAuthMiddleware(t *testing.T) {
 func TestEchoAuthMiddleware_NoAuthHeader(t *testing.T) {
 	cfg := getTestAPIConfig()
 
-	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, mwTestAuth0IssuerBaseURL)
+	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/upload/dm_movie", nil)
@@ -96,7 +70,7 @@ func TestEchoAuthMiddleware_NoAuthHeader(t *testing.T) {
 func TestEchoAuthMiddleware_InvalidAuthHeader(t *testing.T) {
 	cfg := getTestAPIConfig()
 
-	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, mwTestAuth0IssuerBaseURL)
+	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/upload/dm_movie", nil)
@@ -117,7 +91,7 @@ func TestEchoAuthMiddleware_InvalidAuthHeader(t *testing.T) {
 func TestEchoAuthMiddleware_ValidToken(t *testing.T) {
 	cfg := getTestAPIConfig()
 
-	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, mwTestAuth0IssuerBaseURL)
+	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, nil, nil)
 
 	token, err := getTestAPIToken()
 	require.NoError(t, err)
@@ -141,7 +115,7 @@ func TestEchoAuthMiddleware_ValidToken(t *testing.T) {
 func TestEchoAuthMiddleware_AllTUSMethods(t *testing.T) {
 	cfg := getTestAPIConfig()
 
-	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, mwTestAuth0IssuerBaseURL)
+	middleware := NewEchoAuthMiddleware(cfg, mwTestEnv, nil, nil)
 
 	token, err := getTestAPIToken()
 	require.NoError(t, err)
@@ -172,3 +146,42 @@ func TestEchoAuthMiddleware_AllTUSMethods(t *testing.T) {
 		})
 	}
 }
+
+// TestRequireEchoScopes はPublic APIキーのスコープに応じてEchoのルートへのアクセスを制御することを確認
+func TestRequireEchoScopes(t *testing.T) {
+	cfg := getTestAPIConfig()
+	authMiddleware := NewEchoAuthMiddleware(cfg, mwTestEnv, nil, nil)
+	scopeMiddleware := RequireEchoScopes(ScopeUploadsWrite)
+
+	tests := []struct {
+		name   string
+		scopes []string
+		want   int
+	}{
+		{"uploads:write scope", []string{ScopeUploadsWrite}, http.StatusOK},
+		{"other scope", []string{ScopePostsWrite}, http.StatusForbidden},
+		{"legacy write scope", []string{LegacyScopeWrite}, http.StatusOK},
+		{"legacy read scope", []string{LegacyScopeRead}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := newPublicAPIKeyClaims("v2", mwTestEnv, time.Now().Unix(), "", tt.scopes)
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mwTestSecretKey))
+			require.NoError(t, err)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/upload/dm_movie", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := authMiddleware(scopeMiddleware(func(c echo.Context) error {
+				return c.String(http.StatusOK, "OK")
+			}))
+
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
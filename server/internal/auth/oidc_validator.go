@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// oidcSigningMethods はOIDCのJWTで受け入れる署名アルゴリズム
+var oidcSigningMethods = []string{
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodPS256.Alg(),
+	jwt.SigningMethodES256.Alg(),
+}
+
+// discoveryTimeout はOpenID Connect Discoveryの取得のタイムアウト
+const discoveryTimeout = 10 * time.Second
+
+// OIDCValidator はOIDCのIssuer（Auth0、Keycloak、Googleなど）が発行したJWTの検証機能を提供
+type OIDCValidator struct {
+	name        string
+	issuer      string
+	accessLevel AccessLevel
+	jwks        *keyfunc.JWKS
+	parser      *jwt.Parser
+	claims      config.IssuerClaimsConfig
+	perms       config.PermissionsConfig
+}
+
+// NewOIDCValidator はIssuerの設定から新しいOIDCValidatorを作成
+func NewOIDCValidator(cfg config.IssuerConfig) (*OIDCValidator, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("issuer is required")
+	}
+
+	var accessLevel AccessLevel
+	switch AccessLevel(cfg.AccessLevel) {
+	case "", AccessLevelPrivate:
+		accessLevel = AccessLevelPrivate
+	case AccessLevelPublic:
+		accessLevel = AccessLevelPublic
+	default:
+		return nil, fmt.Errorf("invalid access level: %s", cfg.AccessLevel)
+	}
+
+	jwks, err := loadIssuerJWKS(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithLeeway(cfg.ClockSkew),
+		jwt.WithExpirationRequired(),
+	}
+	if len(cfg.Audience) > 0 {
+		options = append(options, jwt.WithAudience(cfg.Audience...))
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Issuer
+	}
+
+	return &OIDCValidator{
+		name:        name,
+		issuer:      cfg.Issuer,
+		accessLevel: accessLevel,
+		jwks:        jwks,
+		parser:      jwt.NewParser(options...),
+		claims:      cfg.Claims,
+		perms:       cfg.Permissions,
+	}, nil
+}
+
+// loadIssuerJWKS はIssuerのJWKSを読み込む（jwks_file、jwks_url、discovery_urlの順に使う）
+func loadIssuerJWKS(cfg config.IssuerConfig) (*keyfunc.JWKS, error) {
+	if cfg.JWKSFile != "" {
+		return loadJWKSFile(cfg.JWKSFile)
+	}
+	if cfg.JWKSURL != "" {
+		return fetchJWKS(cfg.JWKSURL)
+	}
+
+	discoveryURL := cfg.DiscoveryURL
+	if discoveryURL == "" {
+		discoveryURL = strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	}
+	jwksURL, err := discoverJWKSURL(discoveryURL, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return fetchJWKS(jwksURL)
+}
+
+// discoverJWKSURL はOpenID Connect DiscoveryからJWKSのURLを取得
+// Discoveryのissuerが設定と異なる場合はエラー
+func discoverJWKSURL(discoveryURL string, issuer string) (string, error) {
+	client := &http.Client{Timeout: discoveryTimeout}
+	resp, err := client.Get(discoveryURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to get OpenID configuration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get OpenID configuration: status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", fmt.Errorf("failed to parse OpenID configuration: %w", err)
+	}
+	if doc.Issuer != issuer {
+		return "", fmt.Errorf("OpenID configuration issuer mismatch: %s", doc.Issuer)
+	}
+	if doc.JWKSURI == "" {
+		return "", errors.New("OpenID configuration has no jwks_uri")
+	}
+	return doc.JWKSURI, nil
+}
+
+// fetchJWKS はJWKSを取得してキャッシュ
+func fetchJWKS(jwksURL string) (*keyfunc.JWKS, error) {
+	// keyfuncのオプション設定
+	options := keyfunc.Options{
+		RefreshInterval:   time.Hour * 12,   // 12時間ごとに定期更新
+		RefreshRateLimit:  time.Minute * 5,  // 再取得は最低5分あける（DoS対策）
+		RefreshTimeout:    time.Second * 10, // 取得時のタイムアウト
+		RefreshUnknownKID: true,             // 未知のKIDが来たら再取得する（重要！）
+	}
+
+	// JWKSの取得とキャッシュ
+	jwks, err := keyfunc.Get(jwksURL, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWKS: %w", err)
+	}
+	return jwks, nil
+}
+
+// loadJWKSFile はファイルからJWKSを読み込む
+func loadJWKSFile(path string) (*keyfunc.JWKS, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	jwks, err := keyfunc.NewJSON(json.RawMessage(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file: %w", err)
+	}
+	return jwks, nil
+}
+
+// Name はIssuerの名前を返す
+func (v *OIDCValidator) Name() string {
+	return v.name
+}
+
+// AccessLevel はIssuerのJWTに許容する公開レベルを返す
+func (v *OIDCValidator) AccessLevel() AccessLevel {
+	return v.accessLevel
+}
+
+// Validate はJWTを検証（署名、アルゴリズム、iss、aud、exp）
+func (v *OIDCValidator) Validate(tokenString string) (*jwt.Token, error) {
+	// JWTの検証
+	token, err := v.parser.Parse(tokenString, v.jwks.Keyfunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate %s JWT: %w", v.name, err)
+	}
+
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid %s JWT", v.name)
+	}
+
+	return token, nil
+}
+
+// Principal は検証済みのJWTから呼び出し元を作成
+// ロールと紐づくdm_usersのIDは設定したクレーム、スコープはpermissionsとscopeクレームから取得する
+func (v *OIDCValidator) Principal(token *jwt.Token) *Principal {
+	claims, _ := token.Claims.(jwt.MapClaims)
+	principal := newUserPrincipal(v.issuer, claims, v.claims)
+	principal.Scopes = mapPermissions(principal.Scopes, v.perms.Scopes)
+	principal.ScopeRestricted = v.perms.Enforce
+	return principal
+}
+
+// mapPermissions はpermissionsをスコープに変換
+// スコープと同名のものはそのまま、mappingにあるものは対応するスコープに変換し、それ以外は除外する
+func mapPermissions(permissions []string, mapping map[string][]string) []string {
+	var scopes []string
+	seen := make(map[string]bool)
+	add := func(scope string) {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	for _, permission := range permissions {
+		if IsPublicAPIKeyScope(permission) {
+			add(permission)
+		}
+		for _, scope := range mapping[permission] {
+			add(scope)
+		}
+	}
+	return scopes
+}
+
+// Close はリソースを解放
+func (v *OIDCValidator) Close() {
+	if v.jwks != nil {
+		v.jwks.EndBackground()
+	}
+}
@@ -0,0 +1,415 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// テスト用のIssuerの設定値
+const (
+	testAuth0IssuerBaseURL = "https://test-tenant.auth0.com"
+	testAuth0Issuer        = "https://test-tenant.auth0.com/"
+	testKeycloakIssuer     = "https://keycloak.example.com/realms/test"
+	testAudience           = "https://api.example.com"
+	testIssuerKeyID        = "test-key"
+)
+
+// testIssuer はテスト用のIssuerの署名鍵とJWKSファイル
+type testIssuer struct {
+	issuer   string
+	key      *rsa.PrivateKey
+	jwks     []byte
+	jwksFile string
+}
+
+// newTestIssuer は署名鍵を生成し、公開鍵のJWKSファイルを作成
+func newTestIssuer(t *testing.T, issuer string) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(&JWKS{Keys: []JWK{NewRSAJWK(testIssuerKeyID, &key.PublicKey)}})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, data, 0o600))
+
+	return &testIssuer{issuer: issuer, key: key, jwks: data, jwksFile: jwksFile}
+}
+
+// config はJWKSファイルを参照するIssuerの設定を作成
+func (ti *testIssuer) config() config.IssuerConfig {
+	return config.IssuerConfig{
+		Name:      "test",
+		Issuer:    ti.issuer,
+		JWKSFile:  ti.jwksFile,
+		Audience:  []string{testAudience},
+		ClockSkew: 30 * time.Second,
+	}
+}
+
+// auth0APIConfig はJWKSファイルを参照するAuth0の設定を作成
+func (ti *testIssuer) auth0APIConfig() *config.APIConfig {
+	cfg := getTestAPIConfig()
+	cfg.Auth0IssuerBaseURL = testAuth0IssuerBaseURL
+	cfg.Auth0 = config.Auth0Config{
+		Audience:  []string{testAudience},
+		Issuer:    ti.issuer,
+		ClockSkew: 30 * time.Second,
+		JWKSFile:  ti.jwksFile,
+	}
+	return cfg
+}
+
+// sign はテスト用の署名鍵でJWTを作成
+func (ti *testIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testIssuerKeyID
+	tokenString, err := token.SignedString(ti.key)
+	require.NoError(t, err)
+	return tokenString
+}
+
+// claims は有効なJWTのクレームを作成（nilを指定したクレームは削除する）
+func (ti *testIssuer) claims(overrides jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss": ti.issuer,
+		"sub": "user|123",
+		"aud": []string{testAudience, ti.issuer + "userinfo"},
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestNewOIDCValidator(t *testing.T) {
+	ti := newTestIssuer(t, testKeycloakIssuer)
+
+	validator, err := NewOIDCValidator(ti.config())
+	require.NoError(t, err)
+	defer validator.Close()
+	assert.Equal(t, "test", validator.Name())
+	assert.Equal(t, AccessLevelPrivate, validator.AccessLevel())
+
+	cfg := ti.config()
+	cfg.Issuer = ""
+	_, err = NewOIDCValidator(cfg)
+	assert.Error(t, err)
+
+	cfg = ti.config()
+	cfg.AccessLevel = "admin"
+	_, err = NewOIDCValidator(cfg)
+	assert.Error(t, err)
+
+	cfg = ti.config()
+	cfg.JWKSFile = filepath.Join(t.TempDir(), "missing.json")
+	_, err = NewOIDCValidator(cfg)
+	assert.Error(t, err)
+}
+
+func TestNewOIDCValidator_Discovery(t *testing.T) {
+	ti := newTestIssuer(t, "")
+	discoveryIssuer := ""
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   discoveryIssuer,
+			"jwks_uri": server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(ti.jwks)
+	})
+	ti.issuer = server.URL
+
+	// discovery_urlを指定しない場合はissuerの/.well-known/openid-configurationを使う
+	cfg := ti.config()
+	cfg.JWKSFile = ""
+	discoveryIssuer = server.URL
+	validator, err := NewOIDCValidator(cfg)
+	require.NoError(t, err)
+	defer validator.Close()
+
+	_, err = validator.Validate(ti.sign(t, ti.claims(nil)))
+	assert.NoError(t, err)
+
+	// Discoveryのissuerが異なる場合はエラー
+	discoveryIssuer = "https://other.example.com"
+	_, err = NewOIDCValidator(cfg)
+	assert.Error(t, err)
+}
+
+func TestOIDCValidator_Validate(t *testing.T) {
+	ti := newTestIssuer(t, testAuth0Issuer)
+	validator, err := NewOIDCValidator(auth0IssuerConfig(ti.auth0APIConfig()))
+	require.NoError(t, err)
+	defer validator.Close()
+
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		wantErr bool
+	}{
+		{"valid", ti.claims(nil), false},
+		{"single audience", ti.claims(jwt.MapClaims{"aud": testAudience}), false},
+		{"other audience", ti.claims(jwt.MapClaims{"aud": "https://other.example.com"}), true},
+		{"missing audience", ti.claims(jwt.MapClaims{"aud": nil}), true},
+		{"other issuer", ti.claims(jwt.MapClaims{"iss": "https://other-tenant.auth0.com/"}), true},
+		{"expired within clock skew", ti.claims(jwt.MapClaims{"exp": time.Now().Add(-10 * time.Second).Unix()}), false},
+		{"expired", ti.claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}), true},
+		{"missing exp", ti.claims(jwt.MapClaims{"exp": nil}), true},
+		{"not yet valid", ti.claims(jwt.MapClaims{"nbf": time.Now().Add(time.Minute).Unix()}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.Validate(ti.sign(t, tt.claims))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := validator.Validate("invalid-token")
+		assert.Error(t, err)
+	})
+
+	t.Run("other signing key", func(t *testing.T) {
+		other := newTestIssuer(t, testAuth0Issuer)
+		_, err := validator.Validate(other.sign(t, ti.claims(nil)))
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, ti.claims(nil))
+		token.Header["kid"] = testIssuerKeyID
+		tokenString, err := token.SignedString([]byte(mwTestSecretKey))
+		require.NoError(t, err)
+		_, err = validator.Validate(tokenString)
+		assert.Error(t, err)
+	})
+}
+
+func TestOIDCValidator_NoAudience(t *testing.T) {
+	// audienceを指定しない場合はaudを検証しない
+	ti := newTestIssuer(t, testKeycloakIssuer)
+	cfg := ti.config()
+	cfg.Audience = nil
+	validator, err := NewOIDCValidator(cfg)
+	require.NoError(t, err)
+	defer validator.Close()
+
+	_, err = validator.Validate(ti.sign(t, ti.claims(jwt.MapClaims{"aud": "https://other.example.com"})))
+	assert.NoError(t, err)
+}
+
+func TestOIDCValidator_Principal_Auth0Permissions(t *testing.T) {
+	ti := newTestIssuer(t, testAuth0Issuer)
+	cfg := ti.auth0APIConfig()
+	cfg.Auth0.Permissions = config.PermissionsConfig{
+		Enforce: true,
+		Scopes: map[string][]string{
+			"read:users":   {ScopeUsersRead},
+			"manage:posts": {ScopePostsRead, ScopePostsWrite},
+		},
+	}
+	validator, err := NewOIDCValidator(auth0IssuerConfig(cfg))
+	require.NoError(t, err)
+	defer validator.Close()
+
+	token, err := validator.Validate(ti.sign(t, ti.claims(jwt.MapClaims{
+		"scope":       "openid profile news:read",
+		"permissions": []string{"read:users", "manage:posts", "posts:read", "unknown"},
+	})))
+	require.NoError(t, err)
+
+	principal := validator.Principal(token)
+	assert.Equal(t, PrincipalTypeUser, principal.Type)
+	assert.Equal(t, testAuth0Issuer, principal.Issuer)
+	assert.Equal(t, "user|123", principal.Subject)
+	assert.Equal(t, []string{ScopeNewsRead, ScopeUsersRead, ScopePostsRead, ScopePostsWrite}, principal.Scopes)
+	assert.True(t, principal.ScopeRestricted)
+}
+
+func TestOIDCValidator_Principal_ClaimMappings(t *testing.T) {
+	// Keycloakのようにロールが入れ子のクレーム、スコープが配列のクレームの場合
+	ti := newTestIssuer(t, testKeycloakIssuer)
+	cfg := ti.config()
+	cfg.Claims = config.IssuerClaimsConfig{
+		Subject: "preferred_username",
+		Scope:   "scp",
+		Roles:   "realm_access.roles",
+		UserID:  "dm_user_id",
+	}
+	validator, err := NewOIDCValidator(cfg)
+	require.NoError(t, err)
+	defer validator.Close()
+
+	token, err := validator.Validate(ti.sign(t, ti.claims(jwt.MapClaims{
+		"preferred_username": "alice",
+		"scp":                []string{ScopeUsersRead, "profile"},
+		"realm_access":       map[string]interface{}{"roles": []string{RoleAdmin}},
+		"dm_user_id":         "0192a0b0c0d0e0f00000000000000001",
+	})))
+	require.NoError(t, err)
+
+	principal := validator.Principal(token)
+	assert.Equal(t, testKeycloakIssuer, principal.Issuer)
+	assert.Equal(t, "alice", principal.Subject)
+	assert.Equal(t, []string{ScopeUsersRead}, principal.Scopes)
+	assert.True(t, principal.IsAdmin())
+	assert.Equal(t, "0192a0b0c0d0e0f00000000000000001", principal.UserID)
+	assert.False(t, principal.ScopeRestricted)
+}
+
+// newTestPrincipalAPI は呼び出し元のsubjectを返すエンドポイントを登録したHuma APIを作成
+// /api/usersはusers:readスコープ、/api/privateはprivateなエンドポイント
+func newTestPrincipalAPI(t *testing.T, cfg *config.APIConfig) humatest.TestAPI {
+	type output struct {
+		Body struct {
+			Subject string `json:"subject"`
+		}
+	}
+	_, api := humatest.New(t)
+	api.UseMiddleware(NewHumaAuthMiddleware(cfg, mwTestEnv, nil, nil))
+	handler := func(ctx context.Context, input *struct{}) (*output, error) {
+		resp := &output{}
+		if principal, ok := GetPrincipal(ctx); ok {
+			resp.Body.Subject = principal.Subject
+		}
+		return resp, nil
+	}
+	huma.Register(api, huma.Operation{
+		OperationID: "get-users",
+		Method:      http.MethodGet,
+		Path:        "/api/users",
+		Security:    BearerSecurity(ScopeUsersRead),
+	}, handler)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-private",
+		Method:      http.MethodGet,
+		Path:        "/api/private",
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+	}, func(ctx context.Context, input *struct{}) (*output, error) {
+		if err := CheckAccessLevel(ctx, AccessLevelPrivate); err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+		return handler(ctx, input)
+	})
+	return api
+}
+
+func TestHumaAuthMiddleware_Auth0(t *testing.T) {
+	ti := newTestIssuer(t, testAuth0Issuer)
+	withPermissions := "Authorization: Bearer " + ti.sign(t, ti.claims(jwt.MapClaims{"permissions": []string{ScopeUsersRead}}))
+	withoutPermissions := "Authorization: Bearer " + ti.sign(t, ti.claims(nil))
+	otherAudience := "Authorization: Bearer " + ti.sign(t, ti.claims(jwt.MapClaims{"aud": "https://other.example.com"}))
+
+	t.Run("permissions not enforced", func(t *testing.T) {
+		api := newTestPrincipalAPI(t, ti.auth0APIConfig())
+		resp := api.Get("/api/users", withoutPermissions)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), "user|123")
+
+		resp = api.Get("/api/users", otherAudience)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("permissions enforced", func(t *testing.T) {
+		cfg := ti.auth0APIConfig()
+		cfg.Auth0.Permissions.Enforce = true
+		api := newTestPrincipalAPI(t, cfg)
+
+		resp := api.Get("/api/users", withPermissions)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		resp = api.Get("/api/users", withoutPermissions)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Contains(t, resp.Body.String(), "users:read scope required")
+
+		// スコープを宣言していないprivateなエンドポイントはpermissionsなしで利用できる
+		resp = api.Get("/api/private", withoutPermissions)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+}
+
+func TestHumaAuthMiddleware_Issuers(t *testing.T) {
+	keycloak := newTestIssuer(t, testKeycloakIssuer)
+	partner := newTestIssuer(t, "https://partner.example.com")
+	unknown := newTestIssuer(t, "https://unknown.example.com")
+
+	// Auth0なしで、privateのIssuerとpublicのIssuerを受け入れる
+	cfg := getTestAPIConfig()
+	cfg.Auth0IssuerBaseURL = ""
+	partnerCfg := partner.config()
+	partnerCfg.AccessLevel = string(AccessLevelPublic)
+	cfg.Issuers = []config.IssuerConfig{keycloak.config(), partnerCfg}
+	api := newTestPrincipalAPI(t, cfg)
+
+	keycloakToken := "Authorization: Bearer " + keycloak.sign(t, keycloak.claims(nil))
+	partnerToken := "Authorization: Bearer " + partner.sign(t, partner.claims(jwt.MapClaims{"sub": "partner|1"}))
+
+	resp := api.Get("/api/private", keycloakToken)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "user|123")
+
+	resp = api.Get("/api/users", partnerToken)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "partner|1")
+
+	// publicのIssuerはprivateなエンドポイントを利用できない
+	resp = api.Get("/api/private", partnerToken)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	// 登録されていないIssuer
+	resp = api.Get("/api/users", "Authorization: Bearer "+unknown.sign(t, unknown.claims(nil)))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Unknown token issuer")
+
+	// 別のIssuerの鍵で署名したJWT
+	resp = api.Get("/api/users", "Authorization: Bearer "+partner.sign(t, keycloak.claims(nil)))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestHumaAuthMiddleware_NoIssuers(t *testing.T) {
+	// IdPを設定しない場合もPublic APIキーで利用できる
+	cfg := getTestAPIConfig()
+	cfg.Auth0IssuerBaseURL = ""
+	api := newTestPrincipalAPI(t, cfg)
+
+	token, err := getTestAPIToken()
+	require.NoError(t, err)
+	resp := api.Get("/api/users", "Authorization: Bearer "+token)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	ti := newTestIssuer(t, testAuth0Issuer)
+	resp = api.Get("/api/users", "Authorization: Bearer "+ti.sign(t, ti.claims(nil)))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
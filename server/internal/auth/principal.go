@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// PrincipalType は呼び出し元の種類
+type PrincipalType string
+
+const (
+	PrincipalTypeUser   PrincipalType = "user"    // OIDCのIssuer（Auth0など）で認証したユーザー
+	PrincipalTypeAPIKey PrincipalType = "api_key" // Public APIキーを使うクライアント
+)
+
+// RoleAdmin は他のユーザーのデータも更新・削除できるロール
+const RoleAdmin = "admin"
+
+// PrincipalKey は認証済みの呼び出し元を格納するコンテキストキー
+const PrincipalKey contextKey = "principal"
+
+// Principal は認証済みの呼び出し元
+type Principal struct {
+	Issuer          string        // JWTのiss
+	Subject         string        // JWTのsub
+	Type            PrincipalType // 呼び出し元の種類
+	Scopes          []string      // 付与されたスコープ
+	ScopeRestricted bool          // エンドポイントごとにスコープを確認するかどうか
+	KeyID           string        // Public APIキーのjti（ユーザーの場合は空）
+	Roles           []string      // ロール（Public APIキーの場合は空）
+	UserID          string        // 紐づくdm_usersのID（紐づいていない場合は空）
+	Name            string        // ユーザーの名前（Public APIキーの場合は空）
+	Email           string        // ユーザーのメールアドレス（Public APIキーの場合は空）
+}
+
+// HasRole はロールを持つかどうかを判定
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin は管理者ロールを持つかどうかを判定
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+// WithPrincipal はコンテキストに呼び出し元を設定
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, PrincipalKey, principal)
+}
+
+// GetPrincipal はコンテキストから呼び出し元を取得
+func GetPrincipal(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(PrincipalKey).(*Principal)
+	return principal, ok
+}
+
+// newAPIKeyPrincipal はPublic API Key JWTのクレームから呼び出し元を作成
+func newAPIKeyPrincipal(claims *JWTClaims) *Principal {
+	return &Principal{
+		Issuer:          claims.Issuer,
+		Subject:         claims.Subject,
+		Type:            PrincipalTypeAPIKey,
+		Scopes:          claims.Scope,
+		ScopeRestricted: true,
+		KeyID:           claims.JTI,
+	}
+}
+
+// newUserPrincipal はOIDCのJWTのクレームから呼び出し元を作成
+// スコープはscopeクレームとpermissionsクレーム（Auth0 RBACなど）、ロール・紐づくdm_usersのID・名前・メールアドレスはclaimsCfgのクレームから取得する
+func newUserPrincipal(issuer string, claims jwt.MapClaims, claimsCfg config.IssuerClaimsConfig) *Principal {
+	principal := &Principal{Issuer: issuer, Type: PrincipalTypeUser}
+	if claims == nil {
+		return principal
+	}
+
+	principal.Subject, _ = lookupClaim(claims, claimName(claimsCfg.Subject, "sub")).(string)
+	principal.Scopes = append(stringsClaim(claims, claimName(claimsCfg.Scope, "scope")), stringsClaim(claims, claimName(claimsCfg.Permissions, "permissions"))...)
+	if claimsCfg.Roles != "" {
+		principal.Roles = stringsClaim(claims, claimsCfg.Roles)
+	}
+	if claimsCfg.UserID != "" {
+		principal.UserID, _ = lookupClaim(claims, claimsCfg.UserID).(string)
+	}
+	principal.Name, _ = lookupClaim(claims, claimName(claimsCfg.Name, "name")).(string)
+	principal.Email, _ = lookupClaim(claims, claimName(claimsCfg.Email, "email")).(string)
+	return principal
+}
+
+// claimName はクレーム名を返す（設定がない場合はデフォルト）
+func claimName(name string, defaultName string) string {
+	if name == "" {
+		return defaultName
+	}
+	return name
+}
+
+// lookupClaim はクレームを取得（名前と一致するクレームがない場合は.区切りのパスとして入れ子のクレームを探す）
+func lookupClaim(claims jwt.MapClaims, name string) interface{} {
+	if value, ok := claims[name]; ok {
+		return value
+	}
+	var current interface{} = map[string]interface{}(claims)
+	for _, key := range strings.Split(name, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+// stringsClaim は文字列の配列（または空白区切りの文字列）のクレームを取得
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch values := lookupClaim(claims, name).(type) {
+	case string:
+		return strings.Fields(values)
+	case []interface{}:
+		var result []string
+		for _, value := range values {
+			if s, ok := value.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// AuthorizeOwner は呼び出し元がownerUserIDのユーザーのデータを更新・削除できるかを検証
+// Public APIキーはスコープで認可済みのため対象外。ユーザーは自分のデータのみ操作できる（管理者ロールはすべて操作できる）
+// 認証を経由しない呼び出し（コンテキストに呼び出し元がない場合）は対象外
+func AuthorizeOwner(ctx context.Context, ownerUserID string) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.Type == PrincipalTypeAPIKey || principal.IsAdmin() {
+		return nil
+	}
+	if principal.UserID == "" {
+		return apperror.Forbidden("caller is not linked to a user")
+	}
+	if ownerUserID == "" || principal.UserID != ownerUserID {
+		return apperror.Forbidden("caller can only modify their own data")
+	}
+	return nil
+}
+
+// AuthorizeAdmin は呼び出し元が管理者の操作を行えるかを検証
+// Public APIキーはスコープで認可済みのため対象外。ユーザーは管理者ロールが必要
+// 認証を経由しない呼び出し（コンテキストに呼び出し元がない場合）は対象外
+func AuthorizeAdmin(ctx context.Context) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.Type == PrincipalTypeAPIKey || principal.IsAdmin() {
+		return nil
+	}
+	return apperror.Forbidden("admin role required")
+}
+
+// JobOwner はジョブの所有者として記録する呼び出し元の識別子を返す
+// Public APIキーはキーID（jti）、ユーザーはissとsubで識別する。認証を経由しない呼び出しは空
+func JobOwner(ctx context.Context) string {
+	principal, ok := GetPrincipal(ctx)
+	if !ok {
+		return ""
+	}
+	if principal.Type == PrincipalTypeAPIKey && principal.KeyID != "" {
+		return string(principal.Type) + ":" + principal.KeyID
+	}
+	return string(principal.Type) + ":" + principal.Issuer + "|" + principal.Subject
+}
+
+// AuthorizeJobOwner は呼び出し元がジョブを登録した呼び出し元と同じかを検証
+// 管理者ロールはすべてのジョブを参照できる。Public APIキーも同じキーで登録したジョブのみ参照できる
+// 認証を経由しない呼び出し（コンテキストに呼び出し元がない場合）は対象外
+func AuthorizeJobOwner(ctx context.Context, owner string) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+	if owner == "" || JobOwner(ctx) != owner {
+		return apperror.Forbidden("caller can only view their own jobs")
+	}
+	return nil
+}
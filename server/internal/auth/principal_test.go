@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func TestNewUserPrincipal(t *testing.T) {
+	claimsCfg := config.IssuerClaimsConfig{
+		Roles:  "https://example.com/roles",
+		UserID: "https://example.com/dm_user_id",
+	}
+	claims := jwt.MapClaims{
+		"sub":                            "auth0|123",
+		"scope":                          "openid profile",
+		"permissions":                    []interface{}{"users:read"},
+		"https://example.com/roles":      []interface{}{"admin", "editor"},
+		"https://example.com/dm_user_id": "0192a0b0c0d0e0f00000000000000001",
+		"name":                           "Alice",
+		"email":                          "alice@example.com",
+	}
+
+	principal := newUserPrincipal("https://example.auth0.com/", claims, claimsCfg)
+	assert.Equal(t, PrincipalTypeUser, principal.Type)
+	assert.Equal(t, "https://example.auth0.com/", principal.Issuer)
+	assert.Equal(t, "auth0|123", principal.Subject)
+	assert.Equal(t, []string{"openid", "profile", "users:read"}, principal.Scopes)
+	assert.Equal(t, []string{"admin", "editor"}, principal.Roles)
+	assert.Equal(t, "0192a0b0c0d0e0f00000000000000001", principal.UserID)
+	assert.Equal(t, "Alice", principal.Name)
+	assert.Equal(t, "alice@example.com", principal.Email)
+	assert.True(t, principal.IsAdmin())
+
+	// カスタムクレームがない場合はロールなし・未連携
+	principal = newUserPrincipal("https://example.auth0.com/", jwt.MapClaims{"sub": "auth0|456"}, claimsCfg)
+	assert.Empty(t, principal.Roles)
+	assert.Empty(t, principal.UserID)
+	assert.False(t, principal.IsAdmin())
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		owner     string
+		wantErr   bool
+	}{
+		{"owner", &Principal{Type: PrincipalTypeUser, UserID: "user-1"}, "user-1", false},
+		{"other user", &Principal{Type: PrincipalTypeUser, UserID: "user-1"}, "user-2", true},
+		{"unlinked user", &Principal{Type: PrincipalTypeUser}, "user-1", true},
+		{"empty owner", &Principal{Type: PrincipalTypeUser, UserID: "user-1"}, "", true},
+		{"admin", &Principal{Type: PrincipalTypeUser, Roles: []string{RoleAdmin}}, "user-2", false},
+		{"api key", &Principal{Type: PrincipalTypeAPIKey}, "user-2", false},
+		{"no principal", nil, "user-2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = WithPrincipal(ctx, tt.principal)
+			}
+			err := AuthorizeOwner(ctx, tt.owner)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, apperror.ErrForbidden)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeAdmin(t *testing.T) {
+	user := WithPrincipal(context.Background(), &Principal{Type: PrincipalTypeUser, UserID: "user-1"})
+	assert.ErrorIs(t, AuthorizeAdmin(user), apperror.ErrForbidden)
+
+	admin := WithPrincipal(context.Background(), &Principal{Type: PrincipalTypeUser, Roles: []string{RoleAdmin}})
+	assert.NoError(t, AuthorizeAdmin(admin))
+
+	apiKey := WithPrincipal(context.Background(), &Principal{Type: PrincipalTypeAPIKey})
+	assert.NoError(t, AuthorizeAdmin(apiKey))
+}
+
+func TestHumaAuthMiddleware_Principal(t *testing.T) {
+	_, api := humatest.New(t)
+	api.UseMiddleware(NewHumaAuthMiddleware(getTestAPIConfig(), mwTestEnv, nil, nil))
+
+	var got *Principal
+	huma.Register(api, huma.Operation{
+		OperationID: "get-principal",
+		Method:      http.MethodGet,
+		Path:        "/api/principal",
+		Security:    BearerSecurity(ScopeUsersRead),
+	}, func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		got, _ = GetPrincipal(ctx)
+		return nil, nil
+	})
+
+	claims := newPublicAPIKeyClaims("v2", mwTestEnv, time.Now().Unix(), "key-1", []string{ScopeUsersRead})
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mwTestSecretKey))
+	require.NoError(t, err)
+
+	resp := api.Get("/api/principal", "Authorization: Bearer "+token)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, PrincipalTypeAPIKey, got.Type)
+	assert.Equal(t, "public_client", got.Subject)
+	assert.Equal(t, "key-1", got.KeyID)
+	assert.Equal(t, []string{ScopeUsersRead}, got.Scopes)
+}
+
+func TestJobOwner(t *testing.T) {
+	assert.Empty(t, JobOwner(context.Background()))
+
+	apiKey := WithPrincipal(context.Background(), &Principal{Type: PrincipalTypeAPIKey, Subject: "client", KeyID: "key-1"})
+	assert.Equal(t, "api_key:key-1", JobOwner(apiKey))
+
+	user := WithPrincipal(context.Background(), &Principal{Type: PrincipalTypeUser, Issuer: "https://issuer/", Subject: "auth0|1"})
+	assert.Equal(t, "user:https://issuer/|auth0|1", JobOwner(user))
+}
+
+func TestAuthorizeJobOwner(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		owner     string
+		wantErr   bool
+	}{
+		{"same api key", &Principal{Type: PrincipalTypeAPIKey, KeyID: "key-1"}, "api_key:key-1", false},
+		{"other api key", &Principal{Type: PrincipalTypeAPIKey, KeyID: "key-2"}, "api_key:key-1", true},
+		{"same user", &Principal{Type: PrincipalTypeUser, Issuer: "iss", Subject: "sub-1"}, "user:iss|sub-1", false},
+		{"other user", &Principal{Type: PrincipalTypeUser, Issuer: "iss", Subject: "sub-2"}, "user:iss|sub-1", true},
+		{"empty owner", &Principal{Type: PrincipalTypeAPIKey, KeyID: "key-1"}, "", true},
+		{"admin", &Principal{Type: PrincipalTypeUser, Subject: "sub-2", Roles: []string{RoleAdmin}}, "api_key:key-1", false},
+		{"no principal", nil, "api_key:key-1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = WithPrincipal(ctx, tt.principal)
+			}
+			err := AuthorizeJobOwner(ctx, tt.owner)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, apperror.ErrForbidden)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// リソーススコープ（Public APIキーに付与し、エンドポイントごとに要求する）
+const (
+	ScopeUsersRead    = "users:read"
+	ScopeUsersWrite   = "users:write"
+	ScopePostsRead    = "posts:read"
+	ScopePostsWrite   = "posts:write"
+	ScopeNewsRead     = "news:read"
+	ScopeEmailSend    = "email:send"
+	ScopeJobsRead     = "jobs:read"
+	ScopeJobsWrite    = "jobs:write"
+	ScopeExportsRead  = "exports:read"
+	ScopeExportsWrite = "exports:write"
+	ScopeUploadsWrite = "uploads:write"
+)
+
+// 旧形式のスコープ（リソーススコープ導入前に発行したキー）
+// readは参照系（:read）のすべてのスコープ、writeはそれ以外のすべてのスコープとして扱う
+const (
+	LegacyScopeRead  = "read"
+	LegacyScopeWrite = "write"
+)
+
+// securitySchemeName はHumaのOperationでスコープを宣言するセキュリティスキーム名
+const securitySchemeName = "bearerAuth"
+
+// PublicAPIKeyScopes はPublic APIキーに付与できるスコープ
+var PublicAPIKeyScopes = []string{
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopePostsRead,
+	ScopePostsWrite,
+	ScopeNewsRead,
+	ScopeEmailSend,
+	ScopeJobsRead,
+	ScopeJobsWrite,
+	ScopeExportsRead,
+	ScopeExportsWrite,
+	ScopeUploadsWrite,
+}
+
+// IsPublicAPIKeyScope はPublic APIキーに付与できるスコープかどうかを判定
+func IsPublicAPIKeyScope(scope string) bool {
+	for _, s := range PublicAPIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// BearerSecurity はHumaのOperationのSecurityを作成（要求するスコープを宣言する）
+func BearerSecurity(scopes ...string) []map[string][]string {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return []map[string][]string{
+		{securitySchemeName: scopes},
+	}
+}
+
+// RequiredScopes はHumaのOperationのSecurityで宣言されたスコープを返す
+func RequiredScopes(op *huma.Operation) []string {
+	if op == nil {
+		return nil
+	}
+	for _, requirement := range op.Security {
+		if scopes, ok := requirement[securitySchemeName]; ok {
+			return scopes
+		}
+	}
+	return nil
+}
+
+// CheckScopes は認証済みのコンテキストが要求されたスコープをすべて持つかを検証
+// スコープはPublic API Key JWTと、permissionsを強制する設定のIssuer（Auth0など）のJWTに適用する
+// スコープを宣言していないエンドポイントはPublic APIキーで利用できない
+func CheckScopes(ctx context.Context, required []string) error {
+	if _, ok := GetAllowedAccessLevel(ctx); !ok {
+		return errors.New("access level not found in context")
+	}
+
+	principal, ok := GetPrincipal(ctx)
+	if !ok || !principal.ScopeRestricted {
+		// スコープの対象外
+		return nil
+	}
+	if len(required) == 0 {
+		if principal.Type == PrincipalTypeAPIKey {
+			return errors.New("endpoint does not accept api keys")
+		}
+		return nil
+	}
+	for _, scope := range required {
+		if !hasScope(principal.Scopes, scope) {
+			return fmt.Errorf("%s scope required", scope)
+		}
+	}
+	return nil
+}
+
+// hasScope は付与されたスコープに要求されたスコープが含まれるかを判定（旧形式のスコープを含む）
+func hasScope(granted []string, required string) bool {
+	isRead := strings.HasSuffix(required, ":read")
+	for _, scope := range granted {
+		if scope == required {
+			return true
+		}
+		if scope == LegacyScopeRead && isRead {
+			return true
+		}
+		if scope == LegacyScopeWrite && !isRead {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,12 @@
 package auth
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 )
 
@@ -19,3 +23,31 @@ func GenerateSecretKey() (string, error) {
 
 	return encoded, nil
 }
+
+// GenerateSigningPrivateKeyPEM は署名アルゴリズム（RS256 / EdDSA）の秘密鍵を生成してPKCS#8のPEM形式で返す
+func GenerateSigningPrivateKeyPEM(algorithm string) ([]byte, error) {
+	var privateKey interface{}
+	switch algorithm {
+	case SigningAlgorithmRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		privateKey = rsaKey
+	case SigningAlgorithmEdDSA:
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+		}
+		privateKey = edKey
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", algorithm)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
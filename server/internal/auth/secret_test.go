@@ -2,9 +2,13 @@ package auth
 
 import (
 	"encoding/base64"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
 )
 
 func TestGenerateSecretKey(t *testing.T) {
@@ -48,3 +52,21 @@ func TestGenerateSecretKey_Uniqueness(t *testing.T) {
 
 	assert.NotEqual(t, secret1, secret2, "generated secrets should be unique")
 }
+
+func TestGenerateSigningPrivateKeyPEM(t *testing.T) {
+	for _, algorithm := range []string{SigningAlgorithmRS256, SigningAlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			got, err := GenerateSigningPrivateKeyPEM(algorithm)
+			assert.NoError(t, err)
+
+			// 生成した鍵で署名鍵を作成できる
+			path := filepath.Join(t.TempDir(), "key.pem")
+			assert.NoError(t, os.WriteFile(path, got, 0600))
+			_, err = newSigningKey(config.APISigningKeyConfig{ID: "k1", Algorithm: algorithm, PrivateKeyFile: path})
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := GenerateSigningPrivateKeyPEM(SigningAlgorithmHS256)
+	assert.Error(t, err)
+}
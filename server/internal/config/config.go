@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -22,6 +23,13 @@ type Config struct {
 	CacheServer CacheServerConfig `mapstructure:"cache_server"` // キャッシュサーバー設定
 	Upload      UploadConfig      `mapstructure:"upload"`       // アップロード設定
 	Email       EmailConfig       `mapstructure:"email"`        // メール送信設定
+	Export      ExportConfig      `mapstructure:"export"`       // エクスポート設定
+	Feed        FeedConfig        `mapstructure:"feed"`         // ニュースフィード設定
+	Webhook     WebhookConfig     `mapstructure:"webhook"`      // Webhook配信設定
+	Stream      StreamConfig      `mapstructure:"stream"`       // Server-Sent Events設定
+	GRPC        GRPCConfig        `mapstructure:"grpc"`         // gRPCサーバー設定
+	GraphQL     GraphQLConfig     `mapstructure:"graphql"`      // GraphQL設定
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`  // Idempotency-Key設定
 }
 
 // CacheServerConfig はキャッシュサーバー設定
@@ -145,20 +153,138 @@ type CORSConfig struct {
 
 // APIConfig はAPIキー設定
 type APIConfig struct {
-	CurrentVersion     string          `mapstructure:"current_version"`
-	PublicKey          string          `mapstructure:"public_key"`
-	SecretKey          string          `mapstructure:"secret_key"`
-	InvalidVersions    []string        `mapstructure:"invalid_versions"`
-	Auth0IssuerBaseURL string          `mapstructure:"auth0_issuer_base_url"` // Auth0のIssuer Base URL
-	RateLimit          RateLimitConfig `mapstructure:"rate_limit"`            // レートリミット設定
+	CurrentVersion     string               `mapstructure:"current_version"`
+	PublicKey          string               `mapstructure:"public_key"`
+	SecretKey          string               `mapstructure:"secret_key"`
+	InvalidVersions    []string             `mapstructure:"invalid_versions"`
+	Auth0IssuerBaseURL string               `mapstructure:"auth0_issuer_base_url"` // Auth0のIssuer Base URL（空の場合はAuth0 JWTを受け入れない）
+	Versions           []APIVersionConfig   `mapstructure:"versions"`              // URLで指定するAPIバージョン（最後が最新）
+	RateLimit          RateLimitConfig      `mapstructure:"rate_limit"`            // レートリミット設定
+	KeyRegistry        APIKeyRegistryConfig `mapstructure:"key_registry"`          // 発行済みAPIキーの台帳（失効確認・利用記録）の設定
+	Signing            APISigningConfig     `mapstructure:"signing"`               // Public APIキーの署名鍵（kidによるローテーション）の設定
+	Auth0Claims        Auth0ClaimsConfig    `mapstructure:"auth0_claims"`          // Auth0 JWTのカスタムクレーム名
+	Auth0              Auth0Config          `mapstructure:"auth0"`                 // Auth0 JWTの検証設定
+	Issuers            []IssuerConfig       `mapstructure:"issuers"`               // Auth0以外に受け入れるOIDCのIssuer（Keycloak、Googleなど）
+	IdentityCacheTTL   time.Duration        `mapstructure:"identity_cache_ttl"`    // OIDCのユーザーと紐づくdm_usersのIDの解決結果をキャッシュする期間（デフォルト: 1m）
+}
+
+// IssuerConfig はJWTを受け入れるOIDCのIssuerの設定
+// JWKSはjwks_file、jwks_url、discovery_url（デフォルト: issuer + /.well-known/openid-configuration）の順に使う
+type IssuerConfig struct {
+	Name         string             `mapstructure:"name"`          // Issuerの名前（ログ用）
+	Issuer       string             `mapstructure:"issuer"`        // JWTのiss
+	DiscoveryURL string             `mapstructure:"discovery_url"` // OpenID Connect DiscoveryのURL（jwks_uriを取得する）
+	JWKSURL      string             `mapstructure:"jwks_url"`      // JWKSのURL
+	JWKSFile     string             `mapstructure:"jwks_file"`     // 検証に使うJWKSファイル（オフラインのテスト用）
+	Audience     []string           `mapstructure:"audience"`      // 受け入れるaud（いずれかを含むこと。空の場合は検証しない）
+	ClockSkew    time.Duration      `mapstructure:"clock_skew"`    // exp・nbf・iatの時刻のずれの許容範囲（デフォルト: 30s）
+	AccessLevel  string             `mapstructure:"access_level"`  // 許容する公開レベル（private / public、デフォルト: private）
+	Claims       IssuerClaimsConfig `mapstructure:"claims"`        // 呼び出し元の情報を取得するクレーム名
+	Permissions  PermissionsConfig  `mapstructure:"permissions"`   // permissionsとスコープの対応
+}
+
+// IssuerClaimsConfig はJWTから呼び出し元の情報を取得するクレーム名の設定
+// 名前と一致するクレームがない場合は.区切りのパスとして扱う（例: realm_access.roles）
+type IssuerClaimsConfig struct {
+	Subject     string `mapstructure:"subject"`     // 呼び出し元のIDのクレーム名（デフォルト: sub）
+	Scope       string `mapstructure:"scope"`       // スコープ（空白区切りの文字列または配列）のクレーム名（デフォルト: scope）
+	Permissions string `mapstructure:"permissions"` // permissions（文字列の配列）のクレーム名（デフォルト: permissions）
+	Roles       string `mapstructure:"roles"`       // ロール（文字列の配列）のクレーム名（空の場合はロールなし）
+	UserID      string `mapstructure:"user_id"`     // 紐づくdm_usersのIDのクレーム名（空の場合はdm_user_identitiesで紐づける）
+	Name        string `mapstructure:"name"`        // 名前のクレーム名（デフォルト: name）
+	Email       string `mapstructure:"email"`       // メールアドレスのクレーム名（デフォルト: email）
+}
+
+// Auth0Config はAuth0 JWTの検証設定
+type Auth0Config struct {
+	Audience    []string          `mapstructure:"audience"`    // 受け入れるaud（いずれかを含むこと。空の場合は検証しない）
+	Issuer      string            `mapstructure:"issuer"`      // 期待するiss（デフォルト: auth0_issuer_base_url + "/"）
+	ClockSkew   time.Duration     `mapstructure:"clock_skew"`  // exp・nbf・iatの時刻のずれの許容範囲（デフォルト: 30s）
+	JWKSFile    string            `mapstructure:"jwks_file"`   // 検証に使うJWKSファイル（指定した場合はJWKSを取得しない。オフラインのテスト用）
+	Permissions PermissionsConfig `mapstructure:"permissions"` // Auth0 RBACのpermissionsとスコープの対応
+}
+
+// PermissionsConfig はJWTのpermissions（Auth0 RBACなど）とscopeクレームをスコープとして扱う設定
+// スコープと同名のもの（users:readなど）はそのまま、それ以外はscopesの対応でスコープに変換する
+type PermissionsConfig struct {
+	Enforce bool                `mapstructure:"enforce"` // エンドポイントのスコープを確認する（falseの場合はスコープを確認しない）
+	Scopes  map[string][]string `mapstructure:"scopes"`  // permissionと対応するスコープ（例: read:users: ["users:read"]）
+}
+
+// Auth0ClaimsConfig はAuth0 JWTから呼び出し元の情報を取得するカスタムクレーム名の設定
+// Auth0のActionsでアクセストークンに追加する（Auth0の仕様により名前空間付きのURL形式の名前とする）
+type Auth0ClaimsConfig struct {
+	Roles  string `mapstructure:"roles"`   // ロール（文字列の配列）のクレーム名（デフォルト: https://go-webdb-template/roles）
+	UserID string `mapstructure:"user_id"` // 紐づくdm_usersのIDのクレーム名（デフォルト: https://go-webdb-template/dm_user_id）
+	Name   string `mapstructure:"name"`    // 名前のクレーム名（デフォルト: https://go-webdb-template/name）
+	Email  string `mapstructure:"email"`   // メールアドレスのクレーム名（デフォルト: https://go-webdb-template/email）
+}
+
+// APIVersionConfig はURLで指定するAPIバージョンの設定
+// APIキーのバージョン（CurrentVersion / InvalidVersions）とは別のもの
+type APIVersionConfig struct {
+	Name         string `mapstructure:"name"`          // バージョン名（パスの/api/{name}/に使用）
+	DeprecatedAt string `mapstructure:"deprecated_at"` // 非推奨になった日時（RFC 3339、空の場合は非推奨ではない）
+	Sunset       string `mapstructure:"sunset"`        // 提供終了予定日時（RFC 3339、オプション）
 }
 
 // RateLimitConfig はレートリミット設定
 type RateLimitConfig struct {
 	Enabled           bool   `mapstructure:"enabled"`
 	RequestsPerMinute int    `mapstructure:"requests_per_minute"`
-	RequestsPerHour   int    `mapstructure:"requests_per_hour"`  // オプション
-	StorageType       string `mapstructure:"storage_type"`       // "auto"（自動判定）、"redis"（強制Redis）、"memory"（強制InMemory）
+	RequestsPerHour   int    `mapstructure:"requests_per_hour"` // オプション
+	StorageType       string `mapstructure:"storage_type"`      // "auto"（自動判定）、"redis"（強制Redis）、"memory"（強制InMemory）
+
+	Principal    PrincipalRateLimitConfig `mapstructure:"principal"`     // 呼び出し元（APIキー・ユーザー）ごとのレートリミット・クォータ
+	Policies     []RateLimitPolicyConfig  `mapstructure:"policies"`      // ルートごとのポリシー（一致したルートはrequests_per_minute/hourの代わりに適用）
+	ExemptRoutes []string                 `mapstructure:"exempt_routes"` // レートリミットを適用しないルート
+}
+
+// RateLimitPolicyConfig はルートごとのレートリミットのポリシーの設定（IPアドレス単位で数える）
+// ルートは"METHOD /path"または"/path"で指定し、パスの*は1セグメントに一致する。バージョンごとのパスはバージョンなしのパスとして判定する
+type RateLimitPolicyConfig struct {
+	Name      string        `mapstructure:"name"`      // ポリシー名（RateLimit-Policyヘッダーに使用）
+	Routes    []string      `mapstructure:"routes"`    // 適用するルート（複数のポリシーに一致する場合は最初のポリシー）
+	Algorithm string        `mapstructure:"algorithm"` // "fixed_window"（デフォルト）、"sliding_window"、"token_bucket"
+	Limit     int           `mapstructure:"limit"`     // 期間あたりのリクエスト数
+	Period    time.Duration `mapstructure:"period"`    // 期間（デフォルト: 1m）
+	Burst     int           `mapstructure:"burst"`     // token_bucketのバケットの容量（デフォルト: limit）
+}
+
+// PrincipalRateLimitConfig は呼び出し元ごとのレートリミット・クォータの設定
+// 上限はmasterグループのrate_limit_plansのプランで決まり、ストレージはStorageTypeに従う
+type PrincipalRateLimitConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	DefaultPlan string        `mapstructure:"default_plan"` // プランが割り当てられていない呼び出し元のプラン（空の場合は無制限）
+	CacheTTL    time.Duration `mapstructure:"cache_ttl"`    // プランをキャッシュする期間（デフォルト: 1m）
+	KeyPrefix   string        `mapstructure:"key_prefix"`   // Redisのキーのプレフィックス（デフォルト: "ratelimit_principal:"）
+}
+
+// APIKeyRegistryConfig は発行済みPublic APIキーの台帳の設定
+// 失効確認の結果はRedis（cache_server.redis.default）が設定されている場合はRedis、なければメモリにキャッシュする
+type APIKeyRegistryConfig struct {
+	CacheTTL      time.Duration `mapstructure:"cache_ttl"`      // 失効確認の結果をキャッシュする期間（デフォルト: 1m）
+	UsageInterval time.Duration `mapstructure:"usage_interval"` // 最終利用日時を記録する間隔（デフォルト: 5m）
+	KeyPrefix     string        `mapstructure:"key_prefix"`     // Redisのキーのプレフィックス（デフォルト: "api_key:"）
+}
+
+// APISigningConfig はPublic APIキーのJWTの署名鍵の設定
+// SecretKeyはLegacyKeyIDの鍵（HS256）として鍵の一覧に加わる。KeyIDが空の場合はこの鍵でkidなしの署名を行い、kidなしのJWTはこの鍵で検証する
+type APISigningConfig struct {
+	KeyID           string                `mapstructure:"key_id"`            // 署名に使う鍵のID（JWTのkidヘッダー）
+	GracePeriod     time.Duration         `mapstructure:"grace_period"`      // 退役した鍵で検証を続ける期間（デフォルト: 720h）
+	Keys            []APISigningKeyConfig `mapstructure:"keys"`              // 検証に使う鍵の一覧（署名に使う鍵を含む）
+	LegacyKeyID     string                `mapstructure:"legacy_key_id"`     // SecretKeyの鍵のID（デフォルト: "legacy"）
+	LegacyRetiredAt string                `mapstructure:"legacy_retired_at"` // SecretKeyの鍵を退役した日時（RFC 3339、空の場合は現役）
+}
+
+// APISigningKeyConfig は署名鍵の設定
+type APISigningKeyConfig struct {
+	ID             string `mapstructure:"id"`               // 鍵のID（JWTのkidヘッダー）
+	Algorithm      string `mapstructure:"algorithm"`        // "HS256"、"RS256"、"EdDSA"（デフォルト: "HS256"）
+	Secret         string `mapstructure:"secret"`           // HS256の共有鍵
+	PrivateKeyFile string `mapstructure:"private_key_file"` // RS256 / EdDSAの秘密鍵（PKCS#8のPEMファイル）
+	RetiredAt      string `mapstructure:"retired_at"`       // 退役した日時（RFC 3339、空の場合は現役）
 }
 
 // AdminConfig は管理画面設定
@@ -194,6 +320,10 @@ type UploadConfig struct {
 	MaxFileSize       int64         `mapstructure:"max_file_size"`      // 最大ファイルサイズ
 	AllowedExtensions []string      `mapstructure:"allowed_extensions"` // 許可された拡張子リスト
 	Storage           StorageConfig `mapstructure:"storage"`            // ストレージ設定
+
+	// ExportSigningSecret はエクスポートファイルのダウンロードURLの署名鍵（Storage.Typeがlocalの場合は必須）
+	// APIキーの署名鍵（api.secret_key）とは用途が異なるため、別の値を設定する
+	ExportSigningSecret string `mapstructure:"export_signing_secret"`
 }
 
 // StorageConfig はストレージ設定
@@ -214,6 +344,59 @@ type S3StorageConfig struct {
 	Region string `mapstructure:"region"` // AWSリージョン
 }
 
+// ExportConfig はエクスポート機能の設定
+// 出力ファイルはUpload.Storageで設定されたストレージ（local / s3）に保存する
+type ExportConfig struct {
+	KeyPrefix      string        `mapstructure:"key_prefix"`       // ストレージ上のキー接頭辞（デフォルト: "exports"）
+	DownloadURLTTL time.Duration `mapstructure:"download_url_ttl"` // ダウンロードURLの有効期限（デフォルト: 15m）
+	Retention      time.Duration `mapstructure:"retention"`        // ジョブ結果の保持期間（デフォルト: 24h）
+}
+
+// FeedConfig はニュースフィード（RSS / Atom）の設定
+type FeedConfig struct {
+	Title       string        `mapstructure:"title"`         // フィードのタイトル
+	Description string        `mapstructure:"description"`   // フィードの説明
+	SiteURL     string        `mapstructure:"site_url"`      // サイトのURL（各ニュースのリンクは{site_url}/news/{id}）
+	ItemLimit   int           `mapstructure:"item_limit"`    // フィードに含める最大件数（デフォルト: 20）
+	MaxCacheAge time.Duration `mapstructure:"max_cache_age"` // キャッシュ期間の上限（デフォルト: 1h）
+}
+
+// WebhookConfig はWebhook配信の設定
+// 配信はジョブキューで実行し、失敗時は指数バックオフでリトライする
+type WebhookConfig struct {
+	MaxRetry int           `mapstructure:"max_retry"` // 配信失敗時の最大リトライ回数（デフォルト: 8）
+	Timeout  time.Duration `mapstructure:"timeout"`   // 配信先へのリクエストのタイムアウト（デフォルト: 10s）
+}
+
+// StreamConfig はServer-Sent Events（/api/stream/*）の設定
+// Redis（cache_server.redis.default）が設定されている場合はpub/subでAPIサーバー間にイベントを配信する
+type StreamConfig struct {
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"` // ハートビート（コメント行）の送信間隔（デフォルト: 15s）
+	HistorySize       int           `mapstructure:"history_size"`       // Last-Event-IDでの再開用に保持するトピックごとのイベント数（デフォルト: 1000）
+	ChannelPrefix     string        `mapstructure:"channel_prefix"`     // Redis pub/subのチャンネル名のプレフィックス（デフォルト: "stream:"）
+}
+
+// GRPCConfig はgRPCサーバーの設定
+// APIサーバー（cmd/server）がHTTPと並行して起動する
+type GRPCConfig struct {
+	Port int `mapstructure:"port"` // 待ち受けポート（0の場合はgRPCサーバーを起動しない）
+}
+
+// GraphQLConfig はGraphQL（/api/graphql）の設定
+type GraphQLConfig struct {
+	MaxDepth      int `mapstructure:"max_depth"`      // クエリのフィールドの入れ子の深さの上限（デフォルト: 8）
+	MaxComplexity int `mapstructure:"max_complexity"` // クエリの複雑度（取得しうるフィールド数）の上限（デフォルト: 2000）
+}
+
+// IdempotencyConfig はIdempotency-Keyヘッダーによる重複リクエスト防止の設定
+// Redis（cache_server.redis.default）が設定されている場合はRedis、なければメモリにレスポンスを保存する
+type IdempotencyConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	TTL         time.Duration `mapstructure:"ttl"`          // レスポンスを保持する期間（デフォルト: 24h）
+	LockTimeout time.Duration `mapstructure:"lock_timeout"` // 処理中のリクエストのキーを保持する期間（デフォルト: 1m）
+	KeyPrefix   string        `mapstructure:"key_prefix"`   // Redisのキーのプレフィックス（デフォルト: "idempotency:"）
+}
+
 // EmailConfig はメール送信機能の設定
 type EmailConfig struct {
 	SenderType string        `mapstructure:"sender_type"` // 送信方式（"mock", "mailpit", "ses"）
@@ -359,6 +542,146 @@ func Load() (*Config, error) {
 		cfg.Logging.MailLogOutputDir = cfg.Logging.OutputDir
 	}
 
+	// エクスポート設定のデフォルト値設定
+	if cfg.Export.KeyPrefix == "" {
+		cfg.Export.KeyPrefix = "exports"
+	}
+	if cfg.Export.DownloadURLTTL <= 0 {
+		cfg.Export.DownloadURLTTL = 15 * time.Minute
+	}
+	if cfg.Export.Retention <= 0 {
+		cfg.Export.Retention = 24 * time.Hour
+	}
+
+	// ローカルストレージのエクスポートファイルはダウンロードURLを署名して配信するため、署名鍵が必要
+	if cfg.Upload.Storage.Type == "local" && cfg.Upload.ExportSigningSecret == "" {
+		return nil, fmt.Errorf("upload.export_signing_secret is required when upload.storage.type is local")
+	}
+
+	// ニュースフィード設定のデフォルト値設定
+	if cfg.Feed.Title == "" {
+		cfg.Feed.Title = "go-webdb-template News"
+	}
+	if cfg.Feed.SiteURL == "" {
+		cfg.Feed.SiteURL = fmt.Sprintf("http://localhost:%d", cfg.Server.Port)
+	}
+	if cfg.Feed.ItemLimit <= 0 {
+		cfg.Feed.ItemLimit = 20
+	}
+	if cfg.Feed.MaxCacheAge <= 0 {
+		cfg.Feed.MaxCacheAge = time.Hour
+	}
+
+	// Webhook配信設定のデフォルト値設定
+	if cfg.Webhook.MaxRetry <= 0 {
+		cfg.Webhook.MaxRetry = 8
+	}
+	if cfg.Webhook.Timeout <= 0 {
+		cfg.Webhook.Timeout = 10 * time.Second
+	}
+
+	// Server-Sent Events設定のデフォルト値設定
+	if cfg.Stream.HeartbeatInterval <= 0 {
+		cfg.Stream.HeartbeatInterval = 15 * time.Second
+	}
+	if cfg.Stream.HistorySize <= 0 {
+		cfg.Stream.HistorySize = 1000
+	}
+	if cfg.Stream.ChannelPrefix == "" {
+		cfg.Stream.ChannelPrefix = "stream:"
+	}
+
+	// GraphQL設定のデフォルト値設定
+	if cfg.GraphQL.MaxDepth <= 0 {
+		cfg.GraphQL.MaxDepth = 8
+	}
+	if cfg.GraphQL.MaxComplexity <= 0 {
+		cfg.GraphQL.MaxComplexity = 2000
+	}
+
+	// APIキー台帳設定のデフォルト値設定
+	if cfg.API.KeyRegistry.CacheTTL <= 0 {
+		cfg.API.KeyRegistry.CacheTTL = time.Minute
+	}
+	if cfg.API.KeyRegistry.UsageInterval <= 0 {
+		cfg.API.KeyRegistry.UsageInterval = 5 * time.Minute
+	}
+	if cfg.API.KeyRegistry.KeyPrefix == "" {
+		cfg.API.KeyRegistry.KeyPrefix = "api_key:"
+	}
+
+	if cfg.API.IdentityCacheTTL <= 0 {
+		cfg.API.IdentityCacheTTL = time.Minute
+	}
+
+	// 呼び出し元ごとのレートリミット設定のデフォルト値設定
+	if cfg.API.RateLimit.Principal.CacheTTL <= 0 {
+		cfg.API.RateLimit.Principal.CacheTTL = time.Minute
+	}
+	if cfg.API.RateLimit.Principal.KeyPrefix == "" {
+		cfg.API.RateLimit.Principal.KeyPrefix = "ratelimit_principal:"
+	}
+
+	// Auth0のカスタムクレーム名のデフォルト値設定
+	if cfg.API.Auth0Claims.Roles == "" {
+		cfg.API.Auth0Claims.Roles = "https://go-webdb-template/roles"
+	}
+	if cfg.API.Auth0Claims.UserID == "" {
+		cfg.API.Auth0Claims.UserID = "https://go-webdb-template/dm_user_id"
+	}
+	if cfg.API.Auth0Claims.Name == "" {
+		cfg.API.Auth0Claims.Name = "https://go-webdb-template/name"
+	}
+	if cfg.API.Auth0Claims.Email == "" {
+		cfg.API.Auth0Claims.Email = "https://go-webdb-template/email"
+	}
+
+	// Auth0 JWTの検証設定のデフォルト値設定
+	if cfg.API.Auth0.Issuer == "" && cfg.API.Auth0IssuerBaseURL != "" {
+		cfg.API.Auth0.Issuer = strings.TrimSuffix(cfg.API.Auth0IssuerBaseURL, "/") + "/"
+	}
+	if cfg.API.Auth0.ClockSkew <= 0 {
+		cfg.API.Auth0.ClockSkew = 30 * time.Second
+	}
+	if cfg.API.Auth0IssuerBaseURL != "" && len(cfg.API.Auth0.Audience) == 0 {
+		log.Printf("Warning: api.auth0.audience is empty, Auth0 JWT audience is not validated")
+	}
+
+	// OIDCのIssuerのデフォルト値設定
+	for i := range cfg.API.Issuers {
+		issuer := &cfg.API.Issuers[i]
+		if issuer.ClockSkew <= 0 {
+			issuer.ClockSkew = 30 * time.Second
+		}
+		if issuer.AccessLevel == "" {
+			issuer.AccessLevel = "private"
+		}
+		if len(issuer.Audience) == 0 {
+			log.Printf("Warning: api.issuers[%s].audience is empty, JWT audience is not validated", issuer.Name)
+		}
+	}
+
+	// 署名鍵設定のデフォルト値設定
+	if cfg.API.Signing.GracePeriod <= 0 {
+		cfg.API.Signing.GracePeriod = 720 * time.Hour
+	}
+	for i := range cfg.API.Signing.Keys {
+		if cfg.API.Signing.Keys[i].Algorithm == "" {
+			cfg.API.Signing.Keys[i].Algorithm = "HS256"
+		}
+	}
+
+	// Idempotency-Key設定のデフォルト値設定
+	if cfg.Idempotency.TTL <= 0 {
+		cfg.Idempotency.TTL = 24 * time.Hour
+	}
+	if cfg.Idempotency.LockTimeout <= 0 {
+		cfg.Idempotency.LockTimeout = time.Minute
+	}
+	if cfg.Idempotency.KeyPrefix == "" {
+		cfg.Idempotency.KeyPrefix = "idempotency:"
+	}
+
 	// SQLログ有効/無効の環境判定（設定ファイルで明示的に指定されていない場合）
 	// develop/staging: true, production: false
 	// 注意: boolのデフォルトはfalseなので、設定ファイルで明示的にtrueを指定する必要がある
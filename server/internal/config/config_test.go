@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -550,6 +551,31 @@ func TestLoad_RateLimitConfig(t *testing.T) {
 	if cfg.API.RateLimit.RequestsPerHour != 1000 {
 		t.Errorf("expected API.RateLimit.RequestsPerHour 1000, got %d", cfg.API.RateLimit.RequestsPerHour)
 	}
+
+	// ルートごとのポリシーと除外するルート
+	if len(cfg.API.RateLimit.Policies) == 0 || cfg.API.RateLimit.Policies[0].Name != "email" {
+		t.Errorf("expected API.RateLimit.Policies[0].Name email, got %+v", cfg.API.RateLimit.Policies)
+	} else if cfg.API.RateLimit.Policies[0].Algorithm != "token_bucket" || cfg.API.RateLimit.Policies[0].Burst != 5 {
+		t.Errorf("expected email policy token_bucket with burst 5, got %+v", cfg.API.RateLimit.Policies[0])
+	}
+	if len(cfg.API.RateLimit.ExemptRoutes) != 1 || cfg.API.RateLimit.ExemptRoutes[0] != "GET /health" {
+		t.Errorf("expected API.RateLimit.ExemptRoutes [GET /health], got %v", cfg.API.RateLimit.ExemptRoutes)
+	}
+
+	// 呼び出し元ごとのレートリミット設定
+	principal := cfg.API.RateLimit.Principal
+	if !principal.Enabled {
+		t.Error("expected API.RateLimit.Principal.Enabled true, got false")
+	}
+	if principal.DefaultPlan != "default" {
+		t.Errorf("expected API.RateLimit.Principal.DefaultPlan default, got %s", principal.DefaultPlan)
+	}
+	if principal.CacheTTL != time.Minute {
+		t.Errorf("expected API.RateLimit.Principal.CacheTTL 1m, got %v", principal.CacheTTL)
+	}
+	if principal.KeyPrefix != "ratelimit_principal:" {
+		t.Errorf("expected API.RateLimit.Principal.KeyPrefix ratelimit_principal:, got %s", principal.KeyPrefix)
+	}
 }
 
 // タスク2.2, 2.4: 設定ファイルからCacheServerConfigが読み込まれることを確認
@@ -844,6 +870,34 @@ func TestLoad_UploadConfig(t *testing.T) {
 	}
 }
 
+// ローカルストレージでエクスポート署名鍵が未設定の場合はエラーになることを確認
+func TestLoad_ExportSigningSecretRequiredForLocalStorage(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+	defer viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+	if cfg.Upload.ExportSigningSecret == "" {
+		t.Fatal("expected Upload.ExportSigningSecret to be set in develop config")
+	}
+	if cfg.Upload.ExportSigningSecret == cfg.API.SecretKey {
+		t.Error("expected Upload.ExportSigningSecret to differ from API.SecretKey")
+	}
+
+	viper.Reset()
+	viper.Set("upload.export_signing_secret", "")
+
+	if _, err := Load(); err == nil {
+		t.Error("expected error when upload.export_signing_secret is empty with local storage")
+	}
+}
+
 // タスク1.1: GetDSN()メソッドのテスト - PostgreSQL用DSN生成
 func TestShardConfig_GetDSN_Postgres(t *testing.T) {
 	cfg := ShardConfig{
@@ -929,3 +983,255 @@ func TestLoad_DBType_PostgreSQL(t *testing.T) {
 		t.Errorf("expected driver 'postgres', got '%s'", cfg.Database.Groups.Master[0].Driver)
 	}
 }
+
+// 設定ファイルからFeedConfigが読み込まれることを確認
+func TestLoad_FeedConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.Feed.Title == "" {
+		t.Error("expected Feed.Title to be set")
+	}
+	if cfg.Feed.SiteURL != "http://localhost:8080" {
+		t.Errorf("expected Feed.SiteURL 'http://localhost:8080', got %s", cfg.Feed.SiteURL)
+	}
+	if cfg.Feed.ItemLimit != 20 {
+		t.Errorf("expected Feed.ItemLimit 20, got %d", cfg.Feed.ItemLimit)
+	}
+	if cfg.Feed.MaxCacheAge != time.Hour {
+		t.Errorf("expected Feed.MaxCacheAge 1h, got %v", cfg.Feed.MaxCacheAge)
+	}
+}
+
+// 設定ファイルからWebhookConfigが読み込まれることを確認
+func TestLoad_WebhookConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.Webhook.MaxRetry != 8 {
+		t.Errorf("expected Webhook.MaxRetry 8, got %d", cfg.Webhook.MaxRetry)
+	}
+	if cfg.Webhook.Timeout != 10*time.Second {
+		t.Errorf("expected Webhook.Timeout 10s, got %v", cfg.Webhook.Timeout)
+	}
+}
+
+func TestLoad_StreamConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.Stream.HeartbeatInterval != 15*time.Second {
+		t.Errorf("expected Stream.HeartbeatInterval 15s, got %v", cfg.Stream.HeartbeatInterval)
+	}
+	if cfg.Stream.HistorySize != 1000 {
+		t.Errorf("expected Stream.HistorySize 1000, got %d", cfg.Stream.HistorySize)
+	}
+	if cfg.Stream.ChannelPrefix != "stream:" {
+		t.Errorf("expected Stream.ChannelPrefix \"stream:\", got %q", cfg.Stream.ChannelPrefix)
+	}
+}
+
+// 設定ファイルからGRPCConfigが読み込まれることを確認
+func TestLoad_GRPCConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.GRPC.Port != 9090 {
+		t.Errorf("expected GRPC.Port 9090, got %d", cfg.GRPC.Port)
+	}
+}
+
+// 設定ファイルからGraphQLConfigが読み込まれることを確認
+func TestLoad_GraphQLConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.GraphQL.MaxDepth != 8 {
+		t.Errorf("expected GraphQL.MaxDepth 8, got %d", cfg.GraphQL.MaxDepth)
+	}
+	if cfg.GraphQL.MaxComplexity != 2000 {
+		t.Errorf("expected GraphQL.MaxComplexity 2000, got %d", cfg.GraphQL.MaxComplexity)
+	}
+}
+
+func TestLoad_APIVersions(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if len(cfg.API.Versions) != 2 {
+		t.Fatalf("expected 2 API versions, got %d", len(cfg.API.Versions))
+	}
+	if cfg.API.Versions[0].Name != "v1" || cfg.API.Versions[1].Name != "v2" {
+		t.Errorf("expected API versions v1, v2, got %s, %s", cfg.API.Versions[0].Name, cfg.API.Versions[1].Name)
+	}
+	// v1とv2はレスポンスの形式が同じため、デフォルトの設定ではどちらも非推奨にしない
+	for _, version := range cfg.API.Versions {
+		if version.DeprecatedAt != "" {
+			t.Errorf("expected API version %s not to be deprecated, got %s", version.Name, version.DeprecatedAt)
+		}
+	}
+}
+
+func TestLoad_IdempotencyConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if !cfg.Idempotency.Enabled {
+		t.Error("expected Idempotency.Enabled true")
+	}
+	if cfg.Idempotency.TTL != 24*time.Hour {
+		t.Errorf("expected Idempotency.TTL 24h, got %v", cfg.Idempotency.TTL)
+	}
+	if cfg.Idempotency.LockTimeout != time.Minute {
+		t.Errorf("expected Idempotency.LockTimeout 1m, got %v", cfg.Idempotency.LockTimeout)
+	}
+	if cfg.Idempotency.KeyPrefix != "idempotency:" {
+		t.Errorf("expected Idempotency.KeyPrefix idempotency:, got %s", cfg.Idempotency.KeyPrefix)
+	}
+}
+
+func TestLoad_APIKeyRegistryConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.API.KeyRegistry.CacheTTL != time.Minute {
+		t.Errorf("expected API.KeyRegistry.CacheTTL 1m, got %v", cfg.API.KeyRegistry.CacheTTL)
+	}
+	if cfg.API.KeyRegistry.UsageInterval != 5*time.Minute {
+		t.Errorf("expected API.KeyRegistry.UsageInterval 5m, got %v", cfg.API.KeyRegistry.UsageInterval)
+	}
+	if cfg.API.KeyRegistry.KeyPrefix != "api_key:" {
+		t.Errorf("expected API.KeyRegistry.KeyPrefix api_key:, got %s", cfg.API.KeyRegistry.KeyPrefix)
+	}
+}
+
+func TestLoad_APISigningConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.API.Signing.KeyID != "" {
+		t.Errorf("expected API.Signing.KeyID empty, got %s", cfg.API.Signing.KeyID)
+	}
+	if cfg.API.Signing.GracePeriod != 720*time.Hour {
+		t.Errorf("expected API.Signing.GracePeriod 720h, got %v", cfg.API.Signing.GracePeriod)
+	}
+	if len(cfg.API.Signing.Keys) != 0 {
+		t.Errorf("expected no API.Signing.Keys, got %d", len(cfg.API.Signing.Keys))
+	}
+}
+
+func TestLoad_Auth0ClaimsConfig(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	if cfg.API.Auth0Claims.Roles != "https://go-webdb-template/roles" {
+		t.Errorf("expected API.Auth0Claims.Roles https://go-webdb-template/roles, got %s", cfg.API.Auth0Claims.Roles)
+	}
+	if cfg.API.Auth0Claims.UserID != "https://go-webdb-template/dm_user_id" {
+		t.Errorf("expected API.Auth0Claims.UserID https://go-webdb-template/dm_user_id, got %s", cfg.API.Auth0Claims.UserID)
+	}
+}
+
+func TestLoad_Auth0Config(t *testing.T) {
+	originalEnv := os.Getenv("APP_ENV")
+	os.Setenv("APP_ENV", "develop")
+	defer os.Setenv("APP_ENV", originalEnv)
+
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("config files not found: %v", err)
+	}
+
+	expectedIssuer := strings.TrimSuffix(cfg.API.Auth0IssuerBaseURL, "/") + "/"
+	if cfg.API.Auth0.Issuer != expectedIssuer {
+		t.Errorf("expected API.Auth0.Issuer %s, got %s", expectedIssuer, cfg.API.Auth0.Issuer)
+	}
+	if cfg.API.Auth0.ClockSkew != 30*time.Second {
+		t.Errorf("expected API.Auth0.ClockSkew 30s, got %v", cfg.API.Auth0.ClockSkew)
+	}
+	if cfg.API.Auth0.Permissions.Enforce {
+		t.Errorf("expected API.Auth0.Permissions.Enforce false")
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultSigningKeyGracePeriod は退役した鍵で検証を続ける期間のデフォルト値
+const defaultSigningKeyGracePeriod = 720 * time.Hour
+
+// SigningKeyRotation は署名鍵のローテーションの結果
+type SigningKeyRotation struct {
+	KeyID         string   // 新しく署名に使う鍵のID
+	RetiredKeyID  string   // 退役させた鍵のID（署名に使う鍵がなかった場合は空）
+	RemovedKeyIDs []string // 猶予期間を過ぎたため削除した鍵のID
+}
+
+// RotateSigningKey は設定ファイル（config.yaml）のapi.signingの署名鍵をローテーションする
+// 現在の署名鍵をnowで退役させ、newKeyを追加して署名に使う鍵にする。猶予期間を過ぎた鍵は削除する
+// 設定ファイルのコメントは保持する（行末コメントの位置などの書式は整形される）
+func RotateSigningKey(path string, newKey APISigningKeyConfig, now time.Time) (*SigningKeyRotation, error) {
+	if newKey.ID == "" {
+		return nil, errors.New("signing key id is required")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat config file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("config file must be a mapping")
+	}
+
+	api := yamlMappingValue(doc.Content[0], "api")
+	if api == nil || api.Kind != yaml.MappingNode {
+		return nil, errors.New("api section not found in config file")
+	}
+	signing := yamlMappingValue(api, "signing")
+	if signing == nil {
+		signing = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		yamlSetMappingValue(api, "signing", signing)
+	}
+
+	gracePeriod := defaultSigningKeyGracePeriod
+	if node := yamlMappingValue(signing, "grace_period"); node != nil && node.Value != "" {
+		gracePeriod, err = time.ParseDuration(node.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api.signing.grace_period: %w", err)
+		}
+	}
+
+	currentKeyID := ""
+	if node := yamlMappingValue(signing, "key_id"); node != nil {
+		currentKeyID = node.Value
+	}
+
+	keys := yamlMappingValue(signing, "keys")
+	if keys == nil || keys.Kind != yaml.SequenceNode {
+		keys = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		yamlSetMappingValue(signing, "keys", keys)
+	}
+	// 空の場合にフロースタイル（keys: []）で書かれていてもブロックスタイルで出力する
+	keys.Style = 0
+
+	rotation := &SigningKeyRotation{KeyID: newKey.ID, RemovedKeyIDs: make([]string, 0)}
+	remaining := make([]*yaml.Node, 0, len(keys.Content)+1)
+	for _, key := range keys.Content {
+		id := ""
+		if node := yamlMappingValue(key, "id"); node != nil {
+			id = node.Value
+		}
+		if id == newKey.ID {
+			return nil, fmt.Errorf("signing key already exists: %s", id)
+		}
+
+		retiredAt := ""
+		if node := yamlMappingValue(key, "retired_at"); node != nil {
+			retiredAt = node.Value
+		}
+		if id == currentKeyID && retiredAt == "" {
+			// 現在の署名鍵を退役させる（猶予期間の間は検証に使う）
+			yamlSetMappingValue(key, "retired_at", yamlStringNode(now.UTC().Format(time.RFC3339)))
+			rotation.RetiredKeyID = id
+		} else if retiredAt != "" {
+			retired, err := time.Parse(time.RFC3339, retiredAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid retired_at of signing key %s: %w", id, err)
+			}
+			if now.After(retired.Add(gracePeriod)) {
+				rotation.RemovedKeyIDs = append(rotation.RemovedKeyIDs, id)
+				continue
+			}
+		}
+		remaining = append(remaining, key)
+	}
+
+	newKeyNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	yamlSetMappingValue(newKeyNode, "id", yamlStringNode(newKey.ID))
+	yamlSetMappingValue(newKeyNode, "algorithm", yamlStringNode(newKey.Algorithm))
+	if newKey.Secret != "" {
+		yamlSetMappingValue(newKeyNode, "secret", yamlStringNode(newKey.Secret))
+	}
+	if newKey.PrivateKeyFile != "" {
+		yamlSetMappingValue(newKeyNode, "private_key_file", yamlStringNode(newKey.PrivateKeyFile))
+	}
+	keys.Content = append(remaining, newKeyNode)
+
+	yamlSetMappingValue(signing, "key_id", yamlStringNode(newKey.ID))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := os.WriteFile(path, separateTopLevelSections(buf.Bytes()), info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return rotation, nil
+}
+
+// separateTopLevelSections はトップレベルのセクションの間に空行を入れる（yaml.v3の出力では空行が失われるため）
+func separateTopLevelSections(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	out := make([][]byte, 0, len(lines))
+	for i, line := range lines {
+		if i > 0 && len(line) > 0 && line[0] != ' ' && bytes.HasPrefix(lines[i-1], []byte(" ")) {
+			out = append(out, []byte{})
+		}
+		out = append(out, line)
+	}
+	return bytes.Join(out, []byte("\n"))
+}
+
+// yamlMappingValue はマッピングノードのキーに対応する値のノードを返す（存在しない場合はnil）
+func yamlMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlSetMappingValue はマッピングノードのキーの値を設定する（存在しない場合は末尾に追加）
+func yamlSetMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			// 値の行末コメントは保持する
+			value.LineComment = mapping.Content[i+1].LineComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// yamlStringNode はダブルクォートの文字列ノードを作成
+func yamlStringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle}
+}
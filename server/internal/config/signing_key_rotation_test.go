@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// loadSigningConfig はテスト用に設定ファイルのapi.signingを読み込む
+func loadSigningConfig(t *testing.T, path string) APISigningConfig {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	return cfg.API.Signing
+}
+
+func TestRotateSigningKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `api:
+  current_version: "v2"
+  secret_key: "legacy-secret"
+  # 署名鍵
+  signing:
+    key_id: ""
+    grace_period: 24h  # 猶予期間
+    keys: []
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	first := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rotation, err := RotateSigningKey(path, APISigningKeyConfig{ID: "k1", Algorithm: "HS256", Secret: "k1-secret"}, first)
+	if err != nil {
+		t.Fatalf("RotateSigningKey failed: %v", err)
+	}
+	if rotation.KeyID != "k1" || rotation.RetiredKeyID != "" || len(rotation.RemovedKeyIDs) != 0 {
+		t.Errorf("unexpected rotation: %+v", rotation)
+	}
+
+	// 2回目: k1を退役させてk2を追加
+	second := first.Add(time.Hour)
+	rotation, err = RotateSigningKey(path, APISigningKeyConfig{ID: "k2", Algorithm: "EdDSA", PrivateKeyFile: "keys/k2.pem"}, second)
+	if err != nil {
+		t.Fatalf("RotateSigningKey failed: %v", err)
+	}
+	if rotation.RetiredKeyID != "k1" {
+		t.Errorf("expected retired key k1, got %s", rotation.RetiredKeyID)
+	}
+
+	signing := loadSigningConfig(t, path)
+	if signing.KeyID != "k2" {
+		t.Errorf("expected key_id k2, got %s", signing.KeyID)
+	}
+	if signing.GracePeriod != 24*time.Hour {
+		t.Errorf("expected grace_period 24h, got %v", signing.GracePeriod)
+	}
+	if len(signing.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(signing.Keys))
+	}
+	if signing.Keys[0].ID != "k1" || signing.Keys[0].Secret != "k1-secret" || signing.Keys[0].RetiredAt != second.Format(time.RFC3339) {
+		t.Errorf("unexpected retired key: %+v", signing.Keys[0])
+	}
+	if signing.Keys[1].ID != "k2" || signing.Keys[1].Algorithm != "EdDSA" || signing.Keys[1].PrivateKeyFile != "keys/k2.pem" {
+		t.Errorf("unexpected signing key: %+v", signing.Keys[1])
+	}
+
+	// コメントは保持される
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "# 署名鍵") || !strings.Contains(string(data), "# 猶予期間") {
+		t.Errorf("expected comments to be kept:\n%s", data)
+	}
+
+	// 3回目: 猶予期間を過ぎたk1を削除
+	third := second.Add(25 * time.Hour)
+	rotation, err = RotateSigningKey(path, APISigningKeyConfig{ID: "k3", Algorithm: "HS256", Secret: "k3-secret"}, third)
+	if err != nil {
+		t.Fatalf("RotateSigningKey failed: %v", err)
+	}
+	if rotation.RetiredKeyID != "k2" {
+		t.Errorf("expected retired key k2, got %s", rotation.RetiredKeyID)
+	}
+	if len(rotation.RemovedKeyIDs) != 1 || rotation.RemovedKeyIDs[0] != "k1" {
+		t.Errorf("expected removed key k1, got %v", rotation.RemovedKeyIDs)
+	}
+	signing = loadSigningConfig(t, path)
+	if len(signing.Keys) != 2 || signing.Keys[0].ID != "k2" || signing.Keys[1].ID != "k3" {
+		t.Errorf("unexpected keys: %+v", signing.Keys)
+	}
+
+	// 既存のIDは追加できない
+	if _, err := RotateSigningKey(path, APISigningKeyConfig{ID: "k3", Algorithm: "HS256", Secret: "x"}, third); err == nil {
+		t.Error("expected error for duplicate key id")
+	}
+}
@@ -142,7 +142,8 @@ func createGORMConnection(cfg *config.ShardConfig, isWriter bool, sqlLogger *SQL
 	}
 
 	// GORM ConfigにLoggerを設定
-	gormConfig := &gorm.Config{}
+	// TranslateErrorで一意制約違反などをgorm.ErrDuplicatedKey等に変換する
+	gormConfig := &gorm.Config{TranslateError: true}
 	if sqlLogger != nil {
 		gormConfig.Logger = sqlLogger
 	}
@@ -211,7 +212,7 @@ func createGORMConnectionFromDSN(dsn string, driver string) (*gorm.DB, error) {
 		return nil, fmt.Errorf("unsupported driver: %s", driver)
 	}
 
-	db, err := gorm.Open(dialector, &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,207 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humaecho"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+const (
+	// HeaderIdempotencyKey はクライアントが指定する冪等キーのヘッダー
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed は保存済みのレスポンスを返した場合に設定するヘッダー
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	// maxKeyLength は冪等キーの最大長
+	maxKeyLength = 255
+)
+
+// NewIdempotencyMiddleware はIdempotency-Keyヘッダーを処理するHumaミドルウェアを作成
+// /api/以下のPOSTリクエストでIdempotency-Keyが指定された場合、最初のレスポンスを保存し、
+// 同じキーの再送には保存済みのレスポンスを返す
+// 匿名の呼び出し元が記録を作成しないよう、認証ミドルウェア（と呼び出し元ごとのレートリミット）の後に適用する
+func NewIdempotencyMiddleware(cfg *config.Config) (func(ctx huma.Context, next func(huma.Context)), error) {
+	return newHumaMiddleware(&cfg.Idempotency, NewStore(cfg)), nil
+}
+
+// newHumaMiddleware はnewMiddlewareをHumaのオペレーションに適用する（ボディの上限はオペレーションのMaxBodyBytes）
+func newHumaMiddleware(cfg *config.IdempotencyConfig, store Store) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		handler := newMiddleware(cfg, store, ctx.Operation().MaxBodyBytes)(func(echo.Context) error {
+			next(ctx)
+			return nil
+		})
+		if err := handler(humaecho.Unwrap(ctx)); err != nil {
+			logrus.WithError(err).Warn("failed to write idempotent response")
+		}
+	}
+}
+
+// newMiddleware はIdempotency-Keyヘッダーを処理するEchoミドルウェアを作成
+// maxBodyBytesを超えるボディは読み込まずに413を返す（0以下の場合は上限なし）
+func newMiddleware(cfg *config.IdempotencyConfig, store Store, maxBodyBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if !cfg.Enabled || req.Method != http.MethodPost || key == "" || !strings.HasPrefix(req.URL.Path, "/api/") {
+				return next(c)
+			}
+			if len(key) > maxKeyLength {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{
+					"code":    400,
+					"message": "Idempotency-Key must be at most 255 characters",
+				})
+			}
+
+			// フィンガープリントの計算のためにボディを読み込み、ハンドラー用に戻す
+			if maxBodyBytes > 0 {
+				req.Body = http.MaxBytesReader(c.Response(), req.Body, maxBodyBytes)
+			}
+			body, err := io.ReadAll(req.Body)
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return c.JSON(http.StatusRequestEntityTooLarge, map[string]interface{}{
+					"code":    413,
+					"message": fmt.Sprintf("request body is too large limit=%d bytes", maxBodyBytes),
+				})
+			}
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{
+					"code":    400,
+					"message": "failed to read request body",
+				})
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := hashOf(req.Method, req.URL.RequestURI(), string(body))
+			// 別のユーザーと同じキーを使った場合に他人のレスポンスを返さないよう、認証情報をキーに含める
+			storeKey := cfg.KeyPrefix + hashOf(req.Header.Get(echo.HeaderAuthorization), key)
+
+			ctx := req.Context()
+			record, reserved, err := store.Reserve(ctx, storeKey, fingerprint, cfg.LockTimeout)
+			if err != nil {
+				// fail-open方式: エラー時はログに記録し、冪等性の保証なしでリクエストを処理
+				logrus.WithError(err).Warn("idempotency store unavailable, processing request without idempotency")
+				return next(c)
+			}
+
+			if !reserved {
+				switch {
+				case record.Fingerprint != fingerprint:
+					return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+						"code":    422,
+						"message": "Idempotency-Key is already used for a different request",
+					})
+				case !record.Completed:
+					return c.JSON(http.StatusConflict, map[string]interface{}{
+						"code":    409,
+						"message": "A request with the same Idempotency-Key is being processed",
+					})
+				}
+				return replay(c, record)
+			}
+
+			// ハンドラーが設定したヘッダーのみを保存するため、実行前のヘッダーを記録
+			before := c.Response().Header().Clone()
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			handlerErr := next(c)
+			if handlerErr != nil {
+				// エラーをレスポンスに変換してから保存する
+				c.Error(handlerErr)
+			}
+
+			status := c.Response().Status
+			if !storable(status) || !c.Response().Committed {
+				// サーバーエラー・再試行で結果が変わりうるエラーは再試行できるように記録を削除
+				if err := store.Delete(ctx, storeKey); err != nil {
+					logrus.WithError(err).Warn("failed to delete idempotency record")
+				}
+				return nil
+			}
+
+			if err := store.Save(ctx, storeKey, &Record{
+				Fingerprint: fingerprint,
+				Completed:   true,
+				StatusCode:  status,
+				Header:      addedHeaders(before, c.Response().Header()),
+				Body:        recorder.body.Bytes(),
+			}, cfg.TTL); err != nil {
+				logrus.WithError(err).Warn("failed to save idempotency record")
+			}
+			return nil
+		}
+	}
+}
+
+// storable は再送に同じレスポンスを返してよいステータスかどうかを判定
+// 成功（2xx）と、同じリクエストでは結果が変わらないクライアントエラーのみ保存する
+// 401/403/409/429などは認証情報の更新や時間の経過で結果が変わるため保存しない
+func storable(status int) bool {
+	switch status {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusGone, http.StatusUnprocessableEntity:
+		return true
+	}
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// replay は保存済みのレスポンスを返す
+func replay(c echo.Context, record *Record) error {
+	header := c.Response().Header()
+	for name, values := range record.Header {
+		header[name] = slices.Clone(values)
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+	c.Response().WriteHeader(record.StatusCode)
+	_, err := c.Response().Write(record.Body)
+	return err
+}
+
+// addedHeaders はハンドラーが追加・変更したヘッダーを返す
+func addedHeaders(before, after http.Header) http.Header {
+	added := make(http.Header)
+	for name, values := range after {
+		if !slices.Equal(before[name], values) {
+			added[name] = slices.Clone(values)
+		}
+	}
+	return added
+}
+
+// hashOf は値を改行で連結したSHA-256ハッシュ（16進数）を返す
+func hashOf(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// responseRecorder はレスポンスボディを記録しながら書き込むResponseWriter
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush は元のResponseWriterがFlusherの場合にフラッシュする
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
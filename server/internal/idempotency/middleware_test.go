@@ -0,0 +1,331 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humaecho"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func newTestIdempotencyConfig() *config.IdempotencyConfig {
+	return &config.IdempotencyConfig{
+		Enabled:     true,
+		TTL:         time.Hour,
+		LockTimeout: time.Minute,
+		KeyPrefix:   "idempotency:",
+	}
+}
+
+// testMaxBodyBytes はテストで使うリクエストボディの上限
+const testMaxBodyBytes = 1024
+
+// newTestEcho はPOST /api/dm-usersを登録したEchoを作成（handlerの呼び出し回数を返す）
+func newTestEcho(cfg *config.IdempotencyConfig, store Store, handler echo.HandlerFunc) *echo.Echo {
+	e := echo.New()
+	e.Use(newMiddleware(cfg, store, testMaxBodyBytes))
+	e.POST("/api/dm-users", handler)
+	return e
+}
+
+func doPost(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/dm-users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddleware_Replay(t *testing.T) {
+	calls := 0
+	e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+		calls++
+		body, err := io.ReadAll(c.Request().Body)
+		require.NoError(t, err)
+		c.Response().Header().Set(echo.HeaderLocation, "/api/dm-users/1")
+		return c.JSONBlob(http.StatusCreated, body)
+	})
+
+	first := doPost(e, "key-1", `{"name":"alice"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(HeaderIdempotentReplayed))
+
+	second := doPost(e, "key-1", `{"name":"alice"}`)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, "/api/dm-users/1", second.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_DifferentBody(t *testing.T) {
+	calls := 0
+	e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+		calls++
+		return c.NoContent(http.StatusCreated)
+	})
+
+	require.Equal(t, http.StatusCreated, doPost(e, "key-1", `{"name":"alice"}`).Code)
+
+	rec := doPost(e, "key-1", `{"name":"bob"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_Concurrent(t *testing.T) {
+	store := NewMemoryStore()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e := newTestEcho(newTestIdempotencyConfig(), store, func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- doPost(e, "key-1", `{"name":"alice"}`)
+	}()
+	<-started
+
+	// 最初のリクエストの処理中は409を返す
+	rec := doPost(e, "key-1", `{"name":"alice"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+}
+
+func TestIdempotencyMiddleware_ServerErrorIsNotStored(t *testing.T) {
+	calls := 0
+	e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, doPost(e, "key-1", `{}`).Code)
+
+	// サーバーエラーの後は同じキーで再試行できる
+	rec := doPost(e, "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_ClientErrorIsStored(t *testing.T) {
+	calls := 0
+	e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+		calls++
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid name")
+	})
+
+	assert.Equal(t, http.StatusBadRequest, doPost(e, "key-1", `{}`).Code)
+
+	rec := doPost(e, "key-1", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_RetryableClientErrorIsNotStored(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			calls := 0
+			e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+				calls++
+				if calls == 1 {
+					return echo.NewHTTPError(status)
+				}
+				return c.NoContent(http.StatusCreated)
+			})
+
+			assert.Equal(t, status, doPost(e, "key-1", `{}`).Code)
+
+			// 再試行ではハンドラーを実行する
+			rec := doPost(e, "key-1", `{}`)
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+			assert.Equal(t, 2, calls)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_BodyTooLarge(t *testing.T) {
+	store := NewMemoryStore()
+	calls := 0
+	e := newTestEcho(newTestIdempotencyConfig(), store, func(c echo.Context) error {
+		calls++
+		return c.NoContent(http.StatusCreated)
+	})
+
+	rec := doPost(e, "key-1", strings.Repeat("a", testMaxBodyBytes+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, 0, calls)
+
+	// 記録は作成しない
+	_, reserved, err := store.Reserve(context.Background(), "idempotency:"+hashOf("", "key-1"), "", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestIdempotencyHumaMiddleware(t *testing.T) {
+	e := echo.New()
+	api := humaecho.New(e, huma.DefaultConfig("test", "1.0.0"))
+	// 認証ミドルウェアの代わりにAuthorizationヘッダーのないリクエストを拒否する
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		if ctx.Header(echo.HeaderAuthorization) == "" {
+			huma.WriteErr(api, ctx, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(ctx)
+	})
+	store := NewMemoryStore()
+	api.UseMiddleware(newHumaMiddleware(newTestIdempotencyConfig(), store))
+
+	calls := 0
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-item",
+		Method:        http.MethodPost,
+		Path:          "/api/items",
+		MaxBodyBytes:  testMaxBodyBytes,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *struct {
+		Body struct {
+			Name string `json:"name"`
+		}
+	}) (*struct{ Body struct{ Calls int } }, error) {
+		calls++
+		out := &struct{ Body struct{ Calls int } }{}
+		out.Body.Calls = calls
+		return out, nil
+	})
+
+	post := func(authorization, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIdempotencyKey, "key-1")
+		if authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, authorization)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 認証されていないリクエストは記録を作成しない
+	assert.Equal(t, http.StatusUnauthorized, post("", `{"name":"a"}`).Code)
+	_, reserved, err := store.Reserve(context.Background(), "idempotency:"+hashOf("", "key-1"), "", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	first := post("Bearer user-a", `{"name":"a"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	second := post("Bearer user-a", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, 1, calls)
+
+	// オペレーションのMaxBodyBytesを超えるボディは読み込まない
+	rec := post("Bearer user-b", `{"name":"`+strings.Repeat("a", testMaxBodyBytes)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_Passthrough(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  func() *config.IdempotencyConfig
+		key  string
+	}{
+		{"without key", newTestIdempotencyConfig, ""},
+		{"disabled", func() *config.IdempotencyConfig {
+			cfg := newTestIdempotencyConfig()
+			cfg.Enabled = false
+			return cfg
+		}, "key-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			e := newTestEcho(tt.cfg(), NewMemoryStore(), func(c echo.Context) error {
+				calls++
+				return c.NoContent(http.StatusCreated)
+			})
+
+			doPost(e, tt.key, `{}`)
+			rec := doPost(e, tt.key, `{}`)
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+			assert.Equal(t, 2, calls)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_KeyTooLong(t *testing.T) {
+	e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	rec := doPost(e, strings.Repeat("k", maxKeyLength+1), `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestIdempotencyMiddleware_KeyIsScopedByAuthorization(t *testing.T) {
+	calls := 0
+	e := newTestEcho(newTestIdempotencyConfig(), NewMemoryStore(), func(c echo.Context) error {
+		calls++
+		return c.NoContent(http.StatusCreated)
+	})
+
+	for _, token := range []string{"Bearer user-a", "Bearer user-b"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/dm-users", strings.NewReader(`{}`))
+		req.Header.Set(HeaderIdempotencyKey, "key-1")
+		req.Header.Set(echo.HeaderAuthorization, token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+	}
+	assert.Equal(t, 2, calls)
+}
+
+// failingStore は常にエラーを返すストア
+type failingStore struct{}
+
+func (failingStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	return nil, false, errors.New("store unavailable")
+}
+
+func (failingStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	return errors.New("store unavailable")
+}
+
+func (failingStore) Delete(ctx context.Context, key string) error {
+	return errors.New("store unavailable")
+}
+
+func TestIdempotencyMiddleware_StoreError(t *testing.T) {
+	e := newTestEcho(newTestIdempotencyConfig(), failingStore{}, func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	// fail-open方式: ストアのエラー時もリクエストを処理する
+	assert.Equal(t, http.StatusCreated, doPost(e, "key-1", `{}`).Code)
+}
@@ -0,0 +1,132 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// RedisStore はRedisに記録を保存するストア（複数のAPIサーバーで共有する）
+type RedisStore struct {
+	client *redis.ClusterClient
+}
+
+// NewRedisStore は新しいRedisStoreを作成
+// Redisへの接続は遅延接続であり、Redisが起動していない場合は各操作がエラーを返す
+func NewRedisStore(cfg *config.Config) *RedisStore {
+	return &RedisStore{
+		client: redis.NewClusterClient(buildRedisClusterOptions(cfg)),
+	}
+}
+
+// Reserve はSET NXでキーを処理中として予約
+func (s *RedisStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	payload, err := json.Marshal(&Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	// 予約と取得の間にキーが期限切れになった場合に備えて1回だけ再試行
+	for i := 0; i < 2; i++ {
+		ok, err := s.client.SetNX(ctx, key, payload, ttl).Result()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if ok {
+			return nil, true, nil
+		}
+
+		data, err := s.client.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get idempotency record: %w", err)
+		}
+		var record Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+		}
+		return &record, false, nil
+	}
+	return nil, false, fmt.Errorf("failed to reserve idempotency key: %s", key)
+}
+
+// Save は処理が完了した記録を保存
+func (s *RedisStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+	if err := s.client.Set(ctx, key, payload, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save idempotency record: %w", err)
+	}
+	return nil
+}
+
+// Delete は記録を削除
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed to delete idempotency record: %w", err)
+	}
+	return nil
+}
+
+// buildRedisClusterOptions はRedis Cluster接続オプションを構築する
+func buildRedisClusterOptions(cfg *config.Config) *redis.ClusterOptions {
+	clusterCfg := cfg.CacheServer.Redis.Default.Cluster
+	clusterOpts := &redis.ClusterOptions{
+		Addrs: clusterCfg.Addrs,
+	}
+
+	// 接続オプションの設定（設定ファイルから読み込む、未設定の場合はデフォルト値を使用）
+	if clusterCfg.MaxRetries > 0 {
+		clusterOpts.MaxRetries = clusterCfg.MaxRetries
+	} else {
+		clusterOpts.MaxRetries = 2 // デフォルト値
+	}
+
+	if clusterCfg.MinRetryBackoff > 0 {
+		clusterOpts.MinRetryBackoff = clusterCfg.MinRetryBackoff
+	} else {
+		clusterOpts.MinRetryBackoff = 8 * time.Millisecond // デフォルト値
+	}
+
+	if clusterCfg.MaxRetryBackoff > 0 {
+		clusterOpts.MaxRetryBackoff = clusterCfg.MaxRetryBackoff
+	} else {
+		clusterOpts.MaxRetryBackoff = 512 * time.Millisecond // デフォルト値
+	}
+
+	if clusterCfg.DialTimeout > 0 {
+		clusterOpts.DialTimeout = clusterCfg.DialTimeout
+	} else {
+		clusterOpts.DialTimeout = 5 * time.Second // デフォルト値
+	}
+
+	if clusterCfg.ReadTimeout > 0 {
+		clusterOpts.ReadTimeout = clusterCfg.ReadTimeout
+	} else {
+		clusterOpts.ReadTimeout = 3 * time.Second // デフォルト値
+	}
+
+	if clusterCfg.PoolSize > 0 {
+		clusterOpts.PoolSize = clusterCfg.PoolSize
+	} else {
+		clusterOpts.PoolSize = 10 * runtime.NumCPU() // デフォルト値: CPU数×10
+	}
+
+	if clusterCfg.PoolTimeout > 0 {
+		clusterOpts.PoolTimeout = clusterCfg.PoolTimeout
+	} else {
+		clusterOpts.PoolTimeout = 4 * time.Second // デフォルト値
+	}
+
+	return clusterOpts
+}
@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// Record はIdempotency-Keyごとに保存するリクエストとレスポンスの記録
+// Completedがfalseの間は処理中を表す
+type Record struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Store はIdempotency-Keyの記録を保存するストアのインターフェース
+type Store interface {
+	// Reserve はキーが未使用の場合に処理中として予約する
+	// 予約できた場合はtrue、使用済みの場合はfalseと保存済みの記録を返す
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error)
+	// Save は処理が完了した記録を保存
+	Save(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	// Delete は記録を削除（処理に失敗した場合に再試行できるようにする）
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStore は設定に応じたストアを作成
+// Redis（cache_server.redis.default.cluster.addrs）が設定されている場合はRedis、なければメモリに保存する
+func NewStore(cfg *config.Config) Store {
+	if len(cfg.CacheServer.Redis.Default.Cluster.Addrs) == 0 {
+		return NewMemoryStore()
+	}
+	return NewRedisStore(cfg)
+}
+
+// MemoryStore はプロセス内のメモリに記録を保存するストア（Redisがない環境用）
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+// NewMemoryStore は新しいMemoryStoreを作成
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Reserve はキーが未使用（または期限切れ）の場合に処理中として予約
+func (s *MemoryStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.deleteExpired(now)
+
+	if entry, ok := s.records[key]; ok {
+		record := entry.record
+		return &record, false, nil
+	}
+	s.records[key] = &memoryEntry{
+		record:    Record{Fingerprint: fingerprint},
+		expiresAt: now.Add(ttl),
+	}
+	return nil, true, nil
+}
+
+// Save は処理が完了した記録を保存
+func (s *MemoryStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = &memoryEntry{
+		record:    *record,
+		expiresAt: s.now().Add(ttl),
+	}
+	return nil
+}
+
+// Delete は記録を削除
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// deleteExpired は期限切れの記録を削除（ロックを保持して呼び出す）
+func (s *MemoryStore) deleteExpired(now time.Time) {
+	for key, entry := range s.records {
+		if !now.Before(entry.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
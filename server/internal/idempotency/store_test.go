@@ -0,0 +1,56 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func TestNewStore_Memory(t *testing.T) {
+	store := NewStore(&config.Config{})
+	assert.IsType(t, &MemoryStore{}, store)
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	record, reserved, err := store.Reserve(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Nil(t, record)
+
+	// 処理中の記録を返す
+	record, reserved, err = store.Reserve(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	require.NotNil(t, record)
+	assert.Equal(t, "fp", record.Fingerprint)
+	assert.False(t, record.Completed)
+
+	// 保存した記録を返す
+	require.NoError(t, store.Save(ctx, "key", &Record{Fingerprint: "fp", Completed: true, StatusCode: 201, Body: []byte("{}")}, time.Hour))
+	record, reserved, err = store.Reserve(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.True(t, record.Completed)
+	assert.Equal(t, 201, record.StatusCode)
+
+	// 期限切れの後は再び予約できる
+	now = now.Add(time.Hour)
+	_, reserved, err = store.Reserve(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	// 削除の後は再び予約できる
+	require.NoError(t, store.Delete(ctx, "key"))
+	_, reserved, err = store.Reserve(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
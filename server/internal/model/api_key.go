@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// APIKey は発行済みPublic APIキーの台帳のデータモデル
+// masterグループに配置されるテーブル（シャーディング不要）。トークン自体は保存せず、JWTのjtiで識別する
+type APIKey struct {
+	ID         int64      `json:"id,string" db:"id" gorm:"primaryKey;autoIncrement"`
+	JTI        string     `json:"jti" db:"jti" gorm:"type:varchar(32);not null;uniqueIndex:idx_api_keys_jti"`
+	Name       string     `json:"name" db:"name" gorm:"type:text;not null"`
+	Owner      string     `json:"owner" db:"owner" gorm:"type:text;not null"`
+	Scopes     string     `json:"scopes" db:"scopes" gorm:"type:text;not null"` // カンマ区切りのスコープ
+	Version    string     `json:"version" db:"version" gorm:"type:text;not null"`
+	Env        string     `json:"env" db:"env" gorm:"type:text;not null"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName はテーブル名を明示的に指定
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// Revoked はキーが失効しているかを返す
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IssueAPIKeyRequest はPublic APIキー発行のリクエスト
+type IssueAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Owner  string   `json:"owner" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
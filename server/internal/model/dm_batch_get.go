@@ -0,0 +1,25 @@
+package model
+
+// DmBatchGetMaxIDs は一括取得で一度に指定できるIDの上限
+const DmBatchGetMaxIDs = 100
+
+// DmPostKey は投稿を特定するキー
+// 投稿はユーザーIDをキーにシャーディングされるため、投稿IDとユーザーIDの組で指定する
+type DmPostKey struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+// DmUserBatchGetResult はユーザー一括取得の結果
+// Itemsは見つかったユーザー、Missingは見つからなかったIDで、いずれもリクエストの順序を保つ
+type DmUserBatchGetResult struct {
+	Items   []*DmUser `json:"items"`
+	Missing []string  `json:"missing"`
+}
+
+// DmPostBatchGetResult は投稿一括取得の結果
+// Itemsは見つかった投稿、Missingは見つからなかったキーで、いずれもリクエストの順序を保つ
+type DmPostBatchGetResult struct {
+	Items   []*DmPost   `json:"items"`
+	Missing []DmPostKey `json:"missing"`
+}
@@ -0,0 +1,66 @@
+package model
+
+// 一括登録の行ごとの処理結果ステータス
+const (
+	BulkRowStatusOK    = "ok"
+	BulkRowStatusError = "error"
+)
+
+// BulkImportRowResult は一括登録の1行分の処理結果
+type BulkImportRowResult struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkImportResult は一括登録全体の処理結果
+type BulkImportResult struct {
+	Total     int                    `json:"total"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Results   []*BulkImportRowResult `json:"results"`
+
+	// 登録したユーザー・投稿（Webhookの送信に使い、結果のJSONには含めない）
+	CreatedDmUsers []*DmUser `json:"-"`
+	CreatedDmPosts []*DmPost `json:"-"`
+}
+
+// NewBulkImportResult は行数分の結果枠を持つBulkImportResultを作成
+func NewBulkImportResult(total int) *BulkImportResult {
+	results := make([]*BulkImportRowResult, total)
+	for i := range results {
+		results[i] = &BulkImportRowResult{Index: i}
+	}
+	return &BulkImportResult{
+		Total:   total,
+		Results: results,
+	}
+}
+
+// SetOK は指定行を成功として記録
+func (r *BulkImportResult) SetOK(index int, id string) {
+	r.Results[index].ID = id
+	r.Results[index].Status = BulkRowStatusOK
+	r.Results[index].Error = ""
+}
+
+// SetError は指定行を失敗として記録
+func (r *BulkImportResult) SetError(index int, message string) {
+	r.Results[index].ID = ""
+	r.Results[index].Status = BulkRowStatusError
+	r.Results[index].Error = message
+}
+
+// Summarize は行ごとの結果から成功数・失敗数を集計
+func (r *BulkImportResult) Summarize() {
+	r.Succeeded = 0
+	r.Failed = 0
+	for _, row := range r.Results {
+		if row.Status == BulkRowStatusOK {
+			r.Succeeded++
+		} else {
+			r.Failed++
+		}
+	}
+}
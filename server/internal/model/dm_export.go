@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// エクスポート対象
+const (
+	ExportTargetDmUsers = "dm_users"
+	ExportTargetDmPosts = "dm_posts"
+)
+
+// エクスポート形式
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+)
+
+// DmUserExportFields はdm_usersでエクスポート可能なフィールド（既定の出力順）
+var DmUserExportFields = []string{"id", "name", "email", "created_at", "updated_at"}
+
+// DmPostExportFields はdm_postsでエクスポート可能なフィールド（既定の出力順）
+var DmPostExportFields = []string{"id", "user_id", "title", "content", "created_at", "updated_at"}
+
+// ExportFilter はエクスポート対象の絞り込み条件
+type ExportFilter struct {
+	CreatedFrom *time.Time `json:"created_from,omitempty"` // 作成日時の下限（この日時を含む）
+	CreatedTo   *time.Time `json:"created_to,omitempty"`   // 作成日時の上限（この日時を含まない）
+	UserID      string     `json:"user_id,omitempty"`      // ユーザーID（dm_postsのみ）
+}
+
+// ExportRequest はエクスポートリクエスト
+type ExportRequest struct {
+	Target string       `json:"target"`
+	Format string       `json:"format"`
+	Fields []string     `json:"fields,omitempty"` // 出力フィールド（空の場合は全フィールド）
+	Filter ExportFilter `json:"filter"`
+}
+
+// ExportProgress はエクスポートの進捗
+type ExportProgress struct {
+	Processed int64 `json:"processed"`
+	Total     int64 `json:"total"`
+}
+
+// ExportResult はエクスポートジョブの処理結果（ジョブ結果として保存される）
+type ExportResult struct {
+	Progress  ExportProgress `json:"progress"`
+	Completed bool           `json:"completed"`
+	FileKey   string         `json:"file_key,omitempty"` // ストレージ上のキー
+	FileName  string         `json:"file_name,omitempty"`
+	Size      int64          `json:"size,omitempty"`
+}
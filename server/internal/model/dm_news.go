@@ -34,3 +34,12 @@ type UpdateDmNewsRequest struct {
 	AuthorID    *int64     `json:"author_id,omitempty"`
 	PublishedAt *time.Time `json:"published_at,omitempty"`
 }
+
+// DmNewsListQuery は公開済みニュースの一覧取得条件
+// published_atがNow以前のニュースのみを対象とし、published_atの新しい順に並べる
+type DmNewsListQuery struct {
+	AuthorID *int64 // 指定した場合はその作成者のニュースのみ
+	Now      time.Time
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// ニュースフィードの形式
+const (
+	DmNewsFeedFormatRSS  = "rss"
+	DmNewsFeedFormatAtom = "atom"
+)
+
+// ニュースフィードの配信パス
+const (
+	DmNewsFeedRSSPath  = "/feeds/news.rss"
+	DmNewsFeedAtomPath = "/feeds/news.atom"
+)
+
+// DmNewsFeedItem はニュースフィードの1件分のデータ（dm_news_viewから取得）
+type DmNewsFeedItem struct {
+	ID          int64     `db:"id"`
+	Title       string    `db:"title"`
+	Content     string    `db:"content"`
+	PublishedAt time.Time `db:"published_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// DmNewsFeedOptions はニュースフィードの生成設定
+type DmNewsFeedOptions struct {
+	Title       string
+	Description string
+	SiteURL     string // サイトのURL（各ニュースのリンクは{SiteURL}/news/{id}）
+	ItemLimit   int
+	MaxCacheAge time.Duration // 次の公開予定がない場合・遠い場合のキャッシュ期間
+}
+
+// DmNewsFeed は生成済みのニュースフィード
+type DmNewsFeed struct {
+	ContentType   string
+	Body          []byte
+	ETag          string
+	LastModified  time.Time  // 最新アイテムの更新日時（アイテムがない場合はゼロ値）
+	NextPublishAt *time.Time // 次に公開予定のニュースの公開日時（予定がない場合はnil）
+	CacheUntil    time.Time  // レスポンスをキャッシュしてよい期限
+}
@@ -20,7 +20,7 @@ func (DmPost) TableName() string {
 
 // CreateDmPostRequest は投稿作成リクエスト
 type CreateDmPostRequest struct {
-	UserID  string `json:"user_id" validate:"required,len=32"`
+	UserID  string `json:"user_id" validate:"required,len=32,uuidv7"`
 	Title   string `json:"title" validate:"required,min=1,max=200"`
 	Content string `json:"content" validate:"required,min=1"`
 }
@@ -0,0 +1,37 @@
+package model
+
+// 投稿検索の並び順
+const (
+	DmPostSearchSortRelevance = "relevance" // 関連度順（同じ関連度の場合は新しい順）
+	DmPostSearchSortDate      = "date"      // 新しい順
+)
+
+// 投稿検索のトークン化方式
+const (
+	// DmPostSearchModeWord は単語単位の検索
+	// PostgreSQL: to_tsvector('simple')のGINインデックス / MySQL: ngramパーサーのFULLTEXTインデックス（自然言語モード）
+	DmPostSearchModeWord = "word"
+	// DmPostSearchModeNgram は部分一致の検索（分かち書きされない日本語向け）
+	// PostgreSQL: pg_trgmのGINインデックス / MySQL: ngramパーサーのFULLTEXTインデックス（フレーズ検索）
+	DmPostSearchModeNgram = "ngram"
+)
+
+// DmPostSearchMaxWindow は全文検索で取得できる範囲（offset+limit）の上限
+// 全テーブルからoffset+limit件ずつ取得してマージするため、深いページングを制限する
+const DmPostSearchMaxWindow = 1000
+
+// DmPostSearchQuery は投稿の全文検索条件
+type DmPostSearchQuery struct {
+	Query  string
+	Mode   string
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// DmPostSearchHit は投稿の全文検索結果
+// Scoreはドライバーごとの関連度（PostgreSQL: ts_rank / word_similarity、MySQL: MATCH ... AGAINST）
+type DmPostSearchHit struct {
+	DmPost `gorm:"embedded"`
+	Score  float64 `json:"score" gorm:"column:score"`
+}
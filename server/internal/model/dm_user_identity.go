@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// DmUserIdentity はOIDCのIssuerで認証したユーザー（issとsub）とdm_usersの紐づけのデータモデル
+// masterグループに配置されるテーブル（シャーディング不要）。1人のユーザーに複数のIssuerのアカウントを紐づけられる
+type DmUserIdentity struct {
+	ID        int64     `json:"id,string" db:"id" gorm:"primaryKey;autoIncrement"`
+	Issuer    string    `json:"issuer" db:"issuer" gorm:"type:varchar(255);not null;uniqueIndex:idx_dm_user_identities_issuer_subject"`
+	Subject   string    `json:"subject" db:"subject" gorm:"type:varchar(255);not null;uniqueIndex:idx_dm_user_identities_issuer_subject"`
+	DmUserID  string    `json:"dm_user_id" db:"dm_user_id" gorm:"type:varchar(32);not null;index:idx_dm_user_identities_dm_user_id"`
+	Email     string    `json:"email" db:"email" gorm:"type:varchar(255);not null"` // 紐づけた時点のメールアドレス
+	CreatedAt time.Time `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName はテーブル名を明示的に指定
+func (DmUserIdentity) TableName() string {
+	return "dm_user_identities"
+}
+
+// DmMe は呼び出し元のユーザーと紐づくアカウントの一覧
+type DmMe struct {
+	User       *DmUser           `json:"user"`
+	Identities []*DmUserIdentity `json:"identities"`
+}
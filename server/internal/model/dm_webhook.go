@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// Webhookのイベント種別
+const (
+	WebhookEventUserCreated = "user.created"
+	WebhookEventUserUpdated = "user.updated"
+	WebhookEventUserDeleted = "user.deleted"
+	WebhookEventPostCreated = "post.created"
+	WebhookEventPostUpdated = "post.updated"
+	WebhookEventPostDeleted = "post.deleted"
+)
+
+// Webhook配信の状態
+const (
+	WebhookDeliveryStatusPending   = "pending"   // 配信待ち
+	WebhookDeliveryStatusRetrying  = "retrying"  // 配信に失敗し、リトライ待ち
+	WebhookDeliveryStatusSucceeded = "succeeded" // 配信成功（2xx応答）
+	WebhookDeliveryStatusFailed    = "failed"    // リトライ上限に達した、または配信不可
+)
+
+// DmWebhookSubscription はWebhookの購読設定のデータモデル
+// masterグループに配置されるテーブル（シャーディング不要）
+type DmWebhookSubscription struct {
+	ID        int64     `json:"id,string" db:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" db:"name" gorm:"type:text;not null"`
+	TargetURL string    `json:"target_url" db:"target_url" gorm:"type:text;not null"`
+	Secret    string    `json:"-" db:"secret" gorm:"type:text;not null"`
+	Events    string    `json:"events" db:"events" gorm:"type:text;not null"` // カンマ区切りのイベントフィルタ（"*"・"user.*"も指定可能）
+	Active    bool      `json:"active" db:"active" gorm:"not null"`           // GORMでfalseを保存できるようdefaultタグは付けない（DBのデフォルトはtrue）
+	CreatedAt time.Time `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName はテーブル名を明示的に指定
+func (DmWebhookSubscription) TableName() string {
+	return "dm_webhook_subscriptions"
+}
+
+// DmWebhookDelivery はWebhookの配信ログのデータモデル
+// 再送時は同じevent_id・payloadで新しい配信ログを作成し、replay_ofに元の配信ログのIDを設定する
+type DmWebhookDelivery struct {
+	ID             int64      `json:"id,string" db:"id" gorm:"primaryKey;autoIncrement"`
+	SubscriptionID int64      `json:"subscription_id,string" db:"subscription_id" gorm:"not null;index:idx_dm_webhook_deliveries_subscription_id"`
+	Event          string     `json:"event" db:"event" gorm:"not null"`
+	EventID        string     `json:"event_id" db:"event_id" gorm:"not null"`
+	Payload        string     `json:"payload" db:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" db:"status" gorm:"not null;index:idx_dm_webhook_deliveries_status"`
+	Attempts       int        `json:"attempts" db:"attempts" gorm:"not null;default:0"`
+	ResponseStatus *int       `json:"response_status,omitempty" db:"response_status"`
+	LastError      *string    `json:"last_error,omitempty" db:"last_error"`
+	ReplayOf       *int64     `json:"replay_of,omitempty,string" db:"replay_of"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName はテーブル名を明示的に指定
+func (DmWebhookDelivery) TableName() string {
+	return "dm_webhook_deliveries"
+}
+
+// WebhookEventPayload はWebhookで送信するリクエストボディ
+type WebhookEventPayload struct {
+	ID        string      `json:"id"` // イベントID（再送しても変わらない）
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
@@ -0,0 +1,75 @@
+package model
+
+// ListMaxWindow は一覧取得で取得できる範囲（offset+limit）の上限
+// 各シャードテーブルからoffset+limit件を取得してメモリ上で並べ替えるため、範囲を制限する
+const ListMaxWindow = 1000
+
+// 一覧取得のフィルタ演算子
+const (
+	ListFilterOpEq       = "eq"
+	ListFilterOpNe       = "ne"
+	ListFilterOpGt       = "gt"
+	ListFilterOpGte      = "gte"
+	ListFilterOpLt       = "lt"
+	ListFilterOpLte      = "lte"
+	ListFilterOpPrefix   = "prefix"
+	ListFilterOpContains = "contains"
+)
+
+// 一覧取得で指定可能なフィールドの型
+const (
+	ListFieldTypeString = "string"
+	ListFieldTypeTime   = "time"
+)
+
+// ListFieldOps はフィールドの型ごとに利用可能なフィルタ演算子
+var ListFieldOps = map[string][]string{
+	ListFieldTypeString: {ListFilterOpEq, ListFilterOpNe, ListFilterOpPrefix, ListFilterOpContains},
+	ListFieldTypeTime:   {ListFilterOpEq, ListFilterOpNe, ListFilterOpGt, ListFilterOpGte, ListFilterOpLt, ListFilterOpLte},
+}
+
+// ListField は一覧取得でフィルタ・ソートに指定可能なフィールド
+type ListField struct {
+	Name     string
+	Type     string
+	Sortable bool
+}
+
+// DmUserListFields はdm_usersの一覧取得で指定可能なフィールド
+var DmUserListFields = []ListField{
+	{Name: "id", Type: ListFieldTypeString, Sortable: true},
+	{Name: "name", Type: ListFieldTypeString, Sortable: true},
+	{Name: "email", Type: ListFieldTypeString, Sortable: true},
+	{Name: "created_at", Type: ListFieldTypeTime, Sortable: true},
+	{Name: "updated_at", Type: ListFieldTypeTime, Sortable: true},
+}
+
+// DmPostListFields はdm_postsの一覧取得で指定可能なフィールド
+var DmPostListFields = []ListField{
+	{Name: "id", Type: ListFieldTypeString, Sortable: true},
+	{Name: "user_id", Type: ListFieldTypeString, Sortable: true},
+	{Name: "title", Type: ListFieldTypeString, Sortable: true},
+	{Name: "content", Type: ListFieldTypeString, Sortable: false},
+	{Name: "created_at", Type: ListFieldTypeTime, Sortable: true},
+	{Name: "updated_at", Type: ListFieldTypeTime, Sortable: true},
+}
+
+// ListFilter は一覧取得のフィルタ条件
+// Valueは文字列フィールドではstring、日時フィールドではtime.Time
+type ListFilter struct {
+	Field string
+	Op    string
+	Value interface{}
+}
+
+// ListSort は一覧取得の並び順
+type ListSort struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery は一覧取得のフィルタ・ソート条件
+type ListQuery struct {
+	Filters []ListFilter
+	Sorts   []ListSort
+}
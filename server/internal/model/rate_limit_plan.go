@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// RateLimitPlan は呼び出し元（APIキー・ユーザー）ごとのレートリミット・クォータのプランのデータモデル
+// masterグループに配置されるテーブル（シャーディング不要）。各項目の0は無制限
+type RateLimitPlan struct {
+	ID                int64     `json:"id,string" db:"id" gorm:"primaryKey;autoIncrement"`
+	Name              string    `json:"name" db:"name" gorm:"type:varchar(64);not null;uniqueIndex:idx_rate_limit_plans_name"`
+	RequestsPerMinute int       `json:"requests_per_minute" db:"requests_per_minute" gorm:"not null;default:0"`
+	RequestsPerHour   int       `json:"requests_per_hour" db:"requests_per_hour" gorm:"not null;default:0"`
+	RequestsPerDay    int       `json:"requests_per_day" db:"requests_per_day" gorm:"not null;default:0"`     // 1日（UTC）のクォータ
+	RequestsPerMonth  int       `json:"requests_per_month" db:"requests_per_month" gorm:"not null;default:0"` // 1か月（UTC）のクォータ
+	CreatedAt         time.Time `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName はテーブル名を明示的に指定
+func (RateLimitPlan) TableName() string {
+	return "rate_limit_plans"
+}
+
+// RateLimitPlanAssignment はAPIキー・ユーザーへのプランの割り当てのデータモデル
+// principal_typeがapi_keyの場合はAPIキーのjti、userの場合はdm_usersのIDをprincipal_idに指定する
+type RateLimitPlanAssignment struct {
+	ID            int64     `json:"id,string" db:"id" gorm:"primaryKey;autoIncrement"`
+	PrincipalType string    `json:"principal_type" db:"principal_type" gorm:"type:varchar(16);not null;uniqueIndex:idx_rate_limit_plan_assignments_principal"`
+	PrincipalID   string    `json:"principal_id" db:"principal_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_rate_limit_plan_assignments_principal"`
+	PlanName      string    `json:"plan_name" db:"plan_name" gorm:"type:varchar(64);not null"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName はテーブル名を明示的に指定
+func (RateLimitPlanAssignment) TableName() string {
+	return "rate_limit_plan_assignments"
+}
@@ -0,0 +1,25 @@
+package model
+
+import "encoding/json"
+
+// Server-Sent Eventsのトピック（/api/stream/{topic}）
+const (
+	StreamTopicPosts = "posts"
+	StreamTopicNews  = "news"
+)
+
+// Server-Sent Eventsのイベント種別（SSEのeventフィールド）
+const (
+	StreamEventPostCreated   = "post.created"
+	StreamEventNewsPublished = "news.published"
+)
+
+// StreamEvent はServer-Sent Eventsで配信するイベント
+// APIサーバー間ではこの構造体をJSONにしてRedis pub/subで中継する
+type StreamEvent struct {
+	ID     string          `json:"id"`                // UUIDv7（Last-Event-IDでの再開に使用）
+	Topic  string          `json:"topic"`             // StreamTopicPosts, StreamTopicNews
+	Type   string          `json:"type"`              // StreamEventPostCreated など
+	UserID string          `json:"user_id,omitempty"` // 接続ごとのuser_idフィルタの対象（投稿者）
+	Data   json.RawMessage `json:"data"`              // SSEのdataフィールドに出力するJSON
+}
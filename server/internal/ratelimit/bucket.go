@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// BucketStore はトークンバケットの状態を保存するストアのインターフェース
+type BucketStore interface {
+	// Take はバケットにトークンを補充してから1つ取り出し、取り出せたかどうかと残りのトークン数を返す
+	// バケットは容量burstで、1秒にrate個補充する。空のバケットがないキーは満杯として扱う
+	Take(ctx context.Context, key string, rate float64, burst int64, now time.Time) (allowed bool, tokens float64, err error)
+}
+
+// NewBucketStore は設定に応じたストアを作成（ストレージの選択はNewCounterと同じ）
+func NewBucketStore(cfg *config.Config) (BucketStore, error) {
+	storageType := cfg.API.RateLimit.StorageType
+	if storageType == "" {
+		storageType = "auto"
+	}
+
+	hasRedis := len(cfg.CacheServer.Redis.Default.Cluster.Addrs) > 0
+	switch {
+	case storageType == "memory":
+		return NewMemoryBucketStore(), nil
+	case storageType == "redis" && !hasRedis:
+		return nil, fmt.Errorf("redis storage type specified but no redis addresses configured")
+	case hasRedis:
+		return NewRedisBucketStore(cfg), nil
+	default:
+		return NewMemoryBucketStore(), nil
+	}
+}
+
+// refill はlastからnowまでに補充したトークン数を加えた値を返す（容量を超えない）
+func refill(tokens float64, last, now time.Time, rate float64, burst int64) float64 {
+	if now.After(last) {
+		tokens += now.Sub(last).Seconds() * rate
+	}
+	return math.Min(tokens, float64(burst))
+}
+
+// fullAfter は空のバケットが満杯になるまでの時間（キーを残す期間）
+func fullAfter(rate float64, burst int64) time.Duration {
+	return time.Duration(float64(burst) / rate * float64(time.Second))
+}
+
+// takeScript はバケットの補充と取り出しを1回のスクリプトで行う（ARGV: 1秒あたりの補充数、容量、現在時刻(ミリ秒)、キーを残す期間(ミリ秒)）
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) / 1000 * rate)
+  ts = now
+end
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return {allowed, tostring(tokens)}
+`)
+
+// RedisBucketStore はRedisに保存するストア（複数のAPIサーバーで共有する）
+type RedisBucketStore struct {
+	client *redis.ClusterClient
+}
+
+// NewRedisBucketStore は新しいRedisBucketStoreを作成
+// Redisへの接続は遅延接続であり、Redisが起動していない場合は各操作がエラーを返す
+func NewRedisBucketStore(cfg *config.Config) *RedisBucketStore {
+	return &RedisBucketStore{
+		client: redis.NewClusterClient(buildRedisClusterOptions(cfg)),
+	}
+}
+
+// Take はスクリプトでバケットからトークンを1つ取り出す
+func (s *RedisBucketStore) Take(ctx context.Context, key string, rate float64, burst int64, now time.Time) (bool, float64, error) {
+	ttl := fullAfter(rate, burst) + time.Second
+	result, err := takeScript.Run(ctx, s.client, []string{key}, rate, burst, now.UnixMilli(), ttl.Milliseconds()).Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take token bucket: %w", err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected token bucket result: %v", result)
+	}
+	allowed, _ := result[0].(int64)
+	remaining, _ := result[1].(string)
+	tokens, err := strconv.ParseFloat(remaining, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("unexpected token bucket result: %w", err)
+	}
+	return allowed == 1, tokens, nil
+}
+
+// MemoryBucketStore はプロセス内のメモリに保存するストア（Redisがない環境用、APIサーバーごとに数える）
+// 満杯になったバケットはmemorySweepIntervalごとにまとめて削除する
+type MemoryBucketStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucketEntry
+	lastSweep time.Time
+}
+
+type bucketEntry struct {
+	tokens    float64
+	last      time.Time
+	expiresAt time.Time
+}
+
+// NewMemoryBucketStore は新しいMemoryBucketStoreを作成
+func NewMemoryBucketStore() *MemoryBucketStore {
+	return &MemoryBucketStore{
+		buckets: make(map[string]*bucketEntry),
+	}
+}
+
+// Take はバケットからトークンを1つ取り出す
+func (s *MemoryBucketStore) Take(ctx context.Context, key string, rate float64, burst int64, now time.Time) (bool, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.deleteFull(now)
+		s.lastSweep = now
+	}
+
+	entry, ok := s.buckets[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		entry = &bucketEntry{tokens: float64(burst), last: now}
+		s.buckets[key] = entry
+	}
+	entry.tokens = refill(entry.tokens, entry.last, now, rate, burst)
+	entry.last = now
+
+	allowed := entry.tokens >= 1
+	if allowed {
+		entry.tokens--
+	}
+	entry.expiresAt = now.Add(fullAfter(rate, burst))
+	return allowed, entry.tokens, nil
+}
+
+// deleteFull は満杯になったバケットを削除（ロックを保持して呼び出す）
+func (s *MemoryBucketStore) deleteFull(now time.Time) {
+	for key, entry := range s.buckets {
+		if !now.Before(entry.expiresAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// Counter は期間ごとのリクエスト数を数えるカウンターのインターフェース
+type Counter interface {
+	// Incr はキーのカウントを1増やし、増やした後のカウントを返す（キーはexpireAtに削除される）
+	Incr(ctx context.Context, key string, expireAt time.Time) (int64, error)
+	// Get はキーのカウントを返す（キーがない場合は0）
+	Get(ctx context.Context, key string) (int64, error)
+}
+
+// NewCounter は設定に応じたカウンターを作成（ストレージの選択はinitStoreと同じ）
+func NewCounter(cfg *config.Config) (Counter, error) {
+	storageType := cfg.API.RateLimit.StorageType
+	if storageType == "" {
+		storageType = "auto"
+	}
+
+	hasRedis := len(cfg.CacheServer.Redis.Default.Cluster.Addrs) > 0
+	switch {
+	case storageType == "memory":
+		return NewMemoryCounter(), nil
+	case storageType == "redis" && !hasRedis:
+		return nil, fmt.Errorf("redis storage type specified but no redis addresses configured")
+	case hasRedis:
+		return NewRedisCounter(cfg), nil
+	default:
+		return NewMemoryCounter(), nil
+	}
+}
+
+// RedisCounter はRedisで数えるカウンター（複数のAPIサーバーで共有する）
+type RedisCounter struct {
+	client *redis.ClusterClient
+}
+
+// NewRedisCounter は新しいRedisCounterを作成
+// Redisへの接続は遅延接続であり、Redisが起動していない場合は各操作がエラーを返す
+func NewRedisCounter(cfg *config.Config) *RedisCounter {
+	return &RedisCounter{
+		client: redis.NewClusterClient(buildRedisClusterOptions(cfg)),
+	}
+}
+
+// Incr はINCRとEXPIREATでキーのカウントを1増やす
+func (c *RedisCounter) Incr(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireAt(ctx, key, expireAt)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+	return incr.Val(), nil
+}
+
+// Get はキーのカウントを返す
+func (c *RedisCounter) Get(ctx context.Context, key string) (int64, error) {
+	count, err := c.client.Get(ctx, key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rate limit counter: %w", err)
+	}
+	return count, nil
+}
+
+// memorySweepInterval はメモリのカウンター・バケットから期限切れのキーをまとめて削除する間隔
+const memorySweepInterval = time.Minute
+
+// MemoryCounter はプロセス内のメモリで数えるカウンター（Redisがない環境用、APIサーバーごとに数える）
+// 期限切れのキーはmemorySweepIntervalごとにまとめて削除する
+type MemoryCounter struct {
+	mu        sync.Mutex
+	entries   map[string]*counterEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type counterEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// NewMemoryCounter は新しいMemoryCounterを作成
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{
+		entries: make(map[string]*counterEntry),
+		now:     time.Now,
+	}
+}
+
+// Incr はキーのカウントを1増やす（期限切れのキーは0から数え直す）
+func (c *MemoryCounter) Incr(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= memorySweepInterval {
+		c.deleteExpired(now)
+		c.lastSweep = now
+	}
+
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		entry = &counterEntry{expiresAt: expireAt}
+		c.entries[key] = entry
+	}
+	entry.count++
+	return entry.count, nil
+}
+
+// Get はキーのカウントを返す（期限切れのキーは0）
+func (c *MemoryCounter) Get(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return 0, nil
+	}
+	return entry.count, nil
+}
+
+// deleteExpired は期限切れのキーを削除（ロックを保持して呼び出す）
+func (c *MemoryCounter) deleteExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -16,14 +17,18 @@ import (
 )
 
 // NewRateLimitMiddleware はレートリミットミドルウェアを作成
+// ポリシーに一致するルートはポリシーを、それ以外のルートはrequests_per_minute/hourの制限を適用する（どちらもIPアドレス単位）
+// ポリシー・除外するルートの設定が不正な場合はエラーを返す
 func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 	// レートリミットが無効な場合は、常に許可するミドルウェアを返す
 	if !cfg.API.RateLimit.Enabled {
-		return func(next echo.HandlerFunc) echo.HandlerFunc {
-			return func(c echo.Context) error {
-				return next(c)
-			}
-		}, nil
+		return allowAll, nil
+	}
+
+	// レートリミットを適用しないルート
+	exempt, err := newRouteMatcher(cfg.API.RateLimit.ExemptRoutes, cfg.API.Versions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit exempt_routes: %w", err)
 	}
 
 	// 分あたりのレートリミット設定
@@ -37,11 +42,26 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 	if err != nil {
 		// fail-open方式: エラー時はログに記録し、リクエストを許可
 		logrus.WithError(err).Error("failed to initialize rate limit store, allowing all requests")
-		return func(next echo.HandlerFunc) echo.HandlerFunc {
-			return func(c echo.Context) error {
-				return next(c)
-			}
-		}, nil
+		return allowAll, nil
+	}
+
+	// ルートごとのポリシー（ストレージはrequests_per_minute/hourと同じ方法で選択する）
+	var policies []*Policy
+	if len(cfg.API.RateLimit.Policies) > 0 {
+		counter, err := NewCounter(cfg)
+		if err != nil {
+			logrus.WithError(err).Error("failed to initialize rate limit store, allowing all requests")
+			return allowAll, nil
+		}
+		buckets, err := NewBucketStore(cfg)
+		if err != nil {
+			logrus.WithError(err).Error("failed to initialize rate limit store, allowing all requests")
+			return allowAll, nil
+		}
+		policies, err = newPolicies(cfg, counter, buckets)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 分制限limiterインスタンスの作成
@@ -58,11 +78,7 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 		hourStore, err := initStore(cfg, "ratelimit_hour")
 		if err != nil {
 			logrus.WithError(err).Error("failed to initialize hourly rate limit store, allowing all requests")
-			return func(next echo.HandlerFunc) echo.HandlerFunc {
-				return func(c echo.Context) error {
-					return next(c)
-				}
-			}, nil
+			return allowAll, nil
 		}
 
 		hourLimiter = limiter.New(hourStore, hourRate)
@@ -71,6 +87,13 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 	// ミドルウェア関数の返却
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			method, requestPath := c.Request().Method, c.Request().URL.Path
+
+			// 除外するルートは制限しない
+			if exempt.match(method, requestPath) {
+				return next(c)
+			}
+
 			// IPアドレスの取得
 			ip := c.RealIP()
 			if ip == "" {
@@ -78,6 +101,25 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 				return next(c)
 			}
 
+			// ポリシーに一致するルートはポリシーのみ適用
+			if policy := findPolicy(policies, method, requestPath); policy != nil {
+				decision, err := policy.Take(c.Request().Context(), ip, time.Now())
+				if err != nil {
+					// fail-open方式: エラー時はログに記録し、リクエストを許可
+					logrus.WithError(err).WithFields(logrus.Fields{"ip": ip, "policy": policy.name}).Warn("rate limit policy check failed, allowing request")
+					return next(c)
+				}
+
+				// RateLimit-Policy・RateLimitヘッダーの設定
+				c.Response().Header().Set("RateLimit-Policy", policy.PolicyHeader())
+				c.Response().Header().Set("RateLimit", policy.RateLimitHeader(decision))
+
+				if !decision.Allowed {
+					return tooManyRequests(c, decision.RetryAfter)
+				}
+				return next(c)
+			}
+
 			// 分制限のレートリミットチェック
 			minuteContext, err := minuteLimiter.Get(c.Request().Context(), ip)
 			if err != nil {
@@ -91,12 +133,14 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 			c.Response().Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", minuteContext.Remaining))
 			c.Response().Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", minuteContext.Reset))
 
+			// RateLimit-Policy・RateLimitヘッダーの設定（分制限を"minute"ポリシーとして表す）
+			policyHeader, rateLimitHeader := limiterHeaders("minute", time.Minute, minuteContext)
+			c.Response().Header().Set("RateLimit-Policy", policyHeader)
+			c.Response().Header().Set("RateLimit", rateLimitHeader)
+
 			// 分制限超過時
 			if minuteContext.Reached {
-				return c.JSON(http.StatusTooManyRequests, map[string]interface{}{
-					"code":    429,
-					"message": "Too Many Requests",
-				})
+				return tooManyRequests(c, time.Until(time.Unix(minuteContext.Reset, 0)))
 			}
 
 			// 時間制限のレートリミットチェック（設定されている場合のみ）
@@ -113,12 +157,14 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 				c.Response().Header().Set("X-RateLimit-Hour-Remaining", fmt.Sprintf("%d", hourContext.Remaining))
 				c.Response().Header().Set("X-RateLimit-Hour-Reset", fmt.Sprintf("%d", hourContext.Reset))
 
+				// 時間制限を"hour"ポリシーとして追加
+				hourPolicyHeader, hourRateLimitHeader := limiterHeaders("hour", time.Hour, hourContext)
+				c.Response().Header().Set("RateLimit-Policy", policyHeader+", "+hourPolicyHeader)
+				c.Response().Header().Set("RateLimit", rateLimitHeader+", "+hourRateLimitHeader)
+
 				// 時間制限超過時
 				if hourContext.Reached {
-					return c.JSON(http.StatusTooManyRequests, map[string]interface{}{
-						"code":    429,
-						"message": "Too Many Requests",
-					})
+					return tooManyRequests(c, time.Until(time.Unix(hourContext.Reset, 0)))
 				}
 			}
 
@@ -128,6 +174,34 @@ func NewRateLimitMiddleware(cfg *config.Config) (echo.MiddlewareFunc, error) {
 	}, nil
 }
 
+// limiterHeaders はrequests_per_minute/hourの制限をポリシーと同じ形式のRateLimit-Policy・RateLimitヘッダーの値にする
+func limiterHeaders(name string, period time.Duration, lc limiter.Context) (policy string, rateLimit string) {
+	policy = fmt.Sprintf("%q;q=%d;w=%d", name, lc.Limit, int64(period.Seconds()))
+	rateLimit = fmt.Sprintf("%q;r=%d;t=%d", name, lc.Remaining, seconds(time.Until(time.Unix(lc.Reset, 0))))
+	return policy, rateLimit
+}
+
+// allowAll は常に許可するミドルウェア
+func allowAll(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return next(c)
+	}
+}
+
+// tooManyRequests はRetry-Afterヘッダーを付けて429を返す
+func tooManyRequests(c echo.Context, retryAfter time.Duration) error {
+	c.Response().Header().Set("Retry-After", retryAfterHeader(retryAfter))
+	return c.JSON(http.StatusTooManyRequests, map[string]interface{}{
+		"code":    429,
+		"message": "Too Many Requests",
+	})
+}
+
+// retryAfterHeader はRetry-Afterヘッダーの値（切り上げた秒数、最小1秒）
+func retryAfterHeader(retryAfter time.Duration) string {
+	return strconv.FormatInt(max(seconds(retryAfter), 1), 10)
+}
+
 // initStore は環境に応じたストレージを初期化
 func initStore(cfg *config.Config, prefix string) (limiter.Store, error) {
 	// StorageType設定を取得（デフォルトは"auto"）
@@ -4,9 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/taku-o/go-webdb-template/internal/config"
 )
 
@@ -132,6 +134,7 @@ func TestNewRateLimitMiddleware_RateLimitExceeded(t *testing.T) {
 			// 3回目はレートリミット超過
 			assert.NoError(t, err) // ハンドラー自体はエラーを返さない
 			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.NotEmpty(t, rec.Header().Get("Retry-After"))
 		}
 	}
 }
@@ -229,6 +232,10 @@ func TestNewRateLimitMiddleware_HourlyRateLimitEnabled(t *testing.T) {
 	assert.NotEmpty(t, rec.Header().Get("X-RateLimit-Hour-Limit"))
 	assert.NotEmpty(t, rec.Header().Get("X-RateLimit-Hour-Remaining"))
 	assert.NotEmpty(t, rec.Header().Get("X-RateLimit-Hour-Reset"))
+
+	// 分制限・時間制限をポリシーとして表すRateLimit-Policy・RateLimitヘッダーが付与されていることを確認
+	assert.Equal(t, `"minute";q=60;w=60, "hour";q=1000;w=3600`, rec.Header().Get("RateLimit-Policy"))
+	assert.Regexp(t, `^"minute";r=59;t=\d+, "hour";r=999;t=\d+$`, rec.Header().Get("RateLimit"))
 }
 
 // TestNewRateLimitMiddleware_HourlyRateLimitExceeded は時間制限超過時のテスト
@@ -329,3 +336,102 @@ func TestNewRateLimitMiddleware_HourlyRateLimitNotConfigured(t *testing.T) {
 	// X-RateLimit-Hour-*ヘッダーは付与されないことを確認
 	assert.Empty(t, rec.Header().Get("X-RateLimit-Hour-Limit"))
 }
+
+// TestNewRateLimitMiddleware_Policies はルートごとのポリシーと除外するルートのテスト
+func TestNewRateLimitMiddleware_Policies(t *testing.T) {
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Versions: []config.APIVersionConfig{{Name: "v1"}, {Name: "v2"}},
+			RateLimit: config.RateLimitConfig{
+				Enabled:           true,
+				RequestsPerMinute: 100,
+				StorageType:       "memory",
+				Policies: []config.RateLimitPolicyConfig{
+					{Name: "email", Routes: []string{"POST /api/email/send"}, Algorithm: AlgorithmTokenBucket, Limit: 60, Period: time.Hour, Burst: 2},
+					{Name: "uploads", Routes: []string{"/api/upload/dm_movie/*"}, Limit: 1},
+				},
+				ExemptRoutes: []string{"GET /health"},
+			},
+		},
+	}
+
+	middleware, err := NewRateLimitMiddleware(cfg)
+	require.NoError(t, err)
+	handler := middleware(func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	e := echo.New()
+	request := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Real-IP", "192.168.1.30")
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+		return rec
+	}
+
+	// バージョンなし・バージョンごとのパスで同じポリシーを数える
+	rec := request(http.MethodPost, "/api/email/send")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"email";q=60;w=3600;burst=2`, rec.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, `"email";r=1;t=60`, rec.Header().Get("RateLimit"))
+	assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+
+	rec = request(http.MethodPost, "/api/v2/email/send")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = request(http.MethodPost, "/api/v1/email/send")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+	// ポリシーに一致しないルートはグローバルの制限
+	rec = request(http.MethodGet, "/api/dm-users")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "100", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, `"minute";q=100;w=60`, rec.Header().Get("RateLimit-Policy"))
+
+	rec = request(http.MethodPatch, "/api/upload/dm_movie/abc")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = request(http.MethodPatch, "/api/upload/dm_movie/abc")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Contains(t, rec.Header().Get("RateLimit-Policy"), `"uploads"`)
+
+	// 除外するルートは制限しない
+	rec = request(http.MethodGet, "/health")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+}
+
+// TestNewRateLimitMiddleware_InvalidPolicies はポリシーの設定が不正な場合のテスト
+func TestNewRateLimitMiddleware_InvalidPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []config.RateLimitPolicyConfig
+		exempt   []string
+	}{
+		{"名前なし", []config.RateLimitPolicyConfig{{Routes: []string{"/api/a"}, Limit: 1}}, nil},
+		{"重複した名前", []config.RateLimitPolicyConfig{{Name: "a", Routes: []string{"/api/a"}, Limit: 1}, {Name: "a", Routes: []string{"/api/b"}, Limit: 1}}, nil},
+		{"ルートなし", []config.RateLimitPolicyConfig{{Name: "a", Limit: 1}}, nil},
+		{"上限なし", []config.RateLimitPolicyConfig{{Name: "a", Routes: []string{"/api/a"}}}, nil},
+		{"不明なアルゴリズム", []config.RateLimitPolicyConfig{{Name: "a", Routes: []string{"/api/a"}, Limit: 1, Algorithm: "leaky_bucket"}}, nil},
+		{"token_bucket以外のburst", []config.RateLimitPolicyConfig{{Name: "a", Routes: []string{"/api/a"}, Limit: 1, Burst: 2}}, nil},
+		{"不正なルート", []config.RateLimitPolicyConfig{{Name: "a", Routes: []string{"api/a"}, Limit: 1}}, nil},
+		{"不正な除外するルート", nil, []string{"GET /api/[a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				API: config.APIConfig{
+					RateLimit: config.RateLimitConfig{
+						Enabled:           true,
+						RequestsPerMinute: 60,
+						StorageType:       "memory",
+						Policies:          tt.policies,
+						ExemptRoutes:      tt.exempt,
+					},
+				},
+			}
+			_, err := NewRateLimitMiddleware(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// ポリシーのアルゴリズム
+const (
+	AlgorithmFixedWindow   = "fixed_window"   // 期間の区切りごとに数える
+	AlgorithmSlidingWindow = "sliding_window" // 直前の期間のカウントを経過時間で按分して数える
+	AlgorithmTokenBucket   = "token_bucket"   // 容量burstのバケットに期間あたりlimit個のトークンを補充する
+)
+
+// policyKeyPrefix はポリシーのカウンター・バケットのキーのプレフィックス
+const policyKeyPrefix = "ratelimit_policy:"
+
+// Decision はポリシーでリクエストを許可するかどうかの判定結果
+type Decision struct {
+	Allowed    bool
+	Remaining  int64
+	Reset      time.Duration // 上限まで使える状態に戻るまでの時間
+	RetryAfter time.Duration // 拒否した場合に再試行できるまでの時間
+}
+
+// algorithm はポリシーのアルゴリズムのインターフェース
+type algorithm interface {
+	take(ctx context.Context, key string, now time.Time) (Decision, error)
+}
+
+// Policy はルートごとのレートリミットのポリシー
+type Policy struct {
+	name      string
+	routes    *routeMatcher
+	limit     int64
+	period    time.Duration
+	burst     int64 // token_bucketの場合のみ
+	algorithm algorithm
+}
+
+// newPolicies は設定からポリシーの一覧を作成（設定の順に判定する）
+func newPolicies(cfg *config.Config, counter Counter, buckets BucketStore) ([]*Policy, error) {
+	names := make(map[string]bool)
+	var policies []*Policy
+	for _, pc := range cfg.API.RateLimit.Policies {
+		if pc.Name == "" {
+			return nil, fmt.Errorf("rate limit policy name is required")
+		}
+		if names[pc.Name] {
+			return nil, fmt.Errorf("duplicate rate limit policy %q", pc.Name)
+		}
+		names[pc.Name] = true
+		if len(pc.Routes) == 0 {
+			return nil, fmt.Errorf("rate limit policy %q has no routes", pc.Name)
+		}
+		if pc.Limit <= 0 {
+			return nil, fmt.Errorf("rate limit policy %q: limit must be positive", pc.Name)
+		}
+		routes, err := newRouteMatcher(pc.Routes, cfg.API.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit policy %q: %w", pc.Name, err)
+		}
+
+		p := &Policy{
+			name:   pc.Name,
+			routes: routes,
+			limit:  int64(pc.Limit),
+			period: pc.Period,
+		}
+		if p.period <= 0 {
+			p.period = time.Minute
+		}
+		switch pc.Algorithm {
+		case "", AlgorithmFixedWindow:
+			p.algorithm = &fixedWindow{counter: counter, limit: p.limit, period: p.period}
+		case AlgorithmSlidingWindow:
+			p.algorithm = &slidingWindow{counter: counter, limit: p.limit, period: p.period}
+		case AlgorithmTokenBucket:
+			p.burst = int64(pc.Burst)
+			if p.burst <= 0 {
+				p.burst = p.limit
+			}
+			p.algorithm = &tokenBucket{buckets: buckets, rate: float64(p.limit) / p.period.Seconds(), burst: p.burst}
+		default:
+			return nil, fmt.Errorf("rate limit policy %q: unknown algorithm %q", pc.Name, pc.Algorithm)
+		}
+		if pc.Burst > 0 && pc.Algorithm != AlgorithmTokenBucket {
+			return nil, fmt.Errorf("rate limit policy %q: burst is only for %s", pc.Name, AlgorithmTokenBucket)
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// findPolicy はリクエストに一致する最初のポリシーを返す（一致しない場合はnil）
+func findPolicy(policies []*Policy, method, requestPath string) *Policy {
+	for _, p := range policies {
+		if p.routes.match(method, requestPath) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Take はクライアント（IPアドレス）のリクエストを数えて判定する
+func (p *Policy) Take(ctx context.Context, client string, now time.Time) (Decision, error) {
+	return p.algorithm.take(ctx, policyKeyPrefix+p.name+":"+client, now)
+}
+
+// PolicyHeader はRateLimit-Policyヘッダーの値（"name";q=limit;w=期間の秒数。token_bucketはburstも付ける）
+func (p *Policy) PolicyHeader() string {
+	value := fmt.Sprintf("%q;q=%d;w=%d", p.name, p.limit, int64(p.period.Seconds()))
+	if p.burst > 0 {
+		value += ";burst=" + strconv.FormatInt(p.burst, 10)
+	}
+	return value
+}
+
+// RateLimitHeader はRateLimitヘッダーの値（"name";r=残り;t=リセットまでの秒数）
+func (p *Policy) RateLimitHeader(d Decision) string {
+	return fmt.Sprintf("%q;r=%d;t=%d", p.name, d.Remaining, seconds(d.Reset))
+}
+
+// seconds は時間を切り上げた秒数を返す（ヘッダー用）
+func seconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// fixedWindow は期間の区切りごとに数えるアルゴリズム
+type fixedWindow struct {
+	counter Counter
+	limit   int64
+	period  time.Duration
+}
+
+func (a *fixedWindow) take(ctx context.Context, key string, now time.Time) (Decision, error) {
+	start := now.Truncate(a.period)
+	end := start.Add(a.period)
+	count, err := a.counter.Incr(ctx, key+":"+strconv.FormatInt(start.Unix(), 10), end)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	d := Decision{
+		Allowed:   count <= a.limit,
+		Remaining: max(a.limit-count, 0),
+		Reset:     end.Sub(now),
+	}
+	if !d.Allowed {
+		d.RetryAfter = d.Reset
+	}
+	return d, nil
+}
+
+// slidingWindow は直前の期間のカウントを経過時間で按分して数えるアルゴリズム
+// 推定値 = 直前の期間のカウント × (1 - 現在の期間の経過割合) + 現在の期間のカウント
+type slidingWindow struct {
+	counter Counter
+	limit   int64
+	period  time.Duration
+}
+
+func (a *slidingWindow) take(ctx context.Context, key string, now time.Time) (Decision, error) {
+	start := now.Truncate(a.period)
+	end := start.Add(a.period)
+	// 現在の期間のカウントは次の期間で直前の期間として使うため、期間の2倍残す
+	current, err := a.counter.Incr(ctx, key+":"+strconv.FormatInt(start.Unix(), 10), end.Add(a.period))
+	if err != nil {
+		return Decision{}, err
+	}
+	previous, err := a.counter.Get(ctx, key+":"+strconv.FormatInt(start.Add(-a.period).Unix(), 10))
+	if err != nil {
+		return Decision{}, err
+	}
+
+	elapsed := float64(now.Sub(start)) / float64(a.period)
+	weighted := float64(previous) * (1 - elapsed)
+	estimated := weighted + float64(current)
+
+	d := Decision{
+		Allowed:   estimated <= float64(a.limit),
+		Remaining: max(a.limit-int64(math.Ceil(estimated)), 0),
+		Reset:     end.Sub(now),
+	}
+	if !d.Allowed {
+		// 直前の期間の按分が減って上限を下回るまで待つ（現在の期間だけで上限を超えている場合は期間の終わりまで）
+		d.RetryAfter = end.Sub(now)
+		if current < a.limit && previous > 0 {
+			// previous × (1 - t) + current ≤ limit となる経過割合t
+			t := 1 - float64(a.limit-current)/float64(previous)
+			d.RetryAfter = start.Add(time.Duration(t * float64(a.period))).Sub(now)
+		}
+	}
+	return d, nil
+}
+
+// tokenBucket は容量burstのバケットに期間あたりlimit個のトークンを補充するアルゴリズム
+type tokenBucket struct {
+	buckets BucketStore
+	rate    float64 // 1秒あたりの補充数
+	burst   int64
+}
+
+func (a *tokenBucket) take(ctx context.Context, key string, now time.Time) (Decision, error) {
+	allowed, tokens, err := a.buckets.Take(ctx, key, a.rate, a.burst, now)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	d := Decision{
+		Allowed:   allowed,
+		Remaining: int64(math.Floor(tokens)),
+		Reset:     time.Duration((float64(a.burst) - tokens) / a.rate * float64(time.Second)),
+	}
+	if !allowed {
+		d.RetryAfter = time.Duration((1 - tokens) / a.rate * float64(time.Second))
+	}
+	return d, nil
+}
@@ -0,0 +1,151 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func TestRouteMatcher(t *testing.T) {
+	m, err := newRouteMatcher([]string{"POST /api/email/send", "/api/dm-users/*", "get /health"}, []config.APIVersionConfig{{Name: "v1"}, {Name: "v2"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{"POST", "/api/email/send", true},
+		{"POST", "/api/v2/email/send", true},
+		{"GET", "/api/email/send", false},
+		{"DELETE", "/api/v1/dm-users/123", true},
+		{"GET", "/api/dm-users", false},
+		{"GET", "/api/dm-users/123/posts", false},
+		{"GET", "/api/v3/dm-users/123", false},
+		{"GET", "/health", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, m.match(tt.method, tt.path), "%s %s", tt.method, tt.path)
+	}
+
+	_, err = newRouteMatcher([]string{"POST /api/a extra"}, nil)
+	assert.Error(t, err)
+}
+
+func TestFixedWindow(t *testing.T) {
+	counter := NewMemoryCounter()
+	now := time.Date(2026, 10, 19, 12, 30, 15, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+	a := &fixedWindow{counter: counter, limit: 2, period: time.Minute}
+	ctx := context.Background()
+
+	d, err := a.take(ctx, "k", now)
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: true, Remaining: 1, Reset: 45 * time.Second}, d)
+	_, _ = a.take(ctx, "k", now)
+	d, _ = a.take(ctx, "k", now.Add(15*time.Second))
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 30*time.Second, d.RetryAfter)
+
+	// 次の期間は数え直す
+	counter.now = func() time.Time { return now.Add(45 * time.Second) }
+	d, _ = a.take(ctx, "k", now.Add(45*time.Second))
+	assert.True(t, d.Allowed)
+	assert.Equal(t, int64(1), d.Remaining)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	counter := NewMemoryCounter()
+	now := time.Date(2026, 10, 19, 12, 30, 45, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+	a := &slidingWindow{counter: counter, limit: 4, period: time.Minute}
+	ctx := context.Background()
+
+	// 直前の期間（12:29）に4回
+	for i := 0; i < 4; i++ {
+		d, err := a.take(ctx, "k", now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+	}
+
+	// 12:30:45は直前の期間の25%（1回）と数える
+	d, err := a.take(ctx, "k", now)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, int64(2), d.Remaining)
+	_, _ = a.take(ctx, "k", now)
+	_, _ = a.take(ctx, "k", now)
+	d, _ = a.take(ctx, "k", now)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 15*time.Second, d.RetryAfter)
+}
+
+func TestTokenBucket(t *testing.T) {
+	a := &tokenBucket{buckets: NewMemoryBucketStore(), rate: 1, burst: 3}
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+
+	// 容量まで連続して許可する
+	for i := 2; i >= 0; i-- {
+		d, err := a.take(ctx, "k", now)
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, int64(i), d.Remaining)
+	}
+	d, _ := a.take(ctx, "k", now)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, time.Second, d.RetryAfter)
+	assert.Equal(t, 3*time.Second, d.Reset)
+
+	// 1秒に1つ補充する
+	d, _ = a.take(ctx, "k", now.Add(1500*time.Millisecond))
+	assert.True(t, d.Allowed)
+	assert.Equal(t, int64(0), d.Remaining)
+
+	// 別のキーは満杯から
+	d, _ = a.take(ctx, "other", now)
+	assert.Equal(t, int64(2), d.Remaining)
+}
+
+func TestMemoryBucketStore_Sweep(t *testing.T) {
+	store := NewMemoryBucketStore()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+
+	_, _, _ = store.Take(ctx, "a", 1, 3, now)
+	// 満杯になったバケットは次の削除までは残る
+	_, _, _ = store.Take(ctx, "b", 1, 3, now.Add(30*time.Second))
+	assert.Len(t, store.buckets, 2)
+
+	// 残っていても満杯として扱う
+	_, tokens, _ := store.Take(ctx, "a", 1, 3, now.Add(40*time.Second))
+	assert.Equal(t, float64(2), tokens)
+
+	_, _, _ = store.Take(ctx, "c", 1, 3, now.Add(memorySweepInterval+time.Minute))
+	assert.Len(t, store.buckets, 1)
+	assert.Contains(t, store.buckets, "c")
+}
+
+func TestPolicy_Headers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.API.RateLimit.Policies = []config.RateLimitPolicyConfig{
+		{Name: "search", Routes: []string{"/api/search"}, Algorithm: AlgorithmSlidingWindow, Limit: 30},
+		{Name: "burst", Routes: []string{"/api/*"}, Algorithm: AlgorithmTokenBucket, Limit: 10, Period: 10 * time.Second},
+	}
+	policies, err := newPolicies(cfg, NewMemoryCounter(), NewMemoryBucketStore())
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+
+	// 最初に一致したポリシーを使う
+	assert.Equal(t, "search", findPolicy(policies, "GET", "/api/search").name)
+	assert.Equal(t, "burst", findPolicy(policies, "GET", "/api/other").name)
+	assert.Nil(t, findPolicy(policies, "GET", "/health"))
+
+	assert.Equal(t, `"search";q=30;w=60`, policies[0].PolicyHeader())
+	assert.Equal(t, `"burst";q=10;w=10;burst=10`, policies[1].PolicyHeader())
+	assert.Equal(t, `"search";r=5;t=2`, policies[0].RateLimitHeader(Decision{Remaining: 5, Reset: 1500 * time.Millisecond}))
+}
@@ -0,0 +1,348 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PlanSource は呼び出し元に適用するプランを取得するインターフェース（service.RateLimitPlanServiceが実装する）
+// 適用するプランがない場合はnil（無制限）を返す
+type PlanSource interface {
+	GetRateLimitPlan(ctx context.Context, principalType, principalID string) (*model.RateLimitPlan, error)
+}
+
+// window はレートリミット・クォータを数える期間
+type window struct {
+	name    string // カウンターのキーに使う名前
+	header  string // レスポンスヘッダーのプレフィックス
+	message string // 上限に達した場合のエラーメッセージ
+	limit   func(plan *model.RateLimitPlan) int
+	bounds  func(now time.Time) (start, end time.Time)
+}
+
+// windows は確認する順の期間の一覧（日・月はUTCの暦で区切る）
+var windows = []window{
+	{
+		name:    "minute",
+		header:  "X-RateLimit",
+		message: "Too Many Requests",
+		limit:   func(plan *model.RateLimitPlan) int { return plan.RequestsPerMinute },
+		bounds:  truncateBounds(time.Minute),
+	},
+	{
+		name:    "hour",
+		header:  "X-RateLimit-Hour",
+		message: "Too Many Requests",
+		limit:   func(plan *model.RateLimitPlan) int { return plan.RequestsPerHour },
+		bounds:  truncateBounds(time.Hour),
+	},
+	{
+		name:    "day",
+		header:  "X-Quota-Daily",
+		message: "Quota exceeded",
+		limit:   func(plan *model.RateLimitPlan) int { return plan.RequestsPerDay },
+		bounds: func(now time.Time) (time.Time, time.Time) {
+			y, m, d := now.UTC().Date()
+			start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(0, 0, 1)
+		},
+	},
+	{
+		name:    "month",
+		header:  "X-Quota-Monthly",
+		message: "Quota exceeded",
+		limit:   func(plan *model.RateLimitPlan) int { return plan.RequestsPerMonth },
+		bounds: func(now time.Time) (time.Time, time.Time) {
+			y, m, _ := now.UTC().Date()
+			start := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(0, 1, 0)
+		},
+	},
+}
+
+// truncateBounds は固定長の期間の区切りを返す関数を作成
+func truncateBounds(d time.Duration) func(now time.Time) (time.Time, time.Time) {
+	return func(now time.Time) (time.Time, time.Time) {
+		start := now.UTC().Truncate(d)
+		return start, start.Add(d)
+	}
+}
+
+// WindowStatus は期間ごとのレートリミット・クォータの状態
+type WindowStatus struct {
+	Header    string // レスポンスヘッダーのプレフィックス
+	Message   string // 上限に達した場合のエラーメッセージ
+	Limit     int64
+	Remaining int64
+	Reset     int64 // 期間が終わる日時（Unix時間）
+	Reached   bool
+}
+
+// setHeaders は状態をレスポンスヘッダー（{Header}-Limit / -Remaining / -Reset）に設定
+func (s *WindowStatus) setHeaders(set func(key, value string)) {
+	set(s.Header+"-Limit", strconv.FormatInt(s.Limit, 10))
+	set(s.Header+"-Remaining", strconv.FormatInt(s.Remaining, 10))
+	set(s.Header+"-Reset", strconv.FormatInt(s.Reset, 10))
+}
+
+// retryAfter は期間が終わるまでの時間のRetry-Afterヘッダーの値
+func (s *WindowStatus) retryAfter() string {
+	return retryAfterHeader(time.Until(time.Unix(s.Reset, 0)))
+}
+
+// PrincipalLimiter は呼び出し元（APIキー・ユーザー）ごとのレートリミット・クォータ
+// 上限はプランで決まり、プランは呼び出し元ごとにCacheTTLの間キャッシュする
+type PrincipalLimiter struct {
+	source    PlanSource
+	counter   Counter
+	cacheTTL  time.Duration
+	keyPrefix string
+	exempt    *routeMatcher
+	now       func() time.Time
+
+	mu    sync.Mutex
+	plans map[string]cachedPlan
+}
+
+type cachedPlan struct {
+	plan      *model.RateLimitPlan
+	expiresAt time.Time
+}
+
+// NewPrincipalLimiter は新しいPrincipalLimiterを作成
+// レートリミットを適用しないルート（exempt_routes）は呼び出し元ごとにも数えない
+func NewPrincipalLimiter(cfg *config.Config, source PlanSource) (*PrincipalLimiter, error) {
+	exempt, err := newRouteMatcher(cfg.API.RateLimit.ExemptRoutes, cfg.API.Versions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit exempt_routes: %w", err)
+	}
+	counter, err := NewCounter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	l := newPrincipalLimiter(&cfg.API.RateLimit.Principal, source, counter)
+	l.exempt = exempt
+	return l, nil
+}
+
+func newPrincipalLimiter(cfg *config.PrincipalRateLimitConfig, source PlanSource, counter Counter) *PrincipalLimiter {
+	return &PrincipalLimiter{
+		source:    source,
+		counter:   counter,
+		cacheTTL:  cfg.CacheTTL,
+		keyPrefix: cfg.KeyPrefix,
+		exempt:    &routeMatcher{},
+		now:       time.Now,
+		plans:     make(map[string]cachedPlan),
+	}
+}
+
+// Check は呼び出し元のリクエストを数え、プランで上限がある期間の状態を返す
+// 上限に達した期間があればそこで数えるのをやめ、最後の状態のReachedがtrueになる
+func (l *PrincipalLimiter) Check(ctx context.Context, principal *auth.Principal) ([]WindowStatus, error) {
+	principalType, principalID, key := principalKey(principal)
+	plan, err := l.plan(ctx, principalType, principalID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, nil
+	}
+
+	now := l.now()
+	var statuses []WindowStatus
+	for _, w := range windows {
+		limit := int64(w.limit(plan))
+		if limit <= 0 {
+			continue
+		}
+		start, end := w.bounds(now)
+		count, err := l.counter.Incr(ctx, fmt.Sprintf("%s%s:%s:%d", l.keyPrefix, key, w.name, start.Unix()), end)
+		if err != nil {
+			return statuses, err
+		}
+		status := WindowStatus{
+			Header:    w.header,
+			Message:   w.message,
+			Limit:     limit,
+			Remaining: max(limit-count, 0),
+			Reset:     end.Unix(),
+			Reached:   count > limit,
+		}
+		statuses = append(statuses, status)
+		if status.Reached {
+			break
+		}
+	}
+	return statuses, nil
+}
+
+// plan は呼び出し元に適用するプランを取得（キャッシュがあればキャッシュを使う）
+func (l *PrincipalLimiter) plan(ctx context.Context, principalType, principalID string) (*model.RateLimitPlan, error) {
+	cacheKey := principalType + ":" + principalID
+	now := l.now()
+
+	l.mu.Lock()
+	cached, ok := l.plans[cacheKey]
+	l.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.plan, nil
+	}
+
+	plan, err := l.source.GetRateLimitPlan(ctx, principalType, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limit plan: %w", err)
+	}
+
+	l.mu.Lock()
+	for key, entry := range l.plans {
+		if !now.Before(entry.expiresAt) {
+			delete(l.plans, key)
+		}
+	}
+	l.plans[cacheKey] = cachedPlan{plan: plan, expiresAt: now.Add(l.cacheTTL)}
+	l.mu.Unlock()
+	return plan, nil
+}
+
+// principalKey は呼び出し元の種類（rate_limit_plan_assignments.principal_type）・プランの割り当てに使うID・カウンターのキーを返す
+// Public APIキーはjti（jtiがない場合はsub）、ユーザーは紐づくdm_usersのID（紐づいていない場合はissとsub）で数える
+// プランの割り当てに使うIDが空の場合はデフォルトのプランを適用する
+func principalKey(principal *auth.Principal) (principalType, principalID, key string) {
+	principalType = string(principal.Type)
+	if principal.Type == auth.PrincipalTypeAPIKey {
+		if principal.KeyID != "" {
+			return principalType, principal.KeyID, "key:" + principal.KeyID
+		}
+		return principalType, "", "key:" + principal.Subject
+	}
+	if principal.UserID != "" {
+		return principalType, principal.UserID, "user:" + principal.UserID
+	}
+	return principalType, "", "sub:" + principal.Issuer + "|" + principal.Subject
+}
+
+// limit は呼び出し元のリクエストを数えて各期間の状態をsetHeaderで設定し、上限に達した期間の状態を返す（達していない場合はnil）
+// プランの取得やカウンターでエラーが発生した場合はリクエストを許可する（fail-open方式）
+func (l *PrincipalLimiter) limit(ctx context.Context, principal *auth.Principal, setHeader func(key, value string)) *WindowStatus {
+	statuses, err := l.Check(ctx, principal)
+	if err != nil {
+		logrus.WithError(err).WithField("subject", principal.Subject).Warn("principal rate limit check failed, allowing request")
+	}
+	for i := range statuses {
+		statuses[i].setHeaders(setHeader)
+	}
+	if n := len(statuses); n > 0 && statuses[n-1].Reached {
+		return &statuses[n-1]
+	}
+	return nil
+}
+
+// HumaMiddleware は認証済みの呼び出し元ごとにレートリミット・クォータを適用するHumaミドルウェアを作成
+// 認証ミドルウェアの後に適用する。X-RateLimit-*はIPアドレスごとの値を上書きする
+func (l *PrincipalLimiter) HumaMiddleware() func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		principal, ok := auth.GetPrincipal(ctx.Context())
+		if !ok || l.exempt.match(ctx.Method(), ctx.URL().Path) {
+			next(ctx)
+			return
+		}
+
+		if reached := l.limit(ctx.Context(), principal, ctx.SetHeader); reached != nil {
+			ctx.SetHeader("Retry-After", reached.retryAfter())
+			ctx.SetStatus(http.StatusTooManyRequests)
+			ctx.SetHeader("Content-Type", "application/json")
+			json.NewEncoder(ctx.BodyWriter()).Encode(map[string]interface{}{
+				"code":    http.StatusTooManyRequests,
+				"message": reached.Message,
+			})
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// EchoMiddleware は認証済みの呼び出し元ごとにレートリミット・クォータを適用するEchoミドルウェアを作成
+// Echoに直接登録するルート（TUSアップロード、Server-Sent Events）でauth.NewEchoAuthMiddlewareの後に適用する
+func (l *PrincipalLimiter) EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			principal, ok := auth.GetPrincipal(req.Context())
+			if !ok || l.exempt.match(req.Method, req.URL.Path) {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			if reached := l.limit(req.Context(), principal, header.Set); reached != nil {
+				header.Set("Retry-After", reached.retryAfter())
+				return c.JSON(http.StatusTooManyRequests, map[string]interface{}{
+					"code":    http.StatusTooManyRequests,
+					"message": reached.Message,
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// GRPCInterceptors は認証済みの呼び出し元ごとにレートリミット・クォータを適用するgRPCのインターセプター（unary, stream）を作成
+// auth.NewGRPCAuthInterceptorsの後に適用する。状態はヘッダーのメタデータ（小文字のx-ratelimit-*など）で返し、
+// 上限に達した場合はRESOURCE_EXHAUSTEDを返す
+func (l *PrincipalLimiter) GRPCInterceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	check := func(ctx context.Context, setHeader func(metadata.MD) error) error {
+		principal, ok := auth.GetPrincipal(ctx)
+		if !ok {
+			return nil
+		}
+
+		md := metadata.MD{}
+		reached := l.limit(ctx, principal, func(key, value string) { md.Set(key, value) })
+		if reached != nil {
+			md.Set("retry-after", reached.retryAfter())
+		}
+		if len(md) > 0 {
+			if err := setHeader(md); err != nil {
+				logrus.WithError(err).Warn("failed to set rate limit metadata")
+			}
+		}
+		if reached != nil {
+			return status.Error(codes.ResourceExhausted, reached.Message)
+		}
+		return nil
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(ctx, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(ss.Context(), ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+
+	return unary, stream
+}
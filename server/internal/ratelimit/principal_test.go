@@ -0,0 +1,339 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockPlanSource はPlanSourceのモック
+type mockPlanSource struct {
+	plans map[string]*model.RateLimitPlan // principal_type:principal_id -> プラン（":"の後が空のキーはデフォルト）
+	err   error
+	calls int
+}
+
+func (m *mockPlanSource) GetRateLimitPlan(ctx context.Context, principalType, principalID string) (*model.RateLimitPlan, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	if plan, ok := m.plans[principalType+":"+principalID]; ok {
+		return plan, nil
+	}
+	return m.plans[principalType+":"], nil
+}
+
+func newTestPrincipalLimiter(source PlanSource, now time.Time) *PrincipalLimiter {
+	counter := NewMemoryCounter()
+	counter.now = func() time.Time { return now }
+	l := newPrincipalLimiter(&config.PrincipalRateLimitConfig{CacheTTL: time.Minute, KeyPrefix: "test:"}, source, counter)
+	l.now = func() time.Time { return now }
+	return l
+}
+
+func TestPrincipalKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		wantType  string
+		wantID    string
+		wantKey   string
+	}{
+		{"APIキー", &auth.Principal{Type: auth.PrincipalTypeAPIKey, Subject: "public", KeyID: "jti-1"}, "api_key", "jti-1", "key:jti-1"},
+		{"jtiがないAPIキー", &auth.Principal{Type: auth.PrincipalTypeAPIKey, Subject: "public"}, "api_key", "", "key:public"},
+		{"紐づいたユーザー", &auth.Principal{Type: auth.PrincipalTypeUser, Issuer: "https://example.auth0.com/", Subject: "auth0|1", UserID: "u1"}, "user", "u1", "user:u1"},
+		{"紐づいていないユーザー", &auth.Principal{Type: auth.PrincipalTypeUser, Issuer: "https://example.auth0.com/", Subject: "auth0|1"}, "user", "", "sub:https://example.auth0.com/|auth0|1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principalType, principalID, key := principalKey(tt.principal)
+			assert.Equal(t, tt.wantType, principalType)
+			assert.Equal(t, tt.wantID, principalID)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
+
+func TestPrincipalLimiter_Check(t *testing.T) {
+	source := &mockPlanSource{plans: map[string]*model.RateLimitPlan{
+		"api_key:":      {Name: "default", RequestsPerMinute: 2, RequestsPerDay: 3},
+		"api_key:jti-2": {Name: "quota", RequestsPerMonth: 1},
+		"user:":         nil,
+	}}
+	now := time.Date(2026, 10, 19, 12, 30, 15, 0, time.UTC)
+	l := newTestPrincipalLimiter(source, now)
+	ctx := context.Background()
+	key1 := &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "jti-1"}
+
+	statuses, err := l.Check(ctx, key1)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, WindowStatus{Header: "X-RateLimit", Message: "Too Many Requests", Limit: 2, Remaining: 1, Reset: now.Truncate(time.Minute).Add(time.Minute).Unix()}, statuses[0])
+	assert.Equal(t, WindowStatus{Header: "X-Quota-Daily", Message: "Quota exceeded", Limit: 3, Remaining: 2, Reset: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC).Unix()}, statuses[1])
+
+	_, err = l.Check(ctx, key1)
+	require.NoError(t, err)
+
+	// 分の上限に達した場合は日のクォータを数えない
+	statuses, err = l.Check(ctx, key1)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Reached)
+	assert.Equal(t, int64(0), statuses[0].Remaining)
+
+	// 次の分は日のクォータの上限に達する
+	l.now = func() time.Time { return now.Add(45 * time.Second) }
+	statuses, err = l.Check(ctx, key1)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.False(t, statuses[1].Reached)
+	assert.Equal(t, int64(0), statuses[1].Remaining)
+	statuses, err = l.Check(ctx, key1)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.False(t, statuses[0].Reached)
+	assert.True(t, statuses[1].Reached)
+	assert.Equal(t, "Quota exceeded", statuses[1].Message)
+
+	// キーごとに数える
+	statuses, err = l.Check(ctx, &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "jti-2"})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "X-Quota-Monthly", statuses[0].Header)
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC).Unix(), statuses[0].Reset)
+
+	// プランがない場合は無制限
+	statuses, err = l.Check(ctx, &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "auth0|1"})
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+
+	// プランはキャッシュする
+	assert.Equal(t, 3, source.calls)
+}
+
+func TestPrincipalLimiter_HumaMiddleware(t *testing.T) {
+	source := &mockPlanSource{plans: map[string]*model.RateLimitPlan{
+		"api_key:": {Name: "default", RequestsPerMinute: 1, RequestsPerHour: 10},
+	}}
+	l := newTestPrincipalLimiter(source, time.Now())
+
+	principal := &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "jti-1"}
+	_, api := humatest.New(t)
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		if ctx.Header("X-Test-Auth") != "" {
+			ctx = huma.WithContext(ctx, auth.WithPrincipal(ctx.Context(), principal))
+		}
+		next(ctx)
+	})
+	api.UseMiddleware(l.HumaMiddleware())
+	huma.Register(api, huma.Operation{
+		OperationID: "get-test",
+		Method:      http.MethodGet,
+		Path:        "/api/test",
+	}, func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		return nil, nil
+	})
+
+	resp := api.Get("/api/test", "X-Test-Auth: 1")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", resp.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "10", resp.Header().Get("X-RateLimit-Hour-Limit"))
+	assert.Equal(t, "9", resp.Header().Get("X-RateLimit-Hour-Remaining"))
+
+	resp = api.Get("/api/test", "X-Test-Auth: 1")
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.JSONEq(t, `{"code":429,"message":"Too Many Requests"}`, resp.Body.String())
+	assert.NotEmpty(t, resp.Header().Get("Retry-After"))
+
+	// レートリミットを適用しないルートは数えない
+	l.exempt, _ = newRouteMatcher([]string{"GET /api/test"}, nil)
+	resp = api.Get("/api/test", "X-Test-Auth: 1")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	l.exempt = &routeMatcher{}
+
+	// 認証していないリクエストは数えない
+	resp = api.Get("/api/test")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Empty(t, resp.Header().Get("X-RateLimit-Limit"))
+
+	// プランを取得できない場合は許可する（fail-open方式）
+	failing := newTestPrincipalLimiter(&mockPlanSource{err: errors.New("connection refused")}, time.Now())
+	_, api = humatest.New(t)
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		next(huma.WithContext(ctx, auth.WithPrincipal(ctx.Context(), principal)))
+	})
+	api.UseMiddleware(failing.HumaMiddleware())
+	huma.Register(api, huma.Operation{
+		OperationID: "get-test",
+		Method:      http.MethodGet,
+		Path:        "/api/test",
+	}, func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		return nil, nil
+	})
+	resp = api.Get("/api/test")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestPrincipalLimiter_EchoMiddleware(t *testing.T) {
+	source := &mockPlanSource{plans: map[string]*model.RateLimitPlan{
+		"api_key:": {Name: "default", RequestsPerMinute: 1},
+	}}
+	l := newTestPrincipalLimiter(source, time.Now())
+	principal := &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "jti-1"}
+
+	e := echo.New()
+	handler := l.EchoMiddleware()(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	serve := func(authenticated bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/stream/posts", nil)
+		if authenticated {
+			req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+		return rec
+	}
+
+	rec := serve(true)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = serve(true)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"code":429,"message":"Too Many Requests"}`, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// 認証していないリクエストは数えない
+	rec = serve(false)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+}
+
+// mockServerStream はヘッダーのメタデータを記録するServerStreamのモック
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestPrincipalLimiter_GRPCInterceptors(t *testing.T) {
+	source := &mockPlanSource{plans: map[string]*model.RateLimitPlan{
+		"api_key:": {Name: "default", RequestsPerMinute: 1},
+	}}
+	l := newTestPrincipalLimiter(source, time.Now())
+	unary, stream := l.GRPCInterceptors()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "jti-1"})
+
+	called := 0
+	unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called++
+		return "ok", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/dm.v1.DmUserService/GetDmUser"}
+
+	resp, err := unary(ctx, nil, info, unaryHandler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = unary(ctx, nil, info, unaryHandler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 1, called)
+
+	// 認証していない呼び出し（ヘルスチェック）は数えない
+	_, err = unary(context.Background(), nil, info, unaryHandler)
+	assert.NoError(t, err)
+
+	// ストリームは同じ呼び出し元のカウンターで数え、状態をヘッダーのメタデータで返す
+	ss := &mockServerStream{ctx: ctx}
+	err = stream(nil, ss, &grpc.StreamServerInfo{FullMethod: "/dm.v1.DmPostService/ListDmPosts"}, func(srv interface{}, ss grpc.ServerStream) error {
+		t.Fatal("handler should not be called")
+		return nil
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"1"}, ss.header.Get("x-ratelimit-limit"))
+	assert.Equal(t, []string{"0"}, ss.header.Get("x-ratelimit-remaining"))
+	assert.NotEmpty(t, ss.header.Get("retry-after"))
+}
+
+func TestMemoryCounter(t *testing.T) {
+	counter := NewMemoryCounter()
+	now := time.Now()
+	counter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	count, err := counter.Incr(ctx, "a", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, _ = counter.Incr(ctx, "a", now.Add(time.Minute))
+	assert.Equal(t, int64(2), count)
+	count, _ = counter.Incr(ctx, "b", now.Add(time.Minute))
+	assert.Equal(t, int64(1), count)
+
+	// 期限切れのキーは0から数え直す
+	counter.now = func() time.Time { return now.Add(time.Minute) }
+	count, _ = counter.Incr(ctx, "a", now.Add(2*time.Minute))
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemoryCounter_Sweep(t *testing.T) {
+	counter := NewMemoryCounter()
+	now := time.Now()
+	counter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _ = counter.Incr(ctx, "a", now.Add(time.Second))
+	// 期限切れのキーは次の削除までは残る
+	counter.now = func() time.Time { return now.Add(30 * time.Second) }
+	_, _ = counter.Incr(ctx, "b", now.Add(2*time.Minute))
+	assert.Len(t, counter.entries, 2)
+
+	counter.now = func() time.Time { return now.Add(memorySweepInterval) }
+	_, _ = counter.Incr(ctx, "c", now.Add(2*time.Minute))
+	assert.Len(t, counter.entries, 2)
+	assert.NotContains(t, counter.entries, "a")
+}
+
+func TestNewCounter(t *testing.T) {
+	cfg := &config.Config{}
+	counter, err := NewCounter(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryCounter{}, counter)
+
+	cfg.API.RateLimit.StorageType = "redis"
+	_, err = NewCounter(cfg)
+	assert.Error(t, err)
+
+	cfg.CacheServer.Redis.Default.Cluster.Addrs = []string{"localhost:7000"}
+	counter, err = NewCounter(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &RedisCounter{}, counter)
+}
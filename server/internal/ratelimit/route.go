@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// route はレートリミットの設定で指定するルート（"METHOD /path"または"/path"）
+type route struct {
+	method  string // 空の場合は全てのメソッド
+	pattern string // path.Matchのパターン（*は1セグメントに一致）
+}
+
+// routeMatcher はリクエストのメソッドとパスがルートの一覧に一致するかを判定
+// バージョンごとのパス（/api/{version}/...）はバージョンなしのパス（/api/...）として判定する
+type routeMatcher struct {
+	routes   []route
+	versions []string
+}
+
+// newRouteMatcher は新しいrouteMatcherを作成
+func newRouteMatcher(routes []string, versions []config.APIVersionConfig) (*routeMatcher, error) {
+	m := &routeMatcher{}
+	for _, v := range versions {
+		m.versions = append(m.versions, v.Name)
+	}
+	for _, s := range routes {
+		r, err := parseRoute(s)
+		if err != nil {
+			return nil, err
+		}
+		m.routes = append(m.routes, r)
+	}
+	return m, nil
+}
+
+// parseRoute はルートの文字列を解析
+func parseRoute(s string) (route, error) {
+	var r route
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		r.pattern = fields[0]
+	case 2:
+		r.method, r.pattern = strings.ToUpper(fields[0]), fields[1]
+	default:
+		return r, fmt.Errorf("invalid route %q", s)
+	}
+	if !strings.HasPrefix(r.pattern, "/") {
+		return r, fmt.Errorf("invalid route %q: path must start with /", s)
+	}
+	if _, err := path.Match(r.pattern, ""); err != nil {
+		return r, fmt.Errorf("invalid route %q: %w", s, err)
+	}
+	return r, nil
+}
+
+// match はメソッドとパスがいずれかのルートに一致するかを判定
+func (m *routeMatcher) match(method, requestPath string) bool {
+	requestPath = m.unversioned(requestPath)
+	for _, r := range m.routes {
+		if r.method != "" && r.method != method {
+			continue
+		}
+		if ok, _ := path.Match(r.pattern, requestPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unversioned はバージョンごとのパスからバージョンを取り除く
+func (m *routeMatcher) unversioned(requestPath string) string {
+	for _, v := range m.versions {
+		if rest, ok := strings.CutPrefix(requestPath, "/api/"+v+"/"); ok {
+			return "/api/" + rest
+		}
+	}
+	return requestPath
+}
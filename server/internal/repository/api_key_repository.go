@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrAPIKeyNotFound はAPIキーが台帳に存在しない場合のエラー
+var ErrAPIKeyNotFound = apperror.NotFound("api key not found")
+
+// APIKeyRepository は発行済みPublic APIキーの台帳のデータアクセスを担当
+// 台帳はmasterグループに配置する
+type APIKeyRepository struct {
+	groupManager *db.GroupManager
+}
+
+// NewAPIKeyRepository は新しいAPIKeyRepositoryを作成
+func NewAPIKeyRepository(groupManager *db.GroupManager) *APIKeyRepository {
+	return &APIKeyRepository{
+		groupManager: groupManager,
+	}
+}
+
+// Create はAPIキーを台帳に登録
+func (r *APIKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	// リトライ機能付きでGORM APIで作成
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("api_keys").Create(apiKey).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByJTI はjtiでAPIキーを取得
+func (r *APIKeyRepository) GetByJTI(ctx context.Context, jti string) (*model.APIKey, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var apiKey model.APIKey
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("api_keys").Where("jti = ?", jti).First(&apiKey).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrAPIKeyNotFound, jti)
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+// List はAPIキーを新しい順に取得
+func (r *APIKeyRepository) List(ctx context.Context, limit, offset int) ([]*model.APIKey, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	apiKeys := make([]*model.APIKey, 0)
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("api_keys").Order("id DESC").Limit(limit).Offset(offset).Find(&apiKeys).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// Revoke はAPIキーを失効させる（失効済みの場合は失効日時を変更しない）
+func (r *APIKeyRepository) Revoke(ctx context.Context, jti string, revokedAt time.Time) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var result *gorm.DB
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		result = conn.DB.WithContext(ctx).Table("api_keys").Where("jti = ?", jti).Updates(map[string]interface{}{
+			"revoked_at": gorm.Expr("COALESCE(revoked_at, ?)", revokedAt),
+			"updated_at": revokedAt,
+		})
+		return result.Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", ErrAPIKeyNotFound, jti)
+	}
+
+	return nil
+}
+
+// UpdateLastUsedAt はAPIキーの最終利用日時を更新（台帳にないキーの場合は何もしない）
+func (r *APIKeyRepository) UpdateLastUsedAt(ctx context.Context, jti string, usedAt time.Time) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("api_keys").Where("jti = ?", jti).Update("last_used_at", usedAt).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update api key last used at: %w", err)
+	}
+
+	return nil
+}
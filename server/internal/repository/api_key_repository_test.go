@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/test/testutil"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	repo := repository.NewAPIKeyRepository(groupManager)
+	ctx := context.Background()
+
+	apiKey := &model.APIKey{JTI: "019a1b2c3d4e7f00a123456789abcdef", Name: "partner", Owner: "ops", Scopes: "read,write", Version: "v2", Env: "test"}
+	require.NoError(t, repo.Create(ctx, apiKey))
+	require.NotZero(t, apiKey.ID)
+
+	usedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.UpdateLastUsedAt(ctx, apiKey.JTI, usedAt))
+	// 台帳にないキーはエラーにしない
+	require.NoError(t, repo.UpdateLastUsedAt(ctx, "unknown", usedAt))
+
+	revokedAt := usedAt.Add(time.Minute)
+	require.NoError(t, repo.Revoke(ctx, apiKey.JTI, revokedAt))
+	// 失効済みのキーは失効日時を変更しない
+	require.NoError(t, repo.Revoke(ctx, apiKey.JTI, revokedAt.Add(time.Hour)))
+
+	got, err := repo.GetByJTI(ctx, apiKey.JTI)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastUsedAt)
+	assert.True(t, got.LastUsedAt.Equal(usedAt))
+	require.NotNil(t, got.RevokedAt)
+	assert.True(t, got.RevokedAt.Equal(revokedAt))
+
+	apiKeys, err := repo.List(ctx, 20, 0)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 1)
+	assert.Equal(t, "partner", apiKeys[0].Name)
+
+	_, err = repo.GetByJTI(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+	assert.ErrorIs(t, repo.Revoke(ctx, "unknown", revokedAt), repository.ErrAPIKeyNotFound)
+}
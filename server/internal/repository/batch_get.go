@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"gorm.io/gorm"
+)
+
+// groupIDsByTable はIDをシャーディングキーのテーブル番号ごとにまとめる
+// keyOfはi番目のIDのシャーディングキー（UUID）を返す。テーブル番号を計算できないキーのIDは存在しないものとして除外する
+func groupIDsByTable(ts *db.TableSelector, ids []string, keyOf func(i int) string) map[int][]string {
+	idsByTable := make(map[int][]string)
+	for i, id := range ids {
+		tableNum, err := ts.GetTableNumberFromUUID(keyOf(i))
+		if err != nil {
+			continue
+		}
+		idsByTable[tableNum] = append(idsByTable[tableNum], id)
+	}
+	return idsByTable
+}
+
+// findByIDsInTables はテーブルごとに WHERE id IN (...) を並行して実行し、取得した行をまとめて返す
+// 結果の順序はテーブル番号順で、リクエストの順序には並べ替えない
+func findByIDsInTables[T any](ctx context.Context, groupManager *db.GroupManager, baseName string, idsByTable map[int][]string) ([]*T, error) {
+	return queryInTables[T](ctx, groupManager, baseName, idsByTable, func(tx *gorm.DB, tableName string, ids []string) *gorm.DB {
+		return tx.Table(tableName).Where("id IN ?", ids)
+	})
+}
+
+// queryInTables はテーブルごとにbuildで組み立てたクエリを並行して実行し、取得した行をまとめて返す
+// buildにはテーブルの接続、テーブル名、そのテーブルに属するIDが渡される。結果の順序はテーブル番号順
+func queryInTables[T any](ctx context.Context, groupManager *db.GroupManager, baseName string, idsByTable map[int][]string, build func(tx *gorm.DB, tableName string, ids []string) *gorm.DB) ([]*T, error) {
+	tableNums := make([]int, 0, len(idsByTable))
+	for tableNum := range idsByTable {
+		tableNums = append(tableNums, tableNum)
+	}
+	sort.Ints(tableNums)
+
+	results := make([][]*T, len(tableNums))
+	errs := make([]error, len(tableNums))
+	var wg sync.WaitGroup
+	for i, tableNum := range tableNums {
+		wg.Add(1)
+		go func(i, tableNum int) {
+			defer wg.Done()
+
+			// テーブル番号から接続を取得
+			conn, err := groupManager.GetShardingConnection(tableNum)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get connection for table %d: %w", tableNum, err)
+				return
+			}
+
+			tableName := fmt.Sprintf("%s_%03d", baseName, tableNum)
+
+			var rows []*T
+			// リトライ機能付きでクエリ実行
+			err = db.ExecuteWithRetry(func() error {
+				return build(conn.DB.WithContext(ctx), tableName, idsByTable[tableNum]).Find(&rows).Error
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to query table %s: %w", tableName, err)
+				return
+			}
+			results[i] = rows
+		}(i, tableNum)
+	}
+	wg.Wait()
+
+	rows := make([]*T, 0)
+	for i := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		rows = append(rows, results[i]...)
+	}
+	return rows, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/db"
+)
+
+func TestGroupIDsByTable(t *testing.T) {
+	ts := db.NewTableSelector(db.DBShardingTableCount, db.DBShardingTablesPerDB)
+
+	// 後ろ2文字の16進数をテーブル数で割った余りでまとめる（0x00=0, 0x21=33→1, 0x01=1）
+	ids := []string{"aaaa00", "bbbb21", "cccc01", "dddd00", "invalid-zz"}
+	got := groupIDsByTable(ts, ids, func(i int) string { return ids[i] })
+	assert.Equal(t, map[int][]string{
+		0: {"aaaa00", "dddd00"},
+		1: {"bbbb21", "cccc01"},
+	}, got)
+
+	// シャーディングキーとIDが異なる場合はキーでまとめ、IDを格納する
+	keys := []string{"user-1f", "user-00"}
+	got = groupIDsByTable(ts, []string{"post-a", "post-b"}, func(i int) string { return keys[i] })
+	assert.Equal(t, map[int][]string{
+		31: {"post-a"},
+		0:  {"post-b"},
+	}, got)
+}
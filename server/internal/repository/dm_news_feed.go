@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// dmNewsViewName はニュースフィードの取得元のビュー名
+const dmNewsViewName = "dm_news_view"
+
+// applyDmNewsFeedItemsQuery はフィードに載せる公開済みニュースの取得条件をクエリに適用
+func applyDmNewsFeedItemsQuery(query *gorm.DB, now time.Time, limit int) *gorm.DB {
+	return query.Select("id, title, content, published_at, updated_at").
+		Where("published_at IS NOT NULL AND published_at <= ?", now).
+		Order("published_at DESC").Order("id DESC").Limit(limit)
+}
+
+// applyDmNewsNextPublishQuery は次に公開予定のニュースの公開日時の取得条件をクエリに適用
+func applyDmNewsNextPublishQuery(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Select("MIN(published_at)").Where("published_at > ?", now)
+}
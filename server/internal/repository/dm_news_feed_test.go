@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestApplyDmNewsFeedItemsQuery_SQL(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	var items []*model.DmNewsFeedItem
+	stmt := applyDmNewsFeedItemsQuery(dryRunDB(t, "postgres").Table(dmNewsViewName), now, 20).Find(&items).Statement
+	assert.Equal(t, `SELECT id, title, content, published_at, updated_at FROM "dm_news_view" WHERE published_at IS NOT NULL AND published_at <= $1 ORDER BY published_at DESC,id DESC LIMIT $2`, stmt.SQL.String())
+	assert.Equal(t, []interface{}{now, 20}, stmt.Vars)
+}
+
+func TestApplyDmNewsNextPublishQuery_SQL(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		driver  string
+		wantSQL string
+	}{
+		{driver: "postgres", wantSQL: `SELECT MIN(published_at) FROM "dm_news_view" WHERE published_at > $1`},
+		{driver: "mysql", wantSQL: "SELECT MIN(published_at) FROM `dm_news_view` WHERE published_at > ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			var next sql.NullTime
+			stmt := applyDmNewsNextPublishQuery(dryRunDB(t, tt.driver).Table(dmNewsViewName), now).Scan(&next).Statement
+			assert.Equal(t, tt.wantSQL, stmt.SQL.String())
+			assert.Equal(t, []interface{}{now}, stmt.Vars)
+		})
+	}
+}
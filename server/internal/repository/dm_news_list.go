@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+)
+
+// applyDmNewsListQuery は公開済みニュースの一覧取得条件をクエリに適用
+func applyDmNewsListQuery(query *gorm.DB, q *model.DmNewsListQuery) *gorm.DB {
+	query = query.Where("published_at IS NOT NULL AND published_at <= ?", q.Now)
+	if q.AuthorID != nil {
+		query = query.Where("author_id = ?", *q.AuthorID)
+	}
+	return query.Order("published_at DESC").Order("id DESC").Limit(q.Limit).Offset(q.Offset)
+}
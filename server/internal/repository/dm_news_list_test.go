@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestApplyDmNewsListQuery_SQL(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	authorID := int64(7)
+
+	tests := []struct {
+		name     string
+		q        *model.DmNewsListQuery
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "公開日時で絞り込む",
+			q:        &model.DmNewsListQuery{Now: now, Limit: 20},
+			wantSQL:  `SELECT * FROM "dm_news" WHERE published_at IS NOT NULL AND published_at <= $1 ORDER BY published_at DESC,id DESC LIMIT $2`,
+			wantVars: []interface{}{now, 20},
+		},
+		{
+			name:     "作成者でも絞り込む",
+			q:        &model.DmNewsListQuery{AuthorID: &authorID, Now: now, Limit: 20, Offset: 40},
+			wantSQL:  `SELECT * FROM "dm_news" WHERE (published_at IS NOT NULL AND published_at <= $1) AND author_id = $2 ORDER BY published_at DESC,id DESC LIMIT $3 OFFSET $4`,
+			wantVars: []interface{}{now, authorID, 20, 40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var news []*model.DmNews
+			stmt := applyDmNewsListQuery(dryRunDB(t, "postgres").Table("dm_news"), tt.q).Find(&news).Statement
+			assert.Equal(t, tt.wantSQL, stmt.SQL.String())
+			assert.Equal(t, tt.wantVars, stmt.Vars)
+		})
+	}
+}
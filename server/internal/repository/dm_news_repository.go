@@ -2,12 +2,21 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
+	"time"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/db"
 	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
 )
 
+// ErrDmNewsNotFound はニュースが存在しない場合のエラー
+var ErrDmNewsNotFound = apperror.NotFound("news not found")
+
 // DmNewsRepository はニュースのデータアクセスを担当
 type DmNewsRepository struct {
 	groupManager *db.GroupManager
@@ -51,3 +60,184 @@ func (r *DmNewsRepository) InsertDmNewsBatch(ctx context.Context, dmNews []*mode
 
 	return nil
 }
+
+// Create はニュースを作成
+func (r *DmNewsRepository) Create(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	news := &model.DmNews{
+		Title:       req.Title,
+		Content:     req.Content,
+		AuthorID:    req.AuthorID,
+		PublishedAt: req.PublishedAt,
+	}
+
+	// リトライ機能付きでGORM APIで作成
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_news").Create(news).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create news: %w", err)
+	}
+
+	return news, nil
+}
+
+// GetByID はIDでニュースを取得
+func (r *DmNewsRepository) GetByID(ctx context.Context, id int64) (*model.DmNews, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var news model.DmNews
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_news").Where("id = ?", id).First(&news).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrDmNewsNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get news: %w", err)
+	}
+
+	return &news, nil
+}
+
+// ListPublished は公開済みのニュース一覧を取得
+// published_atがq.Now以前のニュースのみを返し、公開日時の新しい順（同じ場合はIDの降順）に並べる
+func (r *DmNewsRepository) ListPublished(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	news := make([]*model.DmNews, 0)
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return applyDmNewsListQuery(conn.DB.WithContext(ctx).Table("dm_news"), q).Find(&news).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list news: %w", err)
+	}
+
+	return news, nil
+}
+
+// Update はニュースを更新（空の項目・nilの項目は更新しない）
+func (r *DmNewsRepository) Update(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+	updates := make(map[string]interface{})
+	if req.Title != "" {
+		updates["title"] = req.Title
+	}
+	if req.Content != "" {
+		updates["content"] = req.Content
+	}
+	if req.AuthorID != nil {
+		updates["author_id"] = *req.AuthorID
+	}
+	if req.PublishedAt != nil {
+		updates["published_at"] = *req.PublishedAt
+	}
+
+	return r.Patch(ctx, id, updates)
+}
+
+// Patch は指定されたカラムのみを更新（fieldsが空の場合は更新せずに現在の値を返す）
+func (r *DmNewsRepository) Patch(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error) {
+	if len(fields) == 0 {
+		return r.GetByID(ctx, id)
+	}
+
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	updates := maps.Clone(fields)
+	updates["updated_at"] = time.Now()
+
+	var result *gorm.DB
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		result = conn.DB.WithContext(ctx).Table("dm_news").Where("id = ?", id).Updates(updates)
+		return result.Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update news: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrDmNewsNotFound, id)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// Delete はニュースを削除
+func (r *DmNewsRepository) Delete(ctx context.Context, id int64) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var result *gorm.DB
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		result = conn.DB.WithContext(ctx).Table("dm_news").Where("id = ?", id).Delete(&model.DmNews{})
+		return result.Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete news: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", ErrDmNewsNotFound, id)
+	}
+
+	return nil
+}
+
+// ListFeedItems はフィードに載せる公開済みニュースをdm_news_viewから取得
+// published_atがnow以前のニュースを公開日時の新しい順（同じ場合はIDの降順）にlimit件まで返す
+func (r *DmNewsRepository) ListFeedItems(ctx context.Context, now time.Time, limit int) ([]*model.DmNewsFeedItem, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	items := make([]*model.DmNewsFeedItem, 0)
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return applyDmNewsFeedItemsQuery(conn.DB.WithContext(ctx).Table(dmNewsViewName), now, limit).Find(&items).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetNextPublishAt は次に公開予定のニュースの公開日時をdm_news_viewから取得（予定がない場合はnil）
+func (r *DmNewsRepository) GetNextPublishAt(ctx context.Context, now time.Time) (*time.Time, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var next sql.NullTime
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return applyDmNewsNextPublishQuery(conn.DB.WithContext(ctx).Table(dmNewsViewName), now).Row().Scan(&next)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next publish time: %w", err)
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+
+	return &next.Time, nil
+}
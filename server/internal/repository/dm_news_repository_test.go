@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/repository"
@@ -107,3 +108,166 @@ func TestNewDmNewsRepository(t *testing.T) {
 	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
 	assert.NotNil(t, dmNewsRepo)
 }
+
+func TestDmNewsRepository_Patch(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
+	ctx := context.Background()
+
+	authorID := int64(12345)
+	publishedAt := time.Now()
+	news := &model.DmNews{
+		Title:       "Patch News",
+		Content:     "Patch content",
+		AuthorID:    &authorID,
+		PublishedAt: &publishedAt,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	require.NoError(t, dmNewsRepo.InsertDmNewsBatch(ctx, []*model.DmNews{news}))
+	require.NotZero(t, news.ID)
+
+	// nullを指定したカラムはNULLに更新され、指定のないカラムは変更されない
+	patched, err := dmNewsRepo.Patch(ctx, news.ID, map[string]interface{}{
+		"title":     "Patched Title",
+		"author_id": nil,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched Title", patched.Title)
+	assert.Equal(t, "Patch content", patched.Content)
+	assert.Nil(t, patched.AuthorID)
+	assert.NotNil(t, patched.PublishedAt)
+
+	_, err = dmNewsRepo.Patch(ctx, news.ID+1000000, map[string]interface{}{"title": "x"})
+	assert.Error(t, err)
+}
+
+func TestDmNewsRepository_CRUD(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
+	ctx := context.Background()
+
+	created, err := dmNewsRepo.Create(ctx, &model.CreateDmNewsRequest{
+		Title:   "CRUD News",
+		Content: "CRUD content",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	assert.Nil(t, created.PublishedAt)
+
+	// 空の項目・nilの項目は更新しない
+	publishedAt := time.Now().Add(-time.Minute)
+	updated, err := dmNewsRepo.Update(ctx, created.ID, &model.UpdateDmNewsRequest{
+		Title:       "Updated News",
+		PublishedAt: &publishedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Updated News", updated.Title)
+	assert.Equal(t, "CRUD content", updated.Content)
+	require.NotNil(t, updated.PublishedAt)
+
+	require.NoError(t, dmNewsRepo.Delete(ctx, created.ID))
+	_, err = dmNewsRepo.GetByID(ctx, created.ID)
+	assert.Error(t, err)
+	assert.Error(t, dmNewsRepo.Delete(ctx, created.ID))
+}
+
+func TestDmNewsRepository_ListPublished(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
+	ctx := context.Background()
+
+	// 他のテストのデータと区別するため、作成者IDを一意にする
+	authorID := time.Now().UnixNano()
+	now := time.Now()
+	older := now.Add(-2 * time.Hour)
+	newer := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	var ids []int64
+	for _, req := range []*model.CreateDmNewsRequest{
+		{Title: "Older", Content: "c", AuthorID: &authorID, PublishedAt: &older},
+		{Title: "Newer", Content: "c", AuthorID: &authorID, PublishedAt: &newer},
+		{Title: "Scheduled", Content: "c", AuthorID: &authorID, PublishedAt: &future},
+		{Title: "Draft", Content: "c", AuthorID: &authorID},
+	} {
+		news, err := dmNewsRepo.Create(ctx, req)
+		require.NoError(t, err)
+		ids = append(ids, news.ID)
+	}
+
+	// クリーンアップ
+	defer func() {
+		for _, id := range ids {
+			_ = dmNewsRepo.Delete(ctx, id)
+		}
+	}()
+
+	news, err := dmNewsRepo.ListPublished(ctx, &model.DmNewsListQuery{AuthorID: &authorID, Now: now, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 2)
+	assert.Equal(t, "Newer", news[0].Title)
+	assert.Equal(t, "Older", news[1].Title)
+
+	// 公開日時を過ぎると一覧に含まれる
+	news, err = dmNewsRepo.ListPublished(ctx, &model.DmNewsListQuery{AuthorID: &authorID, Now: future, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 3)
+	assert.Equal(t, "Scheduled", news[0].Title)
+}
+
+func TestDmNewsRepository_FeedItems(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmNewsRepo := repository.NewDmNewsRepository(groupManager)
+	ctx := context.Background()
+
+	// 他のテストのデータと区別するため、過去の日時を基準にする
+	base := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	older := base.Add(-2 * time.Hour)
+	newer := base.Add(-time.Hour)
+	scheduled := base.Add(time.Hour)
+
+	var ids []int64
+	for _, req := range []*model.CreateDmNewsRequest{
+		{Title: "Older", Content: "c", PublishedAt: &older},
+		{Title: "Newer", Content: "c", PublishedAt: &newer},
+		{Title: "Scheduled", Content: "c", PublishedAt: &scheduled},
+	} {
+		news, err := dmNewsRepo.Create(ctx, req)
+		require.NoError(t, err)
+		ids = append(ids, news.ID)
+	}
+
+	// クリーンアップ
+	defer func() {
+		for _, id := range ids {
+			_ = dmNewsRepo.Delete(ctx, id)
+		}
+	}()
+
+	items, err := dmNewsRepo.ListFeedItems(ctx, base, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Newer", items[0].Title)
+	assert.Equal(t, "Older", items[1].Title)
+	assert.False(t, items[0].UpdatedAt.IsZero())
+
+	// 件数の上限
+	items, err = dmNewsRepo.ListFeedItems(ctx, base, 1)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	// 次に公開予定のニュースの公開日時
+	next, err := dmNewsRepo.GetNextPublishAt(ctx, base)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.True(t, scheduled.Equal(*next), "expected %v, got %v", scheduled, *next)
+}
@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"sync"
 	"time"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/db"
 	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/util/idgen"
 	"gorm.io/gorm"
 )
 
+// ErrDmPostNotFound は投稿が存在しない場合のエラー
+var ErrDmPostNotFound = apperror.NotFound("post not found")
+
 // DmPostRepository は投稿のデータアクセスを担当
 type DmPostRepository struct {
 	groupManager  *db.GroupManager
@@ -85,7 +91,7 @@ func (r *DmPostRepository) GetByID(ctx context.Context, id string, userID string
 	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("post not found: %s", id)
+			return nil, fmt.Errorf("%w: %s", ErrDmPostNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
@@ -93,8 +99,37 @@ func (r *DmPostRepository) GetByID(ctx context.Context, id string, userID string
 	return &post, nil
 }
 
+// GetByKeys は複数の投稿IDとユーザーIDの組で投稿を取得し、キーをキーとするマップで返す
+// ユーザーIDでテーブルをまとめ、テーブルごとに1回のクエリを並行して実行する
+// 投稿IDが存在してもユーザーIDが一致しない場合は見つからないものとして扱う
+func (r *DmPostRepository) GetByKeys(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error) {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	idsByTable := groupIDsByTable(r.tableSelector, ids, func(i int) string { return keys[i].UserID })
+
+	posts, err := findByIDsInTables[model.DmPost](ctx, r.groupManager, "dm_posts", idsByTable)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[model.DmPostKey]bool, len(keys))
+	for _, key := range keys {
+		requested[key] = true
+	}
+	found := make(map[model.DmPostKey]*model.DmPost, len(posts))
+	for _, post := range posts {
+		key := model.DmPostKey{ID: post.ID, UserID: post.UserID}
+		if requested[key] {
+			found[key] = post
+		}
+	}
+	return found, nil
+}
+
 // ListByUserID はユーザーIDで投稿一覧を取得
-func (r *DmPostRepository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+func (r *DmPostRepository) ListByUserID(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 	// UserIDをキーとしてテーブル/DBを決定
 	tableName, err := r.tableSelector.GetTableNameFromUUID("dm_posts", userID)
 	if err != nil {
@@ -107,13 +142,15 @@ func (r *DmPostRepository) ListByUserID(ctx context.Context, userID string, limi
 		return nil, fmt.Errorf("failed to get sharding connection: %w", err)
 	}
 
+	query, err := applyListQuery(conn.DB.WithContext(ctx).Table(tableName).Where("user_id = ?", userID), q, model.DmPostListFields, "created_at DESC")
+	if err != nil {
+		return nil, err
+	}
+
 	var posts []*model.DmPost
 	// リトライ機能付きでクエリ実行
 	err = db.ExecuteWithRetry(func() error {
-		return conn.DB.WithContext(ctx).
-			Table(tableName).
-			Where("user_id = ?", userID).
-			Order("created_at DESC").
+		return query.Session(&gorm.Session{}).
 			Limit(limit).
 			Offset(offset).
 			Find(&posts).Error
@@ -125,8 +162,37 @@ func (r *DmPostRepository) ListByUserID(ctx context.Context, userID string, limi
 	return posts, nil
 }
 
+// ListByUserIDs は複数ユーザーの投稿をユーザーごとに新しい順でlimit件ずつ取得し、ユーザーIDをキーとするマップで返す
+// ユーザーIDでテーブルをまとめ、テーブルごとに1回のクエリ（ROW_NUMBER()でユーザーごとの件数を制限）を並行して実行する
+// 投稿がないユーザーはマップに含まれない
+func (r *DmPostRepository) ListByUserIDs(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	idsByTable := groupIDsByTable(r.tableSelector, userIDs, func(i int) string { return userIDs[i] })
+
+	posts, err := queryInTables[model.DmPost](ctx, r.groupManager, "dm_posts", idsByTable, func(tx *gorm.DB, tableName string, ids []string) *gorm.DB {
+		ranked := tx.Session(&gorm.Session{NewDB: true}).
+			Table(tableName).
+			Select(tableName+".*, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC) AS row_num").
+			Where("user_id IN ?", ids)
+		return tx.Table("(?) AS ranked", ranked).
+			Select("id, user_id, title, content, created_at, updated_at").
+			Where("row_num <= ?", limit).
+			Order("user_id, row_num")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string][]*model.DmPost)
+	for _, post := range posts {
+		found[post.UserID] = append(found[post.UserID], post)
+	}
+	return found, nil
+}
+
 // List はすべての投稿を取得（クロステーブルクエリ）
-func (r *DmPostRepository) List(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+// qのフィルタ・ソート条件は各テーブルに同じように適用され、結果全体もソート条件に従って並べ替える
+// offset・limitは並べ替えた結果全体に適用する
+func (r *DmPostRepository) List(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 	posts := make([]*model.DmPost, 0)
 
 	// テーブル数分ループして各テーブルからデータを取得
@@ -142,12 +208,14 @@ func (r *DmPostRepository) List(ctx context.Context, limit, offset int) ([]*mode
 
 		var tablePosts []*model.DmPost
 		// リトライ機能付きでクエリ実行
+		query, err := applyListQuery(conn.DB.WithContext(ctx).Table(tableName), q, model.DmPostListFields, "created_at DESC")
+		if err != nil {
+			return nil, err
+		}
+		// 結果全体でoffsetを適用するため、各テーブルからは先頭offset+limit件を取得
 		err = db.ExecuteWithRetry(func() error {
-			return conn.DB.WithContext(ctx).
-				Table(tableName).
-				Order("created_at DESC").
-				Limit(limit).
-				Offset(offset).
+			return query.Session(&gorm.Session{}).
+				Limit(offset + limit).
 				Find(&tablePosts).Error
 		})
 		if err != nil {
@@ -156,7 +224,7 @@ func (r *DmPostRepository) List(ctx context.Context, limit, offset int) ([]*mode
 		posts = append(posts, tablePosts...)
 	}
 
-	return posts, nil
+	return mergeListResults(posts, limit, offset, q, dmPostDefaultSorts, dmPostListValue), nil
 }
 
 // GetUserPosts はユーザーと投稿をJOINして取得（クロステーブルクエリ）
@@ -206,6 +274,23 @@ func (r *DmPostRepository) GetUserPosts(ctx context.Context, limit, offset int)
 
 // Update は投稿を更新
 func (r *DmPostRepository) Update(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error) {
+	updates := make(map[string]interface{})
+	if req.Title != "" {
+		updates["title"] = req.Title
+	}
+	if req.Content != "" {
+		updates["content"] = req.Content
+	}
+
+	return r.Patch(ctx, id, userID, updates)
+}
+
+// Patch は指定されたカラムのみを更新（fieldsが空の場合は更新せずに現在の値を返す）
+func (r *DmPostRepository) Patch(ctx context.Context, id string, userID string, fields map[string]interface{}) (*model.DmPost, error) {
+	if len(fields) == 0 {
+		return r.GetByID(ctx, id, userID)
+	}
+
 	// UserIDをキーとしてテーブル/DBを決定
 	tableName, err := r.tableSelector.GetTableNameFromUUID("dm_posts", userID)
 	if err != nil {
@@ -218,13 +303,7 @@ func (r *DmPostRepository) Update(ctx context.Context, id string, userID string,
 		return nil, fmt.Errorf("failed to get sharding connection: %w", err)
 	}
 
-	updates := make(map[string]interface{})
-	if req.Title != "" {
-		updates["title"] = req.Title
-	}
-	if req.Content != "" {
-		updates["content"] = req.Content
-	}
+	updates := maps.Clone(fields)
 	updates["updated_at"] = time.Now()
 
 	var result *gorm.DB
@@ -240,7 +319,7 @@ func (r *DmPostRepository) Update(ctx context.Context, id string, userID string,
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
 	if result.RowsAffected == 0 {
-		return nil, fmt.Errorf("post not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", ErrDmPostNotFound, id)
 	}
 
 	return r.GetByID(ctx, id, userID)
@@ -273,7 +352,7 @@ func (r *DmPostRepository) Delete(ctx context.Context, id string, userID string)
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("post not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrDmPostNotFound, id)
 	}
 
 	return nil
@@ -315,3 +394,162 @@ func (r *DmPostRepository) InsertDmPostsBatch(ctx context.Context, tableName str
 
 	return nil
 }
+
+// Search は全テーブルに並行して全文検索を実行し、結果をマージして返す
+// 各テーブルからoffset+limit件ずつ取得し、並び順に従ってマージした後にページングを適用する
+func (r *DmPostRepository) Search(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+	tableCount := r.tableSelector.GetTableCount()
+	perTable := q.Offset + q.Limit
+
+	results := make([][]*model.DmPostSearchHit, tableCount)
+	errs := make([]error, tableCount)
+	var wg sync.WaitGroup
+	for tableNum := 0; tableNum < tableCount; tableNum++ {
+		wg.Add(1)
+		go func(tableNum int) {
+			defer wg.Done()
+
+			// テーブル番号から接続を取得
+			conn, err := r.groupManager.GetShardingConnection(tableNum)
+			if err != nil {
+				errs[tableNum] = fmt.Errorf("failed to get connection for table %d: %w", tableNum, err)
+				return
+			}
+
+			tableName := fmt.Sprintf("dm_posts_%03d", tableNum)
+
+			var tableHits []*model.DmPostSearchHit
+			// リトライ機能付きでクエリ実行
+			err = db.ExecuteWithRetry(func() error {
+				query, err := applyDmPostSearch(conn.DB.WithContext(ctx).Table(tableName), conn.Driver, q, perTable)
+				if err != nil {
+					return err
+				}
+				return query.Find(&tableHits).Error
+			})
+			if err != nil {
+				errs[tableNum] = fmt.Errorf("failed to search table %s: %w", tableName, err)
+				return
+			}
+			results[tableNum] = tableHits
+		}(tableNum)
+	}
+	wg.Wait()
+
+	hits := make([]*model.DmPostSearchHit, 0)
+	for tableNum := range results {
+		if errs[tableNum] != nil {
+			return nil, errs[tableNum]
+		}
+		hits = append(hits, results[tableNum]...)
+	}
+
+	return mergeDmPostSearchHits(hits, q.Sort, q.Offset, q.Limit), nil
+}
+
+// StreamAll はフィルタ条件に一致する投稿を全テーブルからbatchSize件ずつ取得し、fnに渡す
+// テーブルごとにid昇順のキーセットページネーションで取得するため、メモリ使用量はbatchSize件分に抑えられる
+// filter.UserIDが指定された場合は、そのユーザーの投稿を格納するテーブルのみを対象とする
+func (r *DmPostRepository) StreamAll(ctx context.Context, filter *model.ExportFilter, batchSize int, fn func(dmPosts []*model.DmPost) error) error {
+	tableNumbers, err := r.exportTableNumbers(filter)
+	if err != nil {
+		return err
+	}
+
+	for _, tableNum := range tableNumbers {
+		// テーブル番号から接続を取得
+		conn, err := r.groupManager.GetShardingConnection(tableNum)
+		if err != nil {
+			return fmt.Errorf("failed to get connection for table %d: %w", tableNum, err)
+		}
+
+		tableName := fmt.Sprintf("dm_posts_%03d", tableNum)
+
+		lastID := ""
+		for {
+			var batch []*model.DmPost
+			// リトライ機能付きでクエリ実行
+			err = db.ExecuteWithRetry(func() error {
+				query := r.applyExportFilter(conn.DB.WithContext(ctx).Table(tableName), filter)
+				if lastID != "" {
+					query = query.Where("id > ?", lastID)
+				}
+				return query.Order("id").Limit(batchSize).Find(&batch).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to query table %s: %w", tableName, err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			if err := fn(batch); err != nil {
+				return err
+			}
+			if len(batch) < batchSize {
+				break
+			}
+			lastID = batch[len(batch)-1].ID
+		}
+	}
+
+	return nil
+}
+
+// CountAll はフィルタ条件に一致する投稿数を全テーブルから集計
+func (r *DmPostRepository) CountAll(ctx context.Context, filter *model.ExportFilter) (int64, error) {
+	tableNumbers, err := r.exportTableNumbers(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, tableNum := range tableNumbers {
+		// テーブル番号から接続を取得
+		conn, err := r.groupManager.GetShardingConnection(tableNum)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get connection for table %d: %w", tableNum, err)
+		}
+
+		tableName := fmt.Sprintf("dm_posts_%03d", tableNum)
+
+		var count int64
+		// リトライ機能付きでクエリ実行
+		err = db.ExecuteWithRetry(func() error {
+			return r.applyExportFilter(conn.DB.WithContext(ctx).Table(tableName), filter).Count(&count).Error
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to count table %s: %w", tableName, err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// exportTableNumbers はエクスポート対象のテーブル番号を返す
+// dm_postsはuser_idでシャーディングされているため、UserID指定時は1テーブルのみとなる
+func (r *DmPostRepository) exportTableNumbers(filter *model.ExportFilter) ([]int, error) {
+	if filter != nil && filter.UserID != "" {
+		tableNum, err := r.tableSelector.GetTableNumberFromUUID(filter.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table number: %w", err)
+		}
+		return []int{tableNum}, nil
+	}
+
+	tableCount := r.tableSelector.GetTableCount()
+	tableNumbers := make([]int, tableCount)
+	for i := range tableNumbers {
+		tableNumbers[i] = i
+	}
+	return tableNumbers, nil
+}
+
+// applyExportFilter はエクスポートフィルタをクエリに適用
+func (r *DmPostRepository) applyExportFilter(query *gorm.DB, filter *model.ExportFilter) *gorm.DB {
+	query = applyCreatedAtFilter(query, filter)
+	if filter != nil && filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	return query
+}
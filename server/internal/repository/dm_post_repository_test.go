@@ -102,6 +102,95 @@ func TestDmPostRepository_GetByID_NotFound(t *testing.T) {
 	assert.Nil(t, dmPost)
 }
 
+func TestDmPostRepository_GetByKeys(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmPostRepo := repository.NewDmPostRepository(groupManager)
+	ctx := context.Background()
+
+	// 異なるテーブルに配置されるよう2人分のユーザーIDで投稿を作成
+	var created []*model.DmPost
+	for i := 0; i < 2; i++ {
+		userID, err := idgen.GenerateUUIDv7()
+		require.NoError(t, err)
+		post, err := dmPostRepo.Create(ctx, &model.CreateDmPostRequest{
+			UserID:  userID,
+			Title:   fmt.Sprintf("Batch Post %d", i),
+			Content: "Test content",
+		})
+		require.NoError(t, err)
+		created = append(created, post)
+	}
+
+	// クリーンアップ
+	defer func() {
+		for _, post := range created {
+			_ = dmPostRepo.Delete(ctx, post.ID, post.UserID)
+		}
+	}()
+
+	keyA := model.DmPostKey{ID: created[0].ID, UserID: created[0].UserID}
+	keyB := model.DmPostKey{ID: created[1].ID, UserID: created[1].UserID}
+	// 投稿IDが存在してもユーザーIDが一致しないキーは見つからない
+	wrongUser := model.DmPostKey{ID: created[0].ID, UserID: created[1].UserID}
+
+	found, err := dmPostRepo.GetByKeys(ctx, []model.DmPostKey{keyA, keyB, wrongUser})
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+	assert.Equal(t, "Batch Post 0", found[keyA].Title)
+	assert.Equal(t, "Batch Post 1", found[keyB].Title)
+	assert.NotContains(t, found, wrongUser)
+}
+
+func TestDmPostRepository_ListByUserIDs(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmPostRepo := repository.NewDmPostRepository(groupManager)
+	ctx := context.Background()
+
+	// 2人分のユーザーIDでそれぞれ3件ずつ投稿を作成
+	var userIDs []string
+	var created []*model.DmPost
+	for i := 0; i < 2; i++ {
+		userID, err := idgen.GenerateUUIDv7()
+		require.NoError(t, err)
+		userIDs = append(userIDs, userID)
+		for j := 0; j < 3; j++ {
+			post, err := dmPostRepo.Create(ctx, &model.CreateDmPostRequest{
+				UserID:  userID,
+				Title:   fmt.Sprintf("User %d Post %d", i, j),
+				Content: "Test content",
+			})
+			require.NoError(t, err)
+			created = append(created, post)
+		}
+	}
+
+	// クリーンアップ
+	defer func() {
+		for _, post := range created {
+			_ = dmPostRepo.Delete(ctx, post.ID, post.UserID)
+		}
+	}()
+
+	// 投稿のないユーザーはマップに含まれない
+	noPostsUserID, err := idgen.GenerateUUIDv7()
+	require.NoError(t, err)
+
+	found, err := dmPostRepo.ListByUserIDs(ctx, append(userIDs, noPostsUserID), 2)
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+	for _, userID := range userIDs {
+		require.Len(t, found[userID], 2)
+		for _, post := range found[userID] {
+			assert.Equal(t, userID, post.UserID)
+		}
+	}
+	assert.NotContains(t, found, noPostsUserID)
+}
+
 func TestDmPostRepository_Update(t *testing.T) {
 	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
 	defer testutil.CleanupTestGroupManager(groupManager)
@@ -210,7 +299,7 @@ func TestDmPostRepository_ListByUserID(t *testing.T) {
 	}()
 
 	// Get posts by user ID
-	dmPosts, err := dmPostRepo.ListByUserID(ctx, userID, 10, 0)
+	dmPosts, err := dmPostRepo.ListByUserID(ctx, userID, 10, 0, nil)
 	assert.NoError(t, err)
 	assert.Len(t, dmPosts, 2)
 }
@@ -227,7 +316,7 @@ func TestDmPostRepository_List(t *testing.T) {
 	require.NoError(t, err)
 
 	// テスト前の件数を取得（特定テーブルのみ）
-	initialPosts, err := dmPostRepo.ListByUserID(ctx, userID, 1000, 0)
+	initialPosts, err := dmPostRepo.ListByUserID(ctx, userID, 1000, 0, nil)
 	require.NoError(t, err)
 	initialCount := len(initialPosts)
 
@@ -255,7 +344,7 @@ func TestDmPostRepository_List(t *testing.T) {
 	}()
 
 	// List posts by user ID (single table query)
-	dmPosts, err := dmPostRepo.ListByUserID(ctx, userID, 1000, 0)
+	dmPosts, err := dmPostRepo.ListByUserID(ctx, userID, 1000, 0, nil)
 	assert.NoError(t, err)
 	// 2件増えたことを確認
 	assert.Equal(t, initialCount+2, len(dmPosts))
@@ -409,3 +498,115 @@ func TestDmPostRepository_InsertDmPostsBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestDmPostRepository_StreamAllAndCountAll_UserIDFilter(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmPostRepo := repository.NewDmPostRepository(groupManager)
+	ctx := context.Background()
+
+	userID, err := idgen.GenerateUUIDv7()
+	require.NoError(t, err)
+
+	var created []*model.DmPost
+	for i := 0; i < 3; i++ {
+		dmPost, err := dmPostRepo.Create(ctx, &model.CreateDmPostRequest{
+			UserID:  userID,
+			Title:   fmt.Sprintf("Stream Post %d", i),
+			Content: "content",
+		})
+		require.NoError(t, err)
+		created = append(created, dmPost)
+	}
+	defer func() {
+		for _, p := range created {
+			_ = dmPostRepo.Delete(ctx, p.ID, p.UserID)
+		}
+	}()
+
+	filter := &model.ExportFilter{UserID: userID}
+
+	count, err := dmPostRepo.CountAll(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	var streamed []*model.DmPost
+	err = dmPostRepo.StreamAll(ctx, filter, 2, func(dmPosts []*model.DmPost) error {
+		streamed = append(streamed, dmPosts...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, streamed, 3)
+	for _, p := range streamed {
+		assert.Equal(t, userID, p.UserID)
+	}
+}
+
+func TestDmPostRepository_Search(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmPostRepo := repository.NewDmPostRepository(groupManager)
+	ctx := context.Background()
+
+	// 異なるテーブルに配置されるよう、ユーザーごとに投稿を作成
+	contents := []struct {
+		title   string
+		content string
+	}{
+		{"Search keyword alpha", "first post about gophers"},
+		{"Another post", "the keyword appears in content"},
+		{"東京の天気", "今日は晴れです"},
+		{"Unrelated", "nothing to see here"},
+	}
+	var created []*model.DmPost
+	for _, c := range contents {
+		userID, err := idgen.GenerateUUIDv7()
+		require.NoError(t, err)
+		dmPost, err := dmPostRepo.Create(ctx, &model.CreateDmPostRequest{UserID: userID, Title: c.title, Content: c.content})
+		require.NoError(t, err)
+		created = append(created, dmPost)
+	}
+	defer func() {
+		for _, dmPost := range created {
+			_ = dmPostRepo.Delete(ctx, dmPost.ID, dmPost.UserID)
+		}
+	}()
+
+	// 単語検索（全テーブルから該当する投稿を取得）
+	hits, err := dmPostRepo.Search(ctx, &model.DmPostSearchQuery{
+		Query: "keyword",
+		Mode:  model.DmPostSearchModeWord,
+		Sort:  model.DmPostSearchSortRelevance,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, hits, 2)
+	for _, hit := range hits {
+		assert.Greater(t, hit.Score, 0.0)
+	}
+
+	// 部分一致検索（分かち書きされない日本語）
+	hits, err = dmPostRepo.Search(ctx, &model.DmPostSearchQuery{
+		Query: "天気",
+		Mode:  model.DmPostSearchModeNgram,
+		Sort:  model.DmPostSearchSortDate,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, created[2].ID, hits[0].ID)
+
+	// ページング
+	hits, err = dmPostRepo.Search(ctx, &model.DmPostSearchQuery{
+		Query:  "keyword",
+		Mode:   model.DmPostSearchModeWord,
+		Sort:   model.DmPostSearchSortDate,
+		Limit:  1,
+		Offset: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, created[0].ID, hits[0].ID)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+)
+
+// 全文検索で使用する式（マイグレーションで作成したインデックスの式と一致させること）
+const (
+	// postgresSearchDocument は検索対象の文書（pg_trgmインデックスの式）
+	postgresSearchDocument = "(title || ' ' || content)"
+	// postgresSearchVector は検索対象のtsvector（GINインデックスの式）
+	// 'simple'は語幹処理を行わないため、言語に依存せず空白・記号区切りで単語化される
+	postgresSearchVector = "to_tsvector('simple', title || ' ' || content)"
+	// mysqlSearchMatch はFULLTEXTインデックス（ngramパーサー）の対象カラム
+	mysqlSearchMatch = "MATCH(title, content)"
+)
+
+// applyDmPostSearch は1テーブル分の全文検索条件・関連度・並び順をクエリに適用
+// 結果は上位limit件に絞り込む（ページングは全テーブルのマージ後に行う）
+func applyDmPostSearch(query *gorm.DB, driver string, q *model.DmPostSearchQuery, limit int) (*gorm.DB, error) {
+	switch driver {
+	case "postgres":
+		if q.Mode == model.DmPostSearchModeNgram {
+			query = query.
+				Select("*, word_similarity(?, "+postgresSearchDocument+") AS score", q.Query).
+				Where(postgresSearchDocument+" ILIKE ?", "%"+escapeLikePattern(q.Query)+"%")
+		} else {
+			query = query.
+				Select("*, ts_rank("+postgresSearchVector+", websearch_to_tsquery('simple', ?)) AS score", q.Query).
+				Where(postgresSearchVector+" @@ websearch_to_tsquery('simple', ?)", q.Query)
+		}
+	case "mysql":
+		against := "AGAINST (? IN NATURAL LANGUAGE MODE)"
+		text := q.Query
+		if q.Mode == model.DmPostSearchModeNgram {
+			// ngramトークンの連続として一致させるため、フレーズ検索にする
+			against = "AGAINST (? IN BOOLEAN MODE)"
+			text = `"` + strings.ReplaceAll(q.Query, `"`, " ") + `"`
+		}
+		query = query.
+			Select("*, "+mysqlSearchMatch+" "+against+" AS score", text).
+			Where(mysqlSearchMatch+" "+against, text)
+	default:
+		return nil, fmt.Errorf("full-text search is not supported for driver: %s", driver)
+	}
+
+	if q.Sort == model.DmPostSearchSortDate {
+		query = query.Order("created_at DESC").Order("id DESC")
+	} else {
+		query = query.Order("score DESC").Order("created_at DESC").Order("id DESC")
+	}
+	return query.Limit(limit), nil
+}
+
+// mergeDmPostSearchHits は各テーブルの検索結果を並び順に従ってマージし、offset/limitを適用
+func mergeDmPostSearchHits(hits []*model.DmPostSearchHit, sortBy string, offset, limit int) []*model.DmPostSearchHit {
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if sortBy != model.DmPostSearchSortDate && a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	if offset >= len(hits) {
+		return []*model.DmPostSearchHit{}
+	}
+	end := min(offset+limit, len(hits))
+	return hits[offset:end]
+}
+
+// escapeLikePattern はLIKEパターンのワイルドカード文字をエスケープ
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
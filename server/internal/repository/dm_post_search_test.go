@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// dryRunDB はSQL生成のみを行うGORMインスタンスを作成（DB接続は行わない）
+func dryRunDB(t *testing.T, driver string) *gorm.DB {
+	var dialector gorm.Dialector
+	if driver == "mysql" {
+		dialector = mysql.New(mysql.Config{DSN: "user:pass@tcp(localhost:3306)/db", SkipInitializeWithVersion: true})
+	} else {
+		dialector = postgres.New(postgres.Config{DSN: "host=localhost"})
+	}
+	gdb, err := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return gdb
+}
+
+func TestApplyDmPostSearch_SQL(t *testing.T) {
+	tests := []struct {
+		name         string
+		driver       string
+		mode         string
+		sort         string
+		wantContains []string
+		wantVars     []interface{}
+	}{
+		{
+			name:   "postgres word",
+			driver: "postgres",
+			mode:   model.DmPostSearchModeWord,
+			sort:   model.DmPostSearchSortRelevance,
+			wantContains: []string{
+				"ts_rank(to_tsvector('simple', title || ' ' || content), websearch_to_tsquery('simple', $1)) AS score",
+				"to_tsvector('simple', title || ' ' || content) @@ websearch_to_tsquery('simple', $2)",
+				"ORDER BY score DESC,created_at DESC,id DESC LIMIT $3",
+			},
+			wantVars: []interface{}{"50%_off", "50%_off", 30},
+		},
+		{
+			name:   "postgres ngram",
+			driver: "postgres",
+			mode:   model.DmPostSearchModeNgram,
+			sort:   model.DmPostSearchSortDate,
+			wantContains: []string{
+				"word_similarity($1, (title || ' ' || content)) AS score",
+				"(title || ' ' || content) ILIKE $2",
+				"ORDER BY created_at DESC,id DESC LIMIT $3",
+			},
+			wantVars: []interface{}{"50%_off", `%50\%\_off%`, 30},
+		},
+		{
+			name:   "mysql word",
+			driver: "mysql",
+			mode:   model.DmPostSearchModeWord,
+			sort:   model.DmPostSearchSortRelevance,
+			wantContains: []string{
+				"MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS score",
+				"WHERE MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			},
+			wantVars: []interface{}{"50%_off", "50%_off", 30},
+		},
+		{
+			name:   "mysql ngram",
+			driver: "mysql",
+			mode:   model.DmPostSearchModeNgram,
+			sort:   model.DmPostSearchSortRelevance,
+			wantContains: []string{
+				"MATCH(title, content) AGAINST (? IN BOOLEAN MODE) AS score",
+			},
+			wantVars: []interface{}{`"50%_off"`, `"50%_off"`, 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &model.DmPostSearchQuery{Query: "50%_off", Mode: tt.mode, Sort: tt.sort}
+			query, err := applyDmPostSearch(dryRunDB(t, tt.driver).Table("dm_posts_000"), tt.driver, q, 30)
+			require.NoError(t, err)
+
+			var hits []*model.DmPostSearchHit
+			stmt := query.Find(&hits).Statement
+			sql := stmt.SQL.String()
+			for _, want := range tt.wantContains {
+				assert.Contains(t, sql, want)
+			}
+			assert.Equal(t, tt.wantVars, stmt.Vars)
+		})
+	}
+}
+
+func TestApplyDmPostSearch_UnsupportedDriver(t *testing.T) {
+	_, err := applyDmPostSearch(nil, "sqlite", &model.DmPostSearchQuery{Query: "a"}, 10)
+	assert.Error(t, err)
+}
+
+func TestMergeDmPostSearchHits(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newHits := func() []*model.DmPostSearchHit {
+		return []*model.DmPostSearchHit{
+			{DmPost: model.DmPost{ID: "a", CreatedAt: base}, Score: 0.5},
+			{DmPost: model.DmPost{ID: "b", CreatedAt: base.Add(time.Hour)}, Score: 0.1},
+			{DmPost: model.DmPost{ID: "c", CreatedAt: base.Add(2 * time.Hour)}, Score: 0.5},
+			{DmPost: model.DmPost{ID: "d", CreatedAt: base.Add(3 * time.Hour)}, Score: 0.9},
+		}
+	}
+	ids := func(hits []*model.DmPostSearchHit) []string {
+		result := make([]string, len(hits))
+		for i, h := range hits {
+			result[i] = h.ID
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"d", "c", "a", "b"}, ids(mergeDmPostSearchHits(newHits(), model.DmPostSearchSortRelevance, 0, 10)))
+	assert.Equal(t, []string{"d", "c", "b", "a"}, ids(mergeDmPostSearchHits(newHits(), model.DmPostSearchSortDate, 0, 10)))
+	assert.Equal(t, []string{"c", "a"}, ids(mergeDmPostSearchHits(newHits(), model.DmPostSearchSortRelevance, 1, 2)))
+	assert.Empty(t, mergeDmPostSearchHits(newHits(), model.DmPostSearchSortRelevance, 10, 2))
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrDmUserIdentityNotFound はアカウントの紐づけが存在しない場合のエラー
+var ErrDmUserIdentityNotFound = apperror.NotFound("user identity not found")
+
+// ErrDmUserIdentityConflict はアカウントが既に紐づけられている場合のエラー
+var ErrDmUserIdentityConflict = apperror.Conflict("identity already linked")
+
+// DmUserIdentityRepository はOIDCのIssuerのアカウントとdm_usersの紐づけのデータアクセスを担当
+// 紐づけはmasterグループに配置する
+type DmUserIdentityRepository struct {
+	groupManager *db.GroupManager
+}
+
+// NewDmUserIdentityRepository は新しいDmUserIdentityRepositoryを作成
+func NewDmUserIdentityRepository(groupManager *db.GroupManager) *DmUserIdentityRepository {
+	return &DmUserIdentityRepository{
+		groupManager: groupManager,
+	}
+}
+
+// Create はアカウントの紐づけを登録（issとsubが登録済みの場合はErrDmUserIdentityConflict）
+func (r *DmUserIdentityRepository) Create(ctx context.Context, identity *model.DmUserIdentity) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	// リトライ機能付きでGORM APIで作成
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_user_identities").Create(identity).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%w: %s %s", ErrDmUserIdentityConflict, identity.Issuer, identity.Subject)
+		}
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return nil
+}
+
+// GetByIssuerSubject はissとsubでアカウントの紐づけを取得
+func (r *DmUserIdentityRepository) GetByIssuerSubject(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var identity model.DmUserIdentity
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_user_identities").Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s %s", ErrDmUserIdentityNotFound, issuer, subject)
+		}
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// ListByDmUserID はユーザーに紐づくアカウントを登録順に取得
+func (r *DmUserIdentityRepository) ListByDmUserID(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	identities := make([]*model.DmUserIdentity, 0)
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_user_identities").Where("dm_user_id = ?", dmUserID).Order("id ASC").Find(&identities).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// Delete はユーザーに紐づくアカウントの紐づけを削除（他のユーザーの紐づけの場合はErrDmUserIdentityNotFound）
+func (r *DmUserIdentityRepository) Delete(ctx context.Context, dmUserID string, id int64) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var result *gorm.DB
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		result = conn.DB.WithContext(ctx).Table("dm_user_identities").Where("id = ? AND dm_user_id = ?", id, dmUserID).Delete(&model.DmUserIdentity{})
+		return result.Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete user identity: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", ErrDmUserIdentityNotFound, id)
+	}
+
+	return nil
+}
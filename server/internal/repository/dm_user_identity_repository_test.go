@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/test/testutil"
+)
+
+func TestDmUserIdentityRepository(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	repo := repository.NewDmUserIdentityRepository(groupManager)
+	ctx := context.Background()
+
+	const dmUserID = "019a1b2c3d4e7f00a123456789abcdef"
+	auth0 := &model.DmUserIdentity{Issuer: "https://example.auth0.com/", Subject: "auth0|123", DmUserID: dmUserID, Email: "user@example.com"}
+	require.NoError(t, repo.Create(ctx, auth0))
+	require.NotZero(t, auth0.ID)
+	keycloak := &model.DmUserIdentity{Issuer: "https://keycloak.example.com/realms/app", Subject: "456", DmUserID: dmUserID, Email: "user@example.com"}
+	require.NoError(t, repo.Create(ctx, keycloak))
+
+	// 同じissとsubは紐づけられない
+	duplicate := &model.DmUserIdentity{Issuer: auth0.Issuer, Subject: auth0.Subject, DmUserID: "019a1b2c3d4e7f00a123456789abcdee", Email: "other@example.com"}
+	assert.ErrorIs(t, repo.Create(ctx, duplicate), repository.ErrDmUserIdentityConflict)
+
+	got, err := repo.GetByIssuerSubject(ctx, auth0.Issuer, auth0.Subject)
+	require.NoError(t, err)
+	assert.Equal(t, dmUserID, got.DmUserID)
+
+	identities, err := repo.ListByDmUserID(ctx, dmUserID)
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	assert.Equal(t, auth0.ID, identities[0].ID)
+
+	// 他のユーザーの紐づけは削除できない
+	assert.ErrorIs(t, repo.Delete(ctx, "019a1b2c3d4e7f00a123456789abcdee", keycloak.ID), repository.ErrDmUserIdentityNotFound)
+	require.NoError(t, repo.Delete(ctx, dmUserID, keycloak.ID))
+
+	_, err = repo.GetByIssuerSubject(ctx, keycloak.Issuer, keycloak.Subject)
+	assert.ErrorIs(t, err, repository.ErrDmUserIdentityNotFound)
+}
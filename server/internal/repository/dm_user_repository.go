@@ -4,16 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/db"
 	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/util/idgen"
 	"gorm.io/gorm"
 )
 
+// ErrDmUserNotFound はユーザーが存在しない場合のエラー
+var ErrDmUserNotFound = apperror.NotFound("user not found")
+
+// ErrDmUserEmailConflict はメールアドレスが既に登録されている場合のエラー
+var ErrDmUserEmailConflict = apperror.Conflict("email already exists")
+
 // DmUserRepository はユーザーのデータアクセスを担当
 type DmUserRepository struct {
 	groupManager  *db.GroupManager
@@ -59,6 +67,9 @@ func (r *DmUserRepository) Create(ctx context.Context, req *model.CreateDmUserRe
 		return conn.DB.WithContext(ctx).Table(tableName).Create(user).Error
 	})
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("%w: %s", ErrDmUserEmailConflict, req.Email)
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -86,7 +97,7 @@ func (r *DmUserRepository) GetByID(ctx context.Context, id string) (*model.DmUse
 	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user not found: %s", id)
+			return nil, fmt.Errorf("%w: %s", ErrDmUserNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -94,8 +105,27 @@ func (r *DmUserRepository) GetByID(ctx context.Context, id string) (*model.DmUse
 	return &user, nil
 }
 
+// GetByIDs は複数のIDでユーザーを取得し、IDをキーとするマップで返す
+// IDをテーブルごとにまとめ、テーブルごとに1回のクエリを並行して実行する。見つからないIDはマップに含まれない
+func (r *DmUserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*model.DmUser, error) {
+	idsByTable := groupIDsByTable(r.tableSelector, ids, func(i int) string { return ids[i] })
+
+	users, err := findByIDsInTables[model.DmUser](ctx, r.groupManager, "dm_users", idsByTable)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*model.DmUser, len(users))
+	for _, user := range users {
+		found[user.ID] = user
+	}
+	return found, nil
+}
+
 // List はすべてのユーザーを取得（クロステーブルクエリ）
-func (r *DmUserRepository) List(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+// qのフィルタ・ソート条件は各テーブルに同じように適用され、結果全体もソート条件に従って並べ替える
+// offset・limitは並べ替えた結果全体に適用する
+func (r *DmUserRepository) List(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 	users := make([]*model.DmUser, 0)
 
 	// テーブル数分ループして各テーブルからデータを取得
@@ -111,12 +141,14 @@ func (r *DmUserRepository) List(ctx context.Context, limit, offset int) ([]*mode
 
 		var tableUsers []*model.DmUser
 		// リトライ機能付きでクエリ実行
+		query, err := applyListQuery(conn.DB.WithContext(ctx).Table(tableName), q, model.DmUserListFields, "id")
+		if err != nil {
+			return nil, err
+		}
+		// 結果全体でoffsetを適用するため、各テーブルからは先頭offset+limit件を取得
 		err = db.ExecuteWithRetry(func() error {
-			return conn.DB.WithContext(ctx).
-				Table(tableName).
-				Order("id").
-				Limit(limit).
-				Offset(offset).
+			return query.Session(&gorm.Session{}).
+				Limit(offset + limit).
 				Find(&tableUsers).Error
 		})
 		if err != nil {
@@ -125,11 +157,28 @@ func (r *DmUserRepository) List(ctx context.Context, limit, offset int) ([]*mode
 		users = append(users, tableUsers...)
 	}
 
-	return users, nil
+	return mergeListResults(users, limit, offset, q, dmUserDefaultSorts, dmUserListValue), nil
 }
 
 // Update はユーザーを更新
 func (r *DmUserRepository) Update(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error) {
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Email != "" {
+		updates["email"] = req.Email
+	}
+
+	return r.Patch(ctx, id, updates)
+}
+
+// Patch は指定されたカラムのみを更新（fieldsが空の場合は更新せずに現在の値を返す）
+func (r *DmUserRepository) Patch(ctx context.Context, id string, fields map[string]interface{}) (*model.DmUser, error) {
+	if len(fields) == 0 {
+		return r.GetByID(ctx, id)
+	}
+
 	// テーブル名の生成
 	tableName, err := r.tableSelector.GetTableNameFromUUID("dm_users", id)
 	if err != nil {
@@ -142,13 +191,7 @@ func (r *DmUserRepository) Update(ctx context.Context, id string, req *model.Upd
 		return nil, fmt.Errorf("failed to get sharding connection: %w", err)
 	}
 
-	updates := make(map[string]interface{})
-	if req.Name != "" {
-		updates["name"] = req.Name
-	}
-	if req.Email != "" {
-		updates["email"] = req.Email
-	}
+	updates := maps.Clone(fields)
 	updates["updated_at"] = time.Now()
 
 	var result *gorm.DB
@@ -161,7 +204,7 @@ func (r *DmUserRepository) Update(ctx context.Context, id string, req *model.Upd
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 	if result.RowsAffected == 0 {
-		return nil, fmt.Errorf("user not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", ErrDmUserNotFound, id)
 	}
 
 	return r.GetByID(ctx, id)
@@ -191,7 +234,7 @@ func (r *DmUserRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrDmUserNotFound, id)
 	}
 
 	return nil
@@ -225,6 +268,32 @@ func (r *DmUserRepository) CheckEmailExists(ctx context.Context, email string) (
 	return false, nil
 }
 
+// FindExistingEmails は指定したメールアドレスのうち既に登録されているものを返す（全シャード検索）
+// 全テーブルに対してemail IN (...)のクエリを並行して実行する
+func (r *DmUserRepository) FindExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	if len(emails) == 0 {
+		return found, nil
+	}
+
+	emailsByTable := make(map[int][]string)
+	for tableNum := 0; tableNum < r.tableSelector.GetTableCount(); tableNum++ {
+		emailsByTable[tableNum] = emails
+	}
+
+	users, err := queryInTables[model.DmUser](ctx, r.groupManager, "dm_users", emailsByTable, func(tx *gorm.DB, tableName string, emails []string) *gorm.DB {
+		return tx.Table(tableName).Select("email").Where("email IN ?", emails)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		found[user.Email] = true
+	}
+	return found, nil
+}
+
 // InsertDmUsersBatch はdm_usersテーブルにバッチでデータを挿入
 func (r *DmUserRepository) InsertDmUsersBatch(ctx context.Context, tableName string, dmUsers []*model.DmUser) error {
 	if len(dmUsers) == 0 {
@@ -263,6 +332,77 @@ func (r *DmUserRepository) InsertDmUsersBatch(ctx context.Context, tableName str
 	return nil
 }
 
+// StreamAll はフィルタ条件に一致するユーザーを全テーブルからbatchSize件ずつ取得し、fnに渡す
+// テーブルごとにid昇順のキーセットページネーションで取得するため、メモリ使用量はbatchSize件分に抑えられる
+func (r *DmUserRepository) StreamAll(ctx context.Context, filter *model.ExportFilter, batchSize int, fn func(dmUsers []*model.DmUser) error) error {
+	tableCount := r.tableSelector.GetTableCount()
+	for tableNum := 0; tableNum < tableCount; tableNum++ {
+		// テーブル番号から接続を取得
+		conn, err := r.groupManager.GetShardingConnection(tableNum)
+		if err != nil {
+			return fmt.Errorf("failed to get connection for table %d: %w", tableNum, err)
+		}
+
+		tableName := fmt.Sprintf("dm_users_%03d", tableNum)
+
+		lastID := ""
+		for {
+			var batch []*model.DmUser
+			// リトライ機能付きでクエリ実行
+			err = db.ExecuteWithRetry(func() error {
+				query := applyCreatedAtFilter(conn.DB.WithContext(ctx).Table(tableName), filter)
+				if lastID != "" {
+					query = query.Where("id > ?", lastID)
+				}
+				return query.Order("id").Limit(batchSize).Find(&batch).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to query table %s: %w", tableName, err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			if err := fn(batch); err != nil {
+				return err
+			}
+			if len(batch) < batchSize {
+				break
+			}
+			lastID = batch[len(batch)-1].ID
+		}
+	}
+
+	return nil
+}
+
+// CountAll はフィルタ条件に一致するユーザー数を全テーブルから集計
+func (r *DmUserRepository) CountAll(ctx context.Context, filter *model.ExportFilter) (int64, error) {
+	var total int64
+
+	tableCount := r.tableSelector.GetTableCount()
+	for tableNum := 0; tableNum < tableCount; tableNum++ {
+		// テーブル番号から接続を取得
+		conn, err := r.groupManager.GetShardingConnection(tableNum)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get connection for table %d: %w", tableNum, err)
+		}
+
+		tableName := fmt.Sprintf("dm_users_%03d", tableNum)
+
+		var count int64
+		// リトライ機能付きでクエリ実行
+		err = db.ExecuteWithRetry(func() error {
+			return applyCreatedAtFilter(conn.DB.WithContext(ctx).Table(tableName), filter).Count(&count).Error
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to count table %s: %w", tableName, err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
 // extractTableNumber はテーブル名からテーブル番号を抽出
 func extractTableNumber(tableName, prefix string) (int, error) {
 	if !strings.HasPrefix(tableName, prefix) {
@@ -96,6 +96,45 @@ func TestDmUserRepository_GetByID_NotFound(t *testing.T) {
 	assert.Nil(t, dmUser)
 }
 
+func TestDmUserRepository_GetByIDs(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmUserRepo := repository.NewDmUserRepository(groupManager)
+	ctx := context.Background()
+
+	var created []*model.DmUser
+	for i := 0; i < 3; i++ {
+		uniqueID, err := idgen.GenerateUUIDv7()
+		require.NoError(t, err)
+		user, err := dmUserRepo.Create(ctx, &model.CreateDmUserRequest{
+			Name:  fmt.Sprintf("Batch User %d", i),
+			Email: fmt.Sprintf("test-%s@example.com", uniqueID),
+		})
+		require.NoError(t, err)
+		created = append(created, user)
+	}
+
+	// クリーンアップ
+	defer func() {
+		for _, user := range created {
+			_ = dmUserRepo.Delete(ctx, user.ID)
+		}
+	}()
+
+	missingID, err := idgen.GenerateUUIDv7()
+	require.NoError(t, err)
+
+	found, err := dmUserRepo.GetByIDs(ctx, []string{created[2].ID, missingID, created[0].ID, created[1].ID})
+	require.NoError(t, err)
+	assert.Len(t, found, 3)
+	for i, user := range created {
+		require.Contains(t, found, user.ID)
+		assert.Equal(t, fmt.Sprintf("Batch User %d", i), found[user.ID].Name)
+	}
+	assert.NotContains(t, found, missingID)
+}
+
 func TestDmUserRepository_Update(t *testing.T) {
 	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
 	defer testutil.CleanupTestGroupManager(groupManager)
@@ -140,6 +179,41 @@ func TestDmUserRepository_Update(t *testing.T) {
 	assert.Equal(t, updatedEmail, dmUser.Email)
 }
 
+
+func TestDmUserRepository_Patch(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmUserRepo := repository.NewDmUserRepository(groupManager)
+	ctx := context.Background()
+
+	uniqueID, err := idgen.GenerateUUIDv7()
+	require.NoError(t, err)
+	email := fmt.Sprintf("patch-%s@example.com", uniqueID)
+
+	created, err := dmUserRepo.Create(ctx, &model.CreateDmUserRequest{Name: "Original Name", Email: email})
+	require.NoError(t, err)
+	defer func() {
+		_ = dmUserRepo.Delete(ctx, created.ID)
+	}()
+
+	// 指定したカラムのみ更新される
+	patched, err := dmUserRepo.Patch(ctx, created.ID, map[string]interface{}{"name": "Patched Name"})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched Name", patched.Name)
+	assert.Equal(t, email, patched.Email)
+
+	// 空のパッチは更新せずに現在の値を返す
+	unchanged, err := dmUserRepo.Patch(ctx, created.ID, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched Name", unchanged.Name)
+
+	// 存在しないユーザー
+	missingID, err := idgen.GenerateUUIDv7()
+	require.NoError(t, err)
+	_, err = dmUserRepo.Patch(ctx, missingID, map[string]interface{}{"name": "x"})
+	assert.Error(t, err)
+}
 func TestDmUserRepository_Delete(t *testing.T) {
 	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
 	defer testutil.CleanupTestGroupManager(groupManager)
@@ -369,3 +443,80 @@ func TestDmUserRepository_CheckEmailExists(t *testing.T) {
 		})
 	}
 }
+
+func TestDmUserRepository_FindExistingEmails(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmUserRepo := repository.NewDmUserRepository(groupManager)
+	ctx := context.Background()
+
+	uniqueID, err := idgen.GenerateUUIDv7()
+	require.NoError(t, err)
+	existingEmail := fmt.Sprintf("existing-%s@example.com", uniqueID)
+	nonExistingEmail := fmt.Sprintf("non-existing-%s@example.com", uniqueID)
+
+	created, err := dmUserRepo.Create(ctx, &model.CreateDmUserRequest{
+		Name:  "Test User",
+		Email: existingEmail,
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = dmUserRepo.Delete(ctx, created.ID)
+	}()
+
+	found, err := dmUserRepo.FindExistingEmails(ctx, []string{existingEmail, nonExistingEmail})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{existingEmail: true}, found)
+
+	found, err = dmUserRepo.FindExistingEmails(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestDmUserRepository_StreamAllAndCountAll(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	dmUserRepo := repository.NewDmUserRepository(groupManager)
+	ctx := context.Background()
+
+	// 複数テーブルに分散するようにユーザーを作成
+	created := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		uniqueID, err := idgen.GenerateUUIDv7()
+		require.NoError(t, err)
+		dmUser, err := dmUserRepo.Create(ctx, &model.CreateDmUserRequest{
+			Name:  fmt.Sprintf("Stream User %d", i),
+			Email: fmt.Sprintf("stream-%s@example.com", uniqueID),
+		})
+		require.NoError(t, err)
+		created[dmUser.ID] = true
+	}
+	defer func() {
+		for id := range created {
+			_ = dmUserRepo.Delete(ctx, id)
+		}
+	}()
+
+	count, err := dmUserRepo.CountAll(ctx, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(len(created)))
+
+	// バッチサイズ2で全件取得し、作成したユーザーがすべて含まれることを確認
+	seen := 0
+	streamed := int64(0)
+	err = dmUserRepo.StreamAll(ctx, nil, 2, func(dmUsers []*model.DmUser) error {
+		assert.LessOrEqual(t, len(dmUsers), 2)
+		for _, u := range dmUsers {
+			if created[u.ID] {
+				seen++
+			}
+		}
+		streamed += int64(len(dmUsers))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, len(created), seen)
+	assert.Equal(t, count, streamed)
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrWebhookSubscriptionNotFound は購読設定が存在しない（削除された）場合のエラー
+var ErrWebhookSubscriptionNotFound = apperror.NotFound("webhook subscription not found")
+
+// ErrWebhookDeliveryNotFound は配信ログが存在しない場合のエラー
+var ErrWebhookDeliveryNotFound = apperror.NotFound("webhook delivery not found")
+
+// DmWebhookRepository はWebhookの購読設定・配信ログのデータアクセスを担当
+// 購読設定・配信ログはmasterグループに配置する
+type DmWebhookRepository struct {
+	groupManager *db.GroupManager
+}
+
+// NewDmWebhookRepository は新しいDmWebhookRepositoryを作成
+func NewDmWebhookRepository(groupManager *db.GroupManager) *DmWebhookRepository {
+	return &DmWebhookRepository{
+		groupManager: groupManager,
+	}
+}
+
+// ListActiveSubscriptions は有効な購読設定をID順に取得
+func (r *DmWebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]*model.DmWebhookSubscription, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	subscriptions := make([]*model.DmWebhookSubscription, 0)
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_webhook_subscriptions").Where("active = ?", true).Order("id").Find(&subscriptions).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetSubscription はIDで購読設定を取得
+func (r *DmWebhookRepository) GetSubscription(ctx context.Context, id int64) (*model.DmWebhookSubscription, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var subscription model.DmWebhookSubscription
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_webhook_subscriptions").Where("id = ?", id).First(&subscription).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrWebhookSubscriptionNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// CreateSubscription は購読設定を作成
+func (r *DmWebhookRepository) CreateSubscription(ctx context.Context, subscription *model.DmWebhookSubscription) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	// リトライ機能付きでGORM APIで作成
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_webhook_subscriptions").Create(subscription).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDelivery は配信ログを作成
+func (r *DmWebhookRepository) CreateDelivery(ctx context.Context, delivery *model.DmWebhookDelivery) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	// リトライ機能付きでGORM APIで作成
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_webhook_deliveries").Create(delivery).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetDelivery はIDで配信ログを取得
+func (r *DmWebhookRepository) GetDelivery(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var delivery model.DmWebhookDelivery
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("dm_webhook_deliveries").Where("id = ?", id).First(&delivery).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrWebhookDeliveryNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// UpdateDelivery は配信ログの指定されたカラムを更新
+func (r *DmWebhookRepository) UpdateDelivery(ctx context.Context, id int64, fields map[string]interface{}) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	updates := maps.Clone(fields)
+	updates["updated_at"] = time.Now()
+
+	var result *gorm.DB
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		result = conn.DB.WithContext(ctx).Table("dm_webhook_deliveries").Where("id = ?", id).Updates(updates)
+		return result.Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", ErrWebhookDeliveryNotFound, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/test/testutil"
+)
+
+func TestDmWebhookRepository_Subscriptions(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	repo := repository.NewDmWebhookRepository(groupManager)
+	ctx := context.Background()
+
+	active := &model.DmWebhookSubscription{Name: "partner", TargetURL: "http://localhost/hook", Secret: "s3cret", Events: "user.*", Active: true}
+	require.NoError(t, repo.CreateSubscription(ctx, active))
+	inactive := &model.DmWebhookSubscription{Name: "disabled", TargetURL: "http://localhost/hook2", Secret: "s3cret", Events: "*", Active: false}
+	require.NoError(t, repo.CreateSubscription(ctx, inactive))
+
+	subscriptions, err := repo.ListActiveSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, active.ID, subscriptions[0].ID)
+	assert.Equal(t, "s3cret", subscriptions[0].Secret)
+
+	got, err := repo.GetSubscription(ctx, inactive.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Active)
+
+	_, err = repo.GetSubscription(ctx, inactive.ID+1000)
+	assert.ErrorIs(t, err, repository.ErrWebhookSubscriptionNotFound)
+}
+
+func TestDmWebhookRepository_Deliveries(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	repo := repository.NewDmWebhookRepository(groupManager)
+	ctx := context.Background()
+
+	delivery := &model.DmWebhookDelivery{
+		SubscriptionID: 1,
+		Event:          model.WebhookEventUserCreated,
+		EventID:        "evt1",
+		Payload:        `{"id":"evt1"}`,
+		Status:         model.WebhookDeliveryStatusPending,
+	}
+	require.NoError(t, repo.CreateDelivery(ctx, delivery))
+	require.NotZero(t, delivery.ID)
+
+	require.NoError(t, repo.UpdateDelivery(ctx, delivery.ID, map[string]interface{}{
+		"status":          model.WebhookDeliveryStatusRetrying,
+		"attempts":        1,
+		"response_status": 500,
+		"last_error":      "unexpected status 500",
+	}))
+
+	got, err := repo.GetDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.WebhookDeliveryStatusRetrying, got.Status)
+	assert.Equal(t, 1, got.Attempts)
+	require.NotNil(t, got.ResponseStatus)
+	assert.Equal(t, 500, *got.ResponseStatus)
+	require.NotNil(t, got.LastError)
+	assert.Equal(t, "unexpected status 500", *got.LastError)
+	assert.Nil(t, got.DeliveredAt)
+
+	assert.Error(t, repo.UpdateDelivery(ctx, delivery.ID+1000, map[string]interface{}{"attempts": 2}))
+	_, err = repo.GetDelivery(ctx, delivery.ID+1000)
+	assert.Error(t, err)
+}
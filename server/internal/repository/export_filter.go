@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+)
+
+// applyCreatedAtFilter はエクスポートフィルタの作成日時条件をクエリに適用
+func applyCreatedAtFilter(query *gorm.DB, filter *model.ExportFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	return query
+}
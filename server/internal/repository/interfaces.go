@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/taku-o/go-webdb-template/internal/model"
 )
@@ -10,8 +11,10 @@ import (
 type DmUserRepositoryInterface interface {
 	Create(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error)
 	GetByID(ctx context.Context, id string) (*model.DmUser, error)
-	List(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+	GetByIDs(ctx context.Context, ids []string) (map[string]*model.DmUser, error)
+	List(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error)
 	Update(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error)
+	Patch(ctx context.Context, id string, fields map[string]interface{}) (*model.DmUser, error)
 	Delete(ctx context.Context, id string) error
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 }
@@ -20,9 +23,59 @@ type DmUserRepositoryInterface interface {
 type DmPostRepositoryInterface interface {
 	Create(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
 	GetByID(ctx context.Context, id string, userID string) (*model.DmPost, error)
-	ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error)
-	List(ctx context.Context, limit, offset int) ([]*model.DmPost, error)
+	GetByKeys(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error)
+	ListByUserID(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error)
+	ListByUserIDs(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error)
+	List(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error)
 	GetUserPosts(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
 	Update(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
+	Patch(ctx context.Context, id string, userID string, fields map[string]interface{}) (*model.DmPost, error)
 	Delete(ctx context.Context, id string, userID string) error
+	Search(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error)
+}
+
+// DmNewsRepositoryInterface はDmNewsRepositoryの共通インターフェース
+type DmNewsRepositoryInterface interface {
+	Create(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error)
+	GetByID(ctx context.Context, id int64) (*model.DmNews, error)
+	ListPublished(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error)
+	Update(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error)
+	Patch(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error)
+	Delete(ctx context.Context, id int64) error
+	ListFeedItems(ctx context.Context, now time.Time, limit int) ([]*model.DmNewsFeedItem, error)
+	GetNextPublishAt(ctx context.Context, now time.Time) (*time.Time, error)
+}
+
+// DmWebhookRepositoryInterface はDmWebhookRepositoryの共通インターフェース
+type DmWebhookRepositoryInterface interface {
+	ListActiveSubscriptions(ctx context.Context) ([]*model.DmWebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*model.DmWebhookSubscription, error)
+	CreateSubscription(ctx context.Context, subscription *model.DmWebhookSubscription) error
+	CreateDelivery(ctx context.Context, delivery *model.DmWebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*model.DmWebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, id int64, fields map[string]interface{}) error
+}
+
+// APIKeyRepositoryInterface はAPIKeyRepositoryの共通インターフェース
+type APIKeyRepositoryInterface interface {
+	Create(ctx context.Context, apiKey *model.APIKey) error
+	GetByJTI(ctx context.Context, jti string) (*model.APIKey, error)
+	List(ctx context.Context, limit, offset int) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, jti string, revokedAt time.Time) error
+	UpdateLastUsedAt(ctx context.Context, jti string, usedAt time.Time) error
+}
+
+// DmUserIdentityRepositoryInterface はDmUserIdentityRepositoryの共通インターフェース
+type DmUserIdentityRepositoryInterface interface {
+	Create(ctx context.Context, identity *model.DmUserIdentity) error
+	GetByIssuerSubject(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error)
+	ListByDmUserID(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error)
+	Delete(ctx context.Context, dmUserID string, id int64) error
+}
+
+// RateLimitPlanRepositoryInterface はRateLimitPlanRepositoryの共通インターフェース
+type RateLimitPlanRepositoryInterface interface {
+	GetByName(ctx context.Context, name string) (*model.RateLimitPlan, error)
+	GetAssignedPlanName(ctx context.Context, principalType, principalID string) (string, error)
+	Assign(ctx context.Context, assignment *model.RateLimitPlanAssignment) error
 }
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// listFilterOperators はフィルタ演算子とSQL演算子の対応
+var listFilterOperators = map[string]string{
+	model.ListFilterOpEq:       "=",
+	model.ListFilterOpNe:       "<>",
+	model.ListFilterOpGt:       ">",
+	model.ListFilterOpGte:      ">=",
+	model.ListFilterOpLt:       "<",
+	model.ListFilterOpLte:      "<=",
+	model.ListFilterOpPrefix:   "LIKE",
+	model.ListFilterOpContains: "LIKE",
+}
+
+// applyListQuery は一覧取得のフィルタ・ソート条件をGORMの句に変換してクエリに適用
+// ソート指定がない場合はdefaultOrderを使用し、指定がある場合はidを最終キーとして順序を確定させる
+func applyListQuery(query *gorm.DB, q *model.ListQuery, fields []model.ListField, defaultOrder string) (*gorm.DB, error) {
+	if q == nil {
+		return query.Order(defaultOrder), nil
+	}
+
+	for _, f := range q.Filters {
+		if !isListField(fields, f.Field, false) {
+			return nil, fmt.Errorf("unsupported filter field: %s", f.Field)
+		}
+		op, ok := listFilterOperators[f.Op]
+		if !ok {
+			return nil, fmt.Errorf("unsupported filter operator: %s", f.Op)
+		}
+
+		value := f.Value
+		switch f.Op {
+		case model.ListFilterOpPrefix:
+			value = escapeLikePattern(fmt.Sprint(f.Value)) + "%"
+		case model.ListFilterOpContains:
+			value = "%" + escapeLikePattern(fmt.Sprint(f.Value)) + "%"
+		}
+		query = query.Where(fmt.Sprintf("? %s ?", op), clause.Column{Name: f.Field}, value)
+	}
+
+	if len(q.Sorts) == 0 {
+		return query.Order(defaultOrder), nil
+	}
+	hasID := false
+	for _, s := range q.Sorts {
+		if !isListField(fields, s.Field, true) {
+			return nil, fmt.Errorf("unsupported sort field: %s", s.Field)
+		}
+		hasID = hasID || s.Field == "id"
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Field}, Desc: s.Desc})
+	}
+	if !hasID {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+	}
+	return query, nil
+}
+
+// isListField は許可フィールドに含まれるかを判定
+func isListField(fields []model.ListField, name string, sortable bool) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return !sortable || f.Sortable
+		}
+	}
+	return false
+}
+
+// mergeListResults は複数テーブルから先頭offset+limit件ずつ取得した結果をソート条件に従って並べ替え、結果全体にoffset・limitを適用
+// ソート指定がない場合は各テーブルのデフォルトの並び順に対応するdefaultSortsで並べ替える
+func mergeListResults[T any](items []T, limit, offset int, q *model.ListQuery, defaultSorts []model.ListSort, value func(item T, field string) interface{}) []T {
+	sorts := defaultSorts
+	if q != nil && len(q.Sorts) > 0 {
+		sorts = q.Sorts
+	}
+	sortListResults(items, sorts, value)
+
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// sortListResults は複数テーブルから取得した結果をソート条件に従って並べ替え
+// 各テーブルで適用した並び順を結果全体でも保つために使用する
+func sortListResults[T any](items []T, sorts []model.ListSort, value func(item T, field string) interface{}) {
+	if len(sorts) == 0 {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b T) int {
+		for _, s := range sorts {
+			c := compareListValues(value(a, s.Field), value(b, s.Field))
+			if s.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return compareListValues(value(a, "id"), value(b, "id"))
+	})
+}
+
+// compareListValues はフィールド値を比較
+func compareListValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		bv, _ := b.(string)
+		return cmp.Compare(av, bv)
+	case time.Time:
+		bv, _ := b.(time.Time)
+		return av.Compare(bv)
+	}
+	return 0
+}
+
+// dmUserDefaultSorts はdm_usersのデフォルトの並び順（id昇順）
+var dmUserDefaultSorts = []model.ListSort{{Field: "id"}}
+
+// dmPostDefaultSorts はdm_postsのデフォルトの並び順（created_at降順）
+var dmPostDefaultSorts = []model.ListSort{{Field: "created_at", Desc: true}}
+
+// dmUserListValue はdm_usersのフィールド値を取得
+func dmUserListValue(u *model.DmUser, field string) interface{} {
+	switch field {
+	case "id":
+		return u.ID
+	case "name":
+		return u.Name
+	case "email":
+		return u.Email
+	case "created_at":
+		return u.CreatedAt
+	case "updated_at":
+		return u.UpdatedAt
+	}
+	return nil
+}
+
+// dmPostListValue はdm_postsのフィールド値を取得
+func dmPostListValue(p *model.DmPost, field string) interface{} {
+	switch field {
+	case "id":
+		return p.ID
+	case "user_id":
+		return p.UserID
+	case "title":
+		return p.Title
+	case "content":
+		return p.Content
+	case "created_at":
+		return p.CreatedAt
+	case "updated_at":
+		return p.UpdatedAt
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestApplyListQuery_SQL(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := &model.ListQuery{
+		Filters: []model.ListFilter{
+			{Field: "name", Op: model.ListFilterOpPrefix, Value: "ta_k"},
+			{Field: "email", Op: model.ListFilterOpContains, Value: "example"},
+			{Field: "created_at", Op: model.ListFilterOpGte, Value: from},
+		},
+		Sorts: []model.ListSort{{Field: "created_at", Desc: true}},
+	}
+
+	tests := []struct {
+		driver       string
+		wantContains []string
+	}{
+		{
+			driver: "postgres",
+			wantContains: []string{
+				`"name" LIKE $1 AND "email" LIKE $2 AND "created_at" >= $3`,
+				`ORDER BY "created_at" DESC,"id"`,
+			},
+		},
+		{
+			driver: "mysql",
+			wantContains: []string{
+				"`name` LIKE ? AND `email` LIKE ? AND `created_at` >= ?",
+				"ORDER BY `created_at` DESC,`id`",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			query, err := applyListQuery(dryRunDB(t, tt.driver).Table("dm_users_000"), q, model.DmUserListFields, "id")
+			require.NoError(t, err)
+
+			var users []*model.DmUser
+			stmt := query.Find(&users).Statement
+			sql := stmt.SQL.String()
+			for _, want := range tt.wantContains {
+				assert.Contains(t, sql, want)
+			}
+			assert.Equal(t, []interface{}{`ta\_k%`, "%example%", from}, stmt.Vars)
+		})
+	}
+}
+
+func TestApplyListQuery_DefaultOrder(t *testing.T) {
+	query, err := applyListQuery(dryRunDB(t, "postgres").Table("dm_posts_000"), nil, model.DmPostListFields, "created_at DESC")
+	require.NoError(t, err)
+
+	var posts []*model.DmPost
+	assert.Contains(t, query.Find(&posts).Statement.SQL.String(), "ORDER BY created_at DESC")
+}
+
+func TestApplyListQuery_RejectsUnknownField(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *model.ListQuery
+	}{
+		{"filter field", &model.ListQuery{Filters: []model.ListFilter{{Field: "password", Op: model.ListFilterOpEq, Value: "x"}}}},
+		{"filter operator", &model.ListQuery{Filters: []model.ListFilter{{Field: "title", Op: "regex", Value: "x"}}}},
+		{"sort field", &model.ListQuery{Sorts: []model.ListSort{{Field: "1; DROP TABLE dm_posts_000"}}}},
+		{"non sortable field", &model.ListQuery{Sorts: []model.ListSort{{Field: "content"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := applyListQuery(dryRunDB(t, "postgres").Table("dm_posts_000"), tt.q, model.DmPostListFields, "created_at DESC")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSortListResults(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newPosts := func() []*model.DmPost {
+		return []*model.DmPost{
+			{ID: "a", Title: "beta", CreatedAt: base},
+			{ID: "b", Title: "alpha", CreatedAt: base.Add(2 * time.Hour)},
+			{ID: "c", Title: "alpha", CreatedAt: base.Add(time.Hour)},
+		}
+	}
+	ids := func(posts []*model.DmPost) []string {
+		result := make([]string, len(posts))
+		for i, p := range posts {
+			result[i] = p.ID
+		}
+		return result
+	}
+
+	posts := newPosts()
+	sortListResults(posts, []model.ListSort{{Field: "created_at", Desc: true}}, dmPostListValue)
+	assert.Equal(t, []string{"b", "c", "a"}, ids(posts))
+
+	posts = newPosts()
+	sortListResults(posts, []model.ListSort{{Field: "title"}}, dmPostListValue)
+	assert.Equal(t, []string{"b", "c", "a"}, ids(posts))
+
+	// ソート指定がない場合は並べ替えない
+	posts = newPosts()
+	sortListResults(posts, nil, dmPostListValue)
+	assert.Equal(t, []string{"a", "b", "c"}, ids(posts))
+}
+
+func TestMergeListResults(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 2テーブルからそれぞれ先頭offset+limit件（created_at降順）を取得した結果
+	newPosts := func() []*model.DmPost {
+		return []*model.DmPost{
+			{ID: "a", Title: "delta", CreatedAt: base.Add(4 * time.Hour)},
+			{ID: "b", Title: "alpha", CreatedAt: base.Add(time.Hour)},
+			{ID: "c", Title: "gamma", CreatedAt: base.Add(3 * time.Hour)},
+			{ID: "d", Title: "beta", CreatedAt: base.Add(2 * time.Hour)},
+		}
+	}
+	ids := func(posts []*model.DmPost) []string {
+		result := make([]string, len(posts))
+		for i, p := range posts {
+			result[i] = p.ID
+		}
+		return result
+	}
+
+	// ソート指定がない場合はデフォルトの並び順で結果全体にoffset・limitを適用
+	posts := mergeListResults(newPosts(), 2, 1, nil, dmPostDefaultSorts, dmPostListValue)
+	assert.Equal(t, []string{"c", "d"}, ids(posts))
+
+	q := &model.ListQuery{Sorts: []model.ListSort{{Field: "title"}}}
+	posts = mergeListResults(newPosts(), 2, 0, q, dmPostDefaultSorts, dmPostListValue)
+	assert.Equal(t, []string{"b", "d"}, ids(posts))
+
+	posts = mergeListResults(newPosts(), 10, 3, q, dmPostDefaultSorts, dmPostListValue)
+	assert.Equal(t, []string{"c"}, ids(posts))
+
+	posts = mergeListResults(newPosts(), 2, 4, q, dmPostDefaultSorts, dmPostListValue)
+	assert.Empty(t, posts)
+}
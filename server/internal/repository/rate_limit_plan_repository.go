@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRateLimitPlanNotFound はプランが存在しない場合のエラー
+var ErrRateLimitPlanNotFound = apperror.NotFound("rate limit plan not found")
+
+// ErrRateLimitPlanAssignmentNotFound はプランが割り当てられていない場合のエラー
+var ErrRateLimitPlanAssignmentNotFound = apperror.NotFound("rate limit plan assignment not found")
+
+// RateLimitPlanRepository はレートリミット・クォータのプランと割り当てのデータアクセスを担当
+// プランと割り当てはmasterグループに配置する
+type RateLimitPlanRepository struct {
+	groupManager *db.GroupManager
+}
+
+// NewRateLimitPlanRepository は新しいRateLimitPlanRepositoryを作成
+func NewRateLimitPlanRepository(groupManager *db.GroupManager) *RateLimitPlanRepository {
+	return &RateLimitPlanRepository{
+		groupManager: groupManager,
+	}
+}
+
+// GetByName は名前でプランを取得
+func (r *RateLimitPlanRepository) GetByName(ctx context.Context, name string) (*model.RateLimitPlan, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var plan model.RateLimitPlan
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("rate_limit_plans").Where("name = ?", name).First(&plan).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrRateLimitPlanNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to get rate limit plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// GetAssignedPlanName は呼び出し元に割り当てられたプランの名前を取得
+func (r *RateLimitPlanRepository) GetAssignedPlanName(ctx context.Context, principalType, principalID string) (string, error) {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return "", fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	var assignment model.RateLimitPlanAssignment
+	// リトライ機能付きでクエリ実行
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("rate_limit_plan_assignments").Where("principal_type = ? AND principal_id = ?", principalType, principalID).First(&assignment).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("%w: %s %s", ErrRateLimitPlanAssignmentNotFound, principalType, principalID)
+		}
+		return "", fmt.Errorf("failed to get rate limit plan assignment: %w", err)
+	}
+
+	return assignment.PlanName, nil
+}
+
+// Assign は呼び出し元にプランを割り当てる（割り当て済みの場合は変更する）
+func (r *RateLimitPlanRepository) Assign(ctx context.Context, assignment *model.RateLimitPlanAssignment) error {
+	conn, err := r.groupManager.GetMasterConnection()
+	if err != nil {
+		return fmt.Errorf("failed to get master connection: %w", err)
+	}
+
+	// リトライ機能付きでGORM APIで作成・更新
+	err = db.ExecuteWithRetry(func() error {
+		return conn.DB.WithContext(ctx).Table("rate_limit_plan_assignments").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "principal_type"}, {Name: "principal_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"plan_name", "updated_at"}),
+		}).Create(assignment).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign rate limit plan: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/test/testutil"
+)
+
+func TestRateLimitPlanRepository(t *testing.T) {
+	groupManager := testutil.SetupTestGroupManager(t, 4, 8)
+	defer testutil.CleanupTestGroupManager(groupManager)
+
+	repo := repository.NewRateLimitPlanRepository(groupManager)
+	ctx := context.Background()
+
+	conn, err := groupManager.GetMasterConnection()
+	require.NoError(t, err)
+	require.NoError(t, conn.DB.Create(&model.RateLimitPlan{Name: "pro", RequestsPerMinute: 600, RequestsPerDay: 100000}).Error)
+
+	plan, err := repo.GetByName(ctx, "pro")
+	require.NoError(t, err)
+	assert.Equal(t, 600, plan.RequestsPerMinute)
+	assert.Equal(t, 0, plan.RequestsPerHour)
+	assert.Equal(t, 100000, plan.RequestsPerDay)
+
+	_, err = repo.GetByName(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrRateLimitPlanNotFound)
+
+	_, err = repo.GetAssignedPlanName(ctx, "api_key", "key-1")
+	assert.ErrorIs(t, err, repository.ErrRateLimitPlanAssignmentNotFound)
+
+	require.NoError(t, repo.Assign(ctx, &model.RateLimitPlanAssignment{PrincipalType: "api_key", PrincipalID: "key-1", PlanName: "default"}))
+	name, err := repo.GetAssignedPlanName(ctx, "api_key", "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, "default", name)
+
+	// 割り当て済みの場合はプランを変更する
+	require.NoError(t, repo.Assign(ctx, &model.RateLimitPlanAssignment{PrincipalType: "api_key", PrincipalID: "key-1", PlanName: "pro"}))
+	name, err = repo.GetAssignedPlanName(ctx, "api_key", "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, "pro", name)
+
+	// 種類が異なる場合は別の割り当て
+	_, err = repo.GetAssignedPlanName(ctx, "user", "key-1")
+	assert.ErrorIs(t, err, repository.ErrRateLimitPlanAssignmentNotFound)
+}
@@ -1,30 +1,82 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/util/idgen"
+	"github.com/taku-o/go-webdb-template/internal/validation"
 )
 
 // APIKeyServiceInterface はAPIキーサービスのインターフェース
 type APIKeyServiceInterface interface {
-	GenerateAPIKey(secretKey, version, env string, issuedAt int64) (string, error)
+	GenerateAPIKey(ctx context.Context, version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error)
 	DecodeAPIKeyPayload(token string) (*auth.JWTClaims, error)
+	ListAPIKeys(ctx context.Context, limit, offset int) ([]*model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, jti string) error
 }
 
 // APIKeyService はAPIキー発行のドメインロジックを担当
-type APIKeyService struct{}
+// 発行したキーはjtiで台帳に登録し、キーごとの失効・最終利用日時の記録を行う
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepositoryInterface
+	keySet     *auth.KeySet
+	now        func() time.Time
+}
 
 // NewAPIKeyService は新しいAPIKeyServiceを作成
-func NewAPIKeyService() *APIKeyService {
-	return &APIKeyService{}
+// keySetは発行するキーの署名に使用する（失効確認・利用記録のみに使う場合はnil可）
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepositoryInterface, keySet *auth.KeySet) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		keySet:     keySet,
+		now:        time.Now,
+	}
 }
 
-// GenerateAPIKey はAPIキーを生成
-func (s *APIKeyService) GenerateAPIKey(secretKey, version, env string, issuedAt int64) (string, error) {
-	token, err := auth.GeneratePublicAPIKey(secretKey, version, env, issuedAt)
+// GenerateAPIKey はAPIキーを署名に使う鍵で生成し、台帳に登録
+func (s *APIKeyService) GenerateAPIKey(ctx context.Context, version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error) {
+	if err := validation.Struct(req); err != nil {
+		return "", err
+	}
+	for _, scope := range req.Scopes {
+		if !auth.IsPublicAPIKeyScope(scope) {
+			return "", apperror.Validation(fmt.Sprintf("unknown scope: %s", scope))
+		}
+	}
+	if s.keySet == nil {
+		return "", errors.New("signing key set is not configured")
+	}
+
+	jti, err := idgen.GenerateUUIDv7()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := s.keySet.GeneratePublicAPIKey(version, env, issuedAt, jti, req.Scopes)
 	if err != nil {
 		return "", err
 	}
 
+	apiKey := &model.APIKey{
+		JTI:     jti,
+		Name:    req.Name,
+		Owner:   req.Owner,
+		Scopes:  strings.Join(req.Scopes, ","),
+		Version: version,
+		Env:     env,
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return "", fmt.Errorf("failed to register api key: %w", err)
+	}
+
 	return token, nil
 }
 
@@ -37,3 +89,58 @@ func (s *APIKeyService) DecodeAPIKeyPayload(token string) (*auth.JWTClaims, erro
 
 	return claims, nil
 }
+
+// ListAPIKeys は発行済みのAPIキーを新しい順に取得
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, limit, offset int) ([]*model.APIKey, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	apiKeys, err := s.apiKeyRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey はAPIキーを失効させる
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, jti string) error {
+	if jti == "" {
+		return apperror.Validation("jti is required")
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, jti, s.now()); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+// IsAPIKeyRevoked はAPIキーが失効しているかを返す（台帳にないキーは失効していないものとして扱う）
+func (s *APIKeyService) IsAPIKeyRevoked(ctx context.Context, jti string) (bool, error) {
+	apiKey, err := s.apiKeyRepo.GetByJTI(ctx, jti)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return apiKey.Revoked(), nil
+}
+
+// RecordAPIKeyUsage はAPIキーの最終利用日時を記録
+func (s *APIKeyService) RecordAPIKeyUsage(ctx context.Context, jti string, usedAt time.Time) error {
+	if err := s.apiKeyRepo.UpdateLastUsedAt(ctx, jti, usedAt); err != nil {
+		return fmt.Errorf("failed to record api key usage: %w", err)
+	}
+
+	return nil
+}
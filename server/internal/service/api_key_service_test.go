@@ -1,43 +1,125 @@
 package service
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
 )
 
+// MockAPIKeyRepository はAPIKeyRepositoryInterfaceのモック
+type MockAPIKeyRepository struct {
+	apiKeys map[string]*model.APIKey
+}
+
+func newMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{apiKeys: make(map[string]*model.APIKey)}
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	apiKey.ID = int64(len(m.apiKeys) + 1)
+	m.apiKeys[apiKey.JTI] = apiKey
+	return nil
+}
+
+func (m *MockAPIKeyRepository) GetByJTI(ctx context.Context, jti string) (*model.APIKey, error) {
+	apiKey, ok := m.apiKeys[jti]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repository.ErrAPIKeyNotFound, jti)
+	}
+	return apiKey, nil
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, limit, offset int) ([]*model.APIKey, error) {
+	apiKeys := make([]*model.APIKey, 0, len(m.apiKeys))
+	for _, apiKey := range m.apiKeys {
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, nil
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, jti string, revokedAt time.Time) error {
+	apiKey, ok := m.apiKeys[jti]
+	if !ok {
+		return fmt.Errorf("%w: %s", repository.ErrAPIKeyNotFound, jti)
+	}
+	apiKey.RevokedAt = &revokedAt
+	return nil
+}
+
+func (m *MockAPIKeyRepository) UpdateLastUsedAt(ctx context.Context, jti string, usedAt time.Time) error {
+	if apiKey, ok := m.apiKeys[jti]; ok {
+		apiKey.LastUsedAt = &usedAt
+	}
+	return nil
+}
+
+// newTestKeySet はテスト用のKeySetを作成
+func newTestKeySet(t *testing.T, cfg *config.APIConfig) *auth.KeySet {
+	t.Helper()
+	keySet, err := auth.NewKeySet(cfg)
+	require.NoError(t, err)
+	return keySet
+}
+
 func TestAPIKeyService_GenerateAPIKey(t *testing.T) {
 	tests := []struct {
-		name      string
-		secretKey string
-		version   string
-		env       string
-		issuedAt  int64
-		wantErr   bool
+		name     string
+		cfg      *config.APIConfig
+		version  string
+		env      string
+		issuedAt int64
+		wantKID  string
+		wantErr  bool
 	}{
 		{
-			name:      "正常系: APIキーを生成できる",
-			secretKey: "test-secret-key-12345678901234567890",
-			version:   "v1.0.0",
-			env:       "develop",
-			issuedAt:  time.Now().Unix(),
-			wantErr:   false,
+			name:     "正常系: APIキーを生成できる",
+			cfg:      &config.APIConfig{SecretKey: "test-secret-key-12345678901234567890"},
+			version:  "v1.0.0",
+			env:      "develop",
+			issuedAt: time.Now().Unix(),
+			wantErr:  false,
 		},
 		{
-			name:      "正常系: production環境でAPIキーを生成できる",
-			secretKey: "test-secret-key-12345678901234567890",
-			version:   "v2.0.0",
-			env:       "production",
-			issuedAt:  time.Now().Unix(),
-			wantErr:   false,
+			name:     "正常系: production環境でAPIキーを生成できる",
+			cfg:      &config.APIConfig{SecretKey: "test-secret-key-12345678901234567890"},
+			version:  "v2.0.0",
+			env:      "production",
+			issuedAt: time.Now().Unix(),
+			wantErr:  false,
+		},
+		{
+			name: "正常系: 署名に使う鍵のkidが付与される",
+			cfg: &config.APIConfig{
+				SecretKey: "test-secret-key-12345678901234567890",
+				Signing: config.APISigningConfig{
+					KeyID: "k2",
+					Keys:  []config.APISigningKeyConfig{{ID: "k2", Algorithm: "HS256", Secret: "k2-secret"}},
+				},
+			},
+			version:  "v2.0.0",
+			env:      "develop",
+			issuedAt: time.Now().Unix(),
+			wantKID:  "k2",
+			wantErr:  false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAPIKeyService()
-			got, err := s.GenerateAPIKey(tt.secretKey, tt.version, tt.env, tt.issuedAt)
+			repo := newMockAPIKeyRepository()
+			s := NewAPIKeyService(repo, newTestKeySet(t, tt.cfg))
+			got, err := s.GenerateAPIKey(context.Background(), tt.version, tt.env, tt.issuedAt, &model.IssueAPIKeyRequest{Name: "partner", Owner: "ops", Scopes: []string{auth.ScopeUsersRead, auth.ScopePostsWrite}})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -48,20 +130,56 @@ func TestAPIKeyService_GenerateAPIKey(t *testing.T) {
 			assert.NotEmpty(t, got)
 			// JWTトークン形式（3つのドット区切り）であることを確認
 			assert.Contains(t, got, ".")
+
+			// jtiで台帳に登録される
+			claims, err := s.DecodeAPIKeyPayload(got)
+			require.NoError(t, err)
+			require.Len(t, claims.JTI, 32)
+			apiKey, err := repo.GetByJTI(context.Background(), claims.JTI)
+			require.NoError(t, err)
+			assert.Equal(t, "partner", apiKey.Name)
+			assert.Equal(t, "ops", apiKey.Owner)
+			assert.Equal(t, "users:read,posts:write", apiKey.Scopes)
+			assert.Equal(t, []string{auth.ScopeUsersRead, auth.ScopePostsWrite}, claims.Scope)
+			assert.Equal(t, tt.version, apiKey.Version)
+			assert.Equal(t, tt.env, apiKey.Env)
+
+			token, _, err := jwt.NewParser().ParseUnverified(got, &auth.JWTClaims{})
+			require.NoError(t, err)
+			kid, _ := token.Header["kid"].(string)
+			assert.Equal(t, tt.wantKID, kid)
 		})
 	}
 }
 
+func TestAPIKeyService_GenerateAPIKey_Invalid(t *testing.T) {
+	s := NewAPIKeyService(newMockAPIKeyRepository(), newTestKeySet(t, &config.APIConfig{SecretKey: "secret"}))
+	_, err := s.GenerateAPIKey(context.Background(), "v2", "develop", time.Now().Unix(), &model.IssueAPIKeyRequest{Owner: "ops"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.Contains(t, err.Error(), "name is required")
+
+	// スコープは1つ以上必要
+	_, err = s.GenerateAPIKey(context.Background(), "v2", "develop", time.Now().Unix(), &model.IssueAPIKeyRequest{Name: "partner", Owner: "ops"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	// 付与できないスコープ
+	_, err = s.GenerateAPIKey(context.Background(), "v2", "develop", time.Now().Unix(), &model.IssueAPIKeyRequest{Name: "partner", Owner: "ops", Scopes: []string{"users:delete"}})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.Contains(t, err.Error(), "unknown scope: users:delete")
+}
+
 func TestAPIKeyService_DecodeAPIKeyPayload(t *testing.T) {
-	s := NewAPIKeyService()
+	s := NewAPIKeyService(newMockAPIKeyRepository(), newTestKeySet(t, &config.APIConfig{SecretKey: "test-secret-key-12345678901234567890"}))
 
 	// テスト用トークンを生成
-	secretKey := "test-secret-key-12345678901234567890"
 	version := "v1.0.0"
 	env := "develop"
 	issuedAt := time.Now().Unix()
 
-	validToken, err := s.GenerateAPIKey(secretKey, version, env, issuedAt)
+	validToken, err := s.GenerateAPIKey(context.Background(), version, env, issuedAt, &model.IssueAPIKeyRequest{Name: "partner", Owner: "ops", Scopes: []string{auth.ScopeUsersRead}})
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -109,3 +227,40 @@ func TestAPIKeyService_DecodeAPIKeyPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockAPIKeyRepository()
+	require.NoError(t, repo.Create(ctx, &model.APIKey{JTI: "key1"}))
+	s := NewAPIKeyService(repo, nil)
+
+	revoked, err := s.IsAPIKeyRevoked(ctx, "key1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, s.RevokeAPIKey(ctx, "key1"))
+	revoked, err = s.IsAPIKeyRevoked(ctx, "key1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// 台帳にないキーは失効していないものとして扱う
+	revoked, err = s.IsAPIKeyRevoked(ctx, "unknown")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	err = s.RevokeAPIKey(ctx, "unknown")
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.ErrorIs(t, s.RevokeAPIKey(ctx, ""), apperror.ErrValidation)
+}
+
+func TestAPIKeyService_RecordAPIKeyUsage(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockAPIKeyRepository()
+	require.NoError(t, repo.Create(ctx, &model.APIKey{JTI: "key1"}))
+	s := NewAPIKeyService(repo, nil)
+
+	usedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, s.RecordAPIKeyUsage(ctx, "key1", usedAt))
+	require.NotNil(t, repo.apiKeys["key1"].LastUsedAt)
+	assert.Equal(t, usedAt, *repo.apiKeys["key1"].LastUsedAt)
+}
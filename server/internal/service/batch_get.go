@@ -0,0 +1,32 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// ErrInvalidBatchGet は一括取得の指定が不正な場合のエラー
+var ErrInvalidBatchGet = apperror.Validation("invalid batch get request")
+
+// uniqueBatchKeys は一括取得のキー数を検証し、重複を除いたキーを最初に現れた順序で返す
+func uniqueBatchKeys[K comparable](keys []K) ([]K, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: at least one id is required", ErrInvalidBatchGet)
+	}
+	if len(keys) > model.DmBatchGetMaxIDs {
+		return nil, fmt.Errorf("%w: too many ids (max %d)", ErrInvalidBatchGet, model.DmBatchGetMaxIDs)
+	}
+
+	seen := make(map[K]bool, len(keys))
+	unique := make([]K, 0, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, key)
+	}
+	return unique, nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestUniqueBatchKeys(t *testing.T) {
+	got, err := uniqueBatchKeys([]string{"b", "a", "b", "c", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, got)
+
+	// 上限ちょうどは許可する
+	_, err = uniqueBatchKeys(make([]int, model.DmBatchGetMaxIDs))
+	assert.NoError(t, err)
+
+	_, err = uniqueBatchKeys([]string{})
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+	_, err = uniqueBatchKeys(make([]int, model.DmBatchGetMaxIDs+1))
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/util/idgen"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+)
+
+// DmBulkUserRepositoryInterface は一括登録で使用するDmUserRepositoryのインターフェース
+type DmBulkUserRepositoryInterface interface {
+	GetByID(ctx context.Context, id string) (*model.DmUser, error)
+	FindExistingEmails(ctx context.Context, emails []string) (map[string]bool, error)
+	InsertDmUsersBatch(ctx context.Context, tableName string, dmUsers []*model.DmUser) error
+}
+
+// DmBulkPostRepositoryInterface は一括登録で使用するDmPostRepositoryのインターフェース
+type DmBulkPostRepositoryInterface interface {
+	InsertDmPostsBatch(ctx context.Context, tableName string, dmPosts []*model.DmPost) error
+}
+
+// DmBulkImportService はdm_users/dm_postsの一括登録を担当
+// 行ごとに検証し、シャーディングキーでテーブル単位にグループ化してdb.BatchSize件ずつ挿入する
+type DmBulkImportService struct {
+	dmUserRepo    DmBulkUserRepositoryInterface
+	dmPostRepo    DmBulkPostRepositoryInterface
+	tableSelector *db.TableSelector
+}
+
+// NewDmBulkImportService は新しいDmBulkImportServiceを作成
+func NewDmBulkImportService(dmUserRepo DmBulkUserRepositoryInterface, dmPostRepo DmBulkPostRepositoryInterface, tableSelector *db.TableSelector) *DmBulkImportService {
+	return &DmBulkImportService{
+		dmUserRepo:    dmUserRepo,
+		dmPostRepo:    dmPostRepo,
+		tableSelector: tableSelector,
+	}
+}
+
+// bulkRow はテーブル振り分け後の1行（元の行番号を保持）
+type bulkRow[T any] struct {
+	index int
+	item  T
+}
+
+// ImportDmUsers はユーザーを一括登録し、行ごとの結果を返す（登録したユーザーはresult.CreatedDmUsersに含める）
+func (s *DmBulkImportService) ImportDmUsers(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	result := model.NewBulkImportResult(len(rows))
+	rowsByTable := make(map[int][]bulkRow[*model.DmUser])
+	seenEmails := make(map[string]int)
+	now := time.Now()
+
+	valid := make([]bulkRow[*model.CreateDmUserRequest], 0, len(rows))
+	for i, raw := range rows {
+		var req model.CreateDmUserRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			result.SetError(i, fmt.Sprintf("invalid json: %v", err))
+			continue
+		}
+		if err := validation.Struct(&req); err != nil {
+			result.SetError(i, err.Error())
+			continue
+		}
+		// リクエスト内でのメールアドレス重複チェック
+		if first, ok := seenEmails[req.Email]; ok {
+			result.SetError(i, fmt.Sprintf("duplicate email in request: same as row %d", first))
+			continue
+		}
+		seenEmails[req.Email] = i
+		valid = append(valid, bulkRow[*model.CreateDmUserRequest]{index: i, item: &req})
+	}
+
+	// 登録済みのメールアドレスとの重複チェック
+	existingEmails, err := s.findExistingEmails(ctx, valid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range valid {
+		req := row.item
+		if existingEmails[req.Email] {
+			result.SetError(row.index, validation.NewFieldError("email", validation.RuleUnique, "").Error())
+			continue
+		}
+
+		id, err := idgen.GenerateUUIDv7()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate UUIDv7: %w", err)
+		}
+		tableNumber, err := s.tableSelector.GetTableNumberFromUUID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table number from UUID: %w", err)
+		}
+
+		rowsByTable[tableNumber] = append(rowsByTable[tableNumber], bulkRow[*model.DmUser]{
+			index: row.index,
+			item: &model.DmUser{
+				ID:        id,
+				Name:      req.Name,
+				Email:     req.Email,
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+		})
+	}
+
+	// テーブル番号順にdb.BatchSize件ずつ挿入
+	for _, tableNumber := range sortedTableNumbers(rowsByTable) {
+		tableName := fmt.Sprintf("dm_users_%03d", tableNumber)
+		err := insertInChunks(rowsByTable[tableNumber], func(chunk []*model.DmUser) error {
+			return s.dmUserRepo.InsertDmUsersBatch(ctx, tableName, chunk)
+		}, func(row bulkRow[*model.DmUser], err error) {
+			if err != nil {
+				result.SetError(row.index, fmt.Sprintf("failed to insert: %v", err))
+				return
+			}
+			result.SetOK(row.index, row.item.ID)
+			result.CreatedDmUsers = append(result.CreatedDmUsers, row.item)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Summarize()
+	return result, nil
+}
+
+// findExistingEmails は行のメールアドレスのうち登録済みのものをdb.BatchSize件ずつ問い合わせて返す
+func (s *DmBulkImportService) findExistingEmails(ctx context.Context, rows []bulkRow[*model.CreateDmUserRequest]) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	for start := 0; start < len(rows); start += db.BatchSize {
+		end := start + db.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		emails := make([]string, 0, end-start)
+		for _, row := range rows[start:end] {
+			emails = append(emails, row.item.Email)
+		}
+
+		found, err := s.dmUserRepo.FindExistingEmails(ctx, emails)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check emails: %w", err)
+		}
+		for email := range found {
+			existing[email] = true
+		}
+	}
+	return existing, nil
+}
+
+// ImportDmPosts は投稿を一括登録し、行ごとの結果を返す（登録した投稿はresult.CreatedDmPostsに含める）
+func (s *DmBulkImportService) ImportDmPosts(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	result := model.NewBulkImportResult(len(rows))
+	rowsByTable := make(map[int][]bulkRow[*model.DmPost])
+	// ユーザー存在確認の結果キャッシュ（同一ユーザーへの問い合わせは1回のみ）
+	userExists := make(map[string]bool)
+	now := time.Now()
+
+	for i, raw := range rows {
+		var req model.CreateDmPostRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			result.SetError(i, fmt.Sprintf("invalid json: %v", err))
+			continue
+		}
+		if err := validation.Struct(&req); err != nil {
+			result.SetError(i, err.Error())
+			continue
+		}
+
+		exists, checked := userExists[req.UserID]
+		if !checked {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			_, err := s.dmUserRepo.GetByID(ctx, req.UserID)
+			if err != nil && !errors.Is(err, repository.ErrDmUserNotFound) {
+				return nil, fmt.Errorf("failed to get user: %w", err)
+			}
+			exists = err == nil
+			userExists[req.UserID] = exists
+		}
+		if !exists {
+			result.SetError(i, fmt.Sprintf("user not found: %s", req.UserID))
+			continue
+		}
+
+		id, err := idgen.GenerateUUIDv7()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate UUIDv7: %w", err)
+		}
+		// dm_postsのシャーディングキーはuser_id
+		tableNumber, err := s.tableSelector.GetTableNumberFromUUID(req.UserID)
+		if err != nil {
+			result.SetError(i, fmt.Sprintf("invalid user_id: %v", err))
+			continue
+		}
+
+		rowsByTable[tableNumber] = append(rowsByTable[tableNumber], bulkRow[*model.DmPost]{
+			index: i,
+			item: &model.DmPost{
+				ID:        id,
+				UserID:    req.UserID,
+				Title:     req.Title,
+				Content:   req.Content,
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+		})
+	}
+
+	// テーブル番号順にdb.BatchSize件ずつ挿入
+	for _, tableNumber := range sortedTableNumbers(rowsByTable) {
+		tableName := fmt.Sprintf("dm_posts_%03d", tableNumber)
+		err := insertInChunks(rowsByTable[tableNumber], func(chunk []*model.DmPost) error {
+			return s.dmPostRepo.InsertDmPostsBatch(ctx, tableName, chunk)
+		}, func(row bulkRow[*model.DmPost], err error) {
+			if err != nil {
+				result.SetError(row.index, fmt.Sprintf("failed to insert: %v", err))
+				return
+			}
+			result.SetOK(row.index, row.item.ID)
+			result.CreatedDmPosts = append(result.CreatedDmPosts, row.item)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Summarize()
+	return result, nil
+}
+
+// insertInChunks はdb.BatchSize件ずつinsertを呼び出し、チャンク内の各行の結果をreportに通知する
+// 挿入エラーはチャンク内の全行の失敗として扱い、次のチャンクの処理を継続する
+// contextがキャンセルされた場合のみエラーを返す
+func insertInChunks[T any](rows []bulkRow[T], insert func(chunk []T) error, report func(row bulkRow[T], err error)) error {
+	for start := 0; start < len(rows); start += db.BatchSize {
+		end := start + db.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		chunk := make([]T, 0, end-start)
+		for _, row := range rows[start:end] {
+			chunk = append(chunk, row.item)
+		}
+
+		err := insert(chunk)
+		for _, row := range rows[start:end] {
+			report(row, err)
+		}
+		if err != nil && (err == context.Canceled || err == context.DeadlineExceeded) {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedTableNumbers はテーブル番号を昇順で返す
+func sortedTableNumbers[T any](rowsByTable map[int][]T) []int {
+	tableNumbers := make([]int, 0, len(rowsByTable))
+	for tableNumber := range rowsByTable {
+		tableNumbers = append(tableNumbers, tableNumber)
+	}
+	sort.Ints(tableNumbers)
+	return tableNumbers
+}
@@ -0,0 +1,238 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/db"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// MockBulkDmUserRepository は一括登録用DmUserRepositoryのモック
+type MockBulkDmUserRepository struct {
+	GetByIDFunc            func(ctx context.Context, id string) (*model.DmUser, error)
+	FindExistingEmailsFunc func(ctx context.Context, emails []string) (map[string]bool, error)
+	InsertDmUsersBatchFunc func(ctx context.Context, tableName string, dmUsers []*model.DmUser) error
+}
+
+func (m *MockBulkDmUserRepository) GetByID(ctx context.Context, id string) (*model.DmUser, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return &model.DmUser{ID: id}, nil
+}
+
+func (m *MockBulkDmUserRepository) FindExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	if m.FindExistingEmailsFunc != nil {
+		return m.FindExistingEmailsFunc(ctx, emails)
+	}
+	return map[string]bool{}, nil
+}
+
+func (m *MockBulkDmUserRepository) InsertDmUsersBatch(ctx context.Context, tableName string, dmUsers []*model.DmUser) error {
+	if m.InsertDmUsersBatchFunc != nil {
+		return m.InsertDmUsersBatchFunc(ctx, tableName, dmUsers)
+	}
+	return nil
+}
+
+func toRawMessages(rows ...string) []json.RawMessage {
+	raws := make([]json.RawMessage, len(rows))
+	for i, row := range rows {
+		raws[i] = json.RawMessage(row)
+	}
+	return raws
+}
+
+func TestDmBulkImportService_ImportDmUsers(t *testing.T) {
+	var insertedTables []string
+	userRepo := &MockBulkDmUserRepository{
+		InsertDmUsersBatchFunc: func(ctx context.Context, tableName string, dmUsers []*model.DmUser) error {
+			insertedTables = append(insertedTables, tableName)
+			return nil
+		},
+	}
+	svc := service.NewDmBulkImportService(userRepo, &MockDmPostRepository{}, db.NewTableSelector(32, 8))
+
+	rows := toRawMessages(
+		`{"name":"Alice","email":"alice@example.com"}`,
+		`{"name":"","email":"empty@example.com"}`,
+		`{"name":"Bob","email":"not-an-email"}`,
+		`{"name":"Alice2","email":"alice@example.com"}`,
+		`{broken`,
+	)
+
+	result, err := svc.ImportDmUsers(context.Background(), rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 4, result.Failed)
+	assert.Equal(t, model.BulkRowStatusOK, result.Results[0].Status)
+	assert.Len(t, result.Results[0].ID, 32)
+	assert.Contains(t, result.Results[1].Error, "name is required")
+	assert.Contains(t, result.Results[2].Error, "email must be a valid email address")
+	assert.Contains(t, result.Results[3].Error, "duplicate email")
+	assert.Contains(t, result.Results[4].Error, "invalid json")
+	assert.Len(t, insertedTables, 1)
+
+	// 登録したユーザーのみWebhookの送信用に返す
+	require.Len(t, result.CreatedDmUsers, 1)
+	assert.Equal(t, result.Results[0].ID, result.CreatedDmUsers[0].ID)
+	assert.Equal(t, "Alice", result.CreatedDmUsers[0].Name)
+}
+
+func TestDmBulkImportService_ImportDmUsers_EmailExists(t *testing.T) {
+	var checkedEmails []string
+	userRepo := &MockBulkDmUserRepository{
+		FindExistingEmailsFunc: func(ctx context.Context, emails []string) (map[string]bool, error) {
+			checkedEmails = append(checkedEmails, emails...)
+			return map[string]bool{"taken@example.com": true}, nil
+		},
+	}
+	svc := service.NewDmBulkImportService(userRepo, &MockDmPostRepository{}, db.NewTableSelector(32, 8))
+
+	result, err := svc.ImportDmUsers(context.Background(), toRawMessages(
+		`{"name":"Alice","email":"alice@example.com"}`,
+		`{"name":"Taken","email":"taken@example.com"}`,
+		`{"name":"Bob","email":"invalid"}`,
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, model.BulkRowStatusOK, result.Results[0].Status)
+	assert.Equal(t, model.BulkRowStatusError, result.Results[1].Status)
+	assert.Contains(t, result.Results[1].Error, "email is already registered")
+	// 検証に失敗した行は問い合わせない
+	assert.Equal(t, []string{"alice@example.com", "taken@example.com"}, checkedEmails)
+}
+
+func TestDmBulkImportService_ImportDmUsers_EmailCheckError(t *testing.T) {
+	userRepo := &MockBulkDmUserRepository{
+		FindExistingEmailsFunc: func(ctx context.Context, emails []string) (map[string]bool, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	svc := service.NewDmBulkImportService(userRepo, &MockDmPostRepository{}, db.NewTableSelector(32, 8))
+
+	_, err := svc.ImportDmUsers(context.Background(), toRawMessages(`{"name":"Alice","email":"alice@example.com"}`))
+	assert.Error(t, err)
+}
+
+func TestDmBulkImportService_ImportDmUsers_ChunksByBatchSize(t *testing.T) {
+	var chunkSizes []int
+	userRepo := &MockBulkDmUserRepository{
+		InsertDmUsersBatchFunc: func(ctx context.Context, tableName string, dmUsers []*model.DmUser) error {
+			chunkSizes = append(chunkSizes, len(dmUsers))
+			return nil
+		},
+	}
+	// テーブル数1にして全行を同一テーブルに集める
+	svc := service.NewDmBulkImportService(userRepo, &MockDmPostRepository{}, db.NewTableSelector(1, 1))
+
+	total := db.BatchSize + 10
+	rows := make([]json.RawMessage, total)
+	for i := range rows {
+		rows[i] = json.RawMessage(fmt.Sprintf(`{"name":"user%d","email":"user%d@example.com"}`, i, i))
+	}
+
+	result, err := svc.ImportDmUsers(context.Background(), rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, total, result.Succeeded)
+	assert.Equal(t, []int{db.BatchSize, 10}, chunkSizes)
+}
+
+func TestDmBulkImportService_ImportDmUsers_InsertError(t *testing.T) {
+	userRepo := &MockBulkDmUserRepository{
+		InsertDmUsersBatchFunc: func(ctx context.Context, tableName string, dmUsers []*model.DmUser) error {
+			return errors.New("duplicate key")
+		},
+	}
+	svc := service.NewDmBulkImportService(userRepo, &MockDmPostRepository{}, db.NewTableSelector(32, 8))
+
+	result, err := svc.ImportDmUsers(context.Background(), toRawMessages(
+		`{"name":"Alice","email":"alice@example.com"}`,
+		`{"name":"Bob","email":"bob@example.com"}`,
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	for _, row := range result.Results {
+		assert.Equal(t, model.BulkRowStatusError, row.Status)
+		assert.Contains(t, row.Error, "failed to insert")
+		assert.Empty(t, row.ID)
+	}
+	assert.Empty(t, result.CreatedDmUsers)
+}
+
+func TestDmBulkImportService_ImportDmPosts(t *testing.T) {
+	existingUserID := "0194e79d4fb67af2a20bf9a8b29a2d58"
+	missingUserID := "0194e79d4fb67af2a20bf9a8b29a2d59"
+
+	getByIDCalls := 0
+	userRepo := &MockBulkDmUserRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
+			getByIDCalls++
+			if id == existingUserID {
+				return &model.DmUser{ID: id}, nil
+			}
+			return nil, fmt.Errorf("%w: %s", repository.ErrDmUserNotFound, id)
+		},
+	}
+	var insertedPosts []*model.DmPost
+	postRepo := &MockDmPostRepository{
+		InsertDmPostsBatchFunc: func(ctx context.Context, tableName string, dmPosts []*model.DmPost) error {
+			insertedPosts = append(insertedPosts, dmPosts...)
+			return nil
+		},
+	}
+	svc := service.NewDmBulkImportService(userRepo, postRepo, db.NewTableSelector(32, 8))
+
+	rows := toRawMessages(
+		fmt.Sprintf(`{"user_id":"%s","title":"t1","content":"c1"}`, existingUserID),
+		fmt.Sprintf(`{"user_id":"%s","title":"t2","content":"c2"}`, existingUserID),
+		fmt.Sprintf(`{"user_id":"%s","title":"t3","content":"c3"}`, missingUserID),
+		`{"user_id":"short","title":"t4","content":"c4"}`,
+		fmt.Sprintf(`{"user_id":"%s","title":"","content":"c5"}`, existingUserID),
+	)
+
+	result, err := svc.ImportDmPosts(context.Background(), rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 3, result.Failed)
+	assert.Contains(t, result.Results[2].Error, "user not found")
+	assert.Contains(t, result.Results[3].Error, "user_id must be 32 characters")
+	assert.Contains(t, result.Results[4].Error, "title is required")
+	assert.Equal(t, 2, getByIDCalls)
+	require.Len(t, insertedPosts, 2)
+	for _, post := range insertedPosts {
+		assert.Equal(t, existingUserID, post.UserID)
+	}
+	assert.ElementsMatch(t, insertedPosts, result.CreatedDmPosts)
+}
+
+func TestDmBulkImportService_ImportDmPosts_GetUserError(t *testing.T) {
+	userRepo := &MockBulkDmUserRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	svc := service.NewDmBulkImportService(userRepo, &MockDmPostRepository{}, db.NewTableSelector(32, 8))
+
+	// ユーザー不在以外のエラーは行の失敗ではなく処理全体のエラーとして返す
+	_, err := svc.ImportDmPosts(context.Background(), toRawMessages(
+		`{"user_id":"0194e79d4fb67af2a20bf9a8b29a2d58","title":"t1","content":"c1"}`,
+	))
+	assert.Error(t, err)
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/storage"
+	"github.com/taku-o/go-webdb-template/internal/util/xlsx"
+)
+
+// ExportBatchSize はエクスポート時に1回のクエリで取得する件数
+const ExportBatchSize = 1000
+
+// ErrInvalidExportRequest はエクスポートリクエストが不正な場合のエラー
+var ErrInvalidExportRequest = apperror.Validation("invalid export request")
+
+// ErrExportDownloadNotSupported はストレージがダウンロード配信に対応していない場合のエラー
+// S3の場合は署名付きURLで直接ダウンロードするため、APIサーバーからは配信しない
+var ErrExportDownloadNotSupported = apperror.NotFound("export download is not supported by the configured storage")
+
+// DmExportUserRepositoryInterface はエクスポートで使用するDmUserRepositoryのインターフェース
+type DmExportUserRepositoryInterface interface {
+	StreamAll(ctx context.Context, filter *model.ExportFilter, batchSize int, fn func(dmUsers []*model.DmUser) error) error
+	CountAll(ctx context.Context, filter *model.ExportFilter) (int64, error)
+}
+
+// DmExportPostRepositoryInterface はエクスポートで使用するDmPostRepositoryのインターフェース
+type DmExportPostRepositoryInterface interface {
+	StreamAll(ctx context.Context, filter *model.ExportFilter, batchSize int, fn func(dmPosts []*model.DmPost) error) error
+	CountAll(ctx context.Context, filter *model.ExportFilter) (int64, error)
+}
+
+// DmExportService はdm_users/dm_postsのファイルエクスポートを担当
+// 全シャードテーブルをExportBatchSize件ずつ読み込み、一時ファイルへ逐次書き出してからストレージに保存する
+type DmExportService struct {
+	dmUserRepo DmExportUserRepositoryInterface
+	dmPostRepo DmExportPostRepositoryInterface
+	store      storage.ExportStore
+	keyPrefix  string
+}
+
+// NewDmExportService は新しいDmExportServiceを作成
+func NewDmExportService(dmUserRepo DmExportUserRepositoryInterface, dmPostRepo DmExportPostRepositoryInterface, store storage.ExportStore, keyPrefix string) *DmExportService {
+	return &DmExportService{
+		dmUserRepo: dmUserRepo,
+		dmPostRepo: dmPostRepo,
+		store:      store,
+		keyPrefix:  keyPrefix,
+	}
+}
+
+// ValidateRequest はエクスポートリクエストを検証する
+// Fieldsが空の場合は対象の全フィールドを設定する
+func (s *DmExportService) ValidateRequest(req *model.ExportRequest) error {
+	var allowed []string
+	switch req.Target {
+	case model.ExportTargetDmUsers:
+		allowed = model.DmUserExportFields
+		if req.Filter.UserID != "" {
+			return fmt.Errorf("%w: user_id filter is only available for %s", ErrInvalidExportRequest, model.ExportTargetDmPosts)
+		}
+	case model.ExportTargetDmPosts:
+		allowed = model.DmPostExportFields
+	default:
+		return fmt.Errorf("%w: unsupported target: %s", ErrInvalidExportRequest, req.Target)
+	}
+
+	switch req.Format {
+	case model.ExportFormatCSV, model.ExportFormatNDJSON, model.ExportFormatXLSX:
+	default:
+		return fmt.Errorf("%w: unsupported format: %s", ErrInvalidExportRequest, req.Format)
+	}
+
+	if len(req.Fields) == 0 {
+		req.Fields = append([]string(nil), allowed...)
+	}
+	seen := make(map[string]bool, len(req.Fields))
+	for _, field := range req.Fields {
+		if !slices.Contains(allowed, field) {
+			return fmt.Errorf("%w: unsupported field for %s: %s", ErrInvalidExportRequest, req.Target, field)
+		}
+		if seen[field] {
+			return fmt.Errorf("%w: duplicate field: %s", ErrInvalidExportRequest, field)
+		}
+		seen[field] = true
+	}
+
+	if req.Filter.CreatedFrom != nil && req.Filter.CreatedTo != nil && !req.Filter.CreatedFrom.Before(*req.Filter.CreatedTo) {
+		return fmt.Errorf("%w: created_from must be before created_to", ErrInvalidExportRequest)
+	}
+	if req.Filter.UserID != "" && len(req.Filter.UserID) != 32 {
+		return fmt.Errorf("%w: invalid user_id: %s", ErrInvalidExportRequest, req.Filter.UserID)
+	}
+
+	return nil
+}
+
+// Export はリクエストに従ってファイルを作成し、ストレージに保存する
+// progressはバッチごとに呼び出される（nilの場合は通知しない）
+func (s *DmExportService) Export(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error) {
+	if err := s.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	var err error
+	if req.Target == model.ExportTargetDmUsers {
+		total, err = s.dmUserRepo.CountAll(ctx, &req.Filter)
+	} else {
+		total, err = s.dmPostRepo.CountAll(ctx, &req.Filter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rows: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "export-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	buf := bufio.NewWriter(tmp)
+	writer, err := newExportWriter(buf, req.Format, req.Target, req.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ExportResult{
+		Progress: model.ExportProgress{Total: total},
+	}
+	writeBatch := func(records [][]string) error {
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write record: %w", err)
+			}
+		}
+		result.Progress.Processed += int64(len(records))
+		// 件数取得後に追加された行により合計を超えた場合は合計を補正
+		if result.Progress.Processed > result.Progress.Total {
+			result.Progress.Total = result.Progress.Processed
+		}
+		if progress != nil {
+			progress(result.Progress)
+		}
+		return nil
+	}
+
+	if req.Target == model.ExportTargetDmUsers {
+		err = s.dmUserRepo.StreamAll(ctx, &req.Filter, ExportBatchSize, func(dmUsers []*model.DmUser) error {
+			records := make([][]string, len(dmUsers))
+			for i, dmUser := range dmUsers {
+				records[i] = dmUserExportRecord(dmUser, req.Fields)
+			}
+			return writeBatch(records)
+		})
+	} else {
+		err = s.dmPostRepo.StreamAll(ctx, &req.Filter, ExportBatchSize, func(dmPosts []*model.DmPost) error {
+			records := make([][]string, len(dmPosts))
+			for i, dmPost := range dmPosts {
+				records[i] = dmPostExportRecord(dmPost, req.Fields)
+			}
+			return writeBatch(records)
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export rows: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish export file: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush export file: %w", err)
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	key := path.Join(s.keyPrefix, jobID+"."+req.Format)
+	if err := s.store.Save(ctx, key, tmp, size, exportContentType(req.Format)); err != nil {
+		return nil, fmt.Errorf("failed to save export file: %w", err)
+	}
+
+	result.Completed = true
+	result.FileKey = key
+	result.FileName = fmt.Sprintf("%s-%s.%s", req.Target, time.Now().UTC().Format("20060102T150405Z"), req.Format)
+	result.Size = size
+	return result, nil
+}
+
+// DownloadURL はエクスポートファイルの期限付きダウンロードURLを返す
+func (s *DmExportService) DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error) {
+	return s.store.DownloadURL(ctx, key, fileName, ttl)
+}
+
+// OpenDownload は署名付きURLのパラメータを検証し、エクスポートファイルを開く
+// ローカルストレージの場合のみ利用できる
+func (s *DmExportService) OpenDownload(key string, fileName string, expires string, signature string) (*os.File, error) {
+	localStore, ok := s.store.(*storage.LocalExportStore)
+	if !ok {
+		return nil, ErrExportDownloadNotSupported
+	}
+	return localStore.Open(key, fileName, expires, signature)
+}
+
+// dmUserExportRecord はユーザーを出力フィールド順の値に変換
+func dmUserExportRecord(dmUser *model.DmUser, fields []string) []string {
+	record := make([]string, len(fields))
+	for i, field := range fields {
+		switch field {
+		case "id":
+			record[i] = dmUser.ID
+		case "name":
+			record[i] = dmUser.Name
+		case "email":
+			record[i] = dmUser.Email
+		case "created_at":
+			record[i] = dmUser.CreatedAt.Format(time.RFC3339)
+		case "updated_at":
+			record[i] = dmUser.UpdatedAt.Format(time.RFC3339)
+		}
+	}
+	return record
+}
+
+// dmPostExportRecord は投稿を出力フィールド順の値に変換
+func dmPostExportRecord(dmPost *model.DmPost, fields []string) []string {
+	record := make([]string, len(fields))
+	for i, field := range fields {
+		switch field {
+		case "id":
+			record[i] = dmPost.ID
+		case "user_id":
+			record[i] = dmPost.UserID
+		case "title":
+			record[i] = dmPost.Title
+		case "content":
+			record[i] = dmPost.Content
+		case "created_at":
+			record[i] = dmPost.CreatedAt.Format(time.RFC3339)
+		case "updated_at":
+			record[i] = dmPost.UpdatedAt.Format(time.RFC3339)
+		}
+	}
+	return record
+}
+
+// exportContentType は出力形式に対応するContent-Typeを返す
+func exportContentType(format string) string {
+	switch format {
+	case model.ExportFormatNDJSON:
+		return "application/x-ndjson"
+	case model.ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// exportWriter は出力形式ごとのレコード書き込み
+type exportWriter interface {
+	Write(record []string) error
+	Close() error
+}
+
+// newExportWriter は出力形式に対応するexportWriterを作成
+func newExportWriter(w io.Writer, format string, target string, fields []string) (exportWriter, error) {
+	switch format {
+	case model.ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(fields); err != nil {
+			return nil, err
+		}
+		return &csvExportWriter{w: cw}, nil
+	case model.ExportFormatNDJSON:
+		return newNDJSONExportWriter(w, fields)
+	case model.ExportFormatXLSX:
+		xw := xlsx.NewWriter(w, target)
+		xw.SetHeader(fields)
+		return &xlsxExportWriter{w: xw}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format: %s", ErrInvalidExportRequest, format)
+	}
+}
+
+// csvExportWriter はCSV形式のexportWriter（1行目はヘッダー）
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvExportWriter) Write(record []string) error {
+	return c.w.Write(record)
+}
+
+func (c *csvExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonExportWriter はNDJSON形式のexportWriter（フィールド順を保持）
+type ndjsonExportWriter struct {
+	w    io.Writer
+	keys [][]byte
+}
+
+func newNDJSONExportWriter(w io.Writer, fields []string) (*ndjsonExportWriter, error) {
+	keys := make([][]byte, len(fields))
+	for i, field := range fields {
+		key, err := json.Marshal(field)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return &ndjsonExportWriter{w: w, keys: keys}, nil
+}
+
+func (n *ndjsonExportWriter) Write(record []string) error {
+	line := []byte{'{'}
+	for i, value := range record {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line = append(line, n.keys[i]...)
+		line = append(line, ':')
+		line = append(line, encoded...)
+	}
+	line = append(line, '}', '\n')
+	_, err := n.w.Write(line)
+	return err
+}
+
+func (n *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+// xlsxExportWriter はXLSX形式のexportWriter（各シートの1行目はヘッダー）
+type xlsxExportWriter struct {
+	w *xlsx.Writer
+}
+
+func (x *xlsxExportWriter) Write(record []string) error {
+	return x.w.WriteRow(record)
+}
+
+func (x *xlsxExportWriter) Close() error {
+	return x.w.Close()
+}
@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// MockExportDmUserRepository はエクスポート用DmUserRepositoryのモック
+type MockExportDmUserRepository struct {
+	DmUsers []*model.DmUser
+	Err     error
+}
+
+func (m *MockExportDmUserRepository) StreamAll(ctx context.Context, filter *model.ExportFilter, batchSize int, fn func(dmUsers []*model.DmUser) error) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	for start := 0; start < len(m.DmUsers); start += batchSize {
+		end := min(start+batchSize, len(m.DmUsers))
+		if err := fn(m.DmUsers[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockExportDmUserRepository) CountAll(ctx context.Context, filter *model.ExportFilter) (int64, error) {
+	return int64(len(m.DmUsers)), nil
+}
+
+// MockExportDmPostRepository はエクスポート用DmPostRepositoryのモック
+type MockExportDmPostRepository struct {
+	DmPosts []*model.DmPost
+	Filter  *model.ExportFilter
+}
+
+func (m *MockExportDmPostRepository) StreamAll(ctx context.Context, filter *model.ExportFilter, batchSize int, fn func(dmPosts []*model.DmPost) error) error {
+	m.Filter = filter
+	if len(m.DmPosts) == 0 {
+		return nil
+	}
+	return fn(m.DmPosts)
+}
+
+func (m *MockExportDmPostRepository) CountAll(ctx context.Context, filter *model.ExportFilter) (int64, error) {
+	return int64(len(m.DmPosts)), nil
+}
+
+// MockExportStore はExportStoreのモック（保存内容をメモリに保持）
+type MockExportStore struct {
+	Files        map[string][]byte
+	ContentTypes map[string]string
+}
+
+func NewMockExportStore() *MockExportStore {
+	return &MockExportStore{
+		Files:        make(map[string][]byte),
+		ContentTypes: make(map[string]string),
+	}
+}
+
+func (m *MockExportStore) Save(ctx context.Context, key string, body io.ReadSeeker, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.Files[key] = data
+	m.ContentTypes[key] = contentType
+	return nil
+}
+
+func (m *MockExportStore) DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error) {
+	return "https://example.com/" + key, time.Now().Add(ttl), nil
+}
+
+func testExportDmUsers(n int) []*model.DmUser {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	dmUsers := make([]*model.DmUser, n)
+	for i := range dmUsers {
+		dmUsers[i] = &model.DmUser{
+			ID:        strings.Repeat("a", 31) + string(rune('0'+i%10)),
+			Name:      "User, \"quoted\"",
+			Email:     "user@example.com",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+	}
+	return dmUsers
+}
+
+func TestDmExportService_ValidateRequest(t *testing.T) {
+	svc := service.NewDmExportService(&MockExportDmUserRepository{}, &MockExportDmPostRepository{}, NewMockExportStore(), "exports")
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		req     model.ExportRequest
+		wantErr bool
+	}{
+		{"valid users", model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV}, false},
+		{"valid posts with fields", model.ExportRequest{Target: model.ExportTargetDmPosts, Format: model.ExportFormatNDJSON, Fields: []string{"title", "id"}}, false},
+		{"unknown target", model.ExportRequest{Target: "dm_news", Format: model.ExportFormatCSV}, true},
+		{"unknown format", model.ExportRequest{Target: model.ExportTargetDmUsers, Format: "pdf"}, true},
+		{"unknown field", model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV, Fields: []string{"password"}}, true},
+		{"duplicate field", model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV, Fields: []string{"id", "id"}}, true},
+		{"user_id filter on users", model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV, Filter: model.ExportFilter{UserID: strings.Repeat("a", 32)}}, true},
+		{"invalid user_id", model.ExportRequest{Target: model.ExportTargetDmPosts, Format: model.ExportFormatCSV, Filter: model.ExportFilter{UserID: "short"}}, true},
+		{"reversed created range", model.ExportRequest{Target: model.ExportTargetDmPosts, Format: model.ExportFormatCSV, Filter: model.ExportFilter{CreatedFrom: &from, CreatedTo: &to}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ValidateRequest(&tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, service.ErrInvalidExportRequest)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, tt.req.Fields)
+		})
+	}
+}
+
+func TestDmExportService_Export_CSV(t *testing.T) {
+	store := NewMockExportStore()
+	userRepo := &MockExportDmUserRepository{DmUsers: testExportDmUsers(service.ExportBatchSize + 5)}
+	svc := service.NewDmExportService(userRepo, &MockExportDmPostRepository{}, store, "exports")
+
+	var progress []model.ExportProgress
+	req := &model.ExportRequest{
+		Target: model.ExportTargetDmUsers,
+		Format: model.ExportFormatCSV,
+		Fields: []string{"name", "id"},
+	}
+	result, err := svc.Export(context.Background(), "job1", req, func(p model.ExportProgress) {
+		progress = append(progress, p)
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Completed)
+	assert.Equal(t, "exports/job1.csv", result.FileKey)
+	assert.True(t, strings.HasPrefix(result.FileName, "dm_users-"))
+	assert.Equal(t, int64(len(userRepo.DmUsers)), result.Progress.Processed)
+	assert.Equal(t, int64(len(store.Files["exports/job1.csv"])), result.Size)
+	assert.Equal(t, "text/csv; charset=utf-8", store.ContentTypes["exports/job1.csv"])
+
+	// バッチごとに進捗が通知される
+	require.Len(t, progress, 2)
+	assert.Equal(t, int64(service.ExportBatchSize), progress[0].Processed)
+	assert.Equal(t, int64(len(userRepo.DmUsers)), progress[1].Total)
+
+	records, err := csv.NewReader(bytes.NewReader(store.Files["exports/job1.csv"])).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(userRepo.DmUsers)+1)
+	assert.Equal(t, []string{"name", "id"}, records[0])
+	assert.Equal(t, []string{"User, \"quoted\"", userRepo.DmUsers[0].ID}, records[1])
+}
+
+func TestDmExportService_Export_NDJSON(t *testing.T) {
+	store := NewMockExportStore()
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	postRepo := &MockExportDmPostRepository{DmPosts: []*model.DmPost{
+		{ID: "p1", UserID: strings.Repeat("b", 32), Title: "Hello\n\"World\"", CreatedAt: createdAt},
+	}}
+	svc := service.NewDmExportService(&MockExportDmUserRepository{}, postRepo, store, "exports")
+
+	req := &model.ExportRequest{
+		Target: model.ExportTargetDmPosts,
+		Format: model.ExportFormatNDJSON,
+		Fields: []string{"title", "id", "created_at"},
+		Filter: model.ExportFilter{UserID: strings.Repeat("b", 32)},
+	}
+	result, err := svc.Export(context.Background(), "job2", req, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "exports/job2.ndjson", result.FileKey)
+	assert.Equal(t, `{"title":"Hello\n\"World\"","id":"p1","created_at":"2026-01-02T03:04:05Z"}`+"\n", string(store.Files["exports/job2.ndjson"]))
+	require.NotNil(t, postRepo.Filter)
+	assert.Equal(t, strings.Repeat("b", 32), postRepo.Filter.UserID)
+}
+
+func TestDmExportService_Export_XLSX(t *testing.T) {
+	store := NewMockExportStore()
+	svc := service.NewDmExportService(&MockExportDmUserRepository{DmUsers: testExportDmUsers(3)}, &MockExportDmPostRepository{}, store, "exports")
+
+	req := &model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatXLSX}
+	result, err := svc.Export(context.Background(), "job3", req, nil)
+	require.NoError(t, err)
+
+	data := store.Files[result.FileKey]
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	names := make([]string, len(zr.File))
+	for i, f := range zr.File {
+		names[i] = f.Name
+	}
+	assert.Contains(t, names, "xl/worksheets/sheet1.xml")
+	assert.Contains(t, names, "xl/workbook.xml")
+}
+
+func TestDmExportService_Export_RepositoryError(t *testing.T) {
+	store := NewMockExportStore()
+	userRepo := &MockExportDmUserRepository{Err: errors.New("db error")}
+	svc := service.NewDmExportService(userRepo, &MockExportDmPostRepository{}, store, "exports")
+
+	req := &model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV}
+	_, err := svc.Export(context.Background(), "job4", req, nil)
+	assert.Error(t, err)
+	assert.Empty(t, store.Files)
+}
+
+func TestDmExportService_OpenDownload_NotSupported(t *testing.T) {
+	svc := service.NewDmExportService(&MockExportDmUserRepository{}, &MockExportDmPostRepository{}, NewMockExportStore(), "exports")
+
+	_, err := svc.OpenDownload("exports/job1.csv", "a.csv", "0", "sig")
+	assert.ErrorIs(t, err, service.ErrExportDownloadNotSupported)
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+)
+
+// ErrInvalidFeedFormat はフィードの形式が不正な場合のエラー
+var ErrInvalidFeedFormat = apperror.Validation("invalid feed format")
+
+// DmNewsFeedService は公開済みニュースのRSS / Atomフィードの生成を担当
+type DmNewsFeedService struct {
+	dmNewsRepo repository.DmNewsRepositoryInterface
+	now        func() time.Time
+}
+
+// NewDmNewsFeedService は新しいDmNewsFeedServiceを作成
+func NewDmNewsFeedService(dmNewsRepo repository.DmNewsRepositoryInterface) *DmNewsFeedService {
+	return &DmNewsFeedService{
+		dmNewsRepo: dmNewsRepo,
+		now:        time.Now,
+	}
+}
+
+// BuildDmNewsFeed は公開済みニュースのフィードを指定の形式で生成
+// 本文が同じであれば同じETagになるよう、生成時刻ではなくアイテムの日時のみを出力する
+// キャッシュ期限は次の公開予定日時（MaxCacheAgeより後の場合はnow + MaxCacheAge）
+func (s *DmNewsFeedService) BuildDmNewsFeed(ctx context.Context, format string, opts *model.DmNewsFeedOptions) (*model.DmNewsFeed, error) {
+	if format != model.DmNewsFeedFormatRSS && format != model.DmNewsFeedFormatAtom {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFeedFormat, format)
+	}
+
+	now := s.now()
+	items, err := s.dmNewsRepo.ListFeedItems(ctx, now, opts.ItemLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed items: %w", err)
+	}
+	nextPublishAt, err := s.dmNewsRepo.GetNextPublishAt(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next publish time: %w", err)
+	}
+
+	var lastModified time.Time
+	for _, item := range items {
+		if updated := dmNewsFeedItemUpdated(item); updated.After(lastModified) {
+			lastModified = updated
+		}
+	}
+
+	feed := &model.DmNewsFeed{
+		LastModified:  lastModified,
+		NextPublishAt: nextPublishAt,
+		CacheUntil:    now.Add(opts.MaxCacheAge),
+	}
+	if nextPublishAt != nil && nextPublishAt.Before(feed.CacheUntil) {
+		feed.CacheUntil = *nextPublishAt
+	}
+
+	var doc interface{}
+	if format == model.DmNewsFeedFormatRSS {
+		feed.ContentType = "application/rss+xml; charset=utf-8"
+		doc = buildRSSFeed(items, opts, lastModified)
+	} else {
+		feed.ContentType = "application/atom+xml; charset=utf-8"
+		doc = buildAtomFeed(items, opts, lastModified)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render feed: %w", err)
+	}
+	feed.Body = append([]byte(xml.Header), body...)
+
+	sum := sha256.Sum256(feed.Body)
+	feed.ETag = hex.EncodeToString(sum[:16])
+
+	return feed, nil
+}
+
+// dmNewsFeedItemUpdated はアイテムの更新日時を返す
+// 公開予約したニュースは公開日時より前に更新されているため、公開日時と更新日時の遅い方を使う
+func dmNewsFeedItemUpdated(item *model.DmNewsFeedItem) time.Time {
+	if item.UpdatedAt.After(item.PublishedAt) {
+		return item.UpdatedAt
+	}
+	return item.PublishedAt
+}
+
+// dmNewsFeedItemLink はニュースのURLを返す（RSSのguid・Atomのidにも使う）
+func dmNewsFeedItemLink(siteURL string, id int64) string {
+	return strings.TrimRight(siteURL, "/") + "/news/" + strconv.FormatInt(id, 10)
+}
+
+// dmNewsFeedSelfLink はフィード自身のURLを返す
+func dmNewsFeedSelfLink(siteURL string, path string) string {
+	return strings.TrimRight(siteURL, "/") + path
+}
+
+// rssFeed はRSS 2.0のドキュメント
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// buildRSSFeed はRSS 2.0のドキュメントを組み立てる
+// guidは内容を更新しても変わらないニュースのURL（isPermaLink="true"）とする
+func buildRSSFeed(items []*model.DmNewsFeedItem, opts *model.DmNewsFeedOptions, lastModified time.Time) *rssFeed {
+	feed := &rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       opts.Title,
+			Link:        opts.SiteURL,
+			Description: opts.Description,
+			AtomLink: rssLink{
+				Href: dmNewsFeedSelfLink(opts.SiteURL, model.DmNewsFeedRSSPath),
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			Items: make([]rssItem, 0, len(items)),
+		},
+	}
+	if !lastModified.IsZero() {
+		feed.Channel.LastBuildDate = lastModified.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range items {
+		link := dmNewsFeedItemLink(opts.SiteURL, item.ID)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        link,
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			Description: item.Content,
+			PubDate:     item.PublishedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return feed
+}
+
+// atomFeed はAtom（RFC 4287）のドキュメント
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomAuthor  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// buildAtomFeed はAtomのドキュメントを組み立てる
+// idはニュースのURL、updatedは公開日時と更新日時の遅い方とする
+// アイテムがない場合のフィードのupdatedはUnixエポック（ETagを安定させるため）
+func buildAtomFeed(items []*model.DmNewsFeedItem, opts *model.DmNewsFeedOptions, lastModified time.Time) *atomFeed {
+	selfLink := dmNewsFeedSelfLink(opts.SiteURL, model.DmNewsFeedAtomPath)
+	updated := lastModified
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	feed := &atomFeed{
+		ID:       selfLink,
+		Title:    opts.Title,
+		Subtitle: opts.Description,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfLink, Rel: "self", Type: "application/atom+xml"},
+			{Href: opts.SiteURL, Rel: "alternate"},
+		},
+		Author:  atomAuthor{Name: opts.Title},
+		Entries: make([]atomEntry, 0, len(items)),
+	}
+
+	for _, item := range items {
+		link := dmNewsFeedItemLink(opts.SiteURL, item.ID)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        link,
+			Title:     item.Title,
+			Link:      atomLink{Href: link, Rel: "alternate"},
+			Published: item.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   dmNewsFeedItemUpdated(item).UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "text", Value: item.Content},
+		})
+	}
+	return feed
+}
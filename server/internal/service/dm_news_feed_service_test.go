@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func newTestDmNewsFeedService(repo *MockDmNewsRepository, now time.Time) *DmNewsFeedService {
+	s := NewDmNewsFeedService(repo)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func testDmNewsFeedOptions() *model.DmNewsFeedOptions {
+	return &model.DmNewsFeedOptions{
+		Title:       "Test News",
+		Description: "Test Description",
+		SiteURL:     "https://example.com/",
+		ItemLimit:   10,
+		MaxCacheAge: time.Hour,
+	}
+}
+
+func TestDmNewsFeedService_BuildDmNewsFeed_RSS(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	published := now.Add(-2 * time.Hour)
+	var gotLimit int
+	repo := &MockDmNewsRepository{
+		ListFeedItemsFunc: func(ctx context.Context, n time.Time, limit int) ([]*model.DmNewsFeedItem, error) {
+			assert.Equal(t, now, n)
+			gotLimit = limit
+			return []*model.DmNewsFeedItem{
+				// 公開予約されていたニュースは公開日時を更新日時とする
+				{ID: 2, Title: "Second <News>", Content: "Body 2", PublishedAt: published, UpdatedAt: published.Add(-24 * time.Hour)},
+				{ID: 1, Title: "First", Content: "Body 1", PublishedAt: published.Add(-time.Hour), UpdatedAt: published.Add(-time.Hour)},
+			}, nil
+		},
+	}
+
+	feed, err := newTestDmNewsFeedService(repo, now).BuildDmNewsFeed(context.Background(), model.DmNewsFeedFormatRSS, testDmNewsFeedOptions())
+	require.NoError(t, err)
+	assert.Equal(t, 10, gotLimit)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", feed.ContentType)
+	assert.Equal(t, published, feed.LastModified)
+	assert.Nil(t, feed.NextPublishAt)
+	assert.Equal(t, now.Add(time.Hour), feed.CacheUntil)
+	assert.NotEmpty(t, feed.ETag)
+
+	var doc rssFeed
+	require.NoError(t, xml.Unmarshal(feed.Body, &doc))
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "Test News", doc.Channel.Title)
+	assert.Equal(t, published.Format(time.RFC1123Z), doc.Channel.LastBuildDate)
+	require.Len(t, doc.Channel.Items, 2)
+	assert.Equal(t, "Second <News>", doc.Channel.Items[0].Title)
+	assert.Equal(t, "https://example.com/news/2", doc.Channel.Items[0].Link)
+	assert.Equal(t, rssGUID{IsPermaLink: true, Value: "https://example.com/news/2"}, doc.Channel.Items[0].GUID)
+	assert.Equal(t, published.Format(time.RFC1123Z), doc.Channel.Items[0].PubDate)
+	assert.Contains(t, string(feed.Body), `<atom:link href="https://example.com/feeds/news.rss" rel="self" type="application/rss+xml"></atom:link>`)
+}
+
+func TestDmNewsFeedService_BuildDmNewsFeed_Atom(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	published := now.Add(-2 * time.Hour)
+	edited := now.Add(-time.Hour)
+	repo := &MockDmNewsRepository{
+		ListFeedItemsFunc: func(ctx context.Context, n time.Time, limit int) ([]*model.DmNewsFeedItem, error) {
+			return []*model.DmNewsFeedItem{
+				{ID: 3, Title: "Edited", Content: "Body", PublishedAt: published, UpdatedAt: edited},
+			}, nil
+		},
+	}
+
+	feed, err := newTestDmNewsFeedService(repo, now).BuildDmNewsFeed(context.Background(), model.DmNewsFeedFormatAtom, testDmNewsFeedOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", feed.ContentType)
+	assert.Equal(t, edited, feed.LastModified)
+
+	var doc atomFeed
+	require.NoError(t, xml.Unmarshal(feed.Body, &doc))
+	assert.Equal(t, "https://example.com/feeds/news.atom", doc.ID)
+	assert.Equal(t, edited.Format(time.RFC3339), doc.Updated)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "https://example.com/news/3", doc.Entries[0].ID)
+	assert.Equal(t, published.Format(time.RFC3339), doc.Entries[0].Published)
+	assert.Equal(t, edited.Format(time.RFC3339), doc.Entries[0].Updated)
+	assert.Equal(t, atomContent{Type: "text", Value: "Body"}, doc.Entries[0].Content)
+}
+
+func TestDmNewsFeedService_BuildDmNewsFeed_CacheUntilNextPublish(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		next      *time.Time
+		wantUntil time.Time
+	}{
+		{name: "公開予定がない場合はMaxCacheAgeまで", next: nil, wantUntil: now.Add(time.Hour)},
+		{name: "公開予定がMaxCacheAgeより前の場合は公開予定まで", next: ptrTime(now.Add(10 * time.Minute)), wantUntil: now.Add(10 * time.Minute)},
+		{name: "公開予定がMaxCacheAgeより後の場合はMaxCacheAgeまで", next: ptrTime(now.Add(3 * time.Hour)), wantUntil: now.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockDmNewsRepository{
+				GetNextPublishAtFunc: func(ctx context.Context, n time.Time) (*time.Time, error) {
+					return tt.next, nil
+				},
+			}
+
+			feed, err := newTestDmNewsFeedService(repo, now).BuildDmNewsFeed(context.Background(), model.DmNewsFeedFormatRSS, testDmNewsFeedOptions())
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, feed.NextPublishAt)
+			assert.Equal(t, tt.wantUntil, feed.CacheUntil)
+		})
+	}
+}
+
+func TestDmNewsFeedService_BuildDmNewsFeed_StableETag(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := &MockDmNewsRepository{}
+
+	// アイテムが同じであれば生成時刻が違っても同じETagになる
+	first, err := newTestDmNewsFeedService(repo, now).BuildDmNewsFeed(context.Background(), model.DmNewsFeedFormatAtom, testDmNewsFeedOptions())
+	require.NoError(t, err)
+	second, err := newTestDmNewsFeedService(repo, now.Add(time.Minute)).BuildDmNewsFeed(context.Background(), model.DmNewsFeedFormatAtom, testDmNewsFeedOptions())
+	require.NoError(t, err)
+	assert.Equal(t, first.ETag, second.ETag)
+	assert.True(t, first.LastModified.IsZero())
+}
+
+func TestDmNewsFeedService_BuildDmNewsFeed_Errors(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	_, err := newTestDmNewsFeedService(&MockDmNewsRepository{}, now).BuildDmNewsFeed(context.Background(), "json", testDmNewsFeedOptions())
+	assert.ErrorIs(t, err, ErrInvalidFeedFormat)
+
+	repo := &MockDmNewsRepository{
+		ListFeedItemsFunc: func(ctx context.Context, n time.Time, limit int) ([]*model.DmNewsFeedItem, error) {
+			return nil, errors.New("db error")
+		},
+	}
+	_, err = newTestDmNewsFeedService(repo, now).BuildDmNewsFeed(context.Background(), model.DmNewsFeedFormatRSS, testDmNewsFeedOptions())
+	assert.Error(t, err)
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
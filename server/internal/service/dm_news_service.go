@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+)
+
+// DmNewsService はニュースのビジネスロジックを担当
+type DmNewsService struct {
+	dmNewsRepo repository.DmNewsRepositoryInterface
+	now        func() time.Time
+}
+
+// NewDmNewsService は新しいDmNewsServiceを作成
+func NewDmNewsService(dmNewsRepo repository.DmNewsRepositoryInterface) *DmNewsService {
+	return &DmNewsService{
+		dmNewsRepo: dmNewsRepo,
+		now:        time.Now,
+	}
+}
+
+// CreateDmNews はニュースを作成
+// published_atを省略した場合は非公開、未来の日時を指定した場合はその日時まで非公開となる
+func (s *DmNewsService) CreateDmNews(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+	// バリデーション
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	dmNews, err := s.dmNewsRepo.Create(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create news: %w", err)
+	}
+
+	return dmNews, nil
+}
+
+// GetPublishedDmNews はIDで公開済みのニュースを取得
+// 非公開・公開日時前のニュースは存在しないものとして扱う
+func (s *DmNewsService) GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error) {
+	if id <= 0 {
+		return nil, apperror.Validation("news id is required")
+	}
+
+	dmNews, err := s.dmNewsRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get news: %w", err)
+	}
+	if dmNews.PublishedAt == nil || dmNews.PublishedAt.After(s.now()) {
+		return nil, fmt.Errorf("failed to get news: %w: %d", repository.ErrDmNewsNotFound, id)
+	}
+
+	return dmNews, nil
+}
+
+// ListPublishedDmNews は公開済みのニュース一覧を公開日時の新しい順に取得
+// authorIDを指定した場合はその作成者のニュースのみを返す
+func (s *DmNewsService) ListPublishedDmNews(ctx context.Context, authorID *int64, limit, offset int) ([]*model.DmNews, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	dmNews, err := s.dmNewsRepo.ListPublished(ctx, &model.DmNewsListQuery{
+		AuthorID: authorID,
+		Now:      s.now(),
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list news: %w", err)
+	}
+
+	return dmNews, nil
+}
+
+// UpdateDmNews はニュースを更新
+func (s *DmNewsService) UpdateDmNews(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+	if id <= 0 {
+		return nil, apperror.Validation("news id is required")
+	}
+
+	// 更新するフィールドが空の場合はエラー
+	if req.Title == "" && req.Content == "" && req.AuthorID == nil && req.PublishedAt == nil {
+		return nil, apperror.Validation("no fields to update")
+	}
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	dmNews, err := s.dmNewsRepo.Update(ctx, id, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update news: %w", err)
+	}
+
+	return dmNews, nil
+}
+
+// PatchDmNews はJSON Merge Patch（RFC 7396）でニュースを部分更新
+// author_id・published_atはnullを指定するとクリアされる。内容が不正な場合はErrInvalidMergePatchを返す
+func (s *DmNewsService) PatchDmNews(ctx context.Context, id int64, patch []byte) (*model.DmNews, error) {
+	if id <= 0 {
+		return nil, apperror.Validation("news id is required")
+	}
+
+	fields, err := parseMergePatch(patch, model.UpdateDmNewsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	dmNews, err := s.dmNewsRepo.Patch(ctx, id, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch news: %w", err)
+	}
+
+	return dmNews, nil
+}
+
+// DeleteDmNews はニュースを削除
+func (s *DmNewsService) DeleteDmNews(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return apperror.Validation("news id is required")
+	}
+
+	if err := s.dmNewsRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete news: %w", err)
+	}
+
+	return nil
+}
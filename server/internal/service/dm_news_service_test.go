@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockDmNewsRepository はDmNewsRepositoryInterfaceのモック
+type MockDmNewsRepository struct {
+	CreateFunc        func(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error)
+	GetByIDFunc       func(ctx context.Context, id int64) (*model.DmNews, error)
+	ListPublishedFunc func(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error)
+	UpdateFunc        func(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error)
+	PatchFunc         func(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error)
+	DeleteFunc        func(ctx context.Context, id int64) error
+
+	ListFeedItemsFunc    func(ctx context.Context, now time.Time, limit int) ([]*model.DmNewsFeedItem, error)
+	GetNextPublishAtFunc func(ctx context.Context, now time.Time) (*time.Time, error)
+}
+
+func (m *MockDmNewsRepository) Create(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, req)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsRepository) GetByID(ctx context.Context, id int64) (*model.DmNews, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsRepository) ListPublished(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error) {
+	if m.ListPublishedFunc != nil {
+		return m.ListPublishedFunc(ctx, q)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsRepository) Update(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, req)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsRepository) Patch(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error) {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(ctx, id, fields)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsRepository) Delete(ctx context.Context, id int64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockDmNewsRepository) ListFeedItems(ctx context.Context, now time.Time, limit int) ([]*model.DmNewsFeedItem, error) {
+	if m.ListFeedItemsFunc != nil {
+		return m.ListFeedItemsFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsRepository) GetNextPublishAt(ctx context.Context, now time.Time) (*time.Time, error) {
+	if m.GetNextPublishAtFunc != nil {
+		return m.GetNextPublishAtFunc(ctx, now)
+	}
+	return nil, nil
+}
+
+func TestDmNewsService_CreateDmNews(t *testing.T) {
+	mockRepo := &MockDmNewsRepository{
+		CreateFunc: func(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+			return &model.DmNews{ID: 1, Title: req.Title, Content: req.Content, PublishedAt: req.PublishedAt}, nil
+		},
+	}
+	s := NewDmNewsService(mockRepo)
+
+	publishedAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	got, err := s.CreateDmNews(context.Background(), &model.CreateDmNewsRequest{Title: "News", Content: "Content", PublishedAt: &publishedAt})
+	require.NoError(t, err)
+	assert.Equal(t, "News", got.Title)
+	assert.Equal(t, &publishedAt, got.PublishedAt)
+
+	_, err = s.CreateDmNews(context.Background(), &model.CreateDmNewsRequest{Content: "Content"})
+	assert.ErrorContains(t, err, "title is required")
+	_, err = s.CreateDmNews(context.Background(), &model.CreateDmNewsRequest{Title: "News"})
+	assert.ErrorContains(t, err, "content is required")
+}
+
+func TestDmNewsService_GetPublishedDmNews(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		publishedAt *time.Time
+		wantErr     bool
+	}{
+		{name: "公開日時を過ぎたニュースは取得できる", publishedAt: &past},
+		{name: "公開日時ちょうどのニュースは取得できる", publishedAt: &now},
+		{name: "公開日時前のニュースは見つからない", publishedAt: &future, wantErr: true},
+		{name: "公開日時未設定のニュースは見つからない", publishedAt: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockDmNewsRepository{
+				GetByIDFunc: func(ctx context.Context, id int64) (*model.DmNews, error) {
+					return &model.DmNews{ID: id, Title: "News", PublishedAt: tt.publishedAt}, nil
+				},
+			}
+			s := NewDmNewsService(mockRepo)
+			s.now = func() time.Time { return now }
+
+			got, err := s.GetPublishedDmNews(context.Background(), 1)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "news not found")
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), got.ID)
+		})
+	}
+}
+
+func TestDmNewsService_ListPublishedDmNews(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	var gotQuery *model.DmNewsListQuery
+	mockRepo := &MockDmNewsRepository{
+		ListPublishedFunc: func(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error) {
+			gotQuery = q
+			return []*model.DmNews{{ID: 1}}, nil
+		},
+	}
+	s := NewDmNewsService(mockRepo)
+	s.now = func() time.Time { return now }
+
+	authorID := int64(7)
+	got, err := s.ListPublishedDmNews(context.Background(), &authorID, 500, -1)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, &model.DmNewsListQuery{AuthorID: &authorID, Now: now, Limit: 100, Offset: 0}, gotQuery)
+
+	mockRepo.ListPublishedFunc = func(ctx context.Context, q *model.DmNewsListQuery) ([]*model.DmNews, error) {
+		return nil, errors.New("database error")
+	}
+	_, err = s.ListPublishedDmNews(context.Background(), nil, 20, 0)
+	assert.ErrorContains(t, err, "failed to list news")
+}
+
+func TestDmNewsService_UpdateDmNews(t *testing.T) {
+	mockRepo := &MockDmNewsRepository{
+		UpdateFunc: func(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+			return &model.DmNews{ID: id, Title: req.Title}, nil
+		},
+	}
+	s := NewDmNewsService(mockRepo)
+
+	got, err := s.UpdateDmNews(context.Background(), 1, &model.UpdateDmNewsRequest{Title: "Updated"})
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", got.Title)
+
+	_, err = s.UpdateDmNews(context.Background(), 1, &model.UpdateDmNewsRequest{})
+	assert.ErrorContains(t, err, "no fields to update")
+	_, err = s.UpdateDmNews(context.Background(), 0, &model.UpdateDmNewsRequest{Title: "Updated"})
+	assert.Error(t, err)
+}
+
+func TestDmNewsService_DeleteDmNews(t *testing.T) {
+	var deletedID int64
+	mockRepo := &MockDmNewsRepository{
+		DeleteFunc: func(ctx context.Context, id int64) error {
+			deletedID = id
+			return nil
+		},
+	}
+	s := NewDmNewsService(mockRepo)
+
+	require.NoError(t, s.DeleteDmNews(context.Background(), 3))
+	assert.Equal(t, int64(3), deletedID)
+
+	mockRepo.DeleteFunc = func(ctx context.Context, id int64) error {
+		return errors.New("news not found: 3")
+	}
+	assert.ErrorContains(t, s.DeleteDmNews(context.Background(), 3), "failed to delete news")
+	assert.Error(t, s.DeleteDmNews(context.Background(), 0))
+}
+
+func TestDmNewsService_PatchDmNews(t *testing.T) {
+	var gotFields map[string]interface{}
+	mockRepo := &MockDmNewsRepository{
+		PatchFunc: func(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error) {
+			gotFields = fields
+			return &model.DmNews{ID: id, Title: "News"}, nil
+		},
+	}
+	s := NewDmNewsService(mockRepo)
+
+	got, err := s.PatchDmNews(context.Background(), 1, []byte(`{"author_id":null}`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got.ID)
+	require.Contains(t, gotFields, "author_id")
+	assert.Nil(t, gotFields["author_id"])
+}
+
+func TestDmNewsService_PatchDmNews_Error(t *testing.T) {
+	mockRepo := &MockDmNewsRepository{
+		PatchFunc: func(ctx context.Context, id int64, fields map[string]interface{}) (*model.DmNews, error) {
+			return nil, errors.New("database error")
+		},
+	}
+	s := NewDmNewsService(mockRepo)
+
+	_, err := s.PatchDmNews(context.Background(), 0, []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = s.PatchDmNews(context.Background(), 1, []byte(`{"title":null}`))
+	assert.ErrorIs(t, err, ErrInvalidMergePatch)
+
+	_, err = s.PatchDmNews(context.Background(), 1, []byte(`{"title":"x"}`))
+	assert.ErrorContains(t, err, "failed to patch news")
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/validation"
 )
 
 // DmPostService は投稿のビジネスロジックを担当
@@ -25,20 +29,17 @@ func NewDmPostService(dmPostRepo repository.DmPostRepositoryInterface, dmUserRep
 // CreateDmPost は投稿を作成
 func (s *DmPostService) CreateDmPost(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
 	// バリデーション
-	if req.UserID == "" {
-		return nil, fmt.Errorf("user id is required")
-	}
-	if req.Title == "" {
-		return nil, fmt.Errorf("title is required")
-	}
-	if req.Content == "" {
-		return nil, fmt.Errorf("content is required")
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
-	// ユーザーの存在確認
+	// ユーザーの存在確認（存在しない場合はuser_idの検証エラー）
 	_, err := s.dmUserRepo.GetByID(ctx, req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, repository.ErrDmUserNotFound) {
+			return nil, validation.NewFieldError("user_id", validation.RuleExists, "user")
+		}
+		return nil, err
 	}
 
 	// 投稿作成
@@ -53,10 +54,10 @@ func (s *DmPostService) CreateDmPost(ctx context.Context, req *model.CreateDmPos
 // GetDmPost はIDで投稿を取得
 func (s *DmPostService) GetDmPost(ctx context.Context, id string, userID string) (*model.DmPost, error) {
 	if id == "" {
-		return nil, fmt.Errorf("post id is required")
+		return nil, apperror.Validation("post id is required")
 	}
 	if userID == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, apperror.Validation("user id is required")
 	}
 
 	dmPost, err := s.dmPostRepo.GetByID(ctx, id, userID)
@@ -67,8 +68,42 @@ func (s *DmPostService) GetDmPost(ctx context.Context, id string, userID string)
 	return dmPost, nil
 }
 
+// BatchGetDmPosts は複数の投稿IDとユーザーIDの組で投稿を一括取得
+// 結果はリクエストの順序で返し、見つからなかったキーはMissingに含める。重複したキーは1件として扱う
+func (s *DmPostService) BatchGetDmPosts(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error) {
+	keys, err := uniqueBatchKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if key.ID == "" || key.UserID == "" {
+			return nil, fmt.Errorf("%w: post id and user id are required", ErrInvalidBatchGet)
+		}
+	}
+
+	found, err := s.dmPostRepo.GetByKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get posts: %w", err)
+	}
+
+	result := &model.DmPostBatchGetResult{
+		Items:   make([]*model.DmPost, 0, len(found)),
+		Missing: make([]model.DmPostKey, 0),
+	}
+	for _, key := range keys {
+		if dmPost, ok := found[key]; ok {
+			result.Items = append(result.Items, dmPost)
+		} else {
+			result.Missing = append(result.Missing, key)
+		}
+	}
+
+	return result, nil
+}
+
 // ListDmPosts は投稿一覧を取得
-func (s *DmPostService) ListDmPosts(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+// filter・sortはParseListQueryの形式で指定する（空の場合は絞り込み・並べ替えなし）
+func (s *DmPostService) ListDmPosts(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -78,8 +113,16 @@ func (s *DmPostService) ListDmPosts(ctx context.Context, limit, offset int) ([]*
 	if offset < 0 {
 		offset = 0
 	}
+	if offset+limit > model.ListMaxWindow {
+		return nil, apperror.Validation(fmt.Sprintf("offset + limit must be less than or equal to %d", model.ListMaxWindow))
+	}
+
+	q, err := ParseListQuery(filter, sort, model.DmPostListFields)
+	if err != nil {
+		return nil, err
+	}
 
-	dmPosts, err := s.dmPostRepo.List(ctx, limit, offset)
+	dmPosts, err := s.dmPostRepo.List(ctx, limit, offset, q)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
@@ -88,9 +131,9 @@ func (s *DmPostService) ListDmPosts(ctx context.Context, limit, offset int) ([]*
 }
 
 // ListDmPostsByUser はユーザーIDで投稿一覧を取得
-func (s *DmPostService) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+func (s *DmPostService) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 	if userID == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, apperror.Validation("user id is required")
 	}
 	if limit <= 0 {
 		limit = 20
@@ -101,8 +144,16 @@ func (s *DmPostService) ListDmPostsByUser(ctx context.Context, userID string, li
 	if offset < 0 {
 		offset = 0
 	}
+	if offset+limit > model.ListMaxWindow {
+		return nil, apperror.Validation(fmt.Sprintf("offset + limit must be less than or equal to %d", model.ListMaxWindow))
+	}
+
+	q, err := ParseListQuery(filter, sort, model.DmPostListFields)
+	if err != nil {
+		return nil, err
+	}
 
-	dmPosts, err := s.dmPostRepo.ListByUserID(ctx, userID, limit, offset)
+	dmPosts, err := s.dmPostRepo.ListByUserID(ctx, userID, limit, offset, q)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list posts by user: %w", err)
 	}
@@ -110,6 +161,33 @@ func (s *DmPostService) ListDmPostsByUser(ctx context.Context, userID string, li
 	return dmPosts, nil
 }
 
+// ListDmPostsByUsers は複数ユーザーの投稿をユーザーごとに新しい順でlimit件ずつ取得
+// 投稿はユーザーIDのシャーディングテーブルごとにまとめて取得する。投稿がないユーザーは結果に含まれない
+func (s *DmPostService) ListDmPostsByUsers(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	userIDs, err := uniqueBatchKeys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		if userID == "" {
+			return nil, fmt.Errorf("%w: user id is required", ErrInvalidBatchGet)
+		}
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	dmPosts, err := s.dmPostRepo.ListByUserIDs(ctx, userIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts by users: %w", err)
+	}
+
+	return dmPosts, nil
+}
+
 // GetDmUserPosts はユーザーと投稿をJOINして取得
 func (s *DmPostService) GetDmUserPosts(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error) {
 	if limit <= 0 {
@@ -130,18 +208,60 @@ func (s *DmPostService) GetDmUserPosts(ctx context.Context, limit, offset int) (
 	return dmUserPosts, nil
 }
 
+// SearchDmPosts は投稿を全文検索
+func (s *DmPostService) SearchDmPosts(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+	q.Query = strings.TrimSpace(q.Query)
+	if q.Query == "" {
+		return nil, apperror.Validation("search query is required")
+	}
+	if q.Mode == "" {
+		q.Mode = model.DmPostSearchModeWord
+	}
+	if q.Mode != model.DmPostSearchModeWord && q.Mode != model.DmPostSearchModeNgram {
+		return nil, fmt.Errorf("unsupported search mode: %s", q.Mode)
+	}
+	if q.Sort == "" {
+		q.Sort = model.DmPostSearchSortRelevance
+	}
+	if q.Sort != model.DmPostSearchSortRelevance && q.Sort != model.DmPostSearchSortDate {
+		return nil, fmt.Errorf("unsupported sort: %s", q.Sort)
+	}
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+	if q.Limit > 100 {
+		q.Limit = 100
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	if q.Offset+q.Limit > model.DmPostSearchMaxWindow {
+		return nil, fmt.Errorf("offset + limit must be less than or equal to %d", model.DmPostSearchMaxWindow)
+	}
+
+	hits, err := s.dmPostRepo.Search(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	return hits, nil
+}
+
 // UpdateDmPost は投稿を更新
 func (s *DmPostService) UpdateDmPost(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error) {
 	if id == "" {
-		return nil, fmt.Errorf("post id is required")
+		return nil, apperror.Validation("post id is required")
 	}
 	if userID == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, apperror.Validation("user id is required")
 	}
 
 	// 更新するフィールドが空の場合はエラー
 	if req.Title == "" && req.Content == "" {
-		return nil, fmt.Errorf("no fields to update")
+		return nil, apperror.Validation("no fields to update")
+	}
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
 	dmPost, err := s.dmPostRepo.Update(ctx, id, userID, req)
@@ -152,13 +272,36 @@ func (s *DmPostService) UpdateDmPost(ctx context.Context, id string, userID stri
 	return dmPost, nil
 }
 
+// PatchDmPost はJSON Merge Patch（RFC 7396）で投稿を部分更新
+// パッチに含まれるフィールドのみを更新し、内容が不正な場合はErrInvalidMergePatchを返す
+func (s *DmPostService) PatchDmPost(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error) {
+	if id == "" {
+		return nil, apperror.Validation("post id is required")
+	}
+	if userID == "" {
+		return nil, apperror.Validation("user id is required")
+	}
+
+	fields, err := parseMergePatch(patch, model.UpdateDmPostRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	dmPost, err := s.dmPostRepo.Patch(ctx, id, userID, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch post: %w", err)
+	}
+
+	return dmPost, nil
+}
+
 // DeleteDmPost は投稿を削除
 func (s *DmPostService) DeleteDmPost(ctx context.Context, id string, userID string) error {
 	if id == "" {
-		return fmt.Errorf("post id is required")
+		return apperror.Validation("post id is required")
 	}
 	if userID == "" {
-		return fmt.Errorf("user id is required")
+		return apperror.Validation("user id is required")
 	}
 
 	if err := s.dmPostRepo.Delete(ctx, id, userID); err != nil {
@@ -3,22 +3,29 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
 )
 
 // MockDmPostRepository はテスト用のモックリポジトリ
 type MockDmPostRepository struct {
-	CreateFunc       func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
-	GetByIDFunc      func(ctx context.Context, id string, userID string) (*model.DmPost, error)
-	ListByUserIDFunc func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error)
-	ListFunc         func(ctx context.Context, limit, offset int) ([]*model.DmPost, error)
-	GetUserPostsFunc func(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
-	UpdateFunc       func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
-	DeleteFunc       func(ctx context.Context, id string, userID string) error
+	CreateFunc        func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
+	GetByIDFunc       func(ctx context.Context, id string, userID string) (*model.DmPost, error)
+	GetByKeysFunc     func(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error)
+	ListByUserIDFunc  func(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error)
+	ListByUserIDsFunc func(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error)
+	ListFunc          func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error)
+	GetUserPostsFunc  func(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
+	UpdateFunc        func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
+	PatchFunc         func(ctx context.Context, id string, userID string, fields map[string]interface{}) (*model.DmPost, error)
+	DeleteFunc        func(ctx context.Context, id string, userID string) error
+	SearchFunc        func(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error)
 }
 
 func (m *MockDmPostRepository) Create(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
@@ -35,16 +42,30 @@ func (m *MockDmPostRepository) GetByID(ctx context.Context, id string, userID st
 	return nil, nil
 }
 
-func (m *MockDmPostRepository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+func (m *MockDmPostRepository) GetByKeys(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error) {
+	if m.GetByKeysFunc != nil {
+		return m.GetByKeysFunc(ctx, keys)
+	}
+	return nil, nil
+}
+
+func (m *MockDmPostRepository) ListByUserID(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 	if m.ListByUserIDFunc != nil {
-		return m.ListByUserIDFunc(ctx, userID, limit, offset)
+		return m.ListByUserIDFunc(ctx, userID, limit, offset, q)
 	}
 	return nil, nil
 }
 
-func (m *MockDmPostRepository) List(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+func (m *MockDmPostRepository) ListByUserIDs(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	if m.ListByUserIDsFunc != nil {
+		return m.ListByUserIDsFunc(ctx, userIDs, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockDmPostRepository) List(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 	if m.ListFunc != nil {
-		return m.ListFunc(ctx, limit, offset)
+		return m.ListFunc(ctx, limit, offset, q)
 	}
 	return nil, nil
 }
@@ -63,6 +84,13 @@ func (m *MockDmPostRepository) Update(ctx context.Context, id string, userID str
 	return nil, nil
 }
 
+func (m *MockDmPostRepository) Patch(ctx context.Context, id string, userID string, fields map[string]interface{}) (*model.DmPost, error) {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(ctx, id, userID, fields)
+	}
+	return nil, nil
+}
+
 func (m *MockDmPostRepository) Delete(ctx context.Context, id string, userID string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id, userID)
@@ -70,6 +98,16 @@ func (m *MockDmPostRepository) Delete(ctx context.Context, id string, userID str
 	return nil
 }
 
+func (m *MockDmPostRepository) Search(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, q)
+	}
+	return nil, nil
+}
+
+// testUUIDv7UserID はUUIDv7形式のユーザーID（作成リクエストの検証を通過する）
+const testUUIDv7UserID = "019a1b2c3d4e7f00a123456789abcdef"
+
 func TestDmPostService_CreateDmPost(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -82,7 +120,7 @@ func TestDmPostService_CreateDmPost(t *testing.T) {
 		{
 			name: "正常系: 投稿を作成できる",
 			req: &model.CreateDmPostRequest{
-				UserID:  "user-001",
+				UserID:  testUUIDv7UserID,
 				Title:   "Test Title",
 				Content: "Test Content",
 			},
@@ -123,12 +161,12 @@ func TestDmPostService_CreateDmPost(t *testing.T) {
 				return &MockDmUserRepository{}
 			},
 			wantErr:    true,
-			errContain: "user id is required",
+			errContain: "user_id is required",
 		},
 		{
 			name: "異常系: タイトルが空の場合エラー",
 			req: &model.CreateDmPostRequest{
-				UserID:  "user-001",
+				UserID:  testUUIDv7UserID,
 				Title:   "",
 				Content: "Test Content",
 			},
@@ -144,7 +182,7 @@ func TestDmPostService_CreateDmPost(t *testing.T) {
 		{
 			name: "異常系: コンテンツが空の場合エラー",
 			req: &model.CreateDmPostRequest{
-				UserID:  "user-001",
+				UserID:  testUUIDv7UserID,
 				Title:   "Test Title",
 				Content: "",
 			},
@@ -160,7 +198,7 @@ func TestDmPostService_CreateDmPost(t *testing.T) {
 		{
 			name: "異常系: ユーザーが存在しない場合エラー",
 			req: &model.CreateDmPostRequest{
-				UserID:  "user-001",
+				UserID:  testUUIDv7UserID,
 				Title:   "Test Title",
 				Content: "Test Content",
 			},
@@ -170,17 +208,37 @@ func TestDmPostService_CreateDmPost(t *testing.T) {
 			setupMockUser: func() *MockDmUserRepository {
 				return &MockDmUserRepository{
 					GetByIDFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
-						return nil, errors.New("user not found")
+						return nil, fmt.Errorf("%w: %s", repository.ErrDmUserNotFound, id)
 					},
 				}
 			},
 			wantErr:    true,
-			errContain: "user not found",
+			errContain: "user_id must refer to an existing user",
+		},
+		{
+			name: "異常系: ユーザーの取得に失敗した場合はそのまま返す",
+			req: &model.CreateDmPostRequest{
+				UserID:  testUUIDv7UserID,
+				Title:   "Test Title",
+				Content: "Test Content",
+			},
+			setupMockPost: func() *MockDmPostRepository {
+				return &MockDmPostRepository{}
+			},
+			setupMockUser: func() *MockDmUserRepository {
+				return &MockDmUserRepository{
+					GetByIDFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
+						return nil, errors.New("connection refused")
+					},
+				}
+			},
+			wantErr:    true,
+			errContain: "connection refused",
 		},
 		{
 			name: "異常系: リポジトリエラー",
 			req: &model.CreateDmPostRequest{
-				UserID:  "user-001",
+				UserID:  testUUIDv7UserID,
 				Title:   "Test Title",
 				Content: "Test Content",
 			},
@@ -227,6 +285,24 @@ func TestDmPostService_CreateDmPost(t *testing.T) {
 	}
 }
 
+func TestDmPostService_CreateDmPost_UserNotFound(t *testing.T) {
+	mockUserRepo := &MockDmUserRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrDmUserNotFound, id)
+		},
+	}
+	s := NewDmPostService(&MockDmPostRepository{}, mockUserRepo)
+
+	// 存在しないユーザーは404ではなくuser_idの検証エラー（422）
+	_, err := s.CreateDmPost(context.Background(), &model.CreateDmPostRequest{
+		UserID:  testUUIDv7UserID,
+		Title:   "Test Title",
+		Content: "Test Content",
+	})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.NotErrorIs(t, err, apperror.ErrNotFound)
+}
+
 func TestDmPostService_GetDmPost(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -329,7 +405,7 @@ func TestDmPostService_ListDmPosts(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						return []*model.DmPost{
 							{ID: "post-001", Title: "Post 1"},
 							{ID: "post-002", Title: "Post 2"},
@@ -345,7 +421,7 @@ func TestDmPostService_ListDmPosts(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						assert.Equal(t, 20, limit)
 						return []*model.DmPost{}, nil
 					},
@@ -359,7 +435,7 @@ func TestDmPostService_ListDmPosts(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						assert.Equal(t, 100, limit)
 						return []*model.DmPost{}, nil
 					},
@@ -373,7 +449,7 @@ func TestDmPostService_ListDmPosts(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						return nil, errors.New("database error")
 					},
 				}
@@ -388,7 +464,7 @@ func TestDmPostService_ListDmPosts(t *testing.T) {
 			mockPostRepo := tt.setupMockPost()
 			s := NewDmPostService(mockPostRepo, &MockDmUserRepository{})
 
-			got, err := s.ListDmPosts(context.Background(), tt.limit, tt.offset)
+			got, err := s.ListDmPosts(context.Background(), tt.limit, tt.offset, "", "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -421,7 +497,7 @@ func TestDmPostService_ListDmPostsByUser(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListByUserIDFunc: func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+					ListByUserIDFunc: func(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						return []*model.DmPost{
 							{ID: "post-001", UserID: userID, Title: "Post 1"},
 							{ID: "post-002", UserID: userID, Title: "Post 2"},
@@ -442,6 +518,17 @@ func TestDmPostService_ListDmPostsByUser(t *testing.T) {
 			wantErr:    true,
 			errContain: "user id is required",
 		},
+		{
+			name:   "異常系: offset+limitが上限を超える場合エラー",
+			userID: "user-001",
+			limit:  20,
+			offset: model.ListMaxWindow - 10,
+			setupMockPost: func() *MockDmPostRepository {
+				return &MockDmPostRepository{}
+			},
+			wantErr:    true,
+			errContain: "offset + limit must be less than or equal to 1000",
+		},
 		{
 			name:   "正常系: limitが0以下の場合デフォルト20が適用される",
 			userID: "user-001",
@@ -449,7 +536,7 @@ func TestDmPostService_ListDmPostsByUser(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListByUserIDFunc: func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+					ListByUserIDFunc: func(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						assert.Equal(t, 20, limit)
 						return []*model.DmPost{}, nil
 					},
@@ -464,7 +551,7 @@ func TestDmPostService_ListDmPostsByUser(t *testing.T) {
 			offset: 0,
 			setupMockPost: func() *MockDmPostRepository {
 				return &MockDmPostRepository{
-					ListByUserIDFunc: func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+					ListByUserIDFunc: func(ctx context.Context, userID string, limit, offset int, q *model.ListQuery) ([]*model.DmPost, error) {
 						return nil, errors.New("database error")
 					},
 				}
@@ -479,7 +566,7 @@ func TestDmPostService_ListDmPostsByUser(t *testing.T) {
 			mockPostRepo := tt.setupMockPost()
 			s := NewDmPostService(mockPostRepo, &MockDmUserRepository{})
 
-			got, err := s.ListDmPostsByUser(context.Background(), tt.userID, tt.limit, tt.offset)
+			got, err := s.ListDmPostsByUser(context.Background(), tt.userID, tt.limit, tt.offset, "", "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -706,6 +793,94 @@ func TestDmPostService_UpdateDmPost(t *testing.T) {
 	}
 }
 
+func TestDmPostService_PatchDmPost(t *testing.T) {
+	var gotFields map[string]interface{}
+	mockPostRepo := &MockDmPostRepository{
+		PatchFunc: func(ctx context.Context, id string, userID string, fields map[string]interface{}) (*model.DmPost, error) {
+			gotFields = fields
+			return &model.DmPost{ID: id, UserID: userID, Title: "Original", Content: "Patched"}, nil
+		},
+	}
+	s := NewDmPostService(mockPostRepo, &MockDmUserRepository{})
+
+	got, err := s.PatchDmPost(context.Background(), "post-001", "user-001", []byte(`{"content":"Patched"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Patched", got.Content)
+	assert.Equal(t, map[string]interface{}{"content": "Patched"}, gotFields)
+
+	_, err = s.PatchDmPost(context.Background(), "post-001", "user-001", []byte(`{"user_id":"other"}`))
+	assert.ErrorIs(t, err, ErrInvalidMergePatch)
+
+	_, err = s.PatchDmPost(context.Background(), "post-001", "", []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestDmPostService_BatchGetDmPosts(t *testing.T) {
+	keyA := model.DmPostKey{ID: "post-001", UserID: "user-001"}
+	keyB := model.DmPostKey{ID: "post-002", UserID: "user-001"}
+	keyC := model.DmPostKey{ID: "post-003", UserID: "user-002"}
+
+	var gotKeys []model.DmPostKey
+	mockPostRepo := &MockDmPostRepository{
+		GetByKeysFunc: func(ctx context.Context, keys []model.DmPostKey) (map[model.DmPostKey]*model.DmPost, error) {
+			gotKeys = keys
+			return map[model.DmPostKey]*model.DmPost{
+				keyA: {ID: keyA.ID, UserID: keyA.UserID},
+				keyC: {ID: keyC.ID, UserID: keyC.UserID},
+			}, nil
+		},
+	}
+	s := NewDmPostService(mockPostRepo, &MockDmUserRepository{})
+
+	// 重複したキーは1件として扱い、結果はリクエストの順序で返す
+	got, err := s.BatchGetDmPosts(context.Background(), []model.DmPostKey{keyC, keyB, keyA, keyC})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.DmPostKey{keyC, keyB, keyA}, gotKeys)
+	if assert.Len(t, got.Items, 2) {
+		assert.Equal(t, keyC.ID, got.Items[0].ID)
+		assert.Equal(t, keyA.ID, got.Items[1].ID)
+	}
+	assert.Equal(t, []model.DmPostKey{keyB}, got.Missing)
+
+	// 件数が不正な場合・IDが空の場合はErrInvalidBatchGetを返す
+	_, err = s.BatchGetDmPosts(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+	_, err = s.BatchGetDmPosts(context.Background(), []model.DmPostKey{{ID: "post-001"}})
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+}
+
+func TestDmPostService_ListDmPostsByUsers(t *testing.T) {
+	var gotUserIDs []string
+	var gotLimit int
+	mockPostRepo := &MockDmPostRepository{
+		ListByUserIDsFunc: func(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+			gotUserIDs = userIDs
+			gotLimit = limit
+			return map[string][]*model.DmPost{
+				"user-001": {{ID: "post-001", UserID: "user-001"}},
+			}, nil
+		},
+	}
+	s := NewDmPostService(mockPostRepo, &MockDmUserRepository{})
+
+	// 重複したユーザーIDは1件として扱い、limitは1〜100に丸める
+	got, err := s.ListDmPostsByUsers(context.Background(), []string{"user-001", "user-002", "user-001"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-001", "user-002"}, gotUserIDs)
+	assert.Equal(t, 20, gotLimit)
+	assert.Len(t, got["user-001"], 1)
+
+	_, err = s.ListDmPostsByUsers(context.Background(), []string{"user-001"}, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, gotLimit)
+
+	// 件数が不正な場合・IDが空の場合はErrInvalidBatchGetを返す
+	_, err = s.ListDmPostsByUsers(context.Background(), nil, 20)
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+	_, err = s.ListDmPostsByUsers(context.Background(), []string{""}, 20)
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+}
+
 func TestDmPostService_DeleteDmPost(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -783,3 +958,72 @@ func TestDmPostService_DeleteDmPost(t *testing.T) {
 		})
 	}
 }
+
+func TestDmPostService_SearchDmPosts(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      *model.DmPostSearchQuery
+		wantErr    bool
+		errContain string
+		wantQuery  *model.DmPostSearchQuery
+	}{
+		{
+			name:      "applies defaults",
+			query:     &model.DmPostSearchQuery{Query: "  golang  "},
+			wantQuery: &model.DmPostSearchQuery{Query: "golang", Mode: model.DmPostSearchModeWord, Sort: model.DmPostSearchSortRelevance, Limit: 20},
+		},
+		{
+			name:      "caps limit",
+			query:     &model.DmPostSearchQuery{Query: "東京", Mode: model.DmPostSearchModeNgram, Sort: model.DmPostSearchSortDate, Limit: 500, Offset: 40},
+			wantQuery: &model.DmPostSearchQuery{Query: "東京", Mode: model.DmPostSearchModeNgram, Sort: model.DmPostSearchSortDate, Limit: 100, Offset: 40},
+		},
+		{
+			name:       "empty query",
+			query:      &model.DmPostSearchQuery{Query: "   "},
+			wantErr:    true,
+			errContain: "search query is required",
+		},
+		{
+			name:       "unsupported mode",
+			query:      &model.DmPostSearchQuery{Query: "a", Mode: "fuzzy"},
+			wantErr:    true,
+			errContain: "unsupported search mode",
+		},
+		{
+			name:       "unsupported sort",
+			query:      &model.DmPostSearchQuery{Query: "a", Sort: "title"},
+			wantErr:    true,
+			errContain: "unsupported sort",
+		},
+		{
+			name:       "window too large",
+			query:      &model.DmPostSearchQuery{Query: "a", Limit: 100, Offset: 950},
+			wantErr:    true,
+			errContain: "offset + limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery *model.DmPostSearchQuery
+			mockPostRepo := &MockDmPostRepository{
+				SearchFunc: func(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+					gotQuery = q
+					return []*model.DmPostSearchHit{}, nil
+				},
+			}
+			service := NewDmPostService(mockPostRepo, &MockDmUserRepository{})
+
+			hits, err := service.SearchDmPosts(context.Background(), tt.query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContain)
+				assert.Nil(t, gotQuery)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, hits)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+		})
+	}
+}
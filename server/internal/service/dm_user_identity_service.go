@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+)
+
+// ErrDmUserIdentityNotFound はアカウントの紐づけが存在しない場合のエラー
+var ErrDmUserIdentityNotFound = repository.ErrDmUserIdentityNotFound
+
+// DmUserIdentityService はOIDCのIssuerのアカウントとdm_usersの紐づけのビジネスロジックを担当
+type DmUserIdentityService struct {
+	identityRepo repository.DmUserIdentityRepositoryInterface
+}
+
+// NewDmUserIdentityService は新しいDmUserIdentityServiceを作成
+func NewDmUserIdentityService(identityRepo repository.DmUserIdentityRepositoryInterface) *DmUserIdentityService {
+	return &DmUserIdentityService{
+		identityRepo: identityRepo,
+	}
+}
+
+// GetDmUserIdentity はissとsubでアカウントの紐づけを取得（紐づいていない場合はErrDmUserIdentityNotFound）
+func (s *DmUserIdentityService) GetDmUserIdentity(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+	identity, err := s.identityRepo.GetByIssuerSubject(ctx, issuer, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// LinkDmUserIdentity はアカウントをユーザーに紐づける
+// 同じユーザーに紐づけ済みの場合は既存の紐づけを返し、他のユーザーに紐づけ済みの場合はConflictを返す
+func (s *DmUserIdentityService) LinkDmUserIdentity(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error) {
+	if dmUserID == "" || issuer == "" || subject == "" {
+		return nil, apperror.Validation("user id, issuer and subject are required")
+	}
+
+	identity := &model.DmUserIdentity{
+		Issuer:   issuer,
+		Subject:  subject,
+		DmUserID: dmUserID,
+		Email:    email,
+	}
+	err := s.identityRepo.Create(ctx, identity)
+	if err == nil {
+		return identity, nil
+	}
+	if !errors.Is(err, repository.ErrDmUserIdentityConflict) {
+		return nil, fmt.Errorf("failed to link user identity: %w", err)
+	}
+
+	// 紐づけ済みのアカウント
+	existing, getErr := s.identityRepo.GetByIssuerSubject(ctx, issuer, subject)
+	if getErr != nil {
+		return nil, fmt.Errorf("failed to get user identity: %w", getErr)
+	}
+	if existing.DmUserID != dmUserID {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// ListDmUserIdentities はユーザーに紐づくアカウントを取得
+func (s *DmUserIdentityService) ListDmUserIdentities(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+	if dmUserID == "" {
+		return nil, apperror.Validation("user id is required")
+	}
+
+	identities, err := s.identityRepo.ListByDmUserID(ctx, dmUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// UnlinkDmUserIdentity はユーザーに紐づくアカウントの紐づけを解除
+func (s *DmUserIdentityService) UnlinkDmUserIdentity(ctx context.Context, dmUserID string, id int64) error {
+	if dmUserID == "" {
+		return apperror.Validation("user id is required")
+	}
+
+	if err := s.identityRepo.Delete(ctx, dmUserID, id); err != nil {
+		return fmt.Errorf("failed to unlink user identity: %w", err)
+	}
+
+	return nil
+}
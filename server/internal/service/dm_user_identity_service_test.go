@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+)
+
+// MockDmUserIdentityRepository はDmUserIdentityRepositoryInterfaceのモック
+type MockDmUserIdentityRepository struct {
+	identities []*model.DmUserIdentity
+}
+
+func (m *MockDmUserIdentityRepository) Create(ctx context.Context, identity *model.DmUserIdentity) error {
+	for _, existing := range m.identities {
+		if existing.Issuer == identity.Issuer && existing.Subject == identity.Subject {
+			return fmt.Errorf("%w: %s %s", repository.ErrDmUserIdentityConflict, identity.Issuer, identity.Subject)
+		}
+	}
+	identity.ID = int64(len(m.identities) + 1)
+	m.identities = append(m.identities, identity)
+	return nil
+}
+
+func (m *MockDmUserIdentityRepository) GetByIssuerSubject(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+	for _, identity := range m.identities {
+		if identity.Issuer == issuer && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %s", repository.ErrDmUserIdentityNotFound, issuer, subject)
+}
+
+func (m *MockDmUserIdentityRepository) ListByDmUserID(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+	identities := make([]*model.DmUserIdentity, 0)
+	for _, identity := range m.identities {
+		if identity.DmUserID == dmUserID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (m *MockDmUserIdentityRepository) Delete(ctx context.Context, dmUserID string, id int64) error {
+	for i, identity := range m.identities {
+		if identity.ID == id && identity.DmUserID == dmUserID {
+			m.identities = append(m.identities[:i], m.identities[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d", repository.ErrDmUserIdentityNotFound, id)
+}
+
+func TestDmUserIdentityService_LinkDmUserIdentity(t *testing.T) {
+	svc := NewDmUserIdentityService(&MockDmUserIdentityRepository{})
+	ctx := context.Background()
+
+	identity, err := svc.LinkDmUserIdentity(ctx, "user1", "https://example.auth0.com/", "auth0|123", "user1@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "user1", identity.DmUserID)
+
+	// 同じユーザーへの紐づけは既存の紐づけを返す
+	again, err := svc.LinkDmUserIdentity(ctx, "user1", "https://example.auth0.com/", "auth0|123", "user1@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, identity.ID, again.ID)
+
+	// 他のユーザーに紐づけ済みのアカウント
+	_, err = svc.LinkDmUserIdentity(ctx, "user2", "https://example.auth0.com/", "auth0|123", "user2@example.com")
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+
+	_, err = svc.LinkDmUserIdentity(ctx, "user1", "", "auth0|123", "")
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	got, err := svc.GetDmUserIdentity(ctx, "https://example.auth0.com/", "auth0|123")
+	require.NoError(t, err)
+	assert.Equal(t, "user1", got.DmUserID)
+
+	_, err = svc.GetDmUserIdentity(ctx, "https://example.auth0.com/", "unknown")
+	assert.ErrorIs(t, err, ErrDmUserIdentityNotFound)
+}
+
+func TestDmUserIdentityService_UnlinkDmUserIdentity(t *testing.T) {
+	svc := NewDmUserIdentityService(&MockDmUserIdentityRepository{})
+	ctx := context.Background()
+
+	identity, err := svc.LinkDmUserIdentity(ctx, "user1", "https://example.auth0.com/", "auth0|123", "user1@example.com")
+	require.NoError(t, err)
+	_, err = svc.LinkDmUserIdentity(ctx, "user1", "https://keycloak.example.com/realms/app", "456", "user1@example.com")
+	require.NoError(t, err)
+
+	// 他のユーザーの紐づけは解除できない
+	assert.ErrorIs(t, svc.UnlinkDmUserIdentity(ctx, "user2", identity.ID), apperror.ErrNotFound)
+	require.NoError(t, svc.UnlinkDmUserIdentity(ctx, "user1", identity.ID))
+
+	identities, err := svc.ListDmUserIdentities(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "456", identities[0].Subject)
+}
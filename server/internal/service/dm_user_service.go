@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/validation"
 )
 
 // DmUserService はユーザーのビジネスロジックを担当
@@ -23,11 +25,11 @@ func NewDmUserService(dmUserRepo repository.DmUserRepositoryInterface) *DmUserSe
 // CreateDmUser はユーザーを作成
 func (s *DmUserService) CreateDmUser(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
 	// バリデーション
-	if req.Name == "" {
-		return nil, fmt.Errorf("name is required")
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
-	if req.Email == "" {
-		return nil, fmt.Errorf("email is required")
+	if err := s.validateEmailUnique(ctx, "", req.Email); err != nil {
+		return nil, err
 	}
 
 	// ユーザー作成
@@ -42,7 +44,7 @@ func (s *DmUserService) CreateDmUser(ctx context.Context, req *model.CreateDmUse
 // GetDmUser はIDでユーザーを取得
 func (s *DmUserService) GetDmUser(ctx context.Context, id string) (*model.DmUser, error) {
 	if id == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, apperror.Validation("user id is required")
 	}
 
 	dmUser, err := s.dmUserRepo.GetByID(ctx, id)
@@ -53,8 +55,42 @@ func (s *DmUserService) GetDmUser(ctx context.Context, id string) (*model.DmUser
 	return dmUser, nil
 }
 
+// BatchGetDmUsers は複数のIDでユーザーを一括取得
+// 結果はリクエストの順序で返し、見つからなかったIDはMissingに含める。重複したIDは1件として扱う
+func (s *DmUserService) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	ids, err := uniqueBatchKeys(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("%w: user id is required", ErrInvalidBatchGet)
+		}
+	}
+
+	found, err := s.dmUserRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get users: %w", err)
+	}
+
+	result := &model.DmUserBatchGetResult{
+		Items:   make([]*model.DmUser, 0, len(found)),
+		Missing: make([]string, 0),
+	}
+	for _, id := range ids {
+		if dmUser, ok := found[id]; ok {
+			result.Items = append(result.Items, dmUser)
+		} else {
+			result.Missing = append(result.Missing, id)
+		}
+	}
+
+	return result, nil
+}
+
 // ListDmUsers はユーザー一覧を取得
-func (s *DmUserService) ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+// filter・sortはParseListQueryの形式で指定する（空の場合は絞り込み・並べ替えなし）
+func (s *DmUserService) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -64,8 +100,16 @@ func (s *DmUserService) ListDmUsers(ctx context.Context, limit, offset int) ([]*
 	if offset < 0 {
 		offset = 0
 	}
+	if offset+limit > model.ListMaxWindow {
+		return nil, apperror.Validation(fmt.Sprintf("offset + limit must be less than or equal to %d", model.ListMaxWindow))
+	}
 
-	dmUsers, err := s.dmUserRepo.List(ctx, limit, offset)
+	q, err := ParseListQuery(filter, sort, model.DmUserListFields)
+	if err != nil {
+		return nil, err
+	}
+
+	dmUsers, err := s.dmUserRepo.List(ctx, limit, offset, q)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -76,12 +120,20 @@ func (s *DmUserService) ListDmUsers(ctx context.Context, limit, offset int) ([]*
 // UpdateDmUser はユーザーを更新
 func (s *DmUserService) UpdateDmUser(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error) {
 	if id == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, apperror.Validation("user id is required")
 	}
 
 	// 更新するフィールドが空の場合はエラー
 	if req.Name == "" && req.Email == "" {
-		return nil, fmt.Errorf("no fields to update")
+		return nil, apperror.Validation("no fields to update")
+	}
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	if req.Email != "" {
+		if err := s.validateEmailUnique(ctx, id, req.Email); err != nil {
+			return nil, err
+		}
 	}
 
 	dmUser, err := s.dmUserRepo.Update(ctx, id, req)
@@ -95,7 +147,7 @@ func (s *DmUserService) UpdateDmUser(ctx context.Context, id string, req *model.
 // DeleteDmUser はユーザーを削除
 func (s *DmUserService) DeleteDmUser(ctx context.Context, id string) error {
 	if id == "" {
-		return fmt.Errorf("user id is required")
+		return apperror.Validation("user id is required")
 	}
 
 	if err := s.dmUserRepo.Delete(ctx, id); err != nil {
@@ -105,7 +157,51 @@ func (s *DmUserService) DeleteDmUser(ctx context.Context, id string) error {
 	return nil
 }
 
+// PatchDmUser はJSON Merge Patch（RFC 7396）でユーザーを部分更新
+// パッチに含まれるフィールドのみを更新し、内容が不正な場合はErrInvalidMergePatchを返す
+func (s *DmUserService) PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+	if id == "" {
+		return nil, apperror.Validation("user id is required")
+	}
+
+	fields, err := parseMergePatch(patch, model.UpdateDmUserRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if email, ok := fields["email"].(string); ok {
+		if err := s.validateEmailUnique(ctx, id, email); err != nil {
+			return nil, err
+		}
+	}
+
+	dmUser, err := s.dmUserRepo.Patch(ctx, id, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch user: %w", err)
+	}
+
+	return dmUser, nil
+}
+
 // CheckEmailExists はメールアドレスが既に存在するかチェックする
 func (s *DmUserService) CheckEmailExists(ctx context.Context, email string) (bool, error) {
 	return s.dmUserRepo.CheckEmailExists(ctx, email)
 }
+
+// validateEmailUnique はメールアドレスが他のユーザーに登録されていないかを検証
+// idは更新対象のユーザーのID（作成時は空）で、そのユーザー自身のメールアドレスは許可する
+func (s *DmUserService) validateEmailUnique(ctx context.Context, id, email string) error {
+	exists, err := s.dmUserRepo.CheckEmailExists(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+	if id != "" {
+		current, err := s.dmUserRepo.GetByID(ctx, id)
+		if err == nil && current != nil && current.Email == email {
+			return nil
+		}
+	}
+	return validation.NewFieldError("email", validation.RuleUnique, "")
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/model"
 )
 
@@ -14,8 +16,10 @@ import (
 type MockDmUserRepository struct {
 	CreateFunc           func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error)
 	GetByIDFunc          func(ctx context.Context, id string) (*model.DmUser, error)
-	ListFunc             func(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+	GetByIDsFunc         func(ctx context.Context, ids []string) (map[string]*model.DmUser, error)
+	ListFunc             func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error)
 	UpdateFunc           func(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error)
+	PatchFunc            func(ctx context.Context, id string, fields map[string]interface{}) (*model.DmUser, error)
 	DeleteFunc           func(ctx context.Context, id string) error
 	CheckEmailExistsFunc func(ctx context.Context, email string) (bool, error)
 }
@@ -34,9 +38,16 @@ func (m *MockDmUserRepository) GetByID(ctx context.Context, id string) (*model.D
 	return nil, nil
 }
 
-func (m *MockDmUserRepository) List(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+func (m *MockDmUserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*model.DmUser, error) {
+	if m.GetByIDsFunc != nil {
+		return m.GetByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockDmUserRepository) List(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 	if m.ListFunc != nil {
-		return m.ListFunc(ctx, limit, offset)
+		return m.ListFunc(ctx, limit, offset, q)
 	}
 	return nil, nil
 }
@@ -48,6 +59,13 @@ func (m *MockDmUserRepository) Update(ctx context.Context, id string, req *model
 	return nil, nil
 }
 
+func (m *MockDmUserRepository) Patch(ctx context.Context, id string, fields map[string]interface{}) (*model.DmUser, error) {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(ctx, id, fields)
+	}
+	return nil, nil
+}
+
 func (m *MockDmUserRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
@@ -245,7 +263,7 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 			offset: 0,
 			setupMock: func() *MockDmUserRepository {
 				return &MockDmUserRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 						return []*model.DmUser{
 							{ID: "user-001", Name: "User 1", Email: "user1@example.com"},
 							{ID: "user-002", Name: "User 2", Email: "user2@example.com"},
@@ -263,7 +281,7 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 			offset: 0,
 			setupMock: func() *MockDmUserRepository {
 				return &MockDmUserRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 						assert.Equal(t, 20, limit)
 						return []*model.DmUser{}, nil
 					},
@@ -279,7 +297,7 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 			offset: 0,
 			setupMock: func() *MockDmUserRepository {
 				return &MockDmUserRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 						assert.Equal(t, 100, limit)
 						return []*model.DmUser{}, nil
 					},
@@ -295,7 +313,7 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 			offset: -5,
 			setupMock: func() *MockDmUserRepository {
 				return &MockDmUserRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 						assert.Equal(t, 0, offset)
 						return []*model.DmUser{}, nil
 					},
@@ -311,7 +329,7 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 			offset: 0,
 			setupMock: func() *MockDmUserRepository {
 				return &MockDmUserRepository{
-					ListFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+					ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
 						return nil, errors.New("database error")
 					},
 				}
@@ -326,7 +344,7 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 			mockRepo := tt.setupMock()
 			s := NewDmUserService(mockRepo)
 
-			got, err := s.ListDmUsers(context.Background(), tt.limit, tt.offset)
+			got, err := s.ListDmUsers(context.Background(), tt.limit, tt.offset, "", "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -342,6 +360,99 @@ func TestDmUserService_ListDmUsers(t *testing.T) {
 	}
 }
 
+func TestDmUserService_ListDmUsers_WithListQuery(t *testing.T) {
+	var gotQuery *model.ListQuery
+	mockRepo := &MockDmUserRepository{
+		ListFunc: func(ctx context.Context, limit, offset int, q *model.ListQuery) ([]*model.DmUser, error) {
+			gotQuery = q
+			return []*model.DmUser{}, nil
+		},
+	}
+	s := NewDmUserService(mockRepo)
+
+	_, err := s.ListDmUsers(context.Background(), 10, 0, "name:prefix:tak", "-created_at")
+	require.NoError(t, err)
+	require.NotNil(t, gotQuery)
+	assert.Equal(t, []model.ListFilter{{Field: "name", Op: model.ListFilterOpPrefix, Value: "tak"}}, gotQuery.Filters)
+	assert.Equal(t, []model.ListSort{{Field: "created_at", Desc: true}}, gotQuery.Sorts)
+
+	// 不正な指定の場合はリポジトリを呼ばずにErrInvalidListQueryを返す
+	gotQuery = nil
+	_, err = s.ListDmUsers(context.Background(), 10, 0, "password:eq:x", "")
+	assert.ErrorIs(t, err, ErrInvalidListQuery)
+	assert.Nil(t, gotQuery)
+
+	// offset+limitが上限を超える場合はリポジトリを呼ばずにエラーを返す
+	_, err = s.ListDmUsers(context.Background(), 20, model.ListMaxWindow-10, "", "")
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.Nil(t, gotQuery)
+}
+
+func TestDmUserService_PatchDmUser(t *testing.T) {
+	var gotFields map[string]interface{}
+	mockRepo := &MockDmUserRepository{
+		PatchFunc: func(ctx context.Context, id string, fields map[string]interface{}) (*model.DmUser, error) {
+			gotFields = fields
+			return &model.DmUser{ID: id, Name: "Patched", Email: "keep@example.com"}, nil
+		},
+	}
+	s := NewDmUserService(mockRepo)
+
+	got, err := s.PatchDmUser(context.Background(), "user-001", []byte(`{"name":"Patched"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Patched", got.Name)
+	assert.Equal(t, map[string]interface{}{"name": "Patched"}, gotFields)
+
+	// 不正なパッチはリポジトリを呼ばずにErrInvalidMergePatchを返す
+	gotFields = nil
+	_, err = s.PatchDmUser(context.Background(), "user-001", []byte(`{"email":null}`))
+	assert.ErrorIs(t, err, ErrInvalidMergePatch)
+	assert.Nil(t, gotFields)
+
+	_, err = s.PatchDmUser(context.Background(), "", []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestDmUserService_BatchGetDmUsers(t *testing.T) {
+	var gotIDs []string
+	mockRepo := &MockDmUserRepository{
+		GetByIDsFunc: func(ctx context.Context, ids []string) (map[string]*model.DmUser, error) {
+			gotIDs = ids
+			return map[string]*model.DmUser{
+				"user-001": {ID: "user-001"},
+				"user-003": {ID: "user-003"},
+			}, nil
+		},
+	}
+	s := NewDmUserService(mockRepo)
+
+	// 重複したIDは1件として扱い、結果はリクエストの順序で返す
+	got, err := s.BatchGetDmUsers(context.Background(), []string{"user-003", "user-002", "user-001", "user-003"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-003", "user-002", "user-001"}, gotIDs)
+	require.Len(t, got.Items, 2)
+	assert.Equal(t, "user-003", got.Items[0].ID)
+	assert.Equal(t, "user-001", got.Items[1].ID)
+	assert.Equal(t, []string{"user-002"}, got.Missing)
+
+	// 件数が不正な場合・空のIDを含む場合はリポジトリを呼ばずにErrInvalidBatchGetを返す
+	gotIDs = nil
+	_, err = s.BatchGetDmUsers(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+	_, err = s.BatchGetDmUsers(context.Background(), make([]string, model.DmBatchGetMaxIDs+1))
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+	_, err = s.BatchGetDmUsers(context.Background(), []string{"user-001", ""})
+	assert.ErrorIs(t, err, ErrInvalidBatchGet)
+	assert.Nil(t, gotIDs)
+
+	// リポジトリのエラーはそのまま返す
+	mockRepo.GetByIDsFunc = func(ctx context.Context, ids []string) (map[string]*model.DmUser, error) {
+		return nil, errors.New("database error")
+	}
+	_, err = s.BatchGetDmUsers(context.Background(), []string{"user-001"})
+	assert.ErrorContains(t, err, "failed to batch get users")
+}
+
 func TestDmUserService_UpdateDmUser(t *testing.T) {
 	tests := []struct {
 		name       string
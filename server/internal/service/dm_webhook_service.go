@@ -0,0 +1,251 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+	"github.com/taku-o/go-webdb-template/internal/util/idgen"
+)
+
+// Webhookのリクエストヘッダー
+const (
+	WebhookHeaderSignature = "X-Signature"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// webhookResponseBodyLimit はエラー記録のために読み込む配信先のレスポンスボディの上限
+const webhookResponseBodyLimit = 512
+
+// ErrWebhookDeliveryAborted は購読設定の削除・無効化などでリトライしても配信できない場合のエラー
+var ErrWebhookDeliveryAborted = errors.New("webhook delivery aborted")
+
+// DmWebhookService はWebhookの配信ログ作成と配信を担当
+type DmWebhookService struct {
+	dmWebhookRepo repository.DmWebhookRepositoryInterface
+	httpClient    *http.Client
+	now           func() time.Time
+}
+
+// NewDmWebhookService は新しいDmWebhookServiceを作成
+// timeoutは配信先へのリクエスト1回あたりのタイムアウト
+func NewDmWebhookService(dmWebhookRepo repository.DmWebhookRepositoryInterface, timeout time.Duration) *DmWebhookService {
+	return &DmWebhookService{
+		dmWebhookRepo: dmWebhookRepo,
+		httpClient:    &http.Client{Timeout: timeout},
+		now:           time.Now,
+	}
+}
+
+// NewEvent はWebhookで送信するイベントを作成
+// イベントIDは配信ログの作成前に決めるため、配信ログ作成ジョブがリトライしても変わらない
+func (s *DmWebhookService) NewEvent(event string, data interface{}) (*model.WebhookEventPayload, error) {
+	eventID, err := idgen.GenerateUUIDv7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event id: %w", err)
+	}
+	return &model.WebhookEventPayload{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: s.now().UTC(),
+		Data:      data,
+	}, nil
+}
+
+// CreateDeliveries はイベントを購読している有効な購読設定ごとに配信ログを作成
+// 購読設定がない場合は空のスライスを返す
+func (s *DmWebhookService) CreateDeliveries(ctx context.Context, event *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+	subscriptions, err := s.dmWebhookRepo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	deliveries := make([]*model.DmWebhookDelivery, 0)
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !webhookEventMatches(subscription.Events, event.Event) {
+			continue
+		}
+
+		// ペイロードは購読設定によらず同じ内容とする
+		if payload == nil {
+			payload, err = json.Marshal(event)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+			}
+		}
+
+		delivery := &model.DmWebhookDelivery{
+			SubscriptionID: subscription.ID,
+			Event:          event.Event,
+			EventID:        event.ID,
+			Payload:        string(payload),
+			Status:         model.WebhookDeliveryStatusPending,
+		}
+		if err := s.dmWebhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// CreateReplay は配信ログと同じイベント・ペイロードで再送用の配信ログを作成
+func (s *DmWebhookService) CreateReplay(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+	original, err := s.dmWebhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	replay := &model.DmWebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		Event:          original.Event,
+		EventID:        original.EventID,
+		Payload:        original.Payload,
+		Status:         model.WebhookDeliveryStatusPending,
+		ReplayOf:       &original.ID,
+	}
+	if err := s.dmWebhookRepo.CreateDelivery(ctx, replay); err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return replay, nil
+}
+
+// MarkUndeliverable は配信ログを配信失敗として記録（ジョブ登録に失敗した場合など）
+func (s *DmWebhookService) MarkUndeliverable(ctx context.Context, deliveryID int64, reason string) error {
+	return s.dmWebhookRepo.UpdateDelivery(ctx, deliveryID, map[string]interface{}{
+		"status":     model.WebhookDeliveryStatusFailed,
+		"last_error": reason,
+	})
+}
+
+// Deliver は配信ログのペイロードを購読設定の配信先にPOSTし、結果を配信ログに記録
+// 2xx以外の応答・通信エラーはエラーを返す（lastAttemptがfalseの場合はリトライ待ちとして記録）
+// 購読設定が削除・無効化されている場合はErrWebhookDeliveryAbortedを返す
+func (s *DmWebhookService) Deliver(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+	delivery, err := s.dmWebhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	// 配信済みの場合は重複して送信しない
+	if delivery.Status == model.WebhookDeliveryStatusSucceeded {
+		return nil
+	}
+
+	subscription, err := s.dmWebhookRepo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil && !errors.Is(err, repository.ErrWebhookSubscriptionNotFound) {
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	if err != nil || !subscription.Active {
+		reason := "webhook subscription is inactive"
+		if err != nil {
+			reason = err.Error()
+		}
+		if err := s.MarkUndeliverable(ctx, delivery.ID, reason); err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return fmt.Errorf("%w: %s", ErrWebhookDeliveryAborted, reason)
+	}
+
+	attempts := delivery.Attempts + 1
+	statusCode, sendErr := s.send(ctx, subscription, delivery)
+
+	fields := map[string]interface{}{
+		"attempts": attempts,
+	}
+	if statusCode > 0 {
+		fields["response_status"] = statusCode
+	}
+	if sendErr == nil {
+		fields["status"] = model.WebhookDeliveryStatusSucceeded
+		fields["last_error"] = nil
+		fields["delivered_at"] = s.now()
+	} else {
+		fields["status"] = model.WebhookDeliveryStatusRetrying
+		if lastAttempt {
+			fields["status"] = model.WebhookDeliveryStatusFailed
+		}
+		fields["last_error"] = sendErr.Error()
+	}
+	if err := s.dmWebhookRepo.UpdateDelivery(ctx, delivery.ID, fields); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", sendErr)
+	}
+	return nil
+}
+
+// send はペイロードに署名して配信先にPOSTし、応答のステータスコードを返す
+func (s *DmWebhookService) send(ctx context.Context, subscription *model.DmWebhookSubscription, delivery *model.DmWebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := s.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("invalid target url: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeaderEvent, delivery.Event)
+	req.Header.Set(WebhookHeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(subscription.Secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	// コネクションを再利用できるよう、ボディを読み捨てる
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload はWebhookの署名（X-Signatureヘッダーの値）を生成
+// "{timestamp}.{body}" のHMAC-SHA256を "sha256=" に続けて16進数で表す
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookEventMatches は購読設定のイベントフィルタにイベントが含まれるか判定
+// フィルタはカンマ区切りで、"*" は全イベント、"user.*" は "user." で始まるイベントに一致する
+func webhookEventMatches(filter string, event string) bool {
+	for _, pattern := range strings.Split(filter, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case pattern == "":
+			continue
+		case pattern == "*" || pattern == event:
+			return true
+		case strings.HasSuffix(pattern, ".*") && strings.HasPrefix(event, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+	return false
+}
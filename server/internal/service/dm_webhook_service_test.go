@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+)
+
+// MockDmWebhookRepository はDmWebhookRepositoryInterfaceのモック
+type MockDmWebhookRepository struct {
+	ListActiveSubscriptionsFunc func(ctx context.Context) ([]*model.DmWebhookSubscription, error)
+	GetSubscriptionFunc         func(ctx context.Context, id int64) (*model.DmWebhookSubscription, error)
+	CreateSubscriptionFunc      func(ctx context.Context, subscription *model.DmWebhookSubscription) error
+	CreateDeliveryFunc          func(ctx context.Context, delivery *model.DmWebhookDelivery) error
+	GetDeliveryFunc             func(ctx context.Context, id int64) (*model.DmWebhookDelivery, error)
+	UpdateDeliveryFunc          func(ctx context.Context, id int64, fields map[string]interface{}) error
+}
+
+func (m *MockDmWebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]*model.DmWebhookSubscription, error) {
+	if m.ListActiveSubscriptionsFunc != nil {
+		return m.ListActiveSubscriptionsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockDmWebhookRepository) GetSubscription(ctx context.Context, id int64) (*model.DmWebhookSubscription, error) {
+	if m.GetSubscriptionFunc != nil {
+		return m.GetSubscriptionFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockDmWebhookRepository) CreateSubscription(ctx context.Context, subscription *model.DmWebhookSubscription) error {
+	if m.CreateSubscriptionFunc != nil {
+		return m.CreateSubscriptionFunc(ctx, subscription)
+	}
+	return nil
+}
+
+func (m *MockDmWebhookRepository) CreateDelivery(ctx context.Context, delivery *model.DmWebhookDelivery) error {
+	if m.CreateDeliveryFunc != nil {
+		return m.CreateDeliveryFunc(ctx, delivery)
+	}
+	return nil
+}
+
+func (m *MockDmWebhookRepository) GetDelivery(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+	if m.GetDeliveryFunc != nil {
+		return m.GetDeliveryFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockDmWebhookRepository) UpdateDelivery(ctx context.Context, id int64, fields map[string]interface{}) error {
+	if m.UpdateDeliveryFunc != nil {
+		return m.UpdateDeliveryFunc(ctx, id, fields)
+	}
+	return nil
+}
+
+// webhookReceiver はテスト用のWebhook受信サーバー
+// 受信したリクエストの署名をシークレットで検証し、statusで応答する
+type webhookReceiver struct {
+	server   *httptest.Server
+	secret   string
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+	verified []bool
+}
+
+func newWebhookReceiver(t *testing.T, secret string, status int) *webhookReceiver {
+	r := &webhookReceiver{secret: secret, status: status}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		timestamp, err := strconv.ParseInt(req.Header.Get(WebhookHeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		expected := SignWebhookPayload(r.secret, timestamp, body)
+
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		r.verified = append(r.verified, req.Header.Get(WebhookHeaderSignature) == expected)
+
+		w.WriteHeader(r.status)
+		_, _ = w.Write([]byte("receiver response"))
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func TestDmWebhookService_CreateDeliveries(t *testing.T) {
+	var created []*model.DmWebhookDelivery
+	repo := &MockDmWebhookRepository{
+		ListActiveSubscriptionsFunc: func(ctx context.Context) ([]*model.DmWebhookSubscription, error) {
+			return []*model.DmWebhookSubscription{
+				{ID: 1, Events: "*", Active: true},
+				{ID: 2, Events: "post.*", Active: true},
+				{ID: 3, Events: "user.created, user.deleted", Active: true},
+			}, nil
+		},
+		CreateDeliveryFunc: func(ctx context.Context, delivery *model.DmWebhookDelivery) error {
+			delivery.ID = int64(len(created) + 100)
+			created = append(created, delivery)
+			return nil
+		},
+	}
+	svc := NewDmWebhookService(repo, time.Second)
+
+	event, err := svc.NewEvent(model.WebhookEventUserCreated, map[string]string{"id": "u1"})
+	require.NoError(t, err)
+	deliveries, err := svc.CreateDeliveries(context.Background(), event)
+	require.NoError(t, err)
+
+	// post.*の購読設定には配信しない
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, int64(1), deliveries[0].SubscriptionID)
+	assert.Equal(t, int64(3), deliveries[1].SubscriptionID)
+
+	// 購読設定によらず同じイベントID・ペイロードを使う
+	assert.Equal(t, deliveries[0].EventID, deliveries[1].EventID)
+	assert.Equal(t, deliveries[0].Payload, deliveries[1].Payload)
+	assert.Equal(t, model.WebhookDeliveryStatusPending, deliveries[0].Status)
+
+	var payload model.WebhookEventPayload
+	require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+	assert.Equal(t, event.ID, deliveries[0].EventID)
+	assert.Equal(t, deliveries[0].EventID, payload.ID)
+	assert.Equal(t, model.WebhookEventUserCreated, payload.Event)
+	assert.Equal(t, map[string]interface{}{"id": "u1"}, payload.Data)
+}
+
+func TestDmWebhookService_CreateDeliveries_NoSubscription(t *testing.T) {
+	repo := &MockDmWebhookRepository{
+		ListActiveSubscriptionsFunc: func(ctx context.Context) ([]*model.DmWebhookSubscription, error) {
+			return []*model.DmWebhookSubscription{{ID: 1, Events: "post.created", Active: true}}, nil
+		},
+		CreateDeliveryFunc: func(ctx context.Context, delivery *model.DmWebhookDelivery) error {
+			t.Fatal("CreateDelivery should not be called")
+			return nil
+		},
+	}
+	svc := NewDmWebhookService(repo, time.Second)
+
+	deliveries, err := svc.CreateDeliveries(context.Background(), &model.WebhookEventPayload{ID: "evt1", Event: model.WebhookEventUserDeleted})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestDmWebhookService_Deliver(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	payload := `{"id":"evt1","event":"user.created","created_at":"2026-10-19T12:00:00Z","data":{"id":"u1"}}`
+
+	tests := []struct {
+		name        string
+		status      int
+		lastAttempt bool
+		wantErr     bool
+		wantStatus  string
+	}{
+		{name: "2xx応答は配信成功", status: http.StatusNoContent, wantStatus: model.WebhookDeliveryStatusSucceeded},
+		{name: "5xx応答はリトライ待ち", status: http.StatusInternalServerError, wantErr: true, wantStatus: model.WebhookDeliveryStatusRetrying},
+		{name: "最後の試行で失敗した場合は配信失敗", status: http.StatusBadGateway, lastAttempt: true, wantErr: true, wantStatus: model.WebhookDeliveryStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := newWebhookReceiver(t, "s3cret", tt.status)
+
+			var updated map[string]interface{}
+			repo := &MockDmWebhookRepository{
+				GetDeliveryFunc: func(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+					return &model.DmWebhookDelivery{ID: id, SubscriptionID: 1, Event: model.WebhookEventUserCreated, EventID: "evt1", Payload: payload, Status: model.WebhookDeliveryStatusPending, Attempts: 2}, nil
+				},
+				GetSubscriptionFunc: func(ctx context.Context, id int64) (*model.DmWebhookSubscription, error) {
+					return &model.DmWebhookSubscription{ID: id, TargetURL: receiver.server.URL, Secret: "s3cret", Events: "*", Active: true}, nil
+				},
+				UpdateDeliveryFunc: func(ctx context.Context, id int64, fields map[string]interface{}) error {
+					updated = fields
+					return nil
+				},
+			}
+			svc := NewDmWebhookService(repo, time.Second)
+			svc.now = func() time.Time { return now }
+
+			err := svc.Deliver(context.Background(), 10, tt.lastAttempt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, errors.Is(err, ErrWebhookDeliveryAborted))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// 受信側で署名を検証できること
+			require.Len(t, receiver.requests, 1)
+			req := receiver.requests[0]
+			assert.True(t, receiver.verified[0])
+			assert.Equal(t, payload, string(receiver.bodies[0]))
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			assert.Equal(t, model.WebhookEventUserCreated, req.Header.Get(WebhookHeaderEvent))
+			assert.Equal(t, "10", req.Header.Get(WebhookHeaderDelivery))
+			assert.Equal(t, strconv.FormatInt(now.Unix(), 10), req.Header.Get(WebhookHeaderTimestamp))
+
+			// 配信結果が配信ログに記録されること
+			assert.Equal(t, tt.wantStatus, updated["status"])
+			assert.Equal(t, 3, updated["attempts"])
+			assert.Equal(t, tt.status, updated["response_status"])
+			if tt.wantErr {
+				assert.Contains(t, updated["last_error"], fmt.Sprintf("unexpected status %d", tt.status))
+				assert.NotContains(t, updated, "delivered_at")
+			} else {
+				assert.Equal(t, now, updated["delivered_at"])
+			}
+		})
+	}
+}
+
+func TestDmWebhookService_Deliver_WrongSecret(t *testing.T) {
+	// 受信側のシークレットが異なる場合は署名の検証に失敗する
+	receiver := newWebhookReceiver(t, "other", http.StatusOK)
+	repo := &MockDmWebhookRepository{
+		GetDeliveryFunc: func(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+			return &model.DmWebhookDelivery{ID: id, SubscriptionID: 1, Payload: `{}`, Status: model.WebhookDeliveryStatusPending}, nil
+		},
+		GetSubscriptionFunc: func(ctx context.Context, id int64) (*model.DmWebhookSubscription, error) {
+			return &model.DmWebhookSubscription{ID: id, TargetURL: receiver.server.URL, Secret: "s3cret", Active: true}, nil
+		},
+	}
+	svc := NewDmWebhookService(repo, time.Second)
+
+	require.NoError(t, svc.Deliver(context.Background(), 1, false))
+	require.Len(t, receiver.verified, 1)
+	assert.False(t, receiver.verified[0])
+}
+
+func TestDmWebhookService_Deliver_AlreadySucceeded(t *testing.T) {
+	repo := &MockDmWebhookRepository{
+		GetDeliveryFunc: func(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+			return &model.DmWebhookDelivery{ID: id, SubscriptionID: 1, Status: model.WebhookDeliveryStatusSucceeded}, nil
+		},
+		GetSubscriptionFunc: func(ctx context.Context, id int64) (*model.DmWebhookSubscription, error) {
+			t.Fatal("GetSubscription should not be called")
+			return nil, nil
+		},
+	}
+	svc := NewDmWebhookService(repo, time.Second)
+
+	assert.NoError(t, svc.Deliver(context.Background(), 1, false))
+}
+
+func TestDmWebhookService_Deliver_Aborted(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *model.DmWebhookSubscription
+		err          error
+		wantAborted  bool
+	}{
+		{name: "無効化された購読設定", subscription: &model.DmWebhookSubscription{ID: 1, Active: false}, wantAborted: true},
+		{name: "削除された購読設定", err: fmt.Errorf("%w: 1", repository.ErrWebhookSubscriptionNotFound), wantAborted: true},
+		{name: "一時的なDBエラーはリトライする", err: errors.New("connection refused"), wantAborted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated map[string]interface{}
+			repo := &MockDmWebhookRepository{
+				GetDeliveryFunc: func(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+					return &model.DmWebhookDelivery{ID: id, SubscriptionID: 1, Status: model.WebhookDeliveryStatusPending}, nil
+				},
+				GetSubscriptionFunc: func(ctx context.Context, id int64) (*model.DmWebhookSubscription, error) {
+					return tt.subscription, tt.err
+				},
+				UpdateDeliveryFunc: func(ctx context.Context, id int64, fields map[string]interface{}) error {
+					updated = fields
+					return nil
+				},
+			}
+			svc := NewDmWebhookService(repo, time.Second)
+
+			err := svc.Deliver(context.Background(), 1, false)
+			assert.Error(t, err)
+			assert.Equal(t, tt.wantAborted, errors.Is(err, ErrWebhookDeliveryAborted))
+			if tt.wantAborted {
+				assert.Equal(t, model.WebhookDeliveryStatusFailed, updated["status"])
+			} else {
+				assert.Nil(t, updated)
+			}
+		})
+	}
+}
+
+func TestDmWebhookService_CreateReplay(t *testing.T) {
+	var created *model.DmWebhookDelivery
+	repo := &MockDmWebhookRepository{
+		GetDeliveryFunc: func(ctx context.Context, id int64) (*model.DmWebhookDelivery, error) {
+			return &model.DmWebhookDelivery{ID: id, SubscriptionID: 3, Event: model.WebhookEventPostDeleted, EventID: "evt1", Payload: `{"id":"evt1"}`, Status: model.WebhookDeliveryStatusFailed, Attempts: 9}, nil
+		},
+		CreateDeliveryFunc: func(ctx context.Context, delivery *model.DmWebhookDelivery) error {
+			delivery.ID = 20
+			created = delivery
+			return nil
+		},
+	}
+	svc := NewDmWebhookService(repo, time.Second)
+
+	replay, err := svc.CreateReplay(context.Background(), 10)
+	require.NoError(t, err)
+
+	assert.Same(t, created, replay)
+	assert.Equal(t, int64(20), replay.ID)
+	assert.Equal(t, int64(3), replay.SubscriptionID)
+	assert.Equal(t, "evt1", replay.EventID)
+	assert.Equal(t, `{"id":"evt1"}`, replay.Payload)
+	assert.Equal(t, model.WebhookDeliveryStatusPending, replay.Status)
+	assert.Equal(t, 0, replay.Attempts)
+	require.NotNil(t, replay.ReplayOf)
+	assert.Equal(t, int64(10), *replay.ReplayOf)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '1700000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686",
+		SignWebhookPayload("secret", 1700000000, []byte(`{"a":1}`)))
+}
+
+func TestWebhookEventMatches(t *testing.T) {
+	tests := []struct {
+		filter string
+		event  string
+		want   bool
+	}{
+		{"*", model.WebhookEventPostUpdated, true},
+		{"user.created", model.WebhookEventUserCreated, true},
+		{"user.created", model.WebhookEventUserUpdated, false},
+		{"user.*", model.WebhookEventUserDeleted, true},
+		{"user.*", model.WebhookEventPostDeleted, false},
+		{"post.created, user.updated", model.WebhookEventUserUpdated, true},
+		{"", model.WebhookEventUserCreated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter+"/"+tt.event, func(t *testing.T) {
+			assert.Equal(t, tt.want, webhookEventMatches(tt.filter, tt.event))
+		})
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"text/template"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 )
 
+// ErrTemplateNotFound は指定されたテンプレートが存在しない場合のエラー
+var ErrTemplateNotFound = apperror.Validation("template not found")
+
 // TemplateService はメールテンプレートサービス
 type TemplateService struct {
 	templates map[string]*template.Template
@@ -45,7 +50,7 @@ go-webdb-templateへようこそ！
 func (s *TemplateService) Render(templateName string, data interface{}) (string, error) {
 	tmpl, ok := s.templates[templateName]
 	if !ok {
-		return "", fmt.Errorf("template not found: %s", templateName)
+		return "", fmt.Errorf("%w: %s", ErrTemplateNotFound, templateName)
 	}
 
 	var buf bytes.Buffer
@@ -60,7 +65,7 @@ func (s *TemplateService) Render(templateName string, data interface{}) (string,
 func (s *TemplateService) GetSubject(templateName string) (string, error) {
 	subject, ok := s.subjects[templateName]
 	if !ok {
-		return "", fmt.Errorf("subject not found for template: %s", templateName)
+		return "", fmt.Errorf("%w: %s", ErrTemplateNotFound, templateName)
 	}
 	return subject, nil
 }
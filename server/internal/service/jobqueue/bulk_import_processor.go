@@ -0,0 +1,64 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// BulkImportPayload は一括登録ジョブのペイロード
+type BulkImportPayload struct {
+	Target string            `json:"target"` // 登録対象（dm_users / dm_posts）
+	Rows   []json.RawMessage `json:"rows"`
+	Owner  string            `json:"owner,omitempty"` // 登録した呼び出し元（auth.JobOwner）
+}
+
+// BulkImportUsecaseInterface はBulkImportUsecaseのインターフェース
+type BulkImportUsecaseInterface interface {
+	Execute(ctx context.Context, target string, rows []json.RawMessage) (*model.BulkImportResult, error)
+}
+
+// BulkImportProcessor は一括登録ジョブを処理
+// DB接続を必要とするため、起動時に組み立てたusecaseを保持する
+type BulkImportProcessor struct {
+	usecase BulkImportUsecaseInterface
+}
+
+// NewBulkImportProcessor は新しいBulkImportProcessorを作成
+func NewBulkImportProcessor(usecase BulkImportUsecaseInterface) *BulkImportProcessor {
+	return &BulkImportProcessor{
+		usecase: usecase,
+	}
+}
+
+// ProcessTask は一括登録ジョブを処理し、処理結果をジョブ結果として書き込む
+// 一括登録は冪等ではないため、失敗時もリトライしない
+func (p *BulkImportProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// ペイロードの解析
+	var payload BulkImportPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// usecase層の呼び出し
+	result, err := p.usecase.Execute(ctx, payload.Target, payload.Rows)
+	if err != nil {
+		return fmt.Errorf("failed to import rows: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// 処理結果をジョブ結果として保存（ResultWriterはサーバー経由で実行された場合のみ利用可能）
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %v: %w", err, asynq.SkipRetry)
+	}
+	if w := t.ResultWriter(); w != nil {
+		if _, err := w.Write(resultBytes); err != nil {
+			return fmt.Errorf("failed to write result: %v: %w", err, asynq.SkipRetry)
+		}
+	}
+
+	return nil
+}
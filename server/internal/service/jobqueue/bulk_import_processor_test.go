@@ -0,0 +1,67 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockBulkImportUsecase はテスト用のモックusecase
+type MockBulkImportUsecase struct {
+	ExecuteFunc func(ctx context.Context, target string, rows []json.RawMessage) (*model.BulkImportResult, error)
+}
+
+func (m *MockBulkImportUsecase) Execute(ctx context.Context, target string, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	return m.ExecuteFunc(ctx, target, rows)
+}
+
+func TestBulkImportProcessor_ProcessTask(t *testing.T) {
+	var gotTarget string
+	var gotRows int
+	processor := NewBulkImportProcessor(&MockBulkImportUsecase{
+		ExecuteFunc: func(ctx context.Context, target string, rows []json.RawMessage) (*model.BulkImportResult, error) {
+			gotTarget = target
+			gotRows = len(rows)
+			return model.NewBulkImportResult(len(rows)), nil
+		},
+	})
+
+	payloadBytes, err := json.Marshal(BulkImportPayload{
+		Target: "dm_users",
+		Rows:   []json.RawMessage{json.RawMessage(`{"name":"a","email":"a@example.com"}`)},
+	})
+	assert.NoError(t, err)
+
+	// asynq.NewTaskで作成したタスクはResultWriterを持たないが、処理は成功すること
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeBulkImport, payloadBytes))
+	assert.NoError(t, err)
+	assert.Equal(t, "dm_users", gotTarget)
+	assert.Equal(t, 1, gotRows)
+}
+
+func TestBulkImportProcessor_ProcessTask_InvalidJSON(t *testing.T) {
+	processor := NewBulkImportProcessor(&MockBulkImportUsecase{})
+
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeBulkImport, []byte("invalid")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, asynq.SkipRetry))
+}
+
+func TestBulkImportProcessor_ProcessTask_UsecaseError(t *testing.T) {
+	processor := NewBulkImportProcessor(&MockBulkImportUsecase{
+		ExecuteFunc: func(ctx context.Context, target string, rows []json.RawMessage) (*model.BulkImportResult, error) {
+			return nil, errors.New("unsupported bulk import target")
+		},
+	})
+
+	payloadBytes, _ := json.Marshal(BulkImportPayload{Target: "unknown"})
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeBulkImport, payloadBytes))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, asynq.SkipRetry))
+}
@@ -2,20 +2,27 @@ package jobqueue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/config"
 )
 
 // JobOptions はジョブ登録時のオプション
 type JobOptions struct {
-	MaxRetry     int // 最大リトライ回数（0の場合はDefaultMaxRetryを使用）
-	DelaySeconds int // 遅延時間（秒、0の場合はDefaultDelaySecondsを使用）
+	MaxRetry           int           // 最大リトライ回数（0の場合はDefaultMaxRetryを使用）
+	DelaySeconds       int           // 遅延時間（秒、0の場合はDefaultDelaySecondsを使用）
+	ProcessImmediately bool          // trueの場合は遅延なしで即時実行（DelaySecondsは無視）
+	Retention          time.Duration // 完了後に結果を保持する期間（0の場合は保持しない）
 }
 
+// ErrJobNotFound は指定されたジョブが存在しない場合のエラー
+var ErrJobNotFound = apperror.NotFound("job not found")
+
 // Client はAsynqクライアントをラップする構造体
 type Client struct {
 	client      *asynq.Client
@@ -56,11 +63,13 @@ func (c *Client) EnqueueJob(ctx context.Context, jobType string, payload []byte,
 	asynqOpts := []asynq.Option{}
 
 	// 遅延時間の設定
-	delaySeconds := DefaultDelaySeconds
-	if opts != nil && opts.DelaySeconds > 0 {
-		delaySeconds = opts.DelaySeconds
+	if opts == nil || !opts.ProcessImmediately {
+		delaySeconds := DefaultDelaySeconds
+		if opts != nil && opts.DelaySeconds > 0 {
+			delaySeconds = opts.DelaySeconds
+		}
+		asynqOpts = append(asynqOpts, asynq.ProcessIn(time.Duration(delaySeconds)*time.Second))
 	}
-	asynqOpts = append(asynqOpts, asynq.ProcessIn(time.Duration(delaySeconds)*time.Second))
 
 	// 最大リトライ回数の設定
 	maxRetry := DefaultMaxRetry
@@ -69,6 +78,11 @@ func (c *Client) EnqueueJob(ctx context.Context, jobType string, payload []byte,
 	}
 	asynqOpts = append(asynqOpts, asynq.MaxRetry(maxRetry))
 
+	// 結果保持期間の設定
+	if opts != nil && opts.Retention > 0 {
+		asynqOpts = append(asynqOpts, asynq.Retention(opts.Retention))
+	}
+
 	info, err := c.client.Enqueue(task, asynqOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to enqueue job: %w", err)
@@ -77,6 +91,21 @@ func (c *Client) EnqueueJob(ctx context.Context, jobType string, payload []byte,
 	return info, nil
 }
 
+// GetTaskInfo はジョブの状態を取得
+// ジョブが存在しない場合はErrJobNotFoundを返す
+func (c *Client) GetTaskInfo(ctx context.Context, id string) (*asynq.TaskInfo, error) {
+	// NewInspectorFromRedisClientは接続を共有するため、Inspectorのクローズは不要
+	inspector := asynq.NewInspectorFromRedisClient(c.redisClient)
+	info, err := inspector.GetTaskInfo(DefaultQueue, id)
+	if err != nil {
+		if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job info: %w", err)
+	}
+	return info, nil
+}
+
 // Close はクライアントをクローズ
 // NewClientFromRedisClientを使用しているため、Redisクライアントを直接クローズする
 func (c *Client) Close() error {
@@ -5,8 +5,26 @@ const (
 	// JobTypeDelayPrint は遅延出力ジョブのタイプ
 	// 参考コードとして利用するため、将来の実装に影響しない名前を使用
 	JobTypeDelayPrint = "demo:delay_print"
+
+	// JobTypeBulkImport はdm_users/dm_posts一括登録ジョブのタイプ
+	JobTypeBulkImport = "bulk:import"
+
+	// JobTypeExport はdm_users/dm_postsエクスポートジョブのタイプ
+	JobTypeExport = "export:run"
+
+	// JobTypeWebhookDelivery はWebhook配信ジョブのタイプ
+	JobTypeWebhookDelivery = "webhook:deliver"
+
+	// JobTypeWebhookDispatch はWebhookのイベントの配信ログを購読設定ごとに作成するジョブのタイプ
+	JobTypeWebhookDispatch = "webhook:dispatch"
+
+	// JobTypeStreamNewsPublished は予約公開ニュースのSSE配信ジョブのタイプ
+	JobTypeStreamNewsPublished = "stream:news_published"
 )
 
+// DefaultQueue はジョブを登録するキュー名
+const DefaultQueue = "default"
+
 // デフォルトの遅延時間（3分 = 180秒）
 const DefaultDelaySeconds = 180
 
@@ -11,6 +11,36 @@ func TestConstants_JobTypeDelayPrint(t *testing.T) {
 	assert.Equal(t, "demo:delay_print", JobTypeDelayPrint)
 }
 
+func TestConstants_JobTypeBulkImport(t *testing.T) {
+	// ジョブタイプが正しく定義されていること
+	assert.Equal(t, "bulk:import", JobTypeBulkImport)
+}
+
+func TestConstants_JobTypeExport(t *testing.T) {
+	// ジョブタイプが正しく定義されていること
+	assert.Equal(t, "export:run", JobTypeExport)
+}
+
+func TestConstants_JobTypeWebhookDelivery(t *testing.T) {
+	// ジョブタイプが正しく定義されていること
+	assert.Equal(t, "webhook:deliver", JobTypeWebhookDelivery)
+}
+
+func TestConstants_JobTypeWebhookDispatch(t *testing.T) {
+	// ジョブタイプが正しく定義されていること
+	assert.Equal(t, "webhook:dispatch", JobTypeWebhookDispatch)
+}
+
+func TestConstants_JobTypeStreamNewsPublished(t *testing.T) {
+	// ジョブタイプが正しく定義されていること
+	assert.Equal(t, "stream:news_published", JobTypeStreamNewsPublished)
+}
+
+func TestConstants_DefaultQueue(t *testing.T) {
+	// デフォルトキュー名がdefaultであること
+	assert.Equal(t, "default", DefaultQueue)
+}
+
 func TestConstants_DefaultDelaySeconds(t *testing.T) {
 	// デフォルト遅延時間が3分（180秒）であること
 	assert.Equal(t, 180, DefaultDelaySeconds)
@@ -0,0 +1,103 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	"github.com/taku-o/go-webdb-template/internal/util/idgen"
+)
+
+// ExportProgressInterval は進捗をジョブ結果に書き込む最小間隔
+const ExportProgressInterval = time.Second
+
+// ExportPayload はエクスポートジョブのペイロード
+type ExportPayload struct {
+	Request model.ExportRequest `json:"request"`
+	Owner   string              `json:"owner,omitempty"` // 登録した呼び出し元（auth.JobOwner）
+}
+
+// ExportUsecaseInterface はExportUsecaseのインターフェース
+type ExportUsecaseInterface interface {
+	Execute(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error)
+}
+
+// ExportProcessor はエクスポートジョブを処理
+// 処理中の進捗と完了時の結果をジョブ結果として書き込む
+type ExportProcessor struct {
+	usecase ExportUsecaseInterface
+}
+
+// NewExportProcessor は新しいExportProcessorを作成
+func NewExportProcessor(usecase ExportUsecaseInterface) *ExportProcessor {
+	return &ExportProcessor{
+		usecase: usecase,
+	}
+}
+
+// ProcessTask はエクスポートジョブを処理する
+// 出力ファイルはジョブIDごとに上書きされるため、DBやストレージの一時的なエラーはリトライする
+func (p *ExportProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// ペイロードの解析
+	var payload ExportPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// 出力ファイルのキーにジョブIDを使用（サーバー経由でない場合は新規に採番）
+	jobID, ok := asynq.GetTaskID(ctx)
+	if !ok {
+		var err error
+		jobID, err = idgen.GenerateUUIDv7()
+		if err != nil {
+			return fmt.Errorf("failed to generate job id: %w", err)
+		}
+	}
+
+	w := t.ResultWriter()
+	var lastWrite time.Time
+	progress := func(pr model.ExportProgress) {
+		if w == nil || time.Since(lastWrite) < ExportProgressInterval {
+			return
+		}
+		lastWrite = time.Now()
+		// 進捗の書き込み失敗は処理を中断しない
+		if err := writeExportResult(w, &model.ExportResult{Progress: pr}); err != nil {
+			log.Printf("Failed to write export progress: %v", err)
+		}
+	}
+
+	// usecase層の呼び出し
+	result, err := p.usecase.Execute(ctx, jobID, &payload.Request, progress)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidExportRequest) {
+			return fmt.Errorf("failed to export: %v: %w", err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	// 処理結果をジョブ結果として保存（ResultWriterはサーバー経由で実行された場合のみ利用可能）
+	if w != nil {
+		if err := writeExportResult(w, result); err != nil {
+			return fmt.Errorf("failed to write result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeExportResult はエクスポート結果をジョブ結果に書き込む
+func writeExportResult(w *asynq.ResultWriter, result *model.ExportResult) error {
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(resultBytes)
+	return err
+}
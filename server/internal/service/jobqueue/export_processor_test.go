@@ -0,0 +1,82 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// MockExportUsecase はテスト用のモックusecase
+type MockExportUsecase struct {
+	ExecuteFunc func(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error)
+}
+
+func (m *MockExportUsecase) Execute(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error) {
+	return m.ExecuteFunc(ctx, jobID, req, progress)
+}
+
+func TestExportProcessor_ProcessTask(t *testing.T) {
+	var gotJobID string
+	var gotReq *model.ExportRequest
+	processor := NewExportProcessor(&MockExportUsecase{
+		ExecuteFunc: func(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error) {
+			gotJobID = jobID
+			gotReq = req
+			// ResultWriterがない場合も進捗通知でパニックしないこと
+			progress(model.ExportProgress{Processed: 1, Total: 1})
+			return &model.ExportResult{Completed: true}, nil
+		},
+	})
+
+	payloadBytes, err := json.Marshal(ExportPayload{
+		Request: model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV},
+	})
+	assert.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeExport, payloadBytes))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, gotJobID)
+	assert.Equal(t, model.ExportTargetDmUsers, gotReq.Target)
+	assert.Equal(t, model.ExportFormatCSV, gotReq.Format)
+}
+
+func TestExportProcessor_ProcessTask_InvalidJSON(t *testing.T) {
+	processor := NewExportProcessor(&MockExportUsecase{})
+
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeExport, []byte("invalid")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, asynq.SkipRetry))
+}
+
+func TestExportProcessor_ProcessTask_UsecaseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		skipRetry bool
+	}{
+		{name: "不正なリクエストはリトライしない", err: fmt.Errorf("%w: unsupported format", service.ErrInvalidExportRequest), skipRetry: true},
+		{name: "一時的なエラーはリトライする", err: errors.New("connection refused"), skipRetry: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewExportProcessor(&MockExportUsecase{
+				ExecuteFunc: func(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error) {
+					return nil, tt.err
+				},
+			})
+
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeExport, []byte(`{"request":{}}`)))
+			assert.Error(t, err)
+			assert.Equal(t, tt.skipRetry, errors.Is(err, asynq.SkipRetry))
+		})
+	}
+}
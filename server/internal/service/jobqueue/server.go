@@ -1,7 +1,9 @@
 package jobqueue
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
@@ -36,8 +38,9 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		asynq.Config{
 			Concurrency: 10, // 同時実行数
 			Queues: map[string]int{
-				"default": 10, // デフォルトキュー
+				DefaultQueue: 10, // デフォルトキュー
 			},
+			RetryDelayFunc: retryDelay,
 		},
 	)
 
@@ -51,6 +54,21 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	}, nil
 }
 
+// retryDelay はジョブタイプごとのリトライ間隔を返す
+// Webhook配信は指数バックオフ、それ以外はasynqのデフォルトを使用する
+func retryDelay(n int, err error, t *asynq.Task) time.Duration {
+	if t.Type() == JobTypeWebhookDelivery {
+		return WebhookRetryDelay(n)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, t)
+}
+
+// HandleFunc はジョブハンドラーを登録
+// DB接続など起動時に依存を組み立てる必要があるハンドラーの登録に使用する
+func (s *Server) HandleFunc(pattern string, handler func(context.Context, *asynq.Task) error) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
 // Start はサーバーを起動（バックグラウンドで実行）
 func (s *Server) Start() error {
 	if err := s.server.Run(s.mux); err != nil {
@@ -0,0 +1,50 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// StreamNewsPublishedPayload は予約公開ニュースのSSE配信ジョブのペイロード
+// PublishedAtはジョブ登録時の公開日時（公開日時が変更された場合は配信しない）
+type StreamNewsPublishedPayload struct {
+	NewsID      int64     `json:"news_id"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// StreamNewsPublishedUsecaseInterface はStreamNewsPublishedUsecaseのインターフェース
+type StreamNewsPublishedUsecaseInterface interface {
+	Execute(ctx context.Context, newsID int64, publishedAt time.Time) error
+}
+
+// StreamNewsPublishedProcessor は予約公開ニュースのSSE配信ジョブを処理
+type StreamNewsPublishedProcessor struct {
+	usecase StreamNewsPublishedUsecaseInterface
+}
+
+// NewStreamNewsPublishedProcessor は新しいStreamNewsPublishedProcessorを作成
+func NewStreamNewsPublishedProcessor(usecase StreamNewsPublishedUsecaseInterface) *StreamNewsPublishedProcessor {
+	return &StreamNewsPublishedProcessor{
+		usecase: usecase,
+	}
+}
+
+// ProcessTask は予約公開ニュースのSSE配信ジョブを処理する
+func (p *StreamNewsPublishedProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// ペイロードの解析
+	var payload StreamNewsPublishedPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// usecase層の呼び出し
+	if err := p.usecase.Execute(ctx, payload.NewsID, payload.PublishedAt); err != nil {
+		return fmt.Errorf("failed to publish news stream event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockStreamNewsPublishedUsecase はテスト用のモックusecase
+type MockStreamNewsPublishedUsecase struct {
+	ExecuteFunc func(ctx context.Context, newsID int64, publishedAt time.Time) error
+}
+
+func (m *MockStreamNewsPublishedUsecase) Execute(ctx context.Context, newsID int64, publishedAt time.Time) error {
+	return m.ExecuteFunc(ctx, newsID, publishedAt)
+}
+
+func TestStreamNewsPublishedProcessor_ProcessTask(t *testing.T) {
+	publishedAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	var gotID int64
+	var gotPublishedAt time.Time
+	processor := NewStreamNewsPublishedProcessor(&MockStreamNewsPublishedUsecase{
+		ExecuteFunc: func(ctx context.Context, newsID int64, publishedAt time.Time) error {
+			gotID = newsID
+			gotPublishedAt = publishedAt
+			return nil
+		},
+	})
+
+	payloadBytes, err := json.Marshal(StreamNewsPublishedPayload{NewsID: 3, PublishedAt: publishedAt})
+	assert.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeStreamNewsPublished, payloadBytes))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), gotID)
+	assert.True(t, publishedAt.Equal(gotPublishedAt))
+}
+
+func TestStreamNewsPublishedProcessor_ProcessTask_InvalidJSON(t *testing.T) {
+	processor := NewStreamNewsPublishedProcessor(&MockStreamNewsPublishedUsecase{})
+
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeStreamNewsPublished, []byte("invalid")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, asynq.SkipRetry))
+}
+
+func TestStreamNewsPublishedProcessor_ProcessTask_UsecaseError(t *testing.T) {
+	processor := NewStreamNewsPublishedProcessor(&MockStreamNewsPublishedUsecase{
+		ExecuteFunc: func(ctx context.Context, newsID int64, publishedAt time.Time) error {
+			return errors.New("redis down")
+		},
+	})
+
+	payloadBytes, err := json.Marshal(StreamNewsPublishedPayload{NewsID: 3, PublishedAt: time.Now()})
+	assert.NoError(t, err)
+
+	// 一時的なエラーはリトライさせる
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeStreamNewsPublished, payloadBytes))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, asynq.SkipRetry))
+}
@@ -0,0 +1,81 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// Webhook配信のリトライ間隔（指数バックオフ）
+const (
+	WebhookRetryBaseDelay = 30 * time.Second
+	WebhookRetryMaxDelay  = time.Hour
+)
+
+// WebhookDeliveryPayload はWebhook配信ジョブのペイロード
+type WebhookDeliveryPayload struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// WebhookDeliveryUsecaseInterface はWebhookDeliveryUsecaseのインターフェース
+type WebhookDeliveryUsecaseInterface interface {
+	Execute(ctx context.Context, deliveryID int64, lastAttempt bool) error
+}
+
+// WebhookDeliveryProcessor はWebhook配信ジョブを処理
+type WebhookDeliveryProcessor struct {
+	usecase WebhookDeliveryUsecaseInterface
+}
+
+// NewWebhookDeliveryProcessor は新しいWebhookDeliveryProcessorを作成
+func NewWebhookDeliveryProcessor(usecase WebhookDeliveryUsecaseInterface) *WebhookDeliveryProcessor {
+	return &WebhookDeliveryProcessor{
+		usecase: usecase,
+	}
+}
+
+// ProcessTask はWebhook配信ジョブを処理する
+// 配信に失敗した場合はエラーを返し、asynqのリトライ（WebhookRetryDelay）に任せる
+func (p *WebhookDeliveryProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// ペイロードの解析
+	var payload WebhookDeliveryPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// 最後の試行の場合は配信ログを失敗として記録させる（サーバー経由でない場合は最後の試行とみなす）
+	lastAttempt := true
+	retryCount, okRetry := asynq.GetRetryCount(ctx)
+	maxRetry, okMax := asynq.GetMaxRetry(ctx)
+	if okRetry && okMax {
+		lastAttempt = retryCount >= maxRetry
+	}
+
+	// usecase層の呼び出し
+	if err := p.usecase.Execute(ctx, payload.DeliveryID, lastAttempt); err != nil {
+		if errors.Is(err, service.ErrWebhookDeliveryAborted) {
+			return fmt.Errorf("failed to deliver webhook: %v: %w", err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+
+	return nil
+}
+
+// WebhookRetryDelay はWebhook配信のn回目のリトライまでの待ち時間を返す
+// 30秒から倍々に増やし、1時間を上限とする
+func WebhookRetryDelay(n int) time.Duration {
+	delay := WebhookRetryBaseDelay
+	for i := 0; i < n; i++ {
+		delay *= 2
+		if delay >= WebhookRetryMaxDelay {
+			return WebhookRetryMaxDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,95 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// MockWebhookDeliveryUsecase はテスト用のモックusecase
+type MockWebhookDeliveryUsecase struct {
+	ExecuteFunc func(ctx context.Context, deliveryID int64, lastAttempt bool) error
+}
+
+func (m *MockWebhookDeliveryUsecase) Execute(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+	return m.ExecuteFunc(ctx, deliveryID, lastAttempt)
+}
+
+func TestWebhookDeliveryProcessor_ProcessTask(t *testing.T) {
+	var gotID int64
+	var gotLastAttempt bool
+	processor := NewWebhookDeliveryProcessor(&MockWebhookDeliveryUsecase{
+		ExecuteFunc: func(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+			gotID = deliveryID
+			gotLastAttempt = lastAttempt
+			return nil
+		},
+	})
+
+	payloadBytes, err := json.Marshal(WebhookDeliveryPayload{DeliveryID: 42})
+	assert.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeWebhookDelivery, payloadBytes))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), gotID)
+	// サーバー経由でない場合は最後の試行とみなす
+	assert.True(t, gotLastAttempt)
+}
+
+func TestWebhookDeliveryProcessor_ProcessTask_InvalidJSON(t *testing.T) {
+	processor := NewWebhookDeliveryProcessor(&MockWebhookDeliveryUsecase{})
+
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeWebhookDelivery, []byte("invalid")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, asynq.SkipRetry))
+}
+
+func TestWebhookDeliveryProcessor_ProcessTask_UsecaseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		skipRetry bool
+	}{
+		{name: "購読設定が無効な場合はリトライしない", err: fmt.Errorf("%w: inactive", service.ErrWebhookDeliveryAborted), skipRetry: true},
+		{name: "配信失敗はリトライする", err: errors.New("unexpected status 500"), skipRetry: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewWebhookDeliveryProcessor(&MockWebhookDeliveryUsecase{
+				ExecuteFunc: func(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+					return tt.err
+				},
+			})
+
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeWebhookDelivery, []byte(`{"delivery_id":1}`)))
+			assert.Error(t, err)
+			assert.Equal(t, tt.skipRetry, errors.Is(err, asynq.SkipRetry))
+		})
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, WebhookRetryDelay(0))
+	assert.Equal(t, time.Minute, WebhookRetryDelay(1))
+	assert.Equal(t, 4*time.Minute, WebhookRetryDelay(3))
+	assert.Equal(t, 32*time.Minute, WebhookRetryDelay(6))
+	// 1時間を上限とする
+	assert.Equal(t, time.Hour, WebhookRetryDelay(7))
+	assert.Equal(t, time.Hour, WebhookRetryDelay(100))
+}
+
+func TestRetryDelay(t *testing.T) {
+	// Webhook配信ジョブは指数バックオフ
+	assert.Equal(t, time.Minute, retryDelay(1, errors.New("failed"), asynq.NewTask(JobTypeWebhookDelivery, nil)))
+	// それ以外はasynqのデフォルト（0より大きい）
+	assert.Greater(t, retryDelay(1, errors.New("failed"), asynq.NewTask(JobTypeExport, nil)), time.Duration(0))
+}
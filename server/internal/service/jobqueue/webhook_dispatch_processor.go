@@ -0,0 +1,60 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// WebhookDispatchPayload はWebhookのイベントの配信ログを作成するジョブのペイロード
+// イベントIDと日時は登録時に決めるため、ジョブがリトライしても配信するペイロードは変わらない
+type WebhookDispatchPayload struct {
+	EventID   string          `json:"event_id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookDispatchUsecaseInterface はWebhookDispatchUsecaseのインターフェース
+type WebhookDispatchUsecaseInterface interface {
+	Execute(ctx context.Context, event *model.WebhookEventPayload) error
+}
+
+// WebhookDispatchProcessor はWebhookのイベントの配信ログ作成ジョブを処理
+type WebhookDispatchProcessor struct {
+	usecase WebhookDispatchUsecaseInterface
+}
+
+// NewWebhookDispatchProcessor は新しいWebhookDispatchProcessorを作成
+func NewWebhookDispatchProcessor(usecase WebhookDispatchUsecaseInterface) *WebhookDispatchProcessor {
+	return &WebhookDispatchProcessor{
+		usecase: usecase,
+	}
+}
+
+// ProcessTask はWebhookのイベントの配信ログ作成ジョブを処理する
+// 購読設定の取得・配信ログの作成に失敗した場合はエラーを返し、asynqのリトライに任せる
+func (p *WebhookDispatchProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// ペイロードの解析
+	var payload WebhookDispatchPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// usecase層の呼び出し（データは登録時のJSONのまま配信する）
+	event := &model.WebhookEventPayload{
+		ID:        payload.EventID,
+		Event:     payload.Event,
+		CreatedAt: payload.CreatedAt,
+		Data:      payload.Data,
+	}
+	if err := p.usecase.Execute(ctx, event); err != nil {
+		return fmt.Errorf("failed to dispatch webhook: %w", err)
+	}
+
+	return nil
+}
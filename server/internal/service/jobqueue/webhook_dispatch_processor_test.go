@@ -0,0 +1,76 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockWebhookDispatchUsecase はテスト用のモックusecase
+type MockWebhookDispatchUsecase struct {
+	ExecuteFunc func(ctx context.Context, event *model.WebhookEventPayload) error
+}
+
+func (m *MockWebhookDispatchUsecase) Execute(ctx context.Context, event *model.WebhookEventPayload) error {
+	return m.ExecuteFunc(ctx, event)
+}
+
+func TestWebhookDispatchProcessor_ProcessTask(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	var got *model.WebhookEventPayload
+	processor := NewWebhookDispatchProcessor(&MockWebhookDispatchUsecase{
+		ExecuteFunc: func(ctx context.Context, event *model.WebhookEventPayload) error {
+			got = event
+			return nil
+		},
+	})
+
+	payloadBytes, err := json.Marshal(WebhookDispatchPayload{
+		EventID:   "evt1",
+		Event:     model.WebhookEventUserCreated,
+		CreatedAt: createdAt,
+		Data:      json.RawMessage(`{"name":"Alice","id":"u1"}`),
+	})
+	require.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeWebhookDispatch, payloadBytes))
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "evt1", got.ID)
+	assert.Equal(t, model.WebhookEventUserCreated, got.Event)
+	assert.True(t, got.CreatedAt.Equal(createdAt))
+
+	// データは登録時のJSONのまま配信する
+	body, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"data":{"name":"Alice","id":"u1"}`)
+}
+
+func TestWebhookDispatchProcessor_ProcessTask_InvalidJSON(t *testing.T) {
+	processor := NewWebhookDispatchProcessor(&MockWebhookDispatchUsecase{})
+
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeWebhookDispatch, []byte("invalid")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, asynq.SkipRetry))
+}
+
+func TestWebhookDispatchProcessor_ProcessTask_UsecaseError(t *testing.T) {
+	processor := NewWebhookDispatchProcessor(&MockWebhookDispatchUsecase{
+		ExecuteFunc: func(ctx context.Context, event *model.WebhookEventPayload) error {
+			return errors.New("db down")
+		},
+	})
+
+	err := processor.ProcessTask(context.Background(), asynq.NewTask(JobTypeWebhookDispatch, []byte(`{"event_id":"evt1","event":"user.created"}`)))
+	assert.Error(t, err)
+	// 配信ログの作成に失敗した場合はリトライする
+	assert.False(t, errors.Is(err, asynq.SkipRetry))
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+const (
+	// ListQueryMaxFilters は一覧取得で指定可能なフィルタ条件の最大数
+	ListQueryMaxFilters = 10
+	// ListQueryMaxSorts は一覧取得で指定可能なソートキーの最大数
+	ListQueryMaxSorts = 3
+)
+
+// ErrInvalidListQuery は一覧取得のフィルタ・ソート指定が不正な場合のエラー
+var ErrInvalidListQuery = apperror.Validation("invalid list query")
+
+// ParseListQuery はフィルタ・ソート文字列を許可フィールドに基づいて解析
+// filterは "field:op:value" をカンマ区切りで、sortは "field" または "-field"（降順）をカンマ区切りで指定する
+func ParseListQuery(filter, sort string, fields []model.ListField) (*model.ListQuery, error) {
+	q := &model.ListQuery{}
+
+	if filter = strings.TrimSpace(filter); filter != "" {
+		terms := strings.Split(filter, ",")
+		if len(terms) > ListQueryMaxFilters {
+			return nil, fmt.Errorf("%w: too many filters (max %d)", ErrInvalidListQuery, ListQueryMaxFilters)
+		}
+		for _, term := range terms {
+			f, err := parseListFilter(strings.TrimSpace(term), fields)
+			if err != nil {
+				return nil, err
+			}
+			q.Filters = append(q.Filters, *f)
+		}
+	}
+
+	if sort = strings.TrimSpace(sort); sort != "" {
+		keys := strings.Split(sort, ",")
+		if len(keys) > ListQueryMaxSorts {
+			return nil, fmt.Errorf("%w: too many sort keys (max %d)", ErrInvalidListQuery, ListQueryMaxSorts)
+		}
+		for _, key := range keys {
+			key = strings.TrimSpace(key)
+			s := model.ListSort{Field: key}
+			if strings.HasPrefix(key, "-") {
+				s = model.ListSort{Field: key[1:], Desc: true}
+			}
+			field, ok := findListField(fields, s.Field)
+			if !ok || !field.Sortable {
+				return nil, fmt.Errorf("%w: field %q is not sortable", ErrInvalidListQuery, s.Field)
+			}
+			if slices.ContainsFunc(q.Sorts, func(existing model.ListSort) bool { return existing.Field == s.Field }) {
+				return nil, fmt.Errorf("%w: duplicate sort field %q", ErrInvalidListQuery, s.Field)
+			}
+			q.Sorts = append(q.Sorts, s)
+		}
+	}
+
+	return q, nil
+}
+
+// parseListFilter は "field:op:value" 形式のフィルタ条件を解析
+func parseListFilter(term string, fields []model.ListField) (*model.ListFilter, error) {
+	parts := strings.SplitN(term, ":", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return nil, fmt.Errorf("%w: filter %q must be in field:op:value format", ErrInvalidListQuery, term)
+	}
+	name, op, raw := parts[0], parts[1], parts[2]
+
+	field, ok := findListField(fields, name)
+	if !ok {
+		return nil, fmt.Errorf("%w: field %q is not filterable", ErrInvalidListQuery, name)
+	}
+	if !slices.Contains(model.ListFieldOps[field.Type], op) {
+		return nil, fmt.Errorf("%w: operator %q is not allowed for field %q", ErrInvalidListQuery, op, name)
+	}
+
+	f := &model.ListFilter{Field: name, Op: op, Value: raw}
+	if field.Type == model.ListFieldTypeTime {
+		t, err := parseListTime(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %q requires RFC3339 or YYYY-MM-DD value", ErrInvalidListQuery, name)
+		}
+		f.Value = t
+	}
+	return f, nil
+}
+
+// parseListTime はRFC3339または日付形式の文字列を解析
+func parseListTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+// findListField は名前で許可フィールドを検索
+func findListField(fields []model.ListField, name string) (model.ListField, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return model.ListField{}, false
+}
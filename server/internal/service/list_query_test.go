@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestParseListQuery(t *testing.T) {
+	q, err := ParseListQuery("name:prefix:tak, created_at:gte:2026-01-01,updated_at:lt:2026-02-01T09:00:00+09:00", "-created_at,name", model.DmUserListFields)
+	require.NoError(t, err)
+
+	require.Len(t, q.Filters, 3)
+	assert.Equal(t, model.ListFilter{Field: "name", Op: model.ListFilterOpPrefix, Value: "tak"}, q.Filters[0])
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), q.Filters[1].Value)
+	assert.True(t, q.Filters[2].Value.(time.Time).Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []model.ListSort{{Field: "created_at", Desc: true}, {Field: "name"}}, q.Sorts)
+}
+
+func TestParseListQuery_Empty(t *testing.T) {
+	q, err := ParseListQuery("", " ", model.DmPostListFields)
+	require.NoError(t, err)
+	assert.Empty(t, q.Filters)
+	assert.Empty(t, q.Sorts)
+}
+
+func TestParseListQuery_ValueWithColon(t *testing.T) {
+	q, err := ParseListQuery("title:eq:a:b", "", model.DmPostListFields)
+	require.NoError(t, err)
+	assert.Equal(t, "a:b", q.Filters[0].Value)
+}
+
+func TestParseListQuery_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		sort   string
+	}{
+		{"missing value", "name:eq:", ""},
+		{"missing operator", "name", ""},
+		{"unknown field", "password:eq:x", ""},
+		{"operator not allowed for string", "name:gte:a", ""},
+		{"operator not allowed for time", "created_at:prefix:2026", ""},
+		{"invalid time", "created_at:gte:yesterday", ""},
+		{"too many filters", "name:eq:a,name:eq:b,name:eq:c,name:eq:d,name:eq:e,name:eq:f,name:eq:g,name:eq:h,name:eq:i,name:eq:j,name:eq:k", ""},
+		{"unknown sort field", "", "-password"},
+		{"duplicate sort field", "", "name,-name"},
+		{"too many sort keys", "", "id,name,email,created_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseListQuery(tt.filter, tt.sort, model.DmUserListFields)
+			assert.ErrorIs(t, err, ErrInvalidListQuery)
+		})
+	}
+}
+
+func TestParseListQuery_NonSortableField(t *testing.T) {
+	_, err := ParseListQuery("content:contains:go", "", model.DmPostListFields)
+	require.NoError(t, err)
+
+	_, err = ParseListQuery("", "content", model.DmPostListFields)
+	assert.ErrorIs(t, err, ErrInvalidListQuery)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+)
+
+// ErrInvalidMergePatch はJSON Merge Patchの内容が不正な場合のエラー
+var ErrInvalidMergePatch = apperror.Validation("invalid merge patch")
+
+// parseMergePatch はJSON Merge Patch（RFC 7396）を解析し、更新するカラムと値の組を返す
+// targetは更新リクエストの構造体（例: model.UpdateDmUserRequest{}）で、jsonタグの名前をカラム名として扱い、
+// 各値はvalidateタグで検証する。nullはポインタ型のフィールドのみ指定可能で、NULLへの更新を表す
+func parseMergePatch(patch []byte, target interface{}) (map[string]interface{}, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return nil, fmt.Errorf("%w: patch document must be a JSON object", ErrInvalidMergePatch)
+	}
+
+	targetType := reflect.TypeOf(target)
+	fields := make(map[string]interface{}, len(members))
+	for name, raw := range members {
+		field, ok := findPatchField(targetType, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidMergePatch, name)
+		}
+
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if field.Type.Kind() != reflect.Ptr {
+				return nil, fmt.Errorf("%w: field %q cannot be null", ErrInvalidMergePatch, name)
+			}
+			fields[name] = nil
+			continue
+		}
+
+		valueType := field.Type
+		if valueType.Kind() == reflect.Ptr {
+			valueType = valueType.Elem()
+		}
+		value := reflect.New(valueType)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: field %q has invalid type", ErrInvalidMergePatch, name)
+		}
+
+		// 指定されたフィールドは空値でも検証対象とするため、omitemptyは除外する
+		if tag := patchValidateTag(field); tag != "" {
+			if err := validation.Var(name, value.Elem().Interface(), tag); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidMergePatch, err)
+			}
+		}
+		fields[name] = value.Elem().Interface()
+	}
+
+	return fields, nil
+}
+
+// findPatchField はjsonタグの名前で構造体のフィールドを検索
+func findPatchField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ","); jsonName == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// patchValidateTag はフィールドのvalidateタグからomitemptyを除いたものを返す
+func patchValidateTag(f reflect.StructField) string {
+	rules := make([]string, 0)
+	for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+		if rule != "" && rule != "omitempty" {
+			rules = append(rules, rule)
+		}
+	}
+	return strings.Join(rules, ",")
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestParseMergePatch(t *testing.T) {
+	fields, err := parseMergePatch([]byte(`{"name":"New Name"}`), model.UpdateDmUserRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "New Name"}, fields)
+
+	// 空のパッチは更新なし
+	fields, err = parseMergePatch([]byte(`{}`), model.UpdateDmUserRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, fields)
+}
+
+func TestParseMergePatch_Nullable(t *testing.T) {
+	fields, err := parseMergePatch([]byte(`{"author_id":null,"published_at":"2026-01-02T03:04:05Z","content":"body"}`), model.UpdateDmNewsRequest{})
+	require.NoError(t, err)
+
+	assert.Contains(t, fields, "author_id")
+	assert.Nil(t, fields["author_id"])
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), fields["published_at"])
+	assert.Equal(t, "body", fields["content"])
+
+	fields, err = parseMergePatch([]byte(`{"author_id":42}`), model.UpdateDmNewsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), fields["author_id"])
+}
+
+func TestParseMergePatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		patch  string
+		target interface{}
+	}{
+		{"not an object", `["name"]`, model.UpdateDmUserRequest{}},
+		{"null document", `null`, model.UpdateDmUserRequest{}},
+		{"malformed json", `{"name":`, model.UpdateDmUserRequest{}},
+		{"unknown field", `{"id":"x"}`, model.UpdateDmUserRequest{}},
+		{"null on non-nullable field", `{"name":null}`, model.UpdateDmUserRequest{}},
+		{"wrong type", `{"name":1}`, model.UpdateDmUserRequest{}},
+		{"empty string violates min", `{"name":""}`, model.UpdateDmUserRequest{}},
+		{"invalid email", `{"email":"not-an-email"}`, model.UpdateDmUserRequest{}},
+		{"too long title", `{"title":"` + strings.Repeat("a", 201) + `"}`, model.UpdateDmPostRequest{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMergePatch([]byte(tt.patch), tt.target)
+			assert.ErrorIs(t, err, ErrInvalidMergePatch)
+		})
+	}
+}
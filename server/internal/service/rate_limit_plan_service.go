@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+)
+
+// RateLimitPlanService は呼び出し元に適用するレートリミット・クォータのプランの決定を担当
+type RateLimitPlanService struct {
+	planRepo    repository.RateLimitPlanRepositoryInterface
+	defaultPlan string
+}
+
+// NewRateLimitPlanService は新しいRateLimitPlanServiceを作成
+// defaultPlanはプランが割り当てられていない呼び出し元に適用するプランの名前（空の場合は無制限）
+func NewRateLimitPlanService(planRepo repository.RateLimitPlanRepositoryInterface, defaultPlan string) *RateLimitPlanService {
+	return &RateLimitPlanService{
+		planRepo:    planRepo,
+		defaultPlan: defaultPlan,
+	}
+}
+
+// GetRateLimitPlan は呼び出し元（principalTypeはapi_keyまたはuser）に適用するプランを取得
+// 割り当てがない場合（principalIDが空の場合を含む）はデフォルトのプランを返し、適用するプランがない場合はnil（無制限）を返す
+func (s *RateLimitPlanService) GetRateLimitPlan(ctx context.Context, principalType, principalID string) (*model.RateLimitPlan, error) {
+	planName := s.defaultPlan
+	if principalID != "" {
+		name, err := s.planRepo.GetAssignedPlanName(ctx, principalType, principalID)
+		if err == nil {
+			planName = name
+		} else if !errors.Is(err, repository.ErrRateLimitPlanAssignmentNotFound) {
+			return nil, fmt.Errorf("failed to get rate limit plan assignment: %w", err)
+		}
+	}
+	if planName == "" {
+		return nil, nil
+	}
+
+	plan, err := s.planRepo.GetByName(ctx, planName)
+	if err != nil {
+		if !errors.Is(err, repository.ErrRateLimitPlanNotFound) {
+			return nil, fmt.Errorf("failed to get rate limit plan: %w", err)
+		}
+		// 割り当てたプランが削除されている場合はデフォルトのプランを使う
+		if planName == s.defaultPlan || s.defaultPlan == "" {
+			return nil, nil
+		}
+		return s.GetRateLimitPlan(ctx, principalType, "")
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/repository"
+)
+
+// MockRateLimitPlanRepository はRateLimitPlanRepositoryInterfaceのモック
+type MockRateLimitPlanRepository struct {
+	plans       map[string]*model.RateLimitPlan
+	assignments map[string]string
+	err         error
+}
+
+func (m *MockRateLimitPlanRepository) GetByName(ctx context.Context, name string) (*model.RateLimitPlan, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	plan, ok := m.plans[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repository.ErrRateLimitPlanNotFound, name)
+	}
+	return plan, nil
+}
+
+func (m *MockRateLimitPlanRepository) GetAssignedPlanName(ctx context.Context, principalType, principalID string) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	name, ok := m.assignments[principalType+":"+principalID]
+	if !ok {
+		return "", fmt.Errorf("%w: %s %s", repository.ErrRateLimitPlanAssignmentNotFound, principalType, principalID)
+	}
+	return name, nil
+}
+
+func (m *MockRateLimitPlanRepository) Assign(ctx context.Context, assignment *model.RateLimitPlanAssignment) error {
+	m.assignments[assignment.PrincipalType+":"+assignment.PrincipalID] = assignment.PlanName
+	return nil
+}
+
+func TestRateLimitPlanService_GetRateLimitPlan(t *testing.T) {
+	repo := &MockRateLimitPlanRepository{
+		plans: map[string]*model.RateLimitPlan{
+			"default": {Name: "default", RequestsPerMinute: 60},
+			"pro":     {Name: "pro", RequestsPerMinute: 600},
+		},
+		assignments: map[string]string{
+			"api_key:key-pro":     "pro",
+			"api_key:key-deleted": "deleted",
+		},
+	}
+	s := NewRateLimitPlanService(repo, "default")
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		principalType string
+		principalID   string
+		want          string
+	}{
+		{"割り当てたプラン", "api_key", "key-pro", "pro"},
+		{"割り当てがない場合はデフォルト", "api_key", "key-other", "default"},
+		{"種類が異なる場合はデフォルト", "user", "key-pro", "default"},
+		{"IDが空の場合はデフォルト", "user", "", "default"},
+		{"割り当てたプランがない場合はデフォルト", "api_key", "key-deleted", "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := s.GetRateLimitPlan(ctx, tt.principalType, tt.principalID)
+			require.NoError(t, err)
+			require.NotNil(t, plan)
+			assert.Equal(t, tt.want, plan.Name)
+		})
+	}
+
+	// デフォルトのプランがない場合は無制限
+	plan, err := NewRateLimitPlanService(repo, "").GetRateLimitPlan(ctx, "api_key", "key-other")
+	require.NoError(t, err)
+	assert.Nil(t, plan)
+	plan, err = NewRateLimitPlanService(repo, "missing").GetRateLimitPlan(ctx, "api_key", "key-deleted")
+	require.NoError(t, err)
+	assert.Nil(t, plan)
+
+	// データベースのエラー
+	repo.err = errors.New("connection refused")
+	_, err = s.GetRateLimitPlan(ctx, "api_key", "key-pro")
+	assert.Error(t, err)
+}
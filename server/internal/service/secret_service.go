@@ -2,24 +2,78 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
 )
 
 // SecretServiceInterface は秘密鍵生成サービスのインターフェース
 type SecretServiceInterface interface {
 	GenerateSecretKey(ctx context.Context) (string, error)
+	RotateSigningKey(ctx context.Context, configPath, algorithm, keyDir string) (*config.SigningKeyRotation, error)
 }
 
 // SecretService は秘密鍵生成のビジネスロジックを担当
-type SecretService struct{}
+type SecretService struct {
+	now func() time.Time
+}
 
 // NewSecretService は新しいSecretServiceを作成
 func NewSecretService() *SecretService {
-	return &SecretService{}
+	return &SecretService{
+		now: time.Now,
+	}
 }
 
 // GenerateSecretKey は秘密鍵を生成
 func (s *SecretService) GenerateSecretKey(ctx context.Context) (string, error) {
 	return auth.GenerateSecretKey()
 }
+
+// RotateSigningKey はPublic APIキーの署名鍵を生成し、設定ファイルの署名に使う鍵を切り替える
+// HS256の場合は共有鍵を設定ファイルに書き込み、RS256 / EdDSAの場合は秘密鍵をkeyDirにPEMファイルとして保存する
+func (s *SecretService) RotateSigningKey(ctx context.Context, configPath, algorithm, keyDir string) (*config.SigningKeyRotation, error) {
+	now := s.now()
+	newKey := config.APISigningKeyConfig{
+		ID:        "k" + now.UTC().Format("20060102150405"),
+		Algorithm: algorithm,
+	}
+
+	switch algorithm {
+	case auth.SigningAlgorithmHS256:
+		secret, err := auth.GenerateSecretKey()
+		if err != nil {
+			return nil, err
+		}
+		newKey.Secret = secret
+	case auth.SigningAlgorithmRS256, auth.SigningAlgorithmEdDSA:
+		privateKeyPEM, err := auth.GenerateSigningPrivateKeyPEM(algorithm)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(keyDir, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create key directory: %w", err)
+		}
+		newKey.PrivateKeyFile = filepath.Join(keyDir, newKey.ID+".pem")
+		if err := os.WriteFile(newKey.PrivateKeyFile, privateKeyPEM, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write private key file: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", algorithm)
+	}
+
+	rotation, err := config.RotateSigningKey(configPath, newKey, now)
+	if err != nil {
+		// 設定ファイルに登録できなかった秘密鍵は削除する
+		if newKey.PrivateKeyFile != "" {
+			os.Remove(newKey.PrivateKeyFile)
+		}
+		return nil, fmt.Errorf("failed to rotate signing key: %w", err)
+	}
+
+	return rotation, nil
+}
@@ -2,9 +2,17 @@ package service
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
 )
 
 func TestSecretService_GenerateSecretKey(t *testing.T) {
@@ -35,3 +43,39 @@ func TestSecretService_GenerateSecretKey(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretService_RotateSigningKey(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("api:\n  secret_key: \"legacy\"\n"), 0600))
+	keyDir := filepath.Join(dir, "keys")
+
+	s := NewSecretService()
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	rotation, err := s.RotateSigningKey(ctx, configPath, auth.SigningAlgorithmHS256, keyDir)
+	require.NoError(t, err)
+	assert.Equal(t, "k20261019150000", rotation.KeyID)
+	assert.Empty(t, rotation.RetiredKeyID)
+
+	now = now.Add(time.Hour)
+	rotation, err = s.RotateSigningKey(ctx, configPath, auth.SigningAlgorithmEdDSA, keyDir)
+	require.NoError(t, err)
+	assert.Equal(t, "k20261019160000", rotation.KeyID)
+	assert.Equal(t, "k20261019150000", rotation.RetiredKeyID)
+	assert.FileExists(t, filepath.Join(keyDir, "k20261019160000.pem"))
+
+	// ローテーション後の設定で署名鍵を読み込める
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	require.NoError(t, v.ReadInConfig())
+	var cfg config.Config
+	require.NoError(t, v.Unmarshal(&cfg))
+	_, err = auth.NewKeySet(&cfg.API)
+	require.NoError(t, err)
+
+	_, err = s.RotateSigningKey(ctx, configPath, "none", keyDir)
+	assert.Error(t, err)
+}
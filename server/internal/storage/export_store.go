@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+// LocalExportDownloadPath はローカルストレージのエクスポートファイルのダウンロードパス
+// 署名付きURLで認証するため、JWT認証の対象外（/api/配下以外）に配置する
+const LocalExportDownloadPath = "/exports/download"
+
+// ErrInvalidDownloadSignature はダウンロードURLの署名が不正または期限切れの場合のエラー
+var ErrInvalidDownloadSignature = errors.New("invalid or expired download signature")
+
+// ExportStore はエクスポートファイルの保存先
+type ExportStore interface {
+	// Save はファイルをkeyで保存する
+	Save(ctx context.Context, key string, body io.ReadSeeker, size int64, contentType string) error
+	// DownloadURL は期限付きのダウンロードURLを返す
+	DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error)
+}
+
+// NewExportStore はアップロード設定のストレージ種別に応じたExportStoreを作成する
+// ローカルストレージの場合、ダウンロードURLはcfg.ExportSigningSecretで署名する
+func NewExportStore(cfg *config.UploadConfig) (ExportStore, error) {
+	switch cfg.Storage.Type {
+	case "local":
+		store, err := NewLocalExportStore(cfg.Storage.Local.Path, cfg.ExportSigningSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local export store: %w", err)
+		}
+		return store, nil
+	case "s3":
+		store, err := NewS3ExportStore(cfg.Storage.S3.Bucket, cfg.Storage.S3.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create S3 export store: %w", err)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", cfg.Storage.Type)
+	}
+}
+
+// LocalExportStore はローカルファイルシステムのエクスポートファイル保存先
+// ダウンロードURLはHMAC-SHA256で署名し、LocalExportDownloadPathで配信する
+type LocalExportStore struct {
+	basePath string
+	secret   []byte
+}
+
+// NewLocalExportStore は新しいLocalExportStoreを作成する
+// 指定されたパスのディレクトリが存在しない場合は作成する
+func NewLocalExportStore(basePath string, secretKey string) (*LocalExportStore, error) {
+	if secretKey == "" {
+		return nil, errors.New("secret key is required to sign download URLs")
+	}
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, err
+	}
+	return &LocalExportStore{
+		basePath: basePath,
+		secret:   []byte(secretKey),
+	}, nil
+}
+
+// Save はファイルを一時ファイル経由で保存する（書き込み途中のファイルを配信しないため）
+func (l *LocalExportStore) Save(ctx context.Context, key string, body io.ReadSeeker, size int64, contentType string) error {
+	path, err := l.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// DownloadURL は署名付きのダウンロードURL（パスのみ）を返す
+func (l *LocalExportStore) DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("name", fileName)
+	query.Set("expires", expires)
+	query.Set("signature", l.sign(key, fileName, expires))
+	return LocalExportDownloadPath + "?" + query.Encode(), expiresAt, nil
+}
+
+// Open は署名を検証し、エクスポートファイルを開く
+func (l *LocalExportStore) Open(key string, fileName string, expires string, signature string) (*os.File, error) {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresUnix {
+		return nil, ErrInvalidDownloadSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(l.sign(key, fileName, expires))) {
+		return nil, ErrInvalidDownloadSignature
+	}
+
+	path, err := l.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// sign はダウンロードURLの署名を生成する
+func (l *LocalExportStore) sign(key string, fileName string, expires string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key + "\n" + fileName + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolve はkeyを保存先パスに変換する（basePath外へのアクセスは拒否）
+func (l *LocalExportStore) resolve(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if key == "" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid export key: %s", key)
+	}
+	return filepath.Join(l.basePath, cleaned), nil
+}
+
+// S3ExportStore はAWS S3のエクスポートファイル保存先
+// ダウンロードURLはS3の署名付きURLを使用する
+type S3ExportStore struct {
+	bucket        string
+	s3Client      *s3.Client
+	presignClient *s3.PresignClient
+}
+
+// NewS3ExportStore は新しいS3ExportStoreを作成する
+// AWS認証情報は環境変数またはAWS設定から取得する
+func NewS3ExportStore(bucket, region string) (*S3ExportStore, error) {
+	// AWS設定を読み込み
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+		awsconfig.WithRegion(region),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	return &S3ExportStore{
+		bucket:        bucket,
+		s3Client:      s3Client,
+		presignClient: s3.NewPresignClient(s3Client),
+	}, nil
+}
+
+// Save はファイルをS3にアップロードする
+func (s *S3ExportStore) Save(ctx context.Context, key string, body io.ReadSeeker, size int64, contentType string) error {
+	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        &s.bucket,
+		Key:           &key,
+		Body:          body,
+		ContentLength: &size,
+		ContentType:   &contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+// DownloadURL はS3の署名付きダウンロードURLを返す
+func (s *S3ExportStore) DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error) {
+	disposition := fmt.Sprintf(`attachment; filename="%s"`, fileName)
+	req, err := s.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket:                     &s.bucket,
+		Key:                        &key,
+		ResponseContentDisposition: &disposition,
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to presign URL: %w", err)
+	}
+	return req.URL, time.Now().Add(ttl), nil
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+)
+
+func TestNewExportStore_Local(t *testing.T) {
+	cfg := &config.UploadConfig{
+		Storage: config.StorageConfig{
+			Type: "local",
+			Local: config.LocalStorageConfig{
+				Path: filepath.Join(t.TempDir(), "uploads"),
+			},
+		},
+		ExportSigningSecret: "secret",
+	}
+
+	store, err := NewExportStore(cfg)
+	if err != nil {
+		t.Fatalf("NewExportStore failed: %v", err)
+	}
+	if _, ok := store.(*LocalExportStore); !ok {
+		t.Fatalf("expected *LocalExportStore, got %T", store)
+	}
+}
+
+func TestNewExportStore_InvalidType(t *testing.T) {
+	cfg := &config.UploadConfig{
+		Storage: config.StorageConfig{
+			Type: "invalid",
+		},
+	}
+
+	if _, err := NewExportStore(cfg); err == nil {
+		t.Fatal("expected error for invalid storage type")
+	}
+}
+
+func TestNewLocalExportStore_RequiresSecret(t *testing.T) {
+	if _, err := NewLocalExportStore(t.TempDir(), ""); err == nil {
+		t.Fatal("expected error for empty secret key")
+	}
+}
+
+func TestLocalExportStore_SaveAndOpen(t *testing.T) {
+	store, err := NewLocalExportStore(t.TempDir(), "secret")
+	if err != nil {
+		t.Fatalf("NewLocalExportStore failed: %v", err)
+	}
+	ctx := context.Background()
+
+	content := []byte("id,name\n1,test\n")
+	if err := store.Save(ctx, "exports/job1.csv", bytes.NewReader(content), int64(len(content)), "text/csv"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	downloadURL, expiresAt, err := store.DownloadURL(ctx, "exports/job1.csv", "dm_users.csv", time.Minute)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	if !strings.HasPrefix(downloadURL, LocalExportDownloadPath+"?") {
+		t.Errorf("unexpected download URL: %s", downloadURL)
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("expected expiresAt in the future, got %v", expiresAt)
+	}
+
+	parsed, err := url.Parse(downloadURL)
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+	q := parsed.Query()
+
+	f, err := store.Open(q.Get("key"), q.Get("name"), q.Get("expires"), q.Get("signature"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer f.Close()
+
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+}
+
+func TestLocalExportStore_OpenRejectsInvalidSignature(t *testing.T) {
+	store, err := NewLocalExportStore(t.TempDir(), "secret")
+	if err != nil {
+		t.Fatalf("NewLocalExportStore failed: %v", err)
+	}
+	ctx := context.Background()
+
+	downloadURL, _, err := store.DownloadURL(ctx, "exports/job1.csv", "dm_users.csv", time.Minute)
+	if err != nil {
+		t.Fatalf("DownloadURL failed: %v", err)
+	}
+	parsed, _ := url.Parse(downloadURL)
+	q := parsed.Query()
+
+	tests := []struct {
+		name      string
+		key       string
+		fileName  string
+		expires   string
+		signature string
+	}{
+		{"tampered key", "exports/job2.csv", q.Get("name"), q.Get("expires"), q.Get("signature")},
+		{"tampered name", q.Get("key"), "other.csv", q.Get("expires"), q.Get("signature")},
+		{"tampered expires", q.Get("key"), q.Get("name"), "9999999999", q.Get("signature")},
+		{"expired", q.Get("key"), q.Get("name"), "1", store.sign(q.Get("key"), q.Get("name"), "1")},
+		{"invalid expires", q.Get("key"), q.Get("name"), "abc", q.Get("signature")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := store.Open(tt.key, tt.fileName, tt.expires, tt.signature)
+			if !errors.Is(err, ErrInvalidDownloadSignature) {
+				t.Errorf("expected ErrInvalidDownloadSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLocalExportStore_RejectsPathTraversal(t *testing.T) {
+	store, err := NewLocalExportStore(t.TempDir(), "secret")
+	if err != nil {
+		t.Fatalf("NewLocalExportStore failed: %v", err)
+	}
+
+	err = store.Save(context.Background(), "../outside.csv", bytes.NewReader(nil), 0, "text/csv")
+	if err == nil {
+		t.Fatal("expected error for path traversal key")
+	}
+}
@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/util/idgen"
+)
+
+// ErrBrokerClosed はブローカーの停止後に購読しようとした場合のエラー
+var ErrBrokerClosed = errors.New("stream broker closed")
+
+// Broker はServer-Sent Eventsのイベントを配信するブローカーのインターフェース
+type Broker interface {
+	// Publish はイベントを全APIサーバーの購読者に配信
+	Publish(ctx context.Context, event *model.StreamEvent) error
+	// Subscribe はトピックを購読（lastEventIDより後のイベントを再送、userIDを指定した場合はそのユーザーのイベントのみ）
+	Subscribe(topic, lastEventID, userID string) (*Subscription, error)
+	// Close はブローカーを停止し、全ての購読を終了
+	Close() error
+}
+
+// NewBroker は設定に応じたブローカーを作成
+// Redis（cache_server.redis.default.cluster.addrs）が設定されている場合はRedis pub/sub、なければプロセス内で配信する
+func NewBroker(cfg *config.Config) (Broker, error) {
+	if len(cfg.CacheServer.Redis.Default.Cluster.Addrs) == 0 {
+		return NewMemoryBroker(cfg.Stream.HistorySize), nil
+	}
+	return NewRedisBroker(cfg)
+}
+
+// NewEvent はIDを採番してイベントを作成
+func NewEvent(topic, eventType, userID string, data interface{}) (*model.StreamEvent, error) {
+	id, err := idgen.GenerateUUIDv7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event id: %w", err)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	return &model.StreamEvent{
+		ID:     id,
+		Topic:  topic,
+		Type:   eventType,
+		UserID: userID,
+		Data:   payload,
+	}, nil
+}
+
+// NewPostCreatedEvent は投稿作成のイベントを作成（投稿者のuser_idでフィルタできる）
+func NewPostCreatedEvent(dmPost *model.DmPost) (*model.StreamEvent, error) {
+	return NewEvent(model.StreamTopicPosts, model.StreamEventPostCreated, dmPost.UserID, dmPost)
+}
+
+// NewNewsPublishedEvent はニュース公開のイベントを作成（作成者のauthor_idでフィルタできる）
+func NewNewsPublishedEvent(dmNews *model.DmNews) (*model.StreamEvent, error) {
+	userID := ""
+	if dmNews.AuthorID != nil {
+		userID = strconv.FormatInt(*dmNews.AuthorID, 10)
+	}
+	return NewEvent(model.StreamTopicNews, model.StreamEventNewsPublished, userID, dmNews)
+}
+
+// MemoryBroker はプロセス内の購読者にのみ配信するブローカー（Redisがない環境用）
+type MemoryBroker struct {
+	hub *hub
+}
+
+// NewMemoryBroker は新しいMemoryBrokerを作成
+// historySizeはLast-Event-IDでの再開用に保持するトピックごとのイベント数
+func NewMemoryBroker(historySize int) *MemoryBroker {
+	return &MemoryBroker{
+		hub: newHub(historySize),
+	}
+}
+
+// Publish はイベントをプロセス内の購読者に配信
+func (b *MemoryBroker) Publish(ctx context.Context, event *model.StreamEvent) error {
+	b.hub.dispatch(event)
+	return nil
+}
+
+// Subscribe はトピックを購読
+func (b *MemoryBroker) Subscribe(topic, lastEventID, userID string) (*Subscription, error) {
+	return b.hub.subscribe(topic, lastEventID, userID)
+}
+
+// Close は全ての購読を終了
+func (b *MemoryBroker) Close() error {
+	b.hub.close()
+	return nil
+}
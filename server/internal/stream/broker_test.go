@@ -0,0 +1,194 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func newTestEvent(t *testing.T, topic, userID string) *model.StreamEvent {
+	t.Helper()
+	event, err := NewEvent(topic, "test.event", userID, map[string]string{"user_id": userID})
+	require.NoError(t, err)
+	return event
+}
+
+func receive(t *testing.T, sub *Subscription) *model.StreamEvent {
+	t.Helper()
+	select {
+	case event := <-sub.Events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+		return nil
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	event, err := NewEvent(model.StreamTopicPosts, model.StreamEventPostCreated, "u1", map[string]string{"id": "p1"})
+	require.NoError(t, err)
+
+	assert.Len(t, event.ID, 32)
+	assert.Equal(t, model.StreamTopicPosts, event.Topic)
+	assert.Equal(t, model.StreamEventPostCreated, event.Type)
+	assert.Equal(t, "u1", event.UserID)
+	assert.JSONEq(t, `{"id":"p1"}`, string(event.Data))
+}
+
+func TestNewNewsPublishedEvent(t *testing.T) {
+	authorID := int64(7)
+	event, err := NewNewsPublishedEvent(&model.DmNews{ID: 1, Title: "News", AuthorID: &authorID})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.StreamTopicNews, event.Topic)
+	assert.Equal(t, model.StreamEventNewsPublished, event.Type)
+	assert.Equal(t, "7", event.UserID)
+}
+
+func TestMemoryBroker_PublishSubscribe(t *testing.T) {
+	broker := NewMemoryBroker(10)
+	defer broker.Close()
+
+	posts, err := broker.Subscribe(model.StreamTopicPosts, "", "")
+	require.NoError(t, err)
+	defer posts.Close()
+	news, err := broker.Subscribe(model.StreamTopicNews, "", "")
+	require.NoError(t, err)
+	defer news.Close()
+
+	event := newTestEvent(t, model.StreamTopicPosts, "u1")
+	require.NoError(t, broker.Publish(context.Background(), event))
+
+	assert.Equal(t, event.ID, receive(t, posts).ID)
+	// 別トピックの購読者には配信されない
+	assert.Empty(t, news.Events)
+}
+
+func TestMemoryBroker_UserIDFilter(t *testing.T) {
+	broker := NewMemoryBroker(10)
+	defer broker.Close()
+
+	sub, err := broker.Subscribe(model.StreamTopicPosts, "", "u2")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	require.NoError(t, broker.Publish(context.Background(), newTestEvent(t, model.StreamTopicPosts, "u1")))
+	event := newTestEvent(t, model.StreamTopicPosts, "u2")
+	require.NoError(t, broker.Publish(context.Background(), event))
+
+	assert.Equal(t, event.ID, receive(t, sub).ID)
+	assert.Empty(t, sub.Events)
+}
+
+func TestMemoryBroker_Replay(t *testing.T) {
+	broker := NewMemoryBroker(3)
+	defer broker.Close()
+
+	var events []*model.StreamEvent
+	for i := 0; i < 5; i++ {
+		event := newTestEvent(t, model.StreamTopicPosts, "u1")
+		events = append(events, event)
+		require.NoError(t, broker.Publish(context.Background(), event))
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []*model.StreamEvent
+	}{
+		{"no last event id", "", nil},
+		{"in history", events[3].ID, events[4:]},
+		{"latest", events[4].ID, nil},
+		// 履歴から消えたIDの場合は、IDが大きいイベントを全て再送する
+		{"evicted from history", events[0].ID, events[2:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := broker.Subscribe(model.StreamTopicPosts, tt.lastEventID, "")
+			require.NoError(t, err)
+			defer sub.Close()
+
+			assert.Equal(t, tt.want, sub.Replay)
+		})
+	}
+}
+
+func TestMemoryBroker_SlowSubscriberIsDropped(t *testing.T) {
+	broker := NewMemoryBroker(10)
+	defer broker.Close()
+
+	sub, err := broker.Subscribe(model.StreamTopicPosts, "", "")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		require.NoError(t, broker.Publish(context.Background(), newTestEvent(t, model.StreamTopicPosts, "u1")))
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	assert.Equal(t, subscriberBufferSize, received)
+}
+
+func TestMemoryBroker_Close(t *testing.T) {
+	broker := NewMemoryBroker(10)
+
+	sub, err := broker.Subscribe(model.StreamTopicPosts, "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Close())
+	_, ok := <-sub.Events
+	assert.False(t, ok)
+	// 購読の終了後にCloseしても問題ない
+	sub.Close()
+
+	_, err = broker.Subscribe(model.StreamTopicPosts, "", "")
+	assert.ErrorIs(t, err, ErrBrokerClosed)
+}
+
+func TestNewBroker(t *testing.T) {
+	t.Run("memory when redis is not configured", func(t *testing.T) {
+		cfg := &config.Config{Stream: config.StreamConfig{HistorySize: 10}}
+
+		broker, err := NewBroker(cfg)
+		require.NoError(t, err)
+		defer broker.Close()
+
+		assert.IsType(t, &MemoryBroker{}, broker)
+	})
+
+	t.Run("redis when redis is configured", func(t *testing.T) {
+		cfg := &config.Config{Stream: config.StreamConfig{HistorySize: 10, ChannelPrefix: "stream:"}}
+		cfg.CacheServer.Redis.Default.Cluster.Addrs = []string{"127.0.0.1:1"}
+		cfg.CacheServer.Redis.Default.Cluster.DialTimeout = 100 * time.Millisecond
+
+		broker, err := NewBroker(cfg)
+		require.NoError(t, err)
+
+		redisBroker, ok := broker.(*RedisBroker)
+		require.True(t, ok)
+		assert.Equal(t, "stream:posts", redisBroker.channel(model.StreamTopicPosts))
+		broker.Close()
+	})
+}
+
+func TestStreamEvent_JSON(t *testing.T) {
+	// Redis pub/subで中継してもdataはそのまま出力できること
+	event := newTestEvent(t, model.StreamTopicPosts, "u1")
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var decoded model.StreamEvent
+	require.NoError(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, event, &decoded)
+}
@@ -0,0 +1,168 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// subscriberBufferSize は接続ごとに溜めておけるイベント数
+// 溢れた（クライアントの受信が追いつかない）接続は切断し、Last-Event-IDで再接続させる
+const subscriberBufferSize = 64
+
+// Subscription はトピックの購読
+// Replayは購読開始時点で再送すべきイベント、Eventsは以降に発生したイベント
+// Eventsは購読の終了（Close、受信の遅延、ブローカーの停止）時にcloseされる
+type Subscription struct {
+	Replay []*model.StreamEvent
+	Events <-chan *model.StreamEvent
+
+	hub        *hub
+	topic      string
+	subscriber *subscriber
+}
+
+// Close は購読を終了
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s.topic, s.subscriber)
+}
+
+type subscriber struct {
+	userID string
+	ch     chan *model.StreamEvent
+}
+
+// hub はプロセス内の購読者へのイベント配信と、再開用のイベント履歴を管理
+type hub struct {
+	mu          sync.Mutex
+	historySize int
+	history     map[string][]*model.StreamEvent
+	subscribers map[string]map[*subscriber]struct{}
+	closed      bool
+}
+
+func newHub(historySize int) *hub {
+	return &hub{
+		historySize: historySize,
+		history:     make(map[string][]*model.StreamEvent),
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// subscribe は購読を登録し、lastEventIDより後のイベントを再送対象として返す
+// 履歴の取得と購読の登録を同じロック内で行い、イベントの取りこぼし・重複を防ぐ
+func (h *hub) subscribe(topic, lastEventID, userID string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	sub := &subscriber{
+		userID: userID,
+		ch:     make(chan *model.StreamEvent, subscriberBufferSize),
+	}
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = make(map[*subscriber]struct{})
+	}
+	h.subscribers[topic][sub] = struct{}{}
+
+	var replay []*model.StreamEvent
+	if lastEventID != "" {
+		for _, event := range eventsAfter(h.history[topic], lastEventID) {
+			if sub.matches(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	return &Subscription{
+		Replay:     replay,
+		Events:     sub.ch,
+		hub:        h,
+		topic:      topic,
+		subscriber: sub,
+	}, nil
+}
+
+// unsubscribe は購読を解除（解除済みの場合は何もしない）
+func (h *hub) unsubscribe(topic string, sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[topic][sub]; !ok {
+		return
+	}
+	delete(h.subscribers[topic], sub)
+	close(sub.ch)
+}
+
+// dispatch はイベントを履歴に追加し、トピックの購読者に配信
+func (h *hub) dispatch(event *model.StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	history := append(h.history[event.Topic], event)
+	if len(history) > h.historySize {
+		history = history[len(history)-h.historySize:]
+	}
+	h.history[event.Topic] = history
+
+	for sub := range h.subscribers[event.Topic] {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			// 受信が追いつかない接続は切断する
+			delete(h.subscribers[event.Topic], sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// close は全ての購読を終了
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			close(sub.ch)
+		}
+	}
+	h.subscribers = make(map[string]map[*subscriber]struct{})
+}
+
+// matches はイベントが購読者のuser_idフィルタに一致するか判定
+func (s *subscriber) matches(event *model.StreamEvent) bool {
+	return s.userID == "" || s.userID == event.UserID
+}
+
+// eventsAfter は履歴からlastEventIDより後のイベントを返す
+// lastEventIDが履歴に残っていない場合は、IDがlastEventIDより大きい（UUIDv7なので後に発生した）イベントを返す
+func eventsAfter(history []*model.StreamEvent, lastEventID string) []*model.StreamEvent {
+	for i, event := range history {
+		if event.ID == lastEventID {
+			return history[i+1:]
+		}
+	}
+
+	var events []*model.StreamEvent
+	for _, event := range history {
+		if event.ID > lastEventID {
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// RedisBroker はRedis pub/subで全APIサーバーにイベントを中継するブローカー
+// 各APIサーバーは全トピックのチャンネルを購読し、受信したイベントをプロセス内の購読者に配信する
+type RedisBroker struct {
+	client *redis.ClusterClient
+	pubsub *redis.PubSub
+	hub    *hub
+	prefix string
+	done   chan struct{}
+}
+
+// NewRedisBroker は新しいRedisBrokerを作成
+// Redisへの接続は遅延接続であり、Redisが起動していない場合も購読は自動的に再接続される
+func NewRedisBroker(cfg *config.Config) (*RedisBroker, error) {
+	client := redis.NewClusterClient(buildRedisClusterOptions(cfg))
+	b := &RedisBroker{
+		client: client,
+		pubsub: client.Subscribe(context.Background()),
+		hub:    newHub(cfg.Stream.HistorySize),
+		prefix: cfg.Stream.ChannelPrefix,
+		done:   make(chan struct{}),
+	}
+	go b.receive()
+	return b, nil
+}
+
+// Publish はイベントをRedisのトピックのチャンネルに送信
+// 自プロセスの購読者にも購読中のチャンネル経由で配信される
+func (b *RedisBroker) Publish(ctx context.Context, event *model.StreamEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream event: %w", err)
+	}
+	if err := b.client.Publish(ctx, b.channel(event.Topic), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish stream event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe はトピックを購読
+func (b *RedisBroker) Subscribe(topic, lastEventID, userID string) (*Subscription, error) {
+	return b.hub.subscribe(topic, lastEventID, userID)
+}
+
+// Close はRedisの購読を停止し、全ての購読を終了
+func (b *RedisBroker) Close() error {
+	err := b.pubsub.Close()
+	<-b.done
+	b.hub.close()
+	if closeErr := b.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// receive はRedisから受信したイベントをプロセス内の購読者に配信（pubsubのClose時に終了）
+func (b *RedisBroker) receive() {
+	defer close(b.done)
+
+	// 接続できない場合もチャンネルは登録され、Channel()が再接続時に購読し直す
+	if err := b.pubsub.Subscribe(context.Background(), b.channel(model.StreamTopicPosts), b.channel(model.StreamTopicNews)); err != nil {
+		log.Printf("WARNING: Failed to subscribe stream channels (will retry): %v", err)
+	}
+
+	for msg := range b.pubsub.Channel() {
+		var event model.StreamEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Printf("Failed to decode stream event from %s: %v", msg.Channel, err)
+			continue
+		}
+		b.hub.dispatch(&event)
+	}
+}
+
+// channel はトピックのRedisチャンネル名を返す
+func (b *RedisBroker) channel(topic string) string {
+	return b.prefix + topic
+}
+
+// buildRedisClusterOptions はRedis Cluster接続オプションを構築する
+func buildRedisClusterOptions(cfg *config.Config) *redis.ClusterOptions {
+	clusterCfg := cfg.CacheServer.Redis.Default.Cluster
+	clusterOpts := &redis.ClusterOptions{
+		Addrs: clusterCfg.Addrs,
+	}
+
+	// 接続オプションの設定（設定ファイルから読み込む、未設定の場合はデフォルト値を使用）
+	if clusterCfg.MaxRetries > 0 {
+		clusterOpts.MaxRetries = clusterCfg.MaxRetries
+	} else {
+		clusterOpts.MaxRetries = 2 // デフォルト値
+	}
+
+	if clusterCfg.MinRetryBackoff > 0 {
+		clusterOpts.MinRetryBackoff = clusterCfg.MinRetryBackoff
+	} else {
+		clusterOpts.MinRetryBackoff = 8 * time.Millisecond // デフォルト値
+	}
+
+	if clusterCfg.MaxRetryBackoff > 0 {
+		clusterOpts.MaxRetryBackoff = clusterCfg.MaxRetryBackoff
+	} else {
+		clusterOpts.MaxRetryBackoff = 512 * time.Millisecond // デフォルト値
+	}
+
+	if clusterCfg.DialTimeout > 0 {
+		clusterOpts.DialTimeout = clusterCfg.DialTimeout
+	} else {
+		clusterOpts.DialTimeout = 5 * time.Second // デフォルト値
+	}
+
+	if clusterCfg.ReadTimeout > 0 {
+		clusterOpts.ReadTimeout = clusterCfg.ReadTimeout
+	} else {
+		clusterOpts.ReadTimeout = 3 * time.Second // デフォルト値
+	}
+
+	if clusterCfg.PoolSize > 0 {
+		clusterOpts.PoolSize = clusterCfg.PoolSize
+	} else {
+		clusterOpts.PoolSize = 10 * runtime.NumCPU() // デフォルト値: CPU数×10
+	}
+
+	if clusterCfg.PoolTimeout > 0 {
+		clusterOpts.PoolTimeout = clusterCfg.PoolTimeout
+	} else {
+		clusterOpts.PoolTimeout = 4 * time.Second // デフォルト値
+	}
+
+	return clusterOpts
+}
@@ -3,35 +3,48 @@ package admin
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
 	"github.com/taku-o/go-webdb-template/internal/service"
 )
 
-// APIKeyUsecase はAPIキー発行のビジネスロジックを担当
+// apiKeyListLimit は管理画面に表示するAPIキーの件数
+const apiKeyListLimit = 100
+
+// APIKeyRevocationCacheInterface は失効をAPIサーバーの失効確認のキャッシュに反映するインターフェース（apikey.Registry）
+type APIKeyRevocationCacheInterface interface {
+	MarkRevoked(ctx context.Context, jti string) error
+}
+
+// APIKeyUsecase はAPIキー発行・失効のビジネスロジックを担当
 type APIKeyUsecase struct {
-	apiKeyService service.APIKeyServiceInterface
-	cfg           *config.Config
+	apiKeyService   service.APIKeyServiceInterface
+	revocationCache APIKeyRevocationCacheInterface
+	cfg             *config.Config
 }
 
 // NewAPIKeyUsecase は新しいAPIKeyUsecaseを作成
-func NewAPIKeyUsecase(apiKeyService service.APIKeyServiceInterface, cfg *config.Config) *APIKeyUsecase {
+// revocationCacheがnilの場合、失効はAPIサーバーのキャッシュの期限が切れた後に反映される
+func NewAPIKeyUsecase(apiKeyService service.APIKeyServiceInterface, revocationCache APIKeyRevocationCacheInterface, cfg *config.Config) *APIKeyUsecase {
 	return &APIKeyUsecase{
-		apiKeyService: apiKeyService,
-		cfg:           cfg,
+		apiKeyService:   apiKeyService,
+		revocationCache: revocationCache,
+		cfg:             cfg,
 	}
 }
 
-// GenerateAPIKey はAPIキーを生成
-func (u *APIKeyUsecase) GenerateAPIKey(ctx context.Context, env string) (string, error) {
+// GenerateAPIKey はAPIキーを生成し、名前・所有者とともに台帳に登録
+func (u *APIKeyUsecase) GenerateAPIKey(ctx context.Context, env string, req *model.IssueAPIKeyRequest) (string, error) {
 	if env == "" {
 		return "", fmt.Errorf("環境が指定されていません")
 	}
 
 	now := time.Now()
-	token, err := u.apiKeyService.GenerateAPIKey(u.cfg.API.SecretKey, u.cfg.API.CurrentVersion, env, now.Unix())
+	token, err := u.apiKeyService.GenerateAPIKey(ctx, u.cfg.API.CurrentVersion, env, now.Unix(), req)
 	if err != nil {
 		return "", err
 	}
@@ -48,3 +61,28 @@ func (u *APIKeyUsecase) DecodeAPIKeyPayload(ctx context.Context, token string) (
 
 	return claims, nil
 }
+
+// ListAPIKeys は発行済みのAPIキーを新しい順に取得
+func (u *APIKeyUsecase) ListAPIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	return u.apiKeyService.ListAPIKeys(ctx, apiKeyListLimit, 0)
+}
+
+// RevokeAPIKey はAPIキーを失効させる
+func (u *APIKeyUsecase) RevokeAPIKey(ctx context.Context, jti string) error {
+	if jti == "" {
+		return fmt.Errorf("失効するAPIキーが指定されていません")
+	}
+
+	if err := u.apiKeyService.RevokeAPIKey(ctx, jti); err != nil {
+		return err
+	}
+
+	// 台帳の失効は完了しているため、キャッシュに反映できない場合はログのみ記録する
+	if u.revocationCache != nil {
+		if err := u.revocationCache.MarkRevoked(ctx, jti); err != nil {
+			log.Printf("Failed to mark api key %s revoked in cache: %v", jti, err)
+		}
+	}
+
+	return nil
+}
@@ -8,21 +8,48 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
 )
 
 // MockAPIKeyService はAPIKeyServiceInterfaceのモック
 type MockAPIKeyService struct {
-	GenerateAPIKeyFunc      func(secretKey, version, env string, issuedAt int64) (string, error)
+	GenerateAPIKeyFunc      func(version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error)
 	DecodeAPIKeyPayloadFunc func(token string) (*auth.JWTClaims, error)
+	ListAPIKeysFunc         func(ctx context.Context, limit, offset int) ([]*model.APIKey, error)
+	RevokeAPIKeyFunc        func(ctx context.Context, jti string) error
 }
 
-func (m *MockAPIKeyService) GenerateAPIKey(secretKey, version, env string, issuedAt int64) (string, error) {
+func (m *MockAPIKeyService) GenerateAPIKey(ctx context.Context, version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error) {
 	if m.GenerateAPIKeyFunc != nil {
-		return m.GenerateAPIKeyFunc(secretKey, version, env, issuedAt)
+		return m.GenerateAPIKeyFunc(version, env, issuedAt, req)
 	}
 	return "", nil
 }
 
+func (m *MockAPIKeyService) ListAPIKeys(ctx context.Context, limit, offset int) ([]*model.APIKey, error) {
+	if m.ListAPIKeysFunc != nil {
+		return m.ListAPIKeysFunc(ctx, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, jti string) error {
+	if m.RevokeAPIKeyFunc != nil {
+		return m.RevokeAPIKeyFunc(ctx, jti)
+	}
+	return nil
+}
+
+// MockAPIKeyRevocationCache はAPIKeyRevocationCacheInterfaceのモック
+type MockAPIKeyRevocationCache struct {
+	Revoked []string
+}
+
+func (m *MockAPIKeyRevocationCache) MarkRevoked(ctx context.Context, jti string) error {
+	m.Revoked = append(m.Revoked, jti)
+	return nil
+}
+
 func (m *MockAPIKeyService) DecodeAPIKeyPayload(token string) (*auth.JWTClaims, error) {
 	if m.DecodeAPIKeyPayloadFunc != nil {
 		return m.DecodeAPIKeyPayloadFunc(token)
@@ -34,7 +61,7 @@ func TestAPIKeyUsecase_GenerateAPIKey(t *testing.T) {
 	tests := []struct {
 		name               string
 		env                string
-		generateAPIKeyFunc func(secretKey, version, env string, issuedAt int64) (string, error)
+		generateAPIKeyFunc func(version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error)
 		wantToken          string
 		wantErr            bool
 		wantErrContains    string
@@ -42,7 +69,7 @@ func TestAPIKeyUsecase_GenerateAPIKey(t *testing.T) {
 		{
 			name: "正常系: APIキーを生成できる",
 			env:  "develop",
-			generateAPIKeyFunc: func(secretKey, version, env string, issuedAt int64) (string, error) {
+			generateAPIKeyFunc: func(version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error) {
 				return "generated-token", nil
 			},
 			wantToken: "generated-token",
@@ -58,7 +85,7 @@ func TestAPIKeyUsecase_GenerateAPIKey(t *testing.T) {
 		{
 			name: "異常系: service層からエラーが返された場合",
 			env:  "develop",
-			generateAPIKeyFunc: func(secretKey, version, env string, issuedAt int64) (string, error) {
+			generateAPIKeyFunc: func(version, env string, issuedAt int64, req *model.IssueAPIKeyRequest) (string, error) {
 				return "", errors.New("failed to generate token")
 			},
 			wantToken:       "",
@@ -80,8 +107,8 @@ func TestAPIKeyUsecase_GenerateAPIKey(t *testing.T) {
 				},
 			}
 
-			u := NewAPIKeyUsecase(mockService, cfg)
-			got, err := u.GenerateAPIKey(context.Background(), tt.env)
+			u := NewAPIKeyUsecase(mockService, nil, cfg)
+			got, err := u.GenerateAPIKey(context.Background(), tt.env, &model.IssueAPIKeyRequest{Name: "partner", Owner: "ops", Scopes: []string{"users:read"}})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -150,7 +177,7 @@ func TestAPIKeyUsecase_DecodeAPIKeyPayload(t *testing.T) {
 				},
 			}
 
-			u := NewAPIKeyUsecase(mockService, cfg)
+			u := NewAPIKeyUsecase(mockService, nil, cfg)
 			got, err := u.DecodeAPIKeyPayload(context.Background(), tt.token)
 
 			if tt.wantErr {
@@ -171,3 +198,31 @@ func TestAPIKeyUsecase_DecodeAPIKeyPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIKeyUsecase_RevokeAPIKey(t *testing.T) {
+	var revokedInService []string
+	mockService := &MockAPIKeyService{
+		RevokeAPIKeyFunc: func(ctx context.Context, jti string) error {
+			if jti == "unknown" {
+				return errors.New("api key not found")
+			}
+			revokedInService = append(revokedInService, jti)
+			return nil
+		},
+	}
+	cache := &MockAPIKeyRevocationCache{}
+	u := NewAPIKeyUsecase(mockService, cache, &config.Config{})
+
+	// 台帳とキャッシュの両方に反映する
+	assert.NoError(t, u.RevokeAPIKey(context.Background(), "key1"))
+	assert.Equal(t, []string{"key1"}, revokedInService)
+	assert.Equal(t, []string{"key1"}, cache.Revoked)
+
+	// 台帳で失効できない場合はキャッシュに反映しない
+	assert.Error(t, u.RevokeAPIKey(context.Background(), "unknown"))
+	assert.Equal(t, []string{"key1"}, cache.Revoked)
+
+	err := u.RevokeAPIKey(context.Background(), "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "失効するAPIキーが指定されていません")
+}
@@ -10,13 +10,16 @@ import (
 
 // DmUserRegisterUsecase はdm_user登録のビジネスロジックを担当
 type DmUserRegisterUsecase struct {
-	dmUserService usecaseapi.DmUserServiceInterface
+	dmUserService     usecaseapi.DmUserServiceInterface
+	webhookDispatcher usecaseapi.WebhookDispatcherInterface
 }
 
 // NewDmUserRegisterUsecase は新しいDmUserRegisterUsecaseを作成
-func NewDmUserRegisterUsecase(dmUserService usecaseapi.DmUserServiceInterface) *DmUserRegisterUsecase {
+// webhookDispatcherがnilの場合、Webhookは送信しない
+func NewDmUserRegisterUsecase(dmUserService usecaseapi.DmUserServiceInterface, webhookDispatcher usecaseapi.WebhookDispatcherInterface) *DmUserRegisterUsecase {
 	return &DmUserRegisterUsecase{
-		dmUserService: dmUserService,
+		dmUserService:     dmUserService,
+		webhookDispatcher: webhookDispatcher,
 	}
 }
 
@@ -40,6 +43,9 @@ func (u *DmUserRegisterUsecase) RegisterDmUser(ctx context.Context, name, email
 	if err != nil {
 		return "", err
 	}
+	if u.webhookDispatcher != nil {
+		u.webhookDispatcher.Dispatch(ctx, model.WebhookEventUserCreated, dmUser)
+	}
 
 	return dmUser.ID, nil
 }
@@ -13,8 +13,10 @@ import (
 type MockDmUserService struct {
 	CreateDmUserFunc     func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error)
 	GetDmUserFunc        func(ctx context.Context, id string) (*model.DmUser, error)
-	ListDmUsersFunc      func(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+	BatchGetDmUsersFunc  func(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error)
+	ListDmUsersFunc      func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
 	UpdateDmUserFunc     func(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error)
+	PatchDmUserFunc      func(ctx context.Context, id string, patch []byte) (*model.DmUser, error)
 	DeleteDmUserFunc     func(ctx context.Context, id string) error
 	CheckEmailExistsFunc func(ctx context.Context, email string) (bool, error)
 }
@@ -33,9 +35,16 @@ func (m *MockDmUserService) GetDmUser(ctx context.Context, id string) (*model.Dm
 	return nil, nil
 }
 
-func (m *MockDmUserService) ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+func (m *MockDmUserService) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	if m.BatchGetDmUsersFunc != nil {
+		return m.BatchGetDmUsersFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockDmUserService) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 	if m.ListDmUsersFunc != nil {
-		return m.ListDmUsersFunc(ctx, limit, offset)
+		return m.ListDmUsersFunc(ctx, limit, offset, filter, sort)
 	}
 	return nil, nil
 }
@@ -47,6 +56,13 @@ func (m *MockDmUserService) UpdateDmUser(ctx context.Context, id string, req *mo
 	return nil, nil
 }
 
+func (m *MockDmUserService) PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+	if m.PatchDmUserFunc != nil {
+		return m.PatchDmUserFunc(ctx, id, patch)
+	}
+	return nil, nil
+}
+
 func (m *MockDmUserService) DeleteDmUser(ctx context.Context, id string) error {
 	if m.DeleteDmUserFunc != nil {
 		return m.DeleteDmUserFunc(ctx, id)
@@ -136,7 +152,7 @@ func TestDmUserRegisterUsecase_RegisterDmUser(t *testing.T) {
 				CreateDmUserFunc:     tt.createDmUserFunc,
 			}
 
-			u := NewDmUserRegisterUsecase(mockService)
+			u := NewDmUserRegisterUsecase(mockService, nil)
 			gotID, err := u.RegisterDmUser(context.Background(), tt.inputName, tt.inputEmail)
 
 			if tt.wantErr {
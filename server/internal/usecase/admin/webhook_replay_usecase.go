@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// WebhookReplayerInterface はWebhookDispatcherの再送用インターフェース
+type WebhookReplayerInterface interface {
+	Replay(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error)
+}
+
+// WebhookReplayUsecase はWebhook再送のビジネスロジックを担当
+type WebhookReplayUsecase struct {
+	webhookReplayer WebhookReplayerInterface
+}
+
+// NewWebhookReplayUsecase は新しいWebhookReplayUsecaseを作成
+func NewWebhookReplayUsecase(webhookReplayer WebhookReplayerInterface) *WebhookReplayUsecase {
+	return &WebhookReplayUsecase{
+		webhookReplayer: webhookReplayer,
+	}
+}
+
+// ReplayDelivery は配信ログと同じペイロードでWebhookを再送し、再送用の配信ログを返す
+func (u *WebhookReplayUsecase) ReplayDelivery(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+	if deliveryID <= 0 {
+		return nil, fmt.Errorf("配信ログIDが不正です")
+	}
+
+	return u.webhookReplayer.Replay(ctx, deliveryID)
+}
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockWebhookReplayer はWebhookReplayerInterfaceのモック
+type MockWebhookReplayer struct {
+	ReplayFunc func(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error)
+}
+
+func (m *MockWebhookReplayer) Replay(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+	return m.ReplayFunc(ctx, deliveryID)
+}
+
+func TestWebhookReplayUsecase_ReplayDelivery(t *testing.T) {
+	tests := []struct {
+		name       string
+		deliveryID int64
+		replayErr  error
+		wantErr    bool
+	}{
+		{name: "正常系", deliveryID: 10},
+		{name: "不正なID", deliveryID: 0, wantErr: true},
+		{name: "再送エラー", deliveryID: 10, replayErr: errors.New("job queue unavailable"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			u := NewWebhookReplayUsecase(&MockWebhookReplayer{
+				ReplayFunc: func(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+					called = true
+					if tt.replayErr != nil {
+						return nil, tt.replayErr
+					}
+					return &model.DmWebhookDelivery{ID: 11, ReplayOf: &deliveryID}, nil
+				},
+			})
+
+			replay, err := u.ReplayDelivery(context.Background(), tt.deliveryID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, replay)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, int64(11), replay.ID)
+			assert.Equal(t, tt.deliveryID, *replay.ReplayOf)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+	usecasejobqueue "github.com/taku-o/go-webdb-template/internal/usecase/jobqueue"
+)
+
+// 一括登録の制限値
+const (
+	BulkImportMaxRows         = 100000         // 1リクエストあたりの最大行数
+	BulkImportAsyncThreshold  = 1000           // この行数を超える場合は非同期ジョブで処理
+	BulkImportResultRetention = 24 * time.Hour // 非同期ジョブの結果保持期間
+)
+
+// 一括登録ジョブの状態
+const (
+	BulkImportJobStatusPending    = "pending"
+	BulkImportJobStatusProcessing = "processing"
+	BulkImportJobStatusCompleted  = "completed"
+	BulkImportJobStatusFailed     = "failed"
+)
+
+// ErrBulkImportEmpty は登録対象の行がない場合のエラー
+var ErrBulkImportEmpty = apperror.Validation("no rows to import")
+
+// ErrBulkImportTooManyRows は行数が上限を超えた場合のエラー
+var ErrBulkImportTooManyRows = apperror.Validation(fmt.Sprintf("too many rows: maximum is %d", BulkImportMaxRows))
+
+// DmBulkImportServiceInterface はDmBulkImportServiceのインターフェース
+type DmBulkImportServiceInterface interface {
+	ImportDmUsers(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error)
+	ImportDmPosts(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error)
+}
+
+// BulkImportOutcome は一括登録の受付結果
+// 同期処理の場合はResult、非同期処理の場合はJobIDが設定される
+type BulkImportOutcome struct {
+	Result *model.BulkImportResult
+	JobID  string
+}
+
+// BulkImportJob は非同期一括登録ジョブの状態
+type BulkImportJob struct {
+	JobID       string
+	Status      string
+	Result      *model.BulkImportResult
+	Error       string
+	CompletedAt *time.Time
+}
+
+// DmBulkImportUsecase は一括登録のビジネスロジックを担当するユースケース層
+type DmBulkImportUsecase struct {
+	dmBulkImportService DmBulkImportServiceInterface
+	jobQueueClient      JobQueueClientInterface
+	jobInspector        JobInspectorInterface
+	webhookDispatcher   WebhookDispatcherInterface
+}
+
+// NewDmBulkImportUsecase は新しいDmBulkImportUsecaseを作成
+// jobQueueClient/jobInspectorがnilの場合、非同期処理は利用できない
+// webhookDispatcherがnilの場合、同期処理で登録したユーザー・投稿のWebhookは送信しない（非同期処理ではJobQueueサーバーが送信する）
+func NewDmBulkImportUsecase(dmBulkImportService DmBulkImportServiceInterface, jobQueueClient JobQueueClientInterface, jobInspector JobInspectorInterface, webhookDispatcher WebhookDispatcherInterface) *DmBulkImportUsecase {
+	return &DmBulkImportUsecase{
+		dmBulkImportService: dmBulkImportService,
+		jobQueueClient:      jobQueueClient,
+		jobInspector:        jobInspector,
+		webhookDispatcher:   webhookDispatcher,
+	}
+}
+
+// ImportDmUsers はユーザーを一括登録
+func (u *DmBulkImportUsecase) ImportDmUsers(ctx context.Context, rows []json.RawMessage) (*BulkImportOutcome, error) {
+	return u.importRows(ctx, usecasejobqueue.BulkImportTargetDmUsers, rows)
+}
+
+// ImportDmPosts は投稿を一括登録
+func (u *DmBulkImportUsecase) ImportDmPosts(ctx context.Context, rows []json.RawMessage) (*BulkImportOutcome, error) {
+	return u.importRows(ctx, usecasejobqueue.BulkImportTargetDmPosts, rows)
+}
+
+// importRows は行数に応じて同期処理または非同期ジョブ登録を行う
+// 複数のユーザーのデータを登録するため、Auth0のユーザーは管理者ロールが必要
+func (u *DmBulkImportUsecase) importRows(ctx context.Context, target string, rows []json.RawMessage) (*BulkImportOutcome, error) {
+	if err := auth.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrBulkImportEmpty
+	}
+	if len(rows) > BulkImportMaxRows {
+		return nil, ErrBulkImportTooManyRows
+	}
+
+	// 閾値以下は同期処理
+	if len(rows) <= BulkImportAsyncThreshold {
+		var result *model.BulkImportResult
+		var err error
+		if target == usecasejobqueue.BulkImportTargetDmUsers {
+			result, err = u.dmBulkImportService.ImportDmUsers(ctx, rows)
+		} else {
+			result, err = u.dmBulkImportService.ImportDmPosts(ctx, rows)
+		}
+		if err != nil {
+			return nil, err
+		}
+		usecasejobqueue.DispatchBulkImportWebhooks(ctx, u.webhookDispatcher, result)
+		return &BulkImportOutcome{Result: result}, nil
+	}
+
+	// 閾値を超える場合は非同期ジョブとして登録
+	if u.jobQueueClient == nil {
+		return nil, ErrJobQueueUnavailable
+	}
+
+	payloadBytes, err := json.Marshal(jobqueue.BulkImportPayload{
+		Target: target,
+		Rows:   rows,
+		Owner:  auth.JobOwner(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := u.jobQueueClient.EnqueueJob(ctx, jobqueue.JobTypeBulkImport, payloadBytes, &JobOptions{
+		ProcessImmediately: true,
+		Retention:          BulkImportResultRetention,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkImportOutcome{JobID: info.ID}, nil
+}
+
+// GetImportJob は非同期一括登録ジョブの状態を取得
+// ジョブを登録した呼び出し元のみ参照できる（管理者ロールはすべて参照できる）
+func (u *DmBulkImportUsecase) GetImportJob(ctx context.Context, id string) (*BulkImportJob, error) {
+	if u.jobInspector == nil {
+		return nil, ErrJobQueueUnavailable
+	}
+
+	status, err := u.jobInspector.GetJobInfo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 一括登録以外のジョブは参照させない
+	if status.Type != jobqueue.JobTypeBulkImport {
+		return nil, ErrJobNotFound
+	}
+	if err := auth.AuthorizeJobOwner(ctx, jobOwner(status.Payload)); err != nil {
+		return nil, err
+	}
+
+	job := &BulkImportJob{
+		JobID: status.ID,
+	}
+	switch status.State {
+	case "completed":
+		job.Status = BulkImportJobStatusCompleted
+		if len(status.Result) > 0 {
+			var result model.BulkImportResult
+			if err := json.Unmarshal(status.Result, &result); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal job result: %w", err)
+			}
+			job.Result = &result
+		}
+		if !status.CompletedAt.IsZero() {
+			completedAt := status.CompletedAt
+			job.CompletedAt = &completedAt
+		}
+	case "archived":
+		job.Status = BulkImportJobStatusFailed
+		job.Error = status.LastError
+	case "active", "retry", "aggregating":
+		job.Status = BulkImportJobStatusProcessing
+	default:
+		job.Status = BulkImportJobStatusPending
+	}
+
+	return job, nil
+}
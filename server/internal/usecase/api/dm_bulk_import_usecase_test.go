@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+)
+
+// MockDmBulkImportService はDmBulkImportServiceのモック
+type MockDmBulkImportService struct {
+	ImportDmUsersFunc func(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error)
+	ImportDmPostsFunc func(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error)
+}
+
+func (m *MockDmBulkImportService) ImportDmUsers(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	if m.ImportDmUsersFunc != nil {
+		return m.ImportDmUsersFunc(ctx, rows)
+	}
+	return model.NewBulkImportResult(len(rows)), nil
+}
+
+func (m *MockDmBulkImportService) ImportDmPosts(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	if m.ImportDmPostsFunc != nil {
+		return m.ImportDmPostsFunc(ctx, rows)
+	}
+	return model.NewBulkImportResult(len(rows)), nil
+}
+
+// MockJobInspector はJobInspectorのモック
+type MockJobInspector struct {
+	GetJobInfoFunc func(ctx context.Context, id string) (*JobStatus, error)
+}
+
+func (m *MockJobInspector) GetJobInfo(ctx context.Context, id string) (*JobStatus, error) {
+	return m.GetJobInfoFunc(ctx, id)
+}
+
+func makeRows(n int) []json.RawMessage {
+	rows := make([]json.RawMessage, n)
+	for i := range rows {
+		rows[i] = json.RawMessage(`{}`)
+	}
+	return rows
+}
+
+func TestDmBulkImportUsecase_ImportDmUsers_Sync(t *testing.T) {
+	called := false
+	createdUser := &model.DmUser{ID: "u1"}
+	svc := &MockDmBulkImportService{
+		ImportDmUsersFunc: func(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+			called = true
+			result := model.NewBulkImportResult(len(rows))
+			result.CreatedDmUsers = []*model.DmUser{createdUser}
+			return result, nil
+		},
+	}
+	dispatcher := &MockWebhookDispatcher{}
+	usecase := NewDmBulkImportUsecase(svc, nil, nil, dispatcher)
+
+	outcome, err := usecase.ImportDmUsers(context.Background(), makeRows(3))
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.Empty(t, outcome.JobID)
+	assert.Equal(t, 3, outcome.Result.Total)
+
+	// 登録したユーザーごとにuser.createdのWebhookを送信する
+	assert.Equal(t, []string{model.WebhookEventUserCreated}, dispatcher.Events)
+	assert.Equal(t, []interface{}{createdUser}, dispatcher.Data)
+}
+
+func TestDmBulkImportUsecase_ImportDmPosts_Async(t *testing.T) {
+	var gotType string
+	var gotOpts *JobOptions
+	var gotPayload jobqueue.BulkImportPayload
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+			gotType = jobType
+			gotOpts = opts
+			require.NoError(t, json.Unmarshal(payload, &gotPayload))
+			return &JobInfo{ID: "job-1"}, nil
+		},
+	}
+	usecase := NewDmBulkImportUsecase(&MockDmBulkImportService{}, client, nil, nil)
+
+	outcome, err := usecase.ImportDmPosts(context.Background(), makeRows(BulkImportAsyncThreshold+1))
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", outcome.JobID)
+	assert.Nil(t, outcome.Result)
+	assert.Equal(t, jobqueue.JobTypeBulkImport, gotType)
+	assert.True(t, gotOpts.ProcessImmediately)
+	assert.Equal(t, BulkImportResultRetention, gotOpts.Retention)
+	assert.Equal(t, "dm_posts", gotPayload.Target)
+	assert.Len(t, gotPayload.Rows, BulkImportAsyncThreshold+1)
+}
+
+func TestDmBulkImportUsecase_ImportDmUsers_Errors(t *testing.T) {
+	usecase := NewDmBulkImportUsecase(&MockDmBulkImportService{}, nil, nil, nil)
+	ctx := context.Background()
+
+	_, err := usecase.ImportDmUsers(ctx, nil)
+	assert.ErrorIs(t, err, ErrBulkImportEmpty)
+
+	_, err = usecase.ImportDmUsers(ctx, makeRows(BulkImportMaxRows+1))
+	assert.ErrorIs(t, err, ErrBulkImportTooManyRows)
+
+	// 非同期処理が必要だがジョブキューが利用できない
+	_, err = usecase.ImportDmUsers(ctx, makeRows(BulkImportAsyncThreshold+1))
+	assert.ErrorIs(t, err, ErrJobQueueUnavailable)
+}
+
+func TestDmBulkImportUsecase_GetImportJob(t *testing.T) {
+	completedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	resultBytes, _ := json.Marshal(model.NewBulkImportResult(2))
+
+	tests := []struct {
+		name       string
+		status     *JobStatus
+		statusErr  error
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:       "completed",
+			status:     &JobStatus{ID: "job-1", Type: jobqueue.JobTypeBulkImport, State: "completed", Result: resultBytes, CompletedAt: completedAt},
+			wantStatus: BulkImportJobStatusCompleted,
+		},
+		{
+			name:       "failed",
+			status:     &JobStatus{ID: "job-1", Type: jobqueue.JobTypeBulkImport, State: "archived", LastError: "boom"},
+			wantStatus: BulkImportJobStatusFailed,
+		},
+		{
+			name:       "processing",
+			status:     &JobStatus{ID: "job-1", Type: jobqueue.JobTypeBulkImport, State: "active"},
+			wantStatus: BulkImportJobStatusProcessing,
+		},
+		{
+			name:       "pending",
+			status:     &JobStatus{ID: "job-1", Type: jobqueue.JobTypeBulkImport, State: "pending"},
+			wantStatus: BulkImportJobStatusPending,
+		},
+		{
+			name:    "other job type is not found",
+			status:  &JobStatus{ID: "job-1", Type: jobqueue.JobTypeDelayPrint, State: "completed"},
+			wantErr: ErrJobNotFound,
+		},
+		{
+			name:      "not found",
+			statusErr: ErrJobNotFound,
+			wantErr:   ErrJobNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspector := &MockJobInspector{
+				GetJobInfoFunc: func(ctx context.Context, id string) (*JobStatus, error) {
+					return tt.status, tt.statusErr
+				},
+			}
+			usecase := NewDmBulkImportUsecase(&MockDmBulkImportService{}, nil, inspector, nil)
+
+			job, err := usecase.GetImportJob(context.Background(), "job-1")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, job.Status)
+			if tt.wantStatus == BulkImportJobStatusCompleted {
+				require.NotNil(t, job.Result)
+				assert.Equal(t, 2, job.Result.Total)
+				assert.Equal(t, completedAt, *job.CompletedAt)
+			}
+			if tt.wantStatus == BulkImportJobStatusFailed {
+				assert.Equal(t, "boom", job.Error)
+			}
+		})
+	}
+}
+
+func TestDmBulkImportUsecase_GetImportJob_Owner(t *testing.T) {
+	var payload []byte
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, p []byte, opts *JobOptions) (*JobInfo, error) {
+			payload = p
+			return &JobInfo{ID: "job-1"}, nil
+		},
+	}
+	inspector := &MockJobInspector{
+		GetJobInfoFunc: func(ctx context.Context, id string) (*JobStatus, error) {
+			return &JobStatus{ID: "job-1", Type: jobqueue.JobTypeBulkImport, State: "pending", Payload: payload}, nil
+		},
+	}
+	usecase := NewDmBulkImportUsecase(&MockDmBulkImportService{}, client, inspector, nil)
+
+	owner := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "key-1"})
+	_, err := usecase.ImportDmUsers(owner, makeRows(BulkImportAsyncThreshold+1))
+	require.NoError(t, err)
+
+	job, err := usecase.GetImportJob(owner, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, BulkImportJobStatusPending, job.Status)
+
+	other := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeAPIKey, KeyID: "key-2"})
+	_, err = usecase.GetImportJob(other, "job-1")
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Roles: []string{auth.RoleAdmin}})
+	_, err = usecase.GetImportJob(admin, "job-1")
+	assert.NoError(t, err)
+}
+
+func TestDmBulkImportUsecase_GetImportJob_Unavailable(t *testing.T) {
+	usecase := NewDmBulkImportUsecase(&MockDmBulkImportService{}, nil, nil, nil)
+
+	_, err := usecase.GetImportJob(context.Background(), "job-1")
+	assert.ErrorIs(t, err, ErrJobQueueUnavailable)
+}
+
+func TestDmBulkImportUsecase_RequiresAdmin(t *testing.T) {
+	u := NewDmBulkImportUsecase(&MockDmBulkImportService{}, nil, nil, nil)
+
+	user := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, UserID: "user-001"})
+	_, err := u.ImportDmPosts(user, makeRows(1))
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Roles: []string{auth.RoleAdmin}})
+	_, err = u.ImportDmPosts(admin, makeRows(1))
+	assert.NoError(t, err)
+}
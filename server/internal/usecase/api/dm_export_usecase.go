@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+	"github.com/taku-o/go-webdb-template/internal/storage"
+)
+
+// ExportMaxRetry はエクスポートジョブの最大リトライ回数
+const ExportMaxRetry = 3
+
+// エクスポートジョブの状態
+const (
+	ExportJobStatusPending    = "pending"
+	ExportJobStatusProcessing = "processing"
+	ExportJobStatusCompleted  = "completed"
+	ExportJobStatusFailed     = "failed"
+)
+
+// ErrInvalidExportRequest はエクスポートリクエストが不正な場合のエラー
+var ErrInvalidExportRequest = service.ErrInvalidExportRequest
+
+// ErrExportDownloadNotSupported はストレージがダウンロード配信に対応していない場合のエラー
+var ErrExportDownloadNotSupported = service.ErrExportDownloadNotSupported
+
+// ErrInvalidDownloadSignature はダウンロードURLの署名が不正または期限切れの場合のエラー
+var ErrInvalidDownloadSignature = storage.ErrInvalidDownloadSignature
+
+// DmExportServiceInterface はDmExportServiceのインターフェース
+type DmExportServiceInterface interface {
+	ValidateRequest(req *model.ExportRequest) error
+	DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error)
+	OpenDownload(key string, fileName string, expires string, signature string) (*os.File, error)
+}
+
+// ExportJob はエクスポートジョブの状態
+// 完了時のみDownloadURL・ExpiresAtが設定される
+type ExportJob struct {
+	JobID       string
+	Status      string
+	Progress    *model.ExportProgress
+	FileName    string
+	Size        int64
+	DownloadURL string
+	ExpiresAt   *time.Time
+	Error       string
+	CompletedAt *time.Time
+}
+
+// DmExportUsecase はエクスポートのビジネスロジックを担当するユースケース層
+type DmExportUsecase struct {
+	dmExportService DmExportServiceInterface
+	jobQueueClient  JobQueueClientInterface
+	jobInspector    JobInspectorInterface
+	exportConfig    *config.ExportConfig
+}
+
+// NewDmExportUsecase は新しいDmExportUsecaseを作成
+// jobQueueClient/jobInspectorがnilの場合、エクスポートジョブは利用できない
+func NewDmExportUsecase(dmExportService DmExportServiceInterface, jobQueueClient JobQueueClientInterface, jobInspector JobInspectorInterface, exportConfig *config.ExportConfig) *DmExportUsecase {
+	return &DmExportUsecase{
+		dmExportService: dmExportService,
+		jobQueueClient:  jobQueueClient,
+		jobInspector:    jobInspector,
+		exportConfig:    exportConfig,
+	}
+}
+
+// RequestExport はリクエストを検証し、エクスポートジョブを登録してジョブIDを返す
+func (u *DmExportUsecase) RequestExport(ctx context.Context, req *model.ExportRequest) (string, error) {
+	if err := u.dmExportService.ValidateRequest(req); err != nil {
+		return "", err
+	}
+	if u.jobQueueClient == nil {
+		return "", ErrJobQueueUnavailable
+	}
+
+	payloadBytes, err := json.Marshal(jobqueue.ExportPayload{Request: *req, Owner: auth.JobOwner(ctx)})
+	if err != nil {
+		return "", err
+	}
+
+	info, err := u.jobQueueClient.EnqueueJob(ctx, jobqueue.JobTypeExport, payloadBytes, &JobOptions{
+		MaxRetry:           ExportMaxRetry,
+		ProcessImmediately: true,
+		Retention:          u.exportConfig.Retention,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return info.ID, nil
+}
+
+// GetExport はエクスポートジョブの状態を取得
+// 完了している場合は、呼び出しごとに新しい期限付きダウンロードURLを発行する
+// ジョブを登録した呼び出し元のみ参照できる（管理者ロールはすべて参照できる）
+func (u *DmExportUsecase) GetExport(ctx context.Context, id string) (*ExportJob, error) {
+	if u.jobInspector == nil {
+		return nil, ErrJobQueueUnavailable
+	}
+
+	status, err := u.jobInspector.GetJobInfo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// エクスポート以外のジョブは参照させない
+	if status.Type != jobqueue.JobTypeExport {
+		return nil, ErrJobNotFound
+	}
+	if err := auth.AuthorizeJobOwner(ctx, jobOwner(status.Payload)); err != nil {
+		return nil, err
+	}
+
+	// 処理中は進捗、完了時は最終結果がジョブ結果に保存されている
+	var result model.ExportResult
+	if len(status.Result) > 0 {
+		if err := json.Unmarshal(status.Result, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job result: %w", err)
+		}
+	}
+
+	job := &ExportJob{
+		JobID: status.ID,
+	}
+	if len(status.Result) > 0 {
+		progress := result.Progress
+		job.Progress = &progress
+	}
+
+	switch status.State {
+	case "completed":
+		job.Status = ExportJobStatusCompleted
+		job.FileName = result.FileName
+		job.Size = result.Size
+		if result.FileKey != "" {
+			downloadURL, expiresAt, err := u.dmExportService.DownloadURL(ctx, result.FileKey, result.FileName, u.exportConfig.DownloadURLTTL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create download URL: %w", err)
+			}
+			job.DownloadURL = downloadURL
+			job.ExpiresAt = &expiresAt
+		}
+		if !status.CompletedAt.IsZero() {
+			completedAt := status.CompletedAt
+			job.CompletedAt = &completedAt
+		}
+	case "archived":
+		job.Status = ExportJobStatusFailed
+		job.Error = status.LastError
+	case "active", "retry", "aggregating":
+		job.Status = ExportJobStatusProcessing
+	default:
+		job.Status = ExportJobStatusPending
+	}
+
+	return job, nil
+}
+
+// OpenDownload は署名付きダウンロードURLのパラメータを検証し、エクスポートファイルを開く
+func (u *DmExportUsecase) OpenDownload(key string, fileName string, expires string, signature string) (*os.File, error) {
+	return u.dmExportService.OpenDownload(key, fileName, expires, signature)
+}
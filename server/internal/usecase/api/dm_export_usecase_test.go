@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+)
+
+// MockDmExportService はDmExportServiceのモック
+type MockDmExportService struct {
+	ValidateErr error
+	DownloadKey string
+}
+
+func (m *MockDmExportService) ValidateRequest(req *model.ExportRequest) error {
+	if m.ValidateErr != nil {
+		return m.ValidateErr
+	}
+	if len(req.Fields) == 0 {
+		req.Fields = []string{"id"}
+	}
+	return nil
+}
+
+func (m *MockDmExportService) DownloadURL(ctx context.Context, key string, fileName string, ttl time.Duration) (string, time.Time, error) {
+	m.DownloadKey = key
+	return "https://example.com/" + key, time.Now().Add(ttl), nil
+}
+
+func (m *MockDmExportService) OpenDownload(key string, fileName string, expires string, signature string) (*os.File, error) {
+	return nil, errors.New("not supported")
+}
+
+func testExportConfig() *config.ExportConfig {
+	return &config.ExportConfig{
+		KeyPrefix:      "exports",
+		DownloadURLTTL: 15 * time.Minute,
+		Retention:      24 * time.Hour,
+	}
+}
+
+func TestDmExportUsecase_RequestExport(t *testing.T) {
+	var gotType string
+	var gotOpts *JobOptions
+	var gotPayload jobqueue.ExportPayload
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+			gotType = jobType
+			gotOpts = opts
+			require.NoError(t, json.Unmarshal(payload, &gotPayload))
+			return &JobInfo{ID: "job-1"}, nil
+		},
+	}
+	usecase := NewDmExportUsecase(&MockDmExportService{}, client, nil, testExportConfig())
+
+	jobID, err := usecase.RequestExport(context.Background(), &model.ExportRequest{
+		Target: model.ExportTargetDmUsers,
+		Format: model.ExportFormatCSV,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "job-1", jobID)
+	assert.Equal(t, jobqueue.JobTypeExport, gotType)
+	assert.True(t, gotOpts.ProcessImmediately)
+	assert.Equal(t, ExportMaxRetry, gotOpts.MaxRetry)
+	assert.Equal(t, 24*time.Hour, gotOpts.Retention)
+	// 検証で補完されたフィールドがペイロードに含まれる
+	assert.Equal(t, []string{"id"}, gotPayload.Request.Fields)
+}
+
+func TestDmExportUsecase_RequestExport_Errors(t *testing.T) {
+	validateErr := errors.New("invalid export request")
+
+	usecase := NewDmExportUsecase(&MockDmExportService{ValidateErr: validateErr}, &MockJobQueueClient{}, nil, testExportConfig())
+	_, err := usecase.RequestExport(context.Background(), &model.ExportRequest{})
+	assert.ErrorIs(t, err, validateErr)
+
+	usecase = NewDmExportUsecase(&MockDmExportService{}, nil, nil, testExportConfig())
+	_, err = usecase.RequestExport(context.Background(), &model.ExportRequest{})
+	assert.ErrorIs(t, err, ErrJobQueueUnavailable)
+}
+
+func TestDmExportUsecase_GetExport(t *testing.T) {
+	completedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	completedResult, _ := json.Marshal(model.ExportResult{
+		Progress:  model.ExportProgress{Processed: 10, Total: 10},
+		Completed: true,
+		FileKey:   "exports/job-1.csv",
+		FileName:  "dm_users.csv",
+		Size:      100,
+	})
+	progressResult, _ := json.Marshal(model.ExportResult{
+		Progress: model.ExportProgress{Processed: 5, Total: 10},
+	})
+
+	tests := []struct {
+		name         string
+		status       *JobStatus
+		wantStatus   string
+		wantProgress int64
+	}{
+		{
+			name:         "完了",
+			status:       &JobStatus{ID: "job-1", Type: jobqueue.JobTypeExport, State: "completed", Result: completedResult, CompletedAt: completedAt},
+			wantStatus:   ExportJobStatusCompleted,
+			wantProgress: 10,
+		},
+		{
+			name:         "処理中は進捗を返す",
+			status:       &JobStatus{ID: "job-1", Type: jobqueue.JobTypeExport, State: "active", Result: progressResult},
+			wantStatus:   ExportJobStatusProcessing,
+			wantProgress: 5,
+		},
+		{
+			name:       "失敗",
+			status:     &JobStatus{ID: "job-1", Type: jobqueue.JobTypeExport, State: "archived", LastError: "db error"},
+			wantStatus: ExportJobStatusFailed,
+		},
+		{
+			name:       "待機中",
+			status:     &JobStatus{ID: "job-1", Type: jobqueue.JobTypeExport, State: "pending"},
+			wantStatus: ExportJobStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &MockDmExportService{}
+			inspector := &MockJobInspector{
+				GetJobInfoFunc: func(ctx context.Context, id string) (*JobStatus, error) {
+					return tt.status, nil
+				},
+			}
+			usecase := NewDmExportUsecase(svc, nil, inspector, testExportConfig())
+
+			job, err := usecase.GetExport(context.Background(), "job-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, job.Status)
+
+			if tt.wantProgress > 0 {
+				require.NotNil(t, job.Progress)
+				assert.Equal(t, tt.wantProgress, job.Progress.Processed)
+			}
+			switch tt.wantStatus {
+			case ExportJobStatusCompleted:
+				assert.Equal(t, "exports/job-1.csv", svc.DownloadKey)
+				assert.Equal(t, "https://example.com/exports/job-1.csv", job.DownloadURL)
+				require.NotNil(t, job.ExpiresAt)
+				assert.Equal(t, "dm_users.csv", job.FileName)
+				require.NotNil(t, job.CompletedAt)
+			case ExportJobStatusFailed:
+				assert.Equal(t, "db error", job.Error)
+			default:
+				assert.Empty(t, job.DownloadURL)
+			}
+		})
+	}
+}
+
+func TestDmExportUsecase_GetExport_OtherJobType(t *testing.T) {
+	inspector := &MockJobInspector{
+		GetJobInfoFunc: func(ctx context.Context, id string) (*JobStatus, error) {
+			return &JobStatus{ID: id, Type: jobqueue.JobTypeBulkImport, State: "completed"}, nil
+		},
+	}
+	usecase := NewDmExportUsecase(&MockDmExportService{}, nil, inspector, testExportConfig())
+
+	_, err := usecase.GetExport(context.Background(), "job-1")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestDmExportUsecase_GetExport_Owner(t *testing.T) {
+	var payload []byte
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, p []byte, opts *JobOptions) (*JobInfo, error) {
+			payload = p
+			return &JobInfo{ID: "job-1"}, nil
+		},
+	}
+	inspector := &MockJobInspector{
+		GetJobInfoFunc: func(ctx context.Context, id string) (*JobStatus, error) {
+			return &JobStatus{ID: id, Type: jobqueue.JobTypeExport, State: "pending", Payload: payload}, nil
+		},
+	}
+	usecase := NewDmExportUsecase(&MockDmExportService{}, client, inspector, testExportConfig())
+
+	owner := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Issuer: "iss", Subject: "sub-1"})
+	_, err := usecase.RequestExport(owner, &model.ExportRequest{Target: model.ExportTargetDmUsers, Format: model.ExportFormatCSV})
+	require.NoError(t, err)
+
+	job, err := usecase.GetExport(owner, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, ExportJobStatusPending, job.Status)
+
+	other := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Issuer: "iss", Subject: "sub-2"})
+	_, err = usecase.GetExport(other, "job-1")
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "sub-2", Roles: []string{auth.RoleAdmin}})
+	_, err = usecase.GetExport(admin, "job-1")
+	assert.NoError(t, err)
+}
+
+func TestDmExportUsecase_GetExport_Unavailable(t *testing.T) {
+	usecase := NewDmExportUsecase(&MockDmExportService{}, nil, nil, testExportConfig())
+
+	_, err := usecase.GetExport(context.Background(), "job-1")
+	assert.ErrorIs(t, err, ErrJobQueueUnavailable)
+}
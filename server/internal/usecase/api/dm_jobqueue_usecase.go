@@ -3,8 +3,9 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"errors"
+	"time"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
 	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
 )
 
@@ -13,10 +14,23 @@ type JobQueueClientInterface interface {
 	EnqueueJob(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error)
 }
 
+// JobInspectorInterface はジョブ状態の参照用インターフェース
+type JobInspectorInterface interface {
+	GetJobInfo(ctx context.Context, id string) (*JobStatus, error)
+}
+
+// ErrJobQueueUnavailable はRedis接続が利用できない場合のエラー
+var ErrJobQueueUnavailable = apperror.Unavailable("job queue service is unavailable: Redis is not connected")
+
+// ErrJobNotFound は指定されたジョブが存在しない場合のエラー
+var ErrJobNotFound = jobqueue.ErrJobNotFound
+
 // JobOptions はジョブオプション
 type JobOptions struct {
-	DelaySeconds int
-	MaxRetry     int
+	DelaySeconds       int
+	MaxRetry           int
+	ProcessImmediately bool
+	Retention          time.Duration
 }
 
 // JobInfo はジョブ情報
@@ -24,6 +38,28 @@ type JobInfo struct {
 	ID string
 }
 
+// JobStatus はジョブの状態
+type JobStatus struct {
+	ID          string
+	Type        string
+	State       string
+	Payload     []byte
+	Result      []byte
+	LastError   string
+	CompletedAt time.Time
+}
+
+// jobOwner はジョブのペイロードに記録した所有者を返す（記録がない場合は空）
+func jobOwner(payload []byte) string {
+	var owned struct {
+		Owner string `json:"owner"`
+	}
+	if err := json.Unmarshal(payload, &owned); err != nil {
+		return ""
+	}
+	return owned.Owner
+}
+
 // JobQueueClientAdapter はjobqueue.Clientをラップするアダプター
 type JobQueueClientAdapter struct {
 	client *jobqueue.Client
@@ -40,8 +76,10 @@ func NewJobQueueClientAdapter(client *jobqueue.Client) *JobQueueClientAdapter {
 // EnqueueJob はジョブをキューに登録
 func (a *JobQueueClientAdapter) EnqueueJob(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
 	jobOpts := &jobqueue.JobOptions{
-		DelaySeconds: opts.DelaySeconds,
-		MaxRetry:     opts.MaxRetry,
+		DelaySeconds:       opts.DelaySeconds,
+		MaxRetry:           opts.MaxRetry,
+		ProcessImmediately: opts.ProcessImmediately,
+		Retention:          opts.Retention,
 	}
 
 	info, err := a.client.EnqueueJob(ctx, jobType, payload, jobOpts)
@@ -52,6 +90,24 @@ func (a *JobQueueClientAdapter) EnqueueJob(ctx context.Context, jobType string,
 	return &JobInfo{ID: info.ID}, nil
 }
 
+// GetJobInfo はジョブの状態を取得
+func (a *JobQueueClientAdapter) GetJobInfo(ctx context.Context, id string) (*JobStatus, error) {
+	info, err := a.client.GetTaskInfo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobStatus{
+		ID:          info.ID,
+		Type:        info.Type,
+		State:       info.State.String(),
+		Payload:     info.Payload,
+		Result:      info.Result,
+		LastError:   info.LastErr,
+		CompletedAt: info.CompletedAt,
+	}, nil
+}
+
 // DmJobqueueUsecase はジョブキュー関連のビジネスロジックを担当するユースケース層
 type DmJobqueueUsecase struct {
 	jobQueueClient JobQueueClientInterface
@@ -68,7 +124,7 @@ func NewDmJobqueueUsecase(jobQueueClient JobQueueClientInterface) *DmJobqueueUse
 func (u *DmJobqueueUsecase) RegisterJob(ctx context.Context, message string, delaySeconds int, maxRetry int) (string, error) {
 	// Redis接続が利用できない場合のエラーハンドリング
 	if u.jobQueueClient == nil {
-		return "", errors.New("job queue service is unavailable: Redis is not connected")
+		return "", ErrJobQueueUnavailable
 	}
 
 	// メッセージの設定（デフォルト値）
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+	"github.com/taku-o/go-webdb-template/internal/validation"
+)
+
+// dmUserNameMaxLength は初回アクセス時に作成するユーザーの名前の最大文字数
+const dmUserNameMaxLength = 100
+
+// DmUserIdentityServiceInterface はDmUserIdentityServiceのインターフェース
+type DmUserIdentityServiceInterface interface {
+	GetDmUserIdentity(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error)
+	LinkDmUserIdentity(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error)
+	ListDmUserIdentities(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error)
+	UnlinkDmUserIdentity(ctx context.Context, dmUserID string, id int64) error
+}
+
+// IdentityTokenVerifier は紐づけるアカウントのJWTを検証する（auth.IssuerRegistry）
+type IdentityTokenVerifier interface {
+	VerifyToken(tokenString string) (*auth.Principal, error)
+}
+
+// DmMeUsecase は呼び出し元のユーザーとOIDCのIssuerのアカウントの紐づけを担当
+// auth.IdentityResolverとして認証ミドルウェアから初回アクセス時のユーザー作成にも使う
+type DmMeUsecase struct {
+	dmUserService     DmUserServiceInterface
+	identityService   DmUserIdentityServiceInterface
+	tokenVerifier     IdentityTokenVerifier
+	webhookDispatcher WebhookDispatcherInterface
+	cache             *identityCache
+}
+
+// NewDmMeUsecase は新しいDmMeUsecaseを作成
+// webhookDispatcherがnilの場合、Webhookは送信しない
+// ResolveUserIDの結果はissとsubごとにidentityCacheTTLの間キャッシュする（0以下の場合はキャッシュしない）
+func NewDmMeUsecase(dmUserService DmUserServiceInterface, identityService DmUserIdentityServiceInterface, tokenVerifier IdentityTokenVerifier, webhookDispatcher WebhookDispatcherInterface, identityCacheTTL time.Duration) *DmMeUsecase {
+	return &DmMeUsecase{
+		dmUserService:     dmUserService,
+		identityService:   identityService,
+		tokenVerifier:     tokenVerifier,
+		webhookDispatcher: webhookDispatcher,
+		cache:             newIdentityCache(identityCacheTTL),
+	}
+}
+
+// ResolveUserID はissとsubに紐づくdm_usersのIDを返す
+// 紐づいていない場合はJWTの名前・メールアドレスでユーザーを作成して紐づける
+// メールアドレスがない場合や、メールアドレスが既存のユーザーと同じ場合は紐づけない（空を返す。既存のユーザーへは/api/me/identitiesで紐づける）
+// 認証のたびに呼ばれるため、紐づけられなかった結果も含めてキャッシュし、ユーザー作成の試行やメールアドレスの全シャード検索を繰り返さない
+func (u *DmMeUsecase) ResolveUserID(ctx context.Context, principal *auth.Principal) (string, error) {
+	if userID, found := u.cache.get(principal.Issuer, principal.Subject); found {
+		return userID, nil
+	}
+
+	userID, err := u.resolveUserID(ctx, principal)
+	if err != nil {
+		return "", err
+	}
+	u.cache.set(principal.Issuer, principal.Subject, userID)
+	return userID, nil
+}
+
+// resolveUserID はissとsubに紐づくdm_usersのIDを取得し、紐づいていない場合はユーザーを作成して紐づける
+func (u *DmMeUsecase) resolveUserID(ctx context.Context, principal *auth.Principal) (string, error) {
+	identity, err := u.identityService.GetDmUserIdentity(ctx, principal.Issuer, principal.Subject)
+	if err == nil {
+		return identity.DmUserID, nil
+	}
+	if !errors.Is(err, service.ErrDmUserIdentityNotFound) {
+		return "", err
+	}
+
+	if principal.Email == "" {
+		return "", nil
+	}
+	exists, err := u.dmUserService.CheckEmailExists(ctx, principal.Email)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", nil
+	}
+
+	req := &model.CreateDmUserRequest{
+		Name:  provisionedUserName(principal),
+		Email: principal.Email,
+	}
+	dmUser, err := u.dmUserService.CreateDmUser(ctx, req)
+	if err != nil {
+		// メールアドレスの形式が不正な場合や、同時に同じメールアドレスのユーザーが作成された場合は紐づけない
+		var validationErr *validation.Error
+		if errors.As(err, &validationErr) || errors.Is(err, apperror.ErrConflict) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	identity, err = u.identityService.LinkDmUserIdentity(ctx, dmUser.ID, principal.Issuer, principal.Subject, principal.Email)
+	if err != nil {
+		// 同時のリクエストで先に紐づけられた場合は作成したユーザーを削除し、先の紐づけを使う
+		if errors.Is(err, apperror.ErrConflict) {
+			if deleteErr := u.dmUserService.DeleteDmUser(ctx, dmUser.ID); deleteErr != nil {
+				log.Printf("Failed to delete duplicated user %s: %v", dmUser.ID, deleteErr)
+			}
+			identity, err = u.identityService.GetDmUserIdentity(ctx, principal.Issuer, principal.Subject)
+			if err != nil {
+				return "", err
+			}
+			return identity.DmUserID, nil
+		}
+		return "", err
+	}
+
+	u.dispatchWebhook(ctx, model.WebhookEventUserCreated, dmUser)
+	return identity.DmUserID, nil
+}
+
+// GetDmMe は呼び出し元のユーザーと紐づくアカウントの一覧を取得
+func (u *DmMeUsecase) GetDmMe(ctx context.Context) (*model.DmMe, error) {
+	principal, err := linkedPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dmUser, err := u.dmUserService.GetDmUser(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := u.identityService.ListDmUserIdentities(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DmMe{User: dmUser, Identities: identities}, nil
+}
+
+// LinkDmMeIdentity は別のIssuerのアカウント（JWTで本人確認する）を呼び出し元のユーザーに紐づける
+// 紐づけ済みの場合は既存の紐づけを返し、他のユーザーに紐づけ済みの場合はConflictを返す
+func (u *DmMeUsecase) LinkDmMeIdentity(ctx context.Context, idToken string) (*model.DmUserIdentity, error) {
+	principal, err := linkedPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.tokenVerifier == nil {
+		return nil, apperror.Unavailable("identity linking is not configured")
+	}
+
+	other, err := u.tokenVerifier.VerifyToken(idToken)
+	if err != nil {
+		return nil, apperror.Validation("invalid identity token: " + err.Error())
+	}
+	if other.Subject == "" {
+		return nil, apperror.Validation("identity token has no subject")
+	}
+
+	identity, err := u.identityService.LinkDmUserIdentity(ctx, principal.UserID, other.Issuer, other.Subject, other.Email)
+	if err != nil {
+		return nil, err
+	}
+	u.cache.delete(other.Issuer, other.Subject)
+	return identity, nil
+}
+
+// UnlinkDmMeIdentity は呼び出し元のユーザーに紐づくアカウントの紐づけを解除（使用中のアカウントは解除できない）
+func (u *DmMeUsecase) UnlinkDmMeIdentity(ctx context.Context, id int64) error {
+	principal, err := linkedPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	identities, err := u.identityService.ListDmUserIdentities(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+	var unlinked *model.DmUserIdentity
+	for _, identity := range identities {
+		if identity.ID == id && identity.Issuer == principal.Issuer && identity.Subject == principal.Subject {
+			return apperror.Conflict("cannot unlink the identity in use")
+		}
+		if identity.ID == id {
+			unlinked = identity
+		}
+	}
+
+	if err := u.identityService.UnlinkDmUserIdentity(ctx, principal.UserID, id); err != nil {
+		return err
+	}
+	if unlinked != nil {
+		u.cache.delete(unlinked.Issuer, unlinked.Subject)
+	}
+	return nil
+}
+
+// dispatchWebhook はWebhookの配信を依頼（webhookDispatcherが未設定の場合は何もしない）
+func (u *DmMeUsecase) dispatchWebhook(ctx context.Context, event string, data interface{}) {
+	if u.webhookDispatcher == nil {
+		return
+	}
+	u.webhookDispatcher.Dispatch(ctx, event, data)
+}
+
+// linkedPrincipal はdm_usersに紐づいたユーザーの呼び出し元を取得
+func linkedPrincipal(ctx context.Context) (*auth.Principal, error) {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || principal.Type != auth.PrincipalTypeUser {
+		return nil, apperror.Forbidden("user authentication required")
+	}
+	if principal.UserID == "" {
+		return nil, apperror.Forbidden("caller is not linked to a user")
+	}
+	return principal, nil
+}
+
+// provisionedUserName は初回アクセス時に作成するユーザーの名前（名前のクレームがない場合はメールアドレスの@より前）
+func provisionedUserName(principal *auth.Principal) string {
+	name := strings.TrimSpace(principal.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(principal.Email, "@")
+	}
+	if runes := []rune(name); len(runes) > dmUserNameMaxLength {
+		name = string(runes[:dmUserNameMaxLength])
+	}
+	return name
+}
@@ -0,0 +1,342 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// MockDmUserIdentityService はDmUserIdentityServiceのモック
+type MockDmUserIdentityService struct {
+	GetDmUserIdentityFunc    func(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error)
+	LinkDmUserIdentityFunc   func(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error)
+	ListDmUserIdentitiesFunc func(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error)
+	UnlinkDmUserIdentityFunc func(ctx context.Context, dmUserID string, id int64) error
+}
+
+func (m *MockDmUserIdentityService) GetDmUserIdentity(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+	if m.GetDmUserIdentityFunc != nil {
+		return m.GetDmUserIdentityFunc(ctx, issuer, subject)
+	}
+	return nil, fmt.Errorf("%w: %s %s", service.ErrDmUserIdentityNotFound, issuer, subject)
+}
+
+func (m *MockDmUserIdentityService) LinkDmUserIdentity(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error) {
+	if m.LinkDmUserIdentityFunc != nil {
+		return m.LinkDmUserIdentityFunc(ctx, dmUserID, issuer, subject, email)
+	}
+	return &model.DmUserIdentity{ID: 1, Issuer: issuer, Subject: subject, DmUserID: dmUserID, Email: email}, nil
+}
+
+func (m *MockDmUserIdentityService) ListDmUserIdentities(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+	if m.ListDmUserIdentitiesFunc != nil {
+		return m.ListDmUserIdentitiesFunc(ctx, dmUserID)
+	}
+	return nil, nil
+}
+
+func (m *MockDmUserIdentityService) UnlinkDmUserIdentity(ctx context.Context, dmUserID string, id int64) error {
+	if m.UnlinkDmUserIdentityFunc != nil {
+		return m.UnlinkDmUserIdentityFunc(ctx, dmUserID, id)
+	}
+	return nil
+}
+
+// MockIdentityTokenVerifier はIdentityTokenVerifierのモック
+type MockIdentityTokenVerifier struct {
+	VerifyTokenFunc func(tokenString string) (*auth.Principal, error)
+}
+
+func (m *MockIdentityTokenVerifier) VerifyToken(tokenString string) (*auth.Principal, error) {
+	if m.VerifyTokenFunc != nil {
+		return m.VerifyTokenFunc(tokenString)
+	}
+	return nil, errors.New("invalid token")
+}
+
+// testUserPrincipal はdm_usersに紐づいたユーザーのコンテキストを作成
+func testUserPrincipal(userID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{
+		Issuer:  "https://example.auth0.com/",
+		Subject: "auth0|123",
+		Type:    auth.PrincipalTypeUser,
+		UserID:  userID,
+	})
+}
+
+func TestDmMeUsecase_ResolveUserID(t *testing.T) {
+	principal := &auth.Principal{Issuer: "https://example.auth0.com/", Subject: "auth0|123", Type: auth.PrincipalTypeUser, Name: "Alice", Email: "alice@example.com"}
+
+	t.Run("linked identity", func(t *testing.T) {
+		identityService := &MockDmUserIdentityService{
+			GetDmUserIdentityFunc: func(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+				return &model.DmUserIdentity{DmUserID: "user1"}, nil
+			},
+		}
+		dmUserService := &MockDmUserService{
+			CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+				t.Fatal("CreateDmUser should not be called")
+				return nil, nil
+			},
+		}
+		u := NewDmMeUsecase(dmUserService, identityService, nil, nil, 0)
+
+		userID, err := u.ResolveUserID(context.Background(), principal)
+		require.NoError(t, err)
+		assert.Equal(t, "user1", userID)
+	})
+
+	t.Run("provisions a new user", func(t *testing.T) {
+		var created *model.CreateDmUserRequest
+		var linked []string
+		dmUserService := &MockDmUserService{
+			CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+				created = req
+				return &model.DmUser{ID: "user1", Name: req.Name, Email: req.Email}, nil
+			},
+		}
+		identityService := &MockDmUserIdentityService{
+			LinkDmUserIdentityFunc: func(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error) {
+				linked = []string{dmUserID, issuer, subject, email}
+				return &model.DmUserIdentity{DmUserID: dmUserID}, nil
+			},
+		}
+		dispatcher := &MockWebhookDispatcher{}
+		u := NewDmMeUsecase(dmUserService, identityService, nil, dispatcher, 0)
+
+		userID, err := u.ResolveUserID(context.Background(), principal)
+		require.NoError(t, err)
+		assert.Equal(t, "user1", userID)
+		assert.Equal(t, &model.CreateDmUserRequest{Name: "Alice", Email: "alice@example.com"}, created)
+		assert.Equal(t, []string{"user1", "https://example.auth0.com/", "auth0|123", "alice@example.com"}, linked)
+		assert.Equal(t, []string{model.WebhookEventUserCreated}, dispatcher.Events)
+	})
+
+	t.Run("name falls back to email", func(t *testing.T) {
+		var created *model.CreateDmUserRequest
+		dmUserService := &MockDmUserService{
+			CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+				created = req
+				return &model.DmUser{ID: "user1"}, nil
+			},
+		}
+		u := NewDmMeUsecase(dmUserService, &MockDmUserIdentityService{}, nil, nil, 0)
+
+		_, err := u.ResolveUserID(context.Background(), &auth.Principal{Issuer: principal.Issuer, Subject: principal.Subject, Email: "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "bob", created.Name)
+	})
+
+	t.Run("not provisioned", func(t *testing.T) {
+		dmUserService := &MockDmUserService{
+			CheckEmailExistsFunc: func(ctx context.Context, email string) (bool, error) {
+				return email == "alice@example.com", nil
+			},
+			CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+				t.Fatal("CreateDmUser should not be called")
+				return nil, nil
+			},
+		}
+		u := NewDmMeUsecase(dmUserService, &MockDmUserIdentityService{}, nil, nil, 0)
+
+		// メールアドレスが既存のユーザーと同じ場合は自動で紐づけない
+		userID, err := u.ResolveUserID(context.Background(), principal)
+		require.NoError(t, err)
+		assert.Empty(t, userID)
+
+		// メールアドレスがない場合
+		userID, err = u.ResolveUserID(context.Background(), &auth.Principal{Issuer: principal.Issuer, Subject: principal.Subject})
+		require.NoError(t, err)
+		assert.Empty(t, userID)
+	})
+
+	t.Run("concurrent link", func(t *testing.T) {
+		var deleted string
+		dmUserService := &MockDmUserService{
+			CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+				return &model.DmUser{ID: "user2"}, nil
+			},
+			DeleteDmUserFunc: func(ctx context.Context, id string) error {
+				deleted = id
+				return nil
+			},
+		}
+		lookups := 0
+		identityService := &MockDmUserIdentityService{
+			GetDmUserIdentityFunc: func(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+				lookups++
+				if lookups == 1 {
+					return nil, service.ErrDmUserIdentityNotFound
+				}
+				return &model.DmUserIdentity{DmUserID: "user1"}, nil
+			},
+			LinkDmUserIdentityFunc: func(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error) {
+				return nil, apperror.Conflict("identity already linked")
+			},
+		}
+		dispatcher := &MockWebhookDispatcher{}
+		u := NewDmMeUsecase(dmUserService, identityService, nil, dispatcher, 0)
+
+		userID, err := u.ResolveUserID(context.Background(), principal)
+		require.NoError(t, err)
+		assert.Equal(t, "user1", userID)
+		assert.Equal(t, "user2", deleted)
+		assert.Empty(t, dispatcher.Events)
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		identityService := &MockDmUserIdentityService{
+			GetDmUserIdentityFunc: func(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+				return nil, errors.New("database unavailable")
+			},
+		}
+		u := NewDmMeUsecase(&MockDmUserService{}, identityService, nil, nil, 0)
+
+		_, err := u.ResolveUserID(context.Background(), principal)
+		assert.Error(t, err)
+	})
+}
+
+func TestDmMeUsecase_ResolveUserID_Cache(t *testing.T) {
+	principal := &auth.Principal{Issuer: "https://example.auth0.com/", Subject: "auth0|123", Type: auth.PrincipalTypeUser, Email: "alice@example.com"}
+
+	checkCalls := 0
+	dmUserService := &MockDmUserService{
+		CheckEmailExistsFunc: func(ctx context.Context, email string) (bool, error) {
+			checkCalls++
+			return true, nil
+		},
+	}
+	var getErr error
+	identityService := &MockDmUserIdentityService{
+		GetDmUserIdentityFunc: func(ctx context.Context, issuer, subject string) (*model.DmUserIdentity, error) {
+			if getErr != nil {
+				return nil, getErr
+			}
+			return nil, fmt.Errorf("%w: %s %s", service.ErrDmUserIdentityNotFound, issuer, subject)
+		},
+	}
+	u := NewDmMeUsecase(dmUserService, identityService, nil, nil, time.Minute)
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	u.cache.now = func() time.Time { return now }
+
+	// 紐づけられなかった結果もキャッシュし、メールアドレスの検索を繰り返さない
+	for i := 0; i < 3; i++ {
+		userID, err := u.ResolveUserID(context.Background(), principal)
+		require.NoError(t, err)
+		assert.Empty(t, userID)
+	}
+	assert.Equal(t, 1, checkCalls)
+
+	// 期限が切れた後は再度解決する
+	now = now.Add(time.Minute)
+	_, err := u.ResolveUserID(context.Background(), principal)
+	require.NoError(t, err)
+	assert.Equal(t, 2, checkCalls)
+
+	// エラーはキャッシュしない
+	getErr = errors.New("connection refused")
+	_, err = u.ResolveUserID(context.Background(), &auth.Principal{Issuer: principal.Issuer, Subject: "auth0|456"})
+	assert.Error(t, err)
+	getErr = nil
+	_, err = u.ResolveUserID(context.Background(), &auth.Principal{Issuer: principal.Issuer, Subject: "auth0|456"})
+	assert.NoError(t, err)
+}
+
+func TestDmMeUsecase_GetDmMe(t *testing.T) {
+	dmUserService := &MockDmUserService{
+		GetDmUserFunc: func(ctx context.Context, id string) (*model.DmUser, error) {
+			return &model.DmUser{ID: id}, nil
+		},
+	}
+	identityService := &MockDmUserIdentityService{
+		ListDmUserIdentitiesFunc: func(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+			return []*model.DmUserIdentity{{ID: 1, DmUserID: dmUserID}}, nil
+		},
+	}
+	u := NewDmMeUsecase(dmUserService, identityService, nil, nil, 0)
+
+	dmMe, err := u.GetDmMe(testUserPrincipal("user1"))
+	require.NoError(t, err)
+	assert.Equal(t, "user1", dmMe.User.ID)
+	assert.Len(t, dmMe.Identities, 1)
+
+	// 紐づいていないユーザー・Public APIキー・認証なし
+	_, err = u.GetDmMe(testUserPrincipal(""))
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	_, err = u.GetDmMe(auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeAPIKey}))
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	_, err = u.GetDmMe(context.Background())
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+}
+
+func TestDmMeUsecase_LinkDmMeIdentity(t *testing.T) {
+	verifier := &MockIdentityTokenVerifier{
+		VerifyTokenFunc: func(tokenString string) (*auth.Principal, error) {
+			if tokenString != "keycloak-token" {
+				return nil, errors.New("invalid token")
+			}
+			return &auth.Principal{Issuer: "https://keycloak.example.com/realms/app", Subject: "456", Email: "alice@example.com"}, nil
+		},
+	}
+	var linked []string
+	identityService := &MockDmUserIdentityService{
+		LinkDmUserIdentityFunc: func(ctx context.Context, dmUserID, issuer, subject, email string) (*model.DmUserIdentity, error) {
+			linked = []string{dmUserID, issuer, subject, email}
+			return &model.DmUserIdentity{ID: 2, DmUserID: dmUserID}, nil
+		},
+	}
+	u := NewDmMeUsecase(&MockDmUserService{}, identityService, verifier, nil, time.Minute)
+
+	// 紐づけたアカウントの解決結果のキャッシュは削除する
+	u.cache.set("https://keycloak.example.com/realms/app", "456", "")
+
+	identity, err := u.LinkDmMeIdentity(testUserPrincipal("user1"), "keycloak-token")
+	require.NoError(t, err)
+	_, found := u.cache.get("https://keycloak.example.com/realms/app", "456")
+	assert.False(t, found)
+	assert.Equal(t, int64(2), identity.ID)
+	assert.Equal(t, []string{"user1", "https://keycloak.example.com/realms/app", "456", "alice@example.com"}, linked)
+
+	_, err = u.LinkDmMeIdentity(testUserPrincipal("user1"), "invalid")
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	_, err = u.LinkDmMeIdentity(testUserPrincipal(""), "keycloak-token")
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+}
+
+func TestDmMeUsecase_UnlinkDmMeIdentity(t *testing.T) {
+	var unlinked int64
+	identityService := &MockDmUserIdentityService{
+		ListDmUserIdentitiesFunc: func(ctx context.Context, dmUserID string) ([]*model.DmUserIdentity, error) {
+			return []*model.DmUserIdentity{
+				{ID: 1, Issuer: "https://example.auth0.com/", Subject: "auth0|123", DmUserID: dmUserID},
+				{ID: 2, Issuer: "https://keycloak.example.com/realms/app", Subject: "456", DmUserID: dmUserID},
+			}, nil
+		},
+		UnlinkDmUserIdentityFunc: func(ctx context.Context, dmUserID string, id int64) error {
+			unlinked = id
+			return nil
+		},
+	}
+	u := NewDmMeUsecase(&MockDmUserService{}, identityService, nil, nil, time.Minute)
+
+	// 使用中のアカウントは解除できない
+	err := u.UnlinkDmMeIdentity(testUserPrincipal("user1"), 1)
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+
+	u.cache.set("https://keycloak.example.com/realms/app", "456", "user1")
+	require.NoError(t, u.UnlinkDmMeIdentity(testUserPrincipal("user1"), 2))
+	assert.Equal(t, int64(2), unlinked)
+	_, found := u.cache.get("https://keycloak.example.com/realms/app", "456")
+	assert.False(t, found)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
+)
+
+// ErrInvalidFeedFormat はフィードの形式が不正な場合のエラー
+var ErrInvalidFeedFormat = service.ErrInvalidFeedFormat
+
+// DmNewsFeedServiceInterface はDmNewsFeedServiceのインターフェース
+type DmNewsFeedServiceInterface interface {
+	BuildDmNewsFeed(ctx context.Context, format string, opts *model.DmNewsFeedOptions) (*model.DmNewsFeed, error)
+}
+
+// DmNewsFeedUsecase はニュースフィードのビジネスロジックを担当
+type DmNewsFeedUsecase struct {
+	dmNewsFeedService DmNewsFeedServiceInterface
+	feedConfig        *config.FeedConfig
+}
+
+// NewDmNewsFeedUsecase は新しいDmNewsFeedUsecaseを作成
+func NewDmNewsFeedUsecase(dmNewsFeedService DmNewsFeedServiceInterface, feedConfig *config.FeedConfig) *DmNewsFeedUsecase {
+	return &DmNewsFeedUsecase{
+		dmNewsFeedService: dmNewsFeedService,
+		feedConfig:        feedConfig,
+	}
+}
+
+// GetDmNewsFeed は公開済みニュースのフィードを指定の形式（rss / atom）で取得
+func (u *DmNewsFeedUsecase) GetDmNewsFeed(ctx context.Context, format string) (*model.DmNewsFeed, error) {
+	return u.dmNewsFeedService.BuildDmNewsFeed(ctx, format, &model.DmNewsFeedOptions{
+		Title:       u.feedConfig.Title,
+		Description: u.feedConfig.Description,
+		SiteURL:     u.feedConfig.SiteURL,
+		ItemLimit:   u.feedConfig.ItemLimit,
+		MaxCacheAge: u.feedConfig.MaxCacheAge,
+	})
+}
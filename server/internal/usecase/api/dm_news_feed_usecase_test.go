@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockDmNewsFeedService はDmNewsFeedServiceのモック
+type MockDmNewsFeedService struct {
+	BuildDmNewsFeedFunc func(ctx context.Context, format string, opts *model.DmNewsFeedOptions) (*model.DmNewsFeed, error)
+}
+
+func (m *MockDmNewsFeedService) BuildDmNewsFeed(ctx context.Context, format string, opts *model.DmNewsFeedOptions) (*model.DmNewsFeed, error) {
+	if m.BuildDmNewsFeedFunc != nil {
+		return m.BuildDmNewsFeedFunc(ctx, format, opts)
+	}
+	return nil, nil
+}
+
+func TestDmNewsFeedUsecase_GetDmNewsFeed(t *testing.T) {
+	var gotFormat string
+	var gotOpts *model.DmNewsFeedOptions
+	usecase := NewDmNewsFeedUsecase(&MockDmNewsFeedService{
+		BuildDmNewsFeedFunc: func(ctx context.Context, format string, opts *model.DmNewsFeedOptions) (*model.DmNewsFeed, error) {
+			gotFormat = format
+			gotOpts = opts
+			return &model.DmNewsFeed{ETag: "etag"}, nil
+		},
+	}, &config.FeedConfig{
+		Title:       "News",
+		Description: "Description",
+		SiteURL:     "https://example.com",
+		ItemLimit:   5,
+		MaxCacheAge: 30 * time.Minute,
+	})
+
+	feed, err := usecase.GetDmNewsFeed(context.Background(), model.DmNewsFeedFormatAtom)
+	require.NoError(t, err)
+	assert.Equal(t, "etag", feed.ETag)
+	assert.Equal(t, model.DmNewsFeedFormatAtom, gotFormat)
+	assert.Equal(t, &model.DmNewsFeedOptions{
+		Title:       "News",
+		Description: "Description",
+		SiteURL:     "https://example.com",
+		ItemLimit:   5,
+		MaxCacheAge: 30 * time.Minute,
+	}, gotOpts)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// DmNewsServiceInterface はDmNewsServiceのインターフェース
+type DmNewsServiceInterface interface {
+	CreateDmNews(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error)
+	GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error)
+	ListPublishedDmNews(ctx context.Context, authorID *int64, limit, offset int) ([]*model.DmNews, error)
+	UpdateDmNews(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error)
+	PatchDmNews(ctx context.Context, id int64, patch []byte) (*model.DmNews, error)
+	DeleteDmNews(ctx context.Context, id int64) error
+}
+
+// DmNewsUsecase はdm_news関連のビジネスロジックを担当
+type DmNewsUsecase struct {
+	dmNewsService  DmNewsServiceInterface
+	streamNotifier StreamNotifierInterface
+}
+
+// NewDmNewsUsecase は新しいDmNewsUsecaseを作成
+// streamNotifierがnilの場合、SSEのイベントは送信しない
+func NewDmNewsUsecase(dmNewsService DmNewsServiceInterface, streamNotifier StreamNotifierInterface) *DmNewsUsecase {
+	return &DmNewsUsecase{
+		dmNewsService:  dmNewsService,
+		streamNotifier: streamNotifier,
+	}
+}
+
+// CreateDmNews はニュースを作成
+func (u *DmNewsUsecase) CreateDmNews(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+	dmNews, err := u.dmNewsService.CreateDmNews(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	u.notifyPublished(ctx, dmNews)
+	return dmNews, nil
+}
+
+// GetPublishedDmNews はIDで公開済みのニュースを取得
+func (u *DmNewsUsecase) GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error) {
+	return u.dmNewsService.GetPublishedDmNews(ctx, id)
+}
+
+// ListPublishedDmNews は公開済みのニュース一覧を取得
+func (u *DmNewsUsecase) ListPublishedDmNews(ctx context.Context, authorID *int64, limit, offset int) ([]*model.DmNews, error) {
+	return u.dmNewsService.ListPublishedDmNews(ctx, authorID, limit, offset)
+}
+
+// UpdateDmNews はニュースを更新
+func (u *DmNewsUsecase) UpdateDmNews(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+	dmNews, err := u.dmNewsService.UpdateDmNews(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	// 公開日時を変更した場合のみ公開イベントの対象とする
+	if req.PublishedAt != nil {
+		u.notifyPublished(ctx, dmNews)
+	}
+	return dmNews, nil
+}
+
+// PatchDmNews はJSON Merge Patchでニュースを部分更新
+func (u *DmNewsUsecase) PatchDmNews(ctx context.Context, id int64, patch []byte) (*model.DmNews, error) {
+	dmNews, err := u.dmNewsService.PatchDmNews(ctx, id, patch)
+	if err != nil {
+		return nil, err
+	}
+	// 公開日時を変更した場合のみ公開イベントの対象とする
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err == nil {
+		if _, ok := fields["published_at"]; ok {
+			u.notifyPublished(ctx, dmNews)
+		}
+	}
+	return dmNews, nil
+}
+
+// DeleteDmNews はニュースを削除
+func (u *DmNewsUsecase) DeleteDmNews(ctx context.Context, id int64) error {
+	return u.dmNewsService.DeleteDmNews(ctx, id)
+}
+
+// notifyPublished はニュースの公開をSSEの購読者に通知（streamNotifierが未設定の場合は何もしない）
+func (u *DmNewsUsecase) notifyPublished(ctx context.Context, dmNews *model.DmNews) {
+	if u.streamNotifier == nil {
+		return
+	}
+	u.streamNotifier.NewsPublished(ctx, dmNews)
+}
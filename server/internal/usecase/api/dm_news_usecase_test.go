@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockDmNewsService はDmNewsServiceのモック
+type MockDmNewsService struct {
+	CreateDmNewsFunc        func(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error)
+	GetPublishedDmNewsFunc  func(ctx context.Context, id int64) (*model.DmNews, error)
+	ListPublishedDmNewsFunc func(ctx context.Context, authorID *int64, limit, offset int) ([]*model.DmNews, error)
+	UpdateDmNewsFunc        func(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error)
+	PatchDmNewsFunc         func(ctx context.Context, id int64, patch []byte) (*model.DmNews, error)
+	DeleteDmNewsFunc        func(ctx context.Context, id int64) error
+}
+
+func (m *MockDmNewsService) CreateDmNews(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+	if m.CreateDmNewsFunc != nil {
+		return m.CreateDmNewsFunc(ctx, req)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsService) GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error) {
+	if m.GetPublishedDmNewsFunc != nil {
+		return m.GetPublishedDmNewsFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsService) ListPublishedDmNews(ctx context.Context, authorID *int64, limit, offset int) ([]*model.DmNews, error) {
+	if m.ListPublishedDmNewsFunc != nil {
+		return m.ListPublishedDmNewsFunc(ctx, authorID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsService) UpdateDmNews(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+	if m.UpdateDmNewsFunc != nil {
+		return m.UpdateDmNewsFunc(ctx, id, req)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsService) PatchDmNews(ctx context.Context, id int64, patch []byte) (*model.DmNews, error) {
+	if m.PatchDmNewsFunc != nil {
+		return m.PatchDmNewsFunc(ctx, id, patch)
+	}
+	return nil, nil
+}
+
+func (m *MockDmNewsService) DeleteDmNews(ctx context.Context, id int64) error {
+	if m.DeleteDmNewsFunc != nil {
+		return m.DeleteDmNewsFunc(ctx, id)
+	}
+	return nil
+}
+
+func TestDmNewsUsecase_CreateDmNews(t *testing.T) {
+	usecase := NewDmNewsUsecase(&MockDmNewsService{
+		CreateDmNewsFunc: func(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+			return &model.DmNews{ID: 1, Title: req.Title}, nil
+		},
+	}, nil)
+
+	got, err := usecase.CreateDmNews(context.Background(), &model.CreateDmNewsRequest{Title: "News", Content: "Content"})
+	assert.NoError(t, err)
+	assert.Equal(t, "News", got.Title)
+}
+
+func TestDmNewsUsecase_GetPublishedDmNews(t *testing.T) {
+	usecase := NewDmNewsUsecase(&MockDmNewsService{
+		GetPublishedDmNewsFunc: func(ctx context.Context, id int64) (*model.DmNews, error) {
+			return nil, errors.New("news not found: 1")
+		},
+	}, nil)
+
+	_, err := usecase.GetPublishedDmNews(context.Background(), 1)
+	assert.Error(t, err)
+}
+
+func TestDmNewsUsecase_ListPublishedDmNews(t *testing.T) {
+	var gotAuthorID *int64
+	usecase := NewDmNewsUsecase(&MockDmNewsService{
+		ListPublishedDmNewsFunc: func(ctx context.Context, authorID *int64, limit, offset int) ([]*model.DmNews, error) {
+			gotAuthorID = authorID
+			return []*model.DmNews{{ID: 1}, {ID: 2}}, nil
+		},
+	}, nil)
+
+	authorID := int64(7)
+	got, err := usecase.ListPublishedDmNews(context.Background(), &authorID, 20, 0)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, &authorID, gotAuthorID)
+}
+
+func TestDmNewsUsecase_UpdateDmNews(t *testing.T) {
+	usecase := NewDmNewsUsecase(&MockDmNewsService{
+		UpdateDmNewsFunc: func(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+			return &model.DmNews{ID: id, Title: req.Title}, nil
+		},
+	}, nil)
+
+	got, err := usecase.UpdateDmNews(context.Background(), 1, &model.UpdateDmNewsRequest{Title: "Updated"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated", got.Title)
+}
+
+func TestDmNewsUsecase_DeleteDmNews(t *testing.T) {
+	usecase := NewDmNewsUsecase(&MockDmNewsService{
+		DeleteDmNewsFunc: func(ctx context.Context, id int64) error {
+			return errors.New("service error")
+		},
+	}, nil)
+
+	assert.Error(t, usecase.DeleteDmNews(context.Background(), 1))
+}
+
+func TestDmNewsUsecase_PatchDmNews(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(ctx context.Context, id int64, patch []byte) (*model.DmNews, error)
+		wantErr  bool
+	}{
+		{
+			name: "patches news successfully",
+			mockFunc: func(ctx context.Context, id int64, patch []byte) (*model.DmNews, error) {
+				return &model.DmNews{ID: id, Title: "Patched"}, nil
+			},
+		},
+		{
+			name: "returns error when service fails",
+			mockFunc: func(ctx context.Context, id int64, patch []byte) (*model.DmNews, error) {
+				return nil, errors.New("service error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewDmNewsUsecase(&MockDmNewsService{PatchDmNewsFunc: tt.mockFunc}, nil)
+
+			got, err := usecase.PatchDmNews(context.Background(), 1, []byte(`{"title":"Patched"}`))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Patched", got.Title)
+		})
+	}
+}
+
+func TestDmNewsUsecase_StreamNotifier(t *testing.T) {
+	publishedAt := time.Now()
+	mockService := &MockDmNewsService{
+		CreateDmNewsFunc: func(ctx context.Context, req *model.CreateDmNewsRequest) (*model.DmNews, error) {
+			return &model.DmNews{ID: 1, PublishedAt: req.PublishedAt}, nil
+		},
+		UpdateDmNewsFunc: func(ctx context.Context, id int64, req *model.UpdateDmNewsRequest) (*model.DmNews, error) {
+			return &model.DmNews{ID: id, PublishedAt: &publishedAt}, nil
+		},
+		PatchDmNewsFunc: func(ctx context.Context, id int64, patch []byte) (*model.DmNews, error) {
+			return &model.DmNews{ID: id, PublishedAt: &publishedAt}, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		call       func(u *DmNewsUsecase) error
+		wantNotify bool
+	}{
+		{"create", func(u *DmNewsUsecase) error {
+			_, err := u.CreateDmNews(context.Background(), &model.CreateDmNewsRequest{Title: "News", Content: "Content", PublishedAt: &publishedAt})
+			return err
+		}, true},
+		{"update with published_at", func(u *DmNewsUsecase) error {
+			_, err := u.UpdateDmNews(context.Background(), 1, &model.UpdateDmNewsRequest{PublishedAt: &publishedAt})
+			return err
+		}, true},
+		{"update without published_at", func(u *DmNewsUsecase) error {
+			_, err := u.UpdateDmNews(context.Background(), 1, &model.UpdateDmNewsRequest{Title: "Updated"})
+			return err
+		}, false},
+		{"patch with published_at", func(u *DmNewsUsecase) error {
+			_, err := u.PatchDmNews(context.Background(), 1, []byte(`{"published_at":"2026-01-01T00:00:00Z"}`))
+			return err
+		}, true},
+		{"patch without published_at", func(u *DmNewsUsecase) error {
+			_, err := u.PatchDmNews(context.Background(), 1, []byte(`{"title":"Updated"}`))
+			return err
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &MockStreamNotifier{}
+			usecase := NewDmNewsUsecase(mockService, notifier)
+
+			assert.NoError(t, tt.call(usecase))
+			if tt.wantNotify {
+				assert.Len(t, notifier.News, 1)
+			} else {
+				assert.Empty(t, notifier.News)
+			}
+		})
+	}
+}
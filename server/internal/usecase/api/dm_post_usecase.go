@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/model"
 )
 
@@ -10,28 +11,48 @@ import (
 type DmPostServiceInterface interface {
 	CreateDmPost(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
 	GetDmPost(ctx context.Context, id string, userID string) (*model.DmPost, error)
-	ListDmPosts(ctx context.Context, limit, offset int) ([]*model.DmPost, error)
-	ListDmPostsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error)
+	BatchGetDmPosts(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error)
+	ListDmPosts(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	ListDmPostsByUser(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	ListDmPostsByUsers(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error)
 	GetDmUserPosts(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
+	SearchDmPosts(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error)
 	UpdateDmPost(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
+	PatchDmPost(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error)
 	DeleteDmPost(ctx context.Context, id string, userID string) error
 }
 
 // DmPostUsecase は投稿のビジネスロジックを担当するユースケース層
 type DmPostUsecase struct {
-	dmPostService DmPostServiceInterface
+	dmPostService     DmPostServiceInterface
+	webhookDispatcher WebhookDispatcherInterface
+	streamNotifier    StreamNotifierInterface
 }
 
 // NewDmPostUsecase は新しいDmPostUsecaseを作成
-func NewDmPostUsecase(dmPostService DmPostServiceInterface) *DmPostUsecase {
+// webhookDispatcherがnilの場合はWebhookを、streamNotifierがnilの場合はSSEのイベントを送信しない
+func NewDmPostUsecase(dmPostService DmPostServiceInterface, webhookDispatcher WebhookDispatcherInterface, streamNotifier StreamNotifierInterface) *DmPostUsecase {
 	return &DmPostUsecase{
-		dmPostService: dmPostService,
+		dmPostService:     dmPostService,
+		webhookDispatcher: webhookDispatcher,
+		streamNotifier:    streamNotifier,
 	}
 }
 
-// CreateDmPost は投稿を作成
+// CreateDmPost は投稿を作成（Auth0のユーザーは自分の投稿のみ）
 func (u *DmPostUsecase) CreateDmPost(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
-	return u.dmPostService.CreateDmPost(ctx, req)
+	if err := auth.AuthorizeOwner(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	dmPost, err := u.dmPostService.CreateDmPost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventPostCreated, dmPost)
+	if u.streamNotifier != nil {
+		u.streamNotifier.PostCreated(ctx, dmPost)
+	}
+	return dmPost, nil
 }
 
 // GetDmPost はIDで投稿を取得
@@ -39,14 +60,24 @@ func (u *DmPostUsecase) GetDmPost(ctx context.Context, id string, userID string)
 	return u.dmPostService.GetDmPost(ctx, id, userID)
 }
 
+// BatchGetDmPosts は複数の投稿IDとユーザーIDの組で投稿を一括取得（指定が不正な場合はErrInvalidBatchGet）
+func (u *DmPostUsecase) BatchGetDmPosts(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error) {
+	return u.dmPostService.BatchGetDmPosts(ctx, keys)
+}
+
 // ListDmPosts は投稿一覧を取得
-func (u *DmPostUsecase) ListDmPosts(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
-	return u.dmPostService.ListDmPosts(ctx, limit, offset)
+func (u *DmPostUsecase) ListDmPosts(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+	return u.dmPostService.ListDmPosts(ctx, limit, offset, filter, sort)
 }
 
 // ListDmPostsByUser はユーザーIDで投稿一覧を取得
-func (u *DmPostUsecase) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
-	return u.dmPostService.ListDmPostsByUser(ctx, userID, limit, offset)
+func (u *DmPostUsecase) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
+	return u.dmPostService.ListDmPostsByUser(ctx, userID, limit, offset, filter, sort)
+}
+
+// ListDmPostsByUsers は複数ユーザーの投稿をユーザーごとにlimit件ずつ取得
+func (u *DmPostUsecase) ListDmPostsByUsers(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	return u.dmPostService.ListDmPostsByUsers(ctx, userIDs, limit)
 }
 
 // GetDmUserPosts はユーザーと投稿をJOINして取得
@@ -54,12 +85,53 @@ func (u *DmPostUsecase) GetDmUserPosts(ctx context.Context, limit, offset int) (
 	return u.dmPostService.GetDmUserPosts(ctx, limit, offset)
 }
 
-// UpdateDmPost は投稿を更新
+// SearchDmPosts は投稿を全文検索
+func (u *DmPostUsecase) SearchDmPosts(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+	return u.dmPostService.SearchDmPosts(ctx, q)
+}
+
+// UpdateDmPost は投稿を更新（Auth0のユーザーは自分の投稿のみ）
 func (u *DmPostUsecase) UpdateDmPost(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error) {
-	return u.dmPostService.UpdateDmPost(ctx, id, userID, req)
+	if err := auth.AuthorizeOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+	dmPost, err := u.dmPostService.UpdateDmPost(ctx, id, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventPostUpdated, dmPost)
+	return dmPost, nil
+}
+
+// PatchDmPost はJSON Merge Patchで投稿を部分更新（Auth0のユーザーは自分の投稿のみ）
+func (u *DmPostUsecase) PatchDmPost(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error) {
+	if err := auth.AuthorizeOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+	dmPost, err := u.dmPostService.PatchDmPost(ctx, id, userID, patch)
+	if err != nil {
+		return nil, err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventPostUpdated, dmPost)
+	return dmPost, nil
 }
 
-// DeleteDmPost は投稿を削除
+// DeleteDmPost は投稿を削除（Auth0のユーザーは自分の投稿のみ）
 func (u *DmPostUsecase) DeleteDmPost(ctx context.Context, id string, userID string) error {
-	return u.dmPostService.DeleteDmPost(ctx, id, userID)
+	if err := auth.AuthorizeOwner(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.dmPostService.DeleteDmPost(ctx, id, userID); err != nil {
+		return err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventPostDeleted, map[string]string{"id": id, "user_id": userID})
+	return nil
+}
+
+// dispatchWebhook はWebhookの配信を依頼（webhookDispatcherが未設定の場合は何もしない）
+func (u *DmPostUsecase) dispatchWebhook(ctx context.Context, event string, data interface{}) {
+	if u.webhookDispatcher == nil {
+		return
+	}
+	u.webhookDispatcher.Dispatch(ctx, event, data)
 }
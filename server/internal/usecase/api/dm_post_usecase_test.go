@@ -9,18 +9,24 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/model"
 )
 
 // MockDmPostService はテスト用のDmPostServiceモック
 type MockDmPostService struct {
-	CreateDmPostFunc      func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
-	GetDmPostFunc         func(ctx context.Context, id string, userID string) (*model.DmPost, error)
-	ListDmPostsFunc       func(ctx context.Context, limit, offset int) ([]*model.DmPost, error)
-	ListDmPostsByUserFunc func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error)
-	GetDmUserPostsFunc    func(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
-	UpdateDmPostFunc      func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
-	DeleteDmPostFunc      func(ctx context.Context, id string, userID string) error
+	CreateDmPostFunc       func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
+	GetDmPostFunc          func(ctx context.Context, id string, userID string) (*model.DmPost, error)
+	BatchGetDmPostsFunc    func(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error)
+	ListDmPostsFunc        func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	ListDmPostsByUserFunc  func(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error)
+	ListDmPostsByUsersFunc func(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error)
+	GetDmUserPostsFunc     func(ctx context.Context, limit, offset int) ([]*model.DmUserPost, error)
+	UpdateDmPostFunc       func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error)
+	PatchDmPostFunc        func(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error)
+	DeleteDmPostFunc       func(ctx context.Context, id string, userID string) error
+	SearchDmPostsFunc      func(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error)
 }
 
 func (m *MockDmPostService) CreateDmPost(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
@@ -37,16 +43,30 @@ func (m *MockDmPostService) GetDmPost(ctx context.Context, id string, userID str
 	return nil, nil
 }
 
-func (m *MockDmPostService) ListDmPosts(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+func (m *MockDmPostService) BatchGetDmPosts(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error) {
+	if m.BatchGetDmPostsFunc != nil {
+		return m.BatchGetDmPostsFunc(ctx, keys)
+	}
+	return nil, nil
+}
+
+func (m *MockDmPostService) ListDmPosts(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 	if m.ListDmPostsFunc != nil {
-		return m.ListDmPostsFunc(ctx, limit, offset)
+		return m.ListDmPostsFunc(ctx, limit, offset, filter, sort)
 	}
 	return nil, nil
 }
 
-func (m *MockDmPostService) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+func (m *MockDmPostService) ListDmPostsByUser(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 	if m.ListDmPostsByUserFunc != nil {
-		return m.ListDmPostsByUserFunc(ctx, userID, limit, offset)
+		return m.ListDmPostsByUserFunc(ctx, userID, limit, offset, filter, sort)
+	}
+	return nil, nil
+}
+
+func (m *MockDmPostService) ListDmPostsByUsers(ctx context.Context, userIDs []string, limit int) (map[string][]*model.DmPost, error) {
+	if m.ListDmPostsByUsersFunc != nil {
+		return m.ListDmPostsByUsersFunc(ctx, userIDs, limit)
 	}
 	return nil, nil
 }
@@ -65,6 +85,13 @@ func (m *MockDmPostService) UpdateDmPost(ctx context.Context, id string, userID
 	return nil, nil
 }
 
+func (m *MockDmPostService) PatchDmPost(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error) {
+	if m.PatchDmPostFunc != nil {
+		return m.PatchDmPostFunc(ctx, id, userID, patch)
+	}
+	return nil, nil
+}
+
 func (m *MockDmPostService) DeleteDmPost(ctx context.Context, id string, userID string) error {
 	if m.DeleteDmPostFunc != nil {
 		return m.DeleteDmPostFunc(ctx, id, userID)
@@ -72,16 +99,23 @@ func (m *MockDmPostService) DeleteDmPost(ctx context.Context, id string, userID
 	return nil
 }
 
+func (m *MockDmPostService) SearchDmPosts(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+	if m.SearchDmPostsFunc != nil {
+		return m.SearchDmPostsFunc(ctx, q)
+	}
+	return nil, nil
+}
+
 func TestDmPostUsecase_CreateDmPost(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
 
 	tests := []struct {
-		name        string
-		req         *model.CreateDmPostRequest
-		mockFunc    func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
-		wantErr     bool
-		wantPost    *model.DmPost
+		name     string
+		req      *model.CreateDmPostRequest
+		mockFunc func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error)
+		wantErr  bool
+		wantPost *model.DmPost
 	}{
 		{
 			name: "creates post successfully",
@@ -130,7 +164,7 @@ func TestDmPostUsecase_CreateDmPost(t *testing.T) {
 			mockService := &MockDmPostService{
 				CreateDmPostFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
 			got, err := usecase.CreateDmPost(ctx, tt.req)
 
@@ -201,7 +235,7 @@ func TestDmPostUsecase_GetDmPost(t *testing.T) {
 			mockService := &MockDmPostService{
 				GetDmPostFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
 			got, err := usecase.GetDmPost(ctx, tt.id, tt.userID)
 
@@ -226,7 +260,7 @@ func TestDmPostUsecase_ListDmPosts(t *testing.T) {
 		name      string
 		limit     int
 		offset    int
-		mockFunc  func(ctx context.Context, limit, offset int) ([]*model.DmPost, error)
+		mockFunc  func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error)
 		wantErr   bool
 		wantCount int
 	}{
@@ -234,7 +268,7 @@ func TestDmPostUsecase_ListDmPosts(t *testing.T) {
 			name:   "lists posts successfully",
 			limit:  10,
 			offset: 0,
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 				return []*model.DmPost{
 					{ID: "post1", UserID: "user1", Title: "Post 1", Content: "Content 1", CreatedAt: now, UpdatedAt: now},
 					{ID: "post2", UserID: "user2", Title: "Post 2", Content: "Content 2", CreatedAt: now, UpdatedAt: now},
@@ -247,7 +281,7 @@ func TestDmPostUsecase_ListDmPosts(t *testing.T) {
 			name:   "returns error when service fails",
 			limit:  10,
 			offset: 0,
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmPost, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 				return nil, errors.New("service error")
 			},
 			wantErr:   true,
@@ -260,9 +294,9 @@ func TestDmPostUsecase_ListDmPosts(t *testing.T) {
 			mockService := &MockDmPostService{
 				ListDmPostsFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
-			got, err := usecase.ListDmPosts(ctx, tt.limit, tt.offset)
+			got, err := usecase.ListDmPosts(ctx, tt.limit, tt.offset, "", "")
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -284,7 +318,7 @@ func TestDmPostUsecase_ListDmPostsByUser(t *testing.T) {
 		userID    string
 		limit     int
 		offset    int
-		mockFunc  func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error)
+		mockFunc  func(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error)
 		wantErr   bool
 		wantCount int
 	}{
@@ -293,7 +327,7 @@ func TestDmPostUsecase_ListDmPostsByUser(t *testing.T) {
 			userID: "user123",
 			limit:  10,
 			offset: 0,
-			mockFunc: func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+			mockFunc: func(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 				return []*model.DmPost{
 					{ID: "post1", UserID: userID, Title: "Post 1", Content: "Content 1", CreatedAt: now, UpdatedAt: now},
 					{ID: "post2", UserID: userID, Title: "Post 2", Content: "Content 2", CreatedAt: now, UpdatedAt: now},
@@ -307,7 +341,7 @@ func TestDmPostUsecase_ListDmPostsByUser(t *testing.T) {
 			userID: "user123",
 			limit:  10,
 			offset: 0,
-			mockFunc: func(ctx context.Context, userID string, limit, offset int) ([]*model.DmPost, error) {
+			mockFunc: func(ctx context.Context, userID string, limit, offset int, filter, sort string) ([]*model.DmPost, error) {
 				return nil, errors.New("service error")
 			},
 			wantErr:   true,
@@ -320,9 +354,9 @@ func TestDmPostUsecase_ListDmPostsByUser(t *testing.T) {
 			mockService := &MockDmPostService{
 				ListDmPostsByUserFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
-			got, err := usecase.ListDmPostsByUser(ctx, tt.userID, tt.limit, tt.offset)
+			got, err := usecase.ListDmPostsByUser(ctx, tt.userID, tt.limit, tt.offset, "", "")
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -377,7 +411,7 @@ func TestDmPostUsecase_GetDmUserPosts(t *testing.T) {
 			mockService := &MockDmPostService{
 				GetDmUserPostsFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
 			got, err := usecase.GetDmUserPosts(ctx, tt.limit, tt.offset)
 
@@ -453,7 +487,7 @@ func TestDmPostUsecase_UpdateDmPost(t *testing.T) {
 			mockService := &MockDmPostService{
 				UpdateDmPostFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
 			got, err := usecase.UpdateDmPost(ctx, tt.id, tt.userID, tt.req)
 
@@ -470,6 +504,38 @@ func TestDmPostUsecase_UpdateDmPost(t *testing.T) {
 	}
 }
 
+func TestDmPostUsecase_PatchDmPost(t *testing.T) {
+	mockService := &MockDmPostService{
+		PatchDmPostFunc: func(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error) {
+			return &model.DmPost{ID: id, UserID: userID, Title: "Patched"}, nil
+		},
+	}
+	usecase := NewDmPostUsecase(mockService, nil, nil)
+
+	got, err := usecase.PatchDmPost(context.Background(), "post-001", "user-001", []byte(`{"title":"Patched"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Patched", got.Title)
+	assert.Equal(t, "user-001", got.UserID)
+}
+
+func TestDmPostUsecase_BatchGetDmPosts(t *testing.T) {
+	mockService := &MockDmPostService{
+		BatchGetDmPostsFunc: func(ctx context.Context, keys []model.DmPostKey) (*model.DmPostBatchGetResult, error) {
+			return &model.DmPostBatchGetResult{
+				Items:   []*model.DmPost{{ID: keys[0].ID, UserID: keys[0].UserID}},
+				Missing: keys[1:],
+			}, nil
+		},
+	}
+	usecase := NewDmPostUsecase(mockService, nil, nil)
+
+	keys := []model.DmPostKey{{ID: "post-001", UserID: "user-001"}, {ID: "post-404", UserID: "user-001"}}
+	got, err := usecase.BatchGetDmPosts(context.Background(), keys)
+	assert.NoError(t, err)
+	assert.Equal(t, "post-001", got.Items[0].ID)
+	assert.Equal(t, keys[1:], got.Missing)
+}
+
 func TestDmPostUsecase_DeleteDmPost(t *testing.T) {
 	ctx := context.Background()
 
@@ -505,7 +571,7 @@ func TestDmPostUsecase_DeleteDmPost(t *testing.T) {
 			mockService := &MockDmPostService{
 				DeleteDmPostFunc: tt.mockFunc,
 			}
-			usecase := NewDmPostUsecase(mockService)
+			usecase := NewDmPostUsecase(mockService, nil, nil)
 
 			err := usecase.DeleteDmPost(ctx, tt.id, tt.userID)
 
@@ -517,3 +583,120 @@ func TestDmPostUsecase_DeleteDmPost(t *testing.T) {
 		})
 	}
 }
+
+func TestDmPostUsecase_SearchDmPosts(t *testing.T) {
+	ctx := context.Background()
+	wantHits := []*model.DmPostSearchHit{
+		{DmPost: model.DmPost{ID: "post1", Title: "Go search"}, Score: 0.5},
+	}
+
+	var gotQuery *model.DmPostSearchQuery
+	mockService := &MockDmPostService{
+		SearchDmPostsFunc: func(ctx context.Context, q *model.DmPostSearchQuery) ([]*model.DmPostSearchHit, error) {
+			gotQuery = q
+			return wantHits, nil
+		},
+	}
+	usecase := NewDmPostUsecase(mockService, nil, nil)
+
+	query := &model.DmPostSearchQuery{Query: "search", Limit: 10}
+	hits, err := usecase.SearchDmPosts(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, wantHits, hits)
+	assert.Same(t, query, gotQuery)
+}
+
+func TestDmPostUsecase_Webhook(t *testing.T) {
+	mockService := &MockDmPostService{
+		CreateDmPostFunc: func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
+			return &model.DmPost{ID: "post-001", UserID: req.UserID}, nil
+		},
+		UpdateDmPostFunc: func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error) {
+			return &model.DmPost{ID: id, UserID: userID}, nil
+		},
+		PatchDmPostFunc: func(ctx context.Context, id string, userID string, patch []byte) (*model.DmPost, error) {
+			return nil, errors.New("post not found")
+		},
+		DeleteDmPostFunc: func(ctx context.Context, id string, userID string) error {
+			return nil
+		},
+	}
+	dispatcher := &MockWebhookDispatcher{}
+	usecase := NewDmPostUsecase(mockService, dispatcher, nil)
+	ctx := context.Background()
+
+	_, err := usecase.CreateDmPost(ctx, &model.CreateDmPostRequest{UserID: "user-001"})
+	require.NoError(t, err)
+	_, err = usecase.UpdateDmPost(ctx, "post-001", "user-001", &model.UpdateDmPostRequest{})
+	require.NoError(t, err)
+	// 失敗した操作はWebhookを送信しない
+	_, err = usecase.PatchDmPost(ctx, "post-001", "user-001", []byte(`{}`))
+	require.Error(t, err)
+	require.NoError(t, usecase.DeleteDmPost(ctx, "post-001", "user-001"))
+
+	assert.Equal(t, []string{
+		model.WebhookEventPostCreated,
+		model.WebhookEventPostUpdated,
+		model.WebhookEventPostDeleted,
+	}, dispatcher.Events)
+	assert.Equal(t, map[string]string{"id": "post-001", "user_id": "user-001"}, dispatcher.Data[2])
+}
+
+func TestDmPostUsecase_StreamNotifier(t *testing.T) {
+	mockService := &MockDmPostService{
+		CreateDmPostFunc: func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
+			return &model.DmPost{ID: "post-001", UserID: req.UserID}, nil
+		},
+		UpdateDmPostFunc: func(ctx context.Context, id string, userID string, req *model.UpdateDmPostRequest) (*model.DmPost, error) {
+			return &model.DmPost{ID: id, UserID: userID}, nil
+		},
+	}
+	notifier := &MockStreamNotifier{}
+	usecase := NewDmPostUsecase(mockService, nil, notifier)
+	ctx := context.Background()
+
+	_, err := usecase.CreateDmPost(ctx, &model.CreateDmPostRequest{UserID: "user-001"})
+	require.NoError(t, err)
+	// 更新はSSEのイベントを送信しない
+	_, err = usecase.UpdateDmPost(ctx, "post-001", "user-001", &model.UpdateDmPostRequest{})
+	require.NoError(t, err)
+
+	require.Len(t, notifier.Posts, 1)
+	assert.Equal(t, "user-001", notifier.Posts[0].UserID)
+}
+
+func TestDmPostUsecase_Ownership(t *testing.T) {
+	mockService := &MockDmPostService{
+		CreateDmPostFunc: func(ctx context.Context, req *model.CreateDmPostRequest) (*model.DmPost, error) {
+			return &model.DmPost{ID: "post123", UserID: req.UserID}, nil
+		},
+		DeleteDmPostFunc: func(ctx context.Context, id string, userID string) error {
+			return nil
+		},
+	}
+	usecase := NewDmPostUsecase(mockService, nil, nil)
+
+	owner := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "auth0|owner", UserID: "user123"})
+	unlinked := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "auth0|unlinked"})
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "auth0|admin", Roles: []string{auth.RoleAdmin}})
+
+	// 自分の投稿は作成・削除できる
+	_, err := usecase.CreateDmPost(owner, &model.CreateDmPostRequest{UserID: "user123", Title: "Title", Content: "Content"})
+	assert.NoError(t, err)
+	assert.NoError(t, usecase.DeleteDmPost(owner, "post123", "user123"))
+
+	// 他のユーザーの投稿は作成・更新・削除できない
+	_, err = usecase.CreateDmPost(owner, &model.CreateDmPostRequest{UserID: "user456", Title: "Title", Content: "Content"})
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	_, err = usecase.UpdateDmPost(owner, "post456", "user456", &model.UpdateDmPostRequest{Title: "Updated"})
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	_, err = usecase.PatchDmPost(owner, "post456", "user456", []byte(`{"title":"Updated"}`))
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	assert.ErrorIs(t, usecase.DeleteDmPost(owner, "post456", "user456"), apperror.ErrForbidden)
+
+	// dm_usersに紐づいていないユーザーは操作できない
+	assert.ErrorIs(t, usecase.DeleteDmPost(unlinked, "post123", "user123"), apperror.ErrForbidden)
+
+	// 管理者ロールは他のユーザーの投稿も削除できる
+	assert.NoError(t, usecase.DeleteDmPost(admin, "post456", "user456"))
+}
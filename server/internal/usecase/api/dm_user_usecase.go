@@ -3,34 +3,58 @@ package api
 import (
 	"context"
 
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service"
 )
 
+// ErrInvalidListQuery は一覧取得のフィルタ・ソート指定が不正な場合のエラー
+var ErrInvalidListQuery = service.ErrInvalidListQuery
+
+// ErrInvalidMergePatch はJSON Merge Patchの内容が不正な場合のエラー
+var ErrInvalidMergePatch = service.ErrInvalidMergePatch
+
+// ErrInvalidBatchGet は一括取得の指定が不正な場合のエラー
+var ErrInvalidBatchGet = service.ErrInvalidBatchGet
+
 // DmUserServiceInterface はDmUserServiceのインターフェース
 type DmUserServiceInterface interface {
 	CreateDmUser(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error)
 	GetDmUser(ctx context.Context, id string) (*model.DmUser, error)
-	ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+	BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error)
+	ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
 	UpdateDmUser(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error)
+	PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error)
 	DeleteDmUser(ctx context.Context, id string) error
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 }
 
 // DmUserUsecase はdm_user関連のビジネスロジックを担当
 type DmUserUsecase struct {
-	dmUserService DmUserServiceInterface
+	dmUserService     DmUserServiceInterface
+	webhookDispatcher WebhookDispatcherInterface
 }
 
 // NewDmUserUsecase は新しいDmUserUsecaseを作成
-func NewDmUserUsecase(dmUserService DmUserServiceInterface) *DmUserUsecase {
+// webhookDispatcherがnilの場合、Webhookは送信しない
+func NewDmUserUsecase(dmUserService DmUserServiceInterface, webhookDispatcher WebhookDispatcherInterface) *DmUserUsecase {
 	return &DmUserUsecase{
-		dmUserService: dmUserService,
+		dmUserService:     dmUserService,
+		webhookDispatcher: webhookDispatcher,
 	}
 }
 
-// CreateDmUser はユーザーを作成
+// CreateDmUser はユーザーを作成（Auth0のユーザーは管理者ロールが必要）
 func (u *DmUserUsecase) CreateDmUser(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
-	return u.dmUserService.CreateDmUser(ctx, req)
+	if err := auth.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	dmUser, err := u.dmUserService.CreateDmUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventUserCreated, dmUser)
+	return dmUser, nil
 }
 
 // GetDmUser はIDでユーザーを取得
@@ -38,17 +62,58 @@ func (u *DmUserUsecase) GetDmUser(ctx context.Context, id string) (*model.DmUser
 	return u.dmUserService.GetDmUser(ctx, id)
 }
 
-// ListDmUsers はユーザー一覧を取得
-func (u *DmUserUsecase) ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
-	return u.dmUserService.ListDmUsers(ctx, limit, offset)
+// BatchGetDmUsers は複数のIDでユーザーを一括取得（指定が不正な場合はErrInvalidBatchGet）
+func (u *DmUserUsecase) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	return u.dmUserService.BatchGetDmUsers(ctx, ids)
 }
 
-// UpdateDmUser はユーザーを更新
+// ListDmUsers はユーザー一覧を取得（filter・sortの指定が不正な場合はErrInvalidListQuery）
+func (u *DmUserUsecase) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
+	return u.dmUserService.ListDmUsers(ctx, limit, offset, filter, sort)
+}
+
+// UpdateDmUser はユーザーを更新（Auth0のユーザーは自分のみ）
 func (u *DmUserUsecase) UpdateDmUser(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error) {
-	return u.dmUserService.UpdateDmUser(ctx, id, req)
+	if err := auth.AuthorizeOwner(ctx, id); err != nil {
+		return nil, err
+	}
+	dmUser, err := u.dmUserService.UpdateDmUser(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventUserUpdated, dmUser)
+	return dmUser, nil
 }
 
-// DeleteDmUser はユーザーを削除
+// PatchDmUser はJSON Merge Patchでユーザーを部分更新（Auth0のユーザーは自分のみ）
+func (u *DmUserUsecase) PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+	if err := auth.AuthorizeOwner(ctx, id); err != nil {
+		return nil, err
+	}
+	dmUser, err := u.dmUserService.PatchDmUser(ctx, id, patch)
+	if err != nil {
+		return nil, err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventUserUpdated, dmUser)
+	return dmUser, nil
+}
+
+// DeleteDmUser はユーザーを削除（Auth0のユーザーは自分のみ）
 func (u *DmUserUsecase) DeleteDmUser(ctx context.Context, id string) error {
-	return u.dmUserService.DeleteDmUser(ctx, id)
+	if err := auth.AuthorizeOwner(ctx, id); err != nil {
+		return err
+	}
+	if err := u.dmUserService.DeleteDmUser(ctx, id); err != nil {
+		return err
+	}
+	u.dispatchWebhook(ctx, model.WebhookEventUserDeleted, map[string]string{"id": id})
+	return nil
+}
+
+// dispatchWebhook はWebhookの配信を依頼（webhookDispatcherが未設定の場合は何もしない）
+func (u *DmUserUsecase) dispatchWebhook(ctx context.Context, event string, data interface{}) {
+	if u.webhookDispatcher == nil {
+		return
+	}
+	u.webhookDispatcher.Dispatch(ctx, event, data)
 }
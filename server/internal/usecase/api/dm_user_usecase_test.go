@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/auth"
 	"github.com/taku-o/go-webdb-template/internal/model"
 )
 
@@ -13,8 +16,10 @@ import (
 type MockDmUserService struct {
 	CreateDmUserFunc     func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error)
 	GetDmUserFunc        func(ctx context.Context, id string) (*model.DmUser, error)
-	ListDmUsersFunc      func(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+	BatchGetDmUsersFunc  func(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error)
+	ListDmUsersFunc      func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
 	UpdateDmUserFunc     func(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error)
+	PatchDmUserFunc      func(ctx context.Context, id string, patch []byte) (*model.DmUser, error)
 	DeleteDmUserFunc     func(ctx context.Context, id string) error
 	CheckEmailExistsFunc func(ctx context.Context, email string) (bool, error)
 }
@@ -33,9 +38,16 @@ func (m *MockDmUserService) GetDmUser(ctx context.Context, id string) (*model.Dm
 	return nil, nil
 }
 
-func (m *MockDmUserService) ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+func (m *MockDmUserService) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	if m.BatchGetDmUsersFunc != nil {
+		return m.BatchGetDmUsersFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockDmUserService) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 	if m.ListDmUsersFunc != nil {
-		return m.ListDmUsersFunc(ctx, limit, offset)
+		return m.ListDmUsersFunc(ctx, limit, offset, filter, sort)
 	}
 	return nil, nil
 }
@@ -47,6 +59,13 @@ func (m *MockDmUserService) UpdateDmUser(ctx context.Context, id string, req *mo
 	return nil, nil
 }
 
+func (m *MockDmUserService) PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+	if m.PatchDmUserFunc != nil {
+		return m.PatchDmUserFunc(ctx, id, patch)
+	}
+	return nil, nil
+}
+
 func (m *MockDmUserService) DeleteDmUser(ctx context.Context, id string) error {
 	if m.DeleteDmUserFunc != nil {
 		return m.DeleteDmUserFunc(ctx, id)
@@ -111,7 +130,7 @@ func TestDmUserUsecase_CreateDmUser(t *testing.T) {
 				CreateDmUserFunc: tt.mockFunc,
 			}
 
-			u := NewDmUserUsecase(mockService)
+			u := NewDmUserUsecase(mockService, nil)
 			got, err := u.CreateDmUser(context.Background(), tt.req)
 
 			if tt.wantErr {
@@ -172,7 +191,7 @@ func TestDmUserUsecase_GetDmUser(t *testing.T) {
 				GetDmUserFunc: tt.mockFunc,
 			}
 
-			u := NewDmUserUsecase(mockService)
+			u := NewDmUserUsecase(mockService, nil)
 			got, err := u.GetDmUser(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -191,7 +210,7 @@ func TestDmUserUsecase_GetDmUser(t *testing.T) {
 func TestDmUserUsecase_ListDmUsers(t *testing.T) {
 	tests := []struct {
 		name        string
-		mockFunc    func(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+		mockFunc    func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
 		limit       int
 		offset      int
 		wantCount   int
@@ -200,7 +219,7 @@ func TestDmUserUsecase_ListDmUsers(t *testing.T) {
 	}{
 		{
 			name: "lists users successfully",
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 				return []*model.DmUser{
 					{ID: "user-001", Name: "User 1"},
 					{ID: "user-002", Name: "User 2"},
@@ -213,7 +232,7 @@ func TestDmUserUsecase_ListDmUsers(t *testing.T) {
 		},
 		{
 			name: "returns error when service fails",
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 				return nil, errors.New("service error")
 			},
 			limit:       10,
@@ -230,8 +249,8 @@ func TestDmUserUsecase_ListDmUsers(t *testing.T) {
 				ListDmUsersFunc: tt.mockFunc,
 			}
 
-			u := NewDmUserUsecase(mockService)
-			got, err := u.ListDmUsers(context.Background(), tt.limit, tt.offset)
+			u := NewDmUserUsecase(mockService, nil)
+			got, err := u.ListDmUsers(context.Background(), tt.limit, tt.offset, "", "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -297,7 +316,7 @@ func TestDmUserUsecase_UpdateDmUser(t *testing.T) {
 				UpdateDmUserFunc: tt.mockFunc,
 			}
 
-			u := NewDmUserUsecase(mockService)
+			u := NewDmUserUsecase(mockService, nil)
 			got, err := u.UpdateDmUser(context.Background(), tt.userID, tt.req)
 
 			if tt.wantErr {
@@ -313,6 +332,39 @@ func TestDmUserUsecase_UpdateDmUser(t *testing.T) {
 	}
 }
 
+func TestDmUserUsecase_PatchDmUser(t *testing.T) {
+	var gotPatch []byte
+	mockService := &MockDmUserService{
+		PatchDmUserFunc: func(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+			gotPatch = patch
+			return &model.DmUser{ID: id, Name: "Patched"}, nil
+		},
+	}
+	u := NewDmUserUsecase(mockService, nil)
+
+	got, err := u.PatchDmUser(context.Background(), "user-001", []byte(`{"name":"Patched"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Patched", got.Name)
+	assert.JSONEq(t, `{"name":"Patched"}`, string(gotPatch))
+}
+
+func TestDmUserUsecase_BatchGetDmUsers(t *testing.T) {
+	mockService := &MockDmUserService{
+		BatchGetDmUsersFunc: func(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+			return &model.DmUserBatchGetResult{
+				Items:   []*model.DmUser{{ID: ids[0]}},
+				Missing: ids[1:],
+			}, nil
+		},
+	}
+	u := NewDmUserUsecase(mockService, nil)
+
+	got, err := u.BatchGetDmUsers(context.Background(), []string{"user-001", "user-404"})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-001", got.Items[0].ID)
+	assert.Equal(t, []string{"user-404"}, got.Missing)
+}
+
 func TestDmUserUsecase_DeleteDmUser(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -346,7 +398,7 @@ func TestDmUserUsecase_DeleteDmUser(t *testing.T) {
 				DeleteDmUserFunc: tt.mockFunc,
 			}
 
-			u := NewDmUserUsecase(mockService)
+			u := NewDmUserUsecase(mockService, nil)
 			err := u.DeleteDmUser(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -359,3 +411,87 @@ func TestDmUserUsecase_DeleteDmUser(t *testing.T) {
 		})
 	}
 }
+
+func TestDmUserUsecase_Webhook(t *testing.T) {
+	mockService := &MockDmUserService{
+		CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+			return &model.DmUser{ID: "user-001", Name: req.Name}, nil
+		},
+		UpdateDmUserFunc: func(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error) {
+			return &model.DmUser{ID: id, Name: req.Name}, nil
+		},
+		PatchDmUserFunc: func(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+			return &model.DmUser{ID: id}, nil
+		},
+		DeleteDmUserFunc: func(ctx context.Context, id string) error {
+			if id == "missing" {
+				return errors.New("user not found")
+			}
+			return nil
+		},
+	}
+	dispatcher := &MockWebhookDispatcher{}
+	u := NewDmUserUsecase(mockService, dispatcher)
+	ctx := context.Background()
+
+	_, err := u.CreateDmUser(ctx, &model.CreateDmUserRequest{Name: "Test"})
+	require.NoError(t, err)
+	_, err = u.UpdateDmUser(ctx, "user-001", &model.UpdateDmUserRequest{Name: "Updated"})
+	require.NoError(t, err)
+	_, err = u.PatchDmUser(ctx, "user-001", []byte(`{}`))
+	require.NoError(t, err)
+	require.NoError(t, u.DeleteDmUser(ctx, "user-001"))
+	// 失敗した操作はWebhookを送信しない
+	require.Error(t, u.DeleteDmUser(ctx, "missing"))
+
+	assert.Equal(t, []string{
+		model.WebhookEventUserCreated,
+		model.WebhookEventUserUpdated,
+		model.WebhookEventUserUpdated,
+		model.WebhookEventUserDeleted,
+	}, dispatcher.Events)
+	assert.Equal(t, "user-001", dispatcher.Data[0].(*model.DmUser).ID)
+	assert.Equal(t, map[string]string{"id": "user-001"}, dispatcher.Data[3])
+}
+
+func TestDmUserUsecase_Ownership(t *testing.T) {
+	mockService := &MockDmUserService{
+		CreateDmUserFunc: func(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
+			return &model.DmUser{ID: "user-002"}, nil
+		},
+		UpdateDmUserFunc: func(ctx context.Context, id string, req *model.UpdateDmUserRequest) (*model.DmUser, error) {
+			return &model.DmUser{ID: id}, nil
+		},
+		DeleteDmUserFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+	u := NewDmUserUsecase(mockService, nil)
+
+	owner := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "auth0|owner", UserID: "user-001"})
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeUser, Subject: "auth0|admin", Roles: []string{auth.RoleAdmin}})
+	apiKey := auth.WithPrincipal(context.Background(), &auth.Principal{Type: auth.PrincipalTypeAPIKey, Subject: "public_client"})
+
+	// 自分のユーザーは更新・削除できる
+	_, err := u.UpdateDmUser(owner, "user-001", &model.UpdateDmUserRequest{Name: "Updated"})
+	assert.NoError(t, err)
+	assert.NoError(t, u.DeleteDmUser(owner, "user-001"))
+
+	// 他のユーザーは更新・削除できない
+	_, err = u.UpdateDmUser(owner, "user-002", &model.UpdateDmUserRequest{Name: "Updated"})
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	_, err = u.PatchDmUser(owner, "user-002", []byte(`{"name":"Updated"}`))
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+	assert.ErrorIs(t, u.DeleteDmUser(owner, "user-002"), apperror.ErrForbidden)
+
+	// ユーザーの作成は管理者ロールが必要
+	_, err = u.CreateDmUser(owner, &model.CreateDmUserRequest{Name: "New", Email: "new@example.com"})
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+
+	// 管理者ロールとPublic APIキーは他のユーザーも操作できる
+	for _, ctx := range []context.Context{admin, apiKey} {
+		_, err = u.CreateDmUser(ctx, &model.CreateDmUserRequest{Name: "New", Email: "new@example.com"})
+		assert.NoError(t, err)
+		assert.NoError(t, u.DeleteDmUser(ctx, "user-002"))
+	}
+}
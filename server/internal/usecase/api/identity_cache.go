@@ -0,0 +1,82 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// identityCacheKey はキャッシュのキー（OIDCのissとsub）
+type identityCacheKey struct {
+	issuer  string
+	subject string
+}
+
+type identityCacheEntry struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// identityCache はissとsubごとの紐づくdm_usersのIDの解決結果をプロセス内のメモリにキャッシュする
+// 紐づけられなかった結果（空のID）もキャッシュする。期限切れのエントリはttlごとにまとめて削除する
+// 他のサーバーでの紐づけ・解除は、キャッシュの期限が切れた後に反映される
+type identityCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[identityCacheKey]identityCacheEntry
+	lastSweep time.Time
+}
+
+// newIdentityCache は新しいidentityCacheを作成（ttlが0以下の場合はキャッシュしない）
+func newIdentityCache(ttl time.Duration) *identityCache {
+	return &identityCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[identityCacheKey]identityCacheEntry),
+	}
+}
+
+// get はキャッシュした解決結果を返す（キャッシュがない場合はfoundがfalse）
+func (c *identityCache) get(issuer, subject string) (userID string, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[identityCacheKey{issuer: issuer, subject: subject}]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return "", false
+	}
+	return entry.userID, true
+}
+
+// set は解決結果をttlの間キャッシュする
+func (c *identityCache) set(issuer, subject, userID string) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[identityCacheKey{issuer: issuer, subject: subject}] = identityCacheEntry{
+		userID:    userID,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// delete はキャッシュした解決結果を削除する（このサーバーでの紐づけ・解除を即時に反映する）
+func (c *identityCache) delete(issuer, subject string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, identityCacheKey{issuer: issuer, subject: subject})
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+	"github.com/taku-o/go-webdb-template/internal/stream"
+)
+
+// StreamPublisherInterface はSSEのイベントを配信するブローカーのインターフェース
+type StreamPublisherInterface interface {
+	Publish(ctx context.Context, event *model.StreamEvent) error
+}
+
+// StreamNotifierInterface はStreamNotifierのインターフェース
+type StreamNotifierInterface interface {
+	PostCreated(ctx context.Context, dmPost *model.DmPost)
+	NewsPublished(ctx context.Context, dmNews *model.DmNews)
+}
+
+// StreamNotifier は投稿・ニュースのイベントをSSEの購読者に配信する
+type StreamNotifier struct {
+	publisher      StreamPublisherInterface
+	jobQueueClient JobQueueClientInterface
+	now            func() time.Time
+}
+
+// NewStreamNotifier は新しいStreamNotifierを作成
+// jobQueueClientがnilの場合、公開日時が未来のニュースは公開日時にイベントを配信しない
+func NewStreamNotifier(publisher StreamPublisherInterface, jobQueueClient JobQueueClientInterface) *StreamNotifier {
+	return &StreamNotifier{
+		publisher:      publisher,
+		jobQueueClient: jobQueueClient,
+		now:            time.Now,
+	}
+}
+
+// PostCreated は投稿作成のイベントを配信
+// 元の操作は完了しているため、エラーは返さずログに記録する
+func (n *StreamNotifier) PostCreated(ctx context.Context, dmPost *model.DmPost) {
+	event, err := stream.NewPostCreatedEvent(dmPost)
+	if err != nil {
+		log.Printf("Failed to create post stream event: %v", err)
+		return
+	}
+	if err := n.publisher.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish post stream event: %v", err)
+	}
+}
+
+// NewsPublished はニュース公開のイベントを配信
+// 公開日時が未来の場合は公開日時に配信するジョブを登録し、非公開の場合は何もしない
+func (n *StreamNotifier) NewsPublished(ctx context.Context, dmNews *model.DmNews) {
+	if dmNews.PublishedAt == nil {
+		return
+	}
+
+	if delay := dmNews.PublishedAt.Sub(n.now()); delay > 0 {
+		if err := n.schedule(ctx, dmNews.ID, *dmNews.PublishedAt, delay); err != nil {
+			log.Printf("Failed to schedule news stream event for news %d: %v", dmNews.ID, err)
+		}
+		return
+	}
+
+	event, err := stream.NewNewsPublishedEvent(dmNews)
+	if err != nil {
+		log.Printf("Failed to create news stream event: %v", err)
+		return
+	}
+	if err := n.publisher.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish news stream event: %v", err)
+	}
+}
+
+// schedule は公開日時にニュース公開のイベントを配信するジョブを登録
+func (n *StreamNotifier) schedule(ctx context.Context, newsID int64, publishedAt time.Time, delay time.Duration) error {
+	if n.jobQueueClient == nil {
+		return ErrJobQueueUnavailable
+	}
+
+	payloadBytes, err := json.Marshal(jobqueue.StreamNewsPublishedPayload{
+		NewsID:      newsID,
+		PublishedAt: publishedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// 公開日時より前に実行されないよう秒単位で切り上げる
+	delaySeconds := int((delay + time.Second - 1) / time.Second)
+	_, err = n.jobQueueClient.EnqueueJob(ctx, jobqueue.JobTypeStreamNewsPublished, payloadBytes, &JobOptions{
+		DelaySeconds: delaySeconds,
+	})
+	return err
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+)
+
+// MockStreamPublisher はテスト用のStreamPublisherモック
+type MockStreamPublisher struct {
+	Events []*model.StreamEvent
+	Err    error
+}
+
+func (m *MockStreamPublisher) Publish(ctx context.Context, event *model.StreamEvent) error {
+	m.Events = append(m.Events, event)
+	return m.Err
+}
+
+// MockStreamNotifier はテスト用のStreamNotifierモック
+type MockStreamNotifier struct {
+	Posts []*model.DmPost
+	News  []*model.DmNews
+}
+
+func (m *MockStreamNotifier) PostCreated(ctx context.Context, dmPost *model.DmPost) {
+	m.Posts = append(m.Posts, dmPost)
+}
+
+func (m *MockStreamNotifier) NewsPublished(ctx context.Context, dmNews *model.DmNews) {
+	m.News = append(m.News, dmNews)
+}
+
+func TestStreamNotifier_PostCreated(t *testing.T) {
+	publisher := &MockStreamPublisher{}
+	notifier := NewStreamNotifier(publisher, nil)
+
+	notifier.PostCreated(context.Background(), &model.DmPost{ID: "p1", UserID: "u1"})
+
+	require.Len(t, publisher.Events, 1)
+	assert.Equal(t, model.StreamTopicPosts, publisher.Events[0].Topic)
+	assert.Equal(t, model.StreamEventPostCreated, publisher.Events[0].Type)
+	assert.Equal(t, "u1", publisher.Events[0].UserID)
+}
+
+func TestStreamNotifier_PostCreated_PublishError(t *testing.T) {
+	// 配信に失敗しても元の操作には影響しない
+	notifier := NewStreamNotifier(&MockStreamPublisher{Err: errors.New("redis down")}, nil)
+	notifier.PostCreated(context.Background(), &model.DmPost{ID: "p1", UserID: "u1"})
+}
+
+func TestStreamNotifier_NewsPublished(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(90*time.Second + 500*time.Millisecond)
+
+	tests := []struct {
+		name          string
+		publishedAt   *time.Time
+		wantPublished bool
+		wantScheduled bool
+	}{
+		{"unpublished", nil, false, false},
+		{"published", &past, true, false},
+		{"scheduled", &future, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &MockStreamPublisher{}
+			var gotJobType string
+			var gotPayload []byte
+			var gotOpts *JobOptions
+			notifier := NewStreamNotifier(publisher, &MockJobQueueClient{
+				EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+					gotJobType = jobType
+					gotPayload = payload
+					gotOpts = opts
+					return &JobInfo{ID: "job-1"}, nil
+				},
+			})
+			notifier.now = func() time.Time { return now }
+
+			notifier.NewsPublished(context.Background(), &model.DmNews{ID: 5, PublishedAt: tt.publishedAt})
+
+			if tt.wantPublished {
+				require.Len(t, publisher.Events, 1)
+				assert.Equal(t, model.StreamEventNewsPublished, publisher.Events[0].Type)
+			} else {
+				assert.Empty(t, publisher.Events)
+			}
+
+			if tt.wantScheduled {
+				assert.Equal(t, jobqueue.JobTypeStreamNewsPublished, gotJobType)
+				var payload jobqueue.StreamNewsPublishedPayload
+				require.NoError(t, json.Unmarshal(gotPayload, &payload))
+				assert.Equal(t, int64(5), payload.NewsID)
+				assert.True(t, payload.PublishedAt.Equal(future))
+				// 公開日時より前に実行されないよう切り上げる
+				assert.Equal(t, 91, gotOpts.DelaySeconds)
+			} else {
+				assert.Empty(t, gotJobType)
+			}
+		})
+	}
+}
+
+func TestStreamNotifier_NewsPublished_NoJobQueue(t *testing.T) {
+	publisher := &MockStreamPublisher{}
+	notifier := NewStreamNotifier(publisher, nil)
+
+	future := time.Now().Add(time.Hour)
+	notifier.NewsPublished(context.Background(), &model.DmNews{ID: 5, PublishedAt: &future})
+
+	assert.Empty(t, publisher.Events)
+}
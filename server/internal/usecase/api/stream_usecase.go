@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/taku-o/go-webdb-template/internal/stream"
+)
+
+// StreamSubscriberInterface はSSEのイベントを購読するブローカーのインターフェース
+type StreamSubscriberInterface interface {
+	Subscribe(topic, lastEventID, userID string) (*stream.Subscription, error)
+}
+
+// StreamUsecase はServer-Sent Eventsの購読を担当
+type StreamUsecase struct {
+	subscriber StreamSubscriberInterface
+}
+
+// NewStreamUsecase は新しいStreamUsecaseを作成
+func NewStreamUsecase(subscriber StreamSubscriberInterface) *StreamUsecase {
+	return &StreamUsecase{
+		subscriber: subscriber,
+	}
+}
+
+// Subscribe はトピックを購読
+// lastEventIDより後のイベントを再送し、userIDを指定した場合はそのユーザーのイベントのみを配信する
+func (u *StreamUsecase) Subscribe(topic, lastEventID, userID string) (*stream.Subscription, error) {
+	return u.subscriber.Subscribe(topic, lastEventID, userID)
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+)
+
+// WebhookServiceInterface はDmWebhookServiceのインターフェース
+type WebhookServiceInterface interface {
+	NewEvent(event string, data interface{}) (*model.WebhookEventPayload, error)
+	CreateDeliveries(ctx context.Context, event *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error)
+	CreateReplay(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error)
+	MarkUndeliverable(ctx context.Context, deliveryID int64, reason string) error
+}
+
+// WebhookDispatcherInterface はWebhookDispatcherのインターフェース
+type WebhookDispatcherInterface interface {
+	Dispatch(ctx context.Context, event string, data interface{})
+}
+
+// WebhookDispatcher はWebhookのイベントの配信ログ作成ジョブ・配信ジョブを登録する
+type WebhookDispatcher struct {
+	webhookService WebhookServiceInterface
+	jobQueueClient JobQueueClientInterface
+	maxRetry       int
+}
+
+// NewWebhookDispatcher は新しいWebhookDispatcherを作成
+// jobQueueClientがnilの場合、配信ログは配信失敗として記録される（管理画面から再送できる）
+func NewWebhookDispatcher(webhookService WebhookServiceInterface, jobQueueClient JobQueueClientInterface, webhookConfig *config.WebhookConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookService: webhookService,
+		jobQueueClient: jobQueueClient,
+		maxRetry:       webhookConfig.MaxRetry,
+	}
+}
+
+// Dispatch はイベントの配信ログ作成ジョブを1件登録する
+// 購読設定の取得・配信ログの作成はジョブ（Fanout）で行うため、リクエストの処理では行わない
+// ジョブを登録できない場合は配信ログを配信失敗として記録する。元の操作は完了しているため、エラーは返さずログに記録する
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event string, data interface{}) {
+	payload, err := d.webhookService.NewEvent(event, data)
+	if err != nil {
+		log.Printf("Failed to create webhook event for %s: %v", event, err)
+		return
+	}
+
+	if err := d.enqueueDispatch(ctx, payload); err != nil {
+		log.Printf("Failed to enqueue webhook dispatch for %s: %v", event, err)
+		d.markUndeliverable(ctx, payload, err)
+	}
+}
+
+// Fanout はイベントを購読している購読設定ごとに配信ログを作成し、配信ジョブを登録（配信ログ作成ジョブから呼び出す）
+// 配信ログの作成に失敗した場合はエラーを返す。配信ジョブを登録できない配信ログは配信失敗として記録する
+func (d *WebhookDispatcher) Fanout(ctx context.Context, event *model.WebhookEventPayload) error {
+	deliveries, err := d.webhookService.CreateDeliveries(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook deliveries for %s: %w", event.Event, err)
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.enqueue(ctx, delivery.ID); err != nil {
+			log.Printf("Failed to enqueue webhook delivery %d: %v", delivery.ID, err)
+			if err := d.webhookService.MarkUndeliverable(ctx, delivery.ID, err.Error()); err != nil {
+				log.Printf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// markUndeliverable は配信ログを作成し、配信失敗として記録（管理画面から再送できるようにする）
+func (d *WebhookDispatcher) markUndeliverable(ctx context.Context, event *model.WebhookEventPayload, reason error) {
+	deliveries, err := d.webhookService.CreateDeliveries(ctx, event)
+	if err != nil {
+		log.Printf("Failed to create webhook deliveries for %s: %v", event.Event, err)
+		return
+	}
+	for _, delivery := range deliveries {
+		if err := d.webhookService.MarkUndeliverable(ctx, delivery.ID, reason.Error()); err != nil {
+			log.Printf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+}
+
+// Replay は配信ログと同じペイロードで再送用の配信ログを作成し、配信ジョブを登録
+func (d *WebhookDispatcher) Replay(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+	if d.jobQueueClient == nil {
+		return nil, ErrJobQueueUnavailable
+	}
+
+	replay, err := d.webhookService.CreateReplay(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.enqueue(ctx, replay.ID); err != nil {
+		if markErr := d.webhookService.MarkUndeliverable(ctx, replay.ID, err.Error()); markErr != nil {
+			log.Printf("Failed to update webhook delivery %d: %v", replay.ID, markErr)
+		}
+		return nil, err
+	}
+
+	return replay, nil
+}
+
+// enqueueDispatch は配信ログ作成ジョブを即時実行で登録
+func (d *WebhookDispatcher) enqueueDispatch(ctx context.Context, event *model.WebhookEventPayload) error {
+	if d.jobQueueClient == nil {
+		return ErrJobQueueUnavailable
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook data: %w", err)
+	}
+	payloadBytes, err := json.Marshal(jobqueue.WebhookDispatchPayload{
+		EventID:   event.ID,
+		Event:     event.Event,
+		CreatedAt: event.CreatedAt,
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = d.jobQueueClient.EnqueueJob(ctx, jobqueue.JobTypeWebhookDispatch, payloadBytes, &JobOptions{
+		MaxRetry:           d.maxRetry,
+		ProcessImmediately: true,
+	})
+	return err
+}
+
+// enqueue は配信ジョブを即時実行で登録
+func (d *WebhookDispatcher) enqueue(ctx context.Context, deliveryID int64) error {
+	if d.jobQueueClient == nil {
+		return ErrJobQueueUnavailable
+	}
+
+	payloadBytes, err := json.Marshal(jobqueue.WebhookDeliveryPayload{DeliveryID: deliveryID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = d.jobQueueClient.EnqueueJob(ctx, jobqueue.JobTypeWebhookDelivery, payloadBytes, &JobOptions{
+		MaxRetry:           d.maxRetry,
+		ProcessImmediately: true,
+	})
+	return err
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/service/jobqueue"
+)
+
+// MockWebhookService はWebhookServiceInterfaceのモック
+type MockWebhookService struct {
+	CreateDeliveriesFunc  func(ctx context.Context, event *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error)
+	CreateReplayFunc      func(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error)
+	MarkUndeliverableFunc func(ctx context.Context, deliveryID int64, reason string) error
+}
+
+func (m *MockWebhookService) NewEvent(event string, data interface{}) (*model.WebhookEventPayload, error) {
+	return &model.WebhookEventPayload{
+		ID:        "evt1",
+		Event:     event,
+		CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Data:      data,
+	}, nil
+}
+
+func (m *MockWebhookService) CreateDeliveries(ctx context.Context, event *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+	if m.CreateDeliveriesFunc != nil {
+		return m.CreateDeliveriesFunc(ctx, event)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookService) CreateReplay(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+	if m.CreateReplayFunc != nil {
+		return m.CreateReplayFunc(ctx, deliveryID)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookService) MarkUndeliverable(ctx context.Context, deliveryID int64, reason string) error {
+	if m.MarkUndeliverableFunc != nil {
+		return m.MarkUndeliverableFunc(ctx, deliveryID, reason)
+	}
+	return nil
+}
+
+// MockWebhookDispatcher はWebhookDispatcherInterfaceのモック
+type MockWebhookDispatcher struct {
+	Events []string
+	Data   []interface{}
+}
+
+func (m *MockWebhookDispatcher) Dispatch(ctx context.Context, event string, data interface{}) {
+	m.Events = append(m.Events, event)
+	m.Data = append(m.Data, data)
+}
+
+func TestWebhookDispatcher_Dispatch(t *testing.T) {
+	var jobTypes []string
+	var dispatched jobqueue.WebhookDispatchPayload
+	var gotOpts *JobOptions
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+			jobTypes = append(jobTypes, jobType)
+			require.NoError(t, json.Unmarshal(payload, &dispatched))
+			gotOpts = opts
+			return &JobInfo{ID: "job"}, nil
+		},
+	}
+	svc := &MockWebhookService{
+		CreateDeliveriesFunc: func(ctx context.Context, event *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+			t.Fatal("CreateDeliveries should not be called on the request path")
+			return nil, nil
+		},
+	}
+	d := NewWebhookDispatcher(svc, client, &config.WebhookConfig{MaxRetry: 5})
+
+	d.Dispatch(context.Background(), model.WebhookEventUserCreated, map[string]string{"id": "u1"})
+
+	// リクエストの処理では配信ログ作成ジョブを1件登録するのみ
+	assert.Equal(t, []string{jobqueue.JobTypeWebhookDispatch}, jobTypes)
+	assert.Equal(t, "evt1", dispatched.EventID)
+	assert.Equal(t, model.WebhookEventUserCreated, dispatched.Event)
+	assert.JSONEq(t, `{"id":"u1"}`, string(dispatched.Data))
+	assert.True(t, gotOpts.ProcessImmediately)
+	assert.Equal(t, 5, gotOpts.MaxRetry)
+}
+
+func TestWebhookDispatcher_Dispatch_EnqueueFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		client JobQueueClientInterface
+	}{
+		{name: "ジョブキューが利用できない", client: nil},
+		{name: "ジョブ登録に失敗", client: &MockJobQueueClient{
+			EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+				return nil, errors.New("redis down")
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var marked []int64
+			svc := &MockWebhookService{
+				CreateDeliveriesFunc: func(ctx context.Context, event *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+					assert.Equal(t, model.WebhookEventPostDeleted, event.Event)
+					return []*model.DmWebhookDelivery{{ID: 7}}, nil
+				},
+				MarkUndeliverableFunc: func(ctx context.Context, deliveryID int64, reason string) error {
+					marked = append(marked, deliveryID)
+					return nil
+				},
+			}
+			d := NewWebhookDispatcher(svc, tt.client, &config.WebhookConfig{MaxRetry: 5})
+
+			// 元の操作は完了しているため、エラーは返さない
+			// 配信ログは配信失敗として記録し、管理画面から再送できるようにする
+			d.Dispatch(context.Background(), model.WebhookEventPostDeleted, nil)
+			assert.Equal(t, []int64{7}, marked)
+		})
+	}
+}
+
+func TestWebhookDispatcher_Fanout(t *testing.T) {
+	var enqueued []int64
+	var gotOpts *JobOptions
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+			assert.Equal(t, jobqueue.JobTypeWebhookDelivery, jobType)
+			var p jobqueue.WebhookDeliveryPayload
+			require.NoError(t, json.Unmarshal(payload, &p))
+			enqueued = append(enqueued, p.DeliveryID)
+			gotOpts = opts
+			return &JobInfo{ID: "job"}, nil
+		},
+	}
+	event := &model.WebhookEventPayload{ID: "evt1", Event: model.WebhookEventUserCreated}
+	svc := &MockWebhookService{
+		CreateDeliveriesFunc: func(ctx context.Context, e *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+			assert.Same(t, event, e)
+			return []*model.DmWebhookDelivery{{ID: 1}, {ID: 2}}, nil
+		},
+		MarkUndeliverableFunc: func(ctx context.Context, deliveryID int64, reason string) error {
+			t.Fatal("MarkUndeliverable should not be called")
+			return nil
+		},
+	}
+	d := NewWebhookDispatcher(svc, client, &config.WebhookConfig{MaxRetry: 5})
+
+	require.NoError(t, d.Fanout(context.Background(), event))
+
+	assert.Equal(t, []int64{1, 2}, enqueued)
+	assert.True(t, gotOpts.ProcessImmediately)
+	assert.Equal(t, 5, gotOpts.MaxRetry)
+}
+
+func TestWebhookDispatcher_Fanout_Errors(t *testing.T) {
+	event := &model.WebhookEventPayload{ID: "evt1", Event: model.WebhookEventUserCreated}
+
+	// 配信ログの作成に失敗した場合はエラーを返す（ジョブのリトライに任せる）
+	svc := &MockWebhookService{
+		CreateDeliveriesFunc: func(ctx context.Context, e *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	d := NewWebhookDispatcher(svc, &MockJobQueueClient{}, &config.WebhookConfig{MaxRetry: 5})
+	assert.Error(t, d.Fanout(context.Background(), event))
+
+	// 配信ジョブを登録できない配信ログは配信失敗として記録する
+	var marked []int64
+	svc = &MockWebhookService{
+		CreateDeliveriesFunc: func(ctx context.Context, e *model.WebhookEventPayload) ([]*model.DmWebhookDelivery, error) {
+			return []*model.DmWebhookDelivery{{ID: 3}}, nil
+		},
+		MarkUndeliverableFunc: func(ctx context.Context, deliveryID int64, reason string) error {
+			marked = append(marked, deliveryID)
+			return nil
+		},
+	}
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+			return nil, errors.New("redis down")
+		},
+	}
+	d = NewWebhookDispatcher(svc, client, &config.WebhookConfig{MaxRetry: 5})
+	require.NoError(t, d.Fanout(context.Background(), event))
+	assert.Equal(t, []int64{3}, marked)
+}
+
+func TestWebhookDispatcher_Replay(t *testing.T) {
+	var enqueued int64
+	client := &MockJobQueueClient{
+		EnqueueJobFunc: func(ctx context.Context, jobType string, payload []byte, opts *JobOptions) (*JobInfo, error) {
+			var p jobqueue.WebhookDeliveryPayload
+			require.NoError(t, json.Unmarshal(payload, &p))
+			enqueued = p.DeliveryID
+			return &JobInfo{ID: "job"}, nil
+		},
+	}
+	svc := &MockWebhookService{
+		CreateReplayFunc: func(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+			return &model.DmWebhookDelivery{ID: 11, ReplayOf: &deliveryID}, nil
+		},
+	}
+	d := NewWebhookDispatcher(svc, client, &config.WebhookConfig{MaxRetry: 5})
+
+	replay, err := d.Replay(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), replay.ID)
+	assert.Equal(t, int64(11), enqueued)
+}
+
+func TestWebhookDispatcher_Replay_JobQueueUnavailable(t *testing.T) {
+	svc := &MockWebhookService{
+		CreateReplayFunc: func(ctx context.Context, deliveryID int64) (*model.DmWebhookDelivery, error) {
+			t.Fatal("CreateReplay should not be called")
+			return nil, nil
+		},
+	}
+	d := NewWebhookDispatcher(svc, nil, &config.WebhookConfig{MaxRetry: 5})
+
+	_, err := d.Replay(context.Background(), 10)
+	assert.ErrorIs(t, err, ErrJobQueueUnavailable)
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/taku-o/go-webdb-template/internal/config"
 	"github.com/taku-o/go-webdb-template/internal/service"
 )
 
@@ -22,3 +24,16 @@ func NewGenerateSecretUsecase(secretService service.SecretServiceInterface) *Gen
 func (u *GenerateSecretUsecase) GenerateSecret(ctx context.Context) (string, error) {
 	return u.secretService.GenerateSecretKey(ctx)
 }
+
+// RotateSigningKey はPublic APIキーの署名鍵をローテーションする
+// algorithmが空の場合はHS256を使用する
+func (u *GenerateSecretUsecase) RotateSigningKey(ctx context.Context, configPath, algorithm, keyDir string) (*config.SigningKeyRotation, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("config path is required")
+	}
+	if algorithm == "" {
+		algorithm = "HS256"
+	}
+
+	return u.secretService.RotateSigningKey(ctx, configPath, algorithm, keyDir)
+}
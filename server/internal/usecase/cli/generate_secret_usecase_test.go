@@ -6,11 +6,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/taku-o/go-webdb-template/internal/config"
 )
 
 // MockSecretServiceInterface はSecretServiceInterfaceのモック
 type MockSecretServiceInterface struct {
 	GenerateSecretKeyFunc func(ctx context.Context) (string, error)
+	RotateSigningKeyFunc  func(ctx context.Context, configPath, algorithm, keyDir string) (*config.SigningKeyRotation, error)
 }
 
 func (m *MockSecretServiceInterface) GenerateSecretKey(ctx context.Context) (string, error) {
@@ -20,6 +23,13 @@ func (m *MockSecretServiceInterface) GenerateSecretKey(ctx context.Context) (str
 	return "", nil
 }
 
+func (m *MockSecretServiceInterface) RotateSigningKey(ctx context.Context, configPath, algorithm, keyDir string) (*config.SigningKeyRotation, error) {
+	if m.RotateSigningKeyFunc != nil {
+		return m.RotateSigningKeyFunc(ctx, configPath, algorithm, keyDir)
+	}
+	return nil, nil
+}
+
 func TestGenerateSecretUsecase_GenerateSecret(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -69,3 +79,25 @@ func TestGenerateSecretUsecase_GenerateSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateSecretUsecase_RotateSigningKey(t *testing.T) {
+	var gotAlgorithm string
+	mockService := &MockSecretServiceInterface{
+		RotateSigningKeyFunc: func(ctx context.Context, configPath, algorithm, keyDir string) (*config.SigningKeyRotation, error) {
+			gotAlgorithm = algorithm
+			return &config.SigningKeyRotation{KeyID: "k1"}, nil
+		},
+	}
+	usecase := NewGenerateSecretUsecase(mockService)
+	ctx := context.Background()
+
+	// アルゴリズムの指定がない場合はHS256
+	rotation, err := usecase.RotateSigningKey(ctx, "config.yaml", "", "keys")
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", rotation.KeyID)
+	assert.Equal(t, "HS256", gotAlgorithm)
+
+	// 設定ファイルの指定は必須
+	_, err = usecase.RotateSigningKey(ctx, "", "HS256", "keys")
+	assert.Error(t, err)
+}
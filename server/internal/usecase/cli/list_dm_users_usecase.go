@@ -21,5 +21,5 @@ func NewListDmUsersUsecase(dmUserService usecaseapi.DmUserServiceInterface) *Lis
 
 // ListDmUsers はユーザー一覧を取得
 func (u *ListDmUsersUsecase) ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
-	return u.dmUserService.ListDmUsers(ctx, limit, offset)
+	return u.dmUserService.ListDmUsers(ctx, limit, offset, "", "")
 }
@@ -12,7 +12,7 @@ import (
 
 // MockDmUserServiceInterface はDmUserServiceInterfaceのモック
 type MockDmUserServiceInterface struct {
-	ListDmUsersFunc func(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+	ListDmUsersFunc func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
 }
 
 func (m *MockDmUserServiceInterface) CreateDmUser(ctx context.Context, req *model.CreateDmUserRequest) (*model.DmUser, error) {
@@ -23,9 +23,13 @@ func (m *MockDmUserServiceInterface) GetDmUser(ctx context.Context, id string) (
 	return nil, nil
 }
 
-func (m *MockDmUserServiceInterface) ListDmUsers(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+func (m *MockDmUserServiceInterface) BatchGetDmUsers(ctx context.Context, ids []string) (*model.DmUserBatchGetResult, error) {
+	return nil, nil
+}
+
+func (m *MockDmUserServiceInterface) ListDmUsers(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 	if m.ListDmUsersFunc != nil {
-		return m.ListDmUsersFunc(ctx, limit, offset)
+		return m.ListDmUsersFunc(ctx, limit, offset, filter, sort)
 	}
 	return nil, nil
 }
@@ -34,6 +38,10 @@ func (m *MockDmUserServiceInterface) UpdateDmUser(ctx context.Context, id string
 	return nil, nil
 }
 
+func (m *MockDmUserServiceInterface) PatchDmUser(ctx context.Context, id string, patch []byte) (*model.DmUser, error) {
+	return nil, nil
+}
+
 func (m *MockDmUserServiceInterface) DeleteDmUser(ctx context.Context, id string) error {
 	return nil
 }
@@ -47,7 +55,7 @@ func TestListDmUsersUsecase_ListDmUsers(t *testing.T) {
 		name        string
 		limit       int
 		offset      int
-		mockFunc    func(ctx context.Context, limit, offset int) ([]*model.DmUser, error)
+		mockFunc    func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error)
 		wantUsers   []*model.DmUser
 		wantError   bool
 		expectedErr string
@@ -56,7 +64,7 @@ func TestListDmUsersUsecase_ListDmUsers(t *testing.T) {
 			name:   "success with users",
 			limit:  20,
 			offset: 0,
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 				return []*model.DmUser{
 					{ID: "1", Name: "User 1", Email: "user1@example.com"},
 					{ID: "2", Name: "User 2", Email: "user2@example.com"},
@@ -72,7 +80,7 @@ func TestListDmUsersUsecase_ListDmUsers(t *testing.T) {
 			name:   "success with empty list",
 			limit:  20,
 			offset: 0,
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 				return []*model.DmUser{}, nil
 			},
 			wantUsers: []*model.DmUser{},
@@ -82,7 +90,7 @@ func TestListDmUsersUsecase_ListDmUsers(t *testing.T) {
 			name:   "service error",
 			limit:  20,
 			offset: 0,
-			mockFunc: func(ctx context.Context, limit, offset int) ([]*model.DmUser, error) {
+			mockFunc: func(ctx context.Context, limit, offset int, filter, sort string) ([]*model.DmUser, error) {
 				return nil, errors.New("database error")
 			},
 			wantUsers:   nil,
@@ -0,0 +1,75 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// 一括登録の対象
+const (
+	BulkImportTargetDmUsers = "dm_users"
+	BulkImportTargetDmPosts = "dm_posts"
+)
+
+// BulkImportServiceInterface はDmBulkImportServiceのインターフェース
+type BulkImportServiceInterface interface {
+	ImportDmUsers(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error)
+	ImportDmPosts(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error)
+}
+
+// WebhookDispatcherInterface はWebhookDispatcherのイベント送信用のインターフェース
+type WebhookDispatcherInterface interface {
+	Dispatch(ctx context.Context, event string, data interface{})
+}
+
+// BulkImportUsecase は一括登録ジョブのビジネスロジックを実装
+type BulkImportUsecase struct {
+	service           BulkImportServiceInterface
+	webhookDispatcher WebhookDispatcherInterface
+}
+
+// NewBulkImportUsecase は新しいBulkImportUsecaseを作成
+// webhookDispatcherがnilの場合、Webhookは送信しない
+func NewBulkImportUsecase(service BulkImportServiceInterface, webhookDispatcher WebhookDispatcherInterface) *BulkImportUsecase {
+	return &BulkImportUsecase{
+		service:           service,
+		webhookDispatcher: webhookDispatcher,
+	}
+}
+
+// Execute は対象に応じて一括登録を実行し、登録したユーザー・投稿のWebhookを送信
+func (u *BulkImportUsecase) Execute(ctx context.Context, target string, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	var result *model.BulkImportResult
+	var err error
+	switch target {
+	case BulkImportTargetDmUsers:
+		result, err = u.service.ImportDmUsers(ctx, rows)
+	case BulkImportTargetDmPosts:
+		result, err = u.service.ImportDmPosts(ctx, rows)
+	default:
+		return nil, fmt.Errorf("unsupported bulk import target: %s", target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	DispatchBulkImportWebhooks(ctx, u.webhookDispatcher, result)
+	return result, nil
+}
+
+// DispatchBulkImportWebhooks は一括登録したユーザー・投稿ごとにuser.created・post.createdのWebhookの配信を依頼
+// 単体の登録APIと同じイベントを送信する（webhookDispatcherがnilの場合は何もしない）
+func DispatchBulkImportWebhooks(ctx context.Context, webhookDispatcher WebhookDispatcherInterface, result *model.BulkImportResult) {
+	if webhookDispatcher == nil {
+		return
+	}
+	for _, dmUser := range result.CreatedDmUsers {
+		webhookDispatcher.Dispatch(ctx, model.WebhookEventUserCreated, dmUser)
+	}
+	for _, dmPost := range result.CreatedDmPosts {
+		webhookDispatcher.Dispatch(ctx, model.WebhookEventPostCreated, dmPost)
+	}
+}
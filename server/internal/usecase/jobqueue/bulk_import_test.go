@@ -0,0 +1,88 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockBulkImportService はテスト用のモックサービス
+type MockBulkImportService struct {
+	CalledTarget string
+}
+
+func (m *MockBulkImportService) ImportDmUsers(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	m.CalledTarget = BulkImportTargetDmUsers
+	result := model.NewBulkImportResult(len(rows))
+	result.CreatedDmUsers = []*model.DmUser{{ID: "u1"}}
+	return result, nil
+}
+
+func (m *MockBulkImportService) ImportDmPosts(ctx context.Context, rows []json.RawMessage) (*model.BulkImportResult, error) {
+	m.CalledTarget = BulkImportTargetDmPosts
+	result := model.NewBulkImportResult(len(rows))
+	result.CreatedDmPosts = []*model.DmPost{{ID: "p1"}, {ID: "p2"}}
+	return result, nil
+}
+
+// MockWebhookDispatcher はテスト用のモック
+type MockWebhookDispatcher struct {
+	Events []string
+	Data   []interface{}
+}
+
+func (m *MockWebhookDispatcher) Dispatch(ctx context.Context, event string, data interface{}) {
+	m.Events = append(m.Events, event)
+	m.Data = append(m.Data, data)
+}
+
+func TestBulkImportUsecase_Execute(t *testing.T) {
+	rows := []json.RawMessage{json.RawMessage(`{}`), json.RawMessage(`{}`)}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantErr    bool
+		wantEvents []string
+	}{
+		{name: "dm_usersを登録", target: BulkImportTargetDmUsers, wantEvents: []string{model.WebhookEventUserCreated}},
+		{name: "dm_postsを登録", target: BulkImportTargetDmPosts, wantEvents: []string{model.WebhookEventPostCreated, model.WebhookEventPostCreated}},
+		{name: "未対応の対象はエラー", target: "dm_news", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockBulkImportService{}
+			dispatcher := &MockWebhookDispatcher{}
+			usecase := NewBulkImportUsecase(mockService, dispatcher)
+
+			result, err := usecase.Execute(context.Background(), tt.target, rows)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Empty(t, mockService.CalledTarget)
+				assert.Empty(t, dispatcher.Events)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.Total)
+			assert.Equal(t, tt.target, mockService.CalledTarget)
+			// 登録したユーザー・投稿ごとにWebhookを送信する
+			assert.Equal(t, tt.wantEvents, dispatcher.Events)
+		})
+	}
+}
+
+func TestBulkImportUsecase_Execute_NoWebhookDispatcher(t *testing.T) {
+	usecase := NewBulkImportUsecase(&MockBulkImportService{}, nil)
+
+	result, err := usecase.Execute(context.Background(), BulkImportTargetDmUsers, []json.RawMessage{json.RawMessage(`{}`)})
+	require.NoError(t, err)
+	assert.Len(t, result.CreatedDmUsers, 1)
+}
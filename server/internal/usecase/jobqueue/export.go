@@ -0,0 +1,30 @@
+package jobqueue
+
+import (
+	"context"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// ExportServiceInterface はDmExportServiceのインターフェース
+type ExportServiceInterface interface {
+	Export(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error)
+}
+
+// ExportUsecase はエクスポートジョブのビジネスロジックを実装
+type ExportUsecase struct {
+	service ExportServiceInterface
+}
+
+// NewExportUsecase は新しいExportUsecaseを作成
+func NewExportUsecase(service ExportServiceInterface) *ExportUsecase {
+	return &ExportUsecase{
+		service: service,
+	}
+}
+
+// Execute はエクスポートを実行
+// 出力ファイルのキーはjobIDから決まるため、リトライ時は同じファイルを上書きする
+func (u *ExportUsecase) Execute(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error) {
+	return u.service.Export(ctx, jobID, req, progress)
+}
@@ -0,0 +1,42 @@
+package jobqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockExportService はテスト用のモックサービス
+type MockExportService struct {
+	CalledJobID string
+}
+
+func (m *MockExportService) Export(ctx context.Context, jobID string, req *model.ExportRequest, progress func(model.ExportProgress)) (*model.ExportResult, error) {
+	m.CalledJobID = jobID
+	if progress != nil {
+		progress(model.ExportProgress{Processed: 1, Total: 1})
+	}
+	return &model.ExportResult{Completed: true, FileKey: "exports/" + jobID + "." + req.Format}, nil
+}
+
+func TestExportUsecase_Execute(t *testing.T) {
+	svc := &MockExportService{}
+	usecase := NewExportUsecase(svc)
+
+	var progressCalled bool
+	result, err := usecase.Execute(context.Background(), "job1", &model.ExportRequest{
+		Target: model.ExportTargetDmUsers,
+		Format: model.ExportFormatCSV,
+	}, func(p model.ExportProgress) {
+		progressCalled = true
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "job1", svc.CalledJobID)
+	assert.Equal(t, "exports/job1.csv", result.FileKey)
+	assert.True(t, progressCalled)
+}
@@ -0,0 +1,64 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+	"github.com/taku-o/go-webdb-template/internal/stream"
+)
+
+// StreamNewsServiceInterface はDmNewsServiceのインターフェース
+type StreamNewsServiceInterface interface {
+	GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error)
+}
+
+// StreamPublisherInterface はSSEのイベントを配信するブローカーのインターフェース
+type StreamPublisherInterface interface {
+	Publish(ctx context.Context, event *model.StreamEvent) error
+}
+
+// StreamNewsPublishedUsecase は予約公開ニュースのSSE配信ジョブのビジネスロジックを実装
+type StreamNewsPublishedUsecase struct {
+	dmNewsService StreamNewsServiceInterface
+	publisher     StreamPublisherInterface
+}
+
+// NewStreamNewsPublishedUsecase は新しいStreamNewsPublishedUsecaseを作成
+func NewStreamNewsPublishedUsecase(dmNewsService StreamNewsServiceInterface, publisher StreamPublisherInterface) *StreamNewsPublishedUsecase {
+	return &StreamNewsPublishedUsecase{
+		dmNewsService: dmNewsService,
+		publisher:     publisher,
+	}
+}
+
+// Execute は公開日時を迎えたニュースのイベントを配信
+// ニュースが削除・非公開にされた場合や公開日時が変更された場合は配信しない（変更後の公開日時で別のジョブが登録される）
+func (u *StreamNewsPublishedUsecase) Execute(ctx context.Context, newsID int64, publishedAt time.Time) error {
+	dmNews, err := u.dmNewsService.GetPublishedDmNews(ctx, newsID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			log.Printf("Skip news stream event: news %d is not published", newsID)
+			return nil
+		}
+		return err
+	}
+	// DBに保存される精度の違いを吸収するため秒単位で比較する
+	if !dmNews.PublishedAt.Truncate(time.Second).Equal(publishedAt.Truncate(time.Second)) {
+		log.Printf("Skip news stream event: published_at of news %d has been changed", newsID)
+		return nil
+	}
+
+	event, err := stream.NewNewsPublishedEvent(dmNews)
+	if err != nil {
+		return err
+	}
+	if err := u.publisher.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish news stream event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockStreamNewsService はテスト用のモックサービス
+type MockStreamNewsService struct {
+	News *model.DmNews
+	Err  error
+}
+
+func (m *MockStreamNewsService) GetPublishedDmNews(ctx context.Context, id int64) (*model.DmNews, error) {
+	return m.News, m.Err
+}
+
+// MockStreamPublisher はテスト用のモックブローカー
+type MockStreamPublisher struct {
+	Events []*model.StreamEvent
+}
+
+func (m *MockStreamPublisher) Publish(ctx context.Context, event *model.StreamEvent) error {
+	m.Events = append(m.Events, event)
+	return nil
+}
+
+func TestStreamNewsPublishedUsecase_Execute(t *testing.T) {
+	publishedAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	// DBから読み込んだ値は精度・タイムゾーンが異なる場合がある
+	storedAt := publishedAt.Add(300 * time.Microsecond).In(time.FixedZone("JST", 9*60*60))
+	changedAt := publishedAt.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		service     *MockStreamNewsService
+		wantPublish bool
+		wantErr     bool
+	}{
+		{"published", &MockStreamNewsService{News: &model.DmNews{ID: 1, PublishedAt: &storedAt}}, true, false},
+		{"published_at changed", &MockStreamNewsService{News: &model.DmNews{ID: 1, PublishedAt: &changedAt}}, false, false},
+		{"unpublished or deleted", &MockStreamNewsService{Err: fmt.Errorf("failed to get news: %w: 1", apperror.NotFound("news not found"))}, false, false},
+		{"db error", &MockStreamNewsService{Err: errors.New("connection refused")}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &MockStreamPublisher{}
+			usecase := NewStreamNewsPublishedUsecase(tt.service, publisher)
+
+			err := usecase.Execute(context.Background(), 1, publishedAt)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.wantPublish {
+				require.Len(t, publisher.Events, 1)
+				assert.Equal(t, model.StreamEventNewsPublished, publisher.Events[0].Type)
+			} else {
+				assert.Empty(t, publisher.Events)
+			}
+		})
+	}
+}
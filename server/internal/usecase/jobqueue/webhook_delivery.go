@@ -0,0 +1,28 @@
+package jobqueue
+
+import (
+	"context"
+)
+
+// WebhookDeliveryServiceInterface はDmWebhookServiceのインターフェース
+type WebhookDeliveryServiceInterface interface {
+	Deliver(ctx context.Context, deliveryID int64, lastAttempt bool) error
+}
+
+// WebhookDeliveryUsecase はWebhook配信ジョブのビジネスロジックを実装
+type WebhookDeliveryUsecase struct {
+	service WebhookDeliveryServiceInterface
+}
+
+// NewWebhookDeliveryUsecase は新しいWebhookDeliveryUsecaseを作成
+func NewWebhookDeliveryUsecase(service WebhookDeliveryServiceInterface) *WebhookDeliveryUsecase {
+	return &WebhookDeliveryUsecase{
+		service: service,
+	}
+}
+
+// Execute は配信ログのWebhookを配信
+// lastAttemptがtrueの場合、配信に失敗すると配信ログを失敗として記録する
+func (u *WebhookDeliveryUsecase) Execute(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+	return u.service.Deliver(ctx, deliveryID, lastAttempt)
+}
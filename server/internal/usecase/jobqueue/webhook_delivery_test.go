@@ -0,0 +1,32 @@
+package jobqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWebhookDeliveryService はテスト用のモックサービス
+type MockWebhookDeliveryService struct {
+	CalledDeliveryID  int64
+	CalledLastAttempt bool
+}
+
+func (m *MockWebhookDeliveryService) Deliver(ctx context.Context, deliveryID int64, lastAttempt bool) error {
+	m.CalledDeliveryID = deliveryID
+	m.CalledLastAttempt = lastAttempt
+	return nil
+}
+
+func TestWebhookDeliveryUsecase_Execute(t *testing.T) {
+	svc := &MockWebhookDeliveryService{}
+	usecase := NewWebhookDeliveryUsecase(svc)
+
+	err := usecase.Execute(context.Background(), 7, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), svc.CalledDeliveryID)
+	assert.True(t, svc.CalledLastAttempt)
+}
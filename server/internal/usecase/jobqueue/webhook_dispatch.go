@@ -0,0 +1,29 @@
+package jobqueue
+
+import (
+	"context"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// WebhookFanoutInterface はWebhookDispatcherの配信ログ作成用のインターフェース
+type WebhookFanoutInterface interface {
+	Fanout(ctx context.Context, event *model.WebhookEventPayload) error
+}
+
+// WebhookDispatchUsecase はWebhookのイベントの配信ログ作成ジョブのビジネスロジックを実装
+type WebhookDispatchUsecase struct {
+	dispatcher WebhookFanoutInterface
+}
+
+// NewWebhookDispatchUsecase は新しいWebhookDispatchUsecaseを作成
+func NewWebhookDispatchUsecase(dispatcher WebhookFanoutInterface) *WebhookDispatchUsecase {
+	return &WebhookDispatchUsecase{
+		dispatcher: dispatcher,
+	}
+}
+
+// Execute はイベントを購読している購読設定ごとに配信ログを作成し、配信ジョブを登録
+func (u *WebhookDispatchUsecase) Execute(ctx context.Context, event *model.WebhookEventPayload) error {
+	return u.dispatcher.Fanout(ctx, event)
+}
@@ -0,0 +1,32 @@
+package jobqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+// MockWebhookFanout はテスト用のモック
+type MockWebhookFanout struct {
+	CalledEvent *model.WebhookEventPayload
+}
+
+func (m *MockWebhookFanout) Fanout(ctx context.Context, event *model.WebhookEventPayload) error {
+	m.CalledEvent = event
+	return nil
+}
+
+func TestWebhookDispatchUsecase_Execute(t *testing.T) {
+	fanout := &MockWebhookFanout{}
+	usecase := NewWebhookDispatchUsecase(fanout)
+
+	event := &model.WebhookEventPayload{ID: "evt1", Event: model.WebhookEventPostCreated}
+	err := usecase.Execute(context.Background(), event)
+	require.NoError(t, err)
+
+	assert.Same(t, event, fanout.CalledEvent)
+}
@@ -0,0 +1,229 @@
+// Package xlsx は行を逐次書き出すストリーミングXLSXライターを提供する
+// 全行をメモリに保持せず、ZIPエントリへ直接書き込むため大量データの出力に使用できる
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxRowsPerSheet はシートあたりの最大行数（Excelの上限）
+// 上限を超えた場合は新しいシートに続きを書き込む
+const MaxRowsPerSheet = 1048576
+
+// MaxCellLength はセルあたりの最大文字数（Excelの上限）
+const MaxCellLength = 32767
+
+// maxSheetNameLength はシート名の最大文字数（Excelの上限）
+const maxSheetNameLength = 31
+
+// ErrClosed はクローズ済みのWriterに書き込んだ場合のエラー
+var ErrClosed = errors.New("xlsx: writer is closed")
+
+// Writer はストリーミングXLSXライター
+// すべてのセルはインライン文字列として出力する
+type Writer struct {
+	zw         *zip.Writer
+	sheetName  string
+	header     []string
+	maxRows    int
+	sheet      *bufio.Writer
+	sheetCount int
+	rowInSheet int
+	closed     bool
+}
+
+// NewWriter は新しいWriterを作成
+// sheetNameは1枚目のシート名で、2枚目以降は"_2"、"_3"…を付与する
+func NewWriter(w io.Writer, sheetName string) *Writer {
+	if sheetName == "" {
+		sheetName = "Sheet"
+	}
+	return &Writer{
+		zw:        zip.NewWriter(w),
+		sheetName: sheetName,
+		maxRows:   MaxRowsPerSheet,
+	}
+}
+
+// SetHeader はヘッダー行を設定
+// ヘッダー行は各シートの先頭に出力される
+func (w *Writer) SetHeader(header []string) {
+	w.header = append([]string(nil), header...)
+}
+
+// WriteRow は1行を書き込む
+func (w *Writer) WriteRow(cells []string) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if w.sheet == nil || w.rowInSheet >= w.maxRows {
+		if err := w.nextSheet(); err != nil {
+			return err
+		}
+	}
+	return w.writeRow(cells)
+}
+
+// Close はシートとブック情報を書き込み、XLSXファイルを完成させる
+// 基になるio.Writerはクローズしない
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	// 行が1件もない場合もヘッダーのみのシートを作成する
+	if w.sheet == nil {
+		if err := w.nextSheet(); err != nil {
+			return err
+		}
+	}
+	if err := w.finishSheet(); err != nil {
+		return err
+	}
+	if err := w.writeWorkbook(); err != nil {
+		return err
+	}
+	w.closed = true
+	return w.zw.Close()
+}
+
+// nextSheet は現在のシートを閉じ、新しいシートを開始する
+func (w *Writer) nextSheet() error {
+	if w.sheet != nil {
+		if err := w.finishSheet(); err != nil {
+			return err
+		}
+	}
+
+	w.sheetCount++
+	entry, err := w.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", w.sheetCount))
+	if err != nil {
+		return fmt.Errorf("xlsx: failed to create sheet: %w", err)
+	}
+	w.sheet = bufio.NewWriter(entry)
+	w.rowInSheet = 0
+
+	if _, err := w.sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+	if len(w.header) > 0 {
+		return w.writeRow(w.header)
+	}
+	return nil
+}
+
+// finishSheet は現在のシートの終端を書き込む
+func (w *Writer) finishSheet() error {
+	if _, err := w.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return w.sheet.Flush()
+}
+
+// writeRow は現在のシートに1行を書き込む
+func (w *Writer) writeRow(cells []string) error {
+	w.rowInSheet++
+	rowNum := strconv.Itoa(w.rowInSheet)
+
+	var b strings.Builder
+	b.WriteString(`<row r="`)
+	b.WriteString(rowNum)
+	b.WriteString(`">`)
+	for i, cell := range cells {
+		b.WriteString(`<c r="`)
+		b.WriteString(ColumnName(i))
+		b.WriteString(rowNum)
+		b.WriteString(`" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&b, []byte(truncateCell(cell))); err != nil {
+			return err
+		}
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+
+	_, err := w.sheet.WriteString(b.String())
+	return err
+}
+
+// writeWorkbook はブック・リレーション・コンテンツタイプを書き込む
+func (w *Writer) writeWorkbook() error {
+	var contentTypes, workbook, workbookRels strings.Builder
+
+	contentTypes.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i := 1; i <= w.sheetCount; i++ {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+		workbook.WriteString(`<sheet name="`)
+		if err := xml.EscapeText(&workbook, []byte(w.sheetTitle(i))); err != nil {
+			return err
+		}
+		fmt.Fprintf(&workbook, `" sheetId="%d" r:id="rId%d"/>`, i, i)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+	}
+	for _, f := range files {
+		entry, err := w.zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("xlsx: failed to create %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(entry, f.content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sheetTitle はシート番号に対応するシート名を返す
+func (w *Writer) sheetTitle(index int) string {
+	suffix := ""
+	if index > 1 {
+		suffix = fmt.Sprintf("_%d", index)
+	}
+	name := []rune(w.sheetName)
+	if len(name)+len(suffix) > maxSheetNameLength {
+		name = name[:maxSheetNameLength-len(suffix)]
+	}
+	return string(name) + suffix
+}
+
+// ColumnName は0始まりの列番号をExcelの列名（A, B, …, Z, AA, …）に変換
+func ColumnName(index int) string {
+	name := ""
+	for n := index + 1; n > 0; n = (n - 1) / 26 {
+		name = string(rune('A'+(n-1)%26)) + name
+	}
+	return name
+}
+
+// truncateCell はセルの文字数をExcelの上限に切り詰める
+func truncateCell(s string) string {
+	if utf8.RuneCountInString(s) <= MaxCellLength {
+		return s
+	}
+	return string([]rune(s)[:MaxCellLength])
+}
@@ -0,0 +1,100 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readEntries はXLSX（ZIP）の各エントリの内容を読み込む
+func readEntries(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		entries[f.Name] = string(content)
+	}
+	return entries
+}
+
+func TestWriter_WriteRow(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "dm_users")
+	w.SetHeader([]string{"id", "name"})
+	require.NoError(t, w.WriteRow([]string{"1", "Alice & <Bob>"}))
+	require.NoError(t, w.WriteRow([]string{"2", "太郎"}))
+	require.NoError(t, w.Close())
+
+	entries := readEntries(t, buf.Bytes())
+	assert.Contains(t, entries, "[Content_Types].xml")
+	assert.Contains(t, entries, "_rels/.rels")
+	assert.Contains(t, entries, "xl/workbook.xml")
+	assert.Contains(t, entries, "xl/_rels/workbook.xml.rels")
+
+	sheet := entries["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">Alice &amp; &lt;Bob&gt;</t></is></c>`)
+	assert.Contains(t, sheet, `太郎`)
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="dm_users" sheetId="1" r:id="rId1"/>`)
+}
+
+func TestWriter_SheetRollover(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "dm_posts")
+	w.maxRows = 3 // ヘッダー + 2行で次のシートへ
+	w.SetHeader([]string{"id"})
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		require.NoError(t, w.WriteRow([]string{id}))
+	}
+	require.NoError(t, w.Close())
+
+	entries := readEntries(t, buf.Bytes())
+	assert.Contains(t, entries, "xl/worksheets/sheet3.xml")
+	assert.NotContains(t, entries, "xl/worksheets/sheet4.xml")
+	// 各シートの先頭にヘッダーが出力される
+	assert.Equal(t, 3, strings.Count(entries["xl/worksheets/sheet2.xml"], "<row "))
+	assert.Contains(t, entries["xl/worksheets/sheet2.xml"], `<c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="dm_posts_3" sheetId="3" r:id="rId3"/>`)
+	assert.Contains(t, entries["[Content_Types].xml"], `/xl/worksheets/sheet3.xml`)
+}
+
+func TestWriter_EmptyAndClosed(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "")
+	w.SetHeader([]string{"id"})
+	require.NoError(t, w.Close())
+	// 二重クローズはエラーにしない
+	require.NoError(t, w.Close())
+	assert.ErrorIs(t, w.WriteRow([]string{"1"}), ErrClosed)
+
+	entries := readEntries(t, buf.Bytes())
+	assert.Equal(t, 1, strings.Count(entries["xl/worksheets/sheet1.xml"], "<row "))
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="Sheet"`)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", ColumnName(0))
+	assert.Equal(t, "Z", ColumnName(25))
+	assert.Equal(t, "AA", ColumnName(26))
+	assert.Equal(t, "AZ", ColumnName(51))
+	assert.Equal(t, "BA", ColumnName(52))
+	assert.Equal(t, "XFD", ColumnName(16383))
+}
+
+func TestTruncateCell(t *testing.T) {
+	long := strings.Repeat("あ", MaxCellLength+10)
+	assert.Equal(t, MaxCellLength, len([]rune(truncateCell(long))))
+	assert.Equal(t, "short", truncateCell("short"))
+}
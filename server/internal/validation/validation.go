@@ -0,0 +1,141 @@
+// Package validation はリクエストのモデルをvalidateタグとカスタムルールで検証する
+// HTTP・gRPC・CLI・管理画面のどこから呼ばれても同じ検証を行うため、サービス層から使用する
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+)
+
+// サービス層で検証するルール名
+const (
+	RuleUnique = "unique" // 一意性（他のレコードに登録されていないこと）
+	RuleExists = "exists" // 参照先の存在（paramは参照先の名前）
+)
+
+// FieldError はフィールドごとの検証エラー
+type FieldError struct {
+	Field   string `json:"field"`           // フィールド名（jsonタグの名前）
+	Rule    string `json:"rule"`            // 違反したルール（required, email, uuidv7など）
+	Param   string `json:"param,omitempty"` // ルールのパラメータ（max=100の100など）
+	Message string `json:"message"`
+}
+
+// Error は検証エラー（errors.Is(err, apperror.ErrValidation)がtrueになる）
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+func (e *Error) Unwrap() error {
+	return apperror.ErrValidation
+}
+
+// NewFieldError は1つのフィールドの検証エラーを作成（サービス層のルールで使用する）
+func NewFieldError(field, rule, param string) *Error {
+	return &Error{Fields: []FieldError{newFieldError(field, rule, param)}}
+}
+
+// validate はvalidateタグの検証器（カスタムルールを登録済み）
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// エラーのフィールド名にjsonタグの名前を使用する
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	if err := v.RegisterValidation("uuidv7", isUUIDv7); err != nil {
+		panic(fmt.Sprintf("failed to register uuidv7 validation: %v", err))
+	}
+	return v
+}
+
+// Struct は構造体をvalidateタグで検証（違反がある場合は*Error）
+func Struct(s interface{}) error {
+	return convert(validate.Struct(s), "")
+}
+
+// Var は値を指定したルールで検証（違反がある場合はfieldの*Error）
+func Var(field string, value interface{}, tag string) error {
+	return convert(validate.Var(value, tag), field)
+}
+
+// convert はvalidatorのエラーを*Errorに変換
+// fieldが空の場合は構造体のフィールド名を使用する
+func convert(err error, field string) error {
+	if err == nil {
+		return nil
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	fields := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		name := field
+		if name == "" {
+			name = e.Field()
+		}
+		fields = append(fields, newFieldError(name, e.Tag(), e.Param()))
+	}
+	return &Error{Fields: fields}
+}
+
+// newFieldError はルールに応じたメッセージのフィールドエラーを作成
+func newFieldError(field, rule, param string) FieldError {
+	var message string
+	switch rule {
+	case "required":
+		message = fmt.Sprintf("%s is required", field)
+	case "email":
+		message = fmt.Sprintf("%s must be a valid email address", field)
+	case "min":
+		message = fmt.Sprintf("%s must be at least %s characters", field, param)
+	case "max":
+		message = fmt.Sprintf("%s must be at most %s characters", field, param)
+	case "len":
+		message = fmt.Sprintf("%s must be %s characters", field, param)
+	case "uuidv7":
+		message = fmt.Sprintf("%s must be a UUIDv7 (32 lowercase hexadecimal characters)", field)
+	case RuleUnique:
+		message = fmt.Sprintf("%s is already registered", field)
+	case RuleExists:
+		message = fmt.Sprintf("%s must refer to an existing %s", field, param)
+	default:
+		message = fmt.Sprintf("%s is invalid (%s)", field, rule)
+	}
+	return FieldError{Field: field, Rule: rule, Param: param, Message: message}
+}
+
+// isUUIDv7 はハイフンなし小文字32文字のUUIDv7（idgen.GenerateUUIDv7の形式）かを検証
+func isUUIDv7(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	if len(s) != 32 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	// 13文字目がバージョン（7）、17文字目がバリアント（8, 9, a, b）
+	return s[12] == '7' && strings.ContainsRune("89ab", rune(s[16]))
+}
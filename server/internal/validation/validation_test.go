@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taku-o/go-webdb-template/internal/apperror"
+	"github.com/taku-o/go-webdb-template/internal/model"
+)
+
+func TestStruct(t *testing.T) {
+	err := Struct(&model.CreateDmUserRequest{Name: strings.Repeat("a", 101), Email: "not-an-email"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []FieldError{
+		{Field: "name", Rule: "max", Param: "100", Message: "name must be at most 100 characters"},
+		{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+	}, verr.Fields)
+	assert.Equal(t, "validation failed: name must be at most 100 characters; email must be a valid email address", err.Error())
+}
+
+func TestStruct_Valid(t *testing.T) {
+	assert.NoError(t, Struct(&model.CreateDmUserRequest{Name: "Alice", Email: "alice@example.com"}))
+	// omitemptyのフィールドは空値を許可する
+	assert.NoError(t, Struct(&model.UpdateDmUserRequest{}))
+}
+
+func TestStruct_Required(t *testing.T) {
+	err := Struct(&model.CreateDmPostRequest{})
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 3)
+	assert.Equal(t, "user_id is required", verr.Fields[0].Message)
+	assert.Equal(t, "title is required", verr.Fields[1].Message)
+	assert.Equal(t, "content is required", verr.Fields[2].Message)
+}
+
+func TestVar(t *testing.T) {
+	err := Var("title", "", "min=1,max=200")
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "title", verr.Fields[0].Field)
+	assert.Equal(t, "min", verr.Fields[0].Rule)
+
+	assert.NoError(t, Var("title", "Hello", "min=1,max=200"))
+}
+
+func TestUUIDv7(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"019a1b2c3d4e7f00a123456789abcdef", true},
+		{"019a1b2c3d4e7f00b123456789abcdef", true},
+		{"0123456789abcdef0123456789abcdef", false}, // バージョンが7でない
+		{"019a1b2c3d4e7f00c123456789abcdef", false}, // バリアントが不正
+		{"019A1B2C3D4E7F00A123456789ABCDEF", false}, // 大文字
+		{"019a1b2c-3d4e-7f00-a123-456789ab", false}, // ハイフンあり
+		{"019a1b2c3d4e7f00a123456789abcde", false},  // 31文字
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			err := Var("id", tt.id, "uuidv7")
+			assert.Equal(t, tt.valid, err == nil, fmt.Sprint(err))
+		})
+	}
+}
+
+func TestNewFieldError(t *testing.T) {
+	err := NewFieldError("email", RuleUnique, "")
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.Equal(t, "validation failed: email is already registered", err.Error())
+
+	err = NewFieldError("user_id", RuleExists, "user")
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.Equal(t, []FieldError{
+		{Field: "user_id", Rule: "exists", Param: "user", Message: "user_id must refer to an existing user"},
+	}, err.Fields)
+}
@@ -66,7 +66,7 @@ func setupTestServer(t *testing.T) *httptest.Server {
 
 	// Setup router with test config
 	cfg := testutil.GetTestConfig()
-	r := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, nil, nil, cfg)
+	r := router.NewRouter(router.Deps{DmUserHandler: dmUserHandler, DmPostHandler: dmPostHandler, TodayHandler: todayHandler}, cfg)
 
 	return httptest.NewServer(r)
 }
@@ -50,7 +50,7 @@ func setupEmailE2EServer(t *testing.T) *httptest.Server {
 
 	// Setup router with test config
 	cfg := testutil.GetTestConfig()
-	r := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, emailHandler, nil, cfg)
+	r := router.NewRouter(router.Deps{DmUserHandler: dmUserHandler, DmPostHandler: dmPostHandler, TodayHandler: todayHandler, EmailHandler: emailHandler}, cfg)
 
 	return httptest.NewServer(r)
 }
@@ -171,7 +171,7 @@ func TestEmailE2E_SendEmailValidation(t *testing.T) {
 					"Email": "valid@example.com",
 				},
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 		},
 	}
 
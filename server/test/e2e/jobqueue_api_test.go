@@ -55,7 +55,7 @@ func setupJobqueueE2EServer(t *testing.T, withJobqueueHandler bool) *httptest.Se
 
 	// Setup router with test config
 	cfg := testutil.GetTestConfig()
-	r := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, emailHandler, dmJobqueueHandler, cfg)
+	r := router.NewRouter(router.Deps{DmUserHandler: dmUserHandler, DmPostHandler: dmPostHandler, TodayHandler: todayHandler, EmailHandler: emailHandler, DmJobqueueHandler: dmJobqueueHandler}, cfg)
 
 	return httptest.NewServer(r)
 }
@@ -37,7 +37,7 @@ func setupAuthTestServer(t *testing.T) *httptest.Server {
 
 	// Setup router with test config
 	cfg := testutil.GetTestConfig()
-	r := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, nil, nil, cfg)
+	r := router.NewRouter(router.Deps{DmUserHandler: dmUserHandler, DmPostHandler: dmPostHandler, TodayHandler: todayHandler}, cfg)
 
 	return httptest.NewServer(r)
 }
@@ -95,7 +95,7 @@ func TestAPIAuth_InvalidVersion(t *testing.T) {
 	defer server.Close()
 
 	// Generate token with invalid version (v1)
-	token, err := auth.GeneratePublicAPIKey(testutil.TestSecretKey, "v1", testutil.TestEnv, time.Now().Unix())
+	token, err := auth.GeneratePublicAPIKey(testutil.TestSecretKey, "v1", testutil.TestEnv, time.Now().Unix(), "")
 	require.NoError(t, err)
 
 	// Access API with invalid version token
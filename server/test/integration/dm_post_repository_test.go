@@ -174,7 +174,7 @@ func TestDmPostRepository_CrossShardJoin(t *testing.T) {
 
 	// Test cross-shard List
 	t.Run("List returns dm_posts from all shards", func(t *testing.T) {
-		allDmPosts, err := dmPostRepo.List(ctx, 100, 0)
+		allDmPosts, err := dmPostRepo.List(ctx, 100, 0, nil)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(allDmPosts), 2)
 
@@ -131,7 +131,7 @@ func TestDmUserRepository_CrossShardOperations(t *testing.T) {
 
 	// Test GetAll returns dm_users from all shards
 	t.Run("GetAll returns dm_users from all shards", func(t *testing.T) {
-		allDmUsers, err := dmUserRepo.List(ctx, 100, 0)
+		allDmUsers, err := dmUserRepo.List(ctx, 100, 0, nil)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(allDmUsers), 3)
 
@@ -133,7 +133,7 @@ func TestDmUserCrossShardOperations(t *testing.T) {
 
 	// Test GetAll returns dm_users from all shards
 	t.Run("GetAll returns dm_users from all shards", func(t *testing.T) {
-		allDmUsers, err := dmUserService.ListDmUsers(ctx, 100, 0)
+		allDmUsers, err := dmUserService.ListDmUsers(ctx, 100, 0, "", "")
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(allDmUsers), 3)
 
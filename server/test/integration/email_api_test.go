@@ -48,7 +48,7 @@ func setupEmailTestServer(t *testing.T) *httptest.Server {
 
 	// Setup router with test config
 	cfg := testutil.GetTestConfig()
-	r := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, emailHandler, nil, cfg)
+	r := router.NewRouter(router.Deps{DmUserHandler: dmUserHandler, DmPostHandler: dmPostHandler, TodayHandler: todayHandler, EmailHandler: emailHandler}, cfg)
 
 	return httptest.NewServer(r)
 }
@@ -183,7 +183,7 @@ func TestEmailAPI_SendEmail_InvalidTemplate(t *testing.T) {
 	require.NoError(t, err)
 	defer resp.Body.Close()
 
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
 }
 
 func TestEmailAPI_SendEmail_MultipleRecipients(t *testing.T) {
@@ -56,7 +56,7 @@ func setupJobqueueTestServer(t *testing.T, withJobqueueHandler bool) *httptest.S
 
 	// Setup router with test config
 	cfg := testutil.GetTestConfig()
-	r := router.NewRouter(dmUserHandler, dmPostHandler, todayHandler, emailHandler, dmJobqueueHandler, cfg)
+	r := router.NewRouter(router.Deps{DmUserHandler: dmUserHandler, DmPostHandler: dmPostHandler, TodayHandler: todayHandler, EmailHandler: emailHandler, DmJobqueueHandler: dmJobqueueHandler}, cfg)
 
 	return httptest.NewServer(r)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -33,7 +35,7 @@ const (
 
 // GetTestAPIToken はテスト用のAPIトークンを生成
 func GetTestAPIToken() (string, error) {
-	return auth.GeneratePublicAPIKey(TestSecretKey, "v2", TestEnv, time.Now().Unix())
+	return auth.GeneratePublicAPIKey(TestSecretKey, "v2", TestEnv, time.Now().Unix(), "")
 }
 
 // LoadTestConfig はテスト環境の設定を読み込む
@@ -71,10 +73,24 @@ func GetTestConfig() *config.Config {
 			SecretKey:          TestSecretKey,
 			InvalidVersions:    []string{"v1"},
 			Auth0IssuerBaseURL: "https://dev-oaa5vtzmld4dsxtd.jp.auth0.com",
+			Auth0: config.Auth0Config{
+				// JWKSを取得せず、オフラインで検証する
+				JWKSFile: testAuth0JWKSFile(),
+			},
+			Versions: []config.APIVersionConfig{
+				{Name: "v1"},
+				{Name: "v2"},
+			},
 		},
 	}
 }
 
+// testAuth0JWKSFile はテスト用のAuth0 JWKSファイルのパスを返す
+func testAuth0JWKSFile() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", "auth0_jwks.json")
+}
+
 // SetupTestGroupManager creates a GroupManager with PostgreSQL databases for testing
 // dbCount: number of sharding databases (typically 4) - パラメータは互換性のため維持、設定ファイルの値を使用
 // tablesPerDB: number of tables per database (typically 8, total 32 tables) - パラメータは互換性のため維持、設定ファイルの値を使用
@@ -153,11 +169,108 @@ func InitMasterSchema(t *testing.T, database *gorm.DB) {
 	`
 	err := database.Exec(schema).Error
 	require.NoError(t, err)
+
+	// Webhookの購読設定・配信ログ
+	webhookSchema := `
+		CREATE TABLE IF NOT EXISTS dm_webhook_subscriptions (
+			id SERIAL PRIMARY KEY,
+			name TEXT NOT NULL,
+			target_url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events TEXT NOT NULL,
+			active BOOLEAN NOT NULL DEFAULT true,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS dm_webhook_deliveries (
+			id SERIAL PRIMARY KEY,
+			subscription_id INTEGER NOT NULL,
+			event TEXT NOT NULL,
+			event_id TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			response_status INTEGER,
+			last_error TEXT,
+			replay_of INTEGER,
+			delivered_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	err = database.Exec(webhookSchema).Error
+	require.NoError(t, err)
+
+	// 発行済みPublic APIキーの台帳
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id SERIAL PRIMARY KEY,
+			jti VARCHAR(32) NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			scopes TEXT NOT NULL,
+			version TEXT NOT NULL,
+			env TEXT NOT NULL,
+			last_used_at TIMESTAMP,
+			revoked_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`).Error
+	require.NoError(t, err)
+
+	// OIDCのIssuerで認証したユーザーとdm_usersの紐づけ
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS dm_user_identities (
+			id SERIAL PRIMARY KEY,
+			issuer VARCHAR(255) NOT NULL,
+			subject VARCHAR(255) NOT NULL,
+			dm_user_id VARCHAR(32) NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (issuer, subject)
+		);
+		CREATE INDEX IF NOT EXISTS idx_dm_user_identities_dm_user_id ON dm_user_identities (dm_user_id);
+	`).Error
+	require.NoError(t, err)
+
+	// 呼び出し元ごとのレートリミット・クォータのプランと割り当て
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS rate_limit_plans (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(64) NOT NULL UNIQUE,
+			requests_per_minute INTEGER NOT NULL DEFAULT 0,
+			requests_per_hour INTEGER NOT NULL DEFAULT 0,
+			requests_per_day INTEGER NOT NULL DEFAULT 0,
+			requests_per_month INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS rate_limit_plan_assignments (
+			id SERIAL PRIMARY KEY,
+			principal_type VARCHAR(16) NOT NULL,
+			principal_id VARCHAR(255) NOT NULL,
+			plan_name VARCHAR(64) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (principal_type, principal_id)
+		);
+	`).Error
+	require.NoError(t, err)
+
+	// ニュースフィードで使用するビュー（db/migrations/view_masterと同じ定義）
+	err = database.Exec(`CREATE OR REPLACE VIEW dm_news_view AS SELECT id, title, content, published_at, updated_at FROM dm_news`).Error
+	require.NoError(t, err)
 }
 
 // InitShardingSchema initializes the sharding database schema
 // Creates dm_users_XXX and dm_posts_XXX tables for the given table range
 func InitShardingSchema(t *testing.T, database *gorm.DB, startTable, endTable int) {
+	// 全文検索（ngramモード）で使用するpg_trgm拡張
+	err := database.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm`).Error
+	require.NoError(t, err)
+
 	for i := startTable; i <= endTable; i++ {
 		suffix := fmt.Sprintf("%03d", i)
 
@@ -185,6 +298,12 @@ func InitShardingSchema(t *testing.T, database *gorm.DB, startTable, endTable in
 		`, suffix)
 		err = database.Exec(postsSchema).Error
 		require.NoError(t, err)
+
+		// 全文検索用インデックス（db/migrations/sharding_*と同じ式）
+		err = database.Exec(fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_dm_posts_%s_fulltext ON dm_posts_%s USING gin (to_tsvector('simple', title || ' ' || content))`, suffix, suffix)).Error
+		require.NoError(t, err)
+		err = database.Exec(fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_dm_posts_%s_trgm ON dm_posts_%s USING gin ((title || ' ' || content) gin_trgm_ops)`, suffix, suffix)).Error
+		require.NoError(t, err)
 	}
 }
 
@@ -204,6 +323,106 @@ func InitMySQLMasterSchema(t *testing.T, database *gorm.DB) {
 	`
 	err := database.Exec(schema).Error
 	require.NoError(t, err)
+
+	// Webhookの購読設定・配信ログ
+	for _, webhookSchema := range []string{`
+		CREATE TABLE IF NOT EXISTS dm_webhook_subscriptions (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			name TEXT NOT NULL,
+			target_url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events TEXT NOT NULL,
+			active BOOL NOT NULL DEFAULT 1,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`, `
+		CREATE TABLE IF NOT EXISTS dm_webhook_deliveries (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			subscription_id INT NOT NULL,
+			event VARCHAR(64) NOT NULL,
+			event_id VARCHAR(36) NOT NULL,
+			payload LONGTEXT NOT NULL,
+			status VARCHAR(16) NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			response_status INT NULL,
+			last_error TEXT NULL,
+			replay_of INT NULL,
+			delivered_at TIMESTAMP NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`} {
+		err = database.Exec(webhookSchema).Error
+		require.NoError(t, err)
+	}
+
+	// 発行済みPublic APIキーの台帳
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			jti VARCHAR(32) NOT NULL,
+			name TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			scopes TEXT NOT NULL,
+			version VARCHAR(32) NOT NULL,
+			env VARCHAR(32) NOT NULL,
+			last_used_at TIMESTAMP NULL,
+			revoked_at TIMESTAMP NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE INDEX idx_api_keys_jti (jti)
+		);
+	`).Error
+	require.NoError(t, err)
+
+	// OIDCのIssuerで認証したユーザーとdm_usersの紐づけ
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS dm_user_identities (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			issuer VARCHAR(255) NOT NULL,
+			subject VARCHAR(255) NOT NULL,
+			dm_user_id VARCHAR(32) NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE INDEX idx_dm_user_identities_issuer_subject (issuer, subject),
+			INDEX idx_dm_user_identities_dm_user_id (dm_user_id)
+		);
+	`).Error
+	require.NoError(t, err)
+
+	// 呼び出し元ごとのレートリミット・クォータのプランと割り当て
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS rate_limit_plans (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(64) NOT NULL,
+			requests_per_minute INT NOT NULL DEFAULT 0,
+			requests_per_hour INT NOT NULL DEFAULT 0,
+			requests_per_day INT NOT NULL DEFAULT 0,
+			requests_per_month INT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE INDEX idx_rate_limit_plans_name (name)
+		);
+	`).Error
+	require.NoError(t, err)
+	err = database.Exec(`
+		CREATE TABLE IF NOT EXISTS rate_limit_plan_assignments (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			principal_type VARCHAR(16) NOT NULL,
+			principal_id VARCHAR(255) NOT NULL,
+			plan_name VARCHAR(64) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE INDEX idx_rate_limit_plan_assignments_principal (principal_type, principal_id)
+		);
+	`).Error
+	require.NoError(t, err)
+
+	// ニュースフィードで使用するビュー（db/migrations/view_master-mysqlと同じ定義）
+	err = database.Exec("CREATE OR REPLACE VIEW `dm_news_view` AS SELECT `id`, `title`, `content`, `published_at`, `updated_at` FROM `dm_news`").Error
+	require.NoError(t, err)
 }
 
 // InitMySQLShardingSchema はMySQLのシャーディングデータベーススキーマを初期化する
@@ -230,9 +449,10 @@ func InitMySQLShardingSchema(t *testing.T, database *gorm.DB, startTable, endTab
 				title TEXT NOT NULL,
 				content TEXT NOT NULL,
 				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				FULLTEXT INDEX idx_dm_posts_%s_fulltext (title, content) WITH PARSER ngram
 			);
-		`, suffix)
+		`, suffix, suffix)
 		err = database.Exec(postsSchema).Error
 		require.NoError(t, err)
 	}
@@ -395,13 +615,13 @@ func CreateTodayHandler() *handler.TodayHandler {
 
 // CreateDmUserHandler はテスト用のDmUserHandlerを作成するヘルパー関数
 func CreateDmUserHandler(dmUserService *service.DmUserService) *handler.DmUserHandler {
-	dmUserUsecase := usecaseapi.NewDmUserUsecase(dmUserService)
+	dmUserUsecase := usecaseapi.NewDmUserUsecase(dmUserService, nil)
 	return handler.NewDmUserHandler(dmUserUsecase)
 }
 
 // CreateDmPostHandler はテスト用のDmPostHandlerを作成するヘルパー関数
 func CreateDmPostHandler(dmPostService *service.DmPostService) *handler.DmPostHandler {
-	dmPostUsecase := usecaseapi.NewDmPostUsecase(dmPostService)
+	dmPostUsecase := usecaseapi.NewDmPostUsecase(dmPostService, nil, nil)
 	return handler.NewDmPostHandler(dmPostUsecase)
 }
 